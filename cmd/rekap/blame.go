@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/alexinslc/rekap/pkg/rekap"
+)
+
+// blameTopDomains is how many domains "rekap blame" lists
+const blameTopDomains = 10
+
+// blameHourLayouts are the forms "rekap blame" accepts for the hour
+var blameHourLayouts = []string{"15:04", "15", "3pm", "3:04pm", "3PM", "3:04PM"}
+
+func newBlameCmd() *cobra.Command {
+	var dateFlag string
+	var spanFlag time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "blame <hour>",
+		Short: "Show which apps, sites, and notifications filled one hour",
+		Long: `List everything rekap can see in one hour of the day: the apps you used and
+for how long, the sites you visited, and the notifications that came in.
+For answering "where did my afternoon go?"
+
+The hour is today's unless --date picks another day. It can be given as
+14:00, 14, or 2pm; --span widens or narrows the window.`,
+		Example: `  rekap blame 14:00
+  rekap blame 9am --span 30m
+  rekap blame 15 --date 2026-02-16`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if spanFlag <= 0 {
+				return fmt.Errorf("--span must be positive")
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			w, err := blameWindow(args[0], dateFlag, spanFlag, time.Now())
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), rekap.DefaultTimeout)
+			defer cancel()
+			var data SummaryData
+			data.Window = w
+			var wg sync.WaitGroup
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				data.Apps = collectors.CollectApps(ctx, cfg.Tracking.ExcludeApps, cfg.Sources.Apps, w)
+			}()
+			go func() {
+				defer wg.Done()
+				data.Browsers = collectors.CollectBrowserTabs(ctx, cfg, w)
+			}()
+			go func() {
+				defer wg.Done()
+				data.Notifications = collectors.CollectNotifications(ctx, cfg.Tracking.ExcludeApps, w)
+			}()
+			wg.Wait()
+
+			printBlame(cfg, &data)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day the hour is on (YYYY-MM-DD, default today)")
+	cmd.Flags().DurationVar(&spanFlag, "span", time.Hour, "Length of the window starting at the hour")
+	_ = cmd.RegisterFlagCompletionFunc("date", completeHistoryDates)
+	return cmd
+}
+
+// blameWindow resolves the hour and --date into the window to look at,
+// cut short at now when it's still going
+func blameWindow(hour, date string, span time.Duration, now time.Time) (collectors.Window, error) {
+	var clock time.Time
+	var err error
+	for _, layout := range blameHourLayouts {
+		if clock, err = time.Parse(layout, hour); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return collectors.Window{}, fmt.Errorf("invalid hour %q: use 14:00, 14, or 2pm", hour)
+	}
+
+	dayStart := collectors.DayStart(now)
+	if date != "" {
+		if dayStart, err = collectors.ParseDay(date); err != nil {
+			return collectors.Window{}, fmt.Errorf("invalid --date %q: use YYYY-MM-DD", date)
+		}
+	}
+	start := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), clock.Hour(), clock.Minute(), 0, 0, dayStart.Location())
+	if start.Before(dayStart) {
+		// Past midnight with a later day boundary: 01:00 is the next date
+		start = start.AddDate(0, 0, 1)
+	}
+	if start.After(now) {
+		return collectors.Window{}, fmt.Errorf("%s is in the future", hour)
+	}
+	end := start.Add(span)
+	if end.After(now) {
+		end = now
+	}
+	return collectors.Window{Start: start, End: end}, nil
+}
+
+// printBlame prints the apps, domains, and notifications in data's window
+func printBlame(cfg *config.Config, data *SummaryData) {
+	w := data.Window
+	timeFormat := cfg.Display.TimeFormat
+	title := fmt.Sprintf("🔍 %s – %s", ui.FormatTime(w.Start, timeFormat), ui.FormatTime(w.End, timeFormat))
+	if collectors.DayKey(w.Start) != collectors.DayKey(time.Now()) {
+		title += " on " + locale.Current().ShortDate(w.Start)
+	}
+	fmt.Println(ui.RenderTitle(title, false))
+
+	fmt.Println(ui.RenderHeader("APPS"))
+	switch {
+	case !data.Apps.Available:
+		fmt.Println(ui.RenderHint(unavailableReason(data.Apps.Error, "App usage unavailable")))
+	case len(data.Apps.TopApps) == 0:
+		fmt.Println(ui.RenderHint("No app usage in this window"))
+	}
+	for _, app := range data.Apps.TopApps {
+		text := app.Name + " • " + ui.FormatDuration(app.Minutes)
+		if app.Activations > 0 {
+			text += fmt.Sprintf(" (activated %d time%s)", app.Activations, pluralize(app.Activations))
+		}
+		fmt.Println(ui.RenderDataPoint("📱", text))
+	}
+
+	fmt.Println(ui.RenderHeader("SITES"))
+	domains := topCounts(data.Browsers.HistoryDomains, blameTopDomains)
+	if len(domains) == 0 {
+		fmt.Println(ui.RenderHint("No browser history in this window"))
+	}
+	for _, d := range domains {
+		fmt.Println(ui.RenderDataPoint("🌐", fmt.Sprintf("%s • %d visit%s", d.name, d.count, pluralize(d.count))))
+	}
+	if more := len(data.Browsers.HistoryDomains) - len(domains); more > 0 {
+		fmt.Println(ui.RenderSubItem(fmt.Sprintf("and %d more", more)))
+	}
+
+	fmt.Println(ui.RenderHeader("NOTIFICATIONS"))
+	switch {
+	case !data.Notifications.Available:
+		fmt.Println(ui.RenderHint(unavailableReason(data.Notifications.Error, "Notifications unavailable")))
+	case data.Notifications.TotalNotifications == 0:
+		fmt.Println(ui.RenderHint("No notifications in this window"))
+	}
+	for _, app := range data.Notifications.TopApps {
+		fmt.Println(ui.RenderDataPoint("🔔", fmt.Sprintf("%s • %d", app.Name, app.Count)))
+	}
+}
+
+// unavailableReason is err's message, or fallback without one
+func unavailableReason(err error, fallback string) string {
+	if err == nil {
+		return fallback
+	}
+	return strings.ToUpper(err.Error()[:1]) + err.Error()[1:]
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/theme"
+)
+
+// Dynamic shell completions. "rekap completion bash|zsh|fish" comes from
+// cobra; these fill in values it can't know: themes, sections, history
+// dates, and --quiet keys.
+
+// completeThemes completes built-in theme names and the theme files in
+// ~/.config/rekap/themes. Paths still complete as files.
+func completeThemes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if strings.ContainsAny(toComplete, "/.~") {
+		return []string{"yaml"}, cobra.ShellCompDirectiveFilterFileExt
+	}
+	names := append(theme.ListBuiltIn(), theme.ListInstalled()...)
+	slices.Sort(names)
+	return slices.Compact(names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSectionList completes a comma-separated list of sections, as
+// --only and --skip take, offering the sections not already listed
+func completeSectionList(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	done, prefix := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		done, prefix = toComplete[:i+1], toComplete[i+1:]
+	}
+	listed := strings.Split(strings.TrimSuffix(done, ","), ",")
+
+	var completions []string
+	for _, section := range sectionNames(completionConfig()) {
+		if strings.HasPrefix(section, prefix) && !slices.Contains(listed, section) {
+			completions = append(completions, done+section)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeSectionArgs completes section names as arguments, leaving out
+// the ones already given
+func completeSectionArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	for _, section := range sectionNames(completionConfig()) {
+		if !slices.Contains(args, section) {
+			completions = append(completions, section)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeHistoryDates completes the days with a history snapshot, newest
+// first, with today first whether or not it has one yet
+func completeHistoryDates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	dates := []string{time.Now().Format("2006-01-02")}
+	if store, err := history.Open(); err == nil {
+		defer store.Close()
+		stored, _ := store.Dates()
+		for _, date := range stored {
+			if date != dates[0] {
+				dates = append(dates, date)
+			}
+		}
+	}
+	return dates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeQuietKeys completes the --quiet keys, taken from the demo data
+// so completing doesn't collect anything
+func completeQuietKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg := completionConfig()
+	data := buildDemoData(cfg, time.Now())
+	var b strings.Builder
+	writeQuiet(&b, cfg, &data)
+	var keys []string
+	for _, kv := range parseQuiet(b.String()) {
+		keys = append(keys, kv[0])
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionConfig loads the config for completions, which have nowhere to
+// report a bad one
+func completionConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.Fallback()
+	}
+	return cfg
+}
@@ -14,10 +14,10 @@ func newConfigCmd() *cobra.Command {
 	configCmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage rekap configuration",
-		Long:  `Create, validate, and inspect your rekap configuration file.`,
+		Long:  `Create, validate, inspect, and upgrade your rekap configuration file.`,
 	}
 
-	configCmd.AddCommand(newConfigInitCmd(), newConfigValidateCmd(), newConfigShowCmd())
+	configCmd.AddCommand(newConfigInitCmd(), newConfigValidateCmd(), newConfigShowCmd(), newConfigGetCmd(), newConfigSetCmd(), newConfigDiffCmd(), newConfigMigrateCmd())
 	return configCmd
 }
 
@@ -25,9 +25,10 @@ func newConfigInitCmd() *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:   "init",
-		Short: "Create a starter config file",
-		Long:  `Generate a commented config file at ~/.config/rekap/config.yaml with all available options.`,
+		Use:         "init",
+		Short:       "Create a starter config file",
+		Long:        `Generate a commented config file at ~/.config/rekap/config.yaml (or --config) with all available options.`,
+		Annotations: map[string]string{createsConfig: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			configPath, err := config.GetConfigPath()
 			if err != nil {
@@ -79,12 +80,25 @@ func newConfigValidateCmd() *cobra.Command {
 				return fmt.Errorf("failed to read config file: %w", err)
 			}
 
-			var cfg config.Config
-			if err := yaml.Unmarshal(data, &cfg); err != nil {
-				return fmt.Errorf("YAML syntax error: %w", err)
+			// Keys the file and its includes leave out keep their defaults,
+			// as Load does
+			cfg := config.Default()
+			if err := config.DecodeFile(cfg, configPath); err != nil {
+				return fmt.Errorf("invalid config: %w", err)
 			}
 
-			errors := config.ValidateStrict(&cfg)
+			var root yaml.Node
+			if err := yaml.Unmarshal(data, &root); err == nil && len(root.Content) > 0 {
+				if pending, err := config.PendingMigrations(root.Content[0]); err == nil && len(pending) > 0 {
+					fmt.Printf("Config file: %s is from an older rekap; it still loads, but run 'rekap config migrate' to upgrade it:\n", configPath)
+					for _, p := range pending {
+						fmt.Printf("  %s\n", p)
+					}
+					fmt.Println()
+				}
+			}
+
+			errors := config.ValidateStrict(cfg)
 			if len(errors) > 0 {
 				fmt.Printf("Config file: %s\n\n", configPath)
 				for _, e := range errors {
@@ -101,6 +115,213 @@ func newConfigValidateCmd() *cobra.Command {
 	}
 }
 
+func newConfigGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one setting",
+		Long: `Print the effective value of one setting, by its dotted key path as in the
+config file: your value, or the default when you haven't set one, with the
+active profile applied. A block like "colors" prints as YAML.`,
+		Example: `  rekap config get colors.primary
+  rekap config get display.time_format
+  rekap config get tracking`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			value, err := config.GetValue(cfg, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change one setting in your config file",
+		Long: `Set one setting by its dotted key path, editing the config file in place so
+its comments and the order of its keys stay as they are. The value is
+checked against the key's type and the usual validation before anything is
+written. Lists take comma-separated items.
+
+With --profile or $REKAP_PROFILE, the setting goes in that profile's file,
+which is created if needed, instead of the config file.
+
+Blocks of settings, like projects or custom_sections, are edited in the file.`,
+		Example: `  rekap config set display.time_format 24h
+  rekap config set colors.primary "#ff79c6"
+  rekap config set tracking.exclude_apps Finder,Dock
+  rekap --profile work config set colors.primary "#88c0d0"`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigKeys,
+		Annotations:       map[string]string{createsConfig: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.EditPath()
+			if err != nil {
+				return fmt.Errorf("failed to determine config path: %w", err)
+			}
+			err = config.EditFile(path, func(root *yaml.Node) error {
+				return config.SetValue(root, args[0], args[1])
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update %s: %w", path, err)
+			}
+			fmt.Printf("Set %s to %s in %s\n", args[0], args[1], path)
+			return nil
+		},
+	}
+}
+
+// completeConfigKeys completes the key of "rekap config get" and "set"
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return config.KeyPaths(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func newConfigMigrateCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade an older config file in place",
+		Long: `Rewrite your config file in the current format, renaming and moving keys
+that changed between releases. The original is kept next to it as
+config.yaml.bak, and comments and keys the upgrade doesn't touch stay as
+they are.
+
+rekap applies the same upgrade in memory every time it loads an older
+config, so this is never required; it just makes the file match the docs.`,
+		Example: `  rekap config migrate --dry-run
+  rekap config migrate`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath, err := config.GetConfigPath()
+			if err != nil {
+				return fmt.Errorf("failed to determine config path: %w", err)
+			}
+			data, err := os.ReadFile(configPath)
+			if os.IsNotExist(err) {
+				fmt.Printf("No config file found at %s\n", configPath)
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+
+			if dryRun {
+				var root yaml.Node
+				if err := yaml.Unmarshal(data, &root); err != nil {
+					return fmt.Errorf("YAML syntax error: %w", err)
+				}
+				if len(root.Content) == 0 {
+					fmt.Println("Config is up to date.")
+					return nil
+				}
+				pending, err := config.PendingMigrations(root.Content[0])
+				if err != nil {
+					return err
+				}
+				if len(pending) == 0 {
+					fmt.Println("Config is up to date.")
+					return nil
+				}
+				fmt.Printf("Would upgrade %s:\n", configPath)
+				for _, p := range pending {
+					fmt.Printf("  %s\n", p)
+				}
+				return nil
+			}
+
+			applied, err := config.MigrateFile(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to migrate config: %w", err)
+			}
+			if len(applied) == 0 {
+				fmt.Println("Config is up to date.")
+				return nil
+			}
+			fmt.Printf("Upgraded %s to version %d:\n", configPath, config.SchemaVersion)
+			for _, a := range applied {
+				fmt.Printf("  %s\n", a)
+			}
+			fmt.Printf("The original is saved as %s.bak\n", configPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the changes without writing anything")
+	return cmd
+}
+
+func newConfigDiffCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff",
+		Short: "Show how your config differs from the defaults",
+		Long: `List each setting your config changes from the defaults, with the default
+value and yours, after includes and the active profile are applied.
+
+If the file on disk is in an older format, the upgrades 'rekap config
+migrate' would make to it are listed first; the settings are compared as
+rekap reads them, after the upgrade.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configPath, err := config.GetConfigPath()
+			if err != nil {
+				return fmt.Errorf("failed to determine config path: %w", err)
+			}
+			if data, err := os.ReadFile(configPath); err == nil {
+				var root yaml.Node
+				if err := yaml.Unmarshal(data, &root); err == nil && len(root.Content) > 0 {
+					if pending, err := config.PendingMigrations(root.Content[0]); err == nil && len(pending) > 0 {
+						fmt.Printf("'rekap config migrate' would upgrade %s:\n", configPath)
+						for _, p := range pending {
+							fmt.Printf("  %s\n", p)
+						}
+						fmt.Println()
+					}
+				}
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			defaults := config.Default()
+			defaults.Validate()
+
+			changes, err := config.Diff(defaults, cfg)
+			if err != nil {
+				return fmt.Errorf("failed to compare config: %w", err)
+			}
+			if len(changes) == 0 {
+				fmt.Println("Config matches the defaults.")
+				return nil
+			}
+			for _, c := range changes {
+				fmt.Printf("%s: %s → %s\n", c.Key, orUnset(c.Old), orUnset(c.New))
+			}
+			return nil
+		},
+	}
+}
+
+// orUnset shows an empty config value as "(unset)"
+func orUnset(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
+
 func newConfigShowCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "show",
@@ -110,7 +331,7 @@ func newConfigShowCmd() *cobra.Command {
 			cfg, err := config.Load()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
-				cfg = config.Default()
+				cfg = config.Fallback()
 			}
 
 			out, err := yaml.Marshal(cfg)
@@ -127,6 +348,13 @@ func newConfigShowCmd() *cobra.Command {
 const configTemplate = `# rekap configuration
 # Documentation: https://github.com/alexinslc/rekap/blob/main/docs/CONFIG.md
 
+# Config format version; 'rekap config migrate' upgrades older files
+version: 2
+
+# Shared config files applied first; this file's settings win
+# include:
+#   - ~/dotfiles/rekap/team.yaml  # Relative paths are from this file's directory
+
 # Colors (hex "#RRGGBB" or ANSI codes "0"-"255")
 # colors:
 #   primary: "13"       # Main titles
@@ -136,18 +364,67 @@ const configTemplate = `# rekap configuration
 #   warning: "9"        # Warnings
 #   muted: "240"        # Subdued text
 #   text: "255"         # Main text
+#   chart: ["13", "14"] # Bars and sparklines, one color per series
+#   severity:           # Wellness warnings
+#     low: "11"
+#     medium: "214"
+#     high: "9"
 
 # Display options
 # display:
 #   show_media: true    # Show "Now Playing" section
 #   show_battery: true  # Show battery information
-#   time_format: "12h"  # "12h" or "24h"
+#   time_format: "12h"  # "12h" or "24h"; unset follows the locale
+#   locale: "en_GB"     # Dates, decimals, first day of week; unset uses the system's
+#   section_order: ["wellness", "productivity"]  # Sections shown first; the rest follow as usual
+#   hidden_sections: ["media"]  # Collected for --json and --quiet but never shown
+
+# Day boundary and work hours
+# schedule:
+#   day_starts_at: "00:00"       # When "today" begins; e.g. "04:00" counts 1am work toward the previous day
+#   work_hours: "09:00-18:00"    # Split screen time, notifications, and distraction visits into work and after hours
+#   work_days: [mon, tue, wed, thu, fri]  # Days work_hours applies on; the rest of the week is after hours
+
+# Sections: system, sleep, productivity, meetings, timesheet, dev, media, network, browser, notifications, privacy, fragmentation, issues, wellness, notes
+
+# Sections to run at all; collectors that only feed skipped sections don't run
+# Also "rekap sections disable media", or --only/--skip for one run
+# sections:
+#   only: []                      # Empty runs every section
+#   skip: ["media", "network"]
+
+# Hide sections during parts of the day (checked each run)
+# snooze:
+#   - sections: ["media"]
+#     hours: "09:00-17:00"        # HH:MM-HH:MM, may wrap past midnight; omit for all day
+#     days: ["mon", "tue", "wed", "thu", "fri"]  # omit for every day
+#   - sections: ["wellness"]
+#     hours: "00:00-12:00"
 
 # App tracking
 # tracking:
-#   exclude_apps:
+#   exclude_apps:         # App names or bundle IDs, any case; "*" is a wildcard
 #     - "Activity Monitor"
 #     - "System Preferences"
+#     - "com.apple.*"
+#   space_names:          # Label Spaces (virtual desktops) by number
+#     2: "Client A"
+#   shell_history: false  # Count commands from shell history (opt-in; history can hold secrets)
+#   messages: false       # Count messages sent and received in Messages and Slack (opt-in; counts only)
+#   clipboard: false      # Count clipboard changes per hour from samples (opt-in; never reads contents)
+#   reminders: false      # Count reminders completed in Reminders.app (opt-in; never reads titles)
+#   window_titles: []     # Apps whose front window title is sampled for projects, e.g. ["Code", "Terminal"]
+
+# Data sources ("auto" tries each in order; a pinned source never falls back)
+# sources:
+#   screen: "auto"      # "auto", "pmset", or "knowledgec"
+#   apps: "auto"        # "auto", "screentime", or "sampling"
+#   media: "auto"       # "auto", "applescript", or "nowplaying"
+
+# Performance, for huge browser histories
+# performance:
+#   fast: false         # Same as --fast: recent history only, tab counts without titles
+#   memory_mb: 64       # Memory for counting browser history
 
 # Accessibility
 # accessibility:
@@ -164,10 +441,114 @@ const configTemplate = `# rekap configuration
 #     - "news.ycombinator.com"
 #   neutral:
 #     - "gmail.com"
+#   rules:                        # Checked in order before the lists; the first match wins
+#     - match: "youtube.com/feed/subscriptions"  # Domain, or domain/path prefix
+#       category: distraction
+#     - regex: '^reddit\.com/r/(golang|rust)\b'  # Over host/path?query, without "www."
+#       category: work
+#     - match: "reddit.com"
+#       category: neutral
+#       hours: "19:00-24:00"      # Only then; may wrap past midnight
+#       days: [mon, tue, wed, thu, fri]
 
-# Fragmentation score thresholds
+# Fragmentation score thresholds and factor weights
 # fragmentation:
 #   focused_max: 30     # 0-30 = Focused
 #   moderate_max: 60    # 31-60 = Moderate
 #   fragmented_min: 61  # 61-100 = Fragmented
+#   weights:            # Most each factor adds to the score; must add up to 100
+#     apps: 30
+#     tabs: 25
+#     domains: 25
+#     switches: 20
+#   disable: []         # e.g. ["tabs"] to leave a factor out; the rest scale up to 100
+
+# Burnout warning thresholds in the wellness check
+# wellness:
+#   long_day_hours: 10         # Hours from first to last activity
+#   switches_per_hour: 50      # App switches per hour
+#   max_tabs: 100              # Open browser tabs
+#   no_break_hours: 4          # Hours without a 15-minute break
+#   late_night: "00:00-06:00"  # Activity in this window is late-night work
+#   after_hours_minutes: 60    # Screen time outside schedule.work_hours
+
+# History (daily snapshots used by "rekap report")
+# history:
+#   enabled: true       # Save a compact summary of each day to ~/.local/share/rekap/history.db
+#   retention_days: 30  # Keep daily snapshots this long, then compact them into weekly aggregates (min 7)
+#   keep_weeks: 0       # Delete weekly aggregates older than this many weeks (0 = keep forever)
+#   run_log: false      # Append every run's JSON to ~/.local/share/rekap/runs.jsonl
+#   run_log_max_mb: 10  # Rotate runs.jsonl past this size, keeping 3 old files
+
+# Projects for timesheets ("rekap timesheet")
+# Apps count in full; browser time is split by visits to matching domains, URLs, and issues,
+# and time in apps with sampled window titles by the time in matching windows
+# projects:
+#   - name: "Client A"
+#     apps: ["Figma"]                       # App names or bundle IDs
+#     domains: ["github.com/clienta/*", "clienta.atlassian.net"]
+#     issues: ["PROJ-*"]                    # Issue keys seen in visited URLs
+#     titles: ["clienta-api"]               # Window titles of apps in tracking.window_titles
+
+# Learning time, tracked apart from work and distraction
+# learning:
+#   weekly_goal_hours: 5                   # Progress shows in "rekap report"
+#   apps: ["Anki"]
+#   domains: ["coursera.org", "youtube.com/playlist?list=PL*"]  # Replaces the defaults
+
+# Sites and issues kept out of every output and the history store
+# privacy:
+#   redact_domains: ["*.chase.com", "mychart.org", "reddit.com/r/health"]
+#   issue_denylist: ["SEC-*", "github.com/acme/security"]
+
+# Shell commands run around collection (output goes to stderr)
+# Post-collect hooks receive the same JSON as "rekap --json" on stdin;
+# $REKAP_HOOK, $REKAP_DATE, and $REKAP_VERSION are set for every hook
+# hooks:
+#   pre_collect:
+#     - "~/bin/sync-calendar.sh"
+#   post_collect:
+#     - "curl -s -X POST -H 'Content-Type: application/json' --data-binary @- https://example.com/rekap"
+#   timeout_seconds: 10  # Per command
+
+# Sections filled by your own scripts, shown like the built-in ones
+# A command prints, or a file holds, {"lines": ["..."]} with optional
+# "title" and "icon"; commands get $REKAP_DATE, $REKAP_START, and $REKAP_END
+# custom_sections:
+#   - name: Deploys          # Header, and the key snooze rules use
+#     icon: "🚀"
+#     command: "~/bin/deploys-today --json"
+#   - name: CI
+#     file: "~/.cache/ci-status.json"  # Kept current by a webhook receiver
+
+# Destinations for "rekap send"
+# integrations:
+#   slack:
+#     webhook_url: "keychain:rekap-slack-webhook"  # Save secrets with 'rekap secrets set NAME'
+#     token: "keychain:rekap-slack-token"  # User token with search:read, for tracking.messages
+#   webhooks:                # "rekap send webhook <name>"
+#     homeassistant:
+#       url: "keychain:ha-webhook"  # The URL holds the webhook ID, so keep it out of this file
+#       method: POST         # POST (default), PUT, or PATCH
+#       headers:
+#         Authorization: "Bearer $HA_TOKEN"  # $VARS come from the environment; or "keychain:NAME"
+#       body: '{"screen_minutes": {{.screen.screen_on_minutes}}, "summary": {{json .summary}}}'
+
+# Prose recap from a local model with "rekap narrate" (off by default)
+# narrate:
+#   enabled: true
+#   command: "ollama run llama3"  # Reads the prompt on stdin
+#   timeout_seconds: 120
+
+# Metered connections (iPhone hotspots and Low Data Mode networks are detected automatically)
+# network:
+#   metered_networks:
+#     - "Cafe-Guest"
+#   metered_warning_mb: 500  # Warn when more than this is downloaded on metered connections
+#   per_app: false           # Top 5 apps by data transferred today, from nettop
+
+# Sunrise and sunset for the wellness check, calculated offline
+# location:
+#   latitude: 47.61
+#   longitude: -122.33
 `
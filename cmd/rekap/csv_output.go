@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+// csvTopDomains is how many domains the summary CSV lists; the detail file has all
+const csvTopDomains = 10
+
+// printCSV prints the summary as flat metric,key,value rows for spreadsheets.
+// With detailDir set, it also writes apps.csv and domains.csv there.
+func printCSV(cfg *config.Config, data *SummaryData, detailDir string) {
+	if err := writeSummaryCSV(os.Stdout, cfg, data); err != nil {
+		fmt.Fprintf(os.Stderr, "rekap: csv write error: %v\n", err)
+		os.Exit(1)
+	}
+	if detailDir == "" {
+		return
+	}
+	paths, err := writeDetailCSVs(detailDir, data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rekap: %v\n", err)
+		os.Exit(1)
+	}
+	for _, p := range paths {
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", p)
+	}
+}
+
+// csvRows returns the summary as metric,key,value rows. Scalar values use
+// the section name as metric; lists use a per-item metric such as
+// app_minutes with the item name as key.
+func csvRows(cfg *config.Config, data *SummaryData) [][]string {
+	rows := [][]string{{"metric", "key", "value"}}
+	add := func(metric, key string, value any) {
+		rows = append(rows, []string{metric, key, fmt.Sprint(value)})
+	}
+
+	if data.Uptime.Available {
+		add("uptime", "awake_minutes", data.Uptime.AwakeMinutes)
+		add("uptime", "idle_awake_minutes", data.Uptime.IdleAwakeMinutes)
+		if data.Uptime.Confidence != "" {
+			add("uptime", "confidence", data.Uptime.Confidence)
+		}
+		add("uptime", "boot_time", data.Uptime.BootTime.Format(time.RFC3339))
+	}
+	for _, c := range data.TimeZone.Changes {
+		add("time_zone_change", c.At.Format(time.RFC3339), c.From+" → "+c.To)
+	}
+	if b := data.DayBounds; b.Available {
+		add("day", "start", b.Arrival.Format(time.RFC3339))
+		add("day", "end", b.WrapUp.Format(time.RFC3339))
+		add("day", "ongoing", b.Ongoing)
+		add("day", "span_minutes", b.SpanMinutes)
+		add("day", "overtime_minutes", b.OvertimeMinutes())
+	}
+	if cfg.ShouldShowBattery() {
+		for _, p := range data.Battery.Peripherals {
+			add("peripheral_battery_pct", p.Name, p.Pct)
+		}
+	}
+	if data.Battery.Available && cfg.ShouldShowBattery() {
+		add("battery", "start_pct", data.Battery.StartPct)
+		add("battery", "current_pct", data.Battery.CurrentPct)
+		add("battery", "plug_events", data.Battery.PlugCount)
+		add("battery", "is_plugged", data.Battery.IsPlugged)
+		add("battery", "battery_minutes", data.Battery.BatteryMinutes)
+		add("battery", "plugged_minutes", data.Battery.PluggedMinutes)
+		add("battery", "used_wh", fmt.Sprintf("%.1f", data.Battery.UsedWh))
+		if data.Battery.HealthPct > 0 {
+			add("battery", "health_pct", data.Battery.HealthPct)
+			add("battery", "cycles", data.Battery.CycleCount)
+			add("battery", "condition", data.Battery.Condition)
+		}
+	}
+	if data.Screen.Available {
+		add("screen", "on_minutes", data.Screen.ScreenOnMinutes)
+		add("screen", "lock_count", data.Screen.LockCount)
+		add("screen", "avg_mins_between_locks", data.Screen.AvgMinsBetweenLock)
+	}
+	if data.Downloads.Available {
+		add("downloads", "count", data.Downloads.Count)
+		add("downloads", "bytes", data.Downloads.TotalBytes)
+		for _, t := range data.Downloads.TopTypes {
+			add("download_type_bytes", t.Type, t.Bytes)
+		}
+	}
+	if data.Screenshots.Available {
+		add("screenshots", "screenshots", data.Screenshots.Screenshots)
+		add("screenshots", "recordings", data.Screenshots.Recordings)
+	}
+	if data.Updates.Available {
+		add("brew", "installed", data.Updates.Installed)
+		add("brew", "upgraded", data.Updates.Upgraded)
+		for _, c := range data.Updates.Brew {
+			add("brew_version", c.Name, c.Version)
+		}
+		add("macos_updates", "pending", len(data.Updates.Pending))
+	}
+	if data.Memory.Available {
+		add("memory", "peak_pressure", data.Memory.PeakPressure)
+		add("memory", "peak_used_pct", data.Memory.PeakUsedPct)
+		add("memory", "swap_used_bytes", data.Memory.SwapUsedBytes)
+		add("memory", "peak_swap_bytes", data.Memory.PeakSwapBytes)
+		add("memory", "heavy", data.Memory.Heavy())
+	}
+	if data.CPU.Available {
+		add("cpu", "peak_load", data.CPU.PeakLoad)
+		add("cpu", "avg_load", data.CPU.AvgLoad)
+		add("cpu", "cores", data.CPU.Cores)
+		add("cpu", "throttle_events", len(data.CPU.Throttles))
+		add("cpu", "throttled_minutes", data.CPU.ThrottledMinutes)
+		add("cpu", "min_speed_limit_pct", data.CPU.MinSpeedLimit)
+	}
+	if data.Sessions.Available {
+		add("sessions", "count", data.Sessions.Count)
+		add("sessions", "longest_minutes", data.Sessions.LongestMinutes)
+		add("sessions", "avg_minutes", data.Sessions.AvgMinutes)
+	}
+	if data.Meetings.Available {
+		add("meetings", "count", data.Meetings.Count)
+		add("meetings", "total_minutes", data.Meetings.TotalMinutes)
+		add("meetings", "longest_free_minutes", data.Meetings.LongestFreeMinutes)
+	}
+	if data.Focus.Available {
+		add("focus", "streak_minutes", data.Focus.StreakMinutes)
+		add("focus", "app", data.Focus.AppName)
+	}
+	if data.Apps.Available {
+		if data.Apps.SwitchingAvailable {
+			add("apps", "total_switches", data.Apps.TotalSwitches)
+		}
+		for _, app := range data.Apps.TopApps {
+			add("app_minutes", app.Name, app.Minutes)
+			if app.Activations > 0 {
+				add("app_activations", app.Name, app.Activations)
+			}
+		}
+	}
+	if data.AppSources.Available {
+		add("app_sources", "apple_minutes", data.AppSources.AppleMinutes)
+		add("app_sources", "app_store_minutes", data.AppSources.AppStoreMinutes)
+		add("app_sources", "third_party_minutes", data.AppSources.ThirdPartyMinutes)
+		add("app_sources", "unsandboxed_minutes", data.AppSources.UnsandboxedMinutes)
+	}
+	if data.AppCategories.Available {
+		for _, c := range data.AppCategories.Categories {
+			add("app_category_minutes", c.Name, c.Minutes)
+		}
+	}
+	if data.Spaces.Available {
+		for _, space := range data.Spaces.Spaces {
+			add("space_minutes", space.Name, space.Minutes)
+		}
+	}
+	if data.SSH.Available {
+		add("ssh", "total_minutes", data.SSH.TotalMinutes)
+		for _, host := range data.SSH.Hosts {
+			add("ssh_minutes", host.Host, host.Minutes)
+		}
+	}
+	if data.Shell.Available {
+		add("shell", "commands", data.Shell.Commands)
+		add("shell", "busiest_hour", data.Shell.BusiestHour)
+		for _, c := range data.Shell.TopCommands {
+			add("shell_command_count", c.Name, c.Count)
+		}
+	}
+	if data.Builds.Available {
+		add("builds", "count", data.Builds.Builds)
+		add("builds", "wait_minutes", data.Builds.WaitMinutes)
+		add("builds", "failed", data.Builds.Failed)
+		for _, t := range data.Builds.Tools {
+			add("build_count", t.Tool, t.Builds)
+			add("build_minutes", t.Tool, t.Minutes)
+		}
+	}
+	if data.Reminders.Available {
+		add("reminders", "completed", data.Reminders.Completed)
+		for _, l := range data.Reminders.Lists {
+			add("reminders_completed", l.Name, l.Completed)
+		}
+	}
+	if data.Docker.Available {
+		add("docker", "started", data.Docker.Started)
+		add("docker", "running", data.Docker.Running)
+		add("docker", "cpu_pct", fmt.Sprintf("%.1f", data.Docker.CPUPct))
+		add("docker", "mem_bytes", data.Docker.MemBytes)
+		for _, c := range data.Docker.Containers {
+			add("container_minutes", c.Name, c.Minutes)
+		}
+	}
+	if data.Projects.Available {
+		for _, p := range data.Projects.Projects {
+			add("project_minutes", p.Name, p.Minutes)
+		}
+		add("projects", "unattributed_minutes", data.Projects.UnattributedMinutes)
+	}
+	if data.Learning.Available {
+		add("learning", "minutes", data.Learning.Minutes)
+		add("learning", "app_minutes", data.Learning.AppMinutes)
+		add("learning", "browser_minutes", data.Learning.BrowserMinutes)
+		add("learning", "visits", data.Learning.Visits)
+	}
+	if data.Network.Available {
+		add("network", "interface", data.Network.InterfaceName)
+		add("network", "name", data.Network.NetworkName)
+		add("network", "bytes_received", data.Network.BytesReceived)
+		add("network", "bytes_sent", data.Network.BytesSent)
+		add("network", "since_boot", data.Network.SinceBoot)
+		for _, a := range data.Network.TopApps {
+			add("app_bytes_received", a.Name, a.BytesReceived)
+			add("app_bytes_sent", a.Name, a.BytesSent)
+		}
+	}
+	for _, d := range data.AudioDevices.Devices {
+		add("audio_device_minutes", d.Name, d.Minutes)
+	}
+	if data.Music.Available {
+		add("music", "minutes", data.Music.Minutes)
+		add("music", "tracks", data.Music.Tracks)
+		add("podcasts", "minutes", data.Music.PodcastMinutes)
+		add("podcasts", "episodes", data.Music.Episodes)
+		add("audiobooks", "minutes", data.Music.AudiobookMinutes)
+		for _, a := range data.Music.Artists {
+			add("music_artist_minutes", a.Name, a.Minutes)
+		}
+	}
+	if data.VPN.Available {
+		add("vpn", "minutes", data.VPN.Minutes)
+		add("vpn", "connected", data.VPN.Connected)
+	}
+	if len(data.WiFi.Networks) > 0 {
+		add("wifi", "switches", data.WiFi.Switches)
+		for _, n := range data.WiFi.Networks {
+			add("wifi_network_minutes", n.SSID, n.Minutes)
+		}
+	}
+	if data.Browsers.Available {
+		add("browser", "total_tabs", data.Browsers.TotalTabs)
+		add("browser", "urls_visited", data.Browsers.TotalURLsVisited)
+		for _, d := range topCounts(data.Browsers.HistoryDomains, csvTopDomains) {
+			add("domain_visits", d.name, d.count)
+		}
+	}
+	if data.CloudConsoles.Available {
+		for _, p := range data.CloudConsoles.Providers {
+			add("cloud_console_minutes", p.Name, p.Minutes)
+		}
+		add("cloud_consoles", "long_sessions", len(data.CloudConsoles.LongSessions))
+	}
+	if data.Issues.Available {
+		for _, issue := range data.Issues.Issues {
+			add("issue_visits", issue.ID, issue.VisitCount)
+		}
+	}
+	if data.Notifications.Available {
+		add("notifications", "total", data.Notifications.TotalNotifications)
+		for _, app := range data.Notifications.TopApps {
+			add("notification_count", app.Name, app.Count)
+		}
+	}
+	if data.Messages.Available {
+		add("messages", "sent", data.Messages.Sent)
+		add("messages", "received", data.Messages.Received)
+		for _, s := range data.Messages.Services {
+			add("messages_sent", s.Service, s.Sent)
+			add("messages_received", s.Service, s.Received)
+		}
+	}
+	if data.Sensors.Available {
+		add("sensors", "camera_minutes", data.Sensors.CameraMinutes)
+		add("sensors", "mic_minutes", data.Sensors.MicMinutes)
+		add("sensors", "camera_sessions", data.Sensors.CameraSessions)
+		for _, a := range data.Sensors.Apps {
+			add("camera_minutes", a.Name, a.CameraMinutes)
+			add("mic_minutes", a.Name, a.MicMinutes)
+		}
+	}
+	if data.Clipboard.Available {
+		add("clipboard", "changes", data.Clipboard.Changes)
+		add("clipboard", "changes_per_hour", fmt.Sprintf("%.1f", data.Clipboard.ChangesPerHour))
+		add("clipboard", "busiest_hour", data.Clipboard.BusiestHour)
+	}
+	if data.Fragmentation.Available {
+		add("fragmentation", "score", data.Fragmentation.Score)
+		add("fragmentation", "level", data.Fragmentation.Level)
+	}
+	if d := data.Daylight; d.Available {
+		if d.Polar != "" {
+			add("daylight", "polar", d.Polar)
+		} else {
+			add("daylight", "sunrise", d.Sunrise.Format(time.RFC3339))
+			add("daylight", "sunset", d.Sunset.Format(time.RFC3339))
+		}
+		add("daylight", "screen_after_dark_minutes", d.AfterDarkMinutes)
+	}
+	if h := data.WorkHours; h.Available {
+		add("work_hours", "hours", h.Hours.String())
+		add("work_hours", "screen_work_minutes", h.WorkMinutes())
+		add("work_hours", "screen_after_hours_minutes", h.AfterHoursMinutes)
+		add("work_hours", "after_hours_notifications", h.AfterHoursNotifications)
+		add("work_hours", "after_hours_distraction_visits", h.AfterHoursDistractionVisits)
+	}
+	if data.Burnout.Available {
+		add("burnout", "warnings", len(data.Burnout.Warnings))
+	}
+	for _, note := range data.Notes {
+		add("note", note.Time.Format(time.RFC3339), note.Text)
+	}
+	return rows
+}
+
+func writeSummaryCSV(w io.Writer, cfg *config.Config, data *SummaryData) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(csvRows(cfg, data)); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// writeDetailCSVs writes per-app and per-domain tables into dir and returns
+// the paths written. Files for sections without data are skipped.
+func writeDetailCSVs(dir string, data *SummaryData) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var paths []string
+	if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+		rows := [][]string{{"app", "bundle_id", "minutes"}}
+		for _, app := range data.Apps.TopApps {
+			rows = append(rows, []string{app.Name, app.BundleID, strconv.Itoa(app.Minutes)})
+		}
+		path := filepath.Join(dir, "apps.csv")
+		if err := writeCSVFile(path, rows); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	if data.Browsers.Available && len(data.Browsers.HistoryDomains) > 0 {
+		rows := [][]string{{"domain", "visits"}}
+		for _, d := range topCounts(data.Browsers.HistoryDomains, len(data.Browsers.HistoryDomains)) {
+			rows = append(rows, []string{d.name, strconv.Itoa(d.count)})
+		}
+		path := filepath.Join(dir, "domains.csv")
+		if err := writeCSVFile(path, rows); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeCSVFile(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.WriteAll(rows); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/golden"
+	"github.com/alexinslc/rekap/internal/ui/tui"
+	"github.com/spf13/cobra"
+)
+
+// renderFormats are the render paths "rekap debug render" and the golden
+// tests cover, in output order
+var renderFormats = []string{"human", "quiet", "json", "tui"}
+
+// fixtureNames lists the fixtures in the order they're documented: the
+// demo, an empty day, and the demo scenarios
+var fixtureNames = append([]string{"demo", "empty"}, demoScenarioNames()...)
+
+// fixtureTime is the moment every fixture is built at, so renders don't
+// change from one run to the next
+func fixtureTime() time.Time {
+	return time.Date(2026, 2, 16, 17, 30, 0, 0, time.Local)
+}
+
+// buildFixture returns the named fixture's summary
+func buildFixture(cfg *config.Config, name string) (SummaryData, error) {
+	now := fixtureTime()
+	switch name {
+	case "demo":
+		return buildDemoData(cfg, now), nil
+	case "empty":
+		// A Mac with no permissions granted and nothing else to report
+		denied := fmt.Errorf("Screen Time database not found (requires Full Disk Access)")
+		return SummaryData{
+			Window:        collectors.Today(now),
+			Apps:          collectors.AppsResult{Error: denied},
+			Focus:         collectors.FocusResult{Error: denied},
+			Notifications: collectors.NotificationsResult{Error: denied},
+		}, nil
+	}
+	if slices.Contains(demoScenarioNames(), name) {
+		return buildDemoScenario(cfg, now, name, 0)
+	}
+	return SummaryData{}, fmt.Errorf("unknown fixture %q (available: %s)", name, strings.Join(fixtureNames, ", "))
+}
+
+// renderFixture renders data through one output path, as it would be
+// printed to a pipe
+func renderFixture(cfg *config.Config, data *SummaryData, format string) (string, error) {
+	switch format {
+	case "human":
+		return captureStdout(func() { printHuman(cfg, data) })
+	case "quiet":
+		return captureStdout(func() { printQuiet(cfg, data) })
+	case "json":
+		out := buildJSON(data)
+		out.CollectedAt = data.Window.End.Format(time.RFC3339)
+		b, err := json.MarshalIndent(out, "", "  ")
+		return string(b) + "\n", err
+	case "tui":
+		var b strings.Builder
+		for _, s := range tui.BuildSections(data, cfg) {
+			fmt.Fprintf(&b, "== %s ==\n", s.Name)
+			if !s.Available {
+				fmt.Fprintf(&b, "(unavailable) %s\n\n", s.HintText)
+				continue
+			}
+			fmt.Fprintf(&b, "%s\n\n%s\n\n", s.Summary, s.Expanded)
+		}
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(renderFormats, ", "))
+}
+
+func newDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "debug",
+		Short:  "Tools for working on rekap itself",
+		Hidden: true,
+	}
+
+	var fixture, format string
+	var keepANSI bool
+	render := &cobra.Command{
+		Use:   "render",
+		Short: "Render a built-in fixture through every output path",
+		Long: `Render a fixed summary through the human, quiet, JSON, and TUI section
+output, the same renders the golden tests in cmd/rekap/testdata/golden
+check. The default config is used so the output doesn't depend on yours.
+
+Fixtures:
+  demo    the demo data, with every section filled in
+  empty   no permissions granted and nothing collected
+
+After an intended output change, run "go test ./cmd/rekap -update" and
+review the golden file diff.`,
+		Example: `  rekap debug render
+  rekap debug render --fixture empty --format tui`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Default()
+			data, err := buildFixture(cfg, fixture)
+			if err != nil {
+				return err
+			}
+
+			formats := renderFormats
+			if format != "" {
+				if !slices.Contains(renderFormats, format) {
+					return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(renderFormats, ", "))
+				}
+				formats = []string{format}
+			}
+			for _, f := range formats {
+				out, err := renderFixture(cfg, &data, f)
+				if err != nil {
+					return err
+				}
+				if !keepANSI {
+					out = golden.Normalize(out)
+				}
+				if len(formats) > 1 {
+					fmt.Printf("──── %s ────\n", f)
+				}
+				fmt.Print(out)
+			}
+			return nil
+		},
+	}
+	render.Flags().StringVar(&fixture, "fixture", "demo", "Fixture to render: "+strings.Join(fixtureNames, ", "))
+	render.Flags().StringVar(&format, "format", "", "Render only this path: "+strings.Join(renderFormats, ", "))
+	render.Flags().BoolVar(&keepANSI, "ansi", false, "Keep color escapes instead of stripping them")
+	cmd.AddCommand(render)
+	return cmd
+}
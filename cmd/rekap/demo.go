@@ -3,35 +3,67 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/alexinslc/rekap/internal/collectors"
 	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/summary"
 	"github.com/alexinslc/rekap/internal/ui"
 )
 
-func runDemo(cfg *config.Config, print bool) {
-	ui.ApplyColors(cfg)
-
-	data := buildDemoData(cfg)
+func runDemo(cfg *config.Config, print bool, scenario string, seed uint64) error {
+	now := time.Now()
+	if scenario != "" || seed != 0 {
+		now = demoClock(now)
+	}
+	data, err := buildDemoScenario(cfg, now, scenario, seed)
+	if err != nil {
+		return err
+	}
 
+	ui.ApplyColors(cfg)
 	if print || !ui.IsTTY() {
+		hint := "Showing sample data"
+		if scenario != "" {
+			hint = fmt.Sprintf("Showing sample data for a %s day", strings.ToLower(scenario))
+		}
+		if seed != 0 {
+			hint += fmt.Sprintf(" (seed %d)", seed)
+		}
 		fmt.Println(ui.RenderTitle("🎭 rekap demo mode", false))
-		fmt.Println(ui.RenderHint("Showing randomized sample data"))
+		fmt.Println(ui.RenderHint(hint))
 		fmt.Println()
 		printHuman(cfg, &data)
 	} else {
 		runTUI(cfg, &data)
 	}
+	return nil
+}
+
+func buildDemoData(cfg *config.Config, now time.Time) SummaryData {
+	data := demoCollected(now)
+	finishDemoData(cfg, &data, now)
+	return data
 }
 
-func buildDemoData(cfg *config.Config) SummaryData {
+// demoCollected returns the demo's collector results, before anything is
+// calculated from them
+func demoCollected(now time.Time) SummaryData {
 	data := SummaryData{
+		Window: collectors.Today(now),
 		Uptime: collectors.UptimeResult{
-			BootTime:      time.Now().Add(-8 * time.Hour),
-			AwakeMinutes:  287,
-			FormattedTime: "4h 47m awake",
-			Available:     true,
+			BootTime:         now.Add(-8 * time.Hour),
+			AwakeMinutes:     287,
+			FormattedTime:    "4h 47m awake",
+			RawAwakeMinutes:  342,
+			IdleAwakeMinutes: 55,
+			Confidence:       collectors.AwakeConfidenceHigh,
+			SleepPeriods: []collectors.Period{
+				{Start: now.Add(-5 * time.Hour), End: now.Add(-290 * time.Minute)},
+				{Start: now.Add(-100 * time.Minute), End: now.Add(-50 * time.Minute)},
+			},
+			Available: true,
 		},
 		Battery: collectors.BatteryResult{
 			StartPct:   92,
@@ -39,24 +71,187 @@ func buildDemoData(cfg *config.Config) SummaryData {
 			PlugCount:  1,
 			Available:  true,
 			IsPlugged:  false,
+
+			CycleCount:     412,
+			HealthPct:      87,
+			Condition:      collectors.BatteryConditionNormal,
+			UsedWh:         18.4,
+			BatteryMinutes: 250,
+			PluggedMinutes: 170,
+			HourlyPct:      []int{92, 88, 81, 95, 100, 100, 91, 84, 76, 68},
+			Peripherals: []collectors.PeripheralBattery{
+				{Name: "Magic Keyboard", Kind: collectors.PeripheralKeyboard, Pct: 85},
+				{Name: "Magic Trackpad", Kind: collectors.PeripheralTrackpad, Pct: 12},
+			},
 		},
 		Screen: collectors.ScreenResult{
 			ScreenOnMinutes: 660, // 11h - triggers long day warning
-			Available:       true,
+			OnPeriods: []collectors.Period{
+				{Start: now.Add(-7 * time.Hour), End: now.Add(-5 * time.Hour)},
+				{Start: now.Add(-290 * time.Minute), End: now.Add(-4 * time.Hour)},
+				{Start: now.Add(-3 * time.Hour), End: now.Add(-100 * time.Minute)},
+				{Start: now.Add(-50 * time.Minute), End: now},
+			},
+			Source:    config.SourcePmset,
+			Available: true,
+		},
+		Downloads: collectors.DownloadsResult{
+			Count:      9,
+			TotalBytes: 4_617_000_000,
+			TopTypes: []collectors.DownloadType{
+				{Type: "dmg", Count: 3, Bytes: 4_190_000_000},
+				{Type: "zip", Count: 2, Bytes: 412_000_000},
+				{Type: "pdf", Count: 4, Bytes: 15_000_000},
+			},
+			Available: true,
+		},
+		Screenshots: collectors.ScreenshotsResult{
+			Screenshots: 7,
+			Recordings:  1,
+			Bytes:       38_400_000,
+			Folder:      "~/Desktop",
+			Available:   true,
+		},
+		Updates: collectors.UpdatesResult{
+			Brew: []collectors.BrewChange{
+				{Name: "node", Version: "22.1.0", From: "21.7.3", At: now.Add(-6 * time.Hour)},
+				{Name: "ripgrep", Version: "14.1.0", From: "14.0.3", At: now.Add(-6 * time.Hour)},
+				{Name: "jq", Version: "1.7.1", At: now.Add(-3 * time.Hour)},
+			},
+			Installed: 1,
+			Upgraded:  2,
+			Pending:   []collectors.SoftwareUpdate{{Name: "macOS Sequoia 15.1", Version: "15.1"}},
+			Available: true,
+		},
+		Memory: collectors.MemoryResult{
+			PeakPressure:  collectors.MemoryPressureWarn,
+			PeakUsedPct:   91,
+			SwapUsedBytes: 2_300_000_000,
+			PeakSwapBytes: 3_100_000_000,
+			Samples:       42,
+			Available:     true,
+		},
+		CPU: collectors.CPUResult{
+			PeakLoad: 11.4,
+			AvgLoad:  3.2,
+			Cores:    10,
+			Samples:  42,
+			Throttles: []collectors.Period{
+				{Start: now.Add(-150 * time.Minute), End: now.Add(-2 * time.Hour)},
+			},
+			ThrottledMinutes: 30,
+			MinSpeedLimit:    70,
+			Available:        true,
 		},
 		Apps: collectors.AppsResult{
 			TopApps: []collectors.AppUsage{
-				{Name: "VS Code", Minutes: 142, BundleID: "com.microsoft.VSCode"},
-				{Name: "Safari", Minutes: 89, BundleID: "com.apple.Safari"},
-				{Name: "Slack", Minutes: 52, BundleID: "com.tinyspeck.slackmacgap"},
-				{Name: "Terminal", Minutes: 38, BundleID: "com.apple.Terminal"},
-				{Name: "Chrome", Minutes: 27, BundleID: "com.google.Chrome"},
-				{Name: "Notion", Minutes: 18, BundleID: "com.notion.Notion"},
-				{Name: "Discord", Minutes: 12, BundleID: "com.discord.Discord"},
+				{Name: "VS Code", Minutes: 142, BundleID: "com.microsoft.VSCode", Activations: 14},
+				{Name: "Safari", Minutes: 89, BundleID: "com.apple.Safari", Activations: 23},
+				{Name: "Slack", Minutes: 52, BundleID: "com.tinyspeck.slackmacgap", Activations: 47},
+				{Name: "Terminal", Minutes: 38, BundleID: "com.apple.Terminal", Activations: 19},
+				{Name: "Chrome", Minutes: 27, BundleID: "com.google.Chrome", Activations: 9},
+				{Name: "Notion", Minutes: 18, BundleID: "com.notion.Notion", Activations: 6},
+				{Name: "Discord", Minutes: 12, BundleID: "com.discord.Discord", Activations: 11},
+			},
+			Source:    config.SourceScreenTime,
+			Available: true,
+		},
+		AppSources: collectors.AppSourcesResult{
+			Apps: []collectors.AppOrigin{
+				{Name: "VS Code", Minutes: 142, BundleID: "com.microsoft.VSCode", Source: collectors.AppSourceThirdParty},
+				{Name: "Safari", Minutes: 89, BundleID: "com.apple.Safari", Source: collectors.AppSourceApple, Sandboxed: true},
+				{Name: "Slack", Minutes: 52, BundleID: "com.tinyspeck.slackmacgap", Source: collectors.AppSourceAppStore, Sandboxed: true},
+				{Name: "Terminal", Minutes: 38, BundleID: "com.apple.Terminal", Source: collectors.AppSourceApple},
+				{Name: "Chrome", Minutes: 27, BundleID: "com.google.Chrome", Source: collectors.AppSourceThirdParty},
+				{Name: "Notion", Minutes: 18, BundleID: "com.notion.Notion", Source: collectors.AppSourceThirdParty},
+				{Name: "Discord", Minutes: 12, BundleID: "com.discord.Discord", Source: collectors.AppSourceThirdParty},
+			},
+			AppleMinutes:          127,
+			AppStoreMinutes:       52,
+			ThirdPartyMinutes:     199,
+			UnsandboxedMinutes:    199,
+			UnsandboxedThirdParty: []string{"VS Code", "Chrome", "Notion", "Discord"},
+			Available:             true,
+		},
+		AppCategories: collectors.AppCategoriesResult{
+			Categories: []collectors.AppCategory{
+				{Name: collectors.AppCategoryProductivity, Minutes: 328, Apps: []string{"VS Code", "Safari", "Slack", "Chrome", "Notion"}},
+				{Name: collectors.AppCategoryUtilities, Minutes: 38, Apps: []string{"Terminal"}},
+				{Name: collectors.AppCategorySocial, Minutes: 12, Apps: []string{"Discord"}},
+			},
+			TotalMinutes: 378,
+			Available:    true,
+		},
+		Spaces: collectors.SpacesResult{
+			Spaces: []collectors.SpaceUsage{
+				{Number: 2, Name: "Desktop 2", Minutes: 164},
+				{Number: 1, Name: "Desktop 1", Minutes: 58},
+				{Number: 3, Name: "Desktop 3", Minutes: 21},
+			},
+			Samples:   96,
+			Available: true,
+		},
+		SSH: collectors.SSHResult{
+			Hosts: []collectors.SSHHost{
+				{Host: "staging", Sessions: 3, Minutes: 74, Active: true},
+				{Host: "prod-db", Sessions: 1, Minutes: 12},
+			},
+			TotalMinutes: 86,
+			Sessions:     4,
+			Available:    true,
+		},
+		Shell: collectors.ShellResult{
+			Commands: 142,
+			TopCommands: []collectors.ShellCommandCount{
+				{Name: "git", Count: 48},
+				{Name: "go", Count: 31},
+				{Name: "make", Count: 12},
+				{Name: "kubectl", Count: 9},
+			},
+			BusiestHour:         14,
+			BusiestHourCommands: 37,
+			Available:           true,
+		},
+		Reminders: collectors.RemindersResult{
+			Completed: 7,
+			Lists:     []collectors.ReminderList{{Name: "Work", Completed: 5}, {Name: "Home", Completed: 2}},
+			Available: true,
+		},
+		Builds: collectors.BuildsResult{
+			Builds:      34,
+			WaitMinutes: 48,
+			Failed:      3,
+			Tools: []collectors.BuildTool{
+				{Tool: collectors.BuildToolXcode, Builds: 12, Minutes: 27, Failed: 2},
+				{Tool: "go", Builds: 19, Minutes: 16, Failed: 1},
+				{Tool: "make", Builds: 3, Minutes: 6},
+			},
+			Available: true,
+		},
+		Docker: collectors.DockerResult{
+			Started: 4,
+			Running: 3,
+			Containers: []collectors.Container{
+				{Name: "api", Image: "rekap-api:dev", Started: now.Add(-221 * time.Minute), Running: true, Minutes: 221, CPUPct: 3.1, MemBytes: 184 << 20},
+				{Name: "postgres", Image: "postgres:16", Started: now.Add(-7 * time.Hour), Running: true, Minutes: 420, CPUPct: 1.2, MemBytes: 96 << 20},
+				{Name: "redis", Image: "redis:7", Started: now.Add(-7 * time.Hour), Running: true, Minutes: 420, CPUPct: 0.4, MemBytes: 12 << 20},
+				{Name: "migrate", Image: "rekap-api:dev", Started: now.Add(-418 * time.Minute), Minutes: 1},
 			},
-			Source:    "ScreenTime",
+			CPUPct:    4.7,
+			MemBytes:  292 << 20,
 			Available: true,
 		},
+		CloudConsoles: collectors.CloudConsolesResult{
+			Providers: []collectors.CloudProviderTime{
+				{Provider: collectors.CloudAWS, Name: "AWS", Minutes: 104, Sessions: 2, Visits: 38},
+				{Provider: collectors.CloudGCP, Name: "Google Cloud", Minutes: 14, Sessions: 1, Visits: 6},
+			},
+			LongSessions: []collectors.CloudSession{
+				{Provider: collectors.CloudAWS, Period: collectors.Period{Start: now.Add(-5 * time.Hour), End: now.Add(-5*time.Hour + 95*time.Minute)}},
+			},
+			TotalMinutes: 118,
+			Available:    true,
+		},
 		Focus: collectors.FocusResult{
 			StreakMinutes: 87,
 			AppName:       "VS Code",
@@ -65,14 +260,68 @@ func buildDemoData(cfg *config.Config) SummaryData {
 		Media: collectors.MediaResult{
 			Track:     "Blinding Lights - The Weeknd",
 			App:       "Spotify",
+			Source:    config.SourceAppleScript,
+			Available: true,
+		},
+		AudioDevices: collectors.AudioDevicesResult{
+			Devices: []collectors.AudioDevice{
+				{Name: "AirPods Pro", Type: "Headphones", Minutes: 220, Connected: true},
+				{Name: "Desk Speaker", Type: "Speaker", Minutes: 45},
+			},
+			Samples:   42,
 			Available: true,
 		},
+		Music: collectors.MusicResult{
+			Minutes: 138,
+			Tracks:  34,
+			Artists: []collectors.MusicArtist{
+				{Name: "The Weeknd", Minutes: 52},
+				{Name: "Daft Punk", Minutes: 41},
+				{Name: "Khruangbin", Minutes: 29},
+			},
+			PodcastMinutes:   65,
+			Episodes:         3,
+			AudiobookMinutes: 45,
+			Samples:          42,
+			Available:        true,
+		},
 		Network: collectors.NetworkResult{
 			InterfaceName: "en0",
 			NetworkName:   "Home-5GHz",
 			BytesReceived: 2469606195,
 			BytesSent:     471859200,
-			Available:     true,
+
+			MeteredBytesReceived: 188743680,
+			MeteredBytesSent:     20971520,
+			TopApps: []collectors.AppTraffic{
+				{Name: "Dropbox", BytesReceived: 1180000000, BytesSent: 96000000},
+				{Name: "Google Chrome H", BytesReceived: 610000000, BytesSent: 42000000},
+				{Name: "zoom.us", BytesReceived: 220000000, BytesSent: 180000000},
+			},
+			Available: true,
+		},
+		WiFi: collectors.WiFiResult{
+			AvgRSSI:          -61,
+			AvgNoise:         -92,
+			AvgSNR:           31,
+			AvgTxRate:        585,
+			Quality:          "good",
+			Samples:          14,
+			WorstPeriodStart: now.Add(-3 * time.Hour).Truncate(time.Hour),
+			WorstPeriodSNR:   12,
+			Networks: []collectors.WiFiNetwork{
+				{SSID: "Home-5GHz", Minutes: 395},
+				{SSID: "Blue Bottle Guest", Minutes: 85},
+			},
+			Switches:  2,
+			Available: true,
+		},
+		VPN: collectors.VPNResult{
+			Minutes:   205,
+			Connected: true,
+			Name:      "GlobalProtect",
+			Samples:   42,
+			Available: true,
 		},
 		Browsers: collectors.BrowsersResult{
 			Chrome: collectors.BrowserResult{
@@ -104,12 +353,20 @@ func buildDemoData(cfg *config.Config) SummaryData {
 			},
 			WorkVisits:        19,
 			DistractionVisits: 7,
+			LearningVisits:    4,
 			NeutralVisits:     9,
 			TotalURLsVisited:  147,
 			TopHistoryDomain:  "github.com",
 			TopDomainVisits:   34,
-			AllIssueURLs:      []string{"PROJ-123", "PROJ-456", "org/repo#89"},
-			Available:         true,
+			HistoryDomains: map[string]int{
+				"github.com":        34,
+				"stackoverflow.com": 21,
+				"linear.app":        17,
+				"mail.google.com":   12,
+				"docs.python.org":   9,
+			},
+			AllIssueURLs: []string{"PROJ-123", "PROJ-456", "org/repo#89"},
+			Available:    true,
 		},
 		Notifications: collectors.NotificationsResult{
 			TotalNotifications: 47,
@@ -118,8 +375,37 @@ func buildDemoData(cfg *config.Config) SummaryData {
 				{Name: "Mail", Count: 12, BundleID: "com.apple.mail"},
 				{Name: "Messages", Count: 9, BundleID: "com.apple.MobileSMS"},
 			},
+			AfterHours: 6,
+			Available:  true,
+		},
+		Messages: collectors.MessagesResult{
+			Services: []collectors.MessageCount{
+				{Service: "Messages", Sent: 14, Received: 23},
+				{Service: "Slack", Sent: 38, Received: 17},
+			},
+			Sent:      52,
+			Received:  40,
 			Available: true,
 		},
+		Sensors: collectors.SensorsResult{
+			CameraMinutes:  75,
+			MicMinutes:     110,
+			CameraSessions: 3,
+			Apps: []collectors.SensorApp{
+				{Name: "zoom.us", BundleID: "us.zoom.xos", CameraMinutes: 60, MicMinutes: 85},
+				{Name: "Google Chrome", BundleID: "com.google.Chrome", CameraMinutes: 15, MicMinutes: 15},
+				{Name: "Voice Memos", BundleID: "com.apple.VoiceMemos", MicMinutes: 10},
+			},
+			Available: true,
+		},
+		Clipboard: collectors.ClipboardResult{
+			Changes:        86,
+			PerHour:        [24]int{9: 8, 10: 14, 11: 11, 13: 9, 14: 21, 15: 13, 16: 10},
+			BusiestHour:    14,
+			ChangesPerHour: 11.5,
+			Samples:        451,
+			Available:      true,
+		},
 		Issues: collectors.IssuesResult{
 			Issues: []collectors.IssueVisit{
 				{ID: "PROJ-123", Tracker: "Jira", URL: "https://company.atlassian.net/browse/PROJ-123", VisitCount: 8},
@@ -130,12 +416,51 @@ func buildDemoData(cfg *config.Config) SummaryData {
 		},
 	}
 
-	// Calculate fragmentation for demo
-	fragmentationThresholds := collectors.FragmentationThresholds{
-		FocusedMax:    cfg.Fragmentation.FocusedMax,
-		ModerateMax:   cfg.Fragmentation.ModerateMax,
-		FragmentedMin: cfg.Fragmentation.FragmentedMin,
+	// A winter day: the sun sets before the evening's screen time
+	dayStart := collectors.DayStart(now)
+	data.Daylight = collectors.DaylightResult{
+		Sunrise:          dayStart.Add(7*time.Hour + 12*time.Minute),
+		Sunset:           dayStart.Add(16*time.Hour + 42*time.Minute),
+		AfterDarkMinutes: 95,
+		Available:        true,
+	}
+
+	// A standup and an afternoon planning meeting
+	meetingAt := func(ago, minutes int) collectors.Period {
+		start := now.Add(-time.Duration(ago) * time.Minute)
+		return collectors.Period{Start: start, End: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+	data.Meetings = collectors.MeetingsResult{
+		Meetings: []collectors.Meeting{
+			{Title: "Team standup", Period: meetingAt(400, 15)},
+			{Title: "Sprint planning", Period: meetingAt(170, 60)},
+		},
+		Count:        2,
+		TotalMinutes: 75,
+		Source:       collectors.MeetingSourceICalBuddy,
+		Available:    true,
+	}
+
+	data.Learning = collectors.LearningResult{
+		Minutes:        35,
+		BrowserMinutes: 35,
+		Visits:         4,
+		Available:      true,
+	}
+
+	data.Notes = []summary.Note{
+		{ID: 1, Date: collectors.DayKey(now), Time: now.Add(-3 * time.Hour), Text: "Shipped the v2.0 release"},
+		{ID: 2, Date: collectors.DayKey(now), Time: now.Add(-40 * time.Minute), Text: "Pairing session ran long"},
 	}
+
+	return data
+}
+
+// finishDemoData calculates the results rekap.Collect derives from the
+// collectors', so a scenario's changes carry through to them
+func finishDemoData(cfg *config.Config, data *SummaryData, now time.Time) {
+	// Calculate fragmentation for demo
+	fragmentationThresholds := collectors.FragmentationThresholdsFor(cfg)
 	data.Fragmentation = collectors.CalculateFragmentation(
 		context.Background(),
 		data.Apps,
@@ -143,10 +468,37 @@ func buildDemoData(cfg *config.Config) SummaryData {
 		data.Uptime,
 		fragmentationThresholds,
 	)
+	data.Fragmentation.Breakdown.ClipboardChangesPerHour = data.Clipboard.ChangesPerHour
 
-	// Generate burnout warnings based on demo data
-	burnoutConfig := collectors.DefaultBurnoutConfig()
-	data.Burnout = collectors.CollectBurnout(context.Background(), data.Screen, data.Browsers, burnoutConfig)
+	// Split the demo screen-on periods into work sessions and day bounds
+	data.Sessions = collectors.CalculateSessions(data.Screen)
+	data.DayBounds = collectors.CalculateDayBounds(data.Screen, data.WiFi, data.Apps, now)
+	data.SleepWake = collectors.CalculateSleepWake(data.Uptime, data.Window)
 
-	return data
+	data.Daylight.ScreenMinutes = data.Screen.ScreenOnMinutes
+
+	data.Meetings = collectors.CalculateMeetingFree(data.Meetings, data.DayBounds, data.Window)
+
+	// Generate burnout warnings from the demo data alone; the Screen Time
+	// checks would mix in the real day, so a scenario sets those itself
+	burnoutConfig := collectors.BurnoutConfigFor(cfg)
+	screenTimeWarnings := data.Burnout.Warnings
+	data.Burnout = collectors.CalculateBurnout(data.Screen, data.DayBounds, data.Browsers, burnoutConfig)
+	data.Burnout.Warnings = append(data.Burnout.Warnings, screenTimeWarnings...)
+
+	// Split the demo day by office hours, every day of the week so the demo
+	// reads the same on weekends
+	office := collectors.WorkHours{Start: 9 * 60, End: 18 * 60, Days: []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}}
+	data.Notifications.AfterHours = min(data.Notifications.AfterHours, data.Notifications.TotalNotifications)
+	data.Browsers.HistoryCategories = make(map[string]int)
+	for domain, visits := range data.Browsers.HistoryDomains {
+		data.Browsers.HistoryCategories[cfg.CategorizeDomain(domain)] += visits
+	}
+	data.WorkHours = office.Split(data.Screen, data.Notifications, data.Browsers, data.Window)
+	data.Burnout = collectors.CheckAfterHours(data.Burnout, data.WorkHours, burnoutConfig)
+
+	// Attribute demo time to any projects the user has configured
+	data.Projects = collectors.CalculateProjects(data.Apps, data.Browsers, data.WindowTitles, cfg)
 }
@@ -0,0 +1,551 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+// demoScenario is a --scenario preset: a kind of day, applied on top of the
+// demo's collector results before the derived results are calculated
+type demoScenario struct {
+	Name        string
+	Description string
+	apply       func(data *SummaryData, now time.Time)
+}
+
+// demoScenarios lists the presets in the order they're documented
+var demoScenarios = []demoScenario{
+	{"focused", "Long deep-work streaks, one meeting, a quiet inbox", focusedDay},
+	{"fragmented", "Back-to-back meetings, constant switching, tab overload", fragmentedDay},
+	{"burnout", "A 12-hour day that started before dawn, with every wellness warning", burnoutDay},
+	{"lazy-sunday", "A couple of hours of browsing and music, no work at all", lazySunday},
+}
+
+// demoScenarioNames returns the preset names, for help and errors
+func demoScenarioNames() []string {
+	names := make([]string, len(demoScenarios))
+	for i, s := range demoScenarios {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// demoScenarioHelp lists the presets for the demo command's help
+func demoScenarioHelp() string {
+	var b strings.Builder
+	for _, s := range demoScenarios {
+		fmt.Fprintf(&b, "  %-12s %s\n", s.Name, s.Description)
+	}
+	return b.String()
+}
+
+// demoClock is the moment scenario and seeded demos are built at, 5:30 PM
+// today, so the same flags always render the same day
+func demoClock(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day(), 17, 30, 0, 0, now.Location())
+}
+
+// buildDemoScenario returns the demo data for the named scenario (the
+// standard demo when empty), with its numbers varied by seed when it's not
+// zero
+func buildDemoScenario(cfg *config.Config, now time.Time, scenario string, seed uint64) (SummaryData, error) {
+	data := demoCollected(now)
+	if scenario != "" {
+		found := false
+		for _, s := range demoScenarios {
+			if strings.EqualFold(s.Name, scenario) {
+				s.apply(&data, now)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return SummaryData{}, fmt.Errorf("unknown scenario %q (available: %s)", scenario, strings.Join(demoScenarioNames(), ", "))
+		}
+	}
+	if seed != 0 {
+		varyDemoData(&data, rand.New(rand.NewPCG(seed, seed)))
+	}
+	finishDemoData(cfg, &data, now)
+	return data, nil
+}
+
+// demoApp is one app in a scenario, with what the app source and category
+// collectors would find out about it
+type demoApp struct {
+	Name        string
+	BundleID    string
+	Minutes     int
+	Activations int
+	Source      string
+	Sandboxed   bool
+	Category    string
+}
+
+// setDemoApps fills in the apps, app sources, and app categories from one
+// list, most used first, so the three agree
+func setDemoApps(data *SummaryData, switchesPerHour float64, apps []demoApp) {
+	data.Apps = collectors.AppsResult{Source: config.SourceScreenTime, Available: true}
+	data.AppSources = collectors.AppSourcesResult{Available: true}
+	data.AppCategories = collectors.AppCategoriesResult{Available: true}
+	if switchesPerHour > 0 {
+		data.Apps.SwitchesPerHour = switchesPerHour
+		data.Apps.SwitchingAvailable = true
+	}
+
+	categories := map[string]int{}
+	for _, app := range apps {
+		data.Apps.TopApps = append(data.Apps.TopApps, collectors.AppUsage{Name: app.Name, Minutes: app.Minutes, BundleID: app.BundleID, Activations: app.Activations})
+		data.Apps.TotalSwitches += app.Activations
+
+		data.AppSources.Apps = append(data.AppSources.Apps, collectors.AppOrigin{Name: app.Name, Minutes: app.Minutes, BundleID: app.BundleID, Source: app.Source, Sandboxed: app.Sandboxed})
+		switch app.Source {
+		case collectors.AppSourceApple:
+			data.AppSources.AppleMinutes += app.Minutes
+		case collectors.AppSourceAppStore:
+			data.AppSources.AppStoreMinutes += app.Minutes
+		default:
+			data.AppSources.ThirdPartyMinutes += app.Minutes
+			if !app.Sandboxed {
+				data.AppSources.UnsandboxedMinutes += app.Minutes
+				data.AppSources.UnsandboxedThirdParty = append(data.AppSources.UnsandboxedThirdParty, app.Name)
+			}
+		}
+
+		i, ok := categories[app.Category]
+		if !ok {
+			i = len(data.AppCategories.Categories)
+			categories[app.Category] = i
+			data.AppCategories.Categories = append(data.AppCategories.Categories, collectors.AppCategory{Name: app.Category})
+		}
+		data.AppCategories.Categories[i].Minutes += app.Minutes
+		data.AppCategories.Categories[i].Apps = append(data.AppCategories.Categories[i].Apps, app.Name)
+		data.AppCategories.TotalMinutes += app.Minutes
+	}
+}
+
+// demoSpan is the period from fromAgo to toAgo minutes before now
+func demoSpan(now time.Time, fromAgo, toAgo int) collectors.Period {
+	return collectors.Period{Start: now.Add(-time.Duration(fromAgo) * time.Minute), End: now.Add(-time.Duration(toAgo) * time.Minute)}
+}
+
+// setDemoScreen sets the screen-on periods, each as minutes before now,
+// and an uptime to match, asleep for the given spans and idle for idle
+// minutes on top of the screen-on time
+func setDemoScreen(data *SummaryData, now time.Time, idle int, on [][2]int, asleep [][2]int) {
+	data.Screen = collectors.ScreenResult{Source: config.SourcePmset, Available: true}
+	for _, s := range on {
+		p := demoSpan(now, s[0], s[1])
+		data.Screen.OnPeriods = append(data.Screen.OnPeriods, p)
+		data.Screen.ScreenOnMinutes += int(p.End.Sub(p.Start).Minutes())
+	}
+
+	awake := data.Screen.ScreenOnMinutes
+	data.Uptime = collectors.UptimeResult{
+		BootTime:         now.Add(-time.Duration(on[0][0]+30) * time.Minute),
+		AwakeMinutes:     awake,
+		FormattedTime:    fmt.Sprintf("%dh %dm awake", awake/60, awake%60),
+		RawAwakeMinutes:  awake + idle,
+		IdleAwakeMinutes: idle,
+		Confidence:       collectors.AwakeConfidenceHigh,
+		Available:        true,
+	}
+	for _, s := range asleep {
+		data.Uptime.SleepPeriods = append(data.Uptime.SleepPeriods, demoSpan(now, s[0], s[1]))
+	}
+}
+
+// demoMeeting is a meeting starting ago minutes before now
+func demoMeeting(now time.Time, title string, ago, minutes int) collectors.Meeting {
+	return collectors.Meeting{Title: title, Period: demoSpan(now, ago, ago-minutes)}
+}
+
+// setDemoMeetings replaces the calendar with meetings
+func setDemoMeetings(data *SummaryData, meetings ...collectors.Meeting) {
+	data.Meetings = collectors.MeetingsResult{Source: collectors.MeetingSourceICalBuddy, Available: true}
+	for _, m := range meetings {
+		data.Meetings.Meetings = append(data.Meetings.Meetings, m)
+		data.Meetings.Count++
+		data.Meetings.TotalMinutes += int(m.Period.End.Sub(m.Period.Start).Minutes())
+	}
+}
+
+// setDemoNotifications replaces the notifications with per-app counts
+func setDemoNotifications(data *SummaryData, apps ...collectors.NotificationApp) {
+	data.Notifications = collectors.NotificationsResult{TopApps: apps, Available: true}
+	for _, app := range apps {
+		data.Notifications.TotalNotifications += app.Count
+	}
+}
+
+// setDemoTabs sets each browser's open tab count; zero leaves the browser
+// out
+func setDemoTabs(data *SummaryData, chrome, safari, edge int) {
+	set := func(b *collectors.BrowserResult, name string, tabs int) {
+		*b = collectors.BrowserResult{Browser: name, TabCount: tabs, Available: tabs > 0}
+	}
+	set(&data.Browsers.Chrome, "Chrome", chrome)
+	set(&data.Browsers.Safari, "Safari", safari)
+	set(&data.Browsers.Edge, "Edge", edge)
+	data.Browsers.TotalTabs = chrome + safari + edge
+}
+
+// setDemoHistory sets the browsing history: visits by domain, and how
+// many of them were work, distraction, and learning
+func setDemoHistory(data *SummaryData, work, distraction, learning int, domains map[string]int) {
+	b := &data.Browsers
+	b.HistoryDomains = domains
+	b.TopDomains = map[string]int{}
+	b.TotalURLsVisited, b.TopHistoryDomain, b.TopDomainVisits = 0, "", 0
+	for domain, visits := range domains {
+		b.TopDomains[domain] = max(1, visits/4)
+		b.TotalURLsVisited += visits
+		if visits > b.TopDomainVisits || (visits == b.TopDomainVisits && domain < b.TopHistoryDomain) {
+			b.TopHistoryDomain, b.TopDomainVisits = domain, visits
+		}
+	}
+	b.WorkVisits, b.DistractionVisits, b.LearningVisits = work, distraction, learning
+	b.NeutralVisits = max(0, b.TotalURLsVisited-work-distraction-learning)
+	b.Available = true
+}
+
+// setDemoClipboard spreads changes over the given hours of the day, the
+// busiest first
+func setDemoClipboard(data *SummaryData, changes int, hours ...int) {
+	data.Clipboard = collectors.ClipboardResult{Changes: changes, Samples: 451, Available: true}
+	for i, hour := range hours {
+		// Front-load the first hour so it's the busiest
+		n := changes / len(hours)
+		if i == 0 {
+			n += changes % len(hours)
+		}
+		data.Clipboard.PerHour[hour] = n
+	}
+	data.Clipboard.BusiestHour = hours[0]
+	span := slices.Max(hours) - slices.Min(hours) + 1
+	data.Clipboard.ChangesPerHour = math.Round(float64(changes)/float64(span)*10) / 10
+}
+
+// setDemoNote replaces the day's notes with one written ago minutes before
+// now
+func setDemoNote(data *SummaryData, now time.Time, ago int, text string) {
+	data.Notes = []summary.Note{{ID: 1, Date: collectors.DayKey(now), Time: now.Add(-time.Duration(ago) * time.Minute), Text: text}}
+}
+
+// focusedDay is a maker's schedule: two long blocks around lunch in a few
+// apps, with little to pull attention away
+func focusedDay(data *SummaryData, now time.Time) {
+	setDemoScreen(data, now, 20, [][2]int{{525, 315}, {270, 40}}, [][2]int{{315, 270}})
+	setDemoApps(data, 6, []demoApp{
+		{"VS Code", "com.microsoft.VSCode", 265, 4, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"Terminal", "com.apple.Terminal", 82, 6, collectors.AppSourceApple, false, collectors.AppCategoryUtilities},
+		{"Safari", "com.apple.Safari", 41, 5, collectors.AppSourceApple, true, collectors.AppCategoryProductivity},
+	})
+	data.Focus = collectors.FocusResult{StreakMinutes: 168, AppName: "VS Code", Available: true}
+	data.Spaces.Spaces = data.Spaces.Spaces[:1]
+
+	setDemoTabs(data, 0, 9, 0)
+	setDemoHistory(data, 22, 1, 6, map[string]int{"github.com": 14, "pkg.go.dev": 9, "go.dev": 5, "news.ycombinator.com": 1})
+	data.Browsers.AllIssueURLs = data.Browsers.AllIssueURLs[:1]
+	data.Issues.Issues = data.Issues.Issues[:1]
+	data.CloudConsoles = collectors.CloudConsolesResult{}
+	data.Learning = collectors.LearningResult{Minutes: 40, BrowserMinutes: 40, Visits: 6, Available: true}
+
+	setDemoMeetings(data, demoMeeting(now, "1:1 with Sam", 270, 30))
+	setDemoNotifications(data,
+		collectors.NotificationApp{Name: "Slack", Count: 4, BundleID: "com.tinyspeck.slackmacgap"},
+		collectors.NotificationApp{Name: "Mail", Count: 2, BundleID: "com.apple.mail"},
+	)
+	data.Messages = collectors.MessagesResult{
+		Services:  []collectors.MessageCount{{Service: "Messages", Sent: 3, Received: 5}, {Service: "Slack", Sent: 6, Received: 4}},
+		Sent:      9,
+		Received:  9,
+		Available: true,
+	}
+	setDemoClipboard(data, 24, 10, 11, 14, 15, 16)
+	data.Sensors = collectors.SensorsResult{
+		CameraMinutes:  30,
+		MicMinutes:     30,
+		CameraSessions: 1,
+		Apps:           []collectors.SensorApp{{Name: "zoom.us", BundleID: "us.zoom.xos", CameraMinutes: 30, MicMinutes: 30}},
+		Available:      true,
+	}
+	data.Daylight.AfterDarkMinutes = 8
+	setDemoNote(data, now, 60, "Finished the parser rewrite in one sitting")
+}
+
+// fragmentedDay is a manager's schedule: meetings all day, a dozen apps,
+// and attention split between all of them
+func fragmentedDay(data *SummaryData, now time.Time) {
+	setDemoScreen(data, now, 40, [][2]int{{540, 450}, {445, 380}, {375, 300}, {290, 220}, {215, 150}, {145, 80}, {75, 0}}, nil)
+	setDemoApps(data, 64, []demoApp{
+		{"Slack", "com.tinyspeck.slackmacgap", 96, 88, collectors.AppSourceAppStore, true, collectors.AppCategoryProductivity},
+		{"Chrome", "com.google.Chrome", 84, 41, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"VS Code", "com.microsoft.VSCode", 71, 52, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"zoom.us", "us.zoom.xos", 62, 9, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"Mail", "com.apple.mail", 45, 37, collectors.AppSourceApple, true, collectors.AppCategoryProductivity},
+		{"Notion", "com.notion.Notion", 31, 24, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"Figma", "com.figma.Desktop", 22, 12, collectors.AppSourceThirdParty, false, collectors.AppCategoryCreativity},
+		{"Terminal", "com.apple.Terminal", 19, 30, collectors.AppSourceApple, false, collectors.AppCategoryUtilities},
+		{"Messages", "com.apple.MobileSMS", 14, 26, collectors.AppSourceApple, true, collectors.AppCategorySocial},
+		{"Discord", "com.discord.Discord", 11, 15, collectors.AppSourceThirdParty, false, collectors.AppCategorySocial},
+		{"Calendar", "com.apple.iCal", 8, 19, collectors.AppSourceApple, true, collectors.AppCategoryProductivity},
+	})
+	data.Focus = collectors.FocusResult{StreakMinutes: 14, AppName: "Figma", Available: true}
+
+	setDemoTabs(data, 64, 31, 17)
+	setDemoHistory(data, 31, 24, 2, map[string]int{
+		"mail.google.com": 38, "github.com": 27, "linear.app": 22, "docs.google.com": 19,
+		"reddit.com": 14, "youtube.com": 11, "twitter.com": 9, "figma.com": 8,
+		"calendar.google.com": 7, "stackoverflow.com": 5, "news.ycombinator.com": 4, "notion.so": 3,
+	})
+	data.Learning = collectors.LearningResult{}
+
+	setDemoMeetings(data,
+		demoMeeting(now, "Team standup", 480, 15),
+		demoMeeting(now, "Design review", 420, 45),
+		demoMeeting(now, "Customer call", 270, 30),
+		demoMeeting(now, "1:1 with Priya", 180, 30),
+		demoMeeting(now, "Sprint retro", 90, 45),
+	)
+	setDemoNotifications(data,
+		collectors.NotificationApp{Name: "Slack", Count: 87, BundleID: "com.tinyspeck.slackmacgap"},
+		collectors.NotificationApp{Name: "Mail", Count: 41, BundleID: "com.apple.mail"},
+		collectors.NotificationApp{Name: "Calendar", Count: 19, BundleID: "com.apple.iCal"},
+		collectors.NotificationApp{Name: "Messages", Count: 17, BundleID: "com.apple.MobileSMS"},
+	)
+	data.Messages = collectors.MessagesResult{
+		Services:  []collectors.MessageCount{{Service: "Messages", Sent: 21, Received: 34}, {Service: "Slack", Sent: 96, Received: 142}},
+		Sent:      117,
+		Received:  176,
+		Available: true,
+	}
+	setDemoClipboard(data, 212, 14, 9, 10, 11, 12, 13, 15, 16, 17)
+	data.Burnout.Warnings = []collectors.BurnoutWarning{
+		{Type: "high_switching", Message: "High task switching: 64 app switches/hour", Severity: "medium", MetricValue: 64},
+	}
+	data.Daylight.AfterDarkMinutes = 48
+	setDemoNote(data, now, 20, "Never got to the migration")
+}
+
+// burnoutDay starts before dawn and doesn't stop, tripping every wellness
+// check along the way
+func burnoutDay(data *SummaryData, now time.Time) {
+	setDemoScreen(data, now, 14, [][2]int{{710, 420}, {412, 250}, {246, 0}}, nil)
+	setDemoApps(data, 41, []demoApp{
+		{"VS Code", "com.microsoft.VSCode", 312, 38, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"Slack", "com.tinyspeck.slackmacgap", 148, 96, collectors.AppSourceAppStore, true, collectors.AppCategoryProductivity},
+		{"Chrome", "com.google.Chrome", 121, 44, collectors.AppSourceThirdParty, false, collectors.AppCategoryProductivity},
+		{"Terminal", "com.apple.Terminal", 66, 51, collectors.AppSourceApple, false, collectors.AppCategoryUtilities},
+		{"Mail", "com.apple.mail", 38, 29, collectors.AppSourceApple, true, collectors.AppCategoryProductivity},
+	})
+	data.Focus = collectors.FocusResult{StreakMinutes: 246, AppName: "VS Code", Available: true}
+
+	setDemoTabs(data, 97, 46, 0)
+	data.Learning = collectors.LearningResult{}
+
+	setDemoMeetings(data,
+		demoMeeting(now, "Incident review", 600, 60),
+		demoMeeting(now, "Team standup", 480, 15),
+		demoMeeting(now, "Launch readiness", 300, 60),
+		demoMeeting(now, "Exec sync", 240, 30),
+	)
+	setDemoNotifications(data,
+		collectors.NotificationApp{Name: "Slack", Count: 74, BundleID: "com.tinyspeck.slackmacgap"},
+		collectors.NotificationApp{Name: "Mail", Count: 24, BundleID: "com.apple.mail"},
+		collectors.NotificationApp{Name: "PagerDuty", Count: 23, BundleID: "com.pagerduty.PagerDuty"},
+	)
+	data.Memory.PeakPressure = collectors.MemoryPressureCritical
+	data.Memory.PeakUsedPct = 97
+	data.Battery.PlugCount = 3
+	data.Burnout.Warnings = []collectors.BurnoutWarning{
+		{Type: "late_night", Message: "Late night work: 95 minutes past midnight", Severity: "high", MetricValue: 95},
+		{Type: "no_breaks", Message: "No breaks: 4h+ continuous focus", Severity: "high", MetricValue: 4},
+	}
+	// Up before sunrise and still going after sunset
+	data.Daylight.AfterDarkMinutes = 140
+	setDemoNote(data, now, 15, "Skipped lunch again")
+}
+
+// lazySunday is a day off: a little browsing, a lot of music, and nothing
+// from the dev, meetings, or VPN collectors
+func lazySunday(data *SummaryData, now time.Time) {
+	setDemoScreen(data, now, 15, [][2]int{{420, 370}, {240, 200}, {95, 30}}, [][2]int{{370, 240}, {200, 95}})
+	setDemoApps(data, 0, []demoApp{
+		{"Safari", "com.apple.Safari", 58, 4, collectors.AppSourceApple, true, collectors.AppCategoryProductivity},
+		{"Music", "com.apple.Music", 41, 2, collectors.AppSourceApple, true, collectors.AppCategoryEntertainment},
+		{"Messages", "com.apple.MobileSMS", 24, 9, collectors.AppSourceApple, true, collectors.AppCategorySocial},
+		{"Photos", "com.apple.Photos", 19, 2, collectors.AppSourceApple, true, collectors.AppCategoryCreativity},
+		{"TV", "com.apple.TV", 13, 1, collectors.AppSourceApple, true, collectors.AppCategoryEntertainment},
+	})
+	data.Focus = collectors.FocusResult{StreakMinutes: 38, AppName: "Safari", Available: true}
+	data.Spaces = collectors.SpacesResult{}
+
+	// Nothing work-shaped ran today
+	data.SSH = collectors.SSHResult{}
+	data.Shell = collectors.ShellResult{}
+	data.Builds = collectors.BuildsResult{}
+	data.Docker = collectors.DockerResult{}
+	data.CloudConsoles = collectors.CloudConsolesResult{}
+	data.Issues = collectors.IssuesResult{}
+	data.VPN = collectors.VPNResult{}
+	data.Meetings = collectors.MeetingsResult{}
+	data.Learning = collectors.LearningResult{}
+	data.Reminders = collectors.RemindersResult{
+		Completed: 3,
+		Lists:     []collectors.ReminderList{{Name: "Home", Completed: 3}},
+		Available: true,
+	}
+
+	data.Downloads = collectors.DownloadsResult{
+		Count:      1,
+		TotalBytes: 2_400_000,
+		TopTypes:   []collectors.DownloadType{{Type: "pdf", Count: 1, Bytes: 2_400_000}},
+		Available:  true,
+	}
+	data.Screenshots = collectors.ScreenshotsResult{Screenshots: 2, Bytes: 3_100_000, Folder: "~/Desktop", Available: true}
+	data.CPU.Throttles, data.CPU.ThrottledMinutes, data.CPU.MinSpeedLimit = nil, 0, 0
+	data.CPU.PeakLoad, data.CPU.AvgLoad = 3.1, 0.8
+	data.Memory.PeakPressure, data.Memory.PeakUsedPct = collectors.MemoryPressureNormal, 58
+	data.Memory.SwapUsedBytes, data.Memory.PeakSwapBytes = 0, 0
+
+	data.Media = collectors.MediaResult{Track: "Time (You and I) - Khruangbin", App: "Music", Source: config.SourceAppleScript, Available: true}
+	data.Music = collectors.MusicResult{
+		Minutes: 186,
+		Tracks:  47,
+		Artists: []collectors.MusicArtist{
+			{Name: "Khruangbin", Minutes: 74},
+			{Name: "Bill Evans", Minutes: 61},
+			{Name: "Men I Trust", Minutes: 51},
+		},
+		PodcastMinutes: 48,
+		Episodes:       1,
+		Samples:        42,
+		Available:      true,
+	}
+	data.WiFi.Networks = data.WiFi.Networks[:1]
+	data.WiFi.Switches = 0
+
+	setDemoTabs(data, 0, 14, 0)
+	setDemoHistory(data, 0, 18, 0, map[string]int{"youtube.com": 12, "reddit.com": 9, "nytimes.com": 5, "allrecipes.com": 3})
+	data.Browsers.AllIssueURLs = nil
+
+	setDemoNotifications(data,
+		collectors.NotificationApp{Name: "Messages", Count: 8, BundleID: "com.apple.MobileSMS"},
+		collectors.NotificationApp{Name: "Photos", Count: 3, BundleID: "com.apple.Photos"},
+	)
+	data.Messages = collectors.MessagesResult{
+		Services:  []collectors.MessageCount{{Service: "Messages", Sent: 31, Received: 44}},
+		Sent:      31,
+		Received:  44,
+		Available: true,
+	}
+	setDemoClipboard(data, 9, 15, 11, 14)
+	data.Sensors = collectors.SensorsResult{
+		CameraMinutes:  35,
+		MicMinutes:     35,
+		CameraSessions: 1,
+		Apps:           []collectors.SensorApp{{Name: "FaceTime", BundleID: "com.apple.FaceTime", CameraMinutes: 35, MicMinutes: 35}},
+		Available:      true,
+	}
+	data.Daylight.AfterDarkMinutes = 18
+	setDemoNote(data, now, 120, "Pancakes, then nothing at all")
+}
+
+// varyDemoData scales the demo's app time and its counts by random factors
+// from rng, so a seed gives a different but repeatable day. Time and
+// counts each move together, keeping totals and rankings consistent.
+func varyDemoData(data *SummaryData, rng *rand.Rand) {
+	timeFactor := 0.8 + rng.Float64()*0.4
+	countFactor := 0.6 + rng.Float64()*0.8
+	scaleTime := func(n *int) { *n = int(math.Round(float64(*n) * timeFactor)) }
+	scaleCount := func(n *int) { *n = int(math.Round(float64(*n) * countFactor)) }
+
+	for i := range data.Apps.TopApps {
+		scaleTime(&data.Apps.TopApps[i].Minutes)
+		scaleCount(&data.Apps.TopApps[i].Activations)
+	}
+	// Total the app sources and categories from the scaled apps, so
+	// rounding doesn't leave them a minute out
+	minutes := map[string]int{}
+	for _, app := range data.Apps.TopApps {
+		minutes[app.Name] = app.Minutes
+	}
+	sources := &data.AppSources
+	sources.AppleMinutes, sources.AppStoreMinutes, sources.ThirdPartyMinutes, sources.UnsandboxedMinutes = 0, 0, 0, 0
+	for i := range sources.Apps {
+		app := &sources.Apps[i]
+		app.Minutes = minutes[app.Name]
+		switch app.Source {
+		case collectors.AppSourceApple:
+			sources.AppleMinutes += app.Minutes
+		case collectors.AppSourceAppStore:
+			sources.AppStoreMinutes += app.Minutes
+		default:
+			sources.ThirdPartyMinutes += app.Minutes
+			if !app.Sandboxed {
+				sources.UnsandboxedMinutes += app.Minutes
+			}
+		}
+	}
+	data.AppCategories.TotalMinutes = 0
+	for i := range data.AppCategories.Categories {
+		category := &data.AppCategories.Categories[i]
+		category.Minutes = 0
+		for _, name := range category.Apps {
+			category.Minutes += minutes[name]
+		}
+		data.AppCategories.TotalMinutes += category.Minutes
+	}
+	scaleTime(&data.Focus.StreakMinutes)
+	scaleTime(&data.Music.Minutes)
+	for i := range data.Music.Artists {
+		scaleTime(&data.Music.Artists[i].Minutes)
+	}
+
+	scaleCount(&data.Notifications.AfterHours)
+	data.Notifications.TotalNotifications = 0
+	for i := range data.Notifications.TopApps {
+		scaleCount(&data.Notifications.TopApps[i].Count)
+		data.Notifications.TotalNotifications += data.Notifications.TopApps[i].Count
+	}
+	data.Messages.Sent, data.Messages.Received = 0, 0
+	for i := range data.Messages.Services {
+		scaleCount(&data.Messages.Services[i].Sent)
+		scaleCount(&data.Messages.Services[i].Received)
+		data.Messages.Sent += data.Messages.Services[i].Sent
+		data.Messages.Received += data.Messages.Services[i].Received
+	}
+	data.Browsers.TotalTabs = 0
+	for _, b := range []*collectors.BrowserResult{&data.Browsers.Chrome, &data.Browsers.Safari, &data.Browsers.Edge} {
+		scaleCount(&b.TabCount)
+		data.Browsers.TotalTabs += b.TabCount
+	}
+	for _, n := range []*int{&data.Browsers.WorkVisits, &data.Browsers.DistractionVisits, &data.Browsers.LearningVisits, &data.Browsers.NeutralVisits, &data.Browsers.TotalURLsVisited, &data.Browsers.TopDomainVisits} {
+		scaleCount(n)
+	}
+	for domain := range data.Browsers.HistoryDomains {
+		visits := data.Browsers.HistoryDomains[domain]
+		scaleCount(&visits)
+		data.Browsers.HistoryDomains[domain] = visits
+	}
+	data.Clipboard.Changes = 0
+	for hour := range data.Clipboard.PerHour {
+		scaleCount(&data.Clipboard.PerHour[hour])
+		data.Clipboard.Changes += data.Clipboard.PerHour[hour]
+	}
+	data.Clipboard.ChangesPerHour = math.Round(data.Clipboard.ChangesPerHour*countFactor*10) / 10
+	scaleCount(&data.Shell.Commands)
+	for i := range data.Shell.TopCommands {
+		scaleCount(&data.Shell.TopCommands[i].Count)
+	}
+	scaleCount(&data.Shell.BusiestHourCommands)
+}
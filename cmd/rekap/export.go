@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the day to other tools",
+	}
+	cmd.AddCommand(newExportICalCmd())
+	return cmd
+}
+
+func newExportICalCmd() *cobra.Command {
+	var dateFlag string
+	var outputFlag string
+
+	cmd := &cobra.Command{
+		Use:   "ical",
+		Short: "Write the day's deep-work blocks and meetings as an iCalendar file",
+		Long: `Write today's deep-work blocks and meetings as iCalendar (.ics) events, to
+overlay the work you actually did onto your calendar.
+
+A deep-work block is at least 25 minutes in one app; glances at other apps
+of up to 2 minutes don't break it. Meetings are time in Zoom, Teams, Webex,
+or FaceTime. Events are marked free, and importing the same day again
+updates them instead of adding duplicates.`,
+		Example: `  rekap export ical > today.ics
+  rekap export ical --date 2026-02-18 --output ~/Calendars/rekap.ics`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			now := time.Now()
+			w := collectors.Today(now)
+			if dateFlag != "" {
+				day, err := collectors.ParseDay(dateFlag)
+				if err != nil {
+					return fmt.Errorf("invalid --date %q: use YYYY-MM-DD", dateFlag)
+				}
+				if day.After(now) {
+					return fmt.Errorf("--date %s is in the future", dateFlag)
+				}
+				w = collectors.DayWindow(day, now)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			blocks := collectors.CollectBlocks(ctx, cfg.Tracking.ExcludeApps, cfg.Sources.Apps, w)
+			if !blocks.Available {
+				return fmt.Errorf("app usage data unavailable: %v\nRun 'rekap init' for setup", blocks.Error)
+			}
+
+			if outputFlag == "" {
+				return report.WriteICal(os.Stdout, blocks.Blocks, now)
+			}
+			f, err := os.Create(outputFlag)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", outputFlag, err)
+			}
+			if err := report.WriteICal(f, blocks.Blocks, now); err != nil {
+				f.Close()
+				return fmt.Errorf("failed to write %s: %w", outputFlag, err)
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputFlag, err)
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %d event%s to %s\n", len(blocks.Blocks), pluralize(len(blocks.Blocks)), outputFlag)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Export this day (YYYY-MM-DD) instead of today")
+	cmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Write to this file instead of stdout")
+	_ = cmd.RegisterFlagCompletionFunc("date", completeHistoryDates)
+	return cmd
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/pkg/rekap"
+)
+
+func newGetCmd() *cobra.Command {
+	var listFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one value from the --quiet output",
+		Long: `Collect today's summary and print the value of one --quiet key, with
+nothing else, for shell scripts and prompts.
+
+A key with no value today (the collector was unavailable, or the key only
+appears on some days, like tz_from) prints nothing and exits with status 1.
+Use --list to see the keys that have a value right now.`,
+		Example: `  rekap get fragmentation_score
+  rekap get screen_on_minutes || echo "no screen data"
+  rekap get --list`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if listFlag {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		ValidArgsFunction: completeQuietKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+
+			// No hooks or history snapshot: a prompt may call this often
+			data, _ := rekap.Collect(context.Background(), rekap.Options{Config: cfg, Window: collectors.Today(time.Now())})
+			var b strings.Builder
+			writeQuiet(&b, cfg, &data)
+			values := parseQuiet(b.String())
+
+			if listFlag {
+				for _, kv := range values {
+					fmt.Println(kv[0])
+				}
+				return nil
+			}
+			for _, kv := range values {
+				if kv[0] == args[0] {
+					fmt.Println(kv[1])
+					return nil
+				}
+			}
+			// Nothing on stdout or stderr, so "rekap get key || ..." stays quiet
+			os.Exit(1)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&listFlag, "list", false, "List the keys that have a value today")
+	return cmd
+}
+
+// parseQuiet splits --quiet output into key, value pairs in order
+func parseQuiet(output string) [][2]string {
+	var values [][2]string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			values = append(values, [2]string{key, value})
+		}
+	}
+	return values
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// historyTrendWeeks is how many weeks "rekap history" shows
+const historyTrendWeeks = 12
+
+func newHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Inspect and prune the history store",
+		Long: `Show what the history store holds and the weekly trend over the last
+12 weeks. Daily snapshots older than history.retention_days are compacted
+into weekly aggregates automatically, so long-term trends stay available.`,
+		Example: `  rekap history
+  rekap history prune
+  rekap history backfill --days 28`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := loadHistoryConfig()
+
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			stats, err := store.Stats()
+			if err != nil {
+				return fmt.Errorf("failed to read history: %w", err)
+			}
+
+			end := collectors.DayStart(time.Now())
+			to, _ := history.WeekStart(end.Format("2006-01-02"))
+			from, _ := history.WeekStart(end.AddDate(0, 0, -7*(historyTrendWeeks-1)).Format("2006-01-02"))
+			weeks, err := store.WeeklyTrend(from, to)
+			if err != nil {
+				return err
+			}
+
+			printHistory(cfg, stats, weeks)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newHistoryPruneCmd(), newHistoryBackfillCmd())
+	return cmd
+}
+
+func newHistoryPruneCmd() *cobra.Command {
+	var days int
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Compact old daily snapshots into weekly aggregates",
+		Long: `Fold daily snapshots older than history.retention_days into weekly
+aggregates, delete weekly aggregates older than history.keep_weeks (if set),
+remove domains matching privacy.redact_domains from every snapshot, and
+reclaim the freed disk space.
+
+A summary run redacts past snapshots on its own only when
+privacy.redact_domains has changed since the last time.`,
+		Example: `  rekap history prune
+  rekap history prune --days 14`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := loadHistoryConfig()
+			if days > 0 {
+				if days < config.MinRetentionDays {
+					return fmt.Errorf("--days must be at least %d so weekly reports stay complete", config.MinRetentionDays)
+				}
+				cfg.History.RetentionDays = days
+			}
+
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			dayCutoff, weekCutoff := historyCutoffs(cfg, collectors.DayStart(time.Now()))
+			result, err := store.Prune(dayCutoff, weekCutoff)
+			if err != nil {
+				return err
+			}
+			redacted, err := redactHistory(cfg, store, true)
+			if err != nil {
+				return fmt.Errorf("failed to redact history: %w", err)
+			}
+			if result.DaysCompacted > 0 || result.WeeksPruned > 0 || redacted > 0 {
+				// Best-effort: the rows are already gone even if the file doesn't shrink
+				_ = store.Vacuum()
+			}
+			if redacted > 0 {
+				fmt.Println(ui.RenderSuccess(fmt.Sprintf("Removed redacted domains from %d snapshots", redacted)))
+			}
+
+			if result.DaysCompacted == 0 && result.WeeksPruned == 0 {
+				fmt.Println(ui.RenderSuccess(fmt.Sprintf("Nothing to prune (keeping %d days of daily snapshots)", cfg.History.RetentionDays)))
+				return nil
+			}
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Compacted %d daily snapshots into %d weekly aggregates",
+				result.DaysCompacted, result.WeeksTouched)))
+			if result.WeeksPruned > 0 {
+				fmt.Println(ui.RenderSuccess(fmt.Sprintf("Deleted %d weekly aggregates older than %d weeks",
+					result.WeeksPruned, cfg.History.KeepWeeks)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 0, "Keep this many days of daily snapshots (default: history.retention_days)")
+	return cmd
+}
+
+func newHistoryBackfillCmd() *cobra.Command {
+	var days int
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Import past days from the Screen Time database",
+		Long: `Replay the app usage, focus, and notification queries for each past day
+still held in the Screen Time database (usually about four weeks) and save
+them to the history store, so reports and trends work right after install.
+
+Backfilled days only include what Screen Time recorded: awake time, screen
+time, browser, and network totals aren't available for past days. Days
+already in the history store are skipped unless --force is set.`,
+		Example: `  rekap history backfill
+  rekap history backfill --days 14 --force`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := loadHistoryConfig()
+			if days < 1 {
+				return fmt.Errorf("--days must be at least 1")
+			}
+			if days > cfg.History.RetentionDays {
+				return fmt.Errorf("--days %d is longer than history.retention_days (%d); raise the retention to keep that much daily history",
+					days, cfg.History.RetentionDays)
+			}
+
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			today := collectors.DayStart(time.Now())
+			from := today.AddDate(0, 0, -days)
+			existing := make(map[string]bool)
+			if !force {
+				recorded, err := store.Range(from.Format("2006-01-02"), today.Format("2006-01-02"))
+				if err != nil {
+					return err
+				}
+				for _, d := range recorded {
+					existing[d.Date] = true
+				}
+			}
+
+			saved, skipped, empty := 0, 0, 0
+			for i := days; i >= 1; i-- {
+				start := today.AddDate(0, 0, -i)
+				date := start.Format("2006-01-02")
+				if existing[date] {
+					skipped++
+					continue
+				}
+
+				data, err := backfillDay(cfg, start)
+				if err != nil {
+					return err
+				}
+				if data == nil {
+					empty++
+					continue
+				}
+				if err := store.Save(history.FromData(date, data), time.Now().Unix()); err != nil {
+					return err
+				}
+				saved++
+			}
+
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Backfilled %d days from Screen Time", saved)))
+			if skipped > 0 {
+				fmt.Println(ui.RenderSubItem(fmt.Sprintf("   %d days already recorded (use --force to replace them)", skipped)))
+			}
+			if empty > 0 {
+				fmt.Println(ui.RenderSubItem(fmt.Sprintf("   %d days had no Screen Time data", empty)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 28, "Number of past days to import")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace days already in the history store")
+	return cmd
+}
+
+// backfillDay replays the Screen Time queries for the day starting at start.
+// It returns nil data when Screen Time holds nothing for that day, and an
+// error only when the database can't be read at all.
+func backfillDay(cfg *config.Config, start time.Time) (*SummaryData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := collectors.DayWindow(start, time.Now())
+	data := SummaryData{
+		Window:        w,
+		Apps:          collectors.CollectApps(ctx, cfg.Tracking.ExcludeApps, config.SourceScreenTime, w),
+		Focus:         collectors.CollectFocus(ctx, cfg.Tracking.ExcludeApps, w),
+		Notifications: collectors.CollectNotifications(ctx, cfg.Tracking.ExcludeApps, w),
+	}
+	if !data.Apps.Available {
+		if data.Apps.Error != nil && !data.Notifications.Available {
+			return nil, fmt.Errorf("%v\nRun 'rekap init' for setup", data.Apps.Error)
+		}
+		return nil, nil
+	}
+
+	// Browser history isn't replayed and window titles are only kept a week,
+	// so mapped apps are the only project signal
+	data.Projects = collectors.CalculateProjects(data.Apps, collectors.BrowsersResult{}, collectors.WindowTitlesResult{}, cfg)
+	return &data, nil
+}
+
+func loadHistoryConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+		cfg = config.Fallback()
+	}
+	ui.ApplyColors(cfg)
+	collectors.SetDayStart(cfg.DayStartMinute())
+	return cfg
+}
+
+// historyCutoffs returns the dates before which daily snapshots are
+// compacted and weekly aggregates are deleted. weekCutoff is empty when
+// weekly aggregates are kept forever.
+func historyCutoffs(cfg *config.Config, today time.Time) (dayCutoff, weekCutoff string) {
+	retention := cfg.History.RetentionDays
+	if retention < config.MinRetentionDays {
+		retention = config.Default().History.RetentionDays
+	}
+	dayCutoff = today.AddDate(0, 0, -retention).Format("2006-01-02")
+	if cfg.History.KeepWeeks > 0 {
+		weekCutoff = today.AddDate(0, 0, -7*cfg.History.KeepWeeks).Format("2006-01-02")
+	}
+	return dayCutoff, weekCutoff
+}
+
+func printHistory(cfg *config.Config, stats history.Stats, weeks []history.WeekSummary) {
+	fmt.Println(ui.RenderTitle("📚 History", false))
+
+	fmt.Println()
+	fmt.Println(ui.RenderHeader("STORE"))
+	if stats.Days > 0 {
+		fmt.Println(ui.RenderDataPoint("•", fmt.Sprintf("Daily snapshots:   %d (%s to %s)", stats.Days, stats.FirstDay, stats.LastDay)))
+	} else {
+		fmt.Println(ui.RenderDataPoint("•", "Daily snapshots:   none"))
+	}
+	if stats.Weeks > 0 {
+		fmt.Println(ui.RenderDataPoint("•", fmt.Sprintf("Weekly aggregates: %d (weeks of %s to %s)", stats.Weeks, stats.FirstWeek, stats.LastWeek)))
+	} else {
+		fmt.Println(ui.RenderDataPoint("•", "Weekly aggregates: none"))
+	}
+	keep := "forever"
+	if cfg.History.KeepWeeks > 0 {
+		keep = fmt.Sprintf("%d weeks", cfg.History.KeepWeeks)
+	}
+	fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Daily snapshots kept %d days, weekly aggregates kept %s", cfg.History.RetentionDays, keep)))
+
+	if len(weeks) == 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHint("No history recorded yet -- each rekap run saves a snapshot of the day"))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.RenderHeader("WEEKLY TREND"))
+	widths := []int{11, 5, 11, 11, 7}
+	columns := []string{"Week of", "Days", "Screen/day", "Best focus", "Notifs", "Frag"}
+	fmt.Println(ui.RenderSubItem("   " + formatReportRow(columns, widths)))
+	for _, w := range weeks {
+		start, _ := time.Parse("2006-01-02", w.WeekStart)
+		frag := "-"
+		if w.FragmentationDays > 0 {
+			frag = fmt.Sprintf("%d", w.AvgFragmentation())
+		}
+		row := []string{
+			locale.Current().MonthDay(start),
+			fmt.Sprintf("%d", w.Days),
+			ui.FormatDuration(w.AvgScreenOnMinutes()),
+			ui.FormatDuration(w.LongestFocusMinutes),
+			fmt.Sprintf("%d", w.Notifications),
+			frag,
+		}
+		fmt.Println(ui.RenderSubItem("   " + formatReportRow(row, widths)))
+	}
+}
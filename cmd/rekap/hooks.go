@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/hooks"
+)
+
+// runHooks runs the configured commands for stage. Hook output goes to
+// stderr so it never mixes with --json or --quiet output, and failures are
+// reported as warnings without interrupting the run.
+func runHooks(cfg *config.Config, stage string, data *SummaryData) {
+	commands := cfg.Hooks.PreCollect
+	if stage == hooks.PostCollect {
+		commands = cfg.Hooks.PostCollect
+	}
+	if len(commands) == 0 {
+		return
+	}
+
+	opts := hooks.Options{
+		Stage:   stage,
+		Timeout: time.Duration(cfg.Hooks.TimeoutSeconds) * time.Second,
+		Env: []string{
+			"REKAP_DATE=" + collectors.DayKey(time.Now()),
+			"REKAP_VERSION=" + version,
+		},
+		Output: os.Stderr,
+	}
+	if data != nil {
+		payload, err := json.Marshal(buildJSON(data))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to encode hook payload: %v\n", err)
+			return
+		}
+		opts.Payload = payload
+	}
+
+	for _, err := range hooks.Run(context.Background(), commands, opts) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/alexinslc/rekap/internal/compat"
 	"github.com/alexinslc/rekap/internal/config"
 	"github.com/alexinslc/rekap/internal/permissions"
 	"github.com/alexinslc/rekap/internal/theme"
@@ -15,22 +18,45 @@ import (
 
 const version = "0.1.0"
 
+// createsConfig annotates commands that may run with a --config file or
+// profile that doesn't exist yet, because they write it
+const createsConfig = "creates_config"
+
 func main() {
-	var quietFlag bool
-	var jsonFlag bool
-	var printFlag bool
+	var out outputOptions
 	var themeFlag string
 	var accessibleFlag bool
+	var fastFlag bool
+	var profileFlag string
+	var configFlag string
+	var onlyFlag, skipFlag []string
 
 	rootCmd := &cobra.Command{
 		Use:   "rekap",
 		Short: "Daily Mac Activity Summary",
 		Long:  `A single-binary macOS CLI that summarizes today's computer activity in a friendly, animated terminal UI.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := out.validate(); err != nil {
+				return err
+			}
+
 			cfg, err := config.Load()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
-				cfg = config.Default()
+				cfg = config.Fallback()
+			}
+
+			// First run: set up before the first summary
+			if shouldOnboard(out, time.Now()) {
+				saved, err := runOnboarding(cfg)
+				if err != nil {
+					return err
+				}
+				if saved {
+					if cfg, err = config.Load(); err != nil {
+						return fmt.Errorf("failed to load the new config: %w", err)
+					}
+				}
 			}
 
 			if themeFlag != "" {
@@ -46,24 +72,96 @@ func main() {
 				cfg.Accessibility.HighContrast = true
 			}
 
-			runSummary(quietFlag, jsonFlag, printFlag, cfg)
-			return nil
+			if fastFlag {
+				cfg.Performance.Fast = true
+			}
+
+			if err := applySectionFlags(cfg, cmd.Flags().Changed("only"), onlyFlag, skipFlag); err != nil {
+				return err
+			}
+
+			return runSummary(out, cfg)
 		},
 	}
 
-	rootCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Output machine-parsable key=value format")
-	rootCmd.Flags().BoolVar(&jsonFlag, "json", false, "Output structured JSON to stdout")
-	rootCmd.Flags().BoolVar(&printFlag, "print", false, "Output static text instead of interactive TUI")
+	rootCmd.Flags().BoolVarP(&out.quiet, "quiet", "q", false, "Output machine-parsable key=value format")
+	rootCmd.Flags().BoolVar(&out.json, "json", false, "Output structured JSON to stdout")
+	rootCmd.Flags().BoolVar(&out.print, "print", false, "Output static text instead of interactive TUI")
+	rootCmd.Flags().StringVar(&out.format, "format", "", "Output format: markdown, csv, jsonl, plist, or raycast")
+	rootCmd.Flags().StringVar(&out.csvDir, "csv-dir", "", "With --format csv, also write apps.csv and domains.csv to this directory")
+	rootCmd.Flags().StringVarP(&out.output, "output", "o", "", "Write the output to this file instead of stdout, without colors (--format jsonl appends)")
+	rootCmd.Flags().BoolVar(&out.append, "append", false, "With --output, add to the end of the file instead of replacing it")
+	rootCmd.Flags().StringVar(&out.logFile, "log-file", "", "Append a log of the run and any collector errors to this file")
+	rootCmd.Flags().StringVar(&out.logLevel, "log-level", "info", "Least severe --log-file entries to keep: debug, info, warn, or error")
 	rootCmd.Flags().StringVar(&themeFlag, "theme", "", "Color theme (built-in: default, minimal, hacker, pastel, nord, dracula, solarized) or path to theme file")
-	rootCmd.MarkFlagsMutuallyExclusive("quiet", "json", "print")
+	rootCmd.Flags().StringVar(&out.since, "since", "", "Summarize from this time today (HH:MM) until now")
+	rootCmd.Flags().DurationVar(&out.last, "last", 0, "Summarize the trailing span until now, e.g. 4h or 90m")
+	rootCmd.Flags().StringVar(&out.date, "date", "", "Summarize a past day (YYYY-MM-DD)")
+	rootCmd.Flags().StringSliceVar(&onlyFlag, "only", nil, "Run only these sections, e.g. browser,productivity (replaces sections.only)")
+	rootCmd.Flags().StringSliceVar(&skipFlag, "skip", nil, "Skip these sections, e.g. notifications,media (adds to sections.skip)")
+	rootCmd.Flags().BoolVar(&fastFlag, "fast", false, "Read only recent browser history and count tabs without titles, for huge histories")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "json", "print", "format")
+	rootCmd.MarkFlagsMutuallyExclusive("since", "last", "date")
+	_ = rootCmd.RegisterFlagCompletionFunc("theme", completeThemes)
+	_ = rootCmd.RegisterFlagCompletionFunc("date", completeHistoryDates)
+	_ = rootCmd.RegisterFlagCompletionFunc("only", completeSectionList)
+	_ = rootCmd.RegisterFlagCompletionFunc("skip", completeSectionList)
+	_ = rootCmd.RegisterFlagCompletionFunc("log-level", cobra.FixedCompletions([]string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp))
 	rootCmd.PersistentFlags().BoolVar(&accessibleFlag, "accessible", false, "Enable accessibility mode (color-blind friendly, high contrast)")
+	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Read settings from this file instead of $XDG_CONFIG_HOME/rekap/config.yaml")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Layer ~/.config/rekap/profiles/NAME.yaml over the config (default $REKAP_PROFILE)")
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml")
+	_ = rootCmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return config.ListProfiles(), cobra.ShellCompDirectiveNoFileComp
+	})
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		config.SetProfile(profileFlag)
+		config.SetPath(configFlag)
+
+		// Commands that write the file create it; everything else needs a
+		// file that was asked for by name to exist
+		creates := cmd.Annotations[createsConfig] != ""
+		if configFlag != "" && !creates {
+			if _, err := os.Stat(configFlag); os.IsNotExist(err) {
+				return fmt.Errorf("config file %s not found", configFlag)
+			}
+		}
+		name := config.ActiveProfile()
+		if name == "" {
+			return nil
+		}
+		path, err := config.ProfilePath(name)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) && !creates {
+			return fmt.Errorf("profile %q not found: create %s", name, path)
+		}
+		return nil
+	}
 
 	initCmd := &cobra.Command{
 		Use:   "init",
-		Short: "Permission setup wizard",
-		Long:  `Run the guided permission setup wizard to enable Full Disk Access and other permissions.`,
+		Short: "Setup wizard: permissions, theme, sections, and schedule",
+		Long: `Walk through setting rekap up, saving everything to the config at the end.
+
+  Permissions  Each one is rechecked every few seconds, so you can watch it
+               turn green after granting it, and Enter opens its pane in
+               System Settings. macOS grants them to the terminal app rekap
+               runs in, which the wizard names for you.
+  Theme        Pick a color theme, previewed as you move through the list.
+  Sections     Turn off the sections you don't want.
+  Schedule     Optionally post the summary as a notification every evening,
+               with a launchd agent running 'rekap notify'.
+
+An existing config keeps its other settings and comments. Quitting saves
+nothing. The first time 'rekap' runs without a config and with permissions
+missing, it opens this wizard itself. Without a terminal, init lists the
+missing permissions and where to grant them.`,
+		Annotations: map[string]string{createsConfig: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return permissions.RequestFlow()
+			runInit()
+			return nil
 		},
 	}
 
@@ -76,18 +174,39 @@ func main() {
 			return nil
 		},
 	}
+	doctorCmd.AddCommand(&cobra.Command{
+		Use:   "compat",
+		Short: "Show which query strategies this macOS version uses",
+		Long: `Show the macOS version rekap detected and the strategy it picked for each
+source that changed between releases: the Screen Time stream and database
+location, and the commands that still report Wi-Fi details.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runDoctorCompat()
+			return nil
+		},
+	})
 
 	var demoThemeFlag string
 	var demoPrintFlag bool
+	var demoScenarioFlag string
+	var demoSeedFlag uint64
 	demoCmd := &cobra.Command{
 		Use:   "demo",
 		Short: "See sample output with fake data",
-		Long:  `Display a demo with randomized sample data to preview the output format.`,
+		Long: `Display a demo with sample data to preview the output format.
+
+--scenario picks a kind of day, so every section and warning can be seen
+(and screenshotted) on demand:
+` + demoScenarioHelp() + `
+--seed varies the numbers in a repeatable way: the same seed always gives
+the same day. Scenario and seeded demos are pinned to 5:30 PM today.`,
+		Example: `  rekap demo --scenario burnout --print
+  rekap demo --scenario focused --seed 42 --theme nord`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cfg, err := config.Load()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
-				cfg = config.Default()
+				cfg = config.Fallback()
 			}
 
 			if demoThemeFlag != "" {
@@ -103,14 +222,17 @@ func main() {
 				cfg.Accessibility.HighContrast = true
 			}
 
-			runDemo(cfg, demoPrintFlag)
-			return nil
+			return runDemo(cfg, demoPrintFlag, demoScenarioFlag, demoSeedFlag)
 		},
 	}
 	demoCmd.Flags().StringVar(&demoThemeFlag, "theme", "", "Color theme (built-in: default, minimal, hacker, pastel, nord, dracula, solarized) or path to theme file")
 	demoCmd.Flags().BoolVar(&demoPrintFlag, "print", false, "Output static text instead of interactive TUI")
+	demoCmd.Flags().StringVar(&demoScenarioFlag, "scenario", "", "Kind of day to show: "+strings.Join(demoScenarioNames(), ", "))
+	demoCmd.Flags().Uint64Var(&demoSeedFlag, "seed", 0, "Vary the sample numbers repeatably with this seed (0 keeps the standard data)")
+	_ = demoCmd.RegisterFlagCompletionFunc("theme", completeThemes)
+	_ = demoCmd.RegisterFlagCompletionFunc("scenario", cobra.FixedCompletions(demoScenarioNames(), cobra.ShellCompDirectiveNoFileComp))
 
-	rootCmd.AddCommand(initCmd, doctorCmd, demoCmd, newConfigCmd())
+	rootCmd.AddCommand(initCmd, doctorCmd, demoCmd, newConfigCmd(), newReportCmd(), newTimesheetCmd(), newStandupCmd(), newShareCmd(), newHistoryCmd(), newNoteCmd(), newWrappedCmd(), newServeCmd(), newSampleCmd(), newSendCmd(), newExportCmd(), newSchemaCmd(), newStatusCmd(), newNarrateCmd(), newPlanCmd(), newDebugCmd(), newWatchCmd(), newGetCmd(), newSectionsCmd(), newNotifyCmd(), newBlameCmd(), newSecretsCmd())
 
 	if err := fang.Execute(
 		context.Background(),
@@ -122,6 +244,47 @@ func main() {
 	}
 }
 
+// runInit runs the onboarding wizard, or prints where to grant each
+// missing permission when there's no terminal to run it in
+func runInit() {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.Fallback()
+	}
+	ui.ApplyColors(cfg)
+
+	if !ui.IsTTY() {
+		terminal := permissions.Terminal()
+		if terminal == "" {
+			terminal = "your terminal app"
+		}
+		var missing []permissions.Permission
+		for _, p := range permissions.All() {
+			if !p.Check() {
+				missing = append(missing, p)
+			}
+		}
+		if len(missing) == 0 {
+			fmt.Println("All permissions granted")
+			return
+		}
+		fmt.Printf("Grant these to %s in System Settings > Privacy & Security:\n", terminal)
+		major := permissions.MacOSMajor()
+		for _, p := range missing {
+			fmt.Printf("  %s: open '%s'\n", p.Name, permissions.SettingsURL(p.Pane, major))
+		}
+		return
+	}
+
+	if _, err := runOnboarding(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(permissions.FormatCapabilities(permissions.Check()))
+	fmt.Println()
+	fmt.Println(ui.RenderHint("Run 'rekap' to see your summary, or 'rekap doctor' to check permissions again"))
+}
+
 func runDoctor() {
 	fmt.Println(ui.RenderTitle("🩺 rekap capabilities check", false))
 	fmt.Println()
@@ -136,3 +299,16 @@ func runDoctor() {
 		fmt.Println(ui.RenderSuccess("All major permissions granted!"))
 	}
 }
+
+func runDoctorCompat() {
+	fmt.Println(ui.RenderTitle("🧩 rekap compatibility", false))
+	fmt.Println()
+
+	v := compat.Current()
+	fmt.Printf("macOS %s\n\n", v)
+	home, _ := os.UserHomeDir()
+	for _, s := range compat.Strategies(v, home) {
+		fmt.Printf("%s: %s\n", s.Area, s.Choice)
+		fmt.Println(ui.RenderSubItem(s.Reason))
+	}
+}
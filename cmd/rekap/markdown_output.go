@@ -0,0 +1,364 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// markdownTopN is how many apps and domains the Markdown tables list
+const markdownTopN = 5
+
+// printMarkdown prints the summary as Markdown for pasting into notes apps,
+// journals, and PR descriptions. Like JSON, it ignores snooze rules.
+func printMarkdown(cfg *config.Config, data *SummaryData) {
+	fmt.Print(renderMarkdown(cfg, data))
+}
+
+func renderMarkdown(cfg *config.Config, data *SummaryData) string {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+	section := func(title string) {
+		line("")
+		line("## %s", title)
+		line("")
+	}
+
+	title := locale.Current().FullDate(data.Window.Start)
+	if !data.Window.WholeDay() {
+		title += " (" + summaryPeriod(cfg, data) + ")"
+	}
+	line("# rekap • %s", title)
+	if data.TimeZone.Traveled() {
+		line("")
+		line("_%s_", mdEscape(formatTravel(cfg, data.TimeZone)))
+	}
+
+	// System
+	if data.Uptime.Available || data.DayBounds.Available || ((data.Battery.Available || len(data.Battery.Peripherals) > 0) && cfg.ShouldShowBattery()) || data.Screen.Available || data.Downloads.Count > 0 || showScreenshots(data.Screenshots) || len(data.Updates.Brew) > 0 || len(data.Updates.Pending) > 0 || data.Memory.Available || data.CPU.Available {
+		section("System")
+		if data.Uptime.Available {
+			line("- **Active since:** %s (%s)", ui.FormatTime(data.Uptime.BootTime, cfg.Display.TimeFormat), data.Uptime.FormattedTime)
+			if note := awakeConfidenceText(data.Uptime); note != "" {
+				line("  - %s", note)
+			}
+		}
+		if b := data.DayBounds; b.Available {
+			line("- **Workday:** %s", ui.FormatDayBounds(b.Arrival, b.WrapUp, b.Ongoing, b.SpanMinutes, cfg.Display.TimeFormat))
+		}
+		if data.Screen.Available {
+			line("- **Screen on:** %s", ui.FormatDuration(data.Screen.ScreenOnMinutes))
+			if data.Screen.LockCount > 0 {
+				line("- **Screen locks:** %d", data.Screen.LockCount)
+			}
+		}
+		if data.Battery.Available && cfg.ShouldShowBattery() {
+			status := "discharging"
+			if data.Battery.IsPlugged {
+				status = "plugged in"
+			}
+			line("- **Battery:** %d%% → %d%% (%s)", data.Battery.StartPct, data.Battery.CurrentPct, status)
+			if usage := formatBatteryUsage(data.Battery); usage != "" {
+				line("  - %s", usage)
+			}
+			if data.Battery.HealthPct > 0 {
+				line("  - %s", formatBatteryHealth(data.Battery))
+			}
+		}
+		if len(data.Battery.Peripherals) > 0 && cfg.ShouldShowBattery() {
+			line("- **Devices:** %s", mdEscape(formatPeripherals(data.Battery.Peripherals)))
+		}
+		if data.Downloads.Count > 0 {
+			line("- **Downloads:** %s", mdEscape(formatDownloads(data.Downloads)))
+		}
+		if showScreenshots(data.Screenshots) {
+			line("- **Screenshots:** %s", mdEscape(formatScreenshots(data.Screenshots)))
+		}
+		if len(data.Updates.Brew) > 0 {
+			line("- **Homebrew:** %s", mdEscape(formatBrewChanges(data.Updates, len(data.Updates.Brew))))
+		}
+		if len(data.Updates.Pending) > 0 {
+			line("- **Updates:** %s", mdEscape(formatPendingUpdates(data.Updates.Pending)))
+		}
+		if data.Memory.Available {
+			text := formatMemory(data.Memory)
+			if data.Memory.Heavy() {
+				text += " (heavy)"
+			}
+			line("- **Memory:** %s", mdEscape(text))
+		}
+		if data.CPU.Available {
+			line("- **CPU:** %s", mdEscape(formatCPU(data.CPU)))
+		}
+	}
+
+	// Productivity
+	if data.Focus.Available || (data.Apps.Available && len(data.Apps.TopApps) > 0) || showSpaces(data) || data.SSH.Available || data.Shell.Available || data.Builds.Available || showReminders(data.Reminders) {
+		section("Productivity")
+		if data.Focus.Available {
+			line("- **Best focus:** %s in %s", ui.FormatDuration(data.Focus.StreakMinutes), mdEscape(data.Focus.AppName))
+		}
+		if data.Sessions.Available {
+			line("- **Sessions:** %d (longest %s, avg %s)", data.Sessions.Count,
+				ui.FormatDuration(data.Sessions.LongestMinutes), ui.FormatDuration(data.Sessions.AvgMinutes))
+		}
+		if data.Apps.Available && data.Apps.SwitchingAvailable {
+			line("- **App switches:** %d", data.Apps.TotalSwitches)
+		}
+		if activated := mostActivatedApps(data.Apps.TopApps, markdownTopN); data.Apps.Available && len(activated) > 0 {
+			line("- **Activated:** %s", mdEscape(formatAppActivations(activated)))
+		}
+		if data.AppSources.Available {
+			line("- **App sources:** %s", formatAppSources(data.AppSources))
+		}
+		if showAppCategories(data) {
+			line("- **App categories:** %s", mdEscape(formatAppCategories(data.AppCategories, markdownTopN)))
+		}
+		if showSpaces(data) {
+			line("- **Spaces:** %s", mdEscape(formatSpaces(data.Spaces.Spaces, markdownTopN)))
+		}
+		if data.SSH.Available {
+			line("- **Remote:** %s", mdEscape(formatSSHHosts(data.SSH.Hosts, markdownTopN)))
+		}
+		if data.Shell.Available {
+			line("- **Shell:** %s", mdEscape(formatShell(cfg, data.Shell, markdownTopN)))
+		}
+		if data.Builds.Available {
+			line("- **Builds:** %s", formatBuilds(data.Builds))
+			for _, t := range data.Builds.Tools {
+				line("  - %s: %d build%s, %s", mdEscape(t.Tool), t.Builds, pluralize(t.Builds), ui.FormatDuration(t.Minutes))
+			}
+		}
+		if showReminders(data.Reminders) {
+			line("- **Reminders:** %s", mdEscape(formatReminders(data.Reminders, len(data.Reminders.Lists))))
+		}
+		if data.Learning.Available && data.Learning.Minutes > 0 {
+			line("- **Learning:** %s", formatLearning(data.Learning))
+		}
+		if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+			line("")
+			line("| App | Time |")
+			line("| --- | ---: |")
+			for i, app := range data.Apps.TopApps {
+				if i >= markdownTopN {
+					break
+				}
+				line("| %s | %s |", mdCell(app.Name), ui.FormatDuration(app.Minutes))
+			}
+		}
+	}
+
+	// Meetings
+	if data.Meetings.Available {
+		section("Meetings")
+		line("- **Meetings:** %d (%s)", data.Meetings.Count, ui.FormatDuration(data.Meetings.TotalMinutes))
+		if free := data.Meetings.LongestFree; data.Meetings.LongestFreeMinutes > 0 {
+			line("- **Longest meeting-free block:** %s (%s–%s)", ui.FormatDuration(data.Meetings.LongestFreeMinutes),
+				ui.FormatTime(free.Start, cfg.Display.TimeFormat), ui.FormatTime(free.End, cfg.Display.TimeFormat))
+		}
+		if len(data.Meetings.Meetings) > 0 {
+			line("")
+			line("| Time | Meeting | Length |")
+			line("| --- | --- | ---: |")
+			for _, m := range data.Meetings.Meetings {
+				line("| %s | %s | %s |", ui.FormatTime(m.Start, cfg.Display.TimeFormat), mdCell(m.Title), ui.FormatDuration(m.Minutes()))
+			}
+		}
+	}
+
+	// Timesheet
+	if data.Projects.Available && len(data.Projects.Projects) > 0 {
+		section("Timesheet")
+		line("| Project | Time | Hours |")
+		line("| --- | ---: | ---: |")
+		for _, p := range data.Projects.Projects {
+			line("| %s | %s | %s |", mdCell(p.Name), ui.FormatDuration(p.Minutes), locale.Current().FormatFloat(float64(p.Minutes)/60, 2))
+		}
+		if data.Projects.UnattributedMinutes > 0 {
+			line("")
+			line("Unattributed: %s", ui.FormatDuration(data.Projects.UnattributedMinutes))
+		}
+	}
+
+	// Browser
+	if data.Browsers.Available && (data.Browsers.TotalURLsVisited > 0 || data.Browsers.TotalTabs > 0) {
+		section("Browser")
+		if data.Browsers.TotalURLsVisited > 0 {
+			line("- **URLs visited:** %d", data.Browsers.TotalURLsVisited)
+		}
+		if data.Browsers.TotalTabs > 0 {
+			line("- **Tabs open:** %d", data.Browsers.TotalTabs)
+		}
+		if data.CloudConsoles.Available {
+			line("- **Cloud consoles:** %s", formatCloudConsoles(data.CloudConsoles))
+			for _, s := range data.CloudConsoles.LongSessions {
+				line("- ⚠️ %s", longConsoleSessionText(cfg, s))
+			}
+		}
+		if domains := topCounts(data.Browsers.HistoryDomains, markdownTopN); len(domains) > 0 {
+			line("")
+			line("| Domain | Visits |")
+			line("| --- | ---: |")
+			for _, d := range domains {
+				line("| %s | %d |", mdCell(d.name), d.count)
+			}
+		}
+	}
+
+	// Issues viewed
+	if data.Issues.Available && len(data.Issues.Issues) > 0 {
+		section("Issues Viewed")
+		for _, issue := range data.Issues.Issues {
+			id := mdEscape(issue.ID)
+			if issue.URL != "" {
+				id = fmt.Sprintf("[%s](<%s>)", id, issue.URL)
+			}
+			line("- %s (%s, %d visit%s)", id, issue.Tracker, issue.VisitCount, pluralize(issue.VisitCount))
+		}
+	}
+
+	// Notifications
+	hasNotifications := data.Notifications.Available && data.Notifications.TotalNotifications > 0
+	if hasNotifications || data.Messages.Available {
+		section("Notifications")
+		if hasNotifications {
+			line("- **Total:** %d", data.Notifications.TotalNotifications)
+			for i, app := range data.Notifications.TopApps {
+				if i >= 3 {
+					break
+				}
+				line("- %s: %d", mdEscape(app.Name), app.Count)
+			}
+		}
+		if data.Messages.Available {
+			line("- **Messages:** %s", mdEscape(formatMessages(data.Messages)))
+		}
+	}
+
+	// Privacy
+	if showSensors(data) {
+		section("Privacy")
+		line("- **Camera/mic:** %s", mdEscape(formatSensors(data.Sensors)))
+		for i, app := range data.Sensors.Apps {
+			if i >= 5 {
+				break
+			}
+			line("- %s", mdEscape(formatSensorApp(app)))
+		}
+	}
+
+	// Dev
+	if showDocker(data) {
+		section("Dev")
+		line("- **Docker:** %s", formatDocker(data.Docker))
+		for _, c := range data.Docker.Containers {
+			line("  - %s", mdEscape(formatContainer(c)))
+		}
+	}
+
+	// Network
+	if data.Network.Available || len(data.WiFi.Networks) > 0 || showVPN(data) {
+		section("Network")
+	}
+	if showVPN(data) {
+		line("- **VPN:** %s", mdEscape(formatVPN(data.VPN)))
+	}
+	if len(data.WiFi.Networks) > 0 {
+		line("- **Wi-Fi networks:** %s", mdEscape(formatWiFiNetworks(data.WiFi)))
+	}
+	if data.Network.Available {
+		qualifier := ""
+		if data.Network.SinceBoot {
+			qualifier = " (since boot)"
+		}
+		line("- **%s** (%s): %s down / %s up%s", mdEscape(data.Network.NetworkName), data.Network.InterfaceName,
+			collectors.FormatBytes(data.Network.BytesReceived), collectors.FormatBytes(data.Network.BytesSent), qualifier)
+		for _, a := range data.Network.TopApps {
+			line("  - %s: %s down / %s up", mdEscape(a.Name), collectors.FormatBytes(a.BytesReceived), collectors.FormatBytes(a.BytesSent))
+		}
+	}
+
+	// Fragmentation and wellness
+	if data.Fragmentation.Available {
+		section("Focus Health")
+		line("- **Fragmentation:** %d/100 (%s)", data.Fragmentation.Score, data.Fragmentation.Level)
+		if data.Daylight.Available {
+			line("- **Daylight:** %s", mdEscape(formatDaylight(cfg, data.Daylight)))
+		}
+		if data.WorkHours.Available {
+			line("- **Work hours:** %s", mdEscape(formatWorkHours(data.WorkHours)))
+		}
+		if data.Clipboard.Available && data.Clipboard.Changes > 0 {
+			line("- **Clipboard:** %s", mdEscape(formatClipboard(cfg, data.Clipboard)))
+		}
+		if data.Burnout.Available {
+			for _, w := range data.Burnout.Warnings {
+				line("- %s", mdEscape(w.Message))
+			}
+		}
+	}
+
+	// Custom sections
+	for _, custom := range data.Custom {
+		if custom.Available {
+			section(mdEscape(custom.Title))
+			for _, text := range custom.Lines {
+				line("- %s", mdEscape(text))
+			}
+		}
+	}
+
+	// Notes
+	if len(data.Notes) > 0 {
+		section("Notes")
+		for _, note := range data.Notes {
+			line("- %s: %s", ui.FormatTime(note.Time, cfg.Display.TimeFormat), mdEscape(note.Text))
+		}
+	}
+
+	return b.String()
+}
+
+type nameCount struct {
+	name  string
+	count int
+}
+
+// topCounts returns the n largest entries of counts, ties broken by name
+func topCounts(counts map[string]int, n int) []nameCount {
+	entries := make([]nameCount, 0, len(counts))
+	for name, count := range counts {
+		entries = append(entries, nameCount{name, count})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].count != entries[j].count {
+			return entries[i].count > entries[j].count
+		}
+		return entries[i].name < entries[j].name
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// mdEscape backslash-escapes characters that would start Markdown formatting
+func mdEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, "#", `\#`,
+)
+
+// mdCell escapes s for use inside a table cell
+func mdCell(s string) string {
+	return strings.ReplaceAll(mdEscape(s), "|", `\|`)
+}
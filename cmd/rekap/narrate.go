@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/narrate"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newNarrateCmd() *cobra.Command {
+	var printPrompt bool
+
+	cmd := &cobra.Command{
+		Use:   "narrate",
+		Short: "Describe today in a few sentences using a local model",
+		Long: `Write a short prose recap of today ("You spent most of the morning in VS
+Code...") by piping the JSON summary, wrapped in a prompt, through a local
+model command such as "ollama run llama3".
+
+Narration is off by default. Turn it on with narrate.enabled and
+narrate.command in your config; narrate.prompt overrides the built-in
+prompt with a Go template over {{.Date}}, {{.Summary}}, and {{.JSON}}.
+rekap makes no network requests of its own, so with a local model the
+recap never leaves your Mac. Use --print-prompt to see exactly what the
+model would get.`,
+		Example: `  rekap narrate
+  rekap narrate --print-prompt`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			if !printPrompt {
+				if !cfg.Narrate.Enabled {
+					return fmt.Errorf("narration is disabled\nSet narrate.enabled: true and narrate.command (e.g. \"ollama run llama3\") in ~/.config/rekap/config.yaml")
+				}
+				if strings.TrimSpace(cfg.Narrate.Command) == "" {
+					return fmt.Errorf("narrate.command is not set\nAdd a local model command, e.g. \"ollama run llama3\", to ~/.config/rekap/config.yaml")
+				}
+			}
+			// Catch template mistakes before spending time collecting
+			if _, err := narrate.Parse(cfg.Narrate.Prompt); err != nil {
+				return fmt.Errorf("narrate.prompt: %w", err)
+			}
+
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+			data := collectSummary(cfg, collectors.Today(time.Now()))
+
+			payload, err := json.MarshalIndent(buildJSON(&data), "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode summary: %w", err)
+			}
+			prompt, err := narrate.Render(cfg.Narrate.Prompt, narrate.PromptData{
+				Date:    locale.Current().LongDate(data.Window.Start),
+				Summary: strings.Join(summaryLineParts(&data), " • "),
+				JSON:    string(payload),
+			})
+			if err != nil {
+				return fmt.Errorf("narrate.prompt: %w", err)
+			}
+
+			if printPrompt {
+				fmt.Print(prompt)
+				return nil
+			}
+
+			timeout := time.Duration(cfg.Narrate.TimeoutSeconds) * time.Second
+			text, err := narrate.Run(context.Background(), cfg.Narrate.Command, prompt, timeout)
+			if err != nil {
+				return fmt.Errorf("narration failed: %w", err)
+			}
+			fmt.Println(text)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&printPrompt, "print-prompt", false, "Print the prompt instead of running the model")
+	return cmd
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newNoteCmd() *cobra.Command {
+	var dateFlag string
+	var deleteID int64
+
+	cmd := &cobra.Command{
+		Use:   "note [text]",
+		Short: "Attach a note to today",
+		Long: `Attach a free-text note to a day, so you can later line up the numbers
+with what actually happened. Notes appear in that day's summary, the weekly
+report, and JSON output. Run without text to list the day's notes.`,
+		Example: `  rekap note "shipped the release"
+  rekap note --date 2026-02-16 "offsite, no deep work"
+  rekap note
+  rekap note --delete 3`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := loadHistoryConfig()
+
+			date := collectors.DayKey(time.Now())
+			if dateFlag != "" {
+				if _, err := time.Parse("2006-01-02", dateFlag); err != nil {
+					return fmt.Errorf("invalid --date %q, expected YYYY-MM-DD", dateFlag)
+				}
+				date = dateFlag
+			}
+
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			if deleteID > 0 {
+				found, err := store.DeleteNote(deleteID)
+				if err != nil {
+					return err
+				}
+				if !found {
+					return fmt.Errorf("no note with id %d", deleteID)
+				}
+				fmt.Println(ui.RenderSuccess(fmt.Sprintf("Deleted note %d", deleteID)))
+				return nil
+			}
+
+			if len(args) == 0 {
+				notes, err := store.Notes(date, date)
+				if err != nil {
+					return err
+				}
+				if len(notes) == 0 {
+					fmt.Println(ui.RenderHint(fmt.Sprintf("No notes for %s -- add one with: rekap note \"what happened\"", date)))
+					return nil
+				}
+				fmt.Println(ui.RenderHeader("NOTES • " + date))
+				for _, n := range notes {
+					fmt.Println(ui.RenderDataPoint("📝", fmt.Sprintf("%s • %s", ui.FormatTime(n.Time, cfg.Display.TimeFormat), n.Text)))
+					fmt.Println(ui.RenderSubItem(fmt.Sprintf("   id %d", n.ID)))
+				}
+				return nil
+			}
+
+			note, err := store.AddNote(date, time.Now(), strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Noted for %s (id %d)", note.Date, note.ID)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dateFlag, "date", "", "Day to attach the note to (YYYY-MM-DD, default today)")
+	cmd.Flags().Int64Var(&deleteID, "delete", 0, "Delete the note with this id")
+	_ = cmd.RegisterFlagCompletionFunc("date", completeHistoryDates)
+	return cmd
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+)
+
+func newNotifyCmd() *cobra.Command {
+	var dryRun bool
+	var sound string
+
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Post today's summary as a macOS notification",
+		Long: `Collect today's summary and post it to Notification Center: the summary
+line as the message and the most severe wellness warning, if there is one,
+as the subtitle.
+
+It prints nothing on success, so it fits a launchd agent that runs it on a
+schedule; see the README for one that runs every weekday at 6 PM. Use
+--dry-run to print the notification instead of posting it.`,
+		Example: `  rekap notify
+  rekap notify --sound Glass
+  rekap notify --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			collectors.SetDayStart(cfg.DayStartMinute())
+			now := time.Now()
+			data := collectSummary(cfg, collectors.Today(now))
+
+			n := buildNotification(cfg, &data, now)
+			n.Sound = sound
+			if dryRun {
+				fmt.Println(n.Title)
+				if n.Subtitle != "" {
+					fmt.Println(n.Subtitle)
+				}
+				fmt.Println(n.Message)
+				return nil
+			}
+			return postNotification(n)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the notification instead of posting it")
+	cmd.Flags().StringVar(&sound, "sound", "", "Play this system sound with the notification, e.g. Glass (see /System/Library/Sounds)")
+	return cmd
+}
+
+// notification is what "rekap notify" posts
+type notification struct {
+	Title    string
+	Subtitle string // Most severe wellness warning; empty when there's none
+	Message  string
+	Sound    string // System sound name; empty for none
+}
+
+// buildNotification renders the summary line and the top wellness warning.
+// Snoozing or turning off the wellness section leaves the warning out.
+func buildNotification(cfg *config.Config, data *SummaryData, now time.Time) notification {
+	n := notification{
+		Title:   "rekap • " + locale.Current().LongDate(data.Window.Start),
+		Message: strings.Join(summaryLineParts(data), " • "),
+	}
+	if n.Message == "" {
+		n.Message = "No activity data collected"
+	}
+
+	if data.Burnout.Available && len(data.Burnout.Warnings) > 0 && cfg.SectionShown("wellness", now) {
+		top := sortedWarnings(data.Burnout.Warnings)[0]
+		n.Subtitle = top.Message
+		if !cfg.Accessibility.Enabled || !cfg.Accessibility.NoEmoji {
+			n.Subtitle = burnoutIcon(top.Type) + " " + n.Subtitle
+		}
+	}
+	return n
+}
+
+// postNotification shows n with AppleScript's display notification. The
+// text goes in as arguments, so it needs no escaping.
+func postNotification(n notification) error {
+	display := "display notification (item 1 of argv) with title (item 2 of argv) subtitle (item 3 of argv)"
+	args := []string{"-e", "on run argv"}
+	if n.Sound != "" {
+		display += " sound name (item 4 of argv)"
+	}
+	args = append(args, "-e", display, "-e", "end run", n.Message, n.Title, n.Subtitle)
+	if n.Sound != "" {
+		args = append(args, n.Sound)
+	}
+	if out, err := exec.Command("osascript", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to post notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// notifyAgentLabel is the launchd label of the agent that runs "rekap notify"
+const notifyAgentLabel = "com.github.alexinslc.rekap.notify"
+
+// notifyAgentPlist runs program's "notify" every day at hour
+const notifyAgentPlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+  <key>Label</key>
+  <string>%s</string>
+  <key>ProgramArguments</key>
+  <array>
+    <string>%s</string>
+    <string>notify</string>
+  </array>
+  <key>StartCalendarInterval</key>
+  <dict><key>Hour</key><integer>%d</integer></dict>
+</dict>
+</plist>
+`
+
+// installNotifyAgent writes and loads a launchd agent running this binary's
+// "rekap notify" every day at hour, replacing any earlier one, and returns
+// the agent's path
+func installNotifyAgent(hour int) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the rekap binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(home, "Library", "LaunchAgents", notifyAgentLabel+".plist")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(exe)); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(notifyAgentPlist, notifyAgentLabel, escaped.String(), hour)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	// Unloading first picks up a changed hour; it fails harmlessly when
+	// nothing was loaded
+	_ = exec.Command("launchctl", "unload", path).Run()
+	if out, err := exec.Command("launchctl", "load", "-w", path).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to load %s: %w: %s", path, err, strings.TrimSpace(string(out)))
+	}
+	return path, nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/permissions"
+	"github.com/alexinslc/rekap/internal/state"
+	"github.com/alexinslc/rekap/internal/theme"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/alexinslc/rekap/internal/ui/tui"
+)
+
+// onboardingEvery is how long rekap waits before offering the onboarding
+// wizard again after it was quit without saving
+const onboardingEvery = 24 * time.Hour
+
+// onboardingState records when the wizard was last offered
+type onboardingState struct {
+	OfferedAt time.Time `json:"offered_at"`
+}
+
+// shouldOnboard reports whether a plain "rekap" should open the onboarding
+// wizard before the summary: there's no config yet, the summary would be
+// the TUI, and a permission is missing. Quitting the wizard holds it off
+// for a day so it doesn't greet every run.
+func shouldOnboard(out outputOptions, now time.Time) bool {
+	if !out.interactive() {
+		return false
+	}
+	path, err := config.GetConfigPath()
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return false
+	}
+
+	missing := false
+	for _, p := range permissions.All() {
+		if p.Pane != permissions.PaneAutomation && !p.Check() {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return false
+	}
+
+	var s onboardingState
+	if _, _, err := state.LoadLatest(state.KindOnboarding, &s); err != nil || now.Sub(s.OfferedAt) < onboardingEvery {
+		return false
+	}
+	_ = state.Save(state.KindOnboarding, now.Format("2006-01-02"), onboardingState{OfferedAt: now})
+	return true
+}
+
+// runOnboarding runs the onboarding wizard and saves what it settled on:
+// the config file and, if asked for, the daily notification agent. It
+// returns whether anything was saved; quitting leaves everything as it was.
+func runOnboarding(cfg *config.Config) (bool, error) {
+	final, err := tea.NewProgram(tui.NewOnboarding(cfg)).Run()
+	if err != nil {
+		return false, fmt.Errorf("TUI error: %w", err)
+	}
+	choices, saved := final.(tui.OnboardingModel).Choices()
+	if !saved {
+		return false, nil
+	}
+
+	path, err := config.GetConfigPath()
+	if err != nil {
+		return false, fmt.Errorf("failed to determine config path: %w", err)
+	}
+	if err := writeOnboardingConfig(path, choices); err != nil {
+		return false, fmt.Errorf("failed to write config: %w", err)
+	}
+	fmt.Println(ui.RenderSuccess("Config saved to " + path))
+
+	if choices.NotifyAt >= 0 {
+		agent, err := installNotifyAgent(choices.NotifyAt)
+		if err != nil {
+			return true, err
+		}
+		fmt.Println(ui.RenderSuccess("Daily notification scheduled: " + agent))
+	}
+	return true, nil
+}
+
+// writeOnboardingConfig saves the wizard's choices to the config at path.
+// An existing config is edited in place; a new one starts from the
+// commented template, with the choices added below it.
+func writeOnboardingConfig(path string, choices tui.OnboardingChoices) error {
+	apply := func(root *yaml.Node) error {
+		if choices.Theme != "default" {
+			t, ok := theme.GetBuiltIn(choices.Theme)
+			if !ok {
+				return fmt.Errorf("unknown theme %q", choices.Theme)
+			}
+			if err := config.SetColors(root, t.Colors); err != nil {
+				return err
+			}
+		}
+		for _, section := range choices.Skip {
+			if err := config.SetSectionEnabled(root, section, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return config.EditFile(path, apply)
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if err := apply(root); err != nil {
+		return err
+	}
+	text := configTemplate
+	if len(root.Content) > 0 {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(root); err != nil {
+			return err
+		}
+		text += "\n# Chosen in 'rekap init'\n" + buf.String()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), 0644)
+}
@@ -2,144 +2,440 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/alexinslc/rekap/internal/collectors"
 	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
 	"github.com/alexinslc/rekap/internal/ui"
 )
 
+// maxMeetingsListed is how many of the day's meetings the summary lists
+const maxMeetingsListed = 5
+
 func printQuiet(cfg *config.Config, data *SummaryData) {
+	writeQuiet(os.Stdout, cfg, data)
+}
+
+// writeQuiet writes the --quiet key=value lines to w
+func writeQuiet(w io.Writer, cfg *config.Config, data *SummaryData) {
 	if data.Uptime.Available {
-		fmt.Printf("awake_minutes=%d\n", data.Uptime.AwakeMinutes)
-		fmt.Printf("boot_time=%d\n", data.Uptime.BootTime.Unix())
+		fmt.Fprintf(w, "awake_minutes=%d\n", data.Uptime.AwakeMinutes)
+		fmt.Fprintf(w, "awake_idle_minutes=%d\n", data.Uptime.IdleAwakeMinutes)
+		if data.Uptime.Confidence != "" {
+			fmt.Fprintf(w, "awake_confidence=%s\n", data.Uptime.Confidence)
+		}
+		fmt.Fprintf(w, "boot_time=%d\n", data.Uptime.BootTime.Unix())
+	}
+
+	if tz := data.TimeZone; tz.Traveled() {
+		first, last := tz.Changes[0], tz.Changes[len(tz.Changes)-1]
+		fmt.Fprintf(w, "tz_changes=%d\n", len(tz.Changes))
+		fmt.Fprintf(w, "tz_from=%s\n", first.From)
+		fmt.Fprintf(w, "tz_to=%s\n", last.To)
+		fmt.Fprintf(w, "tz_changed_at=%d\n", last.At.Unix())
+	}
+
+	if s := data.SleepWake; s.Available {
+		if !s.FirstWake.IsZero() {
+			fmt.Fprintf(w, "first_wake=%d\n", s.FirstWake.Unix())
+		}
+		fmt.Fprintf(w, "sleep_count=%d\n", s.Sleeps)
+		fmt.Fprintf(w, "sleep_minutes=%d\n", s.SleepMinutes)
+		fmt.Fprintf(w, "longest_awake_minutes=%d\n", s.LongestAwake.Minutes())
+	}
+
+	if b := data.DayBounds; b.Available {
+		fmt.Fprintf(w, "day_start=%d\n", b.Arrival.Unix())
+		fmt.Fprintf(w, "day_end=%d\n", b.WrapUp.Unix())
+		fmt.Fprintf(w, "day_span_minutes=%d\n", b.SpanMinutes)
+		fmt.Fprintf(w, "day_overtime_minutes=%d\n", b.OvertimeMinutes())
+		if b.Ongoing {
+			fmt.Fprintf(w, "day_ongoing=1\n")
+		} else {
+			fmt.Fprintf(w, "day_ongoing=0\n")
+		}
 	}
 
 	if data.Battery.Available {
-		fmt.Printf("battery_start_pct=%d\n", data.Battery.StartPct)
-		fmt.Printf("battery_now_pct=%d\n", data.Battery.CurrentPct)
-		fmt.Printf("plug_events=%d\n", data.Battery.PlugCount)
+		fmt.Fprintf(w, "battery_start_pct=%d\n", data.Battery.StartPct)
+		fmt.Fprintf(w, "battery_now_pct=%d\n", data.Battery.CurrentPct)
+		fmt.Fprintf(w, "plug_events=%d\n", data.Battery.PlugCount)
 		if data.Battery.IsPlugged {
-			fmt.Printf("is_plugged=1\n")
+			fmt.Fprintf(w, "is_plugged=1\n")
 		} else {
-			fmt.Printf("is_plugged=0\n")
+			fmt.Fprintf(w, "is_plugged=0\n")
+		}
+		fmt.Fprintf(w, "battery_minutes=%d\n", data.Battery.BatteryMinutes)
+		fmt.Fprintf(w, "plugged_minutes=%d\n", data.Battery.PluggedMinutes)
+		fmt.Fprintf(w, "battery_used_wh=%.1f\n", data.Battery.UsedWh)
+		if data.Battery.HealthPct > 0 {
+			fmt.Fprintf(w, "battery_health_pct=%d\n", data.Battery.HealthPct)
+			fmt.Fprintf(w, "battery_cycles=%d\n", data.Battery.CycleCount)
+			fmt.Fprintf(w, "battery_condition=%s\n", data.Battery.Condition)
 		}
 	}
 
 	if data.Screen.Available {
-		fmt.Printf("screen_on_minutes=%d\n", data.Screen.ScreenOnMinutes)
+		fmt.Fprintf(w, "screen_on_minutes=%d\n", data.Screen.ScreenOnMinutes)
 		if data.Screen.LockCount > 0 {
-			fmt.Printf("screen_lock_count=%d\n", data.Screen.LockCount)
-			fmt.Printf("avg_mins_between_locks=%d\n", data.Screen.AvgMinsBetweenLock)
+			fmt.Fprintf(w, "screen_lock_count=%d\n", data.Screen.LockCount)
+			fmt.Fprintf(w, "avg_mins_between_locks=%d\n", data.Screen.AvgMinsBetweenLock)
 		}
 	}
 
+	if d := data.Daylight; d.Available {
+		if d.Polar != "" {
+			fmt.Fprintf(w, "daylight_polar=%s\n", d.Polar)
+		} else {
+			fmt.Fprintf(w, "sunrise=%d\n", d.Sunrise.Unix())
+			fmt.Fprintf(w, "sunset=%d\n", d.Sunset.Unix())
+		}
+		fmt.Fprintf(w, "screen_after_dark_minutes=%d\n", d.AfterDarkMinutes)
+	}
+
+	if h := data.WorkHours; h.Available {
+		fmt.Fprintf(w, "work_hours=%s\n", h.Hours)
+		fmt.Fprintf(w, "screen_work_minutes=%d\n", h.WorkMinutes())
+		fmt.Fprintf(w, "screen_after_hours_minutes=%d\n", h.AfterHoursMinutes)
+		fmt.Fprintf(w, "after_hours_notifications=%d\n", h.AfterHoursNotifications)
+		fmt.Fprintf(w, "after_hours_distraction_visits=%d\n", h.AfterHoursDistractionVisits)
+	}
+
+	if data.Downloads.Available {
+		fmt.Fprintf(w, "downloads_count=%d\n", data.Downloads.Count)
+		fmt.Fprintf(w, "downloads_bytes=%d\n", data.Downloads.TotalBytes)
+	}
+
+	for i, p := range data.Battery.Peripherals {
+		fmt.Fprintf(w, "peripheral_battery_%d=%s\n", i+1, p.Name)
+		fmt.Fprintf(w, "peripheral_battery_%d_pct=%d\n", i+1, p.Pct)
+	}
+
+	if data.Screenshots.Available {
+		fmt.Fprintf(w, "screenshots=%d\n", data.Screenshots.Screenshots)
+		fmt.Fprintf(w, "screen_recordings=%d\n", data.Screenshots.Recordings)
+	}
+
+	if data.Updates.Available {
+		fmt.Fprintf(w, "brew_installed=%d\n", data.Updates.Installed)
+		fmt.Fprintf(w, "brew_upgraded=%d\n", data.Updates.Upgraded)
+		fmt.Fprintf(w, "macos_updates_pending=%d\n", len(data.Updates.Pending))
+	}
+
+	if data.Memory.Available {
+		fmt.Fprintf(w, "memory_peak_pressure=%s\n", data.Memory.PeakPressure)
+		fmt.Fprintf(w, "memory_peak_used_pct=%d\n", data.Memory.PeakUsedPct)
+		fmt.Fprintf(w, "swap_used_bytes=%d\n", data.Memory.SwapUsedBytes)
+	}
+
+	if data.CPU.Available {
+		fmt.Fprintf(w, "cpu_peak_load=%.2f\n", data.CPU.PeakLoad)
+		fmt.Fprintf(w, "cpu_throttle_events=%d\n", len(data.CPU.Throttles))
+		fmt.Fprintf(w, "cpu_throttled_minutes=%d\n", data.CPU.ThrottledMinutes)
+	}
+
 	if data.Apps.Available {
 		for i, app := range data.Apps.TopApps {
 			if i >= 3 {
 				break
 			}
-			fmt.Printf("top_app_%d=%s\n", i+1, app.Name)
-			fmt.Printf("top_app_%d_minutes=%d\n", i+1, app.Minutes)
+			fmt.Fprintf(w, "top_app_%d=%s\n", i+1, app.Name)
+			fmt.Fprintf(w, "top_app_%d_minutes=%d\n", i+1, app.Minutes)
+			if app.Activations > 0 {
+				fmt.Fprintf(w, "top_app_%d_activations=%d\n", i+1, app.Activations)
+			}
+		}
+	}
+
+	if data.AppSources.Available {
+		fmt.Fprintf(w, "apps_apple_minutes=%d\n", data.AppSources.AppleMinutes)
+		fmt.Fprintf(w, "apps_app_store_minutes=%d\n", data.AppSources.AppStoreMinutes)
+		fmt.Fprintf(w, "apps_third_party_minutes=%d\n", data.AppSources.ThirdPartyMinutes)
+		fmt.Fprintf(w, "apps_unsandboxed_minutes=%d\n", data.AppSources.UnsandboxedMinutes)
+	}
+
+	if data.AppCategories.Available {
+		for i, c := range data.AppCategories.Categories {
+			fmt.Fprintf(w, "app_category_%d=%s\n", i+1, c.Name)
+			fmt.Fprintf(w, "app_category_%d_minutes=%d\n", i+1, c.Minutes)
+		}
+	}
+
+	if data.Spaces.Available {
+		for i, space := range data.Spaces.Spaces {
+			fmt.Fprintf(w, "space_%d=%s\n", i+1, space.Name)
+			fmt.Fprintf(w, "space_%d_minutes=%d\n", i+1, space.Minutes)
+		}
+	}
+
+	if data.SSH.Available {
+		fmt.Fprintf(w, "ssh_minutes=%d\n", data.SSH.TotalMinutes)
+		for i, host := range data.SSH.Hosts {
+			fmt.Fprintf(w, "ssh_host_%d=%s\n", i+1, host.Host)
+			fmt.Fprintf(w, "ssh_host_%d_minutes=%d\n", i+1, host.Minutes)
+		}
+	}
+
+	if data.Shell.Available {
+		fmt.Fprintf(w, "shell_commands=%d\n", data.Shell.Commands)
+		fmt.Fprintf(w, "shell_busiest_hour=%d\n", data.Shell.BusiestHour)
+		for i, c := range data.Shell.TopCommands {
+			fmt.Fprintf(w, "shell_command_%d=%s\n", i+1, c.Name)
+			fmt.Fprintf(w, "shell_command_%d_count=%d\n", i+1, c.Count)
 		}
 	}
 
+	if data.Builds.Available {
+		fmt.Fprintf(w, "builds_count=%d\n", data.Builds.Builds)
+		fmt.Fprintf(w, "builds_wait_minutes=%d\n", data.Builds.WaitMinutes)
+		fmt.Fprintf(w, "builds_failed=%d\n", data.Builds.Failed)
+	}
+
+	if data.Reminders.Available {
+		fmt.Fprintf(w, "reminders_completed=%d\n", data.Reminders.Completed)
+	}
+
+	if data.Docker.Available {
+		fmt.Fprintf(w, "docker_started=%d\n", data.Docker.Started)
+		fmt.Fprintf(w, "docker_running=%d\n", data.Docker.Running)
+		fmt.Fprintf(w, "docker_cpu_pct=%.1f\n", data.Docker.CPUPct)
+		fmt.Fprintf(w, "docker_mem_bytes=%d\n", data.Docker.MemBytes)
+	}
+
 	if data.Focus.Available {
-		fmt.Printf("focus_streak_minutes=%d\n", data.Focus.StreakMinutes)
-		fmt.Printf("focus_streak_app=%s\n", data.Focus.AppName)
+		fmt.Fprintf(w, "focus_streak_minutes=%d\n", data.Focus.StreakMinutes)
+		fmt.Fprintf(w, "focus_streak_app=%s\n", data.Focus.AppName)
+	}
+
+	if data.Sessions.Available {
+		fmt.Fprintf(w, "sessions_count=%d\n", data.Sessions.Count)
+		fmt.Fprintf(w, "session_longest_minutes=%d\n", data.Sessions.LongestMinutes)
+		fmt.Fprintf(w, "session_avg_minutes=%d\n", data.Sessions.AvgMinutes)
+	}
+
+	if data.Meetings.Available {
+		fmt.Fprintf(w, "meetings_count=%d\n", data.Meetings.Count)
+		fmt.Fprintf(w, "meetings_minutes=%d\n", data.Meetings.TotalMinutes)
+		fmt.Fprintf(w, "meetings_longest_free_minutes=%d\n", data.Meetings.LongestFreeMinutes)
+	}
+
+	if data.Projects.Available {
+		for i, p := range data.Projects.Projects {
+			fmt.Fprintf(w, "project_%d=%s\n", i+1, p.Name)
+			fmt.Fprintf(w, "project_%d_minutes=%d\n", i+1, p.Minutes)
+		}
+		fmt.Fprintf(w, "projects_unattributed_minutes=%d\n", data.Projects.UnattributedMinutes)
+	}
+
+	if data.Learning.Available {
+		fmt.Fprintf(w, "learning_minutes=%d\n", data.Learning.Minutes)
+		fmt.Fprintf(w, "learning_visits=%d\n", data.Learning.Visits)
 	}
 
 	if data.Media.Available {
-		fmt.Printf("media_track=%s\n", data.Media.Track)
-		fmt.Printf("media_app=%s\n", data.Media.App)
+		fmt.Fprintf(w, "media_track=%s\n", data.Media.Track)
+		fmt.Fprintf(w, "media_app=%s\n", data.Media.App)
+	}
+
+	for i, d := range data.AudioDevices.Devices {
+		fmt.Fprintf(w, "audio_device_%d=%s\n", i+1, d.Name)
+		fmt.Fprintf(w, "audio_device_%d_minutes=%d\n", i+1, d.Minutes)
+	}
+
+	if data.Music.Available {
+		fmt.Fprintf(w, "music_minutes=%d\n", data.Music.Minutes)
+		fmt.Fprintf(w, "music_tracks=%d\n", data.Music.Tracks)
+		if len(data.Music.Artists) > 0 {
+			fmt.Fprintf(w, "music_top_artist=%s\n", data.Music.Artists[0].Name)
+		}
+		fmt.Fprintf(w, "podcast_minutes=%d\n", data.Music.PodcastMinutes)
+		fmt.Fprintf(w, "podcast_episodes=%d\n", data.Music.Episodes)
+		fmt.Fprintf(w, "audiobook_minutes=%d\n", data.Music.AudiobookMinutes)
 	}
 
 	if data.Network.Available {
-		fmt.Printf("network_interface=%s\n", data.Network.InterfaceName)
-		fmt.Printf("network_name=%s\n", data.Network.NetworkName)
-		fmt.Printf("network_bytes_received=%d\n", data.Network.BytesReceived)
-		fmt.Printf("network_bytes_sent=%d\n", data.Network.BytesSent)
+		fmt.Fprintf(w, "network_interface=%s\n", data.Network.InterfaceName)
+		fmt.Fprintf(w, "network_name=%s\n", data.Network.NetworkName)
+		fmt.Fprintf(w, "network_bytes_received=%d\n", data.Network.BytesReceived)
+		fmt.Fprintf(w, "network_bytes_sent=%d\n", data.Network.BytesSent)
 		if data.Network.SinceBoot {
-			fmt.Printf("network_since_boot=1\n")
+			fmt.Fprintf(w, "network_since_boot=1\n")
+		} else {
+			fmt.Fprintf(w, "network_since_boot=0\n")
+		}
+		if data.Network.Metered {
+			fmt.Fprintf(w, "network_metered=1\n")
+			fmt.Fprintf(w, "network_metered_reason=%s\n", data.Network.MeteredReason)
+		} else {
+			fmt.Fprintf(w, "network_metered=0\n")
+		}
+		fmt.Fprintf(w, "network_metered_bytes_received=%d\n", data.Network.MeteredBytesReceived)
+		fmt.Fprintf(w, "network_metered_bytes_sent=%d\n", data.Network.MeteredBytesSent)
+		for i, a := range data.Network.TopApps {
+			fmt.Fprintf(w, "network_app_%d=%s\n", i+1, a.Name)
+			fmt.Fprintf(w, "network_app_%d_bytes=%d\n", i+1, a.Bytes())
+		}
+	}
+
+	if data.WiFi.Available {
+		fmt.Fprintf(w, "wifi_avg_rssi=%d\n", data.WiFi.AvgRSSI)
+		fmt.Fprintf(w, "wifi_avg_noise=%d\n", data.WiFi.AvgNoise)
+		fmt.Fprintf(w, "wifi_avg_snr=%d\n", data.WiFi.AvgSNR)
+		fmt.Fprintf(w, "wifi_avg_tx_rate=%d\n", data.WiFi.AvgTxRate)
+		fmt.Fprintf(w, "wifi_quality=%s\n", data.WiFi.Quality)
+		fmt.Fprintf(w, "wifi_samples=%d\n", data.WiFi.Samples)
+		if !data.WiFi.WorstPeriodStart.IsZero() {
+			fmt.Fprintf(w, "wifi_worst_period_start=%d\n", data.WiFi.WorstPeriodStart.Unix())
+			fmt.Fprintf(w, "wifi_worst_period_snr=%d\n", data.WiFi.WorstPeriodSNR)
+		}
+		if len(data.WiFi.Networks) > 0 {
+			fmt.Fprintf(w, "wifi_networks=%d\n", len(data.WiFi.Networks))
+			fmt.Fprintf(w, "wifi_switches=%d\n", data.WiFi.Switches)
+			for i, n := range data.WiFi.Networks {
+				fmt.Fprintf(w, "wifi_network_%d=%s\n", i+1, n.SSID)
+				fmt.Fprintf(w, "wifi_network_%d_minutes=%d\n", i+1, n.Minutes)
+			}
+		}
+	}
+
+	if data.VPN.Available {
+		fmt.Fprintf(w, "vpn_minutes=%d\n", data.VPN.Minutes)
+		if data.VPN.Connected {
+			fmt.Fprintf(w, "vpn_connected=1\n")
 		} else {
-			fmt.Printf("network_since_boot=0\n")
+			fmt.Fprintf(w, "vpn_connected=0\n")
 		}
 	}
 
 	if data.Browsers.Available {
-		fmt.Printf("browser_total_tabs=%d\n", data.Browsers.TotalTabs)
+		fmt.Fprintf(w, "browser_total_tabs=%d\n", data.Browsers.TotalTabs)
 		if data.Browsers.Chrome.Available {
-			fmt.Printf("browser_chrome_tabs=%d\n", data.Browsers.Chrome.TabCount)
+			fmt.Fprintf(w, "browser_chrome_tabs=%d\n", data.Browsers.Chrome.TabCount)
 		}
 		if data.Browsers.Safari.Available {
-			fmt.Printf("browser_safari_tabs=%d\n", data.Browsers.Safari.TabCount)
+			fmt.Fprintf(w, "browser_safari_tabs=%d\n", data.Browsers.Safari.TabCount)
 		}
 		if data.Browsers.Edge.Available {
-			fmt.Printf("browser_edge_tabs=%d\n", data.Browsers.Edge.TabCount)
+			fmt.Fprintf(w, "browser_edge_tabs=%d\n", data.Browsers.Edge.TabCount)
+		}
+		if data.Browsers.Firefox.Available {
+			fmt.Fprintf(w, "browser_firefox_tabs=%d\n", data.Browsers.Firefox.TabCount)
 		}
-		totalCategorized := data.Browsers.WorkVisits + data.Browsers.DistractionVisits + data.Browsers.NeutralVisits
+		totalCategorized := data.Browsers.WorkVisits + data.Browsers.DistractionVisits + data.Browsers.LearningVisits + data.Browsers.NeutralVisits
 		if totalCategorized > 0 {
-			fmt.Printf("browser_work_visits=%d\n", data.Browsers.WorkVisits)
-			fmt.Printf("browser_distraction_visits=%d\n", data.Browsers.DistractionVisits)
-			fmt.Printf("browser_neutral_visits=%d\n", data.Browsers.NeutralVisits)
+			fmt.Fprintf(w, "browser_work_visits=%d\n", data.Browsers.WorkVisits)
+			fmt.Fprintf(w, "browser_distraction_visits=%d\n", data.Browsers.DistractionVisits)
+			fmt.Fprintf(w, "browser_learning_visits=%d\n", data.Browsers.LearningVisits)
+			fmt.Fprintf(w, "browser_neutral_visits=%d\n", data.Browsers.NeutralVisits)
 		}
 		if data.Browsers.TotalURLsVisited > 0 {
-			fmt.Printf("browser_urls_visited=%d\n", data.Browsers.TotalURLsVisited)
+			fmt.Fprintf(w, "browser_urls_visited=%d\n", data.Browsers.TotalURLsVisited)
 		}
 		if data.Browsers.TopHistoryDomain != "" {
-			fmt.Printf("browser_top_domain=%s\n", data.Browsers.TopHistoryDomain)
-			fmt.Printf("browser_top_domain_visits=%d\n", data.Browsers.TopDomainVisits)
+			fmt.Fprintf(w, "browser_top_domain=%s\n", data.Browsers.TopHistoryDomain)
+			fmt.Fprintf(w, "browser_top_domain_visits=%d\n", data.Browsers.TopDomainVisits)
 		}
 		if len(data.Browsers.AllIssueURLs) > 0 {
-			fmt.Printf("browser_issues_viewed=%d\n", len(data.Browsers.AllIssueURLs))
+			fmt.Fprintf(w, "browser_issues_viewed=%d\n", len(data.Browsers.AllIssueURLs))
 		}
 	}
 
+	if data.CloudConsoles.Available {
+		fmt.Fprintf(w, "cloud_console_minutes=%d\n", data.CloudConsoles.TotalMinutes)
+		for _, p := range data.CloudConsoles.Providers {
+			fmt.Fprintf(w, "cloud_%s_minutes=%d\n", p.Provider, p.Minutes)
+		}
+		fmt.Fprintf(w, "cloud_long_sessions=%d\n", len(data.CloudConsoles.LongSessions))
+	}
+
 	if data.Notifications.Available {
-		fmt.Printf("notifications_total=%d\n", data.Notifications.TotalNotifications)
+		fmt.Fprintf(w, "notifications_total=%d\n", data.Notifications.TotalNotifications)
 		for i, app := range data.Notifications.TopApps {
 			if i >= 3 {
 				break
 			}
-			fmt.Printf("notification_app_%d=%s\n", i+1, app.Name)
-			fmt.Printf("notification_app_%d_count=%d\n", i+1, app.Count)
+			fmt.Fprintf(w, "notification_app_%d=%s\n", i+1, app.Name)
+			fmt.Fprintf(w, "notification_app_%d_count=%d\n", i+1, app.Count)
+		}
+	}
+
+	if data.Messages.Available {
+		fmt.Fprintf(w, "messages_sent=%d\n", data.Messages.Sent)
+		fmt.Fprintf(w, "messages_received=%d\n", data.Messages.Received)
+		for _, s := range data.Messages.Services {
+			fmt.Fprintf(w, "messages_%s_sent=%d\n", strings.ToLower(s.Service), s.Sent)
+			fmt.Fprintf(w, "messages_%s_received=%d\n", strings.ToLower(s.Service), s.Received)
+		}
+	}
+
+	if data.Sensors.Available {
+		fmt.Fprintf(w, "camera_minutes=%d\n", data.Sensors.CameraMinutes)
+		fmt.Fprintf(w, "mic_minutes=%d\n", data.Sensors.MicMinutes)
+		for i, app := range data.Sensors.Apps {
+			if i >= 3 {
+				break
+			}
+			fmt.Fprintf(w, "sensor_app_%d=%s\n", i+1, app.Name)
 		}
 	}
 
+	if data.Clipboard.Available {
+		fmt.Fprintf(w, "clipboard_changes=%d\n", data.Clipboard.Changes)
+		fmt.Fprintf(w, "clipboard_changes_per_hour=%.1f\n", data.Clipboard.ChangesPerHour)
+		fmt.Fprintf(w, "clipboard_busiest_hour=%d\n", data.Clipboard.BusiestHour)
+	}
+
 	if data.Fragmentation.Available {
-		fmt.Printf("fragmentation_score=%d\n", data.Fragmentation.Score)
-		fmt.Printf("fragmentation_level=%s\n", data.Fragmentation.Level)
+		fmt.Fprintf(w, "fragmentation_score=%d\n", data.Fragmentation.Score)
+		fmt.Fprintf(w, "fragmentation_level=%s\n", data.Fragmentation.Level)
 	}
 
 	if data.Issues.Available {
-		fmt.Printf("issues_count=%d\n", len(data.Issues.Issues))
+		fmt.Fprintf(w, "issues_count=%d\n", len(data.Issues.Issues))
 		for i, issue := range data.Issues.Issues {
 			if i >= 10 {
 				break
 			}
-			fmt.Printf("issue_%d_id=%s\n", i+1, issue.ID)
-			fmt.Printf("issue_%d_tracker=%s\n", i+1, issue.Tracker)
-			fmt.Printf("issue_%d_visits=%d\n", i+1, issue.VisitCount)
+			fmt.Fprintf(w, "issue_%d_id=%s\n", i+1, issue.ID)
+			fmt.Fprintf(w, "issue_%d_tracker=%s\n", i+1, issue.Tracker)
+			fmt.Fprintf(w, "issue_%d_visits=%d\n", i+1, issue.VisitCount)
+		}
+	}
+
+	if len(data.Notes) > 0 {
+		fmt.Fprintf(w, "notes_count=%d\n", len(data.Notes))
+		for i, note := range data.Notes {
+			fmt.Fprintf(w, "note_%d_time=%s\n", i+1, note.Time.Format(time.RFC3339))
+			fmt.Fprintf(w, "note_%d_text=%s\n", i+1, strings.ReplaceAll(note.Text, "\n", " "))
 		}
 	}
 
 	overload := collectors.CheckContextOverload(data.Apps, data.Browsers)
 	if overload.IsOverloaded {
-		fmt.Printf("context_overload=1\n")
-		fmt.Printf("context_overload_message=%s\n", overload.WarningMessage)
+		fmt.Fprintf(w, "context_overload=1\n")
+		fmt.Fprintf(w, "context_overload_message=%s\n", overload.WarningMessage)
 	} else {
-		fmt.Printf("context_overload=0\n")
+		fmt.Fprintf(w, "context_overload=0\n")
 	}
 }
 
 func printHuman(cfg *config.Config, data *SummaryData) {
-	title := ui.RenderTitle("📊 Today's rekap", ui.IsTTY())
+	period := summaryPeriod(cfg, data)
+	title := ui.RenderTitle(summaryTitle(period), ui.IsTTY())
 	if title != "" {
 		fmt.Println(title)
 	}
 	fmt.Println()
 
+	// Sections can be snoozed for parts of the day in config
+	now := time.Now()
+	shown := func(section string) bool { return cfg.SectionShown(section, now) }
+
 	// Check for context overload
 	overload := collectors.CheckContextOverload(data.Apps, data.Browsers)
 	if overload.IsOverloaded {
@@ -147,268 +443,559 @@ func printHuman(cfg *config.Config, data *SummaryData) {
 		fmt.Println()
 	}
 
-	// Build summary line
-	var summaryParts []string
-
-	if data.Screen.Available {
-		summaryParts = append(summaryParts, ui.FormatDuration(data.Screen.ScreenOnMinutes)+" screen-on")
+	if summaryParts := summaryLineParts(data); len(summaryParts) > 0 {
+		fmt.Println(ui.RenderSummaryLine(summaryParts))
+		fmt.Println()
 	}
 
-	if data.Apps.Available && len(data.Apps.TopApps) > 0 {
-		var appList []string
-		for i, app := range data.Apps.TopApps {
-			if i >= 3 {
-				break
-			}
-			appList = append(appList, fmt.Sprintf("%s (%s)", app.Name, ui.FormatDurationCompact(app.Minutes)))
-		}
-		if len(appList) > 0 {
-			summaryParts = append(summaryParts, "Top apps: "+strings.Join(appList, ", "))
-		}
+	// Times read in the zone the Mac is in now, so say when that changed
+	if data.TimeZone.Traveled() {
+		fmt.Println(ui.RenderDataPoint("✈️", formatTravel(cfg, data.TimeZone)))
+		fmt.Println()
 	}
 
-	if len(summaryParts) > 0 {
-		fmt.Println(ui.RenderSummaryLine(summaryParts))
-		fmt.Println()
+	// Each section prints under its key, in display.section_order; the
+	// first one printed goes without a blank line above it
+	printed := false
+	header := func(title string) {
+		if printed {
+			fmt.Println()
+		}
+		printed = true
+		fmt.Println(ui.RenderHeader(title))
 	}
+	printers := map[string]func(){}
 
 	// System Status Section
-	fmt.Println(ui.RenderHeader("SYSTEM"))
+	printers["system"] = func() {
+		if shown("system") {
+			header("SYSTEM")
 
-	if data.Uptime.Available {
-		text := fmt.Sprintf("Active since %s • %s",
-			ui.FormatTime(data.Uptime.BootTime, cfg.Display.TimeFormat),
-			data.Uptime.FormattedTime)
-		fmt.Println(ui.RenderDataPoint("⏰", text))
-	}
+			if data.Uptime.Available {
+				text := fmt.Sprintf("Active since %s • %s",
+					ui.FormatTime(data.Uptime.BootTime, cfg.Display.TimeFormat),
+					data.Uptime.FormattedTime)
+				fmt.Println(ui.RenderDataPoint("⏰", text))
+				if note := awakeConfidenceText(data.Uptime); note != "" {
+					fmt.Println(ui.RenderSubItem("   " + note))
+				}
+			}
 
-	if data.Battery.Available && cfg.ShouldShowBattery() {
-		status := "discharging"
-		if data.Battery.IsPlugged {
-			status = "plugged in"
-		}
-		var text string
-		if data.Battery.StartPct != data.Battery.CurrentPct {
-			text = fmt.Sprintf("%d%% → %d%% • %s", data.Battery.StartPct, data.Battery.CurrentPct, status)
-		} else {
-			text = fmt.Sprintf("%d%% • %s", data.Battery.CurrentPct, status)
-		}
-		fmt.Println(ui.RenderDataPoint("🔋", text))
+			if b := data.DayBounds; b.Available {
+				text := "Workday " + ui.FormatDayBounds(b.Arrival, b.WrapUp, b.Ongoing, b.SpanMinutes, cfg.Display.TimeFormat)
+				fmt.Println(ui.RenderDataPoint("🌅", text))
+				if overtime := b.OvertimeMinutes(); overtime > 0 {
+					fmt.Println(ui.RenderSubItem(fmt.Sprintf("   %s past an %dh day", ui.FormatDuration(overtime), collectors.WorkdayMinutes/60)))
+				}
+			}
+
+			if data.Battery.Available && cfg.ShouldShowBattery() {
+				status := "discharging"
+				if data.Battery.IsPlugged {
+					status = "plugged in"
+				}
+				var text string
+				if data.Battery.StartPct != data.Battery.CurrentPct {
+					text = fmt.Sprintf("%d%% → %d%% • %s", data.Battery.StartPct, data.Battery.CurrentPct, status)
+				} else {
+					text = fmt.Sprintf("%d%% • %s", data.Battery.CurrentPct, status)
+				}
+				fmt.Println(ui.RenderDataPoint("🔋", text))
+				if usage := formatBatteryUsage(data.Battery); usage != "" {
+					fmt.Println(ui.RenderSubItem("   " + usage))
+				}
+				if hasBatteryCurve(data.Battery) {
+					fmt.Println(ui.RenderSubItem("   " + ui.RenderSparkline(data.Battery.HourlyPct, 0) + " hourly level"))
+				}
+				if data.Battery.HealthPct > 0 {
+					fmt.Println(ui.RenderSubItem("   " + formatBatteryHealth(data.Battery)))
+				}
+
+				if data.Battery.PlugCount > 0 {
+					plugText := fmt.Sprintf("%d plug event(s) %s", data.Battery.PlugCount, period)
+					fmt.Println(ui.RenderDataPoint("🔌", plugText))
+				}
+			}
+
+			if len(data.Battery.Peripherals) > 0 && cfg.ShouldShowBattery() {
+				fmt.Println(ui.RenderDataPoint("🔋", "Devices: "+formatPeripherals(data.Battery.Peripherals)))
+			}
 
-		if data.Battery.PlugCount > 0 {
-			plugText := fmt.Sprintf("%d plug event(s) today", data.Battery.PlugCount)
-			fmt.Println(ui.RenderDataPoint("🔌", plugText))
+			if data.Screen.Available && data.Screen.LockCount > 0 {
+				var lockText string
+				if data.Screen.AvgMinsBetweenLock > 0 {
+					lockText = fmt.Sprintf("Screen locked %d time%s (avg %s between breaks)",
+						data.Screen.LockCount,
+						pluralize(data.Screen.LockCount),
+						ui.FormatDuration(data.Screen.AvgMinsBetweenLock))
+				} else {
+					lockText = fmt.Sprintf("Screen locked %d time%s %s",
+						data.Screen.LockCount,
+						pluralize(data.Screen.LockCount),
+						period)
+				}
+				fmt.Println(ui.RenderDataPoint("🔒", lockText))
+			}
+
+			if data.Downloads.Available && data.Downloads.Count > 0 {
+				fmt.Println(ui.RenderDataPoint("📥", formatDownloads(data.Downloads)))
+			}
+
+			if showScreenshots(data.Screenshots) {
+				fmt.Println(ui.RenderDataPoint("📸", formatScreenshots(data.Screenshots)))
+			}
+
+			if len(data.Updates.Brew) > 0 {
+				fmt.Println(ui.RenderDataPoint("🍺", "Homebrew: "+formatBrewChanges(data.Updates, 4)))
+			}
+			if len(data.Updates.Pending) > 0 {
+				fmt.Println(ui.RenderDataPoint("⬆️", formatPendingUpdates(data.Updates.Pending)))
+			}
+
+			if data.Memory.Available {
+				fmt.Println(ui.RenderDataPoint("🧠", formatMemory(data.Memory)))
+				if data.Memory.Heavy() {
+					fmt.Println(ui.RenderWarning("Heavy memory day: quit what you aren't using or restart to clear swap"))
+				}
+			}
+
+			if data.CPU.Available {
+				fmt.Println(ui.RenderDataPoint("🌡️", formatCPU(data.CPU)))
+			}
 		}
 	}
 
-	if data.Screen.Available && data.Screen.LockCount > 0 {
-		var lockText string
-		if data.Screen.AvgMinsBetweenLock > 0 {
-			lockText = fmt.Sprintf("Screen locked %d time%s (avg %s between breaks)",
-				data.Screen.LockCount,
-				pluralize(data.Screen.LockCount),
-				ui.FormatDuration(data.Screen.AvgMinsBetweenLock))
-		} else {
-			lockText = fmt.Sprintf("Screen locked %d time%s today",
-				data.Screen.LockCount,
-				pluralize(data.Screen.LockCount))
+	// Sleep & Wake Section
+	printers["sleep"] = func() {
+		if s := data.SleepWake; s.Available && shown("sleep") {
+			header("SLEEP & WAKE")
+
+			if !s.FirstWake.IsZero() {
+				fmt.Println(ui.RenderDataPoint("☀️", "First wake "+ui.FormatTime(s.FirstWake, cfg.Display.TimeFormat)))
+			}
+			fmt.Println(ui.RenderDataPoint("💤", formatSleeps(s)))
+			if s.LongestAwake.Minutes() > 0 {
+				text := fmt.Sprintf("Longest awake %s (%s – %s)", ui.FormatDuration(s.LongestAwake.Minutes()),
+					ui.FormatTime(s.LongestAwake.Start, cfg.Display.TimeFormat), ui.FormatTime(s.LongestAwake.End, cfg.Display.TimeFormat))
+				fmt.Println(ui.RenderDataPoint("⏳", text))
+			}
 		}
-		fmt.Println(ui.RenderDataPoint("🔒", lockText))
 	}
 
 	// Productivity Section
-	if data.Focus.Available || (data.Apps.Available && len(data.Apps.TopApps) > 0) {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("PRODUCTIVITY"))
+	printers["productivity"] = func() {
+		if shown("productivity") && (data.Focus.Available || data.Sessions.Available || (data.Apps.Available && len(data.Apps.TopApps) > 0) || showSpaces(data) || data.SSH.Available || data.Shell.Available || data.Builds.Available || showReminders(data.Reminders)) {
+			header("PRODUCTIVITY")
+
+			if data.Focus.Available {
+				text := fmt.Sprintf("Best focus: %s in %s", ui.FormatDuration(data.Focus.StreakMinutes), data.Focus.AppName)
+				fmt.Println(ui.RenderHighlight("⏱️ ", text))
+			}
+
+			if data.Sessions.Available {
+				text := fmt.Sprintf("%d session%s • longest %s • avg %s",
+					data.Sessions.Count, pluralize(data.Sessions.Count),
+					ui.FormatDuration(data.Sessions.LongestMinutes), ui.FormatDuration(data.Sessions.AvgMinutes))
+				fmt.Println(ui.RenderDataPoint("🧭", text))
+			}
+
+			if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+				for i, app := range data.Apps.TopApps {
+					if i >= 3 {
+						break
+					}
+					appText := fmt.Sprintf("%s • %s", app.Name, ui.FormatDuration(app.Minutes))
+					fmt.Println(ui.RenderDataPoint("📱", appText))
+				}
+				if activated := mostActivatedApps(data.Apps.TopApps, 3); len(activated) > 0 {
+					fmt.Println(ui.RenderDataPoint("🔁", "Activated: "+formatAppActivations(activated)))
+				}
+				if data.Apps.Source == config.SourceSampling {
+					fmt.Println(ui.RenderSubItem("   Sampled from the frontmost app; grant Full Disk Access for Screen Time data"))
+				}
+			}
+
+			if data.AppSources.Available {
+				fmt.Println(ui.RenderDataPoint("🛡️", formatAppSources(data.AppSources)))
+			}
+
+			if showAppCategories(data) {
+				fmt.Println(ui.RenderDataPoint("🥧", "Categories: "+formatAppCategories(data.AppCategories, 4)))
+				fmt.Println(ui.RenderSubItem("   " + ui.RenderStackedBar(appCategoryMinutes(data.AppCategories), 30)))
+			}
+
+			if showSpaces(data) {
+				fmt.Println(ui.RenderDataPoint("🗂️", "Spaces: "+formatSpaces(data.Spaces.Spaces, 3)))
+			}
+
+			if data.SSH.Available {
+				fmt.Println(ui.RenderDataPoint("🖥️", "Remote: "+formatSSHHosts(data.SSH.Hosts, 3)))
+			}
 
-		if data.Focus.Available {
-			text := fmt.Sprintf("Best focus: %s in %s", ui.FormatDuration(data.Focus.StreakMinutes), data.Focus.AppName)
-			fmt.Println(ui.RenderHighlight("⏱️ ", text))
+			if data.Shell.Available {
+				fmt.Println(ui.RenderDataPoint("⌨️", "Shell: "+formatShell(cfg, data.Shell, 3)))
+			}
+
+			if data.Builds.Available {
+				fmt.Println(ui.RenderDataPoint("🔨", "Builds: "+formatBuilds(data.Builds)))
+				if len(data.Builds.Tools) > 1 {
+					fmt.Println(ui.RenderSubItem("   " + formatBuildTools(data.Builds.Tools, 3)))
+				}
+			}
+
+			if showReminders(data.Reminders) {
+				fmt.Println(ui.RenderDataPoint("✅", formatReminders(data.Reminders, 3)))
+			}
+
+			if data.Learning.Available && data.Learning.Minutes > 0 {
+				fmt.Println(ui.RenderDataPoint("📚", "Learning: "+formatLearning(data.Learning)))
+			}
 		}
+	}
 
-		if data.Apps.Available && len(data.Apps.TopApps) > 0 {
-			for i, app := range data.Apps.TopApps {
-				if i >= 3 {
-					break
+	// Meetings Section
+	printers["meetings"] = func() {
+		if data.Meetings.Available && shown("meetings") {
+			header("MEETINGS")
+
+			meetings := data.Meetings
+			if meetings.Count == 0 {
+				fmt.Println(ui.RenderDataPoint("📅", "No meetings "+period))
+			} else {
+				text := fmt.Sprintf("%d meeting%s • %s", meetings.Count, pluralize(meetings.Count), ui.FormatDuration(meetings.TotalMinutes))
+				fmt.Println(ui.RenderDataPoint("📅", text))
+				for i, m := range meetings.Meetings {
+					if i >= maxMeetingsListed {
+						fmt.Println(ui.RenderSubItem(fmt.Sprintf("   +%d more", meetings.Count-i)))
+						break
+					}
+					fmt.Println(ui.RenderSubItem(fmt.Sprintf("   %-8s  %s (%s)",
+						ui.FormatTime(m.Start, cfg.Display.TimeFormat), m.Title, ui.FormatDuration(m.Minutes()))))
 				}
-				appText := fmt.Sprintf("%s • %s", app.Name, ui.FormatDuration(app.Minutes))
-				fmt.Println(ui.RenderDataPoint("📱", appText))
+			}
+			if meetings.LongestFreeMinutes > 0 {
+				text := fmt.Sprintf("Longest meeting-free block: %s (%s–%s)", ui.FormatDuration(meetings.LongestFreeMinutes),
+					ui.FormatTime(meetings.LongestFree.Start, cfg.Display.TimeFormat), ui.FormatTime(meetings.LongestFree.End, cfg.Display.TimeFormat))
+				fmt.Println(ui.RenderDataPoint("🟢", text))
+			}
+		}
+	}
+
+	// Timesheet Section
+	printers["timesheet"] = func() {
+		if data.Projects.Available && shown("timesheet") {
+			header("TIMESHEET")
+
+			if len(data.Projects.Projects) == 0 {
+				fmt.Println(ui.RenderHint("No time matched your configured projects yet"))
+			}
+			for _, p := range data.Projects.Projects {
+				text := fmt.Sprintf("%s • %s (%sh)", p.Name, ui.FormatDuration(p.Minutes), locale.Current().FormatFloat(float64(p.Minutes)/60, 2))
+				fmt.Println(ui.RenderDataPoint("📋", text))
+			}
+			if data.Projects.UnattributedMinutes > 0 {
+				fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Unattributed: %s", ui.FormatDuration(data.Projects.UnattributedMinutes))))
 			}
 		}
 	}
 
 	// Media Section
-	if data.Media.Available && cfg.ShouldShowMedia() {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("NOW PLAYING"))
-		text := fmt.Sprintf("\"%s\" in %s", data.Media.Track, data.Media.App)
-		fmt.Println(ui.RenderDataPoint("🎵", text))
+	printers["media"] = func() {
+		audioDevices := data.AudioDevices.Devices
+		if (data.Media.Available || len(audioDevices) > 0 || showMusic(data.Music)) && cfg.ShouldShowMedia() && shown("media") {
+			if data.Media.Available {
+				header("NOW PLAYING")
+				text := fmt.Sprintf("\"%s\" in %s", data.Media.Track, data.Media.App)
+				fmt.Println(ui.RenderDataPoint("🎵", text))
+			} else {
+				header("AUDIO")
+			}
+			if data.Music.Minutes > 0 {
+				fmt.Println(ui.RenderDataPoint("🎶", formatMusic(data.Music)))
+			}
+			if data.Music.PodcastMinutes+data.Music.AudiobookMinutes > 0 {
+				fmt.Println(ui.RenderDataPoint("🎙️", formatSpoken(data.Music)))
+			}
+			for _, d := range audioDevices {
+				fmt.Println(ui.RenderDataPoint("🎧", formatAudioDevice(d)))
+			}
+		}
 	}
 
-	// Network Activity Section
-	if data.Network.Available {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("NETWORK ACTIVITY"))
+	// Dev Section
+	printers["dev"] = func() {
+		if shown("dev") && showDocker(data) {
+			header("DEV")
 
-		qualifier := ""
-		if data.Network.SinceBoot {
-			qualifier = " (since boot)"
+			fmt.Println(ui.RenderDataPoint("🐳", "Docker: "+formatDocker(data.Docker)))
+			for i, c := range data.Docker.Containers {
+				if i >= 5 {
+					break
+				}
+				fmt.Println(ui.RenderSubItem("   " + formatContainer(c)))
+			}
 		}
-		text := fmt.Sprintf("%s: \"%s\" • %s down / %s up%s",
-			data.Network.InterfaceName,
-			data.Network.NetworkName,
-			collectors.FormatBytes(data.Network.BytesReceived),
-			collectors.FormatBytes(data.Network.BytesSent),
-			qualifier)
-		fmt.Println(ui.RenderDataPoint("🌐", text))
 	}
 
-	// Browser Activity Section (tabs + history + domain breakdown)
-	if data.Browsers.Available && (data.Browsers.TotalTabs > 0 || data.Browsers.TotalURLsVisited > 0) {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("BROWSER ACTIVITY"))
+	// Network Activity Section
+	printers["network"] = func() {
+		showNetwork := shown("network")
+		if showNetwork && (data.Network.Available || data.WiFi.Available || showVPN(data)) {
+			header("NETWORK ACTIVITY")
+		}
 
-		if data.Browsers.TotalURLsVisited > 0 {
-			historyText := fmt.Sprintf("%d URLs visited today", data.Browsers.TotalURLsVisited)
-			if data.Browsers.TopHistoryDomain != "" {
-				historyText += fmt.Sprintf(" • Top: %s (%d visit%s)",
-					data.Browsers.TopHistoryDomain,
-					data.Browsers.TopDomainVisits,
-					pluralize(data.Browsers.TopDomainVisits))
+		if showNetwork && data.Network.Available {
+			qualifier := ""
+			if data.Network.SinceBoot {
+				qualifier = " (since boot)"
 			}
-			fmt.Println(ui.RenderDataPoint("📊", historyText))
+			text := fmt.Sprintf("%s: \"%s\" • %s down / %s up%s",
+				data.Network.InterfaceName,
+				data.Network.NetworkName,
+				collectors.FormatBytes(data.Network.BytesReceived),
+				collectors.FormatBytes(data.Network.BytesSent),
+				qualifier)
+			fmt.Println(ui.RenderDataPoint("🌐", text))
 
-			if len(data.Browsers.AllIssueURLs) > 0 {
-				issueText := fmt.Sprintf("Issues viewed: %s", collectors.FormatIssueURLs(data.Browsers.AllIssueURLs))
-				fmt.Println(ui.RenderDataPoint("🎫", issueText))
+			if data.Network.Metered || data.Network.MeteredBytesReceived > 0 {
+				meteredText := fmt.Sprintf("   Metered: %s down / %s up",
+					collectors.FormatBytes(data.Network.MeteredBytesReceived),
+					collectors.FormatBytes(data.Network.MeteredBytesSent))
+				if data.Network.Metered {
+					meteredText += fmt.Sprintf(" • now on %s", data.Network.MeteredReason)
+				}
+				fmt.Println(ui.RenderSubItem(meteredText))
+			}
+			if apps := data.Network.TopApps; len(apps) > 0 {
+				var parts []string
+				for _, a := range apps {
+					parts = append(parts, fmt.Sprintf("%s %s", a.Name, collectors.FormatBytes(a.Bytes())))
+				}
+				fmt.Println(ui.RenderSubItem("   Top apps: " + strings.Join(parts, " • ")))
+			}
+			if data.Network.MeteredBytesReceived >= cfg.MeteredWarningBytes() {
+				fmt.Println(ui.RenderWarning(fmt.Sprintf("%s downloaded on metered connections today",
+					collectors.FormatBytes(data.Network.MeteredBytesReceived))))
 			}
 		}
 
-		if data.Browsers.TotalTabs > 0 {
-			text := fmt.Sprintf("%d tabs open", data.Browsers.TotalTabs)
-			if data.Browsers.Chrome.Available {
-				text += fmt.Sprintf(" • Chrome: %d", data.Browsers.Chrome.TabCount)
+		if showNetwork && data.WiFi.Available {
+			text := fmt.Sprintf("Wi-Fi %s • avg %d dBm, SNR %d dB, %d Mbps",
+				data.WiFi.Quality, data.WiFi.AvgRSSI, data.WiFi.AvgSNR, data.WiFi.AvgTxRate)
+			fmt.Println(ui.RenderDataPoint("📶", text))
+			if !data.WiFi.WorstPeriodStart.IsZero() {
+				worstText := fmt.Sprintf("   Worst hour: %s (SNR %d dB)",
+					ui.FormatTime(data.WiFi.WorstPeriodStart, cfg.Display.TimeFormat), data.WiFi.WorstPeriodSNR)
+				fmt.Println(ui.RenderSubItem(worstText))
 			}
-			if data.Browsers.Safari.Available {
-				text += fmt.Sprintf(" • Safari: %d", data.Browsers.Safari.TabCount)
+			if len(data.WiFi.Networks) > 0 {
+				fmt.Println(ui.RenderSubItem("   Networks: " + formatWiFiNetworks(data.WiFi)))
+			}
+		}
+
+		if showNetwork && showVPN(data) {
+			fmt.Println(ui.RenderDataPoint("🔐", formatVPN(data.VPN)))
+		}
+	}
+
+	// Browser Activity Section (tabs + history + domain breakdown)
+	printers["browser"] = func() {
+		if shown("browser") && data.Browsers.Available && (data.Browsers.TotalTabs > 0 || data.Browsers.TotalURLsVisited > 0) {
+			header("BROWSER ACTIVITY")
+
+			if data.Browsers.TotalURLsVisited > 0 {
+				historyText := fmt.Sprintf("%d URLs visited %s", data.Browsers.TotalURLsVisited, period)
+				if data.Browsers.TopHistoryDomain != "" {
+					historyText += fmt.Sprintf(" • Top: %s (%d visit%s)",
+						data.Browsers.TopHistoryDomain,
+						data.Browsers.TopDomainVisits,
+						pluralize(data.Browsers.TopDomainVisits))
+				}
+				fmt.Println(ui.RenderDataPoint("📊", historyText))
+
+				if len(data.Browsers.AllIssueURLs) > 0 {
+					issueText := fmt.Sprintf("Issues viewed: %s", collectors.FormatIssueURLs(data.Browsers.AllIssueURLs))
+					fmt.Println(ui.RenderDataPoint("🎫", issueText))
+				}
 			}
-			if data.Browsers.Edge.Available {
-				text += fmt.Sprintf(" • Edge: %d", data.Browsers.Edge.TabCount)
+
+			if data.CloudConsoles.Available {
+				fmt.Println(ui.RenderDataPoint("☁️", "Cloud consoles: "+formatCloudConsoles(data.CloudConsoles)))
+				for _, s := range data.CloudConsoles.LongSessions {
+					fmt.Println(ui.RenderBurnoutWarning("⚠️", longConsoleSessionText(cfg, s)))
+				}
 			}
-			fmt.Println(ui.RenderDataPoint("🌐", text))
 
-			if len(data.Browsers.TopDomains) > 0 {
-				type domainCount struct {
-					domain string
-					count  int
+			if data.Browsers.TotalTabs > 0 {
+				text := fmt.Sprintf("%d tabs open", data.Browsers.TotalTabs)
+				if data.Browsers.Chrome.Available {
+					text += fmt.Sprintf(" • Chrome: %d", data.Browsers.Chrome.TabCount)
+				}
+				if data.Browsers.Safari.Available {
+					text += fmt.Sprintf(" • Safari: %d", data.Browsers.Safari.TabCount)
 				}
-				var domains []domainCount
-				for domain, count := range data.Browsers.TopDomains {
-					domains = append(domains, domainCount{domain, count})
+				if data.Browsers.Edge.Available {
+					text += fmt.Sprintf(" • Edge: %d", data.Browsers.Edge.TabCount)
 				}
-				sort.Slice(domains, func(i, j int) bool {
-					return domains[i].count > domains[j].count
-				})
+				if data.Browsers.Firefox.Available {
+					text += fmt.Sprintf(" • Firefox: %d", data.Browsers.Firefox.TabCount)
+				}
+				fmt.Println(ui.RenderDataPoint("🌐", text))
 
-				fmt.Println(ui.RenderDataPoint("📑", "Top tab domains:"))
-				for i, dc := range domains {
-					if i >= 5 {
-						break
+				if len(data.Browsers.TopDomains) > 0 {
+					fmt.Println(ui.RenderDataPoint("📑", "Top tab domains:"))
+					for _, dc := range topCounts(data.Browsers.TopDomains, 5) {
+						domainText := fmt.Sprintf("   %s (%d tab%s)", dc.name, dc.count, pluralize(dc.count))
+						fmt.Println(ui.RenderSubItem(domainText))
 					}
-					domainText := fmt.Sprintf("   %s (%d tab%s)", dc.domain, dc.count, pluralize(dc.count))
-					fmt.Println(ui.RenderSubItem(domainText))
 				}
 			}
-		}
 
-		// Domain breakdown (work/distraction/neutral)
-		totalCategorized := data.Browsers.WorkVisits + data.Browsers.DistractionVisits + data.Browsers.NeutralVisits
-		if totalCategorized > 0 {
-			workPct := int(float64(data.Browsers.WorkVisits) / float64(totalCategorized) * 100)
-			distractionPct := int(float64(data.Browsers.DistractionVisits) / float64(totalCategorized) * 100)
-			neutralPct := int(float64(data.Browsers.NeutralVisits) / float64(totalCategorized) * 100)
+			// Domain breakdown (work/distraction/neutral)
+			totalCategorized := data.Browsers.WorkVisits + data.Browsers.DistractionVisits + data.Browsers.LearningVisits + data.Browsers.NeutralVisits
+			if totalCategorized > 0 {
+				workPct := int(float64(data.Browsers.WorkVisits) / float64(totalCategorized) * 100)
+				distractionPct := int(float64(data.Browsers.DistractionVisits) / float64(totalCategorized) * 100)
+				learningPct := int(float64(data.Browsers.LearningVisits) / float64(totalCategorized) * 100)
+				neutralPct := int(float64(data.Browsers.NeutralVisits) / float64(totalCategorized) * 100)
 
-			fmt.Println(ui.RenderDataPoint("📊", "Domain breakdown:"))
-			fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Work: %d visits (%d%%)", data.Browsers.WorkVisits, workPct)))
-			fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Distraction: %d visits (%d%%)", data.Browsers.DistractionVisits, distractionPct)))
-			fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Neutral: %d visits (%d%%)", data.Browsers.NeutralVisits, neutralPct)))
+				fmt.Println(ui.RenderDataPoint("📊", "Domain breakdown:"))
+				fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Work: %d visits (%d%%)", data.Browsers.WorkVisits, workPct)))
+				fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Distraction: %d visits (%d%%)", data.Browsers.DistractionVisits, distractionPct)))
+				if data.Browsers.LearningVisits > 0 {
+					fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Learning: %d visits (%d%%)", data.Browsers.LearningVisits, learningPct)))
+				}
+				fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Neutral: %d visits (%d%%)", data.Browsers.NeutralVisits, neutralPct)))
+			}
 		}
 	}
 
 	// Notifications Section
-	if data.Notifications.Available && data.Notifications.TotalNotifications > 0 {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("NOTIFICATIONS"))
+	printers["notifications"] = func() {
+		hasNotifications := data.Notifications.Available && data.Notifications.TotalNotifications > 0
+		if shown("notifications") && (hasNotifications || data.Messages.Available) {
+			header("NOTIFICATIONS")
 
-		text := fmt.Sprintf("%d notification%s today", data.Notifications.TotalNotifications, pluralize(data.Notifications.TotalNotifications))
-		fmt.Println(ui.RenderDataPoint("🔔", text))
+			if hasNotifications {
+				text := fmt.Sprintf("%d notification%s %s", data.Notifications.TotalNotifications, pluralize(data.Notifications.TotalNotifications), period)
+				fmt.Println(ui.RenderDataPoint("🔔", text))
+			}
+			if data.Messages.Available {
+				fmt.Println(ui.RenderDataPoint("💬", formatMessages(data.Messages)))
+			}
 
-		if len(data.Notifications.TopApps) > 0 {
-			fmt.Println(ui.RenderDataPoint("📱", "Top interrupting apps:"))
-			for i, app := range data.Notifications.TopApps {
-				if i >= 3 {
+			if hasNotifications && len(data.Notifications.TopApps) > 0 {
+				fmt.Println(ui.RenderDataPoint("📱", "Top interrupting apps:"))
+				for i, app := range data.Notifications.TopApps {
+					if i >= 3 {
+						break
+					}
+					appText := fmt.Sprintf("   %s (%d notification%s)", app.Name, app.Count, pluralize(app.Count))
+					fmt.Println(ui.RenderSubItem(appText))
+				}
+			}
+		}
+	}
+
+	// Privacy Section
+	printers["privacy"] = func() {
+		if shown("privacy") && showSensors(data) {
+			header("PRIVACY")
+
+			fmt.Println(ui.RenderDataPoint("📷", formatSensors(data.Sensors)))
+			for i, app := range data.Sensors.Apps {
+				if i >= 5 {
 					break
 				}
-				appText := fmt.Sprintf("   %s (%d notification%s)", app.Name, app.Count, pluralize(app.Count))
-				fmt.Println(ui.RenderSubItem(appText))
+				fmt.Println(ui.RenderSubItem("   " + formatSensorApp(app)))
 			}
 		}
 	}
 
 	// Context Fragmentation Section
-	if data.Fragmentation.Available {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("CONTEXT FRAGMENTATION"))
+	printers["fragmentation"] = func() {
+		if data.Fragmentation.Available && shown("fragmentation") {
+			header("CONTEXT FRAGMENTATION")
 
-		text := fmt.Sprintf("%d/100 (%s)", data.Fragmentation.Score, data.Fragmentation.Level)
-		fmt.Println(ui.RenderDataPoint(data.Fragmentation.Emoji, text))
+			text := fmt.Sprintf("%d/100 (%s)", data.Fragmentation.Score, data.Fragmentation.Level)
+			fmt.Println(ui.RenderDataPoint(data.Fragmentation.Emoji, text))
+			if data.Clipboard.Available && data.Clipboard.Changes > 0 {
+				fmt.Println(ui.RenderDataPoint("✂️", formatClipboard(cfg, data.Clipboard)))
+			}
+		}
 	}
 
 	// Issues/Tickets Section
-	if data.Issues.Available && len(data.Issues.Issues) > 0 {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("ISSUES/TICKETS"))
+	printers["issues"] = func() {
+		if shown("issues") && data.Issues.Available && len(data.Issues.Issues) > 0 {
+			header("ISSUES/TICKETS")
 
-		fmt.Println(ui.RenderDataPoint("🎫", "Issues/Tickets viewed today:"))
-		for i, issue := range data.Issues.Issues {
-			if i >= 10 {
-				break
+			fmt.Println(ui.RenderDataPoint("🎫", "Issues/Tickets viewed "+period+":"))
+			for i, issue := range data.Issues.Issues {
+				if i >= 10 {
+					break
+				}
+				issueText := fmt.Sprintf("   %s (%s, %d visit%s)", issue.ID, issue.Tracker, issue.VisitCount, pluralize(issue.VisitCount))
+				fmt.Println(ui.RenderSubItem(issueText))
 			}
-			issueText := fmt.Sprintf("   %s (%s, %d visit%s)", issue.ID, issue.Tracker, issue.VisitCount, pluralize(issue.VisitCount))
-			fmt.Println(ui.RenderSubItem(issueText))
 		}
 	}
 
 	// Burnout Warnings Section
-	if data.Burnout.Available && len(data.Burnout.Warnings) > 0 {
-		fmt.Println()
-		fmt.Println(ui.RenderHeader("WELLNESS CHECK"))
+	printers["wellness"] = func() {
+		hasWarnings := data.Burnout.Available && len(data.Burnout.Warnings) > 0
+		if shown("wellness") && (hasWarnings || data.Daylight.Available || data.WorkHours.Available) {
+			header("WELLNESS CHECK")
 
-		severityOrder := map[string]int{"high": 0, "medium": 1, "low": 2}
-		sortedWarnings := make([]collectors.BurnoutWarning, len(data.Burnout.Warnings))
-		copy(sortedWarnings, data.Burnout.Warnings)
-		sort.Slice(sortedWarnings, func(i, j int) bool {
-			return severityOrder[sortedWarnings[i].Severity] < severityOrder[sortedWarnings[j].Severity]
-		})
+			if data.Daylight.Available {
+				fmt.Println(ui.RenderDataPoint("🌇", formatDaylight(cfg, data.Daylight)))
+			}
+			if data.WorkHours.Available {
+				fmt.Println(ui.RenderDataPoint("🏢", formatWorkHours(data.WorkHours)))
+			}
+			if hasWarnings {
+				for _, warning := range sortedWarnings(data.Burnout.Warnings) {
+					fmt.Println(ui.RenderSeverityWarning(warning.Severity, burnoutIcon(warning.Type), warning.Message))
+				}
+			}
+		}
+	}
 
-		for _, warning := range sortedWarnings {
-			icon := "⚠️"
-			switch warning.Type {
-			case "long_day":
-				icon = "⏰"
-			case "high_switching":
-				icon = "🔄"
-			case "tab_overload":
-				icon = "📑"
-			case "late_night":
-				icon = "🌙"
-			case "no_breaks":
-				icon = "😰"
+	// Notes Section
+	printers["notes"] = func() {
+		if len(data.Notes) > 0 && shown("notes") {
+			header("NOTES")
+			for _, note := range data.Notes {
+				text := fmt.Sprintf("%s • %s", ui.FormatTime(note.Time, cfg.Display.TimeFormat), note.Text)
+				fmt.Println(ui.RenderDataPoint("📝", text))
 			}
-			fmt.Println(ui.RenderBurnoutWarning(icon, warning.Message))
 		}
 	}
 
+	// The default order; custom sections go just before notes
+	keys := []string{
+		"system", "sleep", "productivity", "meetings", "timesheet", "media", "dev", "network",
+		"browser", "notifications", "privacy", "fragmentation", "issues", "wellness",
+	}
+
+	// Custom sections, ordered and snoozed by their config name
+	for _, custom := range data.Custom {
+		keys = append(keys, custom.Name)
+		printers[custom.Name] = func() {
+			if custom.Available && shown(custom.Name) {
+				header(strings.ToUpper(custom.Title))
+				for _, text := range custom.Lines {
+					fmt.Println(ui.RenderDataPoint(custom.Icon, text))
+				}
+			}
+		}
+	}
+
+	keys = append(keys, "notes")
+	for _, key := range cfg.OrderSections(keys) {
+		printers[key]()
+	}
+
 	fmt.Println()
 
 	if !data.Apps.Available && data.Apps.Error != nil {
@@ -416,6 +1003,665 @@ func printHuman(cfg *config.Config, data *SummaryData) {
 	}
 }
 
+// formatTravel describes the day's time zone changes, e.g. "Travel day:
+// PST → EST at 5:05 PM • times shown in EST"
+func formatTravel(cfg *config.Config, tz collectors.TimeZoneResult) string {
+	zones := []string{tz.Changes[0].From}
+	for _, c := range tz.Changes {
+		zones = append(zones, c.To)
+	}
+	last := tz.Changes[len(tz.Changes)-1]
+	return fmt.Sprintf("Travel day: %s at %s • times shown in %s",
+		strings.Join(zones, " → "), ui.FormatTime(last.At, cfg.Display.TimeFormat), last.To)
+}
+
+// formatDaylight describes sunrise and sunset and the screen time after
+// dark, e.g. "Sunrise 7:12 AM • sunset 4:42 PM • 1h 35m of screen time
+// after dark (14%)"
+func formatDaylight(cfg *config.Config, d collectors.DaylightResult) string {
+	var text string
+	switch d.Polar {
+	case collectors.SunAlwaysUp:
+		text = "Midnight sun"
+	case collectors.SunAlwaysDown:
+		text = "Polar night"
+	default:
+		text = fmt.Sprintf("Sunrise %s • sunset %s", ui.FormatTime(d.Sunrise, cfg.Display.TimeFormat), ui.FormatTime(d.Sunset, cfg.Display.TimeFormat))
+	}
+	switch {
+	case d.ScreenMinutes == 0:
+		return text
+	case d.AfterDarkMinutes == 0:
+		return text + " • no screen time after dark"
+	default:
+		return fmt.Sprintf("%s • %s of screen time after dark (%d%%)", text, ui.FormatDuration(d.AfterDarkMinutes), d.AfterDarkPct())
+	}
+}
+
+// formatWorkHours describes the activity outside schedule.work_hours, e.g.
+// "After hours: 1h 40m of 7h 50m screen time outside 09:00-18:00 • 12
+// notifications • 4 distraction visits"
+func formatWorkHours(h collectors.WorkHoursResult) string {
+	text := fmt.Sprintf("After hours: %s of %s screen time outside %s", ui.FormatDuration(h.AfterHoursMinutes), ui.FormatDuration(h.ScreenMinutes), h.Hours)
+	if h.AfterHoursMinutes == 0 {
+		text = "After hours: no screen time outside " + h.Hours.String()
+	}
+	if h.AfterHoursNotifications > 0 {
+		text += fmt.Sprintf(" • %d notification%s", h.AfterHoursNotifications, pluralize(h.AfterHoursNotifications))
+	}
+	if h.AfterHoursDistractionVisits > 0 {
+		text += fmt.Sprintf(" • %d distraction visit%s", h.AfterHoursDistractionVisits, pluralize(h.AfterHoursDistractionVisits))
+	}
+	return text
+}
+
+// mostActivatedApps returns up to n apps by how often they came to the front,
+// leaving out apps with no activations recorded
+func mostActivatedApps(apps []collectors.AppUsage, n int) []collectors.AppUsage {
+	var activated []collectors.AppUsage
+	for _, app := range apps {
+		if app.Activations > 0 {
+			activated = append(activated, app)
+		}
+	}
+	sort.SliceStable(activated, func(i, j int) bool { return activated[i].Activations > activated[j].Activations })
+	if len(activated) > n {
+		activated = activated[:n]
+	}
+	return activated
+}
+
+// formatAppActivations lists how often each app came to the front, e.g.
+// "Slack 47 times • VS Code 12 times • Safari once"
+func formatAppActivations(apps []collectors.AppUsage) string {
+	parts := make([]string, len(apps))
+	for i, app := range apps {
+		if app.Activations == 1 {
+			parts[i] = app.Name + " once"
+		} else {
+			parts[i] = fmt.Sprintf("%s %d times", app.Name, app.Activations)
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatAppSources describes the app time split by install source, e.g.
+// "Apple 1h • App Store 30m • Third-party 2h (1h 40m unsandboxed)"
+func formatAppSources(sources collectors.AppSourcesResult) string {
+	var parts []string
+	if sources.AppleMinutes > 0 {
+		parts = append(parts, "Apple "+ui.FormatDuration(sources.AppleMinutes))
+	}
+	if sources.AppStoreMinutes > 0 {
+		parts = append(parts, "App Store "+ui.FormatDuration(sources.AppStoreMinutes))
+	}
+	if sources.ThirdPartyMinutes > 0 {
+		third := "Third-party " + ui.FormatDuration(sources.ThirdPartyMinutes)
+		if sources.UnsandboxedMinutes > 0 {
+			third += fmt.Sprintf(" (%s unsandboxed)", ui.FormatDuration(sources.UnsandboxedMinutes))
+		}
+		parts = append(parts, third)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// showAppCategories reports whether the category split is worth a line:
+// everything in one category says nothing the app list doesn't
+func showAppCategories(data *SummaryData) bool {
+	return data.AppCategories.Available && len(data.AppCategories.Categories) > 1
+}
+
+// formatAppCategories lists up to n categories by share of app time, e.g.
+// "Productivity & Finance 62% • Social 21% • Entertainment 9%"
+func formatAppCategories(categories collectors.AppCategoriesResult, n int) string {
+	var parts []string
+	for i, c := range categories.Categories {
+		if i >= n {
+			break
+		}
+		pct := 0
+		if categories.TotalMinutes > 0 {
+			pct = c.Minutes * 100 / categories.TotalMinutes
+		}
+		parts = append(parts, fmt.Sprintf("%s %d%%", c.Name, pct))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// appCategoryMinutes returns each category's minutes, for the stacked bar
+func appCategoryMinutes(categories collectors.AppCategoriesResult) []int {
+	minutes := make([]int, len(categories.Categories))
+	for i, c := range categories.Categories {
+		minutes[i] = c.Minutes
+	}
+	return minutes
+}
+
+// formatSleeps describes the sleeps in the window, e.g.
+// "2 sleeps • 1h 5m asleep" or "No sleep since waking"
+func formatSleeps(s collectors.SleepWakeResult) string {
+	if s.Sleeps == 0 {
+		if s.FirstWake.IsZero() {
+			return "No sleep"
+		}
+		return "No sleep since waking"
+	}
+	return fmt.Sprintf("%d sleep%s • %s asleep", s.Sleeps, pluralize(s.Sleeps), ui.FormatDuration(s.SleepMinutes))
+}
+
+// showSpaces reports whether per-Space time is worth a line: a single
+// Space just repeats the screen time
+func showSpaces(data *SummaryData) bool {
+	return data.Spaces.Available && len(data.Spaces.Spaces) > 1
+}
+
+// formatSpaces lists up to n Spaces as "Client A 2h 10m • Desktop 1 45m"
+func formatSpaces(spaces []collectors.SpaceUsage, n int) string {
+	var parts []string
+	for i, space := range spaces {
+		if i >= n {
+			break
+		}
+		parts = append(parts, space.Name+" "+ui.FormatDuration(space.Minutes))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatSSHHosts lists up to n remote hosts as "prod-db 1h 5m • dev-box 20m (open)"
+func formatSSHHosts(hosts []collectors.SSHHost, n int) string {
+	var parts []string
+	for i, host := range hosts {
+		if i >= n {
+			break
+		}
+		part := host.Host + " " + ui.FormatDuration(host.Minutes)
+		if host.Active {
+			part += " (open)"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatMemory describes peak memory pressure and swap, e.g. "Memory
+// pressure peaked at warn (88% used) • 2.0 GB swap"
+func formatMemory(m collectors.MemoryResult) string {
+	text := fmt.Sprintf("Memory pressure peaked at %s", m.PeakPressure)
+	if m.PeakUsedPct > 0 {
+		text += fmt.Sprintf(" (%d%% used)", m.PeakUsedPct)
+	}
+	if m.SwapUsedBytes > 0 {
+		text += fmt.Sprintf(" • %s swap", collectors.FormatBytes(m.SwapUsedBytes))
+	}
+	return text
+}
+
+// formatCPU describes peak load and thermal throttling, e.g. "Load peaked
+// at 12.0 on 8 cores • throttled 2 times for 45m, down to 60% speed"
+func formatCPU(c collectors.CPUResult) string {
+	var parts []string
+	if c.Samples > 0 {
+		load := "Load peaked at " + locale.Current().FormatFloat(c.PeakLoad, 1)
+		if c.Cores > 0 {
+			load += fmt.Sprintf(" on %d cores", c.Cores)
+		}
+		parts = append(parts, load)
+	}
+	if n := len(c.Throttles); n > 0 {
+		parts = append(parts, fmt.Sprintf("throttled %d time%s for %s, down to %d%% speed",
+			n, pluralize(n), ui.FormatDuration(c.ThrottledMinutes), c.MinSpeedLimit))
+	} else {
+		parts = append(parts, "no thermal throttling")
+	}
+	text := strings.Join(parts, " • ")
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+// showMusic reports whether any music, podcasts, or audiobooks were played
+func showMusic(m collectors.MusicResult) bool {
+	return m.Available && m.Minutes+m.PodcastMinutes+m.AudiobookMinutes > 0
+}
+
+// formatMusic describes the day's listening, e.g.
+// "2h 18m of music • 34 tracks • top artist: The Weeknd"
+func formatMusic(m collectors.MusicResult) string {
+	text := fmt.Sprintf("%s of music • %d track%s", ui.FormatDuration(m.Minutes), m.Tracks, pluralize(m.Tracks))
+	if len(m.Artists) > 0 {
+		text += " • top artist: " + m.Artists[0].Name
+	}
+	return text
+}
+
+// formatSpoken describes podcast and audiobook listening, e.g.
+// "1h 5m of podcasts (3 episodes) • 45m of audiobooks"
+func formatSpoken(m collectors.MusicResult) string {
+	var parts []string
+	if m.PodcastMinutes > 0 {
+		parts = append(parts, fmt.Sprintf("%s of podcasts (%d episode%s)", ui.FormatDuration(m.PodcastMinutes), m.Episodes, pluralize(m.Episodes)))
+	}
+	if m.AudiobookMinutes > 0 {
+		parts = append(parts, ui.FormatDuration(m.AudiobookMinutes)+" of audiobooks")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatAudioDevice describes a Bluetooth audio device's time, e.g.
+// "AirPods Pro • 3h 40m • connected"
+func formatAudioDevice(d collectors.AudioDevice) string {
+	text := d.Name + " • " + ui.FormatDuration(d.Minutes)
+	if d.Connected {
+		text += " • connected"
+	}
+	return text
+}
+
+// showVPN reports whether there's VPN use worth a line: time connected in
+// the window, or a connection now
+func showVPN(data *SummaryData) bool {
+	return data.VPN.Available && (data.VPN.Minutes > 0 || data.VPN.Connected)
+}
+
+// formatVPN describes VPN time, e.g. "VPN 3h 20m • connected now (GlobalProtect)"
+func formatVPN(vpn collectors.VPNResult) string {
+	text := "VPN " + ui.FormatDuration(vpn.Minutes)
+	switch {
+	case vpn.Connected:
+		text += " • connected now"
+	case vpn.Name != "":
+		text += " • not connected"
+	}
+	if vpn.Name != "" {
+		text += " (" + vpn.Name + ")"
+	}
+	return text
+}
+
+// formatWiFiNetworks lists time per Wi-Fi network and the switches between
+// them, e.g. "Office 5h 40m • Blue Bottle 1h 10m (switched 3 times)"
+func formatWiFiNetworks(wifi collectors.WiFiResult) string {
+	var parts []string
+	for _, n := range wifi.Networks {
+		parts = append(parts, n.SSID+" "+ui.FormatDuration(n.Minutes))
+	}
+	text := strings.Join(parts, " • ")
+	if wifi.Switches > 0 {
+		text += fmt.Sprintf(" (switched %d time%s)", wifi.Switches, pluralize(wifi.Switches))
+	}
+	return text
+}
+
+// formatBrewChanges describes Homebrew changes with the first n packages,
+// e.g. "1 installed, 2 upgraded (node 21.7.3 → 22.1.0, ripgrep 14.1.0, +1 more)"
+func formatBrewChanges(u collectors.UpdatesResult, n int) string {
+	var counts []string
+	if u.Installed > 0 {
+		counts = append(counts, fmt.Sprintf("%d installed", u.Installed))
+	}
+	if u.Upgraded > 0 {
+		counts = append(counts, fmt.Sprintf("%d upgraded", u.Upgraded))
+	}
+	var names []string
+	for i, c := range u.Brew {
+		if i >= n {
+			names = append(names, fmt.Sprintf("+%d more", len(u.Brew)-n))
+			break
+		}
+		if c.From != "" {
+			names = append(names, fmt.Sprintf("%s %s → %s", c.Name, c.From, c.Version))
+		} else {
+			names = append(names, c.Name+" "+c.Version)
+		}
+	}
+	return strings.Join(counts, ", ") + " (" + strings.Join(names, ", ") + ")"
+}
+
+// formatPendingUpdates lists pending macOS updates, e.g.
+// "2 macOS updates pending: macOS Sequoia 15.1, Safari 18.1"
+func formatPendingUpdates(pending []collectors.SoftwareUpdate) string {
+	names := make([]string, len(pending))
+	for i, u := range pending {
+		names[i] = u.Name
+		if u.Version != "" && !strings.Contains(u.Name, u.Version) {
+			names[i] += " " + u.Version
+		}
+	}
+	return fmt.Sprintf("%d macOS update%s pending: %s", len(pending), pluralize(len(pending)), strings.Join(names, ", "))
+}
+
+// showScreenshots reports whether any screen captures were saved
+func showScreenshots(s collectors.ScreenshotsResult) bool {
+	return s.Available && s.Screenshots+s.Recordings > 0
+}
+
+// formatScreenshots describes the screen captures saved, e.g.
+// "7 screenshots • 1 screen recording"
+func formatScreenshots(s collectors.ScreenshotsResult) string {
+	var parts []string
+	if s.Screenshots > 0 {
+		parts = append(parts, fmt.Sprintf("%d screenshot%s", s.Screenshots, pluralize(s.Screenshots)))
+	}
+	if s.Recordings > 0 {
+		parts = append(parts, fmt.Sprintf("%d screen recording%s", s.Recordings, pluralize(s.Recordings)))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatBatteryUsage describes time on battery and plugged in and the
+// energy used, e.g. "4h 10m on battery • 2h 50m plugged in • ~18.4 Wh used"
+func formatBatteryUsage(b collectors.BatteryResult) string {
+	var parts []string
+	if b.BatteryMinutes > 0 {
+		parts = append(parts, ui.FormatDuration(b.BatteryMinutes)+" on battery")
+	}
+	if b.PluggedMinutes > 0 {
+		parts = append(parts, ui.FormatDuration(b.PluggedMinutes)+" plugged in")
+	}
+	if b.UsedWh >= 0.1 {
+		parts = append(parts, "~"+locale.Current().FormatFloat(b.UsedWh, 1)+" Wh used")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatPeripherals lists other batteries' levels, flagging low ones, e.g.
+// "Magic Keyboard 85% • Magic Trackpad 12% (low) • UPS CP1500 100%"
+func formatPeripherals(peripherals []collectors.PeripheralBattery) string {
+	parts := make([]string, len(peripherals))
+	for i, p := range peripherals {
+		name := p.Name
+		if p.Kind == collectors.PeripheralUPS {
+			name = "UPS " + name
+		}
+		parts[i] = fmt.Sprintf("%s %d%%", name, p.Pct)
+		if p.Pct <= collectors.PeripheralLowPct {
+			parts[i] += " (low)"
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// hasBatteryCurve reports whether there are levels for at least two hours
+func hasBatteryCurve(b collectors.BatteryResult) bool {
+	hours := 0
+	for _, pct := range b.HourlyPct {
+		if pct >= 0 {
+			hours++
+		}
+	}
+	return hours >= 2
+}
+
+// formatBatteryHealth describes battery wear, e.g. "Health 87% (Normal) • 412 cycles"
+func formatBatteryHealth(b collectors.BatteryResult) string {
+	text := fmt.Sprintf("Health %d%%", b.HealthPct)
+	if b.Condition != "" {
+		text += " (" + b.Condition + ")"
+	}
+	return text + fmt.Sprintf(" • %d cycle%s", b.CycleCount, pluralize(b.CycleCount))
+}
+
+// formatDownloads describes the day's downloads with the biggest types,
+// e.g. "3 files downloaded • 4.2 GB (dmg 4.1 GB, zip 80.0 MB)"
+func formatDownloads(d collectors.DownloadsResult) string {
+	text := fmt.Sprintf("%d file%s downloaded • %s", d.Count, pluralize(d.Count), collectors.FormatBytes(d.TotalBytes))
+	var types []string
+	for i, t := range d.TopTypes {
+		if i >= 3 {
+			break
+		}
+		types = append(types, t.Type+" "+collectors.FormatBytes(t.Bytes))
+	}
+	if len(types) > 0 {
+		text += " (" + strings.Join(types, ", ") + ")"
+	}
+	return text
+}
+
+// formatMessages describes message volume, with each service's share when
+// there's more than one
+func formatMessages(m collectors.MessagesResult) string {
+	text := fmt.Sprintf("%d message%s sent • %d received", m.Sent, pluralize(m.Sent), m.Received)
+	if len(m.Services) > 1 {
+		parts := make([]string, len(m.Services))
+		for i, s := range m.Services {
+			parts[i] = fmt.Sprintf("%s %d/%d", s.Service, s.Sent, s.Received)
+		}
+		text += " (" + strings.Join(parts, ", ") + ")"
+	}
+	return text
+}
+
+// showSensors reports whether any app used the camera or microphone
+func showSensors(data *SummaryData) bool {
+	return data.Sensors.Available && len(data.Sensors.Apps) > 0
+}
+
+// formatSensors describes camera and mic time, e.g.
+// "Camera on 1h 0m (2 sessions) • mic on 1h 25m"
+func formatSensors(s collectors.SensorsResult) string {
+	return fmt.Sprintf("Camera on %s (%d session%s) • mic on %s",
+		ui.FormatDuration(s.CameraMinutes), s.CameraSessions, pluralize(s.CameraSessions), ui.FormatDuration(s.MicMinutes))
+}
+
+// formatSensorApp describes one app's sensor use, e.g.
+// "zoom.us: camera 30m, mic 45m"
+func formatSensorApp(app collectors.SensorApp) string {
+	var parts []string
+	if app.CameraMinutes > 0 {
+		parts = append(parts, "camera "+ui.FormatDuration(app.CameraMinutes))
+	}
+	if app.MicMinutes > 0 || app.CameraMinutes == 0 {
+		parts = append(parts, "mic "+ui.FormatDuration(app.MicMinutes))
+	}
+	return app.Name + ": " + strings.Join(parts, ", ")
+}
+
+// formatClipboard describes clipboard use, e.g.
+// "86 clipboard changes • 11.5/hr • busiest 2:00 PM"
+func formatClipboard(cfg *config.Config, c collectors.ClipboardResult) string {
+	return fmt.Sprintf("%d clipboard change%s • %s/hr • busiest %s",
+		c.Changes, pluralize(c.Changes), locale.Current().FormatFloat(c.ChangesPerHour, 1), formatHour(cfg, c.BusiestHour))
+}
+
+// showReminders reports whether any reminders were completed
+func showReminders(r collectors.RemindersResult) bool {
+	return r.Available && r.Completed > 0
+}
+
+// formatReminders describes the reminders completed, with the first n
+// lists when there's more than one, e.g. "7 reminders completed (Work 5, Home 2)"
+func formatReminders(r collectors.RemindersResult, n int) string {
+	text := fmt.Sprintf("%d reminder%s completed", r.Completed, pluralize(r.Completed))
+	if len(r.Lists) > 1 {
+		var lists []string
+		for i, l := range r.Lists {
+			if i >= n {
+				break
+			}
+			lists = append(lists, fmt.Sprintf("%s %d", l.Name, l.Completed))
+		}
+		text += " (" + strings.Join(lists, ", ") + ")"
+	}
+	return text
+}
+
+// formatBuilds describes builds and the time spent on them, e.g.
+// "34 builds • 48m waiting on builds (2 failed)"
+func formatBuilds(b collectors.BuildsResult) string {
+	text := fmt.Sprintf("%d build%s • %s waiting on builds", b.Builds, pluralize(b.Builds), ui.FormatDuration(b.WaitMinutes))
+	if b.Failed > 0 {
+		text += fmt.Sprintf(" (%d failed)", b.Failed)
+	}
+	return text
+}
+
+// formatBuildTools lists the top n build tools, e.g. "Xcode 12 (30m) • go 20 (15m)"
+func formatBuildTools(tools []collectors.BuildTool, n int) string {
+	var parts []string
+	for i, t := range tools {
+		if i >= n {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %d (%s)", t.Tool, t.Builds, ui.FormatDuration(t.Minutes)))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// showDocker reports whether Docker had containers worth a section: started
+// in the window or running now
+func showDocker(data *SummaryData) bool {
+	return data.Docker.Available && (data.Docker.Started > 0 || data.Docker.Running > 0)
+}
+
+// formatDocker summarizes containers, e.g.
+// "5 containers started • 3 running now • 12.8% CPU, 1.2 GB memory"
+func formatDocker(d collectors.DockerResult) string {
+	parts := []string{fmt.Sprintf("%d container%s started", d.Started, pluralize(d.Started))}
+	if d.Running > 0 {
+		parts = append(parts, fmt.Sprintf("%d running now", d.Running))
+	}
+	if d.MemBytes > 0 {
+		parts = append(parts, fmt.Sprintf("%s%% CPU, %s memory", locale.Current().FormatFloat(d.CPUPct, 1), collectors.FormatBytes(d.MemBytes)))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatContainer describes one container, e.g. "db (postgres:16) 8h 0m, running"
+func formatContainer(c collectors.Container) string {
+	text := fmt.Sprintf("%s (%s) %s", c.Name, c.Image, ui.FormatDuration(c.Minutes))
+	if c.Running {
+		text += ", running"
+	}
+	return text
+}
+
+// formatShell describes shell use with the top n programs, as
+// "142 commands • git 48, go 21, make 9 • busiest 2:00 PM"
+func formatShell(cfg *config.Config, shell collectors.ShellResult, n int) string {
+	var top []string
+	for i, c := range shell.TopCommands {
+		if i >= n {
+			break
+		}
+		top = append(top, fmt.Sprintf("%s %d", c.Name, c.Count))
+	}
+	return fmt.Sprintf("%d command%s • %s • busiest %s",
+		shell.Commands, pluralize(shell.Commands), strings.Join(top, ", "), formatHour(cfg, shell.BusiestHour))
+}
+
+// formatHour shows an hour of the day in the configured time format
+func formatHour(cfg *config.Config, hour int) string {
+	return ui.FormatTime(time.Date(2000, 1, 1, hour, 0, 0, 0, time.Local), cfg.Display.TimeFormat)
+}
+
+// awakeConfidenceText explains an awake time that was corrected or couldn't
+// be checked, or returns "" when the screen data backs it up
+func awakeConfidenceText(uptime collectors.UptimeResult) string {
+	var parts []string
+	if uptime.IdleAwakeMinutes > 0 {
+		parts = append(parts, fmt.Sprintf("Excludes %s awake with the display off or no input", ui.FormatDuration(uptime.IdleAwakeMinutes)))
+	}
+	switch uptime.Confidence {
+	case collectors.AwakeConfidenceMedium:
+		parts = append(parts, "medium confidence")
+	case collectors.AwakeConfidenceLow:
+		parts = append(parts, "low confidence: no screen data to check against")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatLearning describes learning time and where it was spent, e.g.
+// "1h 5m (20m in apps, 45m in the browser)"
+func formatLearning(learning collectors.LearningResult) string {
+	text := ui.FormatDuration(learning.Minutes)
+	if learning.AppMinutes > 0 && learning.BrowserMinutes > 0 {
+		text += fmt.Sprintf(" (%s in apps, %s in the browser)",
+			ui.FormatDuration(learning.AppMinutes), ui.FormatDuration(learning.BrowserMinutes))
+	}
+	return text
+}
+
+// formatCloudConsoles lists console time per provider, e.g. "AWS 1h 40m • Azure 15m"
+func formatCloudConsoles(consoles collectors.CloudConsolesResult) string {
+	var parts []string
+	for _, p := range consoles.Providers {
+		parts = append(parts, p.Name+" "+ui.FormatDuration(p.Minutes))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// longConsoleSessionText describes a flagged console session
+func longConsoleSessionText(cfg *config.Config, s collectors.CloudSession) string {
+	return fmt.Sprintf("Long %s console session: %s (%s – %s)", collectors.CloudProviderNames[s.Provider],
+		ui.FormatDuration(s.Minutes()), ui.FormatTime(s.Start, cfg.Display.TimeFormat), ui.FormatTime(s.End, cfg.Display.TimeFormat))
+}
+
+// summaryPeriod names the span the summary covers, e.g. "today" or "since 6:00 AM"
+func summaryPeriod(cfg *config.Config, data *SummaryData) string {
+	w := data.Window
+	return ui.FormatPeriod(w.Start, w.End, w.WholeDay(), w.Live(), cfg.Display.TimeFormat)
+}
+
+// summaryTitle is the heading for a summary covering period
+func summaryTitle(period string) string {
+	if period == "today" {
+		return "📊 Today's rekap"
+	}
+	return "📊 rekap " + period
+}
+
+// summaryLineParts returns the pieces of the one-line summary shown above
+// the sections: screen time and the top 3 apps
+func summaryLineParts(data *SummaryData) []string {
+	var parts []string
+
+	if data.Screen.Available {
+		parts = append(parts, ui.FormatDuration(data.Screen.ScreenOnMinutes)+" screen-on")
+	}
+
+	if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+		var appList []string
+		for i, app := range data.Apps.TopApps {
+			if i >= 3 {
+				break
+			}
+			appList = append(appList, fmt.Sprintf("%s (%s)", app.Name, ui.FormatDurationCompact(app.Minutes)))
+		}
+		parts = append(parts, "Top apps: "+strings.Join(appList, ", "))
+	}
+
+	return parts
+}
+
+// sortedWarnings returns a copy of warnings, most severe first
+func sortedWarnings(warnings []collectors.BurnoutWarning) []collectors.BurnoutWarning {
+	severityOrder := map[string]int{"high": 0, "medium": 1, "low": 2}
+	sorted := make([]collectors.BurnoutWarning, len(warnings))
+	copy(sorted, warnings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityOrder[sorted[i].Severity] < severityOrder[sorted[j].Severity]
+	})
+	return sorted
+}
+
+// burnoutIcon returns the emoji for a burnout warning type
+func burnoutIcon(warningType string) string {
+	switch warningType {
+	case "long_day":
+		return "⏰"
+	case "high_switching":
+		return "🔄"
+	case "tab_overload":
+		return "📑"
+	case "late_night":
+		return "🌙"
+	case "no_breaks":
+		return "😰"
+	case "after_hours":
+		return "🌆"
+	}
+	return "⚠️"
+}
+
 func pluralize(count int) string {
 	if count == 1 {
 		return ""
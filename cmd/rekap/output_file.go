@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Levels accepted by --log-level
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// writeOutputFile runs render with stdout captured and writes what it
+// printed to path, without color escapes, replacing the file or adding to
+// its end with appendTo
+func writeOutputFile(path string, appendTo bool, render func() error) error {
+	var renderErr error
+	text, err := captureStdout(func() { renderErr = render() })
+	if err != nil {
+		return err
+	}
+	if renderErr != nil {
+		return renderErr
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendTo {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open --output file: %w", err)
+	}
+	if _, err := io.WriteString(f, ansi.Strip(text)); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(fn func()) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		out <- string(b)
+	}()
+
+	fn()
+	os.Stdout = stdout
+	w.Close()
+	return <-out, nil
+}
+
+// openLogFile returns a logger appending to path at level, and a function
+// closing it. Without a path the logger discards everything.
+func openLogFile(path, level string) (*slog.Logger, func(), error) {
+	if path == "" {
+		return slog.New(slog.DiscardHandler), func() {}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open --log-file: %w", err)
+	}
+	handler := slog.NewTextHandler(f, &slog.HandlerOptions{Level: logLevels[level]})
+	return slog.New(handler), func() { f.Close() }, nil
+}
+
+// logCollectors logs each collector's result: failures as warnings, and
+// at debug level which collectors had data. Results are found by shape,
+// any field of data with Available and Error fields, so new collectors
+// are logged without being listed here.
+func logCollectors(logger *slog.Logger, data *SummaryData) {
+	v := reflect.ValueOf(data).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Struct {
+			continue
+		}
+		available := field.FieldByName("Available")
+		errField := field.FieldByName("Error")
+		if available.Kind() != reflect.Bool || !errField.IsValid() {
+			continue
+		}
+
+		name := snakeCase(v.Type().Field(i).Name)
+		if err, _ := errField.Interface().(error); err != nil {
+			logger.Warn("collector failed", "collector", name, "err", err)
+		} else {
+			logger.Debug("collector finished", "collector", name, "available", available.Bool())
+		}
+	}
+}
+
+// snakeCase turns a Go field name like AppSources into app_sources
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 && !unicode.IsUpper(rune(name[i-1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
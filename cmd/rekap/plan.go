@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/plan"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// factorNames describe fragmentation factors in headings
+var factorNames = map[string]string{
+	collectors.FactorApps:     "apps in use",
+	collectors.FactorTabs:     "open tabs",
+	collectors.FactorDomains:  "sites open",
+	collectors.FactorSwitches: "app switching",
+}
+
+func newPlanCmd() *cobra.Command {
+	var apply bool
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Ranked actions to reduce today's fragmentation",
+		Long: `Break today's fragmentation score into its factors (apps, open tabs, sites,
+and app switching) and list concrete actions for each, the factor adding the
+most points first.
+
+With --apply, rekap offers to carry out the safe ones itself, asking before
+each: closing duplicate tabs (one copy of every page stays open) and hiding
+apps you barely used (Cmd-Tab brings them back). It never quits apps or
+closes a page you don't have open twice.`,
+		Example: `  rekap plan
+  rekap plan --apply`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			data := collectSummary(cfg, collectors.Today(time.Now()))
+			if !data.Fragmentation.Available {
+				return fmt.Errorf("no app or browser data to score today\nRun 'rekap init' for setup")
+			}
+
+			actions := plan.Build(&data)
+			printPlan(data.Fragmentation, actions, apply)
+			if apply {
+				applyPlan(actions, os.Stdin)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&apply, "apply", false, "Offer to carry out the safe actions, asking before each")
+	return cmd
+}
+
+func printPlan(frag collectors.FragmentationResult, actions []plan.Action, apply bool) {
+	title := ui.RenderTitle(fmt.Sprintf("🧭 rekap plan • fragmentation %d/100 (%s)", frag.Score, frag.Level), ui.IsTTY())
+	if title != "" {
+		fmt.Println(title)
+	}
+	fmt.Println()
+
+	if len(actions) == 0 {
+		fmt.Println(ui.RenderSuccess("Nothing adds to your score today -- keep it up"))
+		return
+	}
+
+	fixes := 0
+	fmt.Println(ui.RenderHeader("ACTIONS"))
+	for i, a := range actions {
+		text := fmt.Sprintf("%d. %s", i+1, a.Text)
+		if i == 0 {
+			fmt.Println(ui.RenderHighlight("👉", text))
+		} else {
+			fmt.Println(ui.RenderDataPoint("👉", text))
+		}
+		detail := fmt.Sprintf("%s • +%.0f points", factorNames[a.Factor], a.Points)
+		if a.Fix != nil {
+			detail += " • rekap can help with --apply"
+			fixes++
+		}
+		fmt.Println(ui.RenderSubItem(detail))
+	}
+
+	if fixes > 0 && !apply {
+		fmt.Println()
+		fmt.Println(ui.RenderHint(fmt.Sprintf("Run 'rekap plan --apply' to have rekap take %d of these for you", fixes)))
+	}
+}
+
+// applyPlan asks before running each action's fix, stopping when input ends
+func applyPlan(actions []plan.Action, in io.Reader) {
+	answers := bufio.NewScanner(in)
+	for _, a := range actions {
+		if a.Fix == nil {
+			continue
+		}
+		fmt.Printf("\n%s [y/N] ", a.Fix.Prompt)
+		if !answers.Scan() {
+			fmt.Println()
+			return
+		}
+		if answer := strings.ToLower(strings.TrimSpace(answers.Text())); answer != "y" && answer != "yes" {
+			continue
+		}
+		result, err := a.Fix.Run()
+		if err != nil {
+			fmt.Println(ui.RenderWarning(err.Error()))
+			continue
+		}
+		fmt.Println(ui.RenderSuccess(result))
+	}
+}
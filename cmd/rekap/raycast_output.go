@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// raycastOutput is the list a Raycast script command or extension renders
+// as-is: one item per summary section
+type raycastOutput struct {
+	Items []raycastItem `json:"items"`
+}
+
+// raycastItem mirrors the props of Raycast's List.Item
+type raycastItem struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Subtitle    string             `json:"subtitle,omitempty"`
+	Icon        string             `json:"icon"`
+	Accessories []raycastAccessory `json:"accessories,omitempty"`
+}
+
+type raycastAccessory struct {
+	Text string `json:"text"`
+}
+
+// printRaycast prints the summary as a Raycast list. Like JSON, it ignores
+// snooze rules.
+func printRaycast(cfg *config.Config, data *SummaryData) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(raycastOutput{Items: raycastItems(cfg, data)}); err != nil {
+		fmt.Fprintf(os.Stderr, "rekap: json encode error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// raycastItems returns a title, a short detail, and the headline number
+// for each available section. Items are never nil so the list is always
+// valid JSON for Raycast.
+func raycastItems(cfg *config.Config, data *SummaryData) []raycastItem {
+	items := []raycastItem{}
+	add := func(id, icon, title, subtitle, accessory string) {
+		item := raycastItem{ID: id, Title: title, Subtitle: subtitle, Icon: icon}
+		if accessory != "" {
+			item.Accessories = []raycastAccessory{{Text: accessory}}
+		}
+		items = append(items, item)
+	}
+
+	if data.Uptime.Available {
+		add("awake", "⏰", "Awake", "Active since "+ui.FormatTime(data.Uptime.BootTime, cfg.Display.TimeFormat), data.Uptime.FormattedTime)
+	}
+	if b := data.DayBounds; b.Available {
+		add("day", "🌅", "Workday", ui.FormatDayBounds(b.Arrival, b.WrapUp, b.Ongoing, b.SpanMinutes, cfg.Display.TimeFormat), ui.FormatDuration(b.SpanMinutes))
+	}
+	if data.Screen.Available {
+		subtitle := fmt.Sprintf("Locked %d time%s", data.Screen.LockCount, pluralize(data.Screen.LockCount))
+		if data.Screen.Source == collectors.ScreenSourceEstimate {
+			subtitle = "Estimated from the time of day"
+		}
+		add("screen", "🔒", "Screen on", subtitle, ui.FormatDuration(data.Screen.ScreenOnMinutes))
+	}
+	if data.Battery.Available && cfg.ShouldShowBattery() {
+		status := "on battery"
+		if data.Battery.IsPlugged {
+			status = "plugged in"
+		}
+		add("battery", "🔋", "Battery", fmt.Sprintf("Started at %d%%, %s", data.Battery.StartPct, status), fmt.Sprintf("%d%%", data.Battery.CurrentPct))
+	}
+	if data.Focus.Available {
+		add("focus", "⏱️", "Best focus", data.Focus.AppName, ui.FormatDuration(data.Focus.StreakMinutes))
+	}
+	if data.Meetings.Available {
+		subtitle := "No meetings"
+		if data.Meetings.Count > 0 {
+			subtitle = fmt.Sprintf("%d meeting%s", data.Meetings.Count, pluralize(data.Meetings.Count))
+		}
+		if data.Meetings.LongestFreeMinutes > 0 {
+			subtitle += ", longest free block " + ui.FormatDuration(data.Meetings.LongestFreeMinutes)
+		}
+		add("meetings", "📅", "Meetings", subtitle, ui.FormatDuration(data.Meetings.TotalMinutes))
+	}
+	if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+		var names []string
+		total := 0
+		for i, app := range data.Apps.TopApps {
+			if i < 3 {
+				names = append(names, app.Name)
+			}
+			total += app.Minutes
+		}
+		add("apps", "📱", "Top apps", strings.Join(names, ", "), ui.FormatDuration(total))
+	}
+	if data.Fragmentation.Available {
+		add("fragmentation", data.Fragmentation.Emoji, "Fragmentation", data.Fragmentation.Level, fmt.Sprintf("%d/100", data.Fragmentation.Score))
+	}
+	if data.Learning.Available && data.Learning.Minutes > 0 {
+		add("learning", "📚", "Learning", fmt.Sprintf("%d visit%s", data.Learning.Visits, pluralize(data.Learning.Visits)), ui.FormatDuration(data.Learning.Minutes))
+	}
+	if data.Browsers.Available {
+		subtitle := ""
+		if top := topCounts(data.Browsers.TopDomains, 3); len(top) > 0 {
+			var domains []string
+			for _, d := range top {
+				domains = append(domains, d.name)
+			}
+			subtitle = strings.Join(domains, ", ")
+		}
+		add("browser", "🌐", "Browser", subtitle, fmt.Sprintf("%d tab%s", data.Browsers.TotalTabs, pluralize(data.Browsers.TotalTabs)))
+	}
+	if data.Issues.Available && len(data.Issues.Issues) > 0 {
+		var ids []string
+		for i, issue := range data.Issues.Issues {
+			if i >= 3 {
+				break
+			}
+			ids = append(ids, issue.ID)
+		}
+		add("issues", "🎫", "Issues", strings.Join(ids, ", "), fmt.Sprintf("%d", len(data.Issues.Issues)))
+	}
+	if data.Network.Available {
+		add("network", "📶", "Network", data.Network.NetworkName,
+			fmt.Sprintf("↓%s ↑%s", collectors.FormatBytes(data.Network.BytesReceived), collectors.FormatBytes(data.Network.BytesSent)))
+	}
+	if data.Notifications.Available {
+		subtitle := ""
+		if len(data.Notifications.TopApps) > 0 {
+			subtitle = "Most from " + data.Notifications.TopApps[0].Name
+		}
+		add("notifications", "🔔", "Notifications", subtitle, fmt.Sprintf("%d", data.Notifications.TotalNotifications))
+	}
+	if data.Media.Available {
+		add("media", "🎵", "Now playing", data.Media.App, data.Media.Track)
+	}
+	for _, custom := range data.Custom {
+		if custom.Available {
+			add("custom-"+strings.ToLower(custom.Name), custom.Icon, custom.Title, strings.Join(custom.Lines[1:], " • "), custom.Lines[0])
+		}
+	}
+	if n := len(data.Notes); n > 0 {
+		add("notes", "📝", "Notes", data.Notes[n-1].Text, fmt.Sprintf("%d", n))
+	}
+	return items
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/golden"
+)
+
+func TestMain(m *testing.M) {
+	// Fixtures are built in local time; pin it so golden files match anywhere
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// TestRenderGolden renders every fixture through every output path and
+// compares against testdata/golden. Run with -update after an intended
+// output change.
+func TestRenderGolden(t *testing.T) {
+	cfg := config.Default()
+	for _, name := range fixtureNames {
+		data, err := buildFixture(cfg, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, format := range renderFormats {
+			out, err := renderFixture(cfg, &data, format)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", name, format, err)
+			}
+			golden.Assert(t, name+"."+format, out)
+		}
+	}
+}
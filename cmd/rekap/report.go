@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newReportCmd() *cobra.Command {
+	var pdfPath string
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Weekly report from recorded history",
+		Long: `Summarize this week from the snapshots rekap records on each run. Weeks
+start on your locale's first day (display.locale), Sunday or Monday for most.
+Use --pdf to write a paginated, print-friendly PDF instead.`,
+		Example: `  rekap report
+  rekap report --pdf week.pdf`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			week, err := loadWeek(thisWeekEnd(), cfg)
+			if err != nil {
+				return err
+			}
+
+			if pdfPath != "" {
+				return writeReportPDF(pdfPath, week)
+			}
+			printWeeklyReport(week)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&pdfPath, "pdf", "", "Write the report as a PDF to this path")
+	return cmd
+}
+
+// thisWeekEnd returns the last day of the current week, which starts on the
+// locale's first day
+func thisWeekEnd() time.Time {
+	return locale.Current().WeekStart(collectors.DayStart(time.Now())).AddDate(0, 0, 6)
+}
+
+// loadWeek reads the 7 days ending on end, and their notes, from the history store
+func loadWeek(end time.Time, cfg *config.Config) (report.Week, error) {
+	from, to := report.WeekRange(end)
+
+	store, err := history.Open()
+	if err != nil {
+		return report.Week{}, err
+	}
+	defer store.Close()
+
+	days, err := store.Range(from, to)
+	if err != nil {
+		return report.Week{}, err
+	}
+	notes, err := store.Notes(from, to)
+	if err != nil {
+		return report.Week{}, err
+	}
+
+	week := report.BuildWeek(end, days)
+	week.AttachNotes(notes)
+	week.LearningGoalMinutes = int(cfg.Learning.WeeklyGoalHours * 60)
+	return week, nil
+}
+
+// loadHistory reads snapshots with from <= date <= to from the history store
+func loadHistory(from, to string) ([]history.DaySummary, error) {
+	store, err := history.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	return store.Range(from, to)
+}
+
+func writeReportPDF(path string, week report.Week) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := report.WritePDF(f, week); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+
+	fmt.Println(ui.RenderSuccess(fmt.Sprintf("Weekly report written to %s", path)))
+	if week.DaysRecorded == 0 {
+		fmt.Println(ui.RenderHint("No history recorded yet -- run rekap during the week to fill in the report"))
+	}
+	return nil
+}
+
+func printWeeklyReport(week report.Week) {
+	fmt.Println(ui.RenderTitle("📅 Weekly report • "+week.Title(), false))
+
+	fmt.Println()
+	fmt.Println(ui.RenderHeader("OVERVIEW"))
+	for _, l := range week.Overview() {
+		fmt.Println(ui.RenderDataPoint("•", fmt.Sprintf("%-17s %s", l.Label+":", l.Value)))
+	}
+
+	if week.DaysRecorded == 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHint("No history recorded yet -- run rekap during the week to fill in the report"))
+		return
+	}
+
+	if len(week.TopApps) > 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHeader("TOP APPS"))
+		for i, app := range week.TopApps {
+			bar := ui.RenderBar(app.Minutes, week.TopApps[0].Minutes, 10, 0)
+			text := fmt.Sprintf("%d. %s %s • %s", i+1, bar, app.Name, ui.FormatDuration(app.Minutes))
+			fmt.Println(ui.RenderDataPoint("📱", text))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(ui.RenderHeader("DAILY BREAKDOWN"))
+	widths := []int{11, 8, 8, 8, 7}
+	fmt.Println(ui.RenderSubItem("   " + formatReportRow(report.DailyColumns, widths)))
+	for _, row := range week.DailyRows() {
+		fmt.Println(ui.RenderSubItem("   " + formatReportRow(row, widths)))
+	}
+
+	// Screen time per day, with a gap for days rekap didn't record
+	screen := make([]int, len(week.Days))
+	for i, d := range week.Days {
+		screen[i] = -1
+		if d.Recorded {
+			screen[i] = d.Summary.ScreenOnMinutes
+		}
+	}
+	fmt.Println(ui.RenderSubItem("   " + fmt.Sprintf("%-11s ", "Screen") + ui.RenderSparkline(screen, 1)))
+
+	if notes := week.NoteLines(); len(notes) > 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHeader("NOTES"))
+		for _, l := range notes {
+			fmt.Println(ui.RenderDataPoint("📝", fmt.Sprintf("%-11s %s", l.Label, l.Value)))
+		}
+	}
+}
+
+// formatReportRow pads all but the last cell to the given widths
+func formatReportRow(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i < len(widths) {
+			b.WriteString(fmt.Sprintf("%-*s ", widths[i], cell))
+		} else {
+			b.WriteString(cell)
+		}
+	}
+	return b.String()
+}
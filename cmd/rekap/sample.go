@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/permissions"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// minSampleEvery keeps "rekap sample --every" from spinning on osascript
+const minSampleEvery = 30 * time.Second
+
+func newSampleCmd() *cobra.Command {
+	var every time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "sample",
+		Short: "Record the frontmost app, Space, and system state for sampled usage",
+		Long: `Record which app is frontmost, which Space (virtual desktop) is current,
+how much memory pressure and swap the system is under, the CPU load,
+whether a VPN is connected, which Wi-Fi network is joined and its signal
+and noise, which Bluetooth headphones or speakers are, and what's playing
+in Music, Spotify, Podcasts, Audible, or Books.
+Without Full Disk Access rekap can't read Screen Time, so it estimates app
+usage from these samples instead; per-Space time, peak memory pressure,
+peak load, VPN time, time per Wi-Fi network, the worst hour for Wi-Fi
+link quality, Bluetooth audio time, and listening time always come from
+them.
+With tracking.clipboard on, each sample also reads the clipboard's change
+counter (never its contents) to count copies per hour. Apps listed in
+tracking.window_titles also have their front window title recorded, so
+projects can match time by the repo or document open.
+Each run of rekap adds one, and running "rekap sample --every 1m" in the
+background (for example from a launchd agent) fills in the rest of the day.
+
+App sampling needs Accessibility permission. Set sources.apps to "sampling"
+to use samples even when Screen Time is readable. With --every, a permission
+that used to work and stops (macOS updates can reset them) raises one
+desktop notification suggesting 'rekap init'.`,
+		Example: `  rekap sample
+  rekap sample --every 1m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			if every == 0 {
+				recorded, err := recordSample(cfg)
+				if err != nil {
+					return err
+				}
+				fmt.Println(ui.RenderSuccess("Recorded " + recorded))
+				return nil
+			}
+			if every < minSampleEvery {
+				return fmt.Errorf("--every must be at least %s", minSampleEvery)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			ticker := time.NewTicker(every)
+			defer ticker.Stop()
+			for {
+				// A failed sample (screen locked, permission revoked) shouldn't end the loop
+				if _, err := recordSample(cfg); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+				permissions.WatchAccess(time.Now())
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&every, "every", 0, "Keep sampling at this interval until interrupted")
+	return cmd
+}
+
+// recordSample records the frontmost app, the current Space, memory
+// pressure, load, VPN state, the Wi-Fi network, Bluetooth audio devices,
+// and what's playing. With tracking.clipboard on it also records the
+// clipboard counter, and with tracking.window_titles the front window title
+// of the listed apps. It returns a description of what it recorded.
+//
+// Only the app sample failing is an error; it's what most setups rely on.
+func recordSample(cfg *config.Config) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	name, err := collectors.RecordAppSample(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the frontmost app: %w\nRun 'rekap init' to grant Accessibility", err)
+	}
+	if space, err := collectors.RecordSpaceSample(ctx); err == nil {
+		name += fmt.Sprintf(" on Desktop %d", space)
+	}
+	collectors.RecordMemorySample(ctx)
+	collectors.RecordCPUSample(ctx)
+	collectors.RecordVPNSample(ctx)
+	collectors.RecordWiFiSample(ctx)
+	collectors.RecordAudioSample(ctx)
+	collectors.RecordMusicSample(ctx)
+	if cfg.Tracking.Clipboard {
+		collectors.RecordClipboardSample(ctx)
+	}
+	collectors.RecordWindowSample(ctx, cfg)
+	return name, nil
+}
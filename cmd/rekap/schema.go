@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/alexinslc/rekap/pkg/rekap"
+	"github.com/spf13/cobra"
+)
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON schema of --json output",
+		Long: `Print a JSON Schema (draft 2020-12) describing the output of rekap --json,
+--format jsonl, --format plist, and GET /v1/today from rekap serve.
+
+Sections rekap couldn't collect are left out rather than set to null, so
+only the top-level version, date, collected_at, and window keys are
+always present. The schema is generated from the same types rekap encodes,
+so it always matches the installed version.`,
+		Example: `  rekap schema > rekap.schema.json
+  rekap schema | jq '.properties | keys'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema := schemaFor(reflect.TypeOf(rekap.JSONOutput{}))
+			schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+			schema["title"] = "rekap " + version + " summary"
+
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(schema); err != nil {
+				return fmt.Errorf("json encode error: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// schemaFor describes how encoding/json encodes t. Struct fields tagged
+// omitempty are optional; every other field is required, and required
+// slices may be null when empty.
+func schemaFor(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]any)
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			prop := schemaFor(f.Type)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+				if f.Type.Kind() == reflect.Slice {
+					prop["type"] = []string{"array", "null"}
+				}
+			}
+			properties[name] = prop
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/keychain"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+func newSecretsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Keep integration tokens in the macOS Keychain",
+		Long: `Keep integration secrets like Slack tokens and webhook URLs in your login
+Keychain instead of the config file. Save one with "rekap secrets set NAME",
+then use "keychain:NAME" as the value in your config.
+
+Without a subcommand, lists the Keychain items your config refers to and
+whether each one is saved.`,
+		Example: `  rekap secrets set rekap-slack-token
+  pbpaste | rekap secrets set rekap-slack-webhook
+  rekap config set integrations.slack.token keychain:rekap-slack-token
+  rekap secrets`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			refs := secretRefs(cfg)
+			if len(refs) == 0 {
+				fmt.Println("Your config doesn't refer to any Keychain items.")
+				fmt.Println(`Save a secret with 'rekap secrets set NAME' and use "keychain:NAME" as a config value.`)
+				return nil
+			}
+			for _, ref := range refs {
+				state := "saved"
+				if _, err := keychain.Get(ref.name); errors.Is(err, keychain.ErrNotFound) {
+					state = "missing"
+				} else if err != nil {
+					state = "unreadable"
+				}
+				fmt.Printf("%-40s %-24s %s\n", ref.key, ref.name, state)
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newSecretsSetCmd(), newSecretsDeleteCmd())
+	return cmd
+}
+
+func newSecretsSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name>",
+		Short: "Save a secret to the Keychain",
+		Long: `Save a secret to your login Keychain under NAME, replacing any secret
+already saved there. The secret is read from a hidden prompt, or from stdin
+when it's piped in, so it never lands in your shell history.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !keychain.ValidName(name) {
+				return fmt.Errorf("invalid name %q (use letters, digits, '.', '_', and '-')", name)
+			}
+			secret, err := readSecret(name)
+			if err != nil {
+				return err
+			}
+			if err := keychain.Set(name, secret); err != nil {
+				return err
+			}
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Saved %s; use \"%s%s\" in your config", name, keychain.Prefix, name)))
+			return nil
+		},
+	}
+}
+
+func newSecretsDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Remove a secret from the Keychain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := keychain.Delete(args[0]); err != nil {
+				return err
+			}
+			fmt.Println(ui.RenderSuccess("Deleted " + args[0]))
+			return nil
+		},
+	}
+}
+
+// readSecret prompts for a secret without echoing it, or reads it from
+// stdin when that isn't a terminal
+func readSecret(name string) (string, error) {
+	var secret string
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "Secret for %s: ", name)
+		data, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret: %w", err)
+		}
+		secret = string(data)
+	} else {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret: %w", err)
+		}
+		secret = string(data)
+	}
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("no secret given")
+	}
+	return secret, nil
+}
+
+// secretRef is a config value that names a Keychain item
+type secretRef struct {
+	key  string // Dotted config key, e.g. integrations.slack.token
+	name string // Keychain item name
+}
+
+// secretRefs lists the config values that are "keychain:NAME" references
+func secretRefs(cfg *config.Config) []secretRef {
+	var refs []secretRef
+	add := func(key, value string) {
+		if name, ok := keychain.Ref(value); ok {
+			refs = append(refs, secretRef{key, name})
+		}
+	}
+	add("integrations.slack.webhook_url", cfg.Integrations.Slack.WebhookURL)
+	add("integrations.slack.token", cfg.Integrations.Slack.Token)
+	for _, name := range webhookNames(cfg) {
+		hook := cfg.Integrations.Webhooks[name]
+		add("integrations.webhooks."+name+".url", hook.URL)
+		for _, header := range slices.Sorted(maps.Keys(hook.Headers)) {
+			add("integrations.webhooks."+name+".headers."+header, hook.Headers[header])
+		}
+	}
+	return refs
+}
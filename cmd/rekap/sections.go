@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func newSectionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sections",
+		Short: "List, enable, and disable summary sections",
+		Long: `List the summary sections and whether each one runs. A disabled section is
+never shown, and collectors that only feed disabled sections are skipped,
+so rekap runs faster.
+
+"rekap sections disable media" adds media to sections.skip in your config;
+"enable" takes it out again. For a single run use --only or --skip instead.
+
+Sections are listed in display order, set with display.section_order.
+Sections in display.hidden_sections still run, for --json and --quiet,
+but never show in the summary or the TUI.`,
+		Example: `  rekap sections
+  rekap sections disable media network
+  rekap sections enable media
+  rekap --only browser,productivity`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			now := time.Now()
+			for _, section := range cfg.OrderSections(sectionNames(cfg)) {
+				state := "on"
+				switch {
+				case !cfg.SectionEnabled(section):
+					state = "off"
+				case cfg.SectionHidden(section):
+					state = "hidden"
+				case cfg.SectionSnoozed(section, now):
+					state = "snoozed"
+				}
+				fmt.Printf("%-14s %s\n", section, state)
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newSectionsToggleCmd(true), newSectionsToggleCmd(false))
+	return cmd
+}
+
+// newSectionsToggleCmd builds "sections enable" or "sections disable"
+func newSectionsToggleCmd(enable bool) *cobra.Command {
+	verb := "disable"
+	if enable {
+		verb = "enable"
+	}
+	return &cobra.Command{
+		Use:               verb + " <section>...",
+		Short:             strings.ToUpper(verb[:1]) + verb[1:] + " sections in your config",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeSectionArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			for _, section := range args {
+				if !cfg.IsSection(section) {
+					return fmt.Errorf("unknown section %q (valid: %s)", section, strings.Join(sectionNames(cfg), ", "))
+				}
+			}
+
+			path, err := config.EditPath()
+			if err != nil {
+				return fmt.Errorf("failed to determine config path: %w", err)
+			}
+			err = config.EditFile(path, func(root *yaml.Node) error {
+				for _, section := range args {
+					if err := config.SetSectionEnabled(root, strings.ToLower(section), enable); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update %s: %w", path, err)
+			}
+			fmt.Printf("%sd %s in %s\n", strings.ToUpper(verb[:1])+verb[1:], strings.Join(args, ", "), path)
+			return nil
+		},
+	}
+}
+
+// sectionNames returns the built-in section keys followed by the custom
+// sections
+func sectionNames(cfg *config.Config) []string {
+	names := append([]string{}, config.SectionKeys...)
+	for _, custom := range cfg.Custom {
+		names = append(names, custom.Name)
+	}
+	return names
+}
+
+// applySectionFlags applies --only and --skip on top of the sections block
+// of the config: --only replaces sections.only, --skip adds to
+// sections.skip
+func applySectionFlags(cfg *config.Config, onlySet bool, only, skip []string) error {
+	for _, section := range append(append([]string{}, only...), skip...) {
+		if !cfg.IsSection(section) {
+			return fmt.Errorf("unknown section %q (valid: %s)", section, strings.Join(sectionNames(cfg), ", "))
+		}
+	}
+	if onlySet {
+		cfg.Sections.Only = only
+	}
+	cfg.Sections.Skip = append(cfg.Sections.Skip, skip...)
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// slackTopApps is how many apps the Slack message lists
+const slackTopApps = 5
+
+func newSendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Send today's summary somewhere",
+		Long:  `Collect today's summary and deliver it to a configured destination.`,
+	}
+
+	cmd.AddCommand(newSendSlackCmd(), newSendWebhookCmd())
+	return cmd
+}
+
+func newSendSlackCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "slack",
+		Short: "Post the summary to a Slack incoming webhook",
+		Long: `Post today's summary line, top apps, and wellness warnings to Slack as a
+Block Kit message. Set integrations.slack.webhook_url in your config to an
+incoming webhook URL; use --dry-run to print the payload instead of sending.`,
+		Example: `  rekap send slack
+  rekap send slack --dry-run | jq .blocks`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			if cfg.Integrations.Slack.WebhookURL == "" && !dryRun {
+				return fmt.Errorf("integrations.slack.webhook_url is not set\nSave your Slack incoming webhook URL with 'rekap secrets set rekap-slack-webhook' and set it to \"keychain:rekap-slack-webhook\"")
+			}
+			var webhook string
+			if !dryRun {
+				if webhook, err = cfg.SlackWebhookURL(); err != nil {
+					return fmt.Errorf("integrations.slack.webhook_url: %w", err)
+				}
+			}
+
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+			data := collectSummary(cfg, collectors.Today(time.Now()))
+
+			payload, err := json.Marshal(buildSlackMessage(&data))
+			if err != nil {
+				return fmt.Errorf("failed to encode Slack message: %w", err)
+			}
+
+			if dryRun {
+				var out bytes.Buffer
+				_ = json.Indent(&out, payload, "", "  ")
+				fmt.Println(out.String())
+				return nil
+			}
+
+			if err := postSlack(webhook, payload); err != nil {
+				return err
+			}
+			fmt.Println(ui.RenderSuccess("Sent today's summary to Slack"))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the Slack payload instead of sending it")
+	return cmd
+}
+
+// slackMessage is an incoming webhook payload. Text is the notification
+// fallback; Blocks is what Slack renders.
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"` // "plain_text" or "mrkdwn"
+	Text string `json:"text"`
+}
+
+// buildSlackMessage renders the summary line, top apps, and wellness
+// warnings as Block Kit. Like JSON, it ignores snooze rules.
+func buildSlackMessage(data *SummaryData) slackMessage {
+	title := "📊 rekap • " + locale.Current().LongDate(data.Window.Start)
+	summaryLine := strings.Join(summaryLineParts(data), " • ")
+	if summaryLine == "" {
+		summaryLine = "No activity data collected"
+	}
+
+	mrkdwn := func(text string) slackBlock {
+		return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}}
+	}
+
+	msg := slackMessage{
+		Text: title + ": " + summaryLine,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: title}},
+			mrkdwn(slackEscape(summaryLine)),
+		},
+	}
+
+	if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+		var b strings.Builder
+		b.WriteString("*Top apps*")
+		for i, app := range data.Apps.TopApps {
+			if i >= slackTopApps {
+				break
+			}
+			fmt.Fprintf(&b, "\n• %s — %s", slackEscape(app.Name), ui.FormatDuration(app.Minutes))
+		}
+		msg.Blocks = append(msg.Blocks, mrkdwn(b.String()))
+	}
+
+	if data.Burnout.Available && len(data.Burnout.Warnings) > 0 {
+		var b strings.Builder
+		b.WriteString("*Wellness check*")
+		for _, w := range sortedWarnings(data.Burnout.Warnings) {
+			fmt.Fprintf(&b, "\n%s %s", burnoutIcon(w.Type), slackEscape(w.Message))
+		}
+		msg.Blocks = append(msg.Blocks, mrkdwn(b.String()))
+	}
+
+	msg.Blocks = append(msg.Blocks, slackBlock{
+		Type:     "context",
+		Elements: []slackText{{Type: "mrkdwn", Text: "Sent by rekap " + version}},
+	})
+	return msg
+}
+
+// slackEscape escapes the characters Slack's mrkdwn treats as control sequences
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// postSlack sends payload to an incoming webhook
+func postSlack(webhook string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("invalid Slack webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send to Slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack rejected the message: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/alexinslc/rekap/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+func newSendWebhookCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "webhook <name>",
+		Short: "Send the summary to a configured webhook",
+		Long: `Send today's summary to an endpoint configured under integrations.webhooks.
+
+Without a body template the request carries the same JSON as "rekap --json".
+A body template is a Go template over that JSON, using its field names
+({{.screen.screen_on_minutes}}), plus {{.summary}} for the one-line summary
+and the json and duration functions. Header values expand $VARS from the
+environment. Use --dry-run to print the request instead of sending it.`,
+		Example: `  rekap send webhook homeassistant
+  rekap send webhook n8n --dry-run`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return webhookNames(cfg), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+
+			name := args[0]
+			hook, ok := cfg.Integrations.Webhooks[name]
+			if !ok {
+				if names := webhookNames(cfg); len(names) > 0 {
+					return fmt.Errorf("no webhook named %q (configured: %s)", name, strings.Join(names, ", "))
+				}
+				return fmt.Errorf("no webhook named %q\nAdd it under integrations.webhooks in ~/.config/rekap/config.yaml", name)
+			}
+			if hook.URL == "" {
+				return fmt.Errorf("integrations.webhooks.%s.url is not set", name)
+			}
+			opts := webhook.Options{URL: hook.URL, Method: hook.Method, Headers: hook.Headers, Body: hook.Body}
+			// Catch template mistakes before spending time collecting
+			if _, err := webhook.Parse(hook.Body); err != nil {
+				return fmt.Errorf("integrations.webhooks.%s.body: %w", name, err)
+			}
+
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+			data := collectSummary(cfg, collectors.Today(time.Now()))
+
+			payload, err := json.Marshal(buildJSON(&data))
+			if err != nil {
+				return fmt.Errorf("failed to encode summary: %w", err)
+			}
+			req, err := webhook.Build(opts, payload, strings.Join(summaryLineParts(&data), " • "))
+			if err != nil {
+				return fmt.Errorf("integrations.webhooks.%s: %w", name, err)
+			}
+
+			if dryRun {
+				fmt.Printf("%s %s\n", req.Method, req.URL)
+				for _, k := range slices.Sorted(maps.Keys(req.Headers)) {
+					fmt.Printf("%s: %s\n", k, req.Headers[k])
+				}
+				fmt.Printf("\n%s\n", req.Body)
+				return nil
+			}
+
+			if err := webhook.Send(context.Background(), req, 10*time.Second); err != nil {
+				return err
+			}
+			fmt.Println(ui.RenderSuccess("Sent today's summary to " + name))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the request instead of sending it")
+	return cmd
+}
+
+// webhookNames lists the configured webhooks, sorted
+func webhookNames(cfg *config.Config) []string {
+	return slices.Sorted(maps.Keys(cfg.Integrations.Webhooks))
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/permissions"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/alexinslc/rekap/pkg/rekap"
+	"github.com/spf13/cobra"
+)
+
+// defaultServeAddr is loopback-only: the API serves personal activity data
+const defaultServeAddr = "127.0.0.1:7827"
+
+func newServeCmd() *cobra.Command {
+	var addr string
+	var maxAge time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve summaries over a local HTTP API",
+		Long: `Run a small HTTP server so scripts and widgets can query rekap without
+starting a new process each time. All responses are JSON:
+
+  GET /v1/today              today's summary, same schema as "rekap --json"
+  GET /v1/history/{date}     the history snapshot for a YYYY-MM-DD date
+  GET /v1/week               the last 7 days; ?end=YYYY-MM-DD picks another week
+
+Today's summary is collected at most once per --max-age; requests in between
+get the cached result. Like "rekap get", collecting it runs no hooks and
+saves no history snapshot. The server listens on localhost only unless
+--addr says otherwise, and answers only requests addressed to localhost or
+the --addr host, so web pages can't reach it through DNS rebinding. If a
+permission that used to work stops working (macOS updates can reset them),
+it raises one desktop notification.`,
+		Example: `  rekap serve
+  rekap serve --addr 127.0.0.1:9000 --max-age 5m
+  curl -s localhost:7827/v1/today | jq .screen`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           newAPIHandler(cfg, addr, maxAge),
+				ReadHeaderTimeout: 5 * time.Second,
+			}
+			errCh := make(chan error, 1)
+			go func() { errCh <- srv.ListenAndServe() }()
+
+			fmt.Fprintln(os.Stderr, ui.RenderSuccess(fmt.Sprintf("Serving rekap on http://%s (Ctrl+C to stop)", addr)))
+
+			select {
+			case err := <-errCh:
+				return fmt.Errorf("server failed: %w", err)
+			case <-ctx.Done():
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", defaultServeAddr, "Address to listen on")
+	cmd.Flags().DurationVar(&maxAge, "max-age", time.Minute, "Reuse today's summary for this long before collecting again")
+	return cmd
+}
+
+// apiServer answers the /v1 endpoints. Collection is serialized and
+// cached so a burst of requests runs the collectors once.
+type apiServer struct {
+	cfg    *config.Config
+	maxAge time.Duration
+
+	mu          sync.Mutex
+	today       rekap.JSONOutput
+	collectedAt time.Time
+}
+
+func newAPIHandler(cfg *config.Config, addr string, maxAge time.Duration) http.Handler {
+	s := &apiServer{cfg: cfg, maxAge: maxAge}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/today", s.handleToday)
+	mux.HandleFunc("GET /v1/history/{date}", s.handleHistory)
+	mux.HandleFunc("GET /v1/week", s.handleWeek)
+	return checkHost(addr, mux)
+}
+
+// checkHost rejects requests whose Host header isn't localhost, 127.0.0.1,
+// [::1], or the host in addr, on addr's port. A page on another site that
+// rebinds its domain to 127.0.0.1 still sends its own name as the Host, so
+// it can't read the API.
+func checkHost(addr string, next http.Handler) http.Handler {
+	listenHost, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		listenHost, port = addr, "80"
+	}
+	allowed := map[string]bool{}
+	for _, host := range []string{"localhost", "127.0.0.1", "::1", strings.ToLower(listenHost)} {
+		if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+			allowed[net.JoinHostPort(host, port)] = true
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			host = net.JoinHostPort(strings.Trim(host, "[]"), "80")
+		}
+		if !allowed[strings.ToLower(host)] {
+			writeAPIError(w, http.StatusForbidden, "host not allowed: "+r.Host)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *apiServer) handleToday(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.collectedAt.IsZero() || time.Since(s.collectedAt) >= s.maxAge {
+		// No hooks or history snapshot: a widget may poll this often
+		data, _ := rekap.Collect(context.Background(), rekap.Options{Config: s.cfg, Window: collectors.Today(time.Now())})
+		s.today = buildJSON(&data)
+		s.collectedAt = time.Now()
+		go permissions.WatchAccess(s.collectedAt)
+	}
+	writeAPIJSON(w, http.StatusOK, s.today)
+}
+
+func (s *apiServer) handleHistory(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "date must be YYYY-MM-DD")
+		return
+	}
+
+	days, err := loadHistory(date, date)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(days) == 0 {
+		writeAPIError(w, http.StatusNotFound, "no snapshot recorded for "+date)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, days[0])
+}
+
+func (s *apiServer) handleWeek(w http.ResponseWriter, r *http.Request) {
+	end := collectors.DayStart(time.Now())
+	if v := r.URL.Query().Get("end"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "end must be YYYY-MM-DD")
+			return
+		}
+		end = t
+	}
+
+	week, err := loadWeek(end, s.cfg)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, buildWeekJSON(week))
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	writeAPIJSON(w, status, map[string]string{"error": msg})
+}
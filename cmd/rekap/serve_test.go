@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckHost(t *testing.T) {
+	t.Parallel()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	for _, tt := range []struct {
+		addr, host string
+		want       int
+	}{
+		{"127.0.0.1:7827", "localhost:7827", http.StatusOK},
+		{"127.0.0.1:7827", "127.0.0.1:7827", http.StatusOK},
+		{"127.0.0.1:7827", "[::1]:7827", http.StatusOK},
+		{"127.0.0.1:7827", "LOCALHOST:7827", http.StatusOK},
+		{"127.0.0.1:7827", "localhost:9000", http.StatusForbidden},
+		{"127.0.0.1:7827", "evil.example:7827", http.StatusForbidden},
+		{"127.0.0.1:7827", "localhost", http.StatusForbidden},
+		{"127.0.0.1:80", "localhost", http.StatusOK},
+		{"MyMac.local:7827", "mymac.local:7827", http.StatusOK},
+		{"MyMac.local:7827", "MyMac.local:7827", http.StatusOK},
+		{"0.0.0.0:7827", "0.0.0.0:7827", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/today", nil)
+		req.Host = tt.host
+		rec := httptest.NewRecorder()
+		checkHost(tt.addr, ok).ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("--addr %s, Host %s: status %d, want %d", tt.addr, tt.host, rec.Code, tt.want)
+		}
+	}
+}
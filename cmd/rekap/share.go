@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newShareCmd() *cobra.Command {
+	var outPath string
+	var expiresFlag string
+	var includeApps bool
+
+	cmd := &cobra.Command{
+		Use:   "share",
+		Short: "Create an encrypted, expiring weekly report page",
+		Long: `Write the weekly report as a single static HTML page for a coach or
+accountability partner. The report is redacted (no network totals, and no app
+names unless --include-apps is set) and encrypted; the key is only in the
+link's #fragment, so whoever hosts the file can't read it. The page stops
+opening after the expiry date.`,
+		Example: `  rekap share
+  rekap share --expires 3d --out ~/Sites/week.html
+  rekap share --include-apps`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			ttl, err := parseExpiry(expiresFlag)
+			if err != nil {
+				return err
+			}
+
+			week, err := loadWeek(thisWeekEnd(), cfg)
+			if err != nil {
+				return err
+			}
+			if week.DaysRecorded == 0 {
+				return fmt.Errorf("no history recorded for this week yet -- run rekap during the week first")
+			}
+
+			if outPath == "" {
+				outPath = fmt.Sprintf("rekap-week-%s.html", week.End.Format("2006-01-02"))
+			}
+			absPath, err := filepath.Abs(outPath)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(absPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", absPath, err)
+			}
+			expires := time.Now().Add(ttl)
+			key, err := report.WriteShareHTML(f, week, report.ShareOptions{Expires: expires, IncludeApps: includeApps})
+			if err != nil {
+				f.Close()
+				return fmt.Errorf("failed to write share page: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write share page: %w", err)
+			}
+
+			fmt.Println(ui.RenderSuccess(fmt.Sprintf("Share page written to %s", absPath)))
+			fmt.Println(ui.RenderDataPoint("🔗", fmt.Sprintf("file://%s#k=%s", absPath, key)))
+			fmt.Println(ui.RenderSubItem(fmt.Sprintf("   Expires %s", locale.Current().ShortDate(expires)+" "+ui.FormatTime(expires, cfg.Display.TimeFormat))))
+			fmt.Println(ui.RenderHint("Upload the file anywhere (static host, shared drive) and send its URL with #k=" + key + " appended"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outPath, "out", "o", "", "Output file (default rekap-week-<date>.html)")
+	cmd.Flags().StringVar(&expiresFlag, "expires", "7d", "How long the link works, e.g. 3d or 36h")
+	cmd.Flags().BoolVar(&includeApps, "include-apps", false, "Include app names in the shared report")
+	return cmd
+}
+
+// parseExpiry accepts Go durations ("36h") plus whole days ("7d")
+func parseExpiry(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --expires %q: use e.g. 7d or 36h", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --expires %q: use e.g. 7d or 36h", s)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("--expires must be positive")
+	}
+	return d, nil
+}
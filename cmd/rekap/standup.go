@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newStandupCmd() *cobra.Command {
+	var todayFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "standup",
+		Short: "Write a standup update from yesterday's activity",
+		Long: `Compose a short "Yesterday I..." update as Markdown bullets, ready to paste
+into Slack: issues you looked at, project and app time, remote hosts, your
+best focus stretch, and any notes added with "rekap note".
+
+On Mondays (and weekends) it covers Friday instead of the day before.`,
+		Example: `  rekap standup
+  rekap standup --today | pbcopy`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			now := time.Now()
+			w, heading := collectors.Today(now), "Today I"
+			if !todayFlag {
+				day := previousWorkday(collectors.DayStart(now))
+				w = collectors.DayWindow(day, now)
+				heading = "Yesterday I"
+				if collectors.DayKey(day) != collectors.DayKey(collectors.DayStart(now).AddDate(0, 0, -1)) {
+					heading = "On " + day.Format("Monday") + " I"
+				}
+			}
+
+			data := collectSummary(cfg, w)
+			fmt.Print(report.Standup(&data, heading))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&todayFlag, "today", false, "Cover today so far instead of the previous workday")
+	return cmd
+}
+
+// previousWorkday returns the start of the last weekday before the day
+// starting at dayStart
+func previousWorkday(dayStart time.Time) time.Time {
+	day := dayStart.AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/state"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Icon sets for "rekap status"
+const (
+	statusIconsEmoji = "emoji"
+	statusIconsNerd  = "nerd"
+	statusIconsNone  = "none"
+)
+
+// Color modes for "rekap status"
+const (
+	statusColorNever = "never"
+	statusColorANSI  = "ansi" // SGR escapes, for starship and other prompts
+	statusColorTmux  = "tmux" // #[fg=...] markup for tmux status bars
+)
+
+// statusLongScreen is the screen-on time past which the status turns to
+// the warning color, matching the long-day burnout check
+const statusLongScreen = 8 * 60
+
+// statusSnapshot is the handful of numbers the status line shows, cached
+// in the state store so status bars polling every few seconds don't
+// collect each time
+type statusSnapshot struct {
+	CollectedAt     int64 `json:"collected_at"`
+	ScreenMinutes   int   `json:"screen_minutes"`
+	ScreenEstimated bool  `json:"screen_estimated"`
+	ScreenAvailable bool  `json:"screen_available"`
+	LockCount       int   `json:"lock_count"`
+	FocusMinutes    int   `json:"focus_minutes"`
+	FocusAvailable  bool  `json:"focus_available"`
+}
+
+func newStatusCmd() *cobra.Command {
+	var segmentFlag bool
+	var iconsFlag string
+	var colorFlag string
+	var maxAge time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print a one-line status for prompts and status bars",
+		Long: `Print today's screen-on time, longest focus streak, and screen locks on one
+line.
+
+With --segment the line is as short as possible ("7h02m • 🎯34 • 🔒12") for
+tmux status bars and starship prompts. Only the screen and focus collectors
+run, and their result is reused for --max-age, so a status bar polling
+every few seconds stays fast. Use --icons nerd for Nerd Font glyphs and
+--color to color the numbers with ANSI escapes or tmux markup.`,
+		Example: `  rekap status
+  # ~/.tmux.conf
+  set -g status-right '#(rekap status --segment --color tmux)'
+  # starship.toml
+  [custom.rekap]
+  command = "rekap status --segment --color ansi"
+  when = true`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch iconsFlag {
+			case "", statusIconsEmoji, statusIconsNerd, statusIconsNone:
+			default:
+				return fmt.Errorf("unknown --icons %q (supported: %s, %s, %s)", iconsFlag, statusIconsEmoji, statusIconsNerd, statusIconsNone)
+			}
+			switch colorFlag {
+			case statusColorNever, statusColorANSI, statusColorTmux:
+			default:
+				return fmt.Errorf("unknown --color %q (supported: %s, %s, %s)", colorFlag, statusColorNever, statusColorANSI, statusColorTmux)
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				cfg = config.Fallback()
+			}
+			collectors.SetDayStart(cfg.DayStartMinute())
+			if iconsFlag == "" {
+				iconsFlag = statusIconsEmoji
+				if cfg.Accessibility.Enabled && cfg.Accessibility.NoEmoji {
+					iconsFlag = statusIconsNone
+				}
+			}
+
+			snap := loadStatus(cfg, time.Now(), maxAge)
+			if segmentFlag {
+				fmt.Println(formatStatusSegment(snap, iconsFlag, colorFlag))
+			} else {
+				fmt.Println(formatStatusLine(snap))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&segmentFlag, "segment", false, "Print the shortest form, for tmux status bars and shell prompts")
+	cmd.Flags().StringVar(&iconsFlag, "icons", "", "Icons for --segment: emoji, nerd (Nerd Font glyphs), or none (default emoji)")
+	cmd.Flags().StringVar(&colorFlag, "color", statusColorNever, "Color the numbers: never, ansi, or tmux")
+	cmd.Flags().DurationVar(&maxAge, "max-age", time.Minute, "Reuse the last status for this long before collecting again")
+	return cmd
+}
+
+// loadStatus returns today's cached status when it's younger than maxAge,
+// and otherwise collects and caches a new one. The cache is best-effort.
+func loadStatus(cfg *config.Config, now time.Time, maxAge time.Duration) statusSnapshot {
+	today := collectors.DayKey(now)
+	var cached statusSnapshot
+	if found, err := state.Load(state.KindStatus, today, &cached); err == nil && found {
+		if age := now.Sub(time.Unix(cached.CollectedAt, 0)); age >= 0 && age < maxAge {
+			return cached
+		}
+	}
+
+	snap := collectStatus(cfg, collectors.Today(now))
+	snap.CollectedAt = now.Unix()
+	_ = state.Save(state.KindStatus, today, snap)
+	return snap
+}
+
+// collectStatus runs just the collectors the status line needs
+func collectStatus(cfg *config.Config, w collectors.Window) statusSnapshot {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var screen collectors.ScreenResult
+	var focus collectors.FocusResult
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); screen = collectors.CollectScreen(ctx, cfg.Sources.Screen, w) }()
+	go func() { defer wg.Done(); focus = collectors.CollectFocus(ctx, cfg.Tracking.ExcludeApps, w) }()
+	wg.Wait()
+
+	return statusSnapshot{
+		ScreenMinutes:   screen.ScreenOnMinutes,
+		ScreenEstimated: screen.Source == collectors.ScreenSourceEstimate,
+		ScreenAvailable: screen.Available,
+		LockCount:       screen.LockCount,
+		FocusMinutes:    focus.StreakMinutes,
+		FocusAvailable:  focus.Available,
+	}
+}
+
+// formatStatusLine describes the status in words, e.g.
+// "Screen 7h 2m • Focus 34m • 12 locks"
+func formatStatusLine(s statusSnapshot) string {
+	var parts []string
+	if s.ScreenAvailable {
+		screen := ui.FormatDuration(s.ScreenMinutes)
+		if s.ScreenEstimated {
+			screen += " (estimated)"
+		}
+		parts = append(parts, "Screen "+screen)
+	}
+	if s.FocusAvailable {
+		parts = append(parts, "Focus "+ui.FormatDuration(s.FocusMinutes))
+	}
+	if s.ScreenAvailable {
+		parts = append(parts, fmt.Sprintf("%d lock%s", s.LockCount, pluralize(s.LockCount)))
+	}
+	if len(parts) == 0 {
+		return "No activity data yet"
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatStatusSegment formats the status as compactly as possible, e.g.
+// "7h02m • 🎯34 • 🔒12". Screen time past statusLongScreen takes the
+// warning color and a focus streak of deep-work length the success color.
+func formatStatusSegment(s statusSnapshot, icons, color string) string {
+	focusIcon, lockIcon := "🎯", "🔒"
+	switch icons {
+	case statusIconsNerd:
+		focusIcon, lockIcon = "\uf140", "\uf023" // nf-fa-bullseye, nf-fa-lock
+	case statusIconsNone:
+		focusIcon, lockIcon = "f", "l"
+	}
+
+	var parts []string
+	if s.ScreenAvailable {
+		screen := fmt.Sprintf("%dh%02dm", s.ScreenMinutes/60, s.ScreenMinutes%60)
+		if s.ScreenEstimated {
+			screen = "~" + screen
+		}
+		if s.ScreenMinutes >= statusLongScreen {
+			screen = statusColorize(screen, "yellow", color)
+		}
+		parts = append(parts, screen)
+	}
+	if s.FocusAvailable {
+		focus := fmt.Sprintf("%s%d", focusIcon, s.FocusMinutes)
+		if time.Duration(s.FocusMinutes)*time.Minute >= collectors.DeepWorkMin {
+			focus = statusColorize(focus, "green", color)
+		}
+		parts = append(parts, focus)
+	}
+	if s.ScreenAvailable {
+		parts = append(parts, fmt.Sprintf("%s%d", lockIcon, s.LockCount))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " • ")
+}
+
+// statusANSI are the SGR codes for the colors the status line uses
+var statusANSI = map[string]string{"green": "32", "yellow": "33"}
+
+// statusColorize wraps text in the color's escapes for mode
+func statusColorize(text, color, mode string) string {
+	switch mode {
+	case statusColorANSI:
+		return "\x1b[" + statusANSI[color] + "m" + text + "\x1b[0m"
+	case statusColorTmux:
+		return "#[fg=" + color + "]" + text + "#[default]"
+	}
+	return text
+}
@@ -2,94 +2,274 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/alexinslc/rekap/internal/collectors"
 	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/hooks"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/alexinslc/rekap/internal/state"
 	"github.com/alexinslc/rekap/internal/summary"
 	"github.com/alexinslc/rekap/internal/ui"
 	"github.com/alexinslc/rekap/internal/ui/tui"
+	"github.com/alexinslc/rekap/pkg/rekap"
 )
 
 // SummaryData is an alias for the shared summary.Data type.
 type SummaryData = summary.Data
 
-func runSummary(quiet bool, asJSON bool, print bool, cfg *config.Config) {
+// Output formats accepted by --format
+const (
+	formatMarkdown = "markdown"
+	formatCSV      = "csv"
+	formatJSONL    = "jsonl"
+	formatPlist    = "plist"
+	formatRaycast  = "raycast"
+)
+
+// outputOptions are the root command's output and time window flags
+type outputOptions struct {
+	quiet  bool
+	json   bool
+	print  bool
+	format string
+	csvDir string // Detail CSV directory for --format csv
+	output string // File to write to instead of stdout; --format jsonl always appends
+	append bool   // Add to the end of output instead of replacing it
+
+	logFile  string // File to log the run and collector errors to
+	logLevel string // debug, info, warn, or error
+
+	since string        // --since HH:MM: from that time today until now
+	last  time.Duration // --last 4h: the trailing span until now
+	date  string        // --date YYYY-MM-DD: a whole past day
+}
+
+// validate rejects bad output flag combinations before any collection runs
+func (o outputOptions) validate() error {
+	switch o.format {
+	case "", formatMarkdown, formatCSV, formatJSONL, formatPlist, formatRaycast:
+	default:
+		return fmt.Errorf("unknown --format %q (supported: %s, %s, %s, %s, %s)", o.format, formatMarkdown, formatCSV, formatJSONL, formatPlist, formatRaycast)
+	}
+	if o.csvDir != "" && o.format != formatCSV {
+		return fmt.Errorf("--csv-dir requires --format csv")
+	}
+	if o.append && o.output == "" {
+		return fmt.Errorf("--append requires --output")
+	}
+	if _, ok := logLevels[o.logLevel]; !ok {
+		return fmt.Errorf("unknown --log-level %q (supported: debug, info, warn, error)", o.logLevel)
+	}
+	if o.since != "" {
+		if _, err := time.Parse("15:04", o.since); err != nil {
+			return fmt.Errorf("invalid --since %q: use HH:MM", o.since)
+		}
+	}
+	if o.last < 0 {
+		return fmt.Errorf("--last must be positive")
+	}
+	if o.date != "" {
+		if _, err := time.Parse("2006-01-02", o.date); err != nil {
+			return fmt.Errorf("invalid --date %q: use YYYY-MM-DD", o.date)
+		}
+	}
+	return nil
+}
+
+// interactive reports whether the summary will be the TUI
+func (o outputOptions) interactive() bool {
+	return !o.json && !o.quiet && !o.print && o.format == "" && o.output == "" && ui.IsTTY()
+}
+
+// window resolves the time window flags against now. It must run after the
+// day boundary is configured; without flags it's today so far.
+func (o outputOptions) window(now time.Time) (collectors.Window, error) {
+	switch {
+	case o.since != "":
+		t, err := time.Parse("15:04", o.since)
+		if err != nil {
+			return collectors.Window{}, fmt.Errorf("invalid --since %q: use HH:MM", o.since)
+		}
+		dayStart := collectors.DayStart(now)
+		start := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if start.Before(dayStart) {
+			// Past midnight with a later day boundary: 01:00 is tomorrow's date
+			start = start.AddDate(0, 0, 1)
+		}
+		if start.After(now) {
+			return collectors.Window{}, fmt.Errorf("--since %s is in the future", o.since)
+		}
+		return collectors.Window{Start: start, End: now}, nil
+	case o.last > 0:
+		return collectors.Window{Start: now.Add(-o.last), End: now}, nil
+	case o.date != "":
+		start, err := collectors.ParseDay(o.date)
+		if err != nil {
+			return collectors.Window{}, fmt.Errorf("invalid --date %q: use YYYY-MM-DD", o.date)
+		}
+		if start.After(now) {
+			return collectors.Window{}, fmt.Errorf("--date %s is in the future", o.date)
+		}
+		return collectors.DayWindow(start, now), nil
+	}
+	return collectors.Today(now), nil
+}
+
+func runSummary(out outputOptions, cfg *config.Config) error {
+	logger, closeLog, err := openLogFile(out.logFile, out.logLevel)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
 	ui.ApplyColors(cfg)
+	collectors.SetDayStart(cfg.DayStartMinute())
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Collect data from all sources concurrently
-	uptimeCh := make(chan collectors.UptimeResult, 1)
-	batteryCh := make(chan collectors.BatteryResult, 1)
-	screenCh := make(chan collectors.ScreenResult, 1)
-	appsCh := make(chan collectors.AppsResult, 1)
-	focusCh := make(chan collectors.FocusResult, 1)
-	mediaCh := make(chan collectors.MediaResult, 1)
-	networkCh := make(chan collectors.NetworkResult, 1)
-	browsersCh := make(chan collectors.BrowsersResult, 1)
-	issuesCh := make(chan collectors.IssuesResult, 1)
-	notificationsCh := make(chan collectors.NotificationsResult, 1)
-
-	go func() { uptimeCh <- collectors.CollectUptime(ctx) }()
-	go func() { batteryCh <- collectors.CollectBattery(ctx) }()
-	go func() { screenCh <- collectors.CollectScreen(ctx) }()
-	go func() { appsCh <- collectors.CollectApps(ctx, cfg.Tracking.ExcludeApps) }()
-	go func() { focusCh <- collectors.CollectFocus(ctx) }()
-	go func() { mediaCh <- collectors.CollectMedia(ctx) }()
-	go func() { networkCh <- collectors.CollectNetwork(ctx) }()
-	go func() { browsersCh <- collectors.CollectBrowserTabs(ctx, cfg) }()
-	go func() { issuesCh <- collectors.CollectIssues(ctx) }()
-	go func() { notificationsCh <- collectors.CollectNotifications(ctx) }()
-
-	data := SummaryData{
-		Uptime:        <-uptimeCh,
-		Battery:       <-batteryCh,
-		Screen:        <-screenCh,
-		Apps:          <-appsCh,
-		Focus:         <-focusCh,
-		Media:         <-mediaCh,
-		Network:       <-networkCh,
-		Browsers:      <-browsersCh,
-		Issues:        <-issuesCh,
-		Notifications: <-notificationsCh,
-	}
-
-	// Calculate fragmentation score after collecting data
-	fragmentationThresholds := collectors.FragmentationThresholds{
-		FocusedMax:    cfg.Fragmentation.FocusedMax,
-		ModerateMax:   cfg.Fragmentation.ModerateMax,
-		FragmentedMin: cfg.Fragmentation.FragmentedMin,
-	}
-	data.Fragmentation = collectors.CalculateFragmentation(ctx, data.Apps, data.Browsers, data.Uptime, fragmentationThresholds)
-
-	// Analyze burnout patterns after collecting primary data
-	burnoutConfig := collectors.DefaultBurnoutConfig()
-	data.Burnout = collectors.CollectBurnout(ctx, data.Screen, data.Browsers, burnoutConfig)
+	started := time.Now()
+	w, err := out.window(started)
+	if err != nil {
+		logger.Error("run failed", "err", err)
+		return err
+	}
+	data := collectSummary(cfg, w)
+	appendRunLog(cfg, &data)
+	logCollectors(logger, &data)
 
+	// JSONL appends its line itself; everything else is captured to the file
+	if out.output != "" && out.format != formatJSONL {
+		err = writeOutputFile(out.output, out.append, func() error { return renderSummary(out, cfg, &data) })
+	} else {
+		err = renderSummary(out, cfg, &data)
+	}
+	if err != nil {
+		logger.Error("run failed", "err", err)
+		return err
+	}
+	logger.Info("run finished", "window", w.Start.Format(time.RFC3339)+"/"+w.End.Format(time.RFC3339), "took", time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+// renderSummary prints data in the format out asks for. With stdout not a
+// terminal, including when it's captured for --output, the TUI falls back
+// to the static summary.
+func renderSummary(out outputOptions, cfg *config.Config, data *SummaryData) error {
 	switch {
-	case asJSON:
-		printJSON(&data)
-	case quiet:
-		printQuiet(cfg, &data)
-	case print || !ui.IsTTY():
-		printHuman(cfg, &data)
+	case out.json:
+		printJSON(data)
+	case out.format == formatMarkdown:
+		printMarkdown(cfg, data)
+	case out.format == formatCSV:
+		printCSV(cfg, data, out.csvDir)
+	case out.format == formatJSONL:
+		return writeJSONL(data, out.output)
+	case out.format == formatPlist:
+		return report.WritePlist(os.Stdout, buildJSON(data))
+	case out.format == formatRaycast:
+		printRaycast(cfg, data)
+	case out.quiet:
+		printQuiet(cfg, data)
+	case out.print || !ui.IsTTY():
+		printHuman(cfg, data)
 	default:
-		runTUI(cfg, &data)
+		runTUI(cfg, data)
 	}
+	return nil
+}
+
+// collectSummary runs the pre-collect hooks, collects w with the rekap
+// package, records today's snapshot in the history store, and hands the
+// results to the post-collect hooks.
+func collectSummary(cfg *config.Config, w collectors.Window) SummaryData {
+	runHooks(cfg, hooks.PreCollect, nil)
+
+	data, err := rekap.Collect(context.Background(), rekap.Options{Config: cfg, Window: w})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// A snapshot stands for a whole day, so partial and past windows
+	// don't overwrite it
+	if cfg.ShouldRecordHistory() && w.WholeDay() && w.Live() {
+		saveHistory(cfg, &data)
+	}
+
+	runHooks(cfg, hooks.PostCollect, &data)
+
+	return data
 }
 
 func runTUI(cfg *config.Config, data *SummaryData) {
 	sections := tui.BuildSections(data, cfg)
-	m := tui.New(sections, cfg)
+	m := tui.New(sections, cfg, data.Window)
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "TUI error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// saveHistory records today's snapshot for multi-day reports and compacts
+// snapshots past the retention window. Best-effort: a failure here should
+// never get in the way of showing today's summary.
+func saveHistory(cfg *config.Config, data *SummaryData) {
+	store, err := history.Open()
+	if err != nil {
+		return
+	}
+	defer store.Close()
+
+	now := time.Now()
+	if err := store.Save(history.FromData(collectors.DayKey(now), data), now.Unix()); err != nil {
+		return
+	}
+	// Today's domains are already redacted; this catches past days saved
+	// before a domain was added to privacy.redact_domains
+	_, _ = redactHistory(cfg, store, false)
+	dayCutoff, weekCutoff := historyCutoffs(cfg, collectors.DayStart(now))
+	_, _ = store.Prune(dayCutoff, weekCutoff)
+}
+
+// redactState records which privacy.redact_domains list the history store
+// was last redacted for, as a hash so the list itself isn't copied
+type redactState struct {
+	Hash string `json:"hash"`
+}
+
+// redactHistory removes the domains privacy.redact_domains matches from
+// every history snapshot, and returns how many it rewrote. Rewriting means
+// reading the whole store, so unless force is set it only runs when the
+// list has changed since the last time.
+func redactHistory(cfg *config.Config, store *history.Store, force bool) (int, error) {
+	sum := sha256.Sum256([]byte(strings.Join(cfg.Privacy.RedactDomains, "\n")))
+	hash := hex.EncodeToString(sum[:])
+
+	var last redactState
+	date, _, err := state.LoadLatest(state.KindRedact, &last)
+	if err != nil {
+		return 0, err
+	}
+	if !force && last.Hash == hash {
+		// Keep the record from aging out of the state store
+		if today := collectors.DayKey(time.Now()); date != today {
+			_ = state.Save(state.KindRedact, today, last)
+		}
+		return 0, nil
+	}
+
+	var n int
+	if len(cfg.Privacy.RedactDomains) > 0 {
+		if n, err = store.Redact(cfg.RedactsDomain); err != nil {
+			return 0, err
+		}
+	}
+	return n, state.Save(state.KindRedact, collectors.DayKey(time.Now()), redactState{Hash: hash})
+}
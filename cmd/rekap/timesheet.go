@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newTimesheetCmd() *cobra.Command {
+	var csvFlag bool
+	var weekFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "timesheet",
+		Short: "Per-project time breakdown for timesheets",
+		Long: `Show time attributed to the projects defined in your config, based on
+app usage and the browser pages you visited. Use --csv to export it.`,
+		Example: `  rekap timesheet
+  rekap timesheet --csv > today.csv
+  rekap timesheet --week --csv > week.csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			if len(cfg.Projects) == 0 {
+				return fmt.Errorf("no projects configured\nAdd a 'projects' section to your config (see 'rekap config init')")
+			}
+
+			var rows []report.TimesheetRow
+			title := "TIMESHEET"
+			if weekFlag {
+				end := thisWeekEnd()
+				from, to := report.WeekRange(end)
+				days, err := loadHistory(from, to)
+				if err != nil {
+					return err
+				}
+				rows = report.TimesheetFromHistory(days)
+				title = "TIMESHEET • " + report.BuildWeek(end, nil).Title()
+			} else {
+				data := collectSummary(cfg, collectors.Today(time.Now()))
+				if !data.Projects.Available {
+					return fmt.Errorf("app usage data unavailable (requires Full Disk Access)\nRun 'rekap init' for setup")
+				}
+				rows = report.TimesheetFromProjects(collectors.DayKey(time.Now()), data.Projects)
+				title = "TIMESHEET • TODAY"
+			}
+
+			if csvFlag {
+				return report.WriteTimesheetCSV(os.Stdout, rows)
+			}
+			printTimesheet(title, rows, weekFlag)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&csvFlag, "csv", false, "Output CSV (date, project, minutes, hours)")
+	cmd.Flags().BoolVar(&weekFlag, "week", false, "Cover this week from recorded history instead of today")
+	return cmd
+}
+
+func printTimesheet(title string, rows []report.TimesheetRow, byDay bool) {
+	fmt.Println(ui.RenderHeader(title))
+
+	if len(rows) == 0 {
+		fmt.Println(ui.RenderHint("No time matched your configured projects"))
+		return
+	}
+
+	// Totals per project, most time first
+	totals := make(map[string]int)
+	for _, r := range rows {
+		totals[r.Project] += r.Minutes
+	}
+	names := make([]string, 0, len(totals))
+	for name := range totals {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if totals[names[i]] != totals[names[j]] {
+			return totals[names[i]] > totals[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		text := fmt.Sprintf("%s • %s (%sh)", name, ui.FormatDuration(totals[name]), locale.Current().FormatFloat(float64(totals[name])/60, 2))
+		fmt.Println(ui.RenderDataPoint("📋", text))
+	}
+
+	if !byDay {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.RenderHeader("BY DAY"))
+	for _, r := range rows {
+		label := r.Date
+		if date, err := time.Parse("2006-01-02", r.Date); err == nil {
+			label = locale.Current().ShortDate(date)
+		}
+		fmt.Println(ui.RenderSubItem(fmt.Sprintf("   %-11s %-20s %s", label, r.Project, ui.FormatDuration(r.Minutes))))
+	}
+}
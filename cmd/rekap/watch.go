@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/alexinslc/rekap/internal/ui/tui"
+)
+
+// minWatchInterval keeps "rekap watch" from collecting back to back; a
+// full collection can take several seconds
+const minWatchInterval = 30 * time.Second
+
+func newWatchCmd() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Keep the summary open and refresh it on an interval",
+		Long: `Open the interactive summary and collect again every --interval (5 minutes
+by default), updating it in place, for an ambient dashboard on a second
+monitor. Press r to refresh now. After midnight (or the configured day
+start) it moves on to the new day.
+
+Each refresh records today's snapshot in history and runs hooks like a
+normal run.`,
+		Example: `  rekap watch
+  rekap watch --interval 1m`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < minWatchInterval {
+				return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+			}
+			if !ui.IsTTY() {
+				return fmt.Errorf("rekap watch needs a terminal; use 'rekap --print' from a scheduled job instead")
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load config: %v\n", err)
+				cfg = config.Fallback()
+			}
+			ui.ApplyColors(cfg)
+			collectors.SetDayStart(cfg.DayStartMinute())
+
+			refresh := func() ([]tui.Section, collectors.Window) {
+				w := collectors.Today(time.Now())
+				data := collectSummary(cfg, w)
+				return tui.BuildSections(&data, cfg), w
+			}
+			sections, w := refresh()
+			m := tui.New(sections, cfg, w).WithRefresh(interval, refresh)
+			if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+				return fmt.Errorf("TUI error: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "How often to collect again, e.g. 1m or 10m")
+	return cmd
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/report"
+	"github.com/alexinslc/rekap/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newWrappedCmd() *cobra.Command {
+	var year int
+
+	cmd := &cobra.Command{
+		Use:   "wrapped",
+		Short: "Your year in review",
+		Long: `Look back at a whole year from the history store: total screen time,
+your top apps and most-visited sites, your longest focus streak, and the
+most fragmented day. Defaults to the current year.`,
+		Example: `  rekap wrapped
+  rekap wrapped --year 2025`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loadHistoryConfig()
+			if year == 0 {
+				year = collectors.DayStart(time.Now()).Year()
+			}
+
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			from, to := report.YearRange(year)
+			days, err := store.Range(from, to)
+			if err != nil {
+				return err
+			}
+			weeks, err := store.Weeks(from, to)
+			if err != nil {
+				return err
+			}
+
+			printWrapped(report.BuildWrapped(year, days, weeks))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&year, "year", 0, "Year to review (default: this year)")
+	return cmd
+}
+
+func printWrapped(w report.Wrapped) {
+	title := ui.RenderTitle(fmt.Sprintf("🎁 rekap wrapped • %d", w.Year), ui.IsTTY())
+	if title != "" {
+		fmt.Println(title)
+	}
+
+	if w.DaysRecorded == 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHint(fmt.Sprintf("No history recorded for %d -- each rekap run saves a snapshot of the day", w.Year)))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.RenderHighlight("⏰", fmt.Sprintf("%d hours of screen time", w.ScreenHours())))
+	fmt.Println(ui.RenderSubItem(w.ScreenTagline()))
+
+	if len(w.TopApps) > 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHeader("TOP APPS"))
+		for i, app := range w.TopApps {
+			text := fmt.Sprintf("%d. %s • %s", i+1, app.Name, ui.FormatDuration(app.Minutes))
+			if i == 0 {
+				fmt.Println(ui.RenderHighlight("📱", text))
+				continue
+			}
+			fmt.Println(ui.RenderDataPoint("📱", text))
+		}
+	}
+
+	if len(w.TopDomains) > 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHeader("MOST VISITED"))
+		for i, d := range w.TopDomains {
+			text := fmt.Sprintf("%d. %s • %d visits", i+1, d.Domain, d.Visits)
+			if i == 0 {
+				fmt.Println(ui.RenderHighlight("🌐", text))
+				continue
+			}
+			fmt.Println(ui.RenderDataPoint("🌐", text))
+		}
+	}
+
+	if records := w.Records(); len(records) > 0 {
+		fmt.Println()
+		fmt.Println(ui.RenderHeader("RECORDS"))
+		for _, l := range records {
+			fmt.Println(ui.RenderDataPoint("🏆", fmt.Sprintf("%-16s %s", l.Label+":", l.Value)))
+		}
+	}
+}
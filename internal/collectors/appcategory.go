@@ -0,0 +1,168 @@
+package collectors
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// App categories, the groups Screen Time's category limits use
+const (
+	AppCategoryProductivity  = "Productivity & Finance"
+	AppCategorySocial        = "Social"
+	AppCategoryEntertainment = "Entertainment"
+	AppCategoryCreativity    = "Creativity"
+	AppCategoryGames         = "Games"
+	AppCategoryEducation     = "Education"
+	AppCategoryReading       = "Information & Reading"
+	AppCategoryHealth        = "Health & Fitness"
+	AppCategoryUtilities     = "Utilities"
+	AppCategoryTravel        = "Travel"
+	AppCategoryOther         = "Other"
+)
+
+// appStoreCategories maps App Store categories, as apps declare them in
+// LSApplicationCategoryType without the "public.app-category." prefix, to
+// Screen Time's groups. Game genres ("action-games") are all Games.
+var appStoreCategories = map[string]string{
+	"productivity":       AppCategoryProductivity,
+	"business":           AppCategoryProductivity,
+	"finance":            AppCategoryProductivity,
+	"developer-tools":    AppCategoryProductivity,
+	"social-networking":  AppCategorySocial,
+	"entertainment":      AppCategoryEntertainment,
+	"music":              AppCategoryEntertainment,
+	"graphics-design":    AppCategoryCreativity,
+	"photography":        AppCategoryCreativity,
+	"video":              AppCategoryCreativity,
+	"games":              AppCategoryGames,
+	"education":          AppCategoryEducation,
+	"news":               AppCategoryReading,
+	"reference":          AppCategoryReading,
+	"weather":            AppCategoryReading,
+	"healthcare-fitness": AppCategoryHealth,
+	"medical":            AppCategoryHealth,
+	"sports":             AppCategoryHealth,
+	"utilities":          AppCategoryUtilities,
+	"travel":             AppCategoryTravel,
+	"lifestyle":          AppCategoryOther,
+}
+
+// AppCategory is the time spent in one category's apps
+type AppCategory struct {
+	Name    string
+	Minutes int
+	Apps    []string // Most time first
+}
+
+// AppCategoriesResult splits app time by category. Like AppSourcesResult
+// it covers the apps in AppsResult.TopApps, not every app used.
+type AppCategoriesResult struct {
+	Categories   []AppCategory // Most time first
+	TotalMinutes int
+	Available    bool
+	Error        error
+}
+
+// appCategoryCache stores each bundle's category so the mdfind and plutil
+// lookups run at most once per bundle per run
+var appCategoryCache sync.Map
+
+// ClassifyAppCategories looks up the App Store category of each top app and
+// totals their time by category
+func ClassifyAppCategories(ctx context.Context, apps AppsResult) AppCategoriesResult {
+	if !apps.Available || len(apps.TopApps) == 0 {
+		return AppCategoriesResult{Available: false}
+	}
+
+	categories := make([]string, len(apps.TopApps))
+	var wg sync.WaitGroup
+	for i, app := range apps.TopApps {
+		wg.Add(1)
+		go func(i int, app AppUsage) {
+			defer wg.Done()
+			categories[i] = lookupAppCategory(ctx, app.BundleID)
+		}(i, app)
+	}
+	wg.Wait()
+
+	return summarizeAppCategories(apps.TopApps, categories)
+}
+
+// summarizeAppCategories totals apps by category; categories[i] is the
+// category of apps[i]
+func summarizeAppCategories(apps []AppUsage, categories []string) AppCategoriesResult {
+	result := AppCategoriesResult{}
+	byName := map[string]*AppCategory{}
+	for i, app := range apps {
+		c := byName[categories[i]]
+		if c == nil {
+			c = &AppCategory{Name: categories[i]}
+			byName[categories[i]] = c
+		}
+		c.Minutes += app.Minutes
+		c.Apps = append(c.Apps, app.Name)
+		result.TotalMinutes += app.Minutes
+	}
+
+	for _, c := range byName {
+		result.Categories = append(result.Categories, *c)
+	}
+	sort.Slice(result.Categories, func(i, j int) bool {
+		a, b := result.Categories[i], result.Categories[j]
+		if a.Minutes != b.Minutes {
+			return a.Minutes > b.Minutes
+		}
+		return a.Name < b.Name
+	})
+	result.Available = len(result.Categories) > 0
+	return result
+}
+
+// lookupAppCategory finds the app bundle for bundleID and reads its
+// category. Apps that can't be found or declare none are Other.
+func lookupAppCategory(ctx context.Context, bundleID string) string {
+	if cached, ok := appCategoryCache.Load(bundleID); ok {
+		return cached.(string)
+	}
+
+	category := AppCategoryOther
+	if bundleID != "" && validBundleID.MatchString(bundleID) {
+		if path := findAppBundle(ctx, bundleID); path != "" {
+			category = screenTimeCategory(readAppStoreCategory(ctx, path))
+		}
+	}
+
+	// Lookups cut short by the run's timeout shouldn't stick
+	if ctx.Err() == nil {
+		appCategoryCache.Store(bundleID, category)
+	}
+	return category
+}
+
+// readAppStoreCategory reads LSApplicationCategoryType from the app's
+// Info.plist, or "" when it declares none
+func readAppStoreCategory(ctx context.Context, path string) string {
+	plist := filepath.Join(path, "Contents", "Info.plist")
+	output, err := exec.CommandContext(ctx, "plutil", "-extract", "LSApplicationCategoryType", "raw", "-o", "-", plist).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// screenTimeCategory maps an LSApplicationCategoryType value such as
+// "public.app-category.developer-tools" to a Screen Time category
+func screenTimeCategory(appStoreCategory string) string {
+	name := strings.ToLower(strings.TrimPrefix(appStoreCategory, "public.app-category."))
+	if category, ok := appStoreCategories[name]; ok {
+		return category
+	}
+	if strings.HasSuffix(name, "-games") {
+		return AppCategoryGames
+	}
+	return AppCategoryOther
+}
@@ -0,0 +1,54 @@
+package collectors
+
+import "testing"
+
+func TestScreenTimeCategory(t *testing.T) {
+	t.Parallel()
+	tests := []struct{ in, want string }{
+		{"public.app-category.developer-tools", AppCategoryProductivity},
+		{"public.app-category.social-networking", AppCategorySocial},
+		{"public.app-category.puzzle-games", AppCategoryGames},
+		{"public.app-category.Music", AppCategoryEntertainment},
+		{"public.app-category.something-new", AppCategoryOther},
+		{"", AppCategoryOther},
+	}
+	for _, tt := range tests {
+		if got := screenTimeCategory(tt.in); got != tt.want {
+			t.Errorf("screenTimeCategory(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeAppCategories(t *testing.T) {
+	t.Parallel()
+	apps := []AppUsage{
+		{Name: "Xcode", Minutes: 120},
+		{Name: "Slack", Minutes: 45},
+		{Name: "Discord", Minutes: 30},
+		{Name: "Terminal", Minutes: 30},
+		{Name: "Messages", Minutes: 10},
+	}
+	categories := []string{AppCategoryProductivity, AppCategoryProductivity, AppCategorySocial, AppCategoryUtilities, AppCategorySocial}
+
+	got := summarizeAppCategories(apps, categories)
+	if !got.Available || got.TotalMinutes != 235 {
+		t.Fatalf("got %+v, want 235 minutes available", got)
+	}
+	want := []struct {
+		name    string
+		minutes int
+		apps    int
+	}{{AppCategoryProductivity, 165, 2}, {AppCategorySocial, 40, 2}, {AppCategoryUtilities, 30, 1}}
+	if len(got.Categories) != len(want) {
+		t.Fatalf("Categories = %+v", got.Categories)
+	}
+	for i, w := range want {
+		c := got.Categories[i]
+		if c.Name != w.name || c.Minutes != w.minutes || len(c.Apps) != w.apps {
+			t.Errorf("Categories[%d] = %+v, want %s with %dm over %d apps", i, c, w.name, w.minutes, w.apps)
+		}
+	}
+	if got.Categories[1].Apps[0] != "Discord" {
+		t.Errorf("Social apps = %v, want Discord first", got.Categories[1].Apps)
+	}
+}
@@ -8,31 +8,56 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
 )
 
 // AppUsage represents usage time for a single app
 type AppUsage struct {
-	Name     string
-	Minutes  int
-	BundleID string
+	Name        string
+	Minutes     int
+	BundleID    string
+	Activations int // Times the app came to the front; 0 when unknown
 }
 
 // AppsResult contains app usage information
 type AppsResult struct {
 	TopApps            []AppUsage
-	Source             string // "ScreenTime" or "Sampling"
+	Source             string // "screentime" or "sampling"
 	Available          bool
 	Error              error
-	ExcludedApps       []string // Apps that were filtered out
-	TotalSwitches      int      // Total number of app switches today
-	AvgMinsBetween     float64  // Average minutes between switches
-	SwitchesPerHour    float64  // Switches per hour rate
-	SwitchingAvailable bool     // Whether switching data is available
+	ExcludedApps       []string  // Apps that were filtered out
+	TotalSwitches      int       // Total number of app switches today
+	AvgMinsBetween     float64   // Average minutes between switches
+	SwitchesPerHour    float64   // Switches per hour rate
+	SwitchingAvailable bool      // Whether switching data is available
+	FirstActivity      time.Time // Start of the first app usage event in range
+	LastActivity       time.Time // End of the last app usage event in range
+}
+
+// CollectApps retrieves top app usage in w from source (see
+// config.SourcesConfig). With config.SourceAuto it reads the Screen Time
+// database and falls back to frontmost-app sampling when that's unreadable
+// (usually missing Full Disk Access) or empty.
+func CollectApps(ctx context.Context, excludedApps []string, source string, w Window) AppsResult {
+	if source == config.SourceSampling {
+		return collectAppsSampling(ctx, excludedApps, w)
+	}
+
+	startTimestamp, endTimestamp := timestampRange(w)
+	result := collectApps(ctx, excludedApps, startTimestamp, endTimestamp)
+	if result.Available || source == config.SourceScreenTime {
+		return result
+	}
+	if sampled := collectAppsSampling(ctx, excludedApps, w); sampled.Available {
+		return sampled
+	}
+	return result
 }
 
-// CollectApps retrieves top app usage from Screen Time database
-func CollectApps(ctx context.Context, excludedApps []string) AppsResult {
-	result := AppsResult{Available: false, Source: "ScreenTime"}
+func collectApps(ctx context.Context, excludedApps []string, startTimestamp, endTimestamp float64) AppsResult {
+	result := AppsResult{Available: false, Source: config.SourceScreenTime}
 	result.ExcludedApps = excludedApps
 
 	db, err := openKnowledgeDB()
@@ -46,8 +71,6 @@ func CollectApps(ctx context.Context, excludedApps []string) AppsResult {
 		}
 	}()
 
-	startTimestamp, endTimestamp := todayTimestampRange()
-
 	// Query app usage from ZOBJECT table
 	// ZSTREAMNAME contains app usage data, ZVALUESTRING has bundle IDs
 	query := `
@@ -55,17 +78,16 @@ func CollectApps(ctx context.Context, excludedApps []string) AppsResult {
 			ZVALUESTRING as bundle_id,
 			SUM((ZENDDATE - ZSTARTDATE)) as duration_seconds
 		FROM ZOBJECT
-		WHERE ZSTREAMNAME = '/app/usage'
+		WHERE ZSTREAMNAME = ?
 			AND ZSTARTDATE >= ?
 			AND ZENDDATE <= ?
 			AND ZVALUESTRING IS NOT NULL
 			AND ZVALUESTRING != ''
 		GROUP BY ZVALUESTRING
 		ORDER BY duration_seconds DESC
-		LIMIT 10
 	`
 
-	rows, err := db.QueryContext(ctx, query, startTimestamp, endTimestamp)
+	rows, err := db.QueryContext(ctx, query, appUsageStream(), startTimestamp, endTimestamp)
 	if err != nil {
 		result.Error = fmt.Errorf("failed to query Screen Time data: %w", err)
 		return result
@@ -89,7 +111,7 @@ func CollectApps(ctx context.Context, excludedApps []string) AppsResult {
 		appName := resolveAppName(bundleID)
 
 		// Skip if app is in exclusion list
-		if isExcluded(appName, excludedApps) {
+		if isExcluded(appName, bundleID, excludedApps) {
 			continue
 		}
 
@@ -104,8 +126,13 @@ func CollectApps(ctx context.Context, excludedApps []string) AppsResult {
 		}
 	}
 
+	// Excluded apps are skipped before the cut, so they don't take top spots
+	if len(apps) > 10 {
+		apps = apps[:10]
+	}
 	result.TopApps = apps
 	result.Available = len(apps) > 0
+	result.FirstActivity, result.LastActivity = appActivityBounds(ctx, db, startTimestamp, endTimestamp)
 
 	// Calculate app switching statistics
 	switchStats := calculateAppSwitching(ctx, db, startTimestamp, endTimestamp, excludedApps)
@@ -113,18 +140,35 @@ func CollectApps(ctx context.Context, excludedApps []string) AppsResult {
 	result.AvgMinsBetween = switchStats.avgMinsBetween
 	result.SwitchesPerHour = switchStats.switchesPerHour
 	result.SwitchingAvailable = switchStats.available
+	for i, app := range apps {
+		result.TopApps[i].Activations = switchStats.activations[app.BundleID]
+	}
 
 	return result
 }
 
-// isExcluded checks if an app name is in the exclusion list
-func isExcluded(appName string, excludedApps []string) bool {
-	for _, excluded := range excludedApps {
-		if excluded == appName {
-			return true
-		}
+// appActivityBounds returns the start of the first and the end of the last
+// app usage event in range, or zero times when there are none
+func appActivityBounds(ctx context.Context, db *sql.DB, startTimestamp, endTimestamp float64) (time.Time, time.Time) {
+	query := `
+		SELECT MIN(ZSTARTDATE), MAX(ZENDDATE)
+		FROM ZOBJECT
+		WHERE ZSTREAMNAME = ?
+			AND ZSTARTDATE >= ?
+			AND ZENDDATE <= ?
+			AND ZVALUESTRING IS NOT NULL
+			AND ZVALUESTRING != ''
+	`
+	var first, last sql.NullFloat64
+	if err := db.QueryRowContext(ctx, query, appUsageStream(), startTimestamp, endTimestamp).Scan(&first, &last); err != nil || !first.Valid || !last.Valid {
+		return time.Time{}, time.Time{}
 	}
-	return false
+	return coreDataTime(first.Float64), coreDataTime(last.Float64)
+}
+
+// isExcluded checks if an app is in the exclusion list, by name or bundle ID
+func isExcluded(appName, bundleID string, excludedApps []string) bool {
+	return config.MatchApp(excludedApps, appName, bundleID)
 }
 
 // validBundleID matches reverse-DNS bundle identifiers (alphanumeric, dots, hyphens, underscores)
@@ -135,10 +179,15 @@ var appNameCache sync.Map
 
 // resolveAppName converts a bundle ID to a human-readable app name.
 // Results are cached globally so each bundle ID is resolved at most once per run.
+// In fast mode, names not cached yet come from the bundle ID and aren't
+// cached, so a later full run still asks Finder.
 func resolveAppName(bundleID string) string {
 	if cached, ok := appNameCache.Load(bundleID); ok {
 		return cached.(string)
 	}
+	if fastMode.Load() {
+		return appNameFromBundleID(bundleID)
+	}
 
 	name := resolveAppNameUncached(bundleID)
 	appNameCache.Store(bundleID, name)
@@ -159,19 +208,21 @@ func resolveAppNameUncached(bundleID string) string {
 		}
 	}
 
-	// Fallback: extract last component from bundle ID
-	parts := strings.Split(bundleID, ".")
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
-	}
+	return appNameFromBundleID(bundleID)
+}
 
-	return bundleID
+// appNameFromBundleID guesses a name from the bundle ID's last component,
+// "Chrome" for "com.google.Chrome"
+func appNameFromBundleID(bundleID string) string {
+	parts := strings.Split(bundleID, ".")
+	return parts[len(parts)-1]
 }
 
 type appSwitchingStats struct {
 	totalSwitches   int
 	avgMinsBetween  float64
 	switchesPerHour float64
+	activations     map[string]int // Per bundle ID
 	available       bool
 }
 
@@ -186,7 +237,7 @@ func calculateAppSwitching(ctx context.Context, db *sql.DB, startTimestamp, endT
 			ZSTARTDATE,
 			ZENDDATE
 		FROM ZOBJECT
-		WHERE ZSTREAMNAME = '/app/usage'
+		WHERE ZSTREAMNAME = ?
 			AND ZSTARTDATE >= ?
 			AND ZENDDATE <= ?
 			AND ZVALUESTRING IS NOT NULL
@@ -194,7 +245,7 @@ func calculateAppSwitching(ctx context.Context, db *sql.DB, startTimestamp, endT
 		ORDER BY ZSTARTDATE ASC
 	`
 
-	rows, err := db.QueryContext(ctx, query, startTimestamp, endTimestamp)
+	rows, err := db.QueryContext(ctx, query, appUsageStream(), startTimestamp, endTimestamp)
 	if err != nil {
 		return stats
 	}
@@ -222,7 +273,7 @@ func calculateAppSwitching(ctx context.Context, db *sql.DB, startTimestamp, endT
 		}
 
 		// Skip excluded apps (resolveAppName is globally cached)
-		if isExcluded(resolveAppName(bundleID), excludedApps) {
+		if isExcluded(resolveAppName(bundleID), bundleID, excludedApps) {
 			continue
 		}
 
@@ -233,6 +284,12 @@ func calculateAppSwitching(ctx context.Context, db *sql.DB, startTimestamp, endT
 		})
 	}
 
+	bundleIDs := make([]string, len(events))
+	for i, e := range events {
+		bundleIDs[i] = e.bundleID
+	}
+	stats.activations = countAppActivations(bundleIDs)
+
 	if len(events) < 2 {
 		// Need at least 2 events to calculate switches
 		return stats
@@ -278,3 +335,17 @@ func calculateAppSwitching(ctx context.Context, db *sql.DB, startTimestamp, endT
 	stats.available = true
 	return stats
 }
+
+// countAppActivations counts, per app, the times it came to the front in a
+// time-ordered run of focus events: each time it takes over from another
+// app. knowledgeC records focus rather than launches and quits, so these
+// are switches to the app, not launches.
+func countAppActivations(apps []string) map[string]int {
+	activations := make(map[string]int)
+	for i, app := range apps {
+		if i == 0 || apps[i-1] != app {
+			activations[app]++
+		}
+	}
+	return activations
+}
@@ -0,0 +1,167 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// App install sources
+const (
+	AppSourceApple      = "apple"       // Ships with macOS or is made by Apple
+	AppSourceAppStore   = "app_store"   // Installed from the Mac App Store
+	AppSourceThirdParty = "third_party" // Downloaded or installed some other way
+)
+
+// AppOrigin is where an app came from and whether it runs in the App Sandbox
+type AppOrigin struct {
+	Name      string
+	BundleID  string
+	Minutes   int
+	Source    string // AppSourceApple, AppSourceAppStore, or AppSourceThirdParty
+	Sandboxed bool
+}
+
+// AppSourcesResult splits app time by install source. It covers the apps
+// in AppsResult.TopApps, not every app used.
+type AppSourcesResult struct {
+	Apps                  []AppOrigin
+	AppleMinutes          int
+	AppStoreMinutes       int
+	ThirdPartyMinutes     int
+	UnsandboxedMinutes    int // Third-party time outside the App Sandbox
+	UnsandboxedThirdParty []string
+	Available             bool
+	Error                 error
+}
+
+// appOriginCache stores each bundle's install source and sandboxing so the
+// mdfind and codesign lookups run at most once per bundle per run
+var appOriginCache sync.Map
+
+// ClassifyAppSources looks up the install source and sandboxing of each
+// top app and totals their time
+func ClassifyAppSources(ctx context.Context, apps AppsResult) AppSourcesResult {
+	result := AppSourcesResult{Available: false}
+	if !apps.Available || len(apps.TopApps) == 0 {
+		return result
+	}
+
+	origins := make([]AppOrigin, len(apps.TopApps))
+	var wg sync.WaitGroup
+	for i, app := range apps.TopApps {
+		wg.Add(1)
+		go func(i int, app AppUsage) {
+			defer wg.Done()
+			origin := lookupAppOrigin(ctx, app.BundleID)
+			origin.Name, origin.BundleID, origin.Minutes = app.Name, app.BundleID, app.Minutes
+			origins[i] = origin
+		}(i, app)
+	}
+	wg.Wait()
+
+	return summarizeAppOrigins(origins)
+}
+
+// summarizeAppOrigins totals classified apps by install source
+func summarizeAppOrigins(origins []AppOrigin) AppSourcesResult {
+	result := AppSourcesResult{Apps: origins}
+	for _, o := range origins {
+		switch o.Source {
+		case AppSourceApple:
+			result.AppleMinutes += o.Minutes
+		case AppSourceAppStore:
+			result.AppStoreMinutes += o.Minutes
+		default:
+			result.ThirdPartyMinutes += o.Minutes
+			if !o.Sandboxed {
+				result.UnsandboxedMinutes += o.Minutes
+				result.UnsandboxedThirdParty = append(result.UnsandboxedThirdParty, o.Name)
+			}
+		}
+	}
+	result.Available = len(origins) > 0
+	return result
+}
+
+// lookupAppOrigin finds the app bundle for bundleID and inspects it. Apps
+// that can't be found (sampled names, removed apps) count as third-party
+// and unsandboxed, the conservative reading.
+func lookupAppOrigin(ctx context.Context, bundleID string) AppOrigin {
+	if cached, ok := appOriginCache.Load(bundleID); ok {
+		return cached.(AppOrigin)
+	}
+
+	var path string
+	if bundleID != "" && validBundleID.MatchString(bundleID) {
+		path = findAppBundle(ctx, bundleID)
+	}
+
+	hasReceipt := false
+	if path != "" {
+		_, err := os.Stat(filepath.Join(path, "Contents", "_MASReceipt", "receipt"))
+		hasReceipt = err == nil
+	}
+	origin := AppOrigin{Source: classifyAppSource(bundleID, hasReceipt)}
+	if path != "" {
+		origin.Sandboxed = appSandboxed(ctx, path)
+	}
+
+	// Lookups cut short by the run's timeout shouldn't stick
+	if ctx.Err() == nil {
+		appOriginCache.Store(bundleID, origin)
+	}
+	return origin
+}
+
+// classifyAppSource decides an app's install source from its bundle ID and
+// whether its bundle carries a Mac App Store receipt
+func classifyAppSource(bundleID string, hasReceipt bool) string {
+	switch {
+	case hasReceipt:
+		return AppSourceAppStore
+	case strings.HasPrefix(bundleID, "com.apple."):
+		return AppSourceApple
+	}
+	return AppSourceThirdParty
+}
+
+// findAppBundle asks Spotlight for the .app bundle with bundleID
+func findAppBundle(ctx context.Context, bundleID string) string {
+	cmd := exec.CommandContext(ctx, "mdfind", "kMDItemCFBundleIdentifier == '"+bundleID+"'")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasSuffix(line, ".app") {
+			return line
+		}
+	}
+	return ""
+}
+
+// appSandboxed reports whether the app at path is signed with the App
+// Sandbox entitlement
+func appSandboxed(ctx context.Context, path string) bool {
+	cmd := exec.CommandContext(ctx, "codesign", "-d", "--entitlements", "-", "--xml", path)
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	return entitlementsSandboxed(string(output))
+}
+
+// entitlementsSandboxed reports whether an entitlements plist turns on
+// com.apple.security.app-sandbox
+func entitlementsSandboxed(plist string) bool {
+	const key = "<key>com.apple.security.app-sandbox</key>"
+	i := strings.Index(plist, key)
+	if i < 0 {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(plist[i+len(key):]), "<true/>")
+}
@@ -0,0 +1,159 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+// How well screen and input activity back up the awake time
+const (
+	AwakeConfidenceHigh   = "high"   // Screen events from the pmset log account for the awake time
+	AwakeConfidenceMedium = "medium" // Coarser screen data, or a large share was taken out as idle
+	AwakeConfidenceLow    = "low"    // No screen data to check against
+)
+
+// idleAwakeShare is the share of raw awake time that, once taken out as
+// idle, lowers confidence to medium
+const idleAwakeShare = 0.25
+
+var hidIdlePattern = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// readInputIdle returns the time since the last keyboard or mouse input
+func readInputIdle(ctx context.Context) (time.Duration, error) {
+	cmd := exec.CommandContext(ctx, "ioreg", "-c", "IOHIDSystem", "-d", "4")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read input idle time: %w", err)
+	}
+	return parseHIDIdleTime(string(output))
+}
+
+// parseHIDIdleTime reads HIDIdleTime (nanoseconds) from ioreg output
+func parseHIDIdleTime(output string) (time.Duration, error) {
+	m := hidIdlePattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0, fmt.Errorf("HIDIdleTime not found")
+	}
+	ns, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid HIDIdleTime %q", m[1])
+	}
+	return time.Duration(ns), nil
+}
+
+// ReconcileAwake checks uptime's awake time against screen-on periods and
+// input idle time. Display-off stretches with at least SessionGap of awake
+// time the pmset log doesn't record as sleep (a closed lid on power, a
+// caffeinate assertion) and, for live windows, a current input idle of at
+// least SessionGap are taken out of AwakeMinutes. Shorter gaps are breaks
+// and stay in.
+func ReconcileAwake(uptime UptimeResult, screen ScreenResult, w Window) UptimeResult {
+	if !uptime.Available || uptime.Confidence != "" {
+		return uptime
+	}
+	uptime.RawAwakeMinutes = uptime.AwakeMinutes
+
+	if !screen.Available || screen.Source == ScreenSourceEstimate || len(screen.OnPeriods) == 0 {
+		uptime.Confidence = AwakeConfidenceLow
+		return uptime
+	}
+
+	awakeStart, awakeEnd, ok := w.clip(uptime.BootTime, w.End)
+	if !ok {
+		uptime.Confidence = AwakeConfidenceHigh
+		return uptime
+	}
+
+	// Periods with evidence of use: screen on, minus the current input idle
+	active := append([]Period(nil), screen.OnPeriods...)
+	sort.Slice(active, func(i, j int) bool { return active[i].Start.Before(active[j].Start) })
+	if uptime.InputIdle >= SessionGap {
+		active = trimPeriods(active, awakeEnd.Add(-uptime.InputIdle))
+	}
+
+	var idle time.Duration
+	for _, gap := range uncovered(Period{Start: awakeStart, End: awakeEnd}, active) {
+		if awake := gap.End.Sub(gap.Start) - overlap(gap, uptime.SleepPeriods); awake >= SessionGap {
+			idle += awake
+		}
+	}
+
+	uptime.IdleAwakeMinutes = int(idle.Minutes())
+	uptime.AwakeMinutes = max(uptime.RawAwakeMinutes-uptime.IdleAwakeMinutes, 0)
+	uptime.FormattedTime = formatAwake(uptime.AwakeMinutes)
+
+	uptime.Confidence = AwakeConfidenceHigh
+	if screen.Source != config.SourcePmset || float64(uptime.IdleAwakeMinutes) > idleAwakeShare*float64(uptime.RawAwakeMinutes) {
+		uptime.Confidence = AwakeConfidenceMedium
+	}
+	return uptime
+}
+
+// trimPeriods cuts sorted periods off at end
+func trimPeriods(periods []Period, end time.Time) []Period {
+	var trimmed []Period
+	for _, p := range periods {
+		if !p.Start.Before(end) {
+			break
+		}
+		if p.End.After(end) {
+			p.End = end
+		}
+		trimmed = append(trimmed, p)
+	}
+	return trimmed
+}
+
+// uncovered returns the parts of span that none of the sorted periods cover
+func uncovered(span Period, periods []Period) []Period {
+	var gaps []Period
+	cursor := span.Start
+	for _, p := range periods {
+		if p.Start.After(cursor) {
+			gaps = append(gaps, Period{Start: cursor, End: minTime(p.Start, span.End)})
+		}
+		if p.End.After(cursor) {
+			cursor = p.End
+		}
+		if !cursor.Before(span.End) {
+			return gaps
+		}
+	}
+	if cursor.Before(span.End) {
+		gaps = append(gaps, Period{Start: cursor, End: span.End})
+	}
+	return gaps
+}
+
+// overlap returns how much of p the periods cover, assuming they don't
+// overlap each other
+func overlap(p Period, periods []Period) time.Duration {
+	var total time.Duration
+	for _, q := range periods {
+		start, end := q.Start, q.End
+		if start.Before(p.Start) {
+			start = p.Start
+		}
+		if end.After(p.End) {
+			end = p.End
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestReconcileAwake(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 18, hour, min, 0, 0, time.Local)
+	}
+	w := Window{Start: at(0, 0), End: at(18, 0)}
+
+	// Booted yesterday and never slept; the lid was closed until 8:00 and
+	// over a 2h lunch, with a 20-minute coffee break in the afternoon
+	uptime := UptimeResult{BootTime: at(0, 0).Add(-time.Hour), AwakeMinutes: 18 * 60, Available: true}
+	screen := ScreenResult{
+		OnPeriods: []Period{
+			{Start: at(8, 0), End: at(12, 0)},
+			{Start: at(14, 0), End: at(15, 0)},
+			{Start: at(15, 20), End: at(18, 0)},
+		},
+		Source:    config.SourcePmset,
+		Available: true,
+	}
+
+	got := ReconcileAwake(uptime, screen, w)
+	if got.RawAwakeMinutes != 18*60 || got.IdleAwakeMinutes != 10*60 || got.AwakeMinutes != 8*60 {
+		t.Errorf("got raw %d, idle %d, awake %d; want 1080, 600, 480", got.RawAwakeMinutes, got.IdleAwakeMinutes, got.AwakeMinutes)
+	}
+	if got.Confidence != AwakeConfidenceMedium || got.FormattedTime != "8h 0m awake" {
+		t.Errorf("got %q %q, want medium confidence and 8h 0m awake", got.Confidence, got.FormattedTime)
+	}
+
+	// Time the pmset log already records as sleep isn't taken out twice
+	uptime.SleepPeriods = []Period{{Start: at(0, 0), End: at(7, 45)}}
+	uptime.AwakeMinutes -= 465
+	got = ReconcileAwake(uptime, screen, w)
+	if got.IdleAwakeMinutes != 2*60 || got.AwakeMinutes != 495 || got.Confidence != AwakeConfidenceHigh {
+		t.Errorf("with sleep: idle %d, awake %d, %q; want 120, 495, high", got.IdleAwakeMinutes, got.AwakeMinutes, got.Confidence)
+	}
+
+	// An idle keyboard counts as idle even with the screen on
+	uptime.InputIdle = 45 * time.Minute
+	got = ReconcileAwake(uptime, screen, w)
+	if got.IdleAwakeMinutes != 2*60+45 {
+		t.Errorf("with input idle: idle %d, want 165", got.IdleAwakeMinutes)
+	}
+
+	// Nothing to check against
+	got = ReconcileAwake(UptimeResult{AwakeMinutes: 600, Available: true}, ScreenResult{Source: ScreenSourceEstimate, Available: true}, w)
+	if got.Confidence != AwakeConfidenceLow || got.AwakeMinutes != 600 {
+		t.Errorf("estimate: %q, %d; want low and unchanged", got.Confidence, got.AwakeMinutes)
+	}
+}
+
+func TestParseHIDIdleTime(t *testing.T) {
+	t.Parallel()
+	out := `    | |   "HIDIdleTime" = 2794123456
+    | |   "HIDParameters" = {}`
+	idle, err := parseHIDIdleTime(out)
+	if err != nil || idle != 2794123456*time.Nanosecond {
+		t.Errorf("parseHIDIdleTime() = %v, %v", idle, err)
+	}
+	if _, err := parseHIDIdleTime("no idle here"); err == nil {
+		t.Error("expected an error without HIDIdleTime")
+	}
+}
@@ -9,8 +9,19 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// Battery conditions in BatteryResult
+const (
+	BatteryConditionNormal  = "Normal"
+	BatteryConditionService = "Service Recommended"
 )
 
+// batteryServiceHealthPct is the health below which macOS recommends service
+const batteryServiceHealthPct = 80
+
 // BatteryResult contains battery usage information
 type BatteryResult struct {
 	StartPct   int
@@ -19,90 +30,284 @@ type BatteryResult struct {
 	Available  bool
 	IsPlugged  bool
 	Error      error
+
+	CycleCount int
+	HealthPct  int    // Full-charge capacity as a share of design capacity; 0 when unknown
+	Condition  string // BatteryConditionNormal or BatteryConditionService; empty when unknown
+
+	UsedWh         float64 // Energy drawn from the battery in the window, estimated from level drops
+	BatteryMinutes int     // Time on battery, from the first reading in the window
+	PluggedMinutes int     // Time on AC power, likewise
+	HourlyPct      []int   // Last level in each hour from the first reading's; -1 for hours without one
+
+	// Peripherals are the other batteries connected now, such as a UPS or a
+	// Magic Keyboard. Live windows only; set even without an internal battery.
+	Peripherals []PeripheralBattery
 }
 
-// CollectBattery retrieves current battery status
-func CollectBattery(ctx context.Context) BatteryResult {
+// Peripheral battery kinds
+const (
+	PeripheralUPS      = "ups"
+	PeripheralKeyboard = "keyboard"
+	PeripheralTrackpad = "trackpad"
+	PeripheralMouse    = "mouse"
+	PeripheralOther    = "other"
+)
+
+// PeripheralLowPct is the level at or below which a peripheral needs charging
+const PeripheralLowPct = 20
+
+// PeripheralBattery is the level of a battery other than the Mac's own
+type PeripheralBattery struct {
+	Name string
+	Kind string // PeripheralUPS, PeripheralKeyboard, ...
+	Pct  int
+}
+
+// CollectBattery retrieves battery usage in w. Live windows read the current
+// level from pmset; past windows take it from the last log reading inside
+// the window.
+func CollectBattery(ctx context.Context, w Window) BatteryResult {
 	result := BatteryResult{Available: false}
+	log := parsePmsetLog(ctx, w)
+
+	if w.Live() {
+		currentPct, plugged, ups, err := readBatteryStatus(ctx)
+		result.Peripherals = append(ups, readPeripheralBatteries(ctx)...)
+		if err != nil {
+			result.Error = err
+			return result
+		}
+		result.CurrentPct = currentPct
+		result.IsPlugged = plugged
+		log.readings = append(log.readings, batteryPoint{At: time.Now(), Pct: currentPct, AC: plugged})
+	} else {
+		if log.startPct < 0 {
+			result.Error = fmt.Errorf("no battery readings in the pmset log for this window")
+			return result
+		}
+		result.CurrentPct = log.endPct
+		result.IsPlugged = log.endPlugged
+	}
+	result.Available = true
+	result.PlugCount = log.plugCount
+
+	health, err := readBatteryHealth(ctx)
+	if err == nil {
+		result.CycleCount = health.cycleCount
+		result.HealthPct = health.healthPct()
+		result.Condition = health.condition()
+	}
+	applyBatteryUsage(&result, log.readings, health, w)
+
+	// The persisted morning reading covers what the log misses, but it's
+	// the start of the day, so it only applies to whole-day windows
+	switch {
+	case w.WholeDay() && w.Live():
+		result.StartPct = startBaseline(log.startPct, log.startAt, result.CurrentPct)
+	case log.startPct >= 0:
+		result.StartPct = log.startPct
+	default:
+		result.StartPct = result.CurrentPct
+	}
+
+	return result
+}
 
-	// Get current battery percentage using pmset
+// readBatteryStatus returns the current battery percentage, whether the
+// machine is on AC power, and any UPS pmset knows of
+func readBatteryStatus(ctx context.Context) (int, bool, []PeripheralBattery, error) {
 	cmd := exec.CommandContext(ctx, "pmset", "-g", "batt")
 	output, err := cmd.Output()
 	if err != nil {
-		result.Error = fmt.Errorf("failed to read battery status: %w", err)
-		return result
+		return 0, false, nil, fmt.Errorf("failed to read battery status: %w", err)
 	}
+	return parsePmsetBatt(string(output))
+}
 
-	outputStr := string(output)
+// pmsetBattPattern matches a power source line of "pmset -g batt", e.g.
+// " -InternalBattery-0 (id=4653155)	68%; discharging; 4:12 remaining"
+var pmsetBattPattern = regexp.MustCompile(`^\s*-(.+?)\s+\(id=\d+\)\s+(\d+)%`)
 
-	// Parse current percentage
-	re := regexp.MustCompile(`(\d+)%`)
-	matches := re.FindStringSubmatch(outputStr)
-	if len(matches) < 2 {
-		result.Error = fmt.Errorf("failed to parse battery percentage")
-		return result
+// parsePmsetBatt reads "pmset -g batt" output. The internal battery is
+// named InternalBattery; any other source is a UPS.
+func parsePmsetBatt(output string) (int, bool, []PeripheralBattery, error) {
+	pct := -1
+	var ups []PeripheralBattery
+	for _, line := range strings.Split(output, "\n") {
+		m := pmsetBattPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[2])
+		if strings.HasPrefix(m[1], "InternalBattery") {
+			pct = n
+			continue
+		}
+		ups = append(ups, PeripheralBattery{Name: m[1], Kind: PeripheralUPS, Pct: n})
+	}
+	if pct < 0 {
+		return 0, false, ups, fmt.Errorf("no internal battery")
 	}
 
-	currentPct, err := strconv.Atoi(matches[1])
+	plugged := strings.Contains(output, "AC Power") || strings.Contains(output, "charged")
+	return pct, plugged, ups, nil
+}
+
+// readPeripheralBatteries lists the Bluetooth and USB devices that report a
+// battery level, such as a Magic Keyboard, Trackpad, or Mouse
+func readPeripheralBatteries(ctx context.Context) []PeripheralBattery {
+	output, err := exec.CommandContext(ctx, "ioreg", "-r", "-l", "-k", "BatteryPercent").Output()
 	if err != nil {
-		result.Error = fmt.Errorf("failed to parse battery percentage: %w", err)
-		return result
+		return nil
 	}
+	return parsePeripheralBatteries(string(output))
+}
 
-	result.CurrentPct = currentPct
-	result.IsPlugged = strings.Contains(outputStr, "AC Power") || strings.Contains(outputStr, "charged")
-	result.Available = true
+// ioregStringPattern matches a top-level string property in ioreg output,
+// e.g. `"Product" = "Magic Keyboard"`
+var ioregStringPattern = regexp.MustCompile(`(?m)^[\s|]*"(\w+)" = "([^"]*)"$`)
 
-	// Parse pmset log for start percentage and plug events since midnight
-	startPct, plugCount := parsePmsetLog(ctx)
-	if startPct >= 0 {
-		result.StartPct = startPct
-	} else {
-		result.StartPct = currentPct
+// parsePeripheralBatteries reads "ioreg -r -l -k BatteryPercent" output,
+// one "+-o" entry per device. A device listed twice, as happens for ones
+// both paired and plugged in, is kept once.
+func parsePeripheralBatteries(output string) []PeripheralBattery {
+	var devices []PeripheralBattery
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(output, "+-o ")[1:] {
+		strs := map[string]string{}
+		for _, m := range ioregStringPattern.FindAllStringSubmatch(entry, -1) {
+			strs[m[1]] = m[2]
+		}
+		ints := map[string]int{}
+		for _, m := range ioregIntPattern.FindAllStringSubmatch(entry, -1) {
+			ints[m[1]], _ = strconv.Atoi(m[2])
+		}
+		pct, ok := ints["BatteryPercent"]
+		name := strs["Product"]
+		if !ok || name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		devices = append(devices, PeripheralBattery{Name: name, Kind: peripheralKind(name), Pct: pct})
 	}
-	result.PlugCount = plugCount
+	return devices
+}
 
-	return result
+// peripheralKind guesses a device's kind from its product name
+func peripheralKind(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "keyboard"):
+		return PeripheralKeyboard
+	case strings.Contains(name, "trackpad"):
+		return PeripheralTrackpad
+	case strings.Contains(name, "mouse"):
+		return PeripheralMouse
+	}
+	return PeripheralOther
+}
+
+// batteryReading is the earliest battery level rekap knows of for a day
+type batteryReading struct {
+	Pct       int    `json:"pct"`
+	Timestamp string `json:"timestamp"`
+}
+
+// startBaseline returns the day's start percentage from the persisted
+// morning reading, recording one on the first run of the day. The pmset log
+// alone misses the morning on machines that slept through the day boundary
+// (no charge line until much later), which made start and current identical.
+func startBaseline(logPct int, logAt time.Time, currentPct int) int {
+	now := time.Now()
+	date := DayKey(now)
+
+	var saved batteryReading
+	found, err := state.Load(state.KindBattery, date, &saved)
+	if err != nil {
+		// No state store: fall back to the log, then to the current level
+		if logPct >= 0 {
+			return logPct
+		}
+		return currentPct
+	}
+
+	var prev *batteryReading
+	if found {
+		prev = &saved
+	}
+	reading, changed := earliestBatteryReading(prev, logPct, logAt, currentPct, now)
+	if changed {
+		_ = state.Save(state.KindBattery, date, reading)
+	}
+	return reading.Pct
+}
+
+// earliestBatteryReading picks the earliest of the saved reading, the first
+// pmset log reading (logPct < 0 when there is none), and the current level.
+// It reports whether the result differs from saved and needs persisting.
+func earliestBatteryReading(saved *batteryReading, logPct int, logAt time.Time, currentPct int, now time.Time) (batteryReading, bool) {
+	best := batteryReading{Pct: currentPct, Timestamp: now.Format(time.RFC3339)}
+	bestAt := now
+	if saved != nil {
+		if at, err := time.Parse(time.RFC3339, saved.Timestamp); err == nil && !at.After(now) {
+			best, bestAt = *saved, at
+		}
+	}
+	if logPct >= 0 && logAt.Before(bestAt) {
+		best = batteryReading{Pct: logPct, Timestamp: logAt.Format(time.RFC3339)}
+	}
+	return best, saved == nil || best != *saved
 }
 
 // pmset log charge pattern: "Using AC(Charge: 80)" or "Using AC (Charge:80%)" or "Using Batt(Charge: 100)"
 var chargePattern = regexp.MustCompile(`Using (AC|Batt).*?Charge:\s*(\d+)`)
 
 // pmset log timestamp pattern: "2026-02-17 14:30:22 -0700"
-var timestampPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})`)
 
-// parsePmsetLog reads pmset log to find the first battery charge after midnight
-// and count AC plug-in events. Returns (startPct, plugCount) where startPct is -1
-// if no data found.
-func parsePmsetLog(ctx context.Context) (int, int) {
+// batteryLog is what the pmset log says about the battery in a window.
+// startPct is -1 if the window has no charge readings.
+type batteryLog struct {
+	startPct   int
+	startAt    time.Time
+	endPct     int
+	endPlugged bool
+	plugCount  int
+	readings   []batteryPoint // Oldest first
+}
+
+// batteryPoint is one battery level reading
+type batteryPoint struct {
+	At  time.Time
+	Pct int
+	AC  bool
+}
+
+// parsePmsetLog reads pmset log to find the first and last battery charge
+// in w and count AC plug-in events
+func parsePmsetLog(ctx context.Context, w Window) batteryLog {
 	// Use grep to filter relevant lines before processing (keeps it fast on large logs)
 	cmd := exec.CommandContext(ctx, "bash", "-c", "pmset -g log 2>/dev/null | grep -E 'Using (AC|Batt)'")
 	output, err := cmd.Output()
 	if err != nil {
-		return -1, 0
+		return batteryLog{startPct: -1}
 	}
 
-	return parsePmsetLogOutput(string(output))
+	return parsePmsetLogOutput(string(output), w)
 }
 
-// parsePmsetLogOutput parses filtered pmset log output for today's battery data.
-// Returns (startPct, plugCount) where startPct is -1 if no data found.
-func parsePmsetLogOutput(output string) (int, int) {
-	today := time.Now().Format("2006-01-02")
-	startPct := -1
-	plugCount := 0
+// parsePmsetLogOutput parses filtered pmset log output for battery data in w
+func parsePmsetLogOutput(output string, w Window) batteryLog {
+	log := batteryLog{startPct: -1}
 	lastSource := "" // "AC" or "Batt"
 
 	scanner := bufio.NewScanner(strings.NewReader(output))
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		// Only process lines from today
-		tsMatches := timestampPattern.FindStringSubmatch(line)
-		if len(tsMatches) < 2 {
-			continue
-		}
-		if !strings.HasPrefix(tsMatches[1], today) {
+		// Only process lines inside the window
+		ts, ok := parsePmsetTime(line, time.Local)
+		if !ok || ts.Before(w.Start) || !ts.Before(w.End) {
 			continue
 		}
 
@@ -117,17 +322,145 @@ func parsePmsetLogOutput(output string) (int, int) {
 			continue
 		}
 
-		// First charge reading of the day is our start percentage
-		if startPct < 0 {
-			startPct = pct
+		// First charge reading in the window is our start percentage
+		if log.startPct < 0 {
+			log.startPct = pct
+			log.startAt = ts
 		}
+		log.endPct = pct
+		log.endPlugged = source == "AC"
+		log.readings = append(log.readings, batteryPoint{At: ts, Pct: pct, AC: source == "AC"})
 
 		// Count transitions from Batt to AC as plug events
 		if source == "AC" && lastSource == "Batt" {
-			plugCount++
+			log.plugCount++
 		}
 		lastSource = source
 	}
 
-	return startPct, plugCount
+	return log
+}
+
+// batteryHealth is what the battery reports about its wear
+type batteryHealth struct {
+	cycleCount     int
+	designMAh      int
+	maxMAh         int // Full-charge capacity now
+	voltageMV      int
+	permanentFault bool
+}
+
+// healthPct returns the full-charge capacity as a share of the design
+// capacity, or 0 when either is unknown
+func (h batteryHealth) healthPct() int {
+	if h.designMAh <= 0 || h.maxMAh <= 0 {
+		return 0
+	}
+	return min(h.maxMAh*100/h.designMAh, 100)
+}
+
+// condition derives the condition System Settings shows: service is
+// recommended below batteryServiceHealthPct or after a permanent fault
+func (h batteryHealth) condition() string {
+	pct := h.healthPct()
+	switch {
+	case h.permanentFault || (pct > 0 && pct < batteryServiceHealthPct):
+		return BatteryConditionService
+	case pct > 0:
+		return BatteryConditionNormal
+	}
+	return ""
+}
+
+// readBatteryHealth reads the battery's cycle count and capacities from
+// the AppleSmartBattery entry in the I/O registry
+func readBatteryHealth(ctx context.Context) (batteryHealth, error) {
+	output, err := exec.CommandContext(ctx, "ioreg", "-rn", "AppleSmartBattery").Output()
+	if err != nil {
+		return batteryHealth{}, fmt.Errorf("failed to read battery health: %w", err)
+	}
+	return parseBatteryHealth(string(output))
+}
+
+// ioregIntPattern matches a top-level integer property in ioreg output,
+// e.g. `"CycleCount" = 412`
+var ioregIntPattern = regexp.MustCompile(`(?m)^[\s|]*"(\w+)" = (\d+)$`)
+
+// parseBatteryHealth reads ioreg's AppleSmartBattery properties. Apple
+// silicon reports MaxCapacity as a percentage and the capacity in mAh as
+// AppleRawMaxCapacity; Intel Macs report MaxCapacity in mAh.
+func parseBatteryHealth(output string) (batteryHealth, error) {
+	props := make(map[string]int)
+	for _, m := range ioregIntPattern.FindAllStringSubmatch(output, -1) {
+		if n, err := strconv.Atoi(m[2]); err == nil {
+			props[m[1]] = n
+		}
+	}
+	if _, ok := props["CycleCount"]; !ok {
+		return batteryHealth{}, fmt.Errorf("no battery found")
+	}
+
+	h := batteryHealth{
+		cycleCount:     props["CycleCount"],
+		designMAh:      props["DesignCapacity"],
+		maxMAh:         props["AppleRawMaxCapacity"],
+		voltageMV:      props["Voltage"],
+		permanentFault: props["PermanentFailureStatus"] != 0,
+	}
+	if h.maxMAh == 0 && props["MaxCapacity"] > 100 {
+		h.maxMAh = props["MaxCapacity"]
+	}
+	return h, nil
+}
+
+// applyBatteryUsage fills in the time on battery and on AC, the energy
+// drawn, and the hourly levels from readings in w. Each reading's power
+// source holds until the next one; level drops on battery are converted to
+// watt-hours with the battery's capacity and voltage when known.
+func applyBatteryUsage(result *BatteryResult, readings []batteryPoint, health batteryHealth, w Window) {
+	if len(readings) == 0 {
+		return
+	}
+
+	end := w.End
+	if now := time.Now(); now.Before(end) {
+		end = now
+	}
+	var onBattery, onAC time.Duration
+	dropped := 0
+	for i, r := range readings {
+		next := end
+		if i+1 < len(readings) {
+			next = readings[i+1].At
+			if !r.AC && r.Pct > readings[i+1].Pct {
+				dropped += r.Pct - readings[i+1].Pct
+			}
+		}
+		if next.After(r.At) {
+			if r.AC {
+				onAC += next.Sub(r.At)
+			} else {
+				onBattery += next.Sub(r.At)
+			}
+		}
+	}
+	result.BatteryMinutes = int(onBattery.Minutes())
+	result.PluggedMinutes = int(onAC.Minutes())
+	if health.maxMAh > 0 && health.voltageMV > 0 {
+		result.UsedWh = float64(dropped) / 100 * float64(health.maxMAh) * float64(health.voltageMV) / 1e6
+	}
+
+	at := readings[0].At
+	first := time.Date(at.Year(), at.Month(), at.Day(), at.Hour(), 0, 0, 0, at.Location())
+	hours := int(readings[len(readings)-1].At.Sub(first).Hours()) + 1
+	if hours < 2 || hours > 48 {
+		return
+	}
+	result.HourlyPct = make([]int, hours)
+	for i := range result.HourlyPct {
+		result.HourlyPct[i] = -1
+	}
+	for _, r := range readings {
+		result.HourlyPct[int(r.At.Sub(first).Hours())] = r.Pct
+	}
 }
@@ -6,7 +6,9 @@ import (
 )
 
 func TestParsePmsetLogOutput(t *testing.T) {
-	today := time.Now().Format("2006-01-02")
+	today := "2026-03-10"
+	start := time.Date(2026, 3, 10, 0, 0, 0, 0, time.FixedZone("MST", -7*3600)) // The fixtures' offset
+	w := Window{Start: start, End: start.AddDate(0, 0, 1)}
 
 	tests := []struct {
 		name          string
@@ -15,7 +17,7 @@ func TestParsePmsetLogOutput(t *testing.T) {
 		wantPlugCount int
 	}{
 		{
-			name:          "no data from today",
+			name:          "no data in window",
 			lines:         "2020-01-01 10:00:00 -0700 Assertions Summary- Using AC(Charge: 50)\n",
 			wantStartPct:  -1,
 			wantPlugCount: 0,
@@ -45,6 +47,13 @@ func TestParsePmsetLogOutput(t *testing.T) {
 			wantStartPct:  100,
 			wantPlugCount: 2,
 		},
+		{
+			name: "readings after the window are ignored",
+			lines: today + " 22:00:00 -0700 Using Batt(Charge: 40)\n" +
+				"2026-03-11 08:00:00 -0700 Using AC(Charge: 35)\n",
+			wantStartPct:  40,
+			wantPlugCount: 0,
+		},
 		{
 			name:          "empty input",
 			lines:         "",
@@ -62,13 +71,184 @@ func TestParsePmsetLogOutput(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			startPct, plugCount := parsePmsetLogOutput(tt.lines)
-			if startPct != tt.wantStartPct {
-				t.Errorf("startPct = %d, want %d", startPct, tt.wantStartPct)
+			log := parsePmsetLogOutput(tt.lines, w)
+			if log.startPct != tt.wantStartPct {
+				t.Errorf("startPct = %d, want %d", log.startPct, tt.wantStartPct)
+			}
+			if log.plugCount != tt.wantPlugCount {
+				t.Errorf("plugCount = %d, want %d", log.plugCount, tt.wantPlugCount)
+			}
+		})
+	}
+}
+
+func TestEarliestBatteryReading(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 2, 17, 15, 0, 0, 0, time.Local)
+	morning := time.Date(2026, 2, 17, 8, 30, 0, 0, time.Local)
+	dawn := time.Date(2026, 2, 17, 6, 0, 0, 0, time.Local)
+	saved := &batteryReading{Pct: 92, Timestamp: morning.Format(time.RFC3339)}
+
+	tests := []struct {
+		name        string
+		saved       *batteryReading
+		logPct      int
+		logAt       time.Time
+		wantPct     int
+		wantChanged bool
+	}{
+		{"first run, no log", nil, -1, time.Time{}, 60, true},
+		{"first run, log has an earlier reading", nil, 95, dawn, 95, true},
+		{"later run keeps the saved morning reading", saved, -1, time.Time{}, 92, false},
+		{"log reading after the saved one is ignored", saved, 70, now.Add(-time.Hour), 92, false},
+		{"earlier log reading replaces the saved one", saved, 98, dawn, 98, true},
+		{"saved reading from the future is replaced", &batteryReading{Pct: 10, Timestamp: now.Add(time.Hour).Format(time.RFC3339)}, -1, time.Time{}, 60, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := earliestBatteryReading(tt.saved, tt.logPct, tt.logAt, 60, now)
+			if got.Pct != tt.wantPct {
+				t.Errorf("Pct = %d, want %d", got.Pct, tt.wantPct)
 			}
-			if plugCount != tt.wantPlugCount {
-				t.Errorf("plugCount = %d, want %d", plugCount, tt.wantPlugCount)
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
 			}
 		})
 	}
 }
+
+func TestParseBatteryHealth(t *testing.T) {
+	t.Parallel()
+	appleSilicon := `+-o AppleSmartBattery  <class AppleSmartBattery>
+    {
+      "DesignCapacity" = 6075
+      "AppleRawMaxCapacity" = 5285
+      "MaxCapacity" = 100
+      "CycleCount" = 412
+      "Voltage" = 12650
+      "PermanentFailureStatus" = 0
+      "BatteryData" = {"CycleCount"=412,"DesignCapacity"=6075}
+    }`
+	h, err := parseBatteryHealth(appleSilicon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.cycleCount != 412 || h.healthPct() != 86 || h.condition() != BatteryConditionNormal {
+		t.Errorf("got %d cycles, %d%%, %q; want 412, 86%%, Normal", h.cycleCount, h.healthPct(), h.condition())
+	}
+
+	intel := `"CycleCount" = 1021
+  "DesignCapacity" = 8790
+  "MaxCapacity" = 6500
+  "Voltage" = 12100`
+	if h, err := parseBatteryHealth(intel); err != nil || h.healthPct() != 73 || h.condition() != BatteryConditionService {
+		t.Errorf("Intel = %+v (%d%%, %q), %v; want 73%% and service recommended", h, h.healthPct(), h.condition(), err)
+	}
+
+	if _, err := parseBatteryHealth(""); err == nil {
+		t.Error("no battery: want an error")
+	}
+}
+
+func TestApplyBatteryUsage(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	w := Window{Start: day, End: at(13, 0)}
+
+	readings := []batteryPoint{
+		{At: at(9, 0), Pct: 100, AC: false},
+		{At: at(10, 30), Pct: 80, AC: false},
+		{At: at(11, 0), Pct: 75, AC: true}, // Plugged in
+		{At: at(12, 0), Pct: 90, AC: false},
+	}
+	health := batteryHealth{maxMAh: 5000, voltageMV: 12000}
+
+	var result BatteryResult
+	applyBatteryUsage(&result, readings, health, w)
+	if result.BatteryMinutes != 180 || result.PluggedMinutes != 60 {
+		t.Errorf("got %dm on battery, %dm plugged, want 180 and 60", result.BatteryMinutes, result.PluggedMinutes)
+	}
+	// 25 points of a 60 Wh battery
+	if result.UsedWh < 14.99 || result.UsedWh > 15.01 {
+		t.Errorf("UsedWh = %.2f, want 15", result.UsedWh)
+	}
+	want := []int{100, 80, 75, 90}
+	if len(result.HourlyPct) != len(want) {
+		t.Fatalf("HourlyPct = %v, want %v", result.HourlyPct, want)
+	}
+	for i := range want {
+		if result.HourlyPct[i] != want[i] {
+			t.Errorf("HourlyPct = %v, want %v", result.HourlyPct, want)
+			break
+		}
+	}
+
+	var unknown BatteryResult
+	applyBatteryUsage(&unknown, readings[:1], batteryHealth{}, w)
+	if unknown.UsedWh != 0 || unknown.HourlyPct != nil || unknown.BatteryMinutes != 240 {
+		t.Errorf("one reading = %+v, want only time on battery", unknown)
+	}
+}
+
+func TestParsePmsetBatt(t *testing.T) {
+	t.Parallel()
+	laptop := "Now drawing from 'Battery Power'\n -InternalBattery-0 (id=4653155)\t68%; discharging; 4:12 remaining present: true\n"
+	pct, plugged, ups, err := parsePmsetBatt(laptop)
+	if err != nil || pct != 68 || plugged || len(ups) != 0 {
+		t.Errorf("laptop = %d, %v, %v, %v; want 68%% on battery", pct, plugged, ups, err)
+	}
+
+	desktop := "Now drawing from 'AC Power'\n -CP1500PFCLCD (id=4653056)\t100%; AC attached; not charging present: true\n"
+	_, _, ups, err = parsePmsetBatt(desktop)
+	if err == nil {
+		t.Error("desktop: want an error for the missing internal battery")
+	}
+	if len(ups) != 1 || ups[0] != (PeripheralBattery{Name: "CP1500PFCLCD", Kind: PeripheralUPS, Pct: 100}) {
+		t.Errorf("desktop UPS = %+v", ups)
+	}
+}
+
+func TestParsePeripheralBatteries(t *testing.T) {
+	t.Parallel()
+	output := `+-o AppleDeviceManagementHIDEventService  <class AppleDeviceManagementHIDEventService, id 0x100000a1b>
+    {
+      "Product" = "Magic Keyboard with Touch ID"
+      "BatteryPercent" = 85
+      "BatteryStatusFlags" = 3
+    }
+    
++-o AppleDeviceManagementHIDEventService  <class AppleDeviceManagementHIDEventService, id 0x100000a2c>
+    {
+      "Product" = "Magic Trackpad"
+      "BatteryPercent" = 12
+    }
+    
++-o AppleDeviceManagementHIDEventService  <class AppleDeviceManagementHIDEventService, id 0x100000a3d>
+    {
+      "Product" = "Magic Trackpad"
+      "BatteryPercent" = 12
+    }
+    
++-o IOUSBHostDevice  <class IOUSBHostDevice, id 0x100000a4e>
+    {
+      "BatteryPercent" = 50
+    }
+`
+	got := parsePeripheralBatteries(output)
+	want := []PeripheralBattery{
+		{Name: "Magic Keyboard with Touch ID", Kind: PeripheralKeyboard, Pct: 85},
+		{Name: "Magic Trackpad", Kind: PeripheralTrackpad, Pct: 12},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsePeripheralBatteries = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("device %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -3,6 +3,7 @@ package collectors
 import (
 	"context"
 	"testing"
+	"time"
 )
 
 // Benchmark for CalculateFragmentation - a critical path in the app
@@ -85,6 +86,7 @@ func BenchmarkExtractDomain(b *testing.B) {
 func BenchmarkCollectBurnout(b *testing.B) {
 	ctx := context.Background()
 	config := DefaultBurnoutConfig()
+	w := Today(time.Now())
 
 	screen := ScreenResult{
 		ScreenOnMinutes: 420,
@@ -98,7 +100,7 @@ func BenchmarkCollectBurnout(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		CollectBurnout(ctx, screen, browsers, config)
+		CollectBurnout(ctx, nil, screen, DayBoundsResult{}, browsers, config, w)
 	}
 }
 
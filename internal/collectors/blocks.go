@@ -0,0 +1,232 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+// Kinds of work block
+const (
+	BlockDeepWork = "deep_work"
+	BlockMeeting  = "meeting"
+)
+
+const (
+	// DeepWorkMin is the shortest run in one app that counts as deep work
+	DeepWorkMin = 25 * time.Minute
+
+	// blockDetour is how long another app can be in front (a quick look at
+	// Slack, a Spotlight search) without ending a deep-work block
+	blockDetour = 2 * time.Minute
+
+	// meetingGap is the pause between meeting-app intervals that ends a
+	// meeting; people switch to notes or a doc mid-call
+	meetingGap = 5 * time.Minute
+
+	// meetingMin is the shortest meeting worth putting on a calendar
+	meetingMin = 5 * time.Minute
+)
+
+// meetingApps are the bundle IDs of video call apps
+var meetingApps = map[string]bool{
+	"us.zoom.xos":                true,
+	"com.microsoft.teams":        true,
+	"com.microsoft.teams2":       true,
+	"com.cisco.webexmeetingsapp": true,
+	"com.webex.meetingmanager":   true,
+	"Cisco-Systems.Spark":        true,
+	"com.apple.FaceTime":         true,
+}
+
+// WorkBlock is a stretch of deep work in one app, or a meeting
+type WorkBlock struct {
+	Kind string // BlockDeepWork or BlockMeeting
+	App  string
+	Period
+}
+
+// BlocksResult is the day's deep-work blocks and meetings, found in the
+// sequence of frontmost apps
+type BlocksResult struct {
+	Blocks          []WorkBlock // Oldest first
+	DeepWorkMinutes int
+	MeetingMinutes  int
+	Available       bool
+	Error           error
+}
+
+// appInterval is a span of time with one app in front
+type appInterval struct {
+	Name     string
+	BundleID string
+	Period
+}
+
+// CollectBlocks finds deep-work blocks and meetings in w. Like CollectApps
+// it reads Screen Time intervals and, depending on source, falls back to
+// frontmost-app samples.
+func CollectBlocks(ctx context.Context, excludedApps []string, source string, w Window) BlocksResult {
+	var intervals []appInterval
+	var err error
+	if source != config.SourceSampling {
+		intervals, err = screenTimeIntervals(ctx, w)
+	}
+	if len(intervals) == 0 && source != config.SourceScreenTime {
+		if sampled := sampledIntervals(w); len(sampled) > 0 {
+			intervals, err = sampled, nil
+		}
+	}
+
+	var kept []appInterval
+	for _, iv := range intervals {
+		if !isExcluded(iv.Name, iv.BundleID, excludedApps) {
+			kept = append(kept, iv)
+		}
+	}
+
+	result := detectBlocks(kept)
+	if !result.Available {
+		if err == nil {
+			err = fmt.Errorf("no app usage data found")
+		}
+		result.Error = err
+	}
+	return result
+}
+
+// screenTimeIntervals reads the app usage intervals in w from the Screen
+// Time database, oldest first
+func screenTimeIntervals(ctx context.Context, w Window) ([]appInterval, error) {
+	db, err := openKnowledgeDB()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	startTimestamp, endTimestamp := timestampRange(w)
+	query := `
+		SELECT ZVALUESTRING, ZSTARTDATE, ZENDDATE
+		FROM ZOBJECT
+		WHERE ZSTREAMNAME = ?
+			AND ZSTARTDATE >= ?
+			AND ZENDDATE <= ?
+			AND ZVALUESTRING IS NOT NULL
+			AND ZVALUESTRING != ''
+		ORDER BY ZSTARTDATE ASC
+	`
+	rows, err := db.QueryContext(ctx, query, appUsageStream(), startTimestamp, endTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Screen Time data: %w", err)
+	}
+	defer rows.Close()
+
+	var intervals []appInterval
+	for rows.Next() {
+		var bundleID string
+		var start, end float64
+		if err := rows.Scan(&bundleID, &start, &end); err != nil || systemApps[bundleID] || end <= start {
+			continue
+		}
+		intervals = append(intervals, appInterval{
+			Name:     resolveAppName(bundleID),
+			BundleID: bundleID,
+			Period:   Period{Start: coreDataTime(start), End: coreDataTime(end)},
+		})
+	}
+	return intervals, rows.Err()
+}
+
+// sampledIntervals turns the frontmost-app samples in w into intervals,
+// each lasting as long as its sample stands for
+func sampledIntervals(w Window) []appInterval {
+	var samples []appSample
+	for _, day := range w.dayKeys() {
+		daySamples, _ := loadAppSamples(day)
+		samples = append(samples, daySamples...)
+	}
+
+	var intervals []appInterval
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		intervals = append(intervals, appInterval{Name: s.Name, BundleID: s.BundleID, Period: Period{Start: at}})
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Start.Before(intervals[j].Start) })
+
+	times := make([]time.Time, len(intervals))
+	for i, iv := range intervals {
+		times[i] = iv.Start
+	}
+	for i, credit := range sampleCredits(times, w.End) {
+		intervals[i].End = intervals[i].Start.Add(credit)
+	}
+	return intervals
+}
+
+// detectBlocks finds deep-work blocks and meetings in intervals (oldest
+// first). A deep-work block is a run of at least DeepWorkMin in one app,
+// allowing detours to other apps of up to blockDetour. A meeting is time in
+// a video call app, with pauses shorter than meetingGap bridged.
+func detectBlocks(intervals []appInterval) BlocksResult {
+	result := BlocksResult{Available: false}
+	if len(intervals) == 0 {
+		return result
+	}
+
+	var current *WorkBlock
+	flush := func() {
+		if current != nil && current.End.Sub(current.Start) >= DeepWorkMin {
+			result.Blocks = append(result.Blocks, *current)
+		}
+		current = nil
+	}
+
+	meetings := make(map[string][]Period)
+	for _, iv := range intervals {
+		if meetingApps[iv.BundleID] {
+			meetings[iv.Name] = append(meetings[iv.Name], iv.Period)
+		}
+
+		if current != nil {
+			if iv.Name == current.App && iv.Start.Sub(current.End) <= blockDetour {
+				if iv.End.After(current.End) {
+					current.End = iv.End
+				}
+				continue
+			}
+			if iv.Name != current.App && iv.End.Sub(current.End) <= blockDetour {
+				continue
+			}
+		}
+		flush()
+		if !meetingApps[iv.BundleID] {
+			current = &WorkBlock{Kind: BlockDeepWork, App: iv.Name, Period: iv.Period}
+		}
+	}
+	flush()
+
+	for app, periods := range meetings {
+		for _, p := range groupSessions(periods, meetingGap) {
+			if p.End.Sub(p.Start) >= meetingMin {
+				result.Blocks = append(result.Blocks, WorkBlock{Kind: BlockMeeting, App: app, Period: p})
+			}
+		}
+	}
+
+	sort.Slice(result.Blocks, func(i, j int) bool { return result.Blocks[i].Start.Before(result.Blocks[j].Start) })
+	for _, b := range result.Blocks {
+		if b.Kind == BlockMeeting {
+			result.MeetingMinutes += b.Minutes()
+		} else {
+			result.DeepWorkMinutes += b.Minutes()
+		}
+	}
+	result.Available = true
+	return result
+}
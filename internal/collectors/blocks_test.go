@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectBlocks(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 18, hour, min, 0, 0, time.Local)
+	}
+	iv := func(name, bundleID string, from, to time.Time) appInterval {
+		return appInterval{Name: name, BundleID: bundleID, Period: Period{Start: from, End: to}}
+	}
+
+	result := detectBlocks([]appInterval{
+		// 9:00-9:50 in the editor with a one-minute look at Slack
+		iv("Code", "com.microsoft.VSCode", at(9, 0), at(9, 20)),
+		iv("Slack", "com.tinyspeck.slackmacgap", at(9, 20), at(9, 21)),
+		iv("Code", "com.microsoft.VSCode", at(9, 21), at(9, 50)),
+		// A 30-minute standup with a short trip to Notes
+		iv("zoom.us", "us.zoom.xos", at(10, 0), at(10, 20)),
+		iv("Notes", "com.apple.Notes", at(10, 20), at(10, 23)),
+		iv("zoom.us", "us.zoom.xos", at(10, 23), at(10, 30)),
+		// Ten-minute bursts don't make a block
+		iv("Code", "com.microsoft.VSCode", at(11, 0), at(11, 10)),
+		iv("Mail", "com.apple.mail", at(11, 10), at(11, 20)),
+	})
+
+	if !result.Available {
+		t.Fatal("expected result to be available")
+	}
+	if len(result.Blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2: %+v", len(result.Blocks), result.Blocks)
+	}
+	work, meeting := result.Blocks[0], result.Blocks[1]
+	if work.Kind != BlockDeepWork || work.App != "Code" || !work.Start.Equal(at(9, 0)) || !work.End.Equal(at(9, 50)) {
+		t.Errorf("deep work block = %+v, want Code 9:00-9:50", work)
+	}
+	if meeting.Kind != BlockMeeting || meeting.App != "zoom.us" || !meeting.Start.Equal(at(10, 0)) || !meeting.End.Equal(at(10, 30)) {
+		t.Errorf("meeting = %+v, want zoom.us 10:00-10:30", meeting)
+	}
+	if result.DeepWorkMinutes != 50 || result.MeetingMinutes != 30 {
+		t.Errorf("minutes = %d deep work, %d meetings, want 50 and 30", result.DeepWorkMinutes, result.MeetingMinutes)
+	}
+
+	if result := detectBlocks(nil); result.Available {
+		t.Error("expected unavailable result without intervals")
+	}
+}
@@ -0,0 +1,215 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// AudioDevice is the time one Bluetooth headphone or speaker was connected
+type AudioDevice struct {
+	Name      string
+	Type      string // As System Information lists it, e.g. "Headphones"
+	Minutes   int
+	Connected bool // Connected at this run; live windows only
+}
+
+// AudioDevicesResult contains the Bluetooth audio devices connected in the
+// window, built from samples taken by each run and by "rekap sample"
+type AudioDevicesResult struct {
+	Devices   []AudioDevice // Most time first
+	Samples   int
+	Available bool
+	Error     error
+}
+
+// audioSample is the Bluetooth audio devices connected at one moment,
+// persisted to the daily log
+type audioSample struct {
+	Timestamp string            `json:"timestamp"`
+	Devices   map[string]string `json:"devices"` // Name -> type
+}
+
+// audioSampleMaxCredit caps the time a sample stands for, as for VPN samples
+const audioSampleMaxCredit = 30 * time.Minute
+
+// RecordAudioSample reads the connected Bluetooth audio devices and appends
+// them to today's sample log. It returns the devices by name.
+func RecordAudioSample(ctx context.Context) (map[string]string, error) {
+	out, err := exec.CommandContext(ctx, "system_profiler", "SPBluetoothDataType", "-json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Bluetooth devices: %w", err)
+	}
+	devices, err := parseBluetoothAudio(out)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	samples, _ := loadAudioSamples(DayKey(now))
+	if shouldRecordAudioSample(samples, now) {
+		samples = append(samples, audioSample{Timestamp: now.Format(time.RFC3339), Devices: devices})
+		if err := state.Save(state.KindAudio, DayKey(now), samples); err != nil {
+			return devices, fmt.Errorf("failed to save Bluetooth sample: %w", err)
+		}
+	}
+	return devices, nil
+}
+
+// CollectAudioDevices reports how long each Bluetooth headphone or speaker
+// was connected in w
+func CollectAudioDevices(ctx context.Context, w Window) AudioDevicesResult {
+	var current map[string]string
+	var sampleErr error
+	if w.Live() {
+		current, sampleErr = RecordAudioSample(ctx)
+	}
+
+	var samples []audioSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadAudioSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeAudioSamples(samples, w)
+	for i, d := range result.Devices {
+		_, result.Devices[i].Connected = current[d.Name]
+	}
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("bluetooth devices unavailable: %w", sampleErr)
+	}
+	return result
+}
+
+// summarizeAudioSamples credits each device in a sample with the time until
+// the next sample (or the end of w), capped at audioSampleMaxCredit
+func summarizeAudioSamples(samples []audioSample, w Window) AudioDevicesResult {
+	result := AudioDevicesResult{Available: false}
+
+	type timedSample struct {
+		audioSample
+		at time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s, at})
+	}
+	if len(timed) == 0 {
+		result.Error = fmt.Errorf("no Bluetooth samples recorded in this window")
+		return result
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := cappedCredits(times, w.End, audioSampleMaxCredit)
+
+	credit := make(map[string]time.Duration)
+	types := make(map[string]string)
+	for i, s := range timed {
+		for name, kind := range s.Devices {
+			credit[name] += credits[i]
+			types[name] = kind
+		}
+	}
+	for name, d := range credit {
+		result.Devices = append(result.Devices, AudioDevice{Name: name, Type: types[name], Minutes: int(d.Minutes())})
+	}
+	sort.Slice(result.Devices, func(i, j int) bool {
+		if result.Devices[i].Minutes != result.Devices[j].Minutes {
+			return result.Devices[i].Minutes > result.Devices[j].Minutes
+		}
+		return result.Devices[i].Name < result.Devices[j].Name
+	})
+
+	result.Samples = len(timed)
+	result.Available = true
+	return result
+}
+
+// audioMinorTypes are the Bluetooth device types that play audio
+var audioMinorTypes = []string{"headphones", "headset", "speaker", "audio"}
+
+// parseBluetoothAudio returns the connected audio devices, name to type, in
+// `system_profiler SPBluetoothDataType -json` output. Ventura and later list
+// them under device_connected; older versions under device_title with a
+// connected flag.
+func parseBluetoothAudio(data []byte) (map[string]string, error) {
+	var report struct {
+		SPBluetoothDataType []struct {
+			Connected []map[string]map[string]any `json:"device_connected"`
+			Titles    []map[string]map[string]any `json:"device_title"`
+		} `json:"SPBluetoothDataType"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse Bluetooth devices: %w", err)
+	}
+
+	devices := make(map[string]string)
+	add := func(entries []map[string]map[string]any, needFlag bool) {
+		for _, entry := range entries {
+			for name, props := range entry {
+				if needFlag && props["device_isconnected"] != "attrib_Yes" {
+					continue
+				}
+				kind, _ := props["device_minorType"].(string)
+				if kind == "" {
+					kind, _ = props["device_minorClassOfDevice_string"].(string)
+				}
+				if isAudioType(kind) {
+					devices[name] = kind
+				}
+			}
+		}
+	}
+	for _, controller := range report.SPBluetoothDataType {
+		add(controller.Connected, false)
+		add(controller.Titles, true)
+	}
+	return devices, nil
+}
+
+func isAudioType(kind string) bool {
+	kind = strings.ToLower(kind)
+	for _, t := range audioMinorTypes {
+		if strings.Contains(kind, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func shouldRecordAudioSample(samples []audioSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadAudioSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadAudioSamples(date string) ([]audioSample, error) {
+	var samples []audioSample
+	if _, err := state.Load(state.KindAudio, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,62 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBluetoothAudio(t *testing.T) {
+	t.Parallel()
+	sonoma := `{"SPBluetoothDataType": [{
+		"controller_properties": {"controller_state": "attrib_on"},
+		"device_connected": [
+			{"AirPods Pro": {"device_address": "AA:BB", "device_minorType": "Headphones"}},
+			{"Magic Keyboard": {"device_address": "CC:DD", "device_minorType": "Keyboard"}}
+		],
+		"device_not_connected": [
+			{"Kitchen Speaker": {"device_address": "EE:FF", "device_minorType": "Speaker"}}
+		]
+	}]}`
+	got, err := parseBluetoothAudio([]byte(sonoma))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["AirPods Pro"] != "Headphones" {
+		t.Errorf("parseBluetoothAudio = %v, want only AirPods Pro", got)
+	}
+
+	monterey := `{"SPBluetoothDataType": [{
+		"device_title": [
+			{"Jabra Evolve": {"device_isconnected": "attrib_Yes", "device_minorClassOfDevice_string": "Headset"}},
+			{"Boom 3": {"device_isconnected": "attrib_No", "device_minorClassOfDevice_string": "Loudspeaker"}}
+		]
+	}]}`
+	if got, err := parseBluetoothAudio([]byte(monterey)); err != nil || len(got) != 1 || got["Jabra Evolve"] != "Headset" {
+		t.Errorf("parseBluetoothAudio (Monterey) = %v, %v, want only Jabra Evolve", got, err)
+	}
+}
+
+func TestSummarizeAudioSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, minute int) string {
+		return time.Date(2026, 2, 18, hour, minute, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	w := Window{Start: time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local), End: time.Date(2026, 2, 18, 12, 0, 0, 0, time.Local)}
+	airpods := map[string]string{"AirPods Pro": "Headphones"}
+
+	result := summarizeAudioSamples([]audioSample{
+		{Timestamp: at(9, 0), Devices: airpods},
+		{Timestamp: at(9, 20), Devices: map[string]string{"AirPods Pro": "Headphones", "Boom 3": "Speaker"}},
+		{Timestamp: at(9, 30), Devices: map[string]string{}},
+		{Timestamp: at(11, 0), Devices: airpods},
+	}, w)
+	want := []AudioDevice{{Name: "AirPods Pro", Type: "Headphones", Minutes: 60}, {Name: "Boom 3", Type: "Speaker", Minutes: 10}}
+	if len(result.Devices) != len(want) {
+		t.Fatalf("Devices = %+v, want %+v", result.Devices, want)
+	}
+	for i := range want {
+		if result.Devices[i] != want[i] {
+			t.Errorf("device %d = %+v, want %+v", i, result.Devices[i], want[i])
+		}
+	}
+}
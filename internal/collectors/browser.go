@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,17 +21,22 @@ import (
 
 // BrowserResult contains browser tab information and history
 type BrowserResult struct {
-	TabCount  int
-	Domains   map[string]int // domain -> tab count
-	Browser   string
-	Available bool
-	Error     error
+	TabCount      int
+	Domains       map[string]int // domain -> tab count
+	TabCategories map[string]int // category -> open tabs, by the domains config
+	Browser       string
+	Available     bool
+	Error         error
 	// History data
-	URLsVisited     int
-	TopDomain       string
-	TopDomainVisits int
-	IssueURLs       []string       // Jira, GitHub, Linear issue URLs
-	HistoryDomains  map[string]int // domain -> visit count from history
+	URLsVisited          int
+	TopDomain            string
+	TopDomainVisits      int
+	IssueURLs            []string       // Jira, GitHub, Linear issue URLs
+	HistoryDomains       map[string]int // domain -> visit count from history; bounded by the memory budget
+	HistoryURLs          map[string]int // url -> visit count from history; the most visited under the memory budget
+	CloudVisits          []CloudVisit   // Visits to cloud provider consoles
+	HistoryCategories    map[string]int // category -> visits, by the domains config at each visit's time
+	AfterHoursCategories map[string]int // category -> visits outside work hours; nil when work hours aren't set
 }
 
 // BrowsersResult aggregates all browser data
@@ -38,17 +44,24 @@ type BrowsersResult struct {
 	Chrome            BrowserResult
 	Safari            BrowserResult
 	Edge              BrowserResult
+	Firefox           BrowserResult
 	TotalTabs         int
 	TopDomains        map[string]int // aggregated across all browsers
 	WorkVisits        int
 	DistractionVisits int
+	LearningVisits    int
 	NeutralVisits     int
 	Available         bool
 	// History aggregation
-	TotalURLsVisited int
-	AllIssueURLs     []string
-	TopHistoryDomain string
-	TopDomainVisits  int
+	TotalURLsVisited     int
+	AllIssueURLs         []string
+	TopHistoryDomain     string
+	TopDomainVisits      int
+	HistoryDomains       map[string]int // domain -> visit count from history, aggregated across browsers; bounded by the memory budget
+	URLVisits            map[string]int // url -> visit count, aggregated across browsers; the most visited under the memory budget
+	CloudVisits          []CloudVisit   // Cloud console visits across browsers, oldest first
+	HistoryCategories    map[string]int // category -> visits, aggregated across browsers
+	AfterHoursCategories map[string]int // category -> visits outside work hours, aggregated across browsers; nil when work hours aren't set
 }
 
 // IssueVisit represents a single issue/ticket visit
@@ -66,9 +79,10 @@ type IssuesResult struct {
 	Error     error
 }
 
-// CollectBrowserTabs retrieves open tabs from Chrome, Safari, and Edge
-// and also parses browser history for today's activity
-func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult {
+// CollectBrowserTabs retrieves open tabs from Chrome, Safari, Edge, and Firefox
+// and also parses browser history for activity in w. Open tabs only
+// describe live windows, so past windows get history alone.
+func CollectBrowserTabs(ctx context.Context, cfg *config.Config, w Window) BrowsersResult {
 	result := BrowsersResult{
 		TopDomains: make(map[string]int),
 	}
@@ -77,26 +91,32 @@ func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult
 	chromeChan := make(chan BrowserResult, 1)
 	safariChan := make(chan BrowserResult, 1)
 	edgeChan := make(chan BrowserResult, 1)
+	firefoxChan := make(chan BrowserResult, 1)
+
+	go func() {
+		chromeChan <- collectChromeTabs(ctx, cfg, w)
+	}()
 
 	go func() {
-		chromeChan <- collectChromeTabs(ctx)
+		safariChan <- collectSafariTabs(ctx, cfg, w)
 	}()
 
 	go func() {
-		safariChan <- collectSafariTabs(ctx)
+		edgeChan <- collectEdgeTabs(ctx, cfg, w)
 	}()
 
 	go func() {
-		edgeChan <- collectEdgeTabs(ctx)
+		firefoxChan <- collectFirefoxTabs(ctx, cfg, w)
 	}()
 
 	// Collect results
 	result.Chrome = <-chromeChan
 	result.Safari = <-safariChan
 	result.Edge = <-edgeChan
+	result.Firefox = <-firefoxChan
 
 	// Aggregate tab data
-	result.TotalTabs = result.Chrome.TabCount + result.Safari.TabCount + result.Edge.TabCount
+	result.TotalTabs = result.Chrome.TabCount + result.Safari.TabCount + result.Edge.TabCount + result.Firefox.TabCount
 
 	for domain, count := range result.Chrome.Domains {
 		result.TopDomains[domain] += count
@@ -107,18 +127,20 @@ func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult
 	for domain, count := range result.Edge.Domains {
 		result.TopDomains[domain] += count
 	}
+	for domain, count := range result.Firefox.Domains {
+		result.TopDomains[domain] += count
+	}
 
-	// Categorize domains if config is provided
-	if cfg != nil {
-		for domain, count := range result.TopDomains {
-			category := cfg.CategorizeDomain(domain)
+	// Tabs are categorized by URL as they're read, when config is provided
+	for _, b := range []BrowserResult{result.Chrome, result.Safari, result.Edge, result.Firefox} {
+		for category, count := range b.TabCategories {
 			switch category {
 			case "work":
 				result.WorkVisits += count
 			case "distraction":
 				result.DistractionVisits += count
-			case "neutral":
-				result.NeutralVisits += count
+			case "learning":
+				result.LearningVisits += count
 			default:
 				result.NeutralVisits += count
 			}
@@ -126,7 +148,7 @@ func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult
 	}
 
 	// Aggregate history data
-	result.TotalURLsVisited = result.Chrome.URLsVisited + result.Safari.URLsVisited + result.Edge.URLsVisited
+	result.TotalURLsVisited = result.Chrome.URLsVisited + result.Safari.URLsVisited + result.Edge.URLsVisited + result.Firefox.URLsVisited
 
 	// Combine all issue URLs, deduplicated
 	issueURLSet := make(map[string]struct{})
@@ -139,22 +161,41 @@ func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult
 	for _, url := range result.Edge.IssueURLs {
 		issueURLSet[url] = struct{}{}
 	}
+	for _, url := range result.Firefox.IssueURLs {
+		issueURLSet[url] = struct{}{}
+	}
 	result.AllIssueURLs = make([]string, 0, len(issueURLSet))
 	for url := range issueURLSet {
 		result.AllIssueURLs = append(result.AllIssueURLs, url)
 	}
 
-	// Find top history domain across all browsers
-	allHistoryDomains := make(map[string]int)
-	for domain, count := range result.Chrome.HistoryDomains {
-		allHistoryDomains[domain] += count
-	}
-	for domain, count := range result.Safari.HistoryDomains {
-		allHistoryDomains[domain] += count
+	// Merge history across browsers into counters under the same budget
+	domains := newTopCounter(counterCapacity())
+	urls := newTopCounter(counterCapacity())
+	result.HistoryCategories = make(map[string]int)
+	afterHours := make(map[string]int)
+	for _, b := range []BrowserResult{result.Chrome, result.Safari, result.Edge, result.Firefox} {
+		for domain, count := range b.HistoryDomains {
+			domains.Add(domain, count)
+		}
+		for url, count := range b.HistoryURLs {
+			urls.Add(url, count)
+		}
+		for category, count := range b.HistoryCategories {
+			result.HistoryCategories[category] += count
+		}
+		for category, count := range b.AfterHoursCategories {
+			afterHours[category] += count
+		}
+		result.CloudVisits = append(result.CloudVisits, b.CloudVisits...)
 	}
-	for domain, count := range result.Edge.HistoryDomains {
-		allHistoryDomains[domain] += count
+	allHistoryDomains := domains.Counts()
+	result.HistoryDomains = allHistoryDomains
+	result.URLVisits = urls.Counts()
+	if workHours.Load() != nil {
+		result.AfterHoursCategories = afterHours
 	}
+	sort.Slice(result.CloudVisits, func(i, j int) bool { return result.CloudVisits[i].At.Before(result.CloudVisits[j].At) })
 
 	// Find top domain
 	maxVisits := 0
@@ -166,7 +207,8 @@ func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult
 		}
 	}
 
-	result.Available = result.Chrome.Available || result.Safari.Available || result.Edge.Available
+	result.Available = result.Chrome.Available || result.Safari.Available || result.Edge.Available || result.Firefox.Available ||
+		result.TotalURLsVisited > 0
 
 	return result
 }
@@ -175,17 +217,24 @@ func CollectBrowserTabs(ctx context.Context, cfg *config.Config) BrowsersResult
 // browserName: display name for the browser (e.g., "Chrome")
 // appName: AppleScript application name (e.g., "Google Chrome")
 // titleProperty: AppleScript property for tab title ("title of t" or "name of t")
-func collectBrowserTabsForApp(ctx context.Context, browserName, appName, titleProperty string) BrowserResult {
+// windows: AppleScript reference to the windows to read, which leaves out
+// private windows where the browser can tell them apart
+func collectBrowserTabsForApp(ctx context.Context, cfg *config.Config, browserName, appName, titleProperty, windows string) BrowserResult {
+	if fastMode.Load() {
+		return countBrowserTabs(ctx, browserName, appName, windows)
+	}
+
 	result := BrowserResult{
-		Browser: browserName,
-		Domains: make(map[string]int),
+		Browser:       browserName,
+		Domains:       make(map[string]int),
+		TabCategories: make(map[string]int),
 	}
 
 	script := fmt.Sprintf(`
 tell application "%s"
 	if it is running then
 		set tabList to {}
-		repeat with w in windows
+		repeat with w in %s
 			repeat with t in tabs of w
 				set end of tabList to (%s) & "|||" & (URL of t)
 			end repeat
@@ -197,7 +246,7 @@ tell application "%s"
 	end if
 end tell
 return ""
-`, appName, titleProperty)
+`, appName, windows, titleProperty)
 
 	cmd := exec.CommandContext(ctx, "osascript", "-e", script)
 	output, err := cmd.Output()
@@ -230,52 +279,118 @@ return ""
 
 		result.TabCount++
 
-		if domain != "" {
+		// Redacted tabs count toward the total but nothing else
+		if domain != "" && (cfg == nil || !cfg.RedactsURL(urlStr)) {
 			result.Domains[domain]++
+			if cfg != nil {
+				result.TabCategories[cfg.CategorizeURL(urlStr, time.Now())]++
+			}
 		}
 	}
 
 	return result
 }
 
-func collectChromeTabs(ctx context.Context) BrowserResult {
-	result := collectBrowserTabsForApp(ctx, "Chrome", "Google Chrome", "title of t")
+// countBrowserTabs counts open tabs without reading their titles or URLs,
+// which is much faster with hundreds of tabs open
+func countBrowserTabs(ctx context.Context, browserName, appName, windows string) BrowserResult {
+	result := BrowserResult{
+		Browser: browserName,
+		Domains: make(map[string]int),
+	}
+
+	script := fmt.Sprintf(`
+tell application "%s"
+	if it is running then
+		set tabCount to 0
+		repeat with w in %s
+			set tabCount to tabCount + (count of tabs of w)
+		end repeat
+		return tabCount
+	end if
+end tell
+return ""
+`, appName, windows)
+
+	output, err := exec.CommandContext(ctx, "osascript", "-e", script).Output()
+	if err != nil {
+		result.Error = fmt.Errorf("%s not running or unavailable: %w", strings.ToLower(browserName), err)
+		return result
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return result
+	}
+	result.TabCount = count
+	result.Available = true
+	return result
+}
+
+// chromiumWindows skips Chrome and Edge incognito and InPrivate windows.
+// Safari doesn't expose whether a window is private, so its tabs are all
+// read. Firefox's session file already leaves private windows out, and
+// private browsing never reaches any browser's history.
+const chromiumWindows = `(windows whose mode is not "incognito")`
+
+func collectChromeTabs(ctx context.Context, cfg *config.Config, w Window) BrowserResult {
+	result := BrowserResult{Browser: "Chrome", Domains: make(map[string]int)}
+	if w.Live() {
+		result = collectBrowserTabsForApp(ctx, cfg, "Chrome", "Google Chrome", "title of t", chromiumWindows)
+	}
 
 	// Also collect history
-	historyData := collectChromeHistory(ctx)
+	historyData := collectChromeHistory(ctx, cfg, w)
 	result.URLsVisited = historyData.URLsVisited
 	result.TopDomain = historyData.TopDomain
 	result.TopDomainVisits = historyData.TopDomainVisits
 	result.IssueURLs = historyData.IssueURLs
 	result.HistoryDomains = historyData.HistoryDomains
+	result.HistoryURLs = historyData.HistoryURLs
+	result.CloudVisits = historyData.CloudVisits
+	result.HistoryCategories = historyData.HistoryCategories
+	result.AfterHoursCategories = historyData.AfterHoursCategories
 
 	return result
 }
 
-func collectSafariTabs(ctx context.Context) BrowserResult {
-	result := collectBrowserTabsForApp(ctx, "Safari", "Safari", "name of t")
+func collectSafariTabs(ctx context.Context, cfg *config.Config, w Window) BrowserResult {
+	result := BrowserResult{Browser: "Safari", Domains: make(map[string]int)}
+	if w.Live() {
+		result = collectBrowserTabsForApp(ctx, cfg, "Safari", "Safari", "name of t", "windows")
+	}
 
 	// Also collect history
-	historyData := collectSafariHistory(ctx)
+	historyData := collectSafariHistory(ctx, cfg, w)
 	result.URLsVisited = historyData.URLsVisited
 	result.TopDomain = historyData.TopDomain
 	result.TopDomainVisits = historyData.TopDomainVisits
 	result.IssueURLs = historyData.IssueURLs
 	result.HistoryDomains = historyData.HistoryDomains
+	result.HistoryURLs = historyData.HistoryURLs
+	result.CloudVisits = historyData.CloudVisits
+	result.HistoryCategories = historyData.HistoryCategories
+	result.AfterHoursCategories = historyData.AfterHoursCategories
 
 	return result
 }
 
-func collectEdgeTabs(ctx context.Context) BrowserResult {
-	result := collectBrowserTabsForApp(ctx, "Edge", "Microsoft Edge", "title of t")
+func collectEdgeTabs(ctx context.Context, cfg *config.Config, w Window) BrowserResult {
+	result := BrowserResult{Browser: "Edge", Domains: make(map[string]int)}
+	if w.Live() {
+		result = collectBrowserTabsForApp(ctx, cfg, "Edge", "Microsoft Edge", "title of t", chromiumWindows)
+	}
 
 	// Also collect history
-	historyData := collectEdgeHistory(ctx)
+	historyData := collectEdgeHistory(ctx, cfg, w)
 	result.URLsVisited = historyData.URLsVisited
 	result.TopDomain = historyData.TopDomain
 	result.TopDomainVisits = historyData.TopDomainVisits
 	result.IssueURLs = historyData.IssueURLs
 	result.HistoryDomains = historyData.HistoryDomains
+	result.HistoryURLs = historyData.HistoryURLs
+	result.CloudVisits = historyData.CloudVisits
+	result.HistoryCategories = historyData.HistoryCategories
+	result.AfterHoursCategories = historyData.AfterHoursCategories
 
 	return result
 }
@@ -338,24 +453,25 @@ func mergeIssues(issueMap map[string]*IssueVisit, issues []IssueVisit) {
 	}
 }
 
-// CollectIssues collects issue/ticket URLs from browser history
-func CollectIssues(ctx context.Context) IssuesResult {
+// CollectIssues collects issue/ticket URLs visited in w from browser
+// history, leaving out redacted URLs and issues on the privacy denylist
+func CollectIssues(ctx context.Context, cfg *config.Config, w Window) IssuesResult {
 	result := IssuesResult{}
 
-	// Collect from Chrome, Safari, and Edge history
+	// Collect from Chrome, Safari, Edge, and Firefox history
 	issueMap := make(map[string]*IssueVisit)
 
-	// Get today's start time (midnight)
-	now := time.Now()
-	todayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
 	// Merge issues from all browsers
-	mergeIssues(issueMap, collectChromeIssues(ctx, todayStart))
-	mergeIssues(issueMap, collectSafariIssues(ctx, todayStart))
-	mergeIssues(issueMap, collectEdgeIssues(ctx, todayStart))
+	mergeIssues(issueMap, collectChromeIssues(ctx, w))
+	mergeIssues(issueMap, collectSafariIssues(ctx, w))
+	mergeIssues(issueMap, collectEdgeIssues(ctx, w))
+	mergeIssues(issueMap, collectFirefoxIssues(ctx, w))
 
 	// Convert map to slice
 	for _, issue := range issueMap {
+		if cfg != nil && (cfg.RedactsURL(issue.URL) || cfg.DeniesIssue(issue.ID, issue.URL)) {
+			continue
+		}
 		result.Issues = append(result.Issues, *issue)
 	}
 
@@ -370,40 +486,40 @@ func CollectIssues(ctx context.Context) IssuesResult {
 }
 
 // collectChromeIssues reads Chrome history database for issue URLs
-func collectChromeIssues(ctx context.Context, since time.Time) []IssueVisit {
+func collectChromeIssues(ctx context.Context, w Window) []IssueVisit {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil
 	}
 
 	historyPath := filepath.Join(homeDir, "Library", "Application Support", "Google", "Chrome", "Default", "History")
-	return parseHistoryDB(ctx, historyPath, since, "chrome")
+	return parseHistoryDB(ctx, historyPath, w)
 }
 
 // collectSafariIssues reads Safari history database for issue URLs
-func collectSafariIssues(ctx context.Context, since time.Time) []IssueVisit {
+func collectSafariIssues(ctx context.Context, w Window) []IssueVisit {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil
 	}
 
 	historyPath := filepath.Join(homeDir, "Library", "Safari", "History.db")
-	return parseSafariHistoryDB(ctx, historyPath, since)
+	return parseSafariHistoryDB(ctx, historyPath, w)
 }
 
 // collectEdgeIssues reads Edge history database for issue URLs
-func collectEdgeIssues(ctx context.Context, since time.Time) []IssueVisit {
+func collectEdgeIssues(ctx context.Context, w Window) []IssueVisit {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil
 	}
 
 	historyPath := filepath.Join(homeDir, "Library", "Application Support", "Microsoft Edge", "Default", "History")
-	return parseHistoryDB(ctx, historyPath, since, "edge")
+	return parseHistoryDB(ctx, historyPath, w)
 }
 
 // parseHistoryDB parses Chrome/Edge-style history databases
-func parseHistoryDB(ctx context.Context, dbPath string, since time.Time, browserType string) []IssueVisit {
+func parseHistoryDB(ctx context.Context, dbPath string, w Window) []IssueVisit {
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
 		return nil
 	}
@@ -420,18 +536,20 @@ func parseHistoryDB(ctx context.Context, dbPath string, since time.Time, browser
 	}
 	defer db.Close()
 
-	// Chrome/Edge use microseconds since January 1, 1601 (Windows epoch)
-	windowsEpoch := time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC)
-	sinceChrome := since.Sub(windowsEpoch).Microseconds()
-
 	query := `
-		SELECT url, visit_count 
-		FROM urls 
-		WHERE last_visit_time >= ?
+		SELECT u.url, COUNT(*) as visit_count
+		FROM (
+			SELECT url FROM visits
+			WHERE visit_time >= ? AND visit_time < ?
+			ORDER BY visit_time DESC
+			LIMIT ?
+		) v
+		JOIN urls u ON u.id = v.url
+		GROUP BY u.url
 		ORDER BY visit_count DESC
 	`
 
-	rows, err := db.QueryContext(ctx, query, sinceChrome)
+	rows, err := db.QueryContext(ctx, query, chromeTime(w.Start), chromeTime(w.End), historyRowLimit())
 	if err != nil {
 		return nil
 	}
@@ -441,7 +559,7 @@ func parseHistoryDB(ctx context.Context, dbPath string, since time.Time, browser
 }
 
 // parseSafariHistoryDB parses Safari history database
-func parseSafariHistoryDB(ctx context.Context, dbPath string, since time.Time) []IssueVisit {
+func parseSafariHistoryDB(ctx context.Context, dbPath string, w Window) []IssueVisit {
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
 		return nil
 	}
@@ -459,21 +577,24 @@ func parseSafariHistoryDB(ctx context.Context, dbPath string, since time.Time) [
 	defer db.Close()
 
 	// Safari uses Core Data timestamp (seconds since 2001-01-01)
-	referenceDate := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
-	sinceSafari := since.Sub(referenceDate).Seconds()
+	startTimestamp, endTimestamp := timestampRange(w)
 
 	query := `
 		SELECT 
 			history_items.url,
-			COUNT(history_visits.id) as visit_count
-		FROM history_items
-		LEFT JOIN history_visits ON history_items.id = history_visits.history_item
-		WHERE history_visits.visit_time >= ?
+			COUNT(*) as visit_count
+		FROM (
+			SELECT history_item FROM history_visits
+			WHERE visit_time >= ? AND visit_time < ?
+			ORDER BY visit_time DESC
+			LIMIT ?
+		) hv
+		JOIN history_items ON history_items.id = hv.history_item
 		GROUP BY history_items.url
 		ORDER BY visit_count DESC
 	`
 
-	rows, err := db.QueryContext(ctx, query, sinceSafari)
+	rows, err := db.QueryContext(ctx, query, startTimestamp, endTimestamp, historyRowLimit())
 	if err != nil {
 		return nil
 	}
@@ -557,50 +678,56 @@ func extractDomain(urlStr string) string {
 
 // BrowserHistoryData contains history-specific data
 type BrowserHistoryData struct {
-	URLsVisited     int
-	TopDomain       string
-	TopDomainVisits int
-	IssueURLs       []string
-	HistoryDomains  map[string]int
+	URLsVisited          int
+	TopDomain            string
+	TopDomainVisits      int
+	IssueURLs            []string
+	HistoryDomains       map[string]int
+	HistoryURLs          map[string]int
+	CloudVisits          []CloudVisit
+	HistoryCategories    map[string]int
+	AfterHoursCategories map[string]int
 }
 
 // collectChromeHistory parses Chrome history database
-func collectChromeHistory(ctx context.Context) BrowserHistoryData {
+func collectChromeHistory(ctx context.Context, cfg *config.Config, w Window) BrowserHistoryData {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return BrowserHistoryData{}
 	}
 
 	historyPath := filepath.Join(homeDir, "Library", "Application Support", "Google", "Chrome", "Default", "History")
-	return collectBrowserHistory(ctx, historyPath, "chrome")
+	return collectBrowserHistory(ctx, cfg, historyPath, "chrome", w)
 }
 
 // collectSafariHistory parses Safari history database
-func collectSafariHistory(ctx context.Context) BrowserHistoryData {
+func collectSafariHistory(ctx context.Context, cfg *config.Config, w Window) BrowserHistoryData {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return BrowserHistoryData{}
 	}
 
 	historyPath := filepath.Join(homeDir, "Library", "Safari", "History.db")
-	return collectBrowserHistory(ctx, historyPath, "safari")
+	return collectBrowserHistory(ctx, cfg, historyPath, "safari", w)
 }
 
 // collectEdgeHistory parses Edge history database
-func collectEdgeHistory(ctx context.Context) BrowserHistoryData {
+func collectEdgeHistory(ctx context.Context, cfg *config.Config, w Window) BrowserHistoryData {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return BrowserHistoryData{}
 	}
 
 	historyPath := filepath.Join(homeDir, "Library", "Application Support", "Microsoft Edge", "Default", "History")
-	return collectBrowserHistory(ctx, historyPath, "edge")
+	return collectBrowserHistory(ctx, cfg, historyPath, "edge", w)
 }
 
-// collectBrowserHistory is a generic function to collect history from Chrome/Edge/Safari databases
-func collectBrowserHistory(ctx context.Context, dbPath, browserType string) BrowserHistoryData {
+// collectBrowserHistory is a generic function to collect history in w from Chrome/Edge/Safari/Firefox databases
+func collectBrowserHistory(ctx context.Context, cfg *config.Config, dbPath, browserType string, w Window) BrowserHistoryData {
 	result := BrowserHistoryData{
-		HistoryDomains: make(map[string]int),
+		HistoryDomains:    make(map[string]int),
+		HistoryURLs:       make(map[string]int),
+		HistoryCategories: make(map[string]int),
 	}
 
 	// Check if database exists
@@ -608,56 +735,75 @@ func collectBrowserHistory(ctx context.Context, dbPath, browserType string) Brow
 		return result
 	}
 
-	// Copy database to temp location to avoid lock issues
-	tempDB, err := copyToTemp(dbPath)
-	if err != nil {
-		return result
-	}
-	defer os.Remove(tempDB)
+	var db *sql.DB
+	if browserType == "firefox" {
+		placesDB, cleanup, err := openWALCopy(dbPath)
+		if err != nil {
+			return result
+		}
+		defer cleanup()
+		db = placesDB
+	} else {
+		// Copy database to temp location to avoid lock issues
+		tempDB, err := copyToTemp(dbPath)
+		if err != nil {
+			return result
+		}
+		defer os.Remove(tempDB)
 
-	// Open the database
-	db, err := sql.Open("sqlite", tempDB)
-	if err != nil {
-		return result
+		// Open the database
+		db, err = sql.Open("sqlite", tempDB)
+		if err != nil {
+			return result
+		}
+		defer db.Close()
 	}
-	defer db.Close()
-
-	// Get today's timestamp range
-	now := time.Now()
-	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 	var rows *sql.Rows
-	if browserType == "safari" {
+	var err error
+	switch browserType {
+	case "safari":
 		// Safari uses Core Data timestamp (seconds since 2001-01-01)
-		coreDataEpoch := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
-		startTimestamp := midnight.Sub(coreDataEpoch).Seconds()
-		endTimestamp := now.Sub(coreDataEpoch).Seconds()
+		startTimestamp, endTimestamp := timestampRange(w)
 
-		// Join history_items and history_visits to get all visits for today
+		// Join history_items and history_visits to get all visits in the window
 		query := `
-			SELECT hi.url, COUNT(*) as today_visit_count
-			FROM history_items hi
-			JOIN history_visits hv ON hi.id = hv.history_item
-			WHERE hv.visit_time >= ? AND hv.visit_time < ?
-			GROUP BY hi.url
-			ORDER BY today_visit_count DESC
+			SELECT url, visit_time FROM (
+				SELECT hi.url AS url, hv.visit_time AS visit_time
+				FROM history_items hi
+				JOIN history_visits hv ON hi.id = hv.history_item
+				WHERE hv.visit_time >= ? AND hv.visit_time < ?
+				ORDER BY hv.visit_time DESC
+				LIMIT ?
+			) ORDER BY url
 		`
-		rows, err = db.QueryContext(ctx, query, startTimestamp, endTimestamp)
-	} else {
-		// Chrome/Edge use microseconds since Unix epoch
-		startTimestamp := midnight.UnixMicro()
-		endTimestamp := now.UnixMicro()
-
-		// Query visits table joined with urls for accurate today-only tracking
+		rows, err = db.QueryContext(ctx, query, startTimestamp, endTimestamp, historyRowLimit())
+	case "firefox":
+		// Firefox visit dates are microseconds since the Unix epoch
+		query := `
+			SELECT url, visit_date FROM (
+				SELECT p.url AS url, v.visit_date AS visit_date
+				FROM moz_places p
+				JOIN moz_historyvisits v ON p.id = v.place_id
+				WHERE v.visit_date >= ? AND v.visit_date < ?
+				ORDER BY v.visit_date DESC
+				LIMIT ?
+			) ORDER BY url
+		`
+		rows, err = db.QueryContext(ctx, query, w.Start.UnixMicro(), w.End.UnixMicro(), historyRowLimit())
+	default:
+		// Query visits table joined with urls for accurate per-window tracking
 		query := `
-			SELECT u.url, COUNT(*) as today_visit_count
-			FROM urls u
-			JOIN visits v ON u.id = v.url
-			WHERE v.visit_time >= ? AND v.visit_time < ?
-			GROUP BY u.url
-			ORDER BY today_visit_count DESC
+			SELECT url, visit_time FROM (
+				SELECT u.url AS url, v.visit_time AS visit_time
+				FROM urls u
+				JOIN visits v ON u.id = v.url
+				WHERE v.visit_time >= ? AND v.visit_time < ?
+				ORDER BY v.visit_time DESC
+				LIMIT ?
+			) ORDER BY url
 		`
-		rows, err = db.QueryContext(ctx, query, startTimestamp, endTimestamp)
+		rows, err = db.QueryContext(ctx, query, chromeTime(w.Start), chromeTime(w.End), historyRowLimit())
 	}
 
 	if err != nil {
@@ -665,31 +811,84 @@ func collectBrowserHistory(ctx context.Context, dbPath, browserType string) Brow
 	}
 	defer rows.Close()
 
-	// Process results - use map to deduplicate issue IDs
+	// Rows come sorted by URL, so each URL's visits are counted as they
+	// stream past and only the bounded counters hold anything per URL
+	urls := newTopCounter(counterCapacity())
+	domains := newTopCounter(counterCapacity())
 	issueIDSet := make(map[string]struct{})
+	hours := workHours.Load()
+	afterHours := make(map[string]int)
+	var lastURL string
+	var lastVisits int
+	flush := func() {
+		if lastVisits == 0 {
+			return
+		}
+		result.URLsVisited++
+		urls.Add(lastURL, lastVisits)
+		if domain := extractDomain(lastURL); domain != "" {
+			domains.Add(domain, lastVisits)
+		}
+	}
 
 	for rows.Next() {
 		var urlStr string
-		var visitCount int
+		var visitTime float64 // Core Data seconds for Safari, Unix microseconds for Firefox, Chrome microseconds otherwise
 
-		if err := rows.Scan(&urlStr, &visitCount); err != nil {
+		if err := rows.Scan(&urlStr, &visitTime); err != nil {
+			continue
+		}
+		if cfg != nil && cfg.RedactsURL(urlStr) {
 			continue
 		}
 
-		result.URLsVisited++
+		if urlStr != lastURL {
+			flush()
+			lastURL, lastVisits = urlStr, 0
+		}
+		lastVisits++
 
-		// Extract domain
 		domain := extractDomain(urlStr)
-		if domain != "" {
-			result.HistoryDomains[domain] += visitCount
+		provider := cloudProvider(domain)
+
+		if provider != "" || hours != nil || cfg != nil {
+			var at time.Time
+			switch browserType {
+			case "safari":
+				at = coreDataTime(visitTime)
+			case "firefox":
+				at = time.UnixMicro(int64(visitTime))
+			default:
+				at = fromChromeTime(int64(visitTime))
+			}
+			if provider != "" {
+				result.CloudVisits = append(result.CloudVisits, CloudVisit{Provider: provider, At: at})
+			}
+			// Categorized per visit, since domain rules can depend on the time
+			category := ""
+			if cfg != nil && domain != "" {
+				category = cfg.CategorizeURL(urlStr, at)
+				result.HistoryCategories[category]++
+			}
+			if hours != nil && category != "" && !hours.contains(at) {
+				afterHours[category]++
+			}
 		}
 
 		// Check if it's an issue URL and deduplicate
 		if isIssueURL(urlStr) {
 			issueID := extractIssueIdentifier(urlStr)
-			issueIDSet[issueID] = struct{}{}
+			if cfg == nil || !cfg.DeniesIssue(issueID, urlStr) {
+				issueIDSet[issueID] = struct{}{}
+			}
 		}
 	}
+	flush()
+	result.HistoryURLs = urls.Counts()
+	result.HistoryDomains = domains.Counts()
+	if hours != nil {
+		result.AfterHoursCategories = afterHours
+	}
 
 	// Convert deduplicated issue IDs to slice
 	result.IssueURLs = make([]string, 0, len(issueIDSet))
@@ -710,6 +909,21 @@ func collectBrowserHistory(ctx context.Context, dbPath, browserType string) Brow
 	return result
 }
 
+// chromeEpochOffset is the number of microseconds between the Windows epoch
+// (1601-01-01) that Chrome and Edge history timestamps count from and the
+// Unix epoch
+const chromeEpochOffset = 11644473600 * 1000000
+
+// chromeTime converts t to a Chrome/Edge history timestamp
+func chromeTime(t time.Time) int64 {
+	return t.UnixMicro() + chromeEpochOffset
+}
+
+// fromChromeTime converts a Chrome/Edge history timestamp to local time
+func fromChromeTime(ts int64) time.Time {
+	return time.UnixMicro(ts - chromeEpochOffset)
+}
+
 // copyToTemp copies a file to a temporary location
 func copyToTemp(srcPath string) (string, error) {
 	src, err := os.Open(srcPath)
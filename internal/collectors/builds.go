@@ -0,0 +1,264 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// minShellBuild is how long a build command from shell history has to run
+// to count. Quick incremental builds aren't time spent waiting.
+const minShellBuild = 10 * time.Second
+
+// BuildToolXcode names builds read from Xcode's logs in BuildTool
+const BuildToolXcode = "Xcode"
+
+// BuildTool is the builds run with one tool in the window
+type BuildTool struct {
+	Tool    string // "Xcode", or the program from shell history, e.g. "cargo"
+	Builds  int
+	Minutes int
+	Failed  int // Only known for Xcode
+}
+
+// BuildsResult contains the builds run in the window and the time spent
+// waiting on them
+type BuildsResult struct {
+	Builds      int
+	WaitMinutes int // Time at least one build was running
+	Failed      int
+	Tools       []BuildTool // Most time first
+	Available   bool
+	Error       error
+}
+
+// buildRun is one build, from Xcode's logs or shell history
+type buildRun struct {
+	Tool   string
+	Period Period
+	Failed bool
+}
+
+// CollectBuilds finds Xcode builds from DerivedData logs and, when shell
+// history is on, long build commands like "go build" and "cargo test" that
+// zsh recorded a duration for
+func CollectBuilds(ctx context.Context, shellHistory bool, w Window) BuildsResult {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return BuildsResult{Error: err}
+	}
+
+	runs, xcodeErr := readXcodeBuilds(ctx, homeDir, w)
+	if shellHistory {
+		if commands, err := readShellHistory(w); err == nil {
+			runs = append(runs, shellBuilds(commands, w)...)
+		}
+	}
+
+	result := summarizeBuilds(runs)
+	if !result.Available {
+		result.Error = fmt.Errorf("no builds found")
+		if xcodeErr != nil && !shellHistory {
+			result.Error = xcodeErr
+		}
+	}
+	return result
+}
+
+// readXcodeBuilds reads the build log index of every project in Xcode's
+// DerivedData folder
+func readXcodeBuilds(ctx context.Context, homeDir string, w Window) ([]buildRun, error) {
+	manifests, _ := filepath.Glob(filepath.Join(homeDir, "Library", "Developer", "Xcode", "DerivedData", "*", "Logs", "Build", "LogStoreManifest.plist"))
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no Xcode build logs found")
+	}
+
+	var runs []buildRun
+	for _, manifest := range manifests {
+		if err := ctx.Err(); err != nil {
+			return runs, err
+		}
+		// Projects untouched in the window can be skipped without parsing
+		if info, err := os.Stat(manifest); err != nil || info.ModTime().Before(w.Start) {
+			continue
+		}
+		output, err := exec.CommandContext(ctx, "plutil", "-convert", "json", "-o", "-", manifest).Output()
+		if err != nil {
+			continue
+		}
+		runs = append(runs, parseXcodeManifest(output, w)...)
+	}
+	return runs, nil
+}
+
+// parseXcodeManifest reads the builds in w from a LogStoreManifest.plist
+// converted to JSON. Times are seconds since 2001, and a status of "E"
+// means the build failed.
+func parseXcodeManifest(data []byte, w Window) []buildRun {
+	var manifest struct {
+		Logs map[string]struct {
+			Title   string  `json:"title"`
+			Started float64 `json:"timeStartedRecording"`
+			Stopped float64 `json:"timeStoppedRecording"`
+			Status  struct {
+				HighLevelStatus string `json:"highLevelStatus"`
+			} `json:"primaryObservable"`
+		} `json:"logs"`
+	}
+	if json.Unmarshal(data, &manifest) != nil {
+		return nil
+	}
+
+	var runs []buildRun
+	for _, log := range manifest.Logs {
+		// Cleans show up in the same index but aren't builds
+		if strings.HasPrefix(log.Title, "Clean") {
+			continue
+		}
+		start := coreDataEpoch.Add(time.Duration(log.Started * float64(time.Second))).Local()
+		end := coreDataEpoch.Add(time.Duration(log.Stopped * float64(time.Second))).Local()
+		start, end, ok := w.clip(start, end)
+		if !ok {
+			continue
+		}
+		runs = append(runs, buildRun{Tool: BuildToolXcode, Period: Period{start, end}, Failed: log.Status.HighLevelStatus == "E"})
+	}
+	return runs
+}
+
+// buildSubcommands are the subcommands that build, for tools that do more
+// than build. Tools with no entry build whatever the arguments.
+var buildSubcommands = map[string][]string{
+	"go":     {"build", "test", "install", "run", "generate"},
+	"cargo":  {"build", "b", "test", "t", "run", "r", "check", "c", "clippy", "bench"},
+	"swift":  {"build", "test", "run"},
+	"bazel":  {"build", "test", "run"},
+	"npm":    {"run", "test"},
+	"pnpm":   {"run", "build", "test"},
+	"yarn":   {"build", "test"},
+	"dotnet": {"build", "test", "run", "publish"},
+	"make":   nil,
+	"ninja":  nil,
+	"gradle": nil,
+	"mvn":    nil,
+	"cmake":  nil,
+
+	"xcodebuild": nil,
+}
+
+// buildWrappers are project-local wrapper scripts, named for what they run
+var buildWrappers = map[string]string{
+	"gradlew": "gradle",
+	"mvnw":    "mvn",
+}
+
+// shellBuilds picks the build commands out of shell history that ran for
+// at least minShellBuild, clipped to w. Only zsh with EXTENDED_HISTORY
+// records how long commands ran.
+func shellBuilds(commands []shellCommand, w Window) []buildRun {
+	var runs []buildRun
+	for _, c := range commands {
+		if c.Duration < minShellBuild {
+			continue
+		}
+		tool := buildTool(c.Command)
+		if tool == "" {
+			continue
+		}
+		start, end, ok := w.clip(c.At, c.At.Add(c.Duration))
+		if !ok {
+			continue
+		}
+		runs = append(runs, buildRun{Tool: tool, Period: Period{start, end}})
+	}
+	return runs
+}
+
+// buildTool returns the build tool a command line runs, or "" when it
+// isn't a build. "./gradlew" counts as gradle.
+func buildTool(command string) string {
+	words := commandWords(command)
+	if len(words) == 0 {
+		return ""
+	}
+	program := filepath.Base(words[0])
+	if wrapped, ok := buildWrappers[program]; ok {
+		program = wrapped
+	}
+	subcommands, ok := buildSubcommands[program]
+	if !ok {
+		return ""
+	}
+	if subcommands == nil {
+		return program
+	}
+
+	// The first argument that isn't a flag or a toolchain ("+nightly") is
+	// the subcommand
+	for _, arg := range words[1:] {
+		if strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "+") {
+			continue
+		}
+		if slices.Contains(subcommands, arg) {
+			return program
+		}
+		break
+	}
+	return ""
+}
+
+// summarizeBuilds totals builds per tool. Wait time counts overlapping
+// builds once, so a parallel Xcode and cargo build isn't double counted.
+func summarizeBuilds(runs []buildRun) BuildsResult {
+	result := BuildsResult{Available: false}
+	if len(runs) == 0 {
+		return result
+	}
+
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Period.Start.Before(runs[j].Period.Start) })
+	tools := map[string]*BuildTool{}
+	toolTime := map[string]time.Duration{}
+	var periods []Period
+	for _, r := range runs {
+		t := tools[r.Tool]
+		if t == nil {
+			t = &BuildTool{Tool: r.Tool}
+			tools[r.Tool] = t
+		}
+		t.Builds++
+		toolTime[r.Tool] += r.Period.End.Sub(r.Period.Start)
+		if r.Failed {
+			t.Failed++
+			result.Failed++
+		}
+		result.Builds++
+		periods = append(periods, r.Period)
+	}
+
+	var wait time.Duration
+	for _, p := range groupSessions(periods, 0) {
+		wait += p.End.Sub(p.Start)
+	}
+	result.WaitMinutes = int(wait.Minutes())
+
+	for name, t := range tools {
+		t.Minutes = int(toolTime[name].Minutes())
+		result.Tools = append(result.Tools, *t)
+	}
+	sort.Slice(result.Tools, func(i, j int) bool {
+		a, b := result.Tools[i], result.Tools[j]
+		if toolTime[a.Tool] != toolTime[b.Tool] {
+			return toolTime[a.Tool] > toolTime[b.Tool]
+		}
+		return a.Tool < b.Tool
+	})
+	result.Available = true
+	return result
+}
@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBuildTool(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"go build ./...":                 "go",
+		"go test -race ./...":            "go",
+		"go mod tidy":                    "",
+		"cargo build --release":          "cargo",
+		"cargo +nightly clippy":          "cargo",
+		"cargo fmt":                      "",
+		"CGO_ENABLED=0 make -j8 release": "make",
+		"./gradlew assembleDebug":        "gradle",
+		"npm install":                    "",
+		"npm run build":                  "npm",
+		"sudo xcodebuild -scheme App":    "xcodebuild",
+		"vim Makefile":                   "",
+		"":                               "",
+	}
+	for command, want := range tests {
+		if got := buildTool(command); got != want {
+			t.Errorf("buildTool(%q) = %q, want %q", command, got, want)
+		}
+	}
+}
+
+func TestBuilds(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 3, 9, hour, min, 0, 0, time.Local)
+	}
+	w := Window{Start: at(0, 0), End: at(18, 0)}
+	since2001 := func(t time.Time) float64 { return t.Sub(coreDataEpoch).Seconds() }
+
+	manifest := fmt.Sprintf(`{"logs": {
+		"A": {"title": "Build App", "timeStartedRecording": %f, "timeStoppedRecording": %f, "primaryObservable": {"highLevelStatus": "S"}},
+		"B": {"title": "Build App", "timeStartedRecording": %f, "timeStoppedRecording": %f, "primaryObservable": {"highLevelStatus": "E"}},
+		"C": {"title": "Clean App", "timeStartedRecording": %f, "timeStoppedRecording": %f},
+		"D": {"title": "Build App", "timeStartedRecording": %f, "timeStoppedRecording": %f}
+	}}`,
+		since2001(at(10, 0)), since2001(at(10, 10)),
+		since2001(at(11, 0)), since2001(at(11, 5)),
+		since2001(at(12, 0)), since2001(at(12, 30)),
+		since2001(at(-2, 0)), since2001(at(-1, 0)), // Yesterday
+	)
+	runs := parseXcodeManifest([]byte(manifest), w)
+	if len(runs) != 2 {
+		t.Fatalf("parsed %d Xcode builds, want 2: %+v", len(runs), runs)
+	}
+
+	runs = append(runs, shellBuilds([]shellCommand{
+		{At: at(10, 5), Duration: 10 * time.Minute, Command: "cargo build"}, // Overlaps the first Xcode build
+		{At: at(14, 0), Duration: 3 * time.Second, Command: "go build ./..."},
+		{At: at(15, 0), Duration: 20 * time.Minute, Command: "go test ./..."},
+		{At: at(16, 0), Duration: time.Hour, Command: "ssh server"},
+		{At: at(17, 50), Duration: 30 * time.Minute, Command: "make"}, // Runs past the window
+	}, w)...)
+
+	got := summarizeBuilds(runs)
+	if !got.Available || got.Builds != 5 || got.Failed != 1 {
+		t.Fatalf("got %d builds, %d failed, want 5 and 1: %+v", got.Builds, got.Failed, got)
+	}
+	// 10:00-10:15 once, 11:00-11:05, 15:00-15:20, 17:50-18:00
+	if got.WaitMinutes != 50 {
+		t.Errorf("WaitMinutes = %d, want 50", got.WaitMinutes)
+	}
+	want := []BuildTool{
+		{Tool: "go", Builds: 1, Minutes: 20},
+		{Tool: "Xcode", Builds: 2, Minutes: 15, Failed: 1},
+		{Tool: "cargo", Builds: 1, Minutes: 10},
+		{Tool: "make", Builds: 1, Minutes: 10},
+	}
+	if len(got.Tools) != len(want) {
+		t.Fatalf("tools = %+v, want %+v", got.Tools, want)
+	}
+	for i := range want {
+		if got.Tools[i] != want[i] {
+			t.Errorf("tool %d = %+v, want %+v", i, got.Tools[i], want[i])
+		}
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
 )
 
 // BurnoutWarning represents a specific burnout indicator
 type BurnoutWarning struct {
-	Type        string // "long_day", "high_switching", "tab_overload", "late_night", "no_breaks"
+	Type        string // "long_day", "high_switching", "tab_overload", "late_night", "no_breaks", "after_hours"
 	Message     string
 	Severity    string // "low", "medium", "high"
 	MetricValue int    // The actual value that triggered the warning
@@ -27,78 +29,113 @@ type BurnoutConfig struct {
 	LongDayHours       int // Default: 10 hours
 	AppSwitchesPerHour int // Default: 50 switches/hour
 	MaxTabs            int // Default: 100 tabs
-	LateNightHour      int // Default: 0 (midnight)
+	LateNightStart     int // Default: 0 (midnight), in minutes after midnight
+	LateNightEnd       int // Default: 360 (6am); before LateNightStart wraps past midnight
 	NoBreakHours       int // Default: 4 hours
+	AfterHoursMinutes  int // Default: 60 minutes outside schedule.work_hours
 }
 
-// DefaultBurnoutConfig returns default burnout detection thresholds
+// DefaultBurnoutConfig returns default burnout detection thresholds, the
+// defaults of the wellness config block
 func DefaultBurnoutConfig() BurnoutConfig {
+	return BurnoutConfigFor(config.Default())
+}
+
+// BurnoutConfigFor returns the thresholds set in cfg's wellness block
+func BurnoutConfigFor(cfg *config.Config) BurnoutConfig {
+	start, end := cfg.LateNightWindow()
 	return BurnoutConfig{
-		LongDayHours:       10,
-		AppSwitchesPerHour: 50,
-		MaxTabs:            100,
-		LateNightHour:      0,
-		NoBreakHours:       4,
+		LongDayHours:       cfg.Wellness.LongDayHours,
+		AppSwitchesPerHour: cfg.Wellness.SwitchesPerHour,
+		MaxTabs:            cfg.Wellness.MaxTabs,
+		LateNightStart:     start,
+		LateNightEnd:       end,
+		NoBreakHours:       cfg.Wellness.NoBreakHours,
+		AfterHoursMinutes:  cfg.Wellness.AfterHoursMinutes,
 	}
 }
 
-// CollectBurnout analyzes activity patterns for burnout indicators
-func CollectBurnout(ctx context.Context, screen ScreenResult, browsers BrowsersResult, config BurnoutConfig) BurnoutResult {
-	result := BurnoutResult{
-		Warnings:  []BurnoutWarning{},
-		Available: true,
+// CollectBurnout analyzes activity patterns in w for burnout indicators:
+// the checks in CalculateBurnout plus the ones that need Screen Time, which
+// leave out excludedApps as the apps collector does
+func CollectBurnout(ctx context.Context, excludedApps []string, screen ScreenResult, bounds DayBoundsResult, browsers BrowsersResult, config BurnoutConfig, w Window) BurnoutResult {
+	result := CalculateBurnout(screen, bounds, browsers, config)
+
+	// Open knowledgeC.db once for all DB-backed checks
+	db, err := openKnowledgeDB()
+	if err != nil {
+		return result
 	}
+	defer db.Close()
 
-	// Check 1: Long work day (>10h screen-on)
-	if screen.Available {
-		longDayHours := screen.ScreenOnMinutes / 60
-		if longDayHours >= config.LongDayHours {
+	// Check 2: High app switching rate
+	appSwitchRate, err := calculateAppSwitchRate(ctx, db, excludedApps, w)
+	if err == nil && appSwitchRate > 0 {
+		if appSwitchRate >= config.AppSwitchesPerHour {
 			result.Warnings = append(result.Warnings, BurnoutWarning{
-				Type:        "long_day",
-				Message:     fmt.Sprintf("Long work day: %dh+ screen time", longDayHours),
+				Type:        "high_switching",
+				Message:     fmt.Sprintf("High task switching: %d app switches/hour", appSwitchRate),
 				Severity:    "medium",
-				MetricValue: longDayHours,
+				MetricValue: appSwitchRate,
 			})
 		}
 	}
 
-	// Open knowledgeC.db once for all DB-backed checks
-	db, err := openKnowledgeDB()
-	if err == nil {
-		defer db.Close()
-
-		// Check 2: High app switching rate (>50 switches/hour)
-		appSwitchRate, err := calculateAppSwitchRate(ctx, db)
-		if err == nil && appSwitchRate > 0 {
-			if appSwitchRate >= config.AppSwitchesPerHour {
-				result.Warnings = append(result.Warnings, BurnoutWarning{
-					Type:        "high_switching",
-					Message:     fmt.Sprintf("High task switching: %d app switches/hour", appSwitchRate),
-					Severity:    "medium",
-					MetricValue: appSwitchRate,
-				})
-			}
+	// Check 4: Late night work (activity in the late-night window)
+	lateNightMinutes, err := detectLateNightWork(ctx, db, excludedApps, config, w)
+	if err == nil && lateNightMinutes > 0 {
+		past := "midnight"
+		if config.LateNightStart != 0 {
+			past = fmt.Sprintf("%02d:%02d", config.LateNightStart/60, config.LateNightStart%60)
 		}
+		result.Warnings = append(result.Warnings, BurnoutWarning{
+			Type:        "late_night",
+			Message:     fmt.Sprintf("Late night work: %d minutes past %s", lateNightMinutes, past),
+			Severity:    "high",
+			MetricValue: lateNightMinutes,
+		})
+	}
 
-		// Check 4: Late night work (activity past midnight)
-		lateNightMinutes, err := detectLateNightWork(ctx, db)
-		if err == nil && lateNightMinutes > 0 {
-			result.Warnings = append(result.Warnings, BurnoutWarning{
-				Type:        "late_night",
-				Message:     fmt.Sprintf("Late night work: %d minutes past midnight", lateNightMinutes),
-				Severity:    "high",
-				MetricValue: lateNightMinutes,
-			})
-		}
+	// Check 5: No breaks (continuous focus)
+	longestStreak, err := calculateLongestNoBreakPeriod(ctx, db, excludedApps, w)
+	if err == nil && longestStreak >= config.NoBreakHours*60 {
+		result.Warnings = append(result.Warnings, BurnoutWarning{
+			Type:        "no_breaks",
+			Message:     fmt.Sprintf("No breaks: %dh+ continuous focus", longestStreak/60),
+			Severity:    "high",
+			MetricValue: longestStreak / 60,
+		})
+	}
+
+	return result
+}
 
-		// Check 5: No breaks (continuous focus >4h)
-		longestStreak, err := calculateLongestNoBreakPeriod(ctx, db)
-		if err == nil && longestStreak >= config.NoBreakHours*60 {
+// CalculateBurnout runs the burnout checks that only need other collectors'
+// results: long days and tab overload
+func CalculateBurnout(screen ScreenResult, bounds DayBoundsResult, browsers BrowsersResult, config BurnoutConfig) BurnoutResult {
+	result := BurnoutResult{
+		Warnings:  []BurnoutWarning{},
+		Available: true,
+	}
+
+	// Check 1: Long work day (>10h from arrival to wrap-up, or >10h screen-on
+	// when the day's bounds are unknown)
+	if bounds.Available && bounds.SpanMinutes/60 >= config.LongDayHours {
+		spanHours := bounds.SpanMinutes / 60
+		result.Warnings = append(result.Warnings, BurnoutWarning{
+			Type:        "long_day",
+			Message:     fmt.Sprintf("Long work day: %dh+ from first to last activity", spanHours),
+			Severity:    "medium",
+			MetricValue: spanHours,
+		})
+	} else if screen.Available {
+		longDayHours := screen.ScreenOnMinutes / 60
+		if longDayHours >= config.LongDayHours {
 			result.Warnings = append(result.Warnings, BurnoutWarning{
-				Type:        "no_breaks",
-				Message:     fmt.Sprintf("No breaks: %dh+ continuous focus", longestStreak/60),
-				Severity:    "high",
-				MetricValue: longestStreak / 60,
+				Type:        "long_day",
+				Message:     fmt.Sprintf("Long work day: %dh+ screen time", longDayHours),
+				Severity:    "medium",
+				MetricValue: longDayHours,
 			})
 		}
 	}
@@ -116,30 +153,75 @@ func CollectBurnout(ctx context.Context, screen ScreenResult, browsers BrowsersR
 	return result
 }
 
-// calculateAppSwitchRate calculates the number of app switches per hour
-func calculateAppSwitchRate(ctx context.Context, db *sql.DB) (int, error) {
-	startTimestamp, endTimestamp := todayTimestampRange()
+// CheckAfterHours adds a warning to result when screen time outside work
+// hours reaches the after-hours threshold
+func CheckAfterHours(result BurnoutResult, work WorkHoursResult, config BurnoutConfig) BurnoutResult {
+	if !result.Available || !work.Available || work.AfterHoursMinutes < config.AfterHoursMinutes {
+		return result
+	}
+	result.Warnings = append(result.Warnings, BurnoutWarning{
+		Type:        "after_hours",
+		Message:     fmt.Sprintf("After hours: %dh %dm of screen time outside work hours", work.AfterHoursMinutes/60, work.AfterHoursMinutes%60),
+		Severity:    "medium",
+		MetricValue: work.AfterHoursMinutes,
+	})
+	return result
+}
+
+// usageSpan is one app-usage event's start and end, in Core Data seconds
+type usageSpan struct {
+	start float64
+	end   float64
+}
 
-	// Count distinct app usage events (each represents a switch)
+// appUsageSpans returns the app-usage events between the two timestamps in
+// start order, leaving out excludedApps
+func appUsageSpans(ctx context.Context, db *sql.DB, excludedApps []string, startTimestamp, endTimestamp float64) ([]usageSpan, error) {
 	query := `
-		SELECT COUNT(*) as switch_count
+		SELECT ZVALUESTRING, ZSTARTDATE, ZENDDATE
 		FROM ZOBJECT
-		WHERE ZSTREAMNAME = '/app/usage'
+		WHERE ZSTREAMNAME = ?
 			AND ZSTARTDATE >= ?
 			AND ZENDDATE <= ?
 			AND ZVALUESTRING IS NOT NULL
 			AND ZVALUESTRING != ''
+		ORDER BY ZSTARTDATE ASC
 	`
 
-	var switchCount int
-	if err := db.QueryRowContext(ctx, query, startTimestamp, endTimestamp).Scan(&switchCount); err != nil {
+	rows, err := db.QueryContext(ctx, query, appUsageStream(), startTimestamp, endTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var spans []usageSpan
+	for rows.Next() {
+		var bundleID string
+		var span usageSpan
+		if err := rows.Scan(&bundleID, &span.start, &span.end); err != nil {
+			continue
+		}
+		if isExcluded(resolveAppName(bundleID), bundleID, excludedApps) {
+			continue
+		}
+		spans = append(spans, span)
+	}
+	return spans, rows.Err()
+}
+
+// calculateAppSwitchRate calculates the number of app switches per hour in w
+func calculateAppSwitchRate(ctx context.Context, db *sql.DB, excludedApps []string, w Window) (int, error) {
+	startTimestamp, endTimestamp := timestampRange(w)
+
+	// Each app usage event represents a switch
+	spans, err := appUsageSpans(ctx, db, excludedApps, startTimestamp, endTimestamp)
+	if err != nil {
 		return 0, fmt.Errorf("failed to query switch count: %w", err)
 	}
+	switchCount := len(spans)
 
 	// Calculate rate per hour
-	now := time.Now()
-	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	hoursActive := time.Since(midnight).Hours()
+	hoursActive := w.End.Sub(w.Start).Hours()
 	if hoursActive < 1 {
 		hoursActive = 1
 	}
@@ -148,73 +230,60 @@ func calculateAppSwitchRate(ctx context.Context, db *sql.DB) (int, error) {
 	return rate, nil
 }
 
-// detectLateNightWork detects app usage past midnight (00:00-06:00)
-func detectLateNightWork(ctx context.Context, db *sql.DB) (int, error) {
-	now := time.Now()
-	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	earlyMorning := midnight.Add(6 * time.Hour)
+// detectLateNightWork detects app usage in the late-night window (by
+// default 00:00-06:00) in w
+func detectLateNightWork(ctx context.Context, db *sql.DB, excludedApps []string, config BurnoutConfig, w Window) (int, error) {
+	opens, closes, ok := w.clip(lateNightWindow(DayStart(w.Start), config.LateNightStart, config.LateNightEnd))
+	if !ok {
+		return 0, nil
+	}
 
-	startTimestamp := midnight.Sub(coreDataEpoch).Seconds()
-	endTimestamp := earlyMorning.Sub(coreDataEpoch).Seconds()
+	startTimestamp := opens.Sub(coreDataEpoch).Seconds()
+	endTimestamp := closes.Sub(coreDataEpoch).Seconds()
 
 	// Sum up activity time in late night hours
-	query := `
-		SELECT SUM(ZENDDATE - ZSTARTDATE) as total_seconds
-		FROM ZOBJECT
-		WHERE ZSTREAMNAME = '/app/usage'
-			AND ZSTARTDATE >= ?
-			AND ZENDDATE <= ?
-			AND ZVALUESTRING IS NOT NULL
-			AND ZVALUESTRING != ''
-	`
-
-	var totalSeconds sql.NullFloat64
-	if err := db.QueryRowContext(ctx, query, startTimestamp, endTimestamp).Scan(&totalSeconds); err != nil {
+	spans, err := appUsageSpans(ctx, db, excludedApps, startTimestamp, endTimestamp)
+	if err != nil {
 		return 0, fmt.Errorf("failed to query late night activity: %w", err)
 	}
 
-	if !totalSeconds.Valid {
-		return 0, nil
+	var totalSeconds float64
+	for _, s := range spans {
+		totalSeconds += s.end - s.start
 	}
+	return int(totalSeconds / 60), nil // Return minutes
+}
 
-	return int(totalSeconds.Float64 / 60), nil // Return minutes
+// lateNightWindow returns the late-night window, start to end minutes
+// after midnight, belonging to the day that starts at dayStart: the first
+// time the window opens at or after dayStart, clipped so it never spills
+// into the next day. With the default 00:00-06:00 and a later day boundary
+// that's the midnight inside the day.
+func lateNightWindow(dayStart time.Time, start, end int) (time.Time, time.Time) {
+	opens := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, start, 0, 0, dayStart.Location())
+	if opens.Before(dayStart) {
+		opens = opens.AddDate(0, 0, 1)
+	}
+	length := end - start
+	if length <= 0 {
+		length += 24 * 60
+	}
+	closes := opens.Add(time.Duration(length) * time.Minute)
+	if dayEnd := dayStart.AddDate(0, 0, 1); closes.After(dayEnd) {
+		closes = dayEnd
+	}
+	return opens, closes
 }
 
-// calculateLongestNoBreakPeriod finds the longest continuous work period without breaks
-func calculateLongestNoBreakPeriod(ctx context.Context, db *sql.DB) (int, error) {
-	startTimestamp, endTimestamp := todayTimestampRange()
+// calculateLongestNoBreakPeriod finds the longest continuous work period in w without breaks
+func calculateLongestNoBreakPeriod(ctx context.Context, db *sql.DB, excludedApps []string, w Window) (int, error) {
+	startTimestamp, endTimestamp := timestampRange(w)
 
 	// Get all app usage intervals ordered by time
-	query := `
-		SELECT ZSTARTDATE, ZENDDATE
-		FROM ZOBJECT
-		WHERE ZSTREAMNAME = '/app/usage'
-			AND ZSTARTDATE >= ?
-			AND ZENDDATE <= ?
-			AND ZVALUESTRING IS NOT NULL
-			AND ZVALUESTRING != ''
-		ORDER BY ZSTARTDATE ASC
-	`
-
-	rows, err := db.QueryContext(ctx, query, startTimestamp, endTimestamp)
+	intervals, err := appUsageSpans(ctx, db, excludedApps, startTimestamp, endTimestamp)
 	if err != nil {
 		return 0, fmt.Errorf("failed to query intervals: %w", err)
 	}
-	defer rows.Close()
-
-	type interval struct {
-		start float64
-		end   float64
-	}
-
-	var intervals []interval
-	for rows.Next() {
-		var start, end float64
-		if err := rows.Scan(&start, &end); err != nil {
-			continue
-		}
-		intervals = append(intervals, interval{start, end})
-	}
 
 	if len(intervals) == 0 {
 		return 0, nil
@@ -3,6 +3,9 @@ package collectors
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
 )
 
 func TestDefaultBurnoutConfig(t *testing.T) {
@@ -18,14 +21,36 @@ func TestDefaultBurnoutConfig(t *testing.T) {
 	if cfg.MaxTabs != 100 {
 		t.Errorf("Expected MaxTabs to be 100, got %d", cfg.MaxTabs)
 	}
-	if cfg.LateNightHour != 0 {
-		t.Errorf("Expected LateNightHour to be 0, got %d", cfg.LateNightHour)
+	if cfg.LateNightStart != 0 || cfg.LateNightEnd != 360 {
+		t.Errorf("Expected late night to be 0-360, got %d-%d", cfg.LateNightStart, cfg.LateNightEnd)
 	}
 	if cfg.NoBreakHours != 4 {
 		t.Errorf("Expected NoBreakHours to be 4, got %d", cfg.NoBreakHours)
 	}
 }
 
+func TestBurnoutConfigFor(t *testing.T) {
+	t.Parallel()
+	cfg := config.Default()
+	cfg.Wellness.LongDayHours = 8
+	cfg.Wellness.MaxTabs = 40
+	cfg.Wellness.LateNight = "23:30-05:00"
+
+	got := BurnoutConfigFor(cfg)
+	if got.LongDayHours != 8 || got.MaxTabs != 40 || got.AppSwitchesPerHour != 50 {
+		t.Errorf("thresholds = %+v", got)
+	}
+	if got.LateNightStart != 23*60+30 || got.LateNightEnd != 5*60 {
+		t.Errorf("late night = %d-%d, want 1410-300", got.LateNightStart, got.LateNightEnd)
+	}
+
+	// Lower thresholds warn sooner
+	burnout := CalculateBurnout(ScreenResult{ScreenOnMinutes: 9 * 60, Available: true}, DayBoundsResult{}, BrowsersResult{TotalTabs: 45, Available: true}, got)
+	if len(burnout.Warnings) != 2 {
+		t.Errorf("warnings = %+v, want long day and tab overload", burnout.Warnings)
+	}
+}
+
 func TestCollectBurnout_LongDay(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -42,7 +67,7 @@ func TestCollectBurnout_LongDay(t *testing.T) {
 		Available: true,
 	}
 
-	result := CollectBurnout(ctx, screen, browsers, config)
+	result := CollectBurnout(ctx, nil, screen, DayBoundsResult{}, browsers, config, Today(time.Now()))
 
 	if !result.Available {
 		t.Error("Expected burnout result to be available")
@@ -82,7 +107,7 @@ func TestCollectBurnout_TabOverload(t *testing.T) {
 		Available: true,
 	}
 
-	result := CollectBurnout(ctx, screen, browsers, config)
+	result := CollectBurnout(ctx, nil, screen, DayBoundsResult{}, browsers, config, Today(time.Now()))
 
 	if !result.Available {
 		t.Error("Expected burnout result to be available")
@@ -114,7 +139,8 @@ func TestCollectBurnout_NoWarnings(t *testing.T) {
 		LongDayHours:       24,
 		AppSwitchesPerHour: 10000,
 		MaxTabs:            10000,
-		LateNightHour:      0,
+		LateNightStart:     0,
+		LateNightEnd:       6 * 60,
 		NoBreakHours:       24,
 	}
 
@@ -129,7 +155,7 @@ func TestCollectBurnout_NoWarnings(t *testing.T) {
 		Available: true,
 	}
 
-	result := CollectBurnout(ctx, screen, browsers, config)
+	result := CollectBurnout(ctx, nil, screen, DayBoundsResult{}, browsers, config, Today(time.Now()))
 
 	if !result.Available {
 		t.Error("Expected burnout result to be available")
@@ -161,7 +187,7 @@ func TestCollectBurnout_MultipleWarnings(t *testing.T) {
 		Available: true,
 	}
 
-	result := CollectBurnout(ctx, screen, browsers, config)
+	result := CollectBurnout(ctx, nil, screen, DayBoundsResult{}, browsers, config, Today(time.Now()))
 
 	if !result.Available {
 		t.Error("Expected burnout result to be available")
@@ -199,7 +225,7 @@ func TestCollectBurnout_UnavailableData(t *testing.T) {
 		Available: false,
 	}
 
-	result := CollectBurnout(ctx, screen, browsers, config)
+	result := CollectBurnout(ctx, nil, screen, DayBoundsResult{}, browsers, config, Today(time.Now()))
 
 	if !result.Available {
 		t.Error("Expected burnout result to be available even when data is not")
@@ -0,0 +1,168 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// ClipboardResult contains how often the clipboard changed in the window.
+// Only the pasteboard's change counter is read, never what was copied.
+type ClipboardResult struct {
+	Changes        int
+	PerHour        [24]int // Changes by hour of day
+	BusiestHour    int
+	ChangesPerHour float64 // Over the time between samples
+	Samples        int
+	Available      bool
+	Error          error
+}
+
+// clipboardSample is a single reading of the pasteboard's change counter
+// persisted to the daily log
+type clipboardSample struct {
+	Timestamp   string `json:"timestamp"`
+	ChangeCount int    `json:"change_count"`
+}
+
+// RecordClipboardSample reads the clipboard's change counter and appends it
+// to today's sample log
+func RecordClipboardSample(ctx context.Context) error {
+	count, err := readClipboardChangeCount(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	samples, _ := loadClipboardSamples(DayKey(now))
+	if shouldRecordClipboardSample(samples, now) {
+		samples = append(samples, clipboardSample{Timestamp: now.Format(time.RFC3339), ChangeCount: count})
+		if err := state.Save(state.KindClipboard, DayKey(now), samples); err != nil {
+			return fmt.Errorf("failed to save clipboard sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// CollectClipboard counts clipboard changes in w from the change counter
+// sampled by each run and by "rekap sample --every"
+func CollectClipboard(ctx context.Context, enabled bool, w Window) ClipboardResult {
+	if !enabled {
+		return ClipboardResult{Error: fmt.Errorf("clipboard counts are off (set tracking.clipboard: true)")}
+	}
+
+	var sampleErr error
+	if w.Live() {
+		sampleErr = RecordClipboardSample(ctx)
+	}
+
+	var samples []clipboardSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadClipboardSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeClipboardSamples(samples, w)
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("clipboard counts unavailable: %w", sampleErr)
+	}
+	return result
+}
+
+// summarizeClipboardSamples credits the counter's rise between consecutive
+// samples to the hour of the later one. The counter restarts at login, so
+// a drop is skipped rather than counted.
+func summarizeClipboardSamples(samples []clipboardSample, w Window) ClipboardResult {
+	result := ClipboardResult{Available: false}
+
+	type timedSample struct {
+		count int
+		at    time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s.ChangeCount, at})
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	var covered time.Duration
+	for i, s := range timed {
+		if s.at.Before(w.Start) {
+			continue
+		}
+		result.Samples++
+		if i == 0 || s.count < timed[i-1].count {
+			continue
+		}
+		changes := s.count - timed[i-1].count
+		result.Changes += changes
+		result.PerHour[s.at.Hour()] += changes
+		covered += s.at.Sub(timed[i-1].at)
+	}
+
+	if result.Samples < 2 {
+		result.Error = fmt.Errorf("not enough clipboard samples recorded in this window")
+		return result
+	}
+	for hour, n := range result.PerHour {
+		if n > result.PerHour[result.BusiestHour] {
+			result.BusiestHour = hour
+		}
+	}
+	if covered > 0 {
+		result.ChangesPerHour = float64(result.Changes) / covered.Hours()
+	}
+	result.Available = true
+	return result
+}
+
+// readClipboardChangeCount reads NSPasteboard's changeCount, which goes up
+// by one each time anything is copied
+func readClipboardChangeCount(ctx context.Context) (int, error) {
+	cmd := exec.CommandContext(ctx, "osascript", "-l", "JavaScript", "-e",
+		`ObjC.import("AppKit"); $.NSPasteboard.generalPasteboard.changeCount`)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read clipboard change count: %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected clipboard change count %q", strings.TrimSpace(string(output)))
+	}
+	return count, nil
+}
+
+// shouldRecordClipboardSample limits samples to one per
+// appSampleMinInterval, so running rekap repeatedly doesn't flood the log
+func shouldRecordClipboardSample(samples []clipboardSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadClipboardSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadClipboardSamples(date string) ([]clipboardSample, error) {
+	var samples []clipboardSample
+	if _, err := state.Load(state.KindClipboard, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
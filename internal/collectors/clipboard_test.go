@@ -0,0 +1,45 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeClipboardSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) string {
+		return time.Date(2026, 3, 9, hour, min, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	w := Window{Start: time.Date(2026, 3, 9, 9, 0, 0, 0, time.Local), End: time.Date(2026, 3, 9, 18, 0, 0, 0, time.Local)}
+
+	samples := []clipboardSample{
+		{at(8, 30), 100}, // Before the window: only a baseline
+		{at(9, 30), 110},
+		{at(10, 30), 140},
+		{at(10, 0), 120}, // Out of order
+		{at(11, 0), 5},   // Counter restarted at login
+		{at(11, 30), 9},
+		{at(18, 30), 50}, // After the window
+		{"garbage", 999},
+	}
+
+	got := summarizeClipboardSamples(samples, w)
+	if !got.Available || got.Samples != 5 {
+		t.Fatalf("got %+v, want 5 samples available", got)
+	}
+	// 10 + 10 + 20 + 4, skipping the restart
+	if got.Changes != 44 {
+		t.Errorf("Changes = %d, want 44", got.Changes)
+	}
+	if got.PerHour[9] != 10 || got.PerHour[10] != 30 || got.PerHour[11] != 4 || got.BusiestHour != 10 {
+		t.Errorf("PerHour 9-11 = %v, busiest %d; want [10 30 4], busiest 10", got.PerHour[9:12], got.BusiestHour)
+	}
+	// 44 changes over the 2.5h covered by counted pairs
+	if got.ChangesPerHour < 17.59 || got.ChangesPerHour > 17.61 {
+		t.Errorf("ChangesPerHour = %.2f, want 17.6", got.ChangesPerHour)
+	}
+
+	if one := summarizeClipboardSamples(samples[:2], w); one.Available {
+		t.Errorf("a single sample in the window = %+v, want unavailable", one)
+	}
+}
@@ -0,0 +1,142 @@
+package collectors
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cloud console providers
+const (
+	CloudAWS   = "aws"
+	CloudGCP   = "gcp"
+	CloudAzure = "azure"
+)
+
+// CloudProviderNames are the display names of the cloud console providers
+var CloudProviderNames = map[string]string{
+	CloudAWS:   "AWS",
+	CloudGCP:   "Google Cloud",
+	CloudAzure: "Azure",
+}
+
+// cloudConsoleHosts maps console hostnames (and their subdomains, such as
+// us-east-1.console.aws.amazon.com) to providers. Documentation and
+// marketing pages on the same domains aren't console time.
+var cloudConsoleHosts = []struct {
+	host     string
+	provider string
+}{
+	{"console.aws.amazon.com", CloudAWS},
+	{"awsapps.com", CloudAWS}, // IAM Identity Center portals
+	{"console.cloud.google.com", CloudGCP},
+	{"shell.cloud.google.com", CloudGCP},
+	{"portal.azure.com", CloudAzure},
+	{"shell.azure.com", CloudAzure},
+}
+
+const (
+	// cloudSessionGap is the pause between console page visits that ends a
+	// console session
+	cloudSessionGap = 10 * time.Minute
+
+	// cloudVisitCredit is the time credited after a session's last visit,
+	// since the page stays in use after it loads
+	cloudVisitCredit = 2 * time.Minute
+
+	// LongConsoleSession is the console session length that gets flagged.
+	// Long stretches in a production console are worth a second look.
+	LongConsoleSession = 90 * time.Minute
+)
+
+// CloudVisit is one page visit to a cloud provider's console
+type CloudVisit struct {
+	Provider string // CloudAWS, CloudGCP, or CloudAzure
+	At       time.Time
+}
+
+// CloudProviderTime is the time spent in one provider's console
+type CloudProviderTime struct {
+	Provider string
+	Name     string
+	Minutes  int
+	Sessions int
+	Visits   int
+}
+
+// CloudSession is a run of console visits to one provider
+type CloudSession struct {
+	Provider string
+	Period
+}
+
+// CloudConsolesResult breaks down browser time in cloud consoles by
+// provider, estimated from page visit times in browser history
+type CloudConsolesResult struct {
+	Providers    []CloudProviderTime // Most time first
+	LongSessions []CloudSession      // Sessions of at least LongConsoleSession, oldest first
+	TotalMinutes int
+	Available    bool
+	Error        error
+}
+
+// cloudProvider returns the provider whose console domain is, or is a
+// subdomain of, one of cloudConsoleHosts
+func cloudProvider(domain string) string {
+	for _, c := range cloudConsoleHosts {
+		if domain == c.host || strings.HasSuffix(domain, "."+c.host) {
+			return c.provider
+		}
+	}
+	return ""
+}
+
+// CalculateCloudConsoles groups the cloud console visits in browsers into
+// sessions per provider. Visits less than cloudSessionGap apart belong to
+// one session, which lasts until cloudVisitCredit after its last visit.
+func CalculateCloudConsoles(browsers BrowsersResult) CloudConsolesResult {
+	result := CloudConsolesResult{Available: false}
+	if len(browsers.CloudVisits) == 0 {
+		return result
+	}
+
+	byProvider := make(map[string][]time.Time)
+	for _, v := range browsers.CloudVisits {
+		byProvider[v.Provider] = append(byProvider[v.Provider], v.At)
+	}
+
+	for provider, times := range byProvider {
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+		var sessions []Period
+		for _, at := range times {
+			p := Period{Start: at, End: at.Add(cloudVisitCredit)}
+			if n := len(sessions); n > 0 && !at.After(sessions[n-1].End.Add(cloudSessionGap-cloudVisitCredit)) {
+				sessions[n-1].End = p.End
+				continue
+			}
+			sessions = append(sessions, p)
+		}
+
+		pt := CloudProviderTime{Provider: provider, Name: CloudProviderNames[provider], Sessions: len(sessions), Visits: len(times)}
+		for _, s := range sessions {
+			pt.Minutes += s.Minutes()
+			if s.End.Sub(s.Start) >= LongConsoleSession {
+				result.LongSessions = append(result.LongSessions, CloudSession{Provider: provider, Period: s})
+			}
+		}
+		result.Providers = append(result.Providers, pt)
+		result.TotalMinutes += pt.Minutes
+	}
+
+	sort.Slice(result.Providers, func(i, j int) bool {
+		if result.Providers[i].Minutes != result.Providers[j].Minutes {
+			return result.Providers[i].Minutes > result.Providers[j].Minutes
+		}
+		return result.Providers[i].Provider < result.Providers[j].Provider
+	})
+	sort.Slice(result.LongSessions, func(i, j int) bool { return result.LongSessions[i].Start.Before(result.LongSessions[j].Start) })
+
+	result.Available = true
+	return result
+}
@@ -0,0 +1,63 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCloudProvider(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"console.aws.amazon.com":           CloudAWS,
+		"us-east-1.console.aws.amazon.com": CloudAWS,
+		"myorg.awsapps.com":                CloudAWS,
+		"aws.amazon.com":                   "", // Marketing and docs
+		"docs.aws.amazon.com":              "",
+		"console.cloud.google.com":         CloudGCP,
+		"cloud.google.com":                 "",
+		"portal.azure.com":                 CloudAzure,
+		"ms.portal.azure.com":              CloudAzure,
+		"notportal.azure.com":              "",
+	}
+	for domain, want := range tests {
+		if got := cloudProvider(domain); got != want {
+			t.Errorf("cloudProvider(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
+
+func TestCalculateCloudConsoles(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+
+	var visits []CloudVisit
+	// A 100-minute AWS session with a visit every 7 minutes
+	for m := 0; m <= 98; m += 7 {
+		visits = append(visits, CloudVisit{Provider: CloudAWS, At: at(13, m)})
+	}
+	visits = append(visits,
+		CloudVisit{Provider: CloudAWS, At: at(16, 0)}, // A separate short session
+		CloudVisit{Provider: CloudGCP, At: at(10, 0)},
+		CloudVisit{Provider: CloudGCP, At: at(10, 9)},
+	)
+
+	result := CalculateCloudConsoles(BrowsersResult{CloudVisits: visits})
+	if !result.Available || len(result.Providers) != 2 {
+		t.Fatalf("got %+v, want 2 providers", result)
+	}
+	aws, gcp := result.Providers[0], result.Providers[1]
+	if aws.Name != "AWS" || aws.Minutes != 102 || aws.Sessions != 2 || aws.Visits != 16 {
+		t.Errorf("AWS = %+v, want 102 minutes over 2 sessions and 16 visits", aws)
+	}
+	if gcp.Minutes != 11 || gcp.Sessions != 1 {
+		t.Errorf("Google Cloud = %+v, want 11 minutes in 1 session", gcp)
+	}
+	if len(result.LongSessions) != 1 || result.LongSessions[0].Provider != CloudAWS || result.LongSessions[0].Minutes() != 100 {
+		t.Errorf("LongSessions = %+v, want the 100-minute AWS session", result.LongSessions)
+	}
+
+	if CalculateCloudConsoles(BrowsersResult{}).Available {
+		t.Error("expected no breakdown without console visits")
+	}
+}
@@ -14,7 +14,7 @@ func TestCollectUptime(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectUptime(ctx)
+	result := CollectUptime(ctx, Today(time.Now()))
 
 	// Uptime collection may fail in some environments (e.g., CI, Linux)
 	if !result.Available {
@@ -39,7 +39,7 @@ func TestCollectBattery(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectBattery(ctx)
+	result := CollectBattery(ctx, Today(time.Now()))
 
 	if !result.Available {
 		t.Skip("Battery not available (running on desktop?)")
@@ -59,7 +59,7 @@ func TestCollectScreen(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectScreen(ctx)
+	result := CollectScreen(ctx, config.SourceAuto, Today(time.Now()))
 
 	// Screen collection is best-effort, may not always work
 	if !result.Available {
@@ -90,12 +90,45 @@ func TestCollectScreen(t *testing.T) {
 		result.ScreenOnMinutes, result.LockCount, result.AvgMinsBetweenLock)
 }
 
+func TestScreenFromPeriods(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 17, hour, min, 0, 0, time.Local)
+	}
+
+	periods := []Period{
+		{at(7, 30), at(9, 30)}, // Clipped to the 8:00 day start
+		{at(9, 30), at(10, 0)}, // Back-to-back: merged, not a lock
+		{at(10, 30), at(11, 0)},
+		{at(11, 10), at(11, 40)},
+		{at(13, 0), at(15, 0)}, // Still on: clipped to the window end
+	}
+	w := Window{Start: at(8, 0), End: at(14, 0)}
+	result := screenFromPeriods(periods, w)
+	if !result.Available {
+		t.Fatalf("expected screen data, got error %v", result.Error)
+	}
+	if result.ScreenOnMinutes != 120+30+30+60 {
+		t.Errorf("ScreenOnMinutes = %d, want 240", result.ScreenOnMinutes)
+	}
+	if result.LockCount != 3 {
+		t.Errorf("LockCount = %d, want 3", result.LockCount)
+	}
+	if result.AvgMinsBetweenLock != 30 {
+		t.Errorf("AvgMinsBetweenLock = %d, want 30", result.AvgMinsBetweenLock)
+	}
+
+	if empty := screenFromPeriods(nil, w); empty.Available || empty.Error == nil {
+		t.Errorf("expected no screen data without periods, got %+v", empty)
+	}
+}
+
 func TestCollectApps(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectApps(ctx, nil)
+	result := CollectApps(ctx, nil, config.SourceAuto, Today(time.Now()))
 
 	// Apps require Full Disk Access, may not be available
 	if !result.Available {
@@ -118,7 +151,7 @@ func TestCollectMedia(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectMedia(ctx)
+	result := CollectMedia(ctx, config.SourceAuto, Today(time.Now()))
 
 	// Media is optional, test if available
 	if !result.Available {
@@ -140,7 +173,7 @@ func TestCollectFocus(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectFocus(ctx)
+	result := CollectFocus(ctx, nil, Today(time.Now()))
 
 	// Focus tracking requires Full Disk Access, may not be available
 	if !result.Available {
@@ -159,6 +192,39 @@ func TestCollectFocus(t *testing.T) {
 	t.Logf("Best flow: %dm in %s", result.StreakMinutes, result.AppName)
 }
 
+func TestCollectPastDayWindow(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	now := time.Now()
+	w := DayWindow(now.AddDate(0, 0, -1), now)
+
+	apps := CollectApps(ctx, nil, config.SourceScreenTime, w)
+	notifications := CollectNotifications(ctx, nil, w)
+	focus := CollectFocus(ctx, nil, w)
+
+	// Past days require Full Disk Access, may not be available
+	if !apps.Available {
+		t.Log("App tracking not available (needs Full Disk Access)")
+		return
+	}
+
+	total := 0
+	for _, app := range apps.TopApps {
+		total += app.Minutes
+	}
+	if total > 24*60 {
+		t.Errorf("Yesterday's app minutes should fit in a day, got %d", total)
+	}
+	if focus.Available && focus.StreakMinutes > 24*60 {
+		t.Errorf("Yesterday's focus streak should fit in a day, got %d", focus.StreakMinutes)
+	}
+	if notifications.TotalNotifications < 0 {
+		t.Errorf("TotalNotifications should be >= 0, got %d", notifications.TotalNotifications)
+	}
+}
+
 func TestCollectorTimeout(t *testing.T) {
 	t.Parallel()
 	// Test that collectors respect context timeout
@@ -168,7 +234,7 @@ func TestCollectorTimeout(t *testing.T) {
 	time.Sleep(2 * time.Millisecond)
 
 	// This should return quickly even though context is already done
-	result := CollectUptime(ctx)
+	result := CollectUptime(ctx, Today(time.Now()))
 
 	// Even with expired context, best-effort should still work
 	if !result.Available {
@@ -181,7 +247,7 @@ func TestCollectNetwork(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectNetwork(ctx)
+	result := CollectNetwork(ctx, nil, false, Today(time.Now()))
 
 	// Network collection is best-effort, may not always work
 	if !result.Available {
@@ -212,7 +278,7 @@ func TestCollectBrowserTabs(t *testing.T) {
 	defer cancel()
 
 	cfg := config.Default()
-	result := CollectBrowserTabs(ctx, cfg)
+	result := CollectBrowserTabs(ctx, cfg, Today(time.Now()))
 
 	// Browser collection is best-effort and depends on running browsers
 	if !result.Available {
@@ -265,25 +331,27 @@ func TestCollectBrowserTabs(t *testing.T) {
 
 func TestIsExcluded(t *testing.T) {
 	t.Parallel()
-	excludedApps := []string{"Activity Monitor", "System Preferences", "Slack"}
+	excludedApps := []string{"Activity Monitor", "System Preferences", "Slack", "com.apple.*"}
 
 	tests := []struct {
 		appName  string
+		bundleID string
 		expected bool
 	}{
-		{"Activity Monitor", true},
-		{"System Preferences", true},
-		{"Slack", true},
-		{"VS Code", false},
-		{"Safari", false},
-		{"", false},
-		{"activity monitor", false}, // Case-sensitive
+		{"Activity Monitor", "", true},
+		{"System Preferences", "", true},
+		{"Slack", "com.tinyspeck.slackmacgap", true},
+		{"VS Code", "com.microsoft.VSCode", false},
+		{"Safari", "com.apple.Safari", true}, // Bundle ID glob
+		{"Safari", "", false},
+		{"", "", false},
+		{"activity monitor", "", true}, // Case-insensitive
 	}
 
 	for _, tt := range tests {
-		result := isExcluded(tt.appName, excludedApps)
+		result := isExcluded(tt.appName, tt.bundleID, excludedApps)
 		if result != tt.expected {
-			t.Errorf("isExcluded(%q) = %v, want %v", tt.appName, result, tt.expected)
+			t.Errorf("isExcluded(%q, %q) = %v, want %v", tt.appName, tt.bundleID, result, tt.expected)
 		}
 	}
 }
@@ -445,7 +513,7 @@ func TestCollectIssues(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
-	result := CollectIssues(ctx)
+	result := CollectIssues(ctx, nil, Today(time.Now()))
 
 	// This is best-effort and may not find any issues
 	// Just verify the structure is correct
@@ -556,7 +624,7 @@ func TestCollectNotifications(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectNotifications(ctx)
+	result := CollectNotifications(ctx, nil, Today(time.Now()))
 
 	// Notifications require Full Disk Access, may not be available
 	if !result.Available {
@@ -634,7 +702,7 @@ func TestCollectAppsWithSwitching(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	result := CollectApps(ctx, nil)
+	result := CollectApps(ctx, nil, config.SourceAuto, Today(time.Now()))
 
 	// Apps require Full Disk Access, may not be available
 	if !result.Available {
@@ -657,3 +725,153 @@ func TestCollectAppsWithSwitching(t *testing.T) {
 			result.TotalSwitches, result.AvgMinsBetween, result.SwitchesPerHour)
 	}
 }
+
+func TestCountAppOpens(t *testing.T) {
+	t.Parallel()
+	got := countAppActivations([]string{"Slack", "Slack", "Code", "Slack", "Code", "Code", "Mail"})
+	want := map[string]int{"Slack": 2, "Code": 2, "Mail": 1}
+	if len(got) != len(want) {
+		t.Fatalf("countAppActivations = %v, want %v", got, want)
+	}
+	for app, n := range want {
+		if got[app] != n {
+			t.Errorf("activations[%s] = %d, want %d", app, got[app], n)
+		}
+	}
+	if got := countAppActivations(nil); len(got) != 0 {
+		t.Errorf("countAppActivations(nil) = %v, want none", got)
+	}
+}
+
+func TestSummarizeAppSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 17, hour, min, 0, 0, time.Local)
+	}
+	sample := func(tm time.Time, name string) appSample {
+		return appSample{Timestamp: tm.Format(time.RFC3339), Name: name, BundleID: "com.example." + name}
+	}
+
+	samples := []appSample{
+		sample(at(8, 50), "Mail"), // Before the window
+		sample(at(9, 0), "Code"),
+		sample(at(9, 2), "Code"),
+		sample(at(9, 4), "Slack"),  // Next sample is an hour later: capped at 5 min
+		sample(at(10, 4), "Code"),  // Followed immediately: counts a minute
+		sample(at(10, 4), "Music"), // Excluded
+		sample(at(10, 5), "Code"),  // Latest: credited until the window end
+	}
+	w := Window{Start: at(9, 0), End: at(10, 8)}
+	result := summarizeAppSamples(samples, []string{"Music"}, w)
+	if !result.Available || result.Source != config.SourceSampling {
+		t.Fatalf("expected sampled apps, got %+v", result)
+	}
+	if len(result.TopApps) != 2 {
+		t.Fatalf("expected 2 apps, got %+v", result.TopApps)
+	}
+	// Code's samples around the excluded one run together: activated twice
+	if got := result.TopApps[0]; got.Name != "Code" || got.Minutes != 2+2+1+3 || got.BundleID != "com.example.Code" || got.Activations != 2 {
+		t.Errorf("top app = %+v, want Code with 8 minutes, activated twice", got)
+	}
+	if got := result.TopApps[1]; got.Name != "Slack" || got.Minutes != 5 || got.Activations != 1 {
+		t.Errorf("second app = %+v, want Slack with 5 minutes, activated once", got)
+	}
+	if !result.FirstActivity.Equal(at(9, 0)) || !result.LastActivity.Equal(at(10, 5)) {
+		t.Errorf("activity bounds = %v-%v, want 9:00-10:05", result.FirstActivity, result.LastActivity)
+	}
+
+	if empty := summarizeAppSamples(nil, nil, w); empty.Available || empty.Error == nil {
+		t.Errorf("expected no apps without samples, got %+v", empty)
+	}
+}
+
+func TestParseCurrentSpace(t *testing.T) {
+	t.Parallel()
+	single := `[{"Display Identifier":"Main","Current Space":{"ManagedSpaceID":7},
+		"Spaces":[{"ManagedSpaceID":1},{"ManagedSpaceID":7},{"ManagedSpaceID":9}]}]`
+	if got, err := parseCurrentSpace([]byte(single)); err != nil || got != 2 {
+		t.Errorf("parseCurrentSpace() = %d, %v; want 2", got, err)
+	}
+
+	// With separate Spaces per display, the main display's Space counts
+	multi := `[{"Display Identifier":"37D8832A","Current Space":{"ManagedSpaceID":12},"Spaces":[{"ManagedSpaceID":12}]},
+		{"Display Identifier":"Main","Current Space":{"ManagedSpaceID":4},"Spaces":[{"ManagedSpaceID":1},{"ManagedSpaceID":3},{"ManagedSpaceID":4}]}]`
+	if got, err := parseCurrentSpace([]byte(multi)); err != nil || got != 3 {
+		t.Errorf("parseCurrentSpace() = %d, %v; want 3", got, err)
+	}
+
+	if _, err := parseCurrentSpace([]byte(`[{"Current Space":{"ManagedSpaceID":5},"Spaces":[]}]`)); err == nil {
+		t.Error("expected an error when the current Space isn't listed")
+	}
+}
+
+func TestSummarizeSpaceSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 17, hour, min, 0, 0, time.Local)
+	}
+	sample := func(tm time.Time, number int) spaceSample {
+		return spaceSample{Timestamp: tm.Format(time.RFC3339), Number: number}
+	}
+
+	samples := []spaceSample{
+		sample(at(9, 0), 1),
+		sample(at(9, 4), 2),
+		sample(at(9, 6), 2),
+		sample(at(9, 8), 1),  // Latest: credited until the window end
+		sample(at(11, 0), 3), // After the window
+	}
+	w := Window{Start: at(9, 0), End: at(9, 10)}
+	result := summarizeSpaceSamples(samples, map[int]string{2: "Client A"}, w)
+	if !result.Available || result.Samples != 4 {
+		t.Fatalf("expected 4 samples in the window, got %+v", result)
+	}
+	want := []SpaceUsage{{Number: 1, Name: "Desktop 1", Minutes: 6}, {Number: 2, Name: "Client A", Minutes: 4}}
+	if len(result.Spaces) != len(want) || result.Spaces[0] != want[0] || result.Spaces[1] != want[1] {
+		t.Errorf("Spaces = %+v, want %+v", result.Spaces, want)
+	}
+
+	if empty := summarizeSpaceSamples(nil, nil, w); empty.Available || empty.Error == nil {
+		t.Errorf("expected no Spaces without samples, got %+v", empty)
+	}
+}
+
+func TestAppSources(t *testing.T) {
+	t.Parallel()
+	if got := classifyAppSource("com.apple.Safari", false); got != AppSourceApple {
+		t.Errorf("Safari = %q, want %q", got, AppSourceApple)
+	}
+	if got := classifyAppSource("com.apple.dt.Xcode", true); got != AppSourceAppStore {
+		t.Errorf("Xcode with a receipt = %q, want %q", got, AppSourceAppStore)
+	}
+	if got := classifyAppSource("com.microsoft.VSCode", false); got != AppSourceThirdParty {
+		t.Errorf("VS Code = %q, want %q", got, AppSourceThirdParty)
+	}
+
+	sandboxed := `<?xml version="1.0" encoding="UTF-8"?><plist version="1.0"><dict>
+	<key>com.apple.security.app-sandbox</key>
+	<true/>
+	<key>com.apple.security.network.client</key><true/></dict></plist>`
+	if !entitlementsSandboxed(sandboxed) {
+		t.Error("expected the sandbox entitlement to be detected")
+	}
+	if entitlementsSandboxed(`<dict><key>com.apple.security.app-sandbox</key><false/></dict>`) {
+		t.Error("a false sandbox entitlement should not count")
+	}
+	if entitlementsSandboxed(`<dict><key>com.apple.security.cs.allow-jit</key><true/></dict>`) {
+		t.Error("an unrelated entitlement should not count")
+	}
+
+	result := summarizeAppOrigins([]AppOrigin{
+		{Name: "VS Code", Minutes: 120, Source: AppSourceThirdParty},
+		{Name: "Safari", Minutes: 60, Source: AppSourceApple},
+		{Name: "Things", Minutes: 30, Source: AppSourceAppStore, Sandboxed: true},
+		{Name: "Slack", Minutes: 20, Source: AppSourceThirdParty, Sandboxed: true},
+	})
+	if result.AppleMinutes != 60 || result.AppStoreMinutes != 30 || result.ThirdPartyMinutes != 140 || result.UnsandboxedMinutes != 120 {
+		t.Errorf("split = %+v", result)
+	}
+	if len(result.UnsandboxedThirdParty) != 1 || result.UnsandboxedThirdParty[0] != "VS Code" {
+		t.Errorf("UnsandboxedThirdParty = %v, want [VS Code]", result.UnsandboxedThirdParty)
+	}
+}
@@ -0,0 +1,258 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// thermlogTimeout bounds "pmset -g thermlog", which prints the events since
+// boot and then keeps listening for new ones until it's killed
+const thermlogTimeout = 2 * time.Second
+
+// CPUResult contains load averages sampled in the window and the thermal
+// throttling recorded in it
+type CPUResult struct {
+	PeakLoad float64 // Highest 1-minute load average sampled
+	AvgLoad  float64
+	Cores    int
+	Samples  int
+
+	Throttles        []Period // When macOS capped CPU speed to cool down
+	ThrottledMinutes int
+	MinSpeedLimit    int // Lowest CPU speed limit, as a percentage of full speed; 100 when never throttled
+
+	Available bool
+	Error     error
+}
+
+// Overloaded reports whether the load peaked above the number of cores,
+// meaning work was queueing for the CPU
+func (c CPUResult) Overloaded() bool {
+	return c.Cores > 0 && c.PeakLoad > float64(c.Cores)
+}
+
+// cpuSample is a single load reading persisted to the daily log
+type cpuSample struct {
+	Timestamp string  `json:"timestamp"`
+	Load1     float64 `json:"load1"`
+	Cores     int     `json:"cores"`
+}
+
+// RecordCPUSample reads the 1-minute load average and appends it to today's
+// sample log
+func RecordCPUSample(ctx context.Context) error {
+	sample, err := readLoad(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadCPUSamples(DayKey(now))
+	if shouldRecordCPUSample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindCPU, DayKey(now), samples); err != nil {
+			return fmt.Errorf("failed to save CPU sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// CollectCPU reports load in w from samples taken by each run and by "rekap
+// sample", and thermal throttling from the pmset thermal log. The log only
+// goes back to boot, so throttling before a restart isn't seen.
+// powermetrics would show more but needs root.
+func CollectCPU(ctx context.Context, w Window) CPUResult {
+	var sampleErr error
+	if w.Live() {
+		sampleErr = RecordCPUSample(ctx)
+	}
+
+	var samples []cpuSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadCPUSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+	result := summarizeCPUSamples(samples, w)
+
+	thermlog, err := readThermlog(ctx)
+	if err == nil {
+		result.Throttles, result.MinSpeedLimit = parseThermlog(thermlog, w)
+		for _, p := range result.Throttles {
+			result.ThrottledMinutes += p.Minutes()
+		}
+		result.Available = true
+	} else {
+		result.MinSpeedLimit = 100
+	}
+
+	if !result.Available {
+		result.Error = fmt.Errorf("CPU load unavailable: %w", errors.Join(sampleErr, err))
+	}
+	return result
+}
+
+// summarizeCPUSamples finds the peak and average load among the samples in w
+func summarizeCPUSamples(samples []cpuSample, w Window) CPUResult {
+	result := CPUResult{Available: false, MinSpeedLimit: 100}
+
+	var total float64
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		result.Samples++
+		total += s.Load1
+		result.PeakLoad = max(result.PeakLoad, s.Load1)
+		result.Cores = max(result.Cores, s.Cores)
+	}
+	if result.Samples > 0 {
+		result.AvgLoad = total / float64(result.Samples)
+		result.Available = true
+	}
+	return result
+}
+
+// readLoad reads the 1-minute load average and the number of cores
+func readLoad(ctx context.Context) (cpuSample, error) {
+	var sample cpuSample
+
+	out, err := exec.CommandContext(ctx, "sysctl", "-n", "vm.loadavg").Output()
+	if err != nil {
+		return sample, fmt.Errorf("failed to read load average: %w", err)
+	}
+	if sample.Load1, err = parseLoadAvg(string(out)); err != nil {
+		return sample, err
+	}
+
+	if out, err := exec.CommandContext(ctx, "sysctl", "-n", "hw.ncpu").Output(); err == nil {
+		sample.Cores, _ = strconv.Atoi(strings.TrimSpace(string(out)))
+	}
+	return sample, nil
+}
+
+// parseLoadAvg reads the 1-minute figure from vm.loadavg, e.g.
+// "{ 2.31 2.05 1.98 }"
+func parseLoadAvg(output string) (float64, error) {
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(output), "{}"))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("failed to parse load average: %q", strings.TrimSpace(output))
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse load average: %w", err)
+	}
+	return load, nil
+}
+
+// readThermlog returns what "pmset -g thermlog" printed before it was
+// stopped. Being stopped is the normal way it ends, so that isn't an error
+// as long as it printed something.
+func readThermlog(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, thermlogTimeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pmset", "-g", "thermlog")
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil && out.Len() == 0 {
+		return "", fmt.Errorf("failed to read thermal log: %w", err)
+	}
+	return out.String(), nil
+}
+
+var (
+	thermlogEventPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} [+-]\d{4} CPU Power notify`)
+	speedLimitPattern    = regexp.MustCompile(`CPU_Speed_Limit\s*=\s*(\d+)`)
+)
+
+// parseThermlog returns the periods in w when the CPU speed limit was below
+// 100%, and the lowest limit seen in w. Each "CPU Power notify" event sets
+// the limit until the next one:
+//
+//	2026-02-16 14:03:12 -0800 CPU Power notify
+//		CPU_Scheduler_Limit 	= 100
+//		CPU_Available_CPUs 	= 8
+//		CPU_Speed_Limit 	= 70
+func parseThermlog(output string, w Window) ([]Period, int) {
+	var throttles []Period
+	minLimit := 100
+	var throttledSince, eventAt time.Time
+	stretchLimit := 100 // Lowest limit since throttledSince
+
+	end := func(at time.Time) {
+		if throttledSince.IsZero() {
+			return
+		}
+		if start, stop, ok := w.clip(throttledSince, at); ok {
+			throttles = append(throttles, Period{Start: start, End: stop})
+			minLimit = min(minLimit, stretchLimit)
+		}
+		throttledSince = time.Time{}
+		stretchLimit = 100
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if thermlogEventPattern.MatchString(line) {
+			eventAt, _ = parsePmsetTime(line, w.Start.Location())
+			continue
+		}
+		m := speedLimitPattern.FindStringSubmatch(line)
+		if m == nil || eventAt.IsZero() {
+			continue
+		}
+		limit, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		if limit < 100 {
+			if throttledSince.IsZero() {
+				throttledSince = eventAt
+			}
+			stretchLimit = min(stretchLimit, limit)
+		} else {
+			end(eventAt)
+		}
+		eventAt = time.Time{}
+	}
+	// Still throttled when the log was read, or at the end of a past window
+	end(w.End)
+
+	return throttles, minLimit
+}
+
+func shouldRecordCPUSample(samples []cpuSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadCPUSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadCPUSamples(date string) ([]cpuSample, error) {
+	var samples []cpuSample
+	if _, err := state.Load(state.KindCPU, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
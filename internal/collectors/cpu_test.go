@@ -0,0 +1,78 @@
+package collectors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseLoadAvg(t *testing.T) {
+	t.Parallel()
+	if got, err := parseLoadAvg("{ 2.31 2.05 1.98 }\n"); err != nil || got != 2.31 {
+		t.Errorf("parseLoadAvg = %v, %v, want 2.31", got, err)
+	}
+	if _, err := parseLoadAvg("{ }"); err == nil {
+		t.Error("parseLoadAvg accepted an empty reading")
+	}
+}
+
+func TestParseThermlog(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 16, hour, min, 0, 0, time.FixedZone("PST", -8*3600))
+	}
+	w := Window{Start: at(0, 0), End: at(18, 0)}
+
+	notify := func(ts string, limit int) string {
+		return ts + " -0800 CPU Power notify\n" +
+			"\tCPU_Scheduler_Limit \t= 100\n" +
+			"\tCPU_Available_CPUs \t= 8\n" +
+			fmt.Sprintf("\tCPU_Speed_Limit \t= %d\n", limit)
+	}
+	out := "Note: No thermal warning level has been recorded\n" +
+		notify("2026-02-15 22:00:00", 80) + // Throttled since the night before
+		notify("2026-02-16 00:30:00", 100) +
+		notify("2026-02-16 14:00:00", 90) +
+		notify("2026-02-16 14:10:00", 60) +
+		notify("2026-02-16 14:40:00", 100) +
+		notify("2026-02-16 17:30:00", 75) // Still throttled at the end
+
+	throttles, minLimit := parseThermlog(out, w)
+	want := []Period{{at(0, 0), at(0, 30)}, {at(14, 0), at(14, 40)}, {at(17, 30), at(18, 0)}}
+	if len(throttles) != len(want) {
+		t.Fatalf("throttles = %+v, want %+v", throttles, want)
+	}
+	for i := range want {
+		if !throttles[i].Start.Equal(want[i].Start) || !throttles[i].End.Equal(want[i].End) {
+			t.Errorf("throttle %d = %v-%v, want %v-%v", i, throttles[i].Start, throttles[i].End, want[i].Start, want[i].End)
+		}
+	}
+	if minLimit != 60 {
+		t.Errorf("minLimit = %d, want 60", minLimit)
+	}
+
+	if throttles, minLimit := parseThermlog("Note: No thermal warning level has been recorded\n", w); len(throttles) != 0 || minLimit != 100 {
+		t.Errorf("quiet log = %v, %d, want no throttling", throttles, minLimit)
+	}
+}
+
+func TestSummarizeCPUSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour int) string {
+		return time.Date(2026, 2, 16, hour, 0, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	w := Window{Start: time.Date(2026, 2, 16, 0, 0, 0, 0, time.Local), End: time.Date(2026, 2, 17, 0, 0, 0, 0, time.Local)}
+
+	got := summarizeCPUSamples([]cpuSample{
+		{Timestamp: at(9), Load1: 2, Cores: 8},
+		{Timestamp: at(14), Load1: 12, Cores: 8},
+		{Timestamp: at(16), Load1: 4, Cores: 8},
+		{Timestamp: at(-1), Load1: 40, Cores: 8}, // The night before
+	}, w)
+	if !got.Available || got.Samples != 3 || got.PeakLoad != 12 || got.AvgLoad != 6 {
+		t.Errorf("summarizeCPUSamples = %+v, want 3 samples peaking at 12, avg 6", got)
+	}
+	if !got.Overloaded() {
+		t.Error("a peak of 12 on 8 cores isn't overloaded")
+	}
+}
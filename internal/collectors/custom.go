@@ -0,0 +1,136 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+// DefaultCustomIcon is shown before a custom section's lines when neither
+// the config nor the section's JSON sets an icon
+const DefaultCustomIcon = "🧩"
+
+// maxCustomLines caps how many lines one custom section can show, so a
+// runaway script can't flood the summary
+const maxCustomLines = 20
+
+// CustomSection is a section filled by an external command or file, as
+// configured in custom_sections
+type CustomSection struct {
+	Name      string // Config name, also the snooze key
+	Title     string // Header; the name unless the JSON overrides it
+	Icon      string
+	Lines     []string
+	Available bool
+	Error     error
+}
+
+// customPayload is the JSON a custom section's command or file provides
+type customPayload struct {
+	Title string   `json:"title"`
+	Icon  string   `json:"icon"`
+	Lines []string `json:"lines"`
+}
+
+// CollectCustomSections fills the configured custom sections concurrently.
+// Commands run with /bin/sh -c and get the window in $REKAP_DATE,
+// $REKAP_START, and $REKAP_END (Unix seconds). Each section succeeds or
+// fails on its own.
+func CollectCustomSections(ctx context.Context, specs []config.CustomSectionConfig, w Window) []CustomSection {
+	sections := make([]CustomSection, len(specs))
+	var wg sync.WaitGroup
+	for i, spec := range specs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sections[i] = collectCustomSection(ctx, spec, w)
+		}()
+	}
+	wg.Wait()
+	return sections
+}
+
+func collectCustomSection(ctx context.Context, spec config.CustomSectionConfig, w Window) CustomSection {
+	section := CustomSection{Name: spec.Name, Title: spec.Name, Icon: spec.Icon, Available: false}
+	if section.Icon == "" {
+		section.Icon = DefaultCustomIcon
+	}
+
+	var raw []byte
+	var err error
+	if spec.Command != "" {
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", spec.Command)
+		cmd.Env = append(os.Environ(),
+			"REKAP_DATE="+w.Day(),
+			"REKAP_START="+strconv.FormatInt(w.Start.Unix(), 10),
+			"REKAP_END="+strconv.FormatInt(w.End.Unix(), 10),
+		)
+		if raw, err = cmd.Output(); err != nil {
+			section.Error = fmt.Errorf("%s: %w", spec.Command, err)
+			return section
+		}
+	} else {
+		if raw, err = os.ReadFile(expandHome(spec.File)); err != nil {
+			section.Error = err
+			return section
+		}
+	}
+
+	payload, err := parseCustomPayload(raw)
+	if err != nil {
+		section.Error = err
+		return section
+	}
+	if payload.Title != "" {
+		section.Title = payload.Title
+	}
+	if payload.Icon != "" {
+		section.Icon = payload.Icon
+	}
+	section.Lines = payload.Lines
+	section.Available = len(section.Lines) > 0
+	return section
+}
+
+// parseCustomPayload decodes a custom section's JSON, dropping blank lines
+// and keeping at most maxCustomLines
+func parseCustomPayload(raw []byte) (customPayload, error) {
+	var payload customPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return customPayload{}, fmt.Errorf("invalid section JSON: %w", err)
+	}
+
+	var lines []string
+	for _, line := range payload.Lines {
+		// Each entry is one line of output
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\n", " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > maxCustomLines {
+		lines = lines[:maxCustomLines]
+	}
+	payload.Lines = lines
+	payload.Title = strings.TrimSpace(payload.Title)
+	payload.Icon = strings.TrimSpace(payload.Icon)
+	return payload, nil
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, rest)
+		}
+	}
+	return path
+}
@@ -0,0 +1,54 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestCollectCustomSections(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "ci.json")
+	if err := os.WriteFile(file, []byte(`{"title": "CI", "icon": "🚦", "lines": ["3 builds green", "", "1 flaky\ntest"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "empty.json"), []byte(`{"lines": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local)
+	w := Window{Start: start, End: start.Add(10 * time.Hour)}
+	specs := []config.CustomSectionConfig{
+		{Name: "Deploys", Command: `printf '{"lines": ["shipped on %s"]}' "$REKAP_DATE"`},
+		{Name: "Builds", File: file},
+		{Name: "Empty", File: filepath.Join(dir, "empty.json")},
+		{Name: "Broken", Command: "echo not json"},
+		{Name: "Failing", Command: "exit 3"},
+	}
+
+	got := CollectCustomSections(context.Background(), specs, w)
+	if len(got) != len(specs) {
+		t.Fatalf("got %d sections, want %d", len(got), len(specs))
+	}
+
+	if s := got[0]; !s.Available || s.Title != "Deploys" || s.Icon != DefaultCustomIcon || strings.Join(s.Lines, "|") != "shipped on 2026-02-18" {
+		t.Errorf("command section = %+v", s)
+	}
+	if s := got[1]; !s.Available || s.Name != "Builds" || s.Title != "CI" || s.Icon != "🚦" || strings.Join(s.Lines, "|") != "3 builds green|1 flaky test" {
+		t.Errorf("file section = %+v", s)
+	}
+	if s := got[2]; s.Available || s.Error != nil {
+		t.Errorf("empty section = %+v, want unavailable without error", s)
+	}
+	for _, s := range got[3:] {
+		if s.Available || s.Error == nil {
+			t.Errorf("%s section = %+v, want an error", s.Name, s)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package collectors
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// dayStartMinute is when a new "today" begins, in minutes after midnight.
+// Defaults to midnight; night owls can move it later so post-midnight
+// work still counts toward the previous day.
+var dayStartMinute atomic.Int32
+
+// SetDayStart configures the day boundary used by all collectors, in
+// minutes after midnight. Out-of-range values fall back to midnight.
+func SetDayStart(minute int) {
+	if minute < 0 || minute >= 24*60 {
+		minute = 0
+	}
+	dayStartMinute.Store(int32(minute))
+}
+
+// DayStart returns the start of the day containing now, honoring the
+// configured day boundary.
+func DayStart(now time.Time) time.Time {
+	return dayStartFor(now, int(dayStartMinute.Load()))
+}
+
+// DayKey returns the YYYY-MM-DD date of the day containing now. With a
+// day boundary of 04:00, 01:30 on the 19th still belongs to the 18th.
+func DayKey(now time.Time) string {
+	return DayStart(now).Format("2006-01-02")
+}
+
+// dayStartFor returns the most recent boundary minute minutes after
+// midnight at or before now
+func dayStartFor(now time.Time, minute int) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), minute/60, minute%60, 0, 0, now.Location())
+	if now.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// Window is the span of time a run summarizes. Collectors only count
+// activity between Start and End.
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Today returns the window from the start of the day containing now to now
+func Today(now time.Time) Window {
+	return Window{Start: DayStart(now), End: now}
+}
+
+// DayWindow returns the whole day that contains day. A day still in
+// progress ends at now.
+func DayWindow(day, now time.Time) Window {
+	start := DayStart(day)
+	end := start.AddDate(0, 0, 1)
+	if end.After(now) {
+		end = now
+	}
+	return Window{Start: start, End: end}
+}
+
+// liveSlack is how long ago a window may end and still count as live;
+// collection itself takes a few seconds
+const liveSlack = time.Minute
+
+// Live reports whether the window ends now, so point-in-time readings
+// (battery level, open tabs, now playing) describe it
+func (w Window) Live() bool {
+	return time.Since(w.End) < liveSlack
+}
+
+// WholeDay reports whether the window starts at a day boundary. Per-day
+// state such as network baselines and the morning battery reading only
+// describes whole-day windows.
+func (w Window) WholeDay() bool {
+	return w.Start.Equal(DayStart(w.Start))
+}
+
+// Day returns the YYYY-MM-DD date of the day the window starts in
+func (w Window) Day() string {
+	return DayKey(w.Start)
+}
+
+// Minutes returns the length of the window in whole minutes
+func (w Window) Minutes() int {
+	return int(w.End.Sub(w.Start).Minutes())
+}
+
+// clip trims start and end to the window. ok is false when they don't
+// overlap it.
+func (w Window) clip(start, end time.Time) (time.Time, time.Time, bool) {
+	if start.Before(w.Start) {
+		start = w.Start
+	}
+	if end.After(w.End) {
+		end = w.End
+	}
+	return start, end, end.After(start)
+}
+
+// dayKeys returns the YYYY-MM-DD keys of every day the window touches,
+// oldest first. A window like --last 4h can cross the day boundary.
+func (w Window) dayKeys() []string {
+	keys := []string{DayKey(w.Start)}
+	for d := DayStart(w.Start).AddDate(0, 0, 1); d.Before(w.End); d = d.AddDate(0, 0, 1) {
+		keys = append(keys, DayKey(d))
+	}
+	return keys
+}
+
+// ParseDay returns the start of the day named by date (YYYY-MM-DD),
+// honoring the configured day boundary
+func ParseDay(date string) (time.Time, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	minute := int(dayStartMinute.Load())
+	return time.Date(t.Year(), t.Month(), t.Day(), minute/60, minute%60, 0, 0, time.Local), nil
+}
@@ -0,0 +1,120 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayStartFor(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("MST", -7*3600)
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		minute int
+		want   time.Time
+	}{
+		{
+			name:   "midnight boundary",
+			now:    time.Date(2026, 2, 18, 14, 0, 0, 0, loc),
+			minute: 0,
+			want:   time.Date(2026, 2, 18, 0, 0, 0, 0, loc),
+		},
+		{
+			name:   "after a 4am boundary",
+			now:    time.Date(2026, 2, 18, 14, 0, 0, 0, loc),
+			minute: 4 * 60,
+			want:   time.Date(2026, 2, 18, 4, 0, 0, 0, loc),
+		},
+		{
+			name:   "past midnight but before a 4am boundary belongs to yesterday",
+			now:    time.Date(2026, 2, 19, 1, 30, 0, 0, loc),
+			minute: 4 * 60,
+			want:   time.Date(2026, 2, 18, 4, 0, 0, 0, loc),
+		},
+		{
+			name:   "exactly on the boundary starts a new day",
+			now:    time.Date(2026, 2, 19, 4, 0, 0, 0, loc),
+			minute: 4 * 60,
+			want:   time.Date(2026, 2, 19, 4, 0, 0, 0, loc),
+		},
+		{
+			name:   "a 4:30am boundary",
+			now:    time.Date(2026, 2, 19, 4, 15, 0, 0, loc),
+			minute: 4*60 + 30,
+			want:   time.Date(2026, 2, 18, 4, 30, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dayStartFor(tt.now, tt.minute); !got.Equal(tt.want) {
+				t.Errorf("dayStartFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLateNightWindow(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("MST", -7*3600)
+
+	// Midnight boundary: 00:00-06:00 of the same calendar day
+	start, end := lateNightWindow(time.Date(2026, 2, 18, 0, 0, 0, 0, loc), 0, 360)
+	if !start.Equal(time.Date(2026, 2, 18, 0, 0, 0, 0, loc)) || !end.Equal(time.Date(2026, 2, 18, 6, 0, 0, 0, loc)) {
+		t.Errorf("midnight boundary window = %v-%v", start, end)
+	}
+
+	// 4am boundary: the following midnight, clipped at the next day start
+	start, end = lateNightWindow(time.Date(2026, 2, 18, 4, 0, 0, 0, loc), 0, 360)
+	if !start.Equal(time.Date(2026, 2, 19, 0, 0, 0, 0, loc)) || !end.Equal(time.Date(2026, 2, 19, 4, 0, 0, 0, loc)) {
+		t.Errorf("4am boundary window = %v-%v", start, end)
+	}
+
+	// 23:00-05:00 with a 4am boundary: from 11pm, clipped at the next day start
+	start, end = lateNightWindow(time.Date(2026, 2, 18, 4, 0, 0, 0, loc), 23*60, 5*60)
+	if !start.Equal(time.Date(2026, 2, 18, 23, 0, 0, 0, loc)) || !end.Equal(time.Date(2026, 2, 19, 4, 0, 0, 0, loc)) {
+		t.Errorf("23:00-05:00 window = %v-%v", start, end)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 2, day, hour, min, 0, 0, time.Local)
+	}
+
+	// A finished day runs boundary to boundary
+	past := DayWindow(at(17, 15, 0), at(18, 9, 0))
+	if !past.Start.Equal(at(17, 0, 0)) || !past.End.Equal(at(18, 0, 0)) {
+		t.Errorf("past day window = %v-%v", past.Start, past.End)
+	}
+	if !past.WholeDay() || past.Live() || past.Minutes() != 24*60 || past.Day() != "2026-02-17" {
+		t.Errorf("past day window = %+v, want a whole, finished day of 1440 minutes", past)
+	}
+
+	// Today so far ends now
+	now := time.Now()
+	today := DayWindow(now, now)
+	if !today.End.Equal(now) || !today.Live() || !today.WholeDay() {
+		t.Errorf("today's window = %+v, want a live whole day ending now", today)
+	}
+
+	// A --last style window isn't a whole day and can cross midnight
+	last := Window{Start: at(17, 22, 0), End: at(18, 2, 0)}
+	if last.WholeDay() {
+		t.Error("22:00-02:00 should not be a whole day")
+	}
+	if keys := last.dayKeys(); len(keys) != 2 || keys[0] != "2026-02-17" || keys[1] != "2026-02-18" {
+		t.Errorf("dayKeys() = %v, want both days", keys)
+	}
+
+	start, end, ok := last.clip(at(17, 21, 0), at(17, 23, 0))
+	if !ok || !start.Equal(at(17, 22, 0)) || !end.Equal(at(17, 23, 0)) {
+		t.Errorf("clip() = %v-%v, %v", start, end, ok)
+	}
+	if _, _, ok := last.clip(at(18, 3, 0), at(18, 4, 0)); ok {
+		t.Error("clip() of a span after the window should not overlap")
+	}
+}
@@ -0,0 +1,80 @@
+package collectors
+
+import (
+	"fmt"
+	"time"
+)
+
+// WorkdayMinutes is the standard workday that overtime is measured against
+const WorkdayMinutes = 8 * 60
+
+// Arrival and wrap-up signal sources
+const (
+	BoundsSourceUnlock = "unlock"
+	BoundsSourceWiFi   = "wifi"
+	BoundsSourceApps   = "apps"
+)
+
+// DayBoundsResult is when the working day started and ended: the first and
+// last sign of activity from screen unlocks, Wi-Fi, and app usage
+type DayBoundsResult struct {
+	Arrival       time.Time
+	ArrivalSource string // Which signal came first: "unlock", "wifi", or "apps"
+	WrapUp        time.Time
+	WrapUpSource  string // "unlock" (screen off) or "apps"
+	Ongoing       bool   // Activity within the last SessionGap; WrapUp is provisional
+	SpanMinutes   int    // Arrival to wrap-up
+	Available     bool
+	Error         error
+}
+
+// OvertimeMinutes returns how far the day's span ran past WorkdayMinutes
+func (b DayBoundsResult) OvertimeMinutes() int {
+	if b.SpanMinutes <= WorkdayMinutes {
+		return 0
+	}
+	return b.SpanMinutes - WorkdayMinutes
+}
+
+// CalculateDayBounds finds the day's arrival and wrap-up times. Arrival is
+// the earliest of the first screen unlock, the first Wi-Fi sample, and the
+// first app event; wrap-up is the later of the last screen-off and the last
+// app event. The Wi-Fi signal is the first rekap run on Wi-Fi, so it only
+// helps when rekap runs on a schedule.
+func CalculateDayBounds(screen ScreenResult, wifi WiFiResult, apps AppsResult, now time.Time) DayBoundsResult {
+	result := DayBoundsResult{Available: false}
+
+	consider := func(first, last time.Time, source string) {
+		if !first.IsZero() && (result.Arrival.IsZero() || first.Before(result.Arrival)) {
+			result.Arrival = first
+			result.ArrivalSource = source
+		}
+		if !last.IsZero() && last.After(result.WrapUp) {
+			result.WrapUp = last
+			result.WrapUpSource = source
+		}
+	}
+
+	if len(screen.OnPeriods) > 0 {
+		consider(screen.OnPeriods[0].Start, screen.OnPeriods[len(screen.OnPeriods)-1].End, BoundsSourceUnlock)
+	}
+	if wifi.Available {
+		consider(wifi.FirstSeen, time.Time{}, BoundsSourceWiFi)
+	}
+	if apps.Available {
+		consider(apps.FirstActivity, apps.LastActivity, BoundsSourceApps)
+	}
+
+	if result.Arrival.IsZero() || result.WrapUp.IsZero() {
+		result.Error = fmt.Errorf("no unlock, Wi-Fi, or app activity recorded today")
+		return result
+	}
+	if result.WrapUp.Before(result.Arrival) {
+		result.WrapUp = result.Arrival
+	}
+
+	result.Ongoing = now.Sub(result.WrapUp) < SessionGap
+	result.SpanMinutes = int(result.WrapUp.Sub(result.Arrival).Minutes())
+	result.Available = true
+	return result
+}
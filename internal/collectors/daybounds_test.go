@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCalculateDayBounds(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 17, hour, min, 0, 0, time.Local)
+	}
+
+	screen := ScreenResult{OnPeriods: []Period{{at(8, 50), at(12, 0)}, {at(13, 0), at(18, 15)}}}
+	wifi := WiFiResult{FirstSeen: at(9, 5), Available: true}
+	apps := AppsResult{FirstActivity: at(8, 42), LastActivity: at(18, 10), Available: true}
+
+	result := CalculateDayBounds(screen, wifi, apps, at(21, 0))
+	if !result.Available {
+		t.Fatalf("expected bounds, got error %v", result.Error)
+	}
+	if !result.Arrival.Equal(at(8, 42)) || result.ArrivalSource != BoundsSourceApps {
+		t.Errorf("arrival = %v from %q, want 8:42 from apps", result.Arrival, result.ArrivalSource)
+	}
+	if !result.WrapUp.Equal(at(18, 15)) || result.WrapUpSource != BoundsSourceUnlock {
+		t.Errorf("wrap-up = %v from %q, want 18:15 from unlock", result.WrapUp, result.WrapUpSource)
+	}
+	if result.Ongoing {
+		t.Error("day should not be ongoing hours after the last activity")
+	}
+	if result.SpanMinutes != 573 || result.OvertimeMinutes() != 93 {
+		t.Errorf("span/overtime = %d/%d, want 573/93", result.SpanMinutes, result.OvertimeMinutes())
+	}
+
+	// Wi-Fi alone can set the arrival, and recent activity keeps the day open
+	wifi.FirstSeen = at(7, 30)
+	result = CalculateDayBounds(screen, wifi, apps, at(18, 30))
+	if result.ArrivalSource != BoundsSourceWiFi || !result.Ongoing {
+		t.Errorf("got arrival from %q, ongoing=%v; want wifi and ongoing", result.ArrivalSource, result.Ongoing)
+	}
+}
+
+func TestCalculateDayBoundsNoSignals(t *testing.T) {
+	t.Parallel()
+	result := CalculateDayBounds(ScreenResult{}, WiFiResult{}, AppsResult{}, time.Now())
+	if result.Available || result.Error == nil {
+		t.Errorf("expected unavailable bounds with an error, got %+v", result)
+	}
+}
+
+func TestBurnoutLongDayFromBounds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	config := DefaultBurnoutConfig()
+
+	// Only 6h of screen time, but 11h between arrival and wrap-up
+	screen := ScreenResult{ScreenOnMinutes: 360, Available: true}
+	bounds := DayBoundsResult{SpanMinutes: 11*60 + 5, Available: true}
+
+	result := CollectBurnout(ctx, nil, screen, bounds, BrowsersResult{}, config, Today(time.Now()))
+	for _, w := range result.Warnings {
+		if w.Type == "long_day" {
+			if w.MetricValue != 11 {
+				t.Errorf("long_day metric = %d, want 11", w.MetricValue)
+			}
+			return
+		}
+	}
+	t.Error("expected a long_day warning from the day span")
+}
@@ -0,0 +1,153 @@
+package collectors
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Polar days with no sunrise or sunset
+const (
+	SunAlwaysUp   = "midnight_sun"
+	SunAlwaysDown = "polar_night"
+)
+
+// sunZenith is the Sun's zenith angle at sunrise and sunset, allowing for
+// refraction and the size of its disc
+const sunZenith = 90.833
+
+// DaylightResult contains the day's sunrise and sunset and how much of the
+// screen time in the window came after dark
+type DaylightResult struct {
+	Sunrise          time.Time // Zero on polar days
+	Sunset           time.Time
+	Polar            string // SunAlwaysUp or SunAlwaysDown; empty on ordinary days
+	ScreenMinutes    int
+	AfterDarkMinutes int // Screen-on time between sunset and sunrise
+	Available        bool
+	Error            error
+}
+
+// AfterDarkPct returns the share of screen time after dark, 0-100
+func (d DaylightResult) AfterDarkPct() int {
+	if d.ScreenMinutes == 0 {
+		return 0
+	}
+	return d.AfterDarkMinutes * 100 / d.ScreenMinutes
+}
+
+// CalculateDaylight works out sunrise and sunset at lat, lon for the days
+// in w, offline, and splits the screen-on time into daylight and dark.
+// Sunrise and sunset are for the day w ends on.
+func CalculateDaylight(screen ScreenResult, lat, lon float64, located bool, w Window) DaylightResult {
+	if !located {
+		return DaylightResult{Error: fmt.Errorf("set location.latitude and location.longitude for sunrise and sunset")}
+	}
+
+	result := DaylightResult{Available: true}
+	result.Sunrise, result.Sunset, result.Polar = sunTimes(DayStart(w.End), lat, lon)
+
+	var daylight []Period
+	for day := DayStart(w.Start); day.Before(w.End); day = day.AddDate(0, 0, 1) {
+		rise, set, polar := sunTimes(day, lat, lon)
+		switch polar {
+		case SunAlwaysUp:
+			daylight = append(daylight, Period{Start: day, End: day.AddDate(0, 0, 1)})
+		case "":
+			daylight = append(daylight, Period{Start: rise, End: set})
+		}
+	}
+
+	if !screen.Available {
+		return result
+	}
+	var on, dark time.Duration
+	for _, p := range screen.OnPeriods {
+		start, end, ok := w.clip(p.Start, p.End)
+		if !ok {
+			continue
+		}
+		clipped := Period{Start: start, End: end}
+		on += end.Sub(start)
+		dark += end.Sub(start) - overlap(clipped, daylight)
+	}
+	result.ScreenMinutes = int(on.Minutes())
+	result.AfterDarkMinutes = int(dark.Minutes())
+	return result
+}
+
+// sunTimes returns sunrise and sunset on the calendar day of day, in day's
+// time zone, at lat, lon, using the sunrise algorithm from the Almanac for
+// Computers (accurate to a minute or two). On polar days rise and set are
+// zero and polar says which kind it is.
+func sunTimes(day time.Time, lat, lon float64) (rise, set time.Time, polar string) {
+	y, m, d := day.Date()
+	noon := time.Date(y, m, d, 12, 0, 0, 0, day.Location())
+	n := float64(noon.YearDay())
+	lngHour := lon / 15
+
+	event := func(hourGuess float64, rising bool) (time.Time, string) {
+		t := n + (hourGuess-lngHour)/24
+		meanAnomaly := 0.9856*t - 3.289
+		trueLong := normalizeDegrees(meanAnomaly + 1.916*sinDeg(meanAnomaly) + 0.020*sinDeg(2*meanAnomaly) + 282.634)
+
+		ra := normalizeDegrees(degrees(math.Atan(0.91764 * tanDeg(trueLong))))
+		ra += math.Floor(trueLong/90)*90 - math.Floor(ra/90)*90 // Same quadrant as the longitude
+		ra /= 15
+
+		sinDec := 0.39782 * sinDeg(trueLong)
+		cosDec := math.Cos(math.Asin(sinDec))
+		cosH := (cosDeg(sunZenith) - sinDec*sinDeg(lat)) / (cosDec * cosDeg(lat))
+		if cosH > 1 {
+			return time.Time{}, SunAlwaysDown
+		}
+		if cosH < -1 {
+			return time.Time{}, SunAlwaysUp
+		}
+
+		h := degrees(math.Acos(cosH))
+		if rising {
+			h = 360 - h
+		}
+		localMean := h/15 + ra - 0.06571*t - 6.622
+		ut := math.Mod(localMean-lngHour, 24)
+		if ut < 0 {
+			ut += 24
+		}
+
+		// ut is hours into a UTC day; pick the one that lands nearest local noon
+		at := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(time.Duration(ut * float64(time.Hour))).In(day.Location())
+		if at.Sub(noon) > 12*time.Hour {
+			at = at.AddDate(0, 0, -1)
+		} else if noon.Sub(at) > 12*time.Hour {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, ""
+	}
+
+	rise, polar = event(6, true)
+	if polar != "" {
+		return time.Time{}, time.Time{}, polar
+	}
+	set, polar = event(18, false)
+	if polar != "" {
+		return time.Time{}, time.Time{}, polar
+	}
+	return rise, set, ""
+}
+
+func sinDeg(x float64) float64 { return math.Sin(x * math.Pi / 180) }
+func cosDeg(x float64) float64 { return math.Cos(x * math.Pi / 180) }
+func tanDeg(x float64) float64 { return math.Tan(x * math.Pi / 180) }
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// normalizeDegrees brings an angle into [0, 360)
+func normalizeDegrees(x float64) float64 {
+	x = math.Mod(x, 360)
+	if x < 0 {
+		x += 360
+	}
+	return x
+}
@@ -0,0 +1,84 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSunTimes(t *testing.T) {
+	t.Parallel()
+	edt := time.FixedZone("EDT", -4*3600)
+	est := time.FixedZone("EST", -5*3600)
+	aedt := time.FixedZone("AEDT", 11*3600)
+	cet := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		name      string
+		day       time.Time
+		lat, lon  float64
+		rise, set string
+		polar     string
+	}{
+		{"New York midsummer", time.Date(2026, 6, 21, 0, 0, 0, 0, edt), 40.71, -74.01, "05:25", "20:31", ""},
+		{"New York midwinter", time.Date(2026, 12, 21, 0, 0, 0, 0, est), 40.71, -74.01, "07:17", "16:32", ""},
+		{"Sydney midsummer", time.Date(2026, 12, 21, 0, 0, 0, 0, aedt), -33.87, 151.21, "05:41", "20:05", ""},
+		{"Tromsø midsummer", time.Date(2026, 6, 21, 0, 0, 0, 0, cet), 69.65, 18.96, "", "", SunAlwaysUp},
+		{"Tromsø midwinter", time.Date(2026, 12, 21, 0, 0, 0, 0, cet), 69.65, 18.96, "", "", SunAlwaysDown},
+	}
+	near := func(got time.Time, want string, day time.Time) bool {
+		w, _ := time.ParseInLocation("15:04", want, day.Location())
+		y, m, d := day.Date()
+		w = time.Date(y, m, d, w.Hour(), w.Minute(), 0, 0, day.Location())
+		diff := got.Sub(w)
+		return diff > -3*time.Minute && diff < 3*time.Minute
+	}
+	for _, tt := range tests {
+		rise, set, polar := sunTimes(tt.day, tt.lat, tt.lon)
+		if polar != tt.polar {
+			t.Errorf("%s: polar = %q, want %q", tt.name, polar, tt.polar)
+			continue
+		}
+		if tt.polar != "" {
+			continue
+		}
+		if !near(rise, tt.rise, tt.day) || !near(set, tt.set, tt.day) {
+			t.Errorf("%s: sunrise %s, sunset %s; want about %s and %s", tt.name, rise.Format("15:04"), set.Format("15:04"), tt.rise, tt.set)
+		}
+	}
+}
+
+func TestCalculateDaylight(t *testing.T) {
+	t.Parallel()
+	est := time.FixedZone("EST", -5*3600)
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 12, 21, hour, min, 0, 0, est)
+	}
+	w := Window{Start: at(0, 0), End: at(22, 0)}
+	screen := ScreenResult{
+		OnPeriods: []Period{
+			{Start: at(6, 17), End: at(8, 17)},  // An hour before sunrise at 7:17
+			{Start: at(12, 0), End: at(13, 0)},  // Daylight
+			{Start: at(16, 2), End: at(18, 32)}, // Two hours after sunset at 16:32
+		},
+		Available: true,
+	}
+
+	got := CalculateDaylight(screen, 40.71, -74.01, true, w)
+	if !got.Available || got.Sunrise.IsZero() || got.Sunset.IsZero() {
+		t.Fatalf("got %+v, want sunrise and sunset", got)
+	}
+	if got.ScreenMinutes != 330 {
+		t.Errorf("ScreenMinutes = %d, want 330", got.ScreenMinutes)
+	}
+	// Sunrise and sunset are only good to a minute or two
+	if got.AfterDarkMinutes < 177 || got.AfterDarkMinutes > 183 {
+		t.Errorf("AfterDarkMinutes = %d, want about 180", got.AfterDarkMinutes)
+	}
+	if pct := got.AfterDarkPct(); pct < 53 || pct > 55 {
+		t.Errorf("AfterDarkPct = %d, want about 54", pct)
+	}
+
+	if none := CalculateDaylight(screen, 0, 0, false, w); none.Available || none.Error == nil {
+		t.Errorf("expected an error without a location, got %+v", none)
+	}
+}
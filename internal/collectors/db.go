@@ -4,9 +4,9 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
+	"github.com/alexinslc/rekap/internal/compat"
 	_ "modernc.org/sqlite"
 )
 
@@ -23,7 +23,8 @@ var systemApps = map[string]bool{
 	"com.apple.Spotlight":            true,
 }
 
-// openKnowledgeDB opens the macOS Screen Time knowledgeC.db database.
+// openKnowledgeDB opens the macOS Screen Time knowledgeC.db database, the
+// first of compat.KnowledgeDBPaths that can be read.
 // Callers are responsible for closing the returned *sql.DB.
 func openKnowledgeDB() (*sql.DB, error) {
 	homeDir, err := os.UserHomeDir()
@@ -31,9 +32,14 @@ func openKnowledgeDB() (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	dbPath := filepath.Join(homeDir, "Library", "Application Support", "Knowledge", "knowledgeC.db")
-
-	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+	dbPath := ""
+	for _, path := range compat.KnowledgeDBPaths(homeDir) {
+		if _, err := os.Stat(path); err == nil {
+			dbPath = path
+			break
+		}
+	}
+	if dbPath == "" {
 		return nil, fmt.Errorf("Screen Time database not found (requires Full Disk Access)")
 	}
 
@@ -45,13 +51,19 @@ func openKnowledgeDB() (*sql.DB, error) {
 	return db, nil
 }
 
-// todayTimestampRange returns the Core Data timestamp range for today
-// (from midnight to now), as seconds since the Core Data epoch (2001-01-01).
-func todayTimestampRange() (start, end float64) {
-	now := time.Now()
-	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+// appUsageStream is the knowledgeC.db stream recording app use on this
+// macOS version
+func appUsageStream() string {
+	return compat.AppUsageStream(compat.Current())
+}
+
+// timestampRange converts w to Core Data timestamps, seconds since the
+// Core Data epoch (2001-01-01)
+func timestampRange(w Window) (start, end float64) {
+	return w.Start.Sub(coreDataEpoch).Seconds(), w.End.Sub(coreDataEpoch).Seconds()
+}
 
-	start = midnight.Sub(coreDataEpoch).Seconds()
-	end = now.Sub(coreDataEpoch).Seconds()
-	return start, end
+// coreDataTime converts a Core Data timestamp to local time
+func coreDataTime(ts float64) time.Time {
+	return coreDataEpoch.Add(time.Duration(ts * float64(time.Second))).Local()
 }
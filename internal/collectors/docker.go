@@ -0,0 +1,190 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Container is a Docker container started in the window
+type Container struct {
+	Name     string
+	Image    string
+	Started  time.Time
+	Running  bool
+	Minutes  int     // Time running in the window
+	CPUPct   float64 // Current CPU use; running containers in live windows only
+	MemBytes int64   // Current memory use; running containers in live windows only
+}
+
+// DockerResult contains the containers started in the window and what the
+// running ones use now
+type DockerResult struct {
+	Started    int
+	Running    int         // Running at this run, including ones started before the window
+	Containers []Container // Started in the window; running first, then newest
+	CPUPct     float64     // Total over running containers; 100 is one full core
+	MemBytes   int64
+	Available  bool
+	Error      error
+}
+
+// CollectDocker asks the Docker daemon, when it's running, which containers
+// started in w and, in live windows, what the running ones use now
+func CollectDocker(ctx context.Context, w Window) DockerResult {
+	output, err := exec.CommandContext(ctx, "docker", "ps", "--all", "--quiet", "--no-trunc").Output()
+	if err != nil {
+		return DockerResult{Error: fmt.Errorf("docker isn't running: %w", err)}
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return DockerResult{Available: true}
+	}
+
+	output, err = exec.CommandContext(ctx, "docker", append([]string{"inspect"}, ids...)...).Output()
+	if err != nil {
+		return DockerResult{Error: fmt.Errorf("failed to inspect containers: %w", err)}
+	}
+	result, err := parseDockerInspect(output, w)
+	if err != nil {
+		return DockerResult{Error: err}
+	}
+
+	// What's running now says nothing about a past window
+	if !w.Live() {
+		result.Running = 0
+		return result
+	}
+	if result.Running > 0 {
+		output, err := exec.CommandContext(ctx, "docker", "stats", "--no-stream", "--format", "{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}").Output()
+		if err == nil {
+			applyDockerStats(&result, parseDockerStats(string(output)))
+		}
+	}
+	return result
+}
+
+// parseDockerInspect reads "docker inspect" output, keeping the containers
+// started in w and counting the ones running now
+func parseDockerInspect(data []byte, w Window) (DockerResult, error) {
+	var inspected []struct {
+		Name  string `json:"Name"`
+		State struct {
+			Running    bool   `json:"Running"`
+			StartedAt  string `json:"StartedAt"`
+			FinishedAt string `json:"FinishedAt"`
+		} `json:"State"`
+		Config struct {
+			Image string `json:"Image"`
+		} `json:"Config"`
+	}
+	if err := json.Unmarshal(data, &inspected); err != nil {
+		return DockerResult{}, fmt.Errorf("failed to parse docker inspect output: %w", err)
+	}
+
+	result := DockerResult{Available: true}
+	for _, c := range inspected {
+		if c.State.Running {
+			result.Running++
+		}
+		started, err := time.Parse(time.RFC3339Nano, c.State.StartedAt)
+		if err != nil || started.Before(w.Start) || !started.Before(w.End) {
+			continue
+		}
+
+		// A stopped container ran until it finished; a running one until now
+		end := w.End
+		if finished, err := time.Parse(time.RFC3339Nano, c.State.FinishedAt); err == nil && !c.State.Running && finished.After(started) {
+			end = finished
+		}
+		container := Container{
+			Name:    strings.TrimPrefix(c.Name, "/"),
+			Image:   c.Config.Image,
+			Started: started.Local(),
+			Running: c.State.Running,
+		}
+		if start, end, ok := w.clip(started, end); ok {
+			container.Minutes = int(end.Sub(start).Minutes())
+		}
+		result.Containers = append(result.Containers, container)
+	}
+	result.Started = len(result.Containers)
+
+	sort.Slice(result.Containers, func(i, j int) bool {
+		a, b := result.Containers[i], result.Containers[j]
+		if a.Running != b.Running {
+			return a.Running
+		}
+		return a.Started.After(b.Started)
+	})
+	return result, nil
+}
+
+// dockerStats is one running container's current use
+type dockerStats struct {
+	CPUPct   float64
+	MemBytes int64
+}
+
+// parseDockerStats reads "docker stats" lines formatted as
+// "<name>\t<cpu>%\t<used> / <limit>", e.g. "db\t1.25%\t256MiB / 7.6GiB"
+func parseDockerStats(output string) map[string]dockerStats {
+	stats := make(map[string]dockerStats)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		cpu, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+		if err != nil {
+			continue
+		}
+		used, _, _ := strings.Cut(fields[2], "/")
+		mem, _ := parseDockerSize(strings.TrimSpace(used))
+		stats[fields[0]] = dockerStats{CPUPct: cpu, MemBytes: mem}
+	}
+	return stats
+}
+
+// dockerSizePattern matches Docker's sizes, e.g. "256MiB" or "1.2GB"
+var dockerSizePattern = regexp.MustCompile(`^([\d.]+)\s*([kKMGT]?i?B)$`)
+
+// dockerSizeUnits are the multipliers of the units Docker prints
+var dockerSizeUnits = map[string]float64{
+	"B": 1, "KiB": 1 << 10, "MiB": 1 << 20, "GiB": 1 << 30, "TiB": 1 << 40,
+	"kB": 1e3, "KB": 1e3, "MB": 1e6, "GB": 1e9, "TB": 1e12,
+}
+
+// parseDockerSize converts a size Docker printed to bytes
+func parseDockerSize(s string) (int64, error) {
+	m := dockerSizePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("unexpected size %q", s)
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(n * dockerSizeUnits[m[2]]), nil
+}
+
+// applyDockerStats adds the current use of every running container to the
+// totals and to the containers started in the window
+func applyDockerStats(result *DockerResult, stats map[string]dockerStats) {
+	for _, s := range stats {
+		result.CPUPct += s.CPUPct
+		result.MemBytes += s.MemBytes
+	}
+	for i, c := range result.Containers {
+		if s, ok := stats[c.Name]; ok && c.Running {
+			result.Containers[i].CPUPct = s.CPUPct
+			result.Containers[i].MemBytes = s.MemBytes
+		}
+	}
+}
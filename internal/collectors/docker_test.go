@@ -0,0 +1,67 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDockerInspect(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) string {
+		return time.Date(2026, 3, 9, hour, min, 0, 0, time.Local).UTC().Format(time.RFC3339Nano)
+	}
+	w := Window{
+		Start: time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local),
+		End:   time.Date(2026, 3, 9, 17, 0, 0, 0, time.Local),
+	}
+
+	data := []byte(`[
+		{"Name": "/db", "State": {"Running": true, "StartedAt": "` + at(9, 0) + `", "FinishedAt": "0001-01-01T00:00:00Z"}, "Config": {"Image": "postgres:16"}},
+		{"Name": "/migrate", "State": {"Running": false, "StartedAt": "` + at(9, 5) + `", "FinishedAt": "` + at(9, 7) + `"}, "Config": {"Image": "app:dev"}},
+		{"Name": "/cache", "State": {"Running": true, "StartedAt": "` + at(-3, 0) + `", "FinishedAt": ""}, "Config": {"Image": "redis:7"}},
+		{"Name": "/never", "State": {"Running": false, "StartedAt": "0001-01-01T00:00:00Z", "FinishedAt": "0001-01-01T00:00:00Z"}, "Config": {"Image": "busybox"}}
+	]`)
+
+	got, err := parseDockerInspect(data, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// cache was started yesterday but still counts as running
+	if got.Started != 2 || got.Running != 2 {
+		t.Fatalf("started %d, running %d; want 2 and 2", got.Started, got.Running)
+	}
+	if c := got.Containers[0]; c.Name != "db" || c.Image != "postgres:16" || !c.Running || c.Minutes != 480 {
+		t.Errorf("first container = %+v, want db running for 480 minutes", c)
+	}
+	if c := got.Containers[1]; c.Name != "migrate" || c.Running || c.Minutes != 2 {
+		t.Errorf("second container = %+v, want migrate stopped after 2 minutes", c)
+	}
+
+	if _, err := parseDockerInspect([]byte("not json"), w); err == nil {
+		t.Error("expected an error for invalid output")
+	}
+}
+
+func TestParseDockerStats(t *testing.T) {
+	t.Parallel()
+	output := "db\t12.50%\t256MiB / 7.6GiB\n" +
+		"cache\t0.30%\t8.5MB / 7.6GiB\n" +
+		"broken\tn/a\t--\n"
+
+	stats := parseDockerStats(output)
+	if len(stats) != 2 {
+		t.Fatalf("parsed %d containers, want 2: %+v", len(stats), stats)
+	}
+	if s := stats["db"]; s.CPUPct != 12.5 || s.MemBytes != 256<<20 {
+		t.Errorf("db = %+v, want 12.5%% and 256 MiB", s)
+	}
+	if s := stats["cache"]; s.MemBytes != 8_500_000 {
+		t.Errorf("cache memory = %d, want 8500000", s.MemBytes)
+	}
+
+	result := DockerResult{Containers: []Container{{Name: "db", Running: true}}}
+	applyDockerStats(&result, stats)
+	if result.CPUPct < 12.79 || result.CPUPct > 12.81 || result.Containers[0].MemBytes != 256<<20 {
+		t.Errorf("after stats = %+v, want 12.8%% total and db at 256 MiB", result)
+	}
+}
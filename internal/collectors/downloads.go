@@ -0,0 +1,138 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxDownloadTypes caps the file types listed in DownloadsResult
+const MaxDownloadTypes = 5
+
+// DownloadType is the files of one type downloaded in the window
+type DownloadType struct {
+	Type  string // Lowercase extension without the dot, "folder", or "other"
+	Count int
+	Bytes int64
+}
+
+// DownloadsResult contains the files that landed in ~/Downloads in the window
+type DownloadsResult struct {
+	Count      int
+	TotalBytes int64
+	TopTypes   []DownloadType // Most bytes first, at most MaxDownloadTypes
+	Available  bool
+	Error      error
+}
+
+// partialDownloadExts mark downloads still in progress, which aren't
+// counted until they finish
+var partialDownloadExts = map[string]bool{
+	"crdownload": true, // Chrome, Edge, Brave
+	"download":   true, // Safari
+	"part":       true, // Firefox
+}
+
+// CollectDownloads scans ~/Downloads for files created in w. Only what's
+// still there is seen, so files already moved or deleted don't count.
+func CollectDownloads(ctx context.Context, w Window) DownloadsResult {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return DownloadsResult{Error: err}
+	}
+	return scanDownloads(ctx, filepath.Join(homeDir, "Downloads"), w)
+}
+
+// scanDownloads counts the entries directly in dir created in w. A folder,
+// such as an unpacked archive or an app bundle, counts as one download with
+// the size of everything in it.
+func scanDownloads(ctx context.Context, dir string, w Window) DownloadsResult {
+	result := DownloadsResult{Available: false}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to read %s: %w", dir, err)
+		return result
+	}
+
+	byType := make(map[string]*DownloadType)
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			result.Error = ctx.Err()
+			return result
+		}
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		created := fileCreated(info)
+		if created.Before(w.Start) || !created.Before(w.End) {
+			continue
+		}
+
+		kind := strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+		if partialDownloadExts[kind] {
+			continue
+		}
+		size := info.Size()
+		if entry.IsDir() {
+			size = dirSize(filepath.Join(dir, name))
+			if kind != "app" {
+				kind = "folder"
+			}
+		}
+		if kind == "" {
+			kind = "other"
+		}
+
+		t, ok := byType[kind]
+		if !ok {
+			t = &DownloadType{Type: kind}
+			byType[kind] = t
+		}
+		t.Count++
+		t.Bytes += size
+		result.Count++
+		result.TotalBytes += size
+	}
+
+	for _, t := range byType {
+		result.TopTypes = append(result.TopTypes, *t)
+	}
+	sort.Slice(result.TopTypes, func(i, j int) bool {
+		a, b := result.TopTypes[i], result.TopTypes[j]
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.Type < b.Type
+	})
+	if len(result.TopTypes) > MaxDownloadTypes {
+		result.TopTypes = result.TopTypes[:MaxDownloadTypes]
+	}
+
+	result.Available = true
+	return result
+}
+
+// dirSize adds up the regular files under dir, skipping anything unreadable
+func dirSize(dir string) int64 {
+	var total int64
+	filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
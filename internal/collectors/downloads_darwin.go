@@ -0,0 +1,17 @@
+package collectors
+
+import (
+	"io/fs"
+	"syscall"
+	"time"
+)
+
+// fileCreated returns when the file was created. APFS records it, so a
+// download whose modification time came from the server still counts on
+// the day it landed.
+func fileCreated(info fs.FileInfo) time.Time {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return time.Unix(st.Birthtimespec.Unix())
+	}
+	return info.ModTime()
+}
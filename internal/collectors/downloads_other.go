@@ -0,0 +1,14 @@
+//go:build !darwin
+
+package collectors
+
+import (
+	"io/fs"
+	"time"
+)
+
+// fileCreated returns when the file was last modified, the closest to a
+// creation time every platform records
+func fileCreated(info fs.FileInfo) time.Time {
+	return info.ModTime()
+}
@@ -0,0 +1,59 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanDownloads(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	now := time.Now()
+	w := Window{Start: now.Add(-time.Hour), End: now.Add(time.Minute)}
+
+	write := func(name string, size int, at time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("Installer.dmg", 3000, now)
+	write("Other Installer.DMG", 1000, now)
+	write("report.pdf", 200, now)
+	write("README", 10, now)
+	write("project/src/main.go", 500, now)
+	write("half.crdownload", 9000, now)
+	write(".DS_Store", 50, now)
+	write("old.zip", 7000, now.Add(-48*time.Hour))
+
+	got := scanDownloads(t.Context(), dir, w)
+	if !got.Available || got.Error != nil {
+		t.Fatalf("scanDownloads = %+v", got)
+	}
+	if got.Count != 5 || got.TotalBytes != 4710 {
+		t.Errorf("got %d files, %d bytes, want 5 and 4710", got.Count, got.TotalBytes)
+	}
+	want := []DownloadType{{"dmg", 2, 4000}, {"folder", 1, 500}, {"pdf", 1, 200}, {"other", 1, 10}}
+	if len(got.TopTypes) != len(want) {
+		t.Fatalf("TopTypes = %+v, want %+v", got.TopTypes, want)
+	}
+	for i := range want {
+		if got.TopTypes[i] != want[i] {
+			t.Errorf("TopTypes[%d] = %+v, want %+v", i, got.TopTypes[i], want[i])
+		}
+	}
+
+	if missing := scanDownloads(t.Context(), filepath.Join(dir, "nope"), w); missing.Available || missing.Error == nil {
+		t.Errorf("missing folder = %+v, want an error", missing)
+	}
+}
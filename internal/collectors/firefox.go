@@ -0,0 +1,370 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+// firefoxDir is where Firefox keeps profiles.ini and the Profiles folder
+func firefoxDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, "Library", "Application Support", "Firefox"), nil
+}
+
+// firefoxProfile returns the default profile's directory
+func firefoxProfile() (string, error) {
+	dir, err := firefoxDir()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(filepath.Join(dir, "profiles.ini"))
+	if err != nil {
+		return "", fmt.Errorf("firefox profiles not found: %w", err)
+	}
+	defer f.Close()
+
+	profile := parseFirefoxProfiles(f)
+	if profile == "" {
+		return "", fmt.Errorf("no default firefox profile")
+	}
+	if !filepath.IsAbs(profile) {
+		profile = filepath.Join(dir, profile)
+	}
+	return profile, nil
+}
+
+// parseFirefoxProfiles returns the default profile's path from profiles.ini.
+// Since Firefox 67 each install names its default in an [Install...]
+// section; older files mark a [Profile...] section with Default=1.
+func parseFirefoxProfiles(r io.Reader) string {
+	var section, installDefault, markedDefault, first string
+	var path string
+	var isDefault bool
+	endProfile := func() {
+		if path == "" {
+			return
+		}
+		if first == "" {
+			first = path
+		}
+		if isDefault && markedDefault == "" {
+			markedDefault = path
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			if strings.HasPrefix(section, "Profile") {
+				endProfile()
+			}
+			section = strings.Trim(line, "[]")
+			path, isDefault = "", false
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(section, "Install") && key == "Default" && installDefault == "":
+			installDefault = value
+		case strings.HasPrefix(section, "Profile") && key == "Path":
+			path = value
+		case strings.HasPrefix(section, "Profile") && key == "Default":
+			isDefault = value == "1"
+		}
+	}
+	if strings.HasPrefix(section, "Profile") {
+		endProfile()
+	}
+
+	switch {
+	case installDefault != "":
+		return installDefault
+	case markedDefault != "":
+		return markedDefault
+	}
+	return first
+}
+
+// collectFirefoxTabs reads open tabs from the session Firefox keeps while
+// running, and history in w from places.sqlite
+func collectFirefoxTabs(ctx context.Context, cfg *config.Config, w Window) BrowserResult {
+	result := BrowserResult{Browser: "Firefox", Domains: make(map[string]int), TabCategories: make(map[string]int)}
+
+	profile, err := firefoxProfile()
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	if w.Live() && firefoxRunning(ctx) {
+		urls, err := readFirefoxSession(filepath.Join(profile, "sessionstore-backups", "recovery.jsonlz4"))
+		if err != nil {
+			result.Error = fmt.Errorf("firefox session unreadable: %w", err)
+		} else {
+			result.Available = true
+			result.TabCount = len(urls)
+			if !fastMode.Load() {
+				for _, u := range urls {
+					if domain := extractDomain(u); domain != "" && (cfg == nil || !cfg.RedactsURL(u)) {
+						result.Domains[domain]++
+						if cfg != nil {
+							result.TabCategories[cfg.CategorizeURL(u, time.Now())]++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	historyData := collectBrowserHistory(ctx, cfg, filepath.Join(profile, "places.sqlite"), "firefox", w)
+	result.URLsVisited = historyData.URLsVisited
+	result.TopDomain = historyData.TopDomain
+	result.TopDomainVisits = historyData.TopDomainVisits
+	result.IssueURLs = historyData.IssueURLs
+	result.HistoryDomains = historyData.HistoryDomains
+	result.HistoryURLs = historyData.HistoryURLs
+	result.CloudVisits = historyData.CloudVisits
+	result.HistoryCategories = historyData.HistoryCategories
+	result.AfterHoursCategories = historyData.AfterHoursCategories
+
+	return result
+}
+
+// firefoxRunning reports whether Firefox is open. The recovery session
+// outlives a crash, so its tabs only count while Firefox runs.
+func firefoxRunning(ctx context.Context) bool {
+	return exec.CommandContext(ctx, "pgrep", "-x", "firefox").Run() == nil
+}
+
+// readFirefoxSession returns the current URL of every open tab in a
+// mozLz4-compressed session file
+func readFirefoxSession(path string) ([]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := decodeMozLz4(raw)
+	if err != nil {
+		return nil, err
+	}
+	return parseFirefoxSession(data)
+}
+
+// parseFirefoxSession returns the current URL of every tab in session JSON.
+// A tab's entries are its back/forward history; index (1-based) is the
+// page showing.
+func parseFirefoxSession(data []byte) ([]string, error) {
+	var session struct {
+		Windows []struct {
+			Tabs []struct {
+				Entries []struct {
+					URL string `json:"url"`
+				} `json:"entries"`
+				Index int `json:"index"`
+			} `json:"tabs"`
+		} `json:"windows"`
+	}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, win := range session.Windows {
+		for _, tab := range win.Tabs {
+			if len(tab.Entries) == 0 {
+				continue
+			}
+			i := tab.Index - 1
+			if i < 0 || i >= len(tab.Entries) {
+				i = len(tab.Entries) - 1
+			}
+			urls = append(urls, tab.Entries[i].URL)
+		}
+	}
+	return urls, nil
+}
+
+// mozLz4Magic starts Firefox's .jsonlz4 files, followed by the decoded
+// size as a little-endian uint32 and one LZ4 block
+var mozLz4Magic = []byte("mozLz40\x00")
+
+// decodeMozLz4 decompresses a mozLz4 file
+func decodeMozLz4(raw []byte) ([]byte, error) {
+	if !bytes.HasPrefix(raw, mozLz4Magic) || len(raw) < len(mozLz4Magic)+4 {
+		return nil, fmt.Errorf("not a mozLz4 file")
+	}
+	size := binary.LittleEndian.Uint32(raw[len(mozLz4Magic):])
+	return decodeLz4Block(raw[len(mozLz4Magic)+4:], int(size))
+}
+
+var errLz4Corrupt = errors.New("corrupt lz4 block")
+
+// decodeLz4Block decompresses one LZ4 block of a known decoded size. Each
+// sequence is a literal run followed by a match copied from earlier output;
+// the last sequence has literals only.
+func decodeLz4Block(src []byte, size int) ([]byte, error) {
+	dst := make([]byte, 0, size)
+	// length reads an LZ4 length: the 4-bit nibble, extended by following
+	// bytes while they're 255
+	length := func(n int, i *int) (int, error) {
+		if n != 15 {
+			return n, nil
+		}
+		for {
+			if *i >= len(src) {
+				return 0, errLz4Corrupt
+			}
+			b := src[*i]
+			*i++
+			n += int(b)
+			if b != 255 {
+				return n, nil
+			}
+		}
+	}
+
+	for i := 0; i < len(src); {
+		token := src[i]
+		i++
+
+		literals, err := length(int(token>>4), &i)
+		if err != nil {
+			return nil, err
+		}
+		if i+literals > len(src) || len(dst)+literals > size {
+			return nil, errLz4Corrupt
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+		if i == len(src) {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, errLz4Corrupt
+		}
+		offset := int(binary.LittleEndian.Uint16(src[i:]))
+		i += 2
+		matchLen, err := length(int(token&0x0f), &i)
+		if err != nil {
+			return nil, err
+		}
+		matchLen += 4
+		if offset == 0 || offset > len(dst) || len(dst)+matchLen > size {
+			return nil, errLz4Corrupt
+		}
+		// Matches may overlap the bytes they produce, so copy one at a time
+		start := len(dst) - offset
+		for j := range matchLen {
+			dst = append(dst, dst[start+j])
+		}
+	}
+	if len(dst) != size {
+		return nil, errLz4Corrupt
+	}
+	return dst, nil
+}
+
+// collectFirefoxIssues reads Firefox history for issue URLs
+func collectFirefoxIssues(ctx context.Context, w Window) []IssueVisit {
+	profile, err := firefoxProfile()
+	if err != nil {
+		return nil
+	}
+
+	db, cleanup, err := openWALCopy(filepath.Join(profile, "places.sqlite"))
+	if err != nil {
+		return nil
+	}
+	defer cleanup()
+
+	query := `
+		SELECT p.url, COUNT(*) as visit_count
+		FROM (
+			SELECT place_id FROM moz_historyvisits
+			WHERE visit_date >= ? AND visit_date < ?
+			ORDER BY visit_date DESC
+			LIMIT ?
+		) v
+		JOIN moz_places p ON p.id = v.place_id
+		GROUP BY p.url
+		ORDER BY visit_count DESC
+	`
+	rows, err := db.QueryContext(ctx, query, w.Start.UnixMicro(), w.End.UnixMicro(), historyRowLimit())
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	return extractIssuesFromRows(rows)
+}
+
+// openWALCopy opens a copy of the SQLite database at dbPath. Apps like
+// Firefox and Messages keep their databases in WAL mode, so recent rows are
+// only in the -wal file, which is copied alongside.
+func openWALCopy(dbPath string) (*sql.DB, func(), error) {
+	tempDir, err := os.MkdirTemp("", "rekap-db-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(tempDir) }
+
+	tempDB := filepath.Join(tempDir, filepath.Base(dbPath))
+	if err := copyFile(dbPath, tempDB); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	if _, err := os.Stat(dbPath + "-wal"); err == nil {
+		if err := copyFile(dbPath+"-wal", tempDB+"-wal"); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+	}
+
+	db, err := sql.Open("sqlite", tempDB)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return db, func() { db.Close(); cleanup() }, nil
+}
+
+// copyFile copies src to dst, readable only by the user
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,179 @@
+package collectors
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestParseFirefoxProfiles(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		ini  string
+		want string
+	}{
+		"install default wins": {`[Profile1]
+Name=work
+Path=Profiles/abc.work
+Default=1
+
+[Profile0]
+Name=default-release
+Path=Profiles/xyz.default-release
+
+[Install4F96D1932A9F858E]
+Default=Profiles/xyz.default-release
+Locked=1
+`, "Profiles/xyz.default-release"},
+		"marked default": {`[General]
+StartWithLastProfile=1
+
+[Profile0]
+Name=default
+Path=Profiles/old.default
+
+[Profile1]
+Name=main
+Path=Profiles/main.default
+Default=1
+`, "Profiles/main.default"},
+		"first profile": {"[Profile0]\nPath=Profiles/only.default\n", "Profiles/only.default"},
+		"none":          {"[General]\nVersion=2\n", ""},
+	}
+	for name, tt := range tests {
+		if got := parseFirefoxProfiles(strings.NewReader(tt.ini)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", name, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeMozLz4(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		block []byte
+		want  string
+	}{
+		// "abc", then a 6-byte match 3 back, then "!"
+		"match": {[]byte{0x32, 'a', 'b', 'c', 3, 0, 0x10, '!'}, "abcabcabc!"},
+		// A match overlapping the bytes it writes
+		"overlap": {[]byte{0x11, 'a', 1, 0, 0x10, 'b'}, "aaaaaab"},
+		// 20 literals: nibble 15 plus an extra length byte of 5
+		"long literals": {append([]byte{0xf0, 5}, "abcdefghijklmnopqrst"...), "abcdefghijklmnopqrst"},
+	}
+	for name, tt := range tests {
+		raw := append([]byte("mozLz40\x00"), binary.LittleEndian.AppendUint32(nil, uint32(len(tt.want)))...)
+		got, err := decodeMozLz4(append(raw, tt.block...))
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: got %q, %v; want %q", name, got, err, tt.want)
+		}
+	}
+
+	if _, err := decodeMozLz4([]byte("not lz4 at all")); err == nil {
+		t.Error("missing magic should fail")
+	}
+	bad := append([]byte("mozLz40\x00"), 10, 0, 0, 0, 0x32, 'a', 'b', 'c', 9, 0)
+	if _, err := decodeMozLz4(bad); err == nil {
+		t.Error("offset past the start of output should fail")
+	}
+}
+
+func TestParseFirefoxSession(t *testing.T) {
+	t.Parallel()
+	session := `{"windows":[
+		{"tabs":[
+			{"entries":[{"url":"https://github.com/"},{"url":"https://github.com/org/repo"}],"index":2},
+			{"entries":[{"url":"https://docs.python.org/3/"}],"index":1}
+		]},
+		{"tabs":[
+			{"entries":[],"index":0},
+			{"entries":[{"url":"https://news.ycombinator.com/"}],"index":7}
+		]}
+	]}`
+	urls, err := parseFirefoxSession([]byte(session))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"https://github.com/org/repo", "https://docs.python.org/3/", "https://news.ycombinator.com/"}
+	if !slices.Equal(urls, want) {
+		t.Errorf("urls = %v, want %v", urls, want)
+	}
+}
+
+func TestFirefoxHistory(t *testing.T) {
+	t.Parallel()
+	dbPath := filepath.Join(t.TempDir(), "places.sqlite")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2026, 2, 16, 0, 0, 0, 0, time.Local)
+	stmts := []string{
+		`PRAGMA journal_mode=WAL`,
+		`CREATE TABLE moz_places (id INTEGER PRIMARY KEY, url TEXT)`,
+		`CREATE TABLE moz_historyvisits (id INTEGER PRIMARY KEY, place_id INTEGER, visit_date INTEGER)`,
+		`INSERT INTO moz_places VALUES (1, 'https://github.com/org/repo/issues/12'), (2, 'https://console.aws.amazon.com/ec2'), (3, 'https://example.com/')`,
+	}
+	for _, s := range stmts {
+		if _, err := db.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	visits := []struct {
+		place int
+		at    time.Time
+	}{
+		{1, day.Add(9 * time.Hour)},
+		{1, day.Add(10 * time.Hour)},
+		{2, day.Add(11 * time.Hour)},
+		{3, day.Add(-time.Hour)}, // The day before
+	}
+	for _, v := range visits {
+		if _, err := db.Exec(`INSERT INTO moz_historyvisits (place_id, visit_date) VALUES (?, ?)`, v.place, v.at.UnixMicro()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Left open so the visits stay in the WAL file, as with a running Firefox
+	defer db.Close()
+	if _, err := os.Stat(dbPath + "-wal"); err != nil {
+		t.Fatalf("expected a WAL file: %v", err)
+	}
+
+	w := Window{Start: day, End: day.Add(24 * time.Hour)}
+	got := collectBrowserHistory(t.Context(), nil, dbPath, "firefox", w)
+	if got.URLsVisited != 2 || got.HistoryDomains["github.com"] != 2 || got.TopDomain != "github.com" {
+		t.Errorf("history = %+v", got)
+	}
+	if len(got.IssueURLs) != 1 || got.IssueURLs[0] != "org/repo#12" {
+		t.Errorf("IssueURLs = %v", got.IssueURLs)
+	}
+	if len(got.CloudVisits) != 1 || !got.CloudVisits[0].At.Equal(day.Add(11*time.Hour)) {
+		t.Errorf("CloudVisits = %+v", got.CloudVisits)
+	}
+
+	// Domain rules see each visit's time
+	cfg := config.Default()
+	cfg.Domains.Rules = []config.DomainRule{{Match: "github.com/org/*", Category: "distraction", Hours: "10:00-12:00"}}
+	got = collectBrowserHistory(t.Context(), cfg, dbPath, "firefox", w)
+	if got.HistoryCategories["work"] != 2 || got.HistoryCategories["distraction"] != 1 {
+		t.Errorf("HistoryCategories = %v, want 2 work and 1 distraction", got.HistoryCategories)
+	}
+
+	// Redacted visits are dropped, and denied issues aren't recorded
+	cfg = config.Default()
+	cfg.Privacy.RedactDomains = []string{"*.amazon.com"}
+	cfg.Privacy.IssueDenylist = []string{"org/repo#*"}
+	got = collectBrowserHistory(t.Context(), cfg, dbPath, "firefox", w)
+	if got.URLsVisited != 1 || got.HistoryDomains["console.aws.amazon.com"] != 0 || len(got.CloudVisits) != 0 {
+		t.Errorf("history with a redacted domain = %+v", got)
+	}
+	if len(got.IssueURLs) != 0 {
+		t.Errorf("IssueURLs = %v, want the denied issue left out", got.IssueURLs)
+	}
+}
@@ -13,8 +13,14 @@ type FocusResult struct {
 	Error         error
 }
 
-// CollectFocus calculates the longest focus streak from app usage data
-func CollectFocus(ctx context.Context) FocusResult {
+// CollectFocus calculates the longest focus streak in w from app usage
+// data, leaving out excludedApps
+func CollectFocus(ctx context.Context, excludedApps []string, w Window) FocusResult {
+	startTimestamp, endTimestamp := timestampRange(w)
+	return collectFocus(ctx, excludedApps, startTimestamp, endTimestamp)
+}
+
+func collectFocus(ctx context.Context, excludedApps []string, startTimestamp, endTimestamp float64) FocusResult {
 	result := FocusResult{Available: false}
 
 	db, err := openKnowledgeDB()
@@ -28,8 +34,6 @@ func CollectFocus(ctx context.Context) FocusResult {
 		}
 	}()
 
-	startTimestamp, endTimestamp := todayTimestampRange()
-
 	// Get all app usage intervals ordered by time
 	query := `
 		SELECT 
@@ -37,7 +41,7 @@ func CollectFocus(ctx context.Context) FocusResult {
 			ZSTARTDATE,
 			ZENDDATE
 		FROM ZOBJECT
-		WHERE ZSTREAMNAME = '/app/usage'
+		WHERE ZSTREAMNAME = ?
 			AND ZSTARTDATE >= ?
 			AND ZENDDATE <= ?
 			AND ZVALUESTRING IS NOT NULL
@@ -45,7 +49,7 @@ func CollectFocus(ctx context.Context) FocusResult {
 		ORDER BY ZSTARTDATE ASC
 	`
 
-	rows, err := db.QueryContext(ctx, query, startTimestamp, endTimestamp)
+	rows, err := db.QueryContext(ctx, query, appUsageStream(), startTimestamp, endTimestamp)
 	if err != nil {
 		result.Error = fmt.Errorf("failed to query data: %w", err)
 		return result
@@ -77,6 +81,11 @@ func CollectFocus(ctx context.Context) FocusResult {
 			continue
 		}
 
+		// Skip excluded apps (resolveAppName is globally cached)
+		if isExcluded(resolveAppName(bundleID), bundleID, excludedApps) {
+			continue
+		}
+
 		minutes := int((end - start) / 60)
 		if minutes > 0 {
 			intervals = append(intervals, interval{
@@ -3,6 +3,8 @@ package collectors
 import (
 	"context"
 	"math"
+
+	"github.com/alexinslc/rekap/internal/config"
 )
 
 // FragmentationResult contains context fragmentation analysis
@@ -21,6 +23,12 @@ type FragmentationBreakdown struct {
 	TotalTabs          int
 	UniqueDomains      int
 	AppSwitchesPerHour float64
+
+	// ClipboardChangesPerHour is shown alongside the factors but doesn't
+	// weigh in the score; it's only known when clipboard sampling is on
+	ClipboardChangesPerHour float64
+
+	Weights FragmentationWeights // Zero means DefaultFragmentationWeights
 }
 
 // FragmentationThresholds defines configurable thresholds
@@ -28,6 +36,34 @@ type FragmentationThresholds struct {
 	FocusedMax    int // 0-30 = Focused
 	ModerateMax   int // 31-60 = Moderate
 	FragmentedMin int // 61-100 = Fragmented
+
+	Weights FragmentationWeights // Zero means DefaultFragmentationWeights
+}
+
+// FragmentationWeights is the most each factor can add to the score, out
+// of 100. A factor weighted 0 is left out of the score.
+type FragmentationWeights struct {
+	Apps     int
+	Tabs     int
+	Domains  int
+	Switches int
+}
+
+// DefaultFragmentationWeights returns the default factor weights
+func DefaultFragmentationWeights() FragmentationWeights {
+	return FragmentationWeights{Apps: 30, Tabs: 25, Domains: 25, Switches: 20}
+}
+
+// FragmentationThresholdsFor returns the thresholds and weights set in
+// cfg's fragmentation block
+func FragmentationThresholdsFor(cfg *config.Config) FragmentationThresholds {
+	w := cfg.FragmentationWeights()
+	return FragmentationThresholds{
+		FocusedMax:    cfg.Fragmentation.FocusedMax,
+		ModerateMax:   cfg.Fragmentation.ModerateMax,
+		FragmentedMin: cfg.Fragmentation.FragmentedMin,
+		Weights:       FragmentationWeights{Apps: w.Apps, Tabs: w.Tabs, Domains: w.Domains, Switches: w.Switches},
+	}
 }
 
 // DefaultFragmentationThresholds returns default threshold values
@@ -36,6 +72,7 @@ func DefaultFragmentationThresholds() FragmentationThresholds {
 		FocusedMax:    30,
 		ModerateMax:   60,
 		FragmentedMin: 61,
+		Weights:       DefaultFragmentationWeights(),
 	}
 }
 
@@ -71,6 +108,7 @@ func CalculateFragmentation(ctx context.Context, apps AppsResult, browsers Brows
 		}
 	}
 
+	breakdown.Weights = thresholds.Weights
 	result.Breakdown = breakdown
 
 	// Calculate weighted score (0-100)
@@ -101,28 +139,47 @@ func CalculateFragmentation(ctx context.Context, apps AppsResult, browsers Brows
 	return result
 }
 
-// calculateWeightedScore computes a weighted score based on multiple factors
-func calculateWeightedScore(breakdown FragmentationBreakdown) float64 {
-	var score float64
-
-	// Factor 1: Unique apps (weight: 30%)
-	// 0-3 apps = low, 4-8 = medium, 9+ = high
-	appsScore := normalizeValue(float64(breakdown.UniqueApps), 3, 9) * 30
-
-	// Factor 2: Total tabs (weight: 25%)
-	// 0-10 tabs = low, 11-25 = medium, 26+ = high
-	tabsScore := normalizeValue(float64(breakdown.TotalTabs), 10, 30) * 25
-
-	// Factor 3: Unique domains (weight: 25%)
-	// 0-5 domains = low, 6-12 = medium, 13+ = high
-	domainsScore := normalizeValue(float64(breakdown.UniqueDomains), 5, 13) * 25
+// Fragmentation factors, as named in FragmentationFactor
+const (
+	FactorApps     = "apps"
+	FactorTabs     = "tabs"
+	FactorDomains  = "domains"
+	FactorSwitches = "switches"
+)
 
-	// Factor 4: App switches per hour (weight: 20%)
-	// 0-1 switches/hr = low, 2-3 = medium, 4+ = high
-	switchesScore := normalizeValue(breakdown.AppSwitchesPerHour, 1, 4) * 20
+// FragmentationFactor is one factor's share of the score
+type FragmentationFactor struct {
+	Name   string  // One of the Factor constants
+	Points float64 // Contribution to the score, 0 to Weight
+	Weight int     // Most the factor can contribute
+	Low    float64 // Values at or below this contribute nothing
+}
 
-	score = appsScore + tabsScore + domainsScore + switchesScore
+// Factors returns each factor's contribution to the score, in the order
+// the score weighs them. A factor weighted 0 contributes nothing.
+func (b FragmentationBreakdown) Factors() []FragmentationFactor {
+	w := b.Weights
+	if w == (FragmentationWeights{}) {
+		w = DefaultFragmentationWeights()
+	}
+	return []FragmentationFactor{
+		// 0-3 apps = low, 4-8 = medium, 9+ = high
+		{Name: FactorApps, Points: normalizeValue(float64(b.UniqueApps), 3, 9) * float64(w.Apps), Weight: w.Apps, Low: 3},
+		// 0-10 tabs = low, 11-25 = medium, 26+ = high
+		{Name: FactorTabs, Points: normalizeValue(float64(b.TotalTabs), 10, 30) * float64(w.Tabs), Weight: w.Tabs, Low: 10},
+		// 0-5 domains = low, 6-12 = medium, 13+ = high
+		{Name: FactorDomains, Points: normalizeValue(float64(b.UniqueDomains), 5, 13) * float64(w.Domains), Weight: w.Domains, Low: 5},
+		// 0-1 switches/hr = low, 2-3 = medium, 4+ = high
+		{Name: FactorSwitches, Points: normalizeValue(b.AppSwitchesPerHour, 1, 4) * float64(w.Switches), Weight: w.Switches, Low: 1},
+	}
+}
 
+// calculateWeightedScore computes a weighted score based on multiple factors
+func calculateWeightedScore(breakdown FragmentationBreakdown) float64 {
+	var score float64
+	for _, f := range breakdown.Factors() {
+		score += f.Points
+	}
 	return score
 }
 
@@ -3,6 +3,8 @@ package collectors
 import (
 	"context"
 	"testing"
+
+	"github.com/alexinslc/rekap/internal/config"
 )
 
 func TestCalculateFragmentation(t *testing.T) {
@@ -381,6 +383,31 @@ func TestFragmentationWithCustomThresholds(t *testing.T) {
 	t.Logf("Custom: level=%s, score=%d", customResult.Level, customResult.Score)
 }
 
+func TestFragmentationWithCustomWeights(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	apps := AppsResult{TopApps: []AppUsage{{Name: "App1", Minutes: 60}, {Name: "App2", Minutes: 30}}, Available: true}
+	browsers := BrowsersResult{TotalTabs: 200, Available: true}
+
+	// 200 tabs alone max out the tabs factor
+	if got := CalculateFragmentation(ctx, apps, browsers, UptimeResult{}, DefaultFragmentationThresholds()); got.Score != 25 {
+		t.Errorf("default weights: score = %d, want 25", got.Score)
+	}
+
+	// With tabs off, the rest are scaled up and tabs no longer count
+	cfg := config.Default()
+	cfg.Fragmentation.Disable = []string{"tabs"}
+	result := CalculateFragmentation(ctx, apps, browsers, UptimeResult{}, FragmentationThresholdsFor(cfg))
+	if result.Score != 0 {
+		t.Errorf("tabs disabled: score = %d, want 0", result.Score)
+	}
+	for _, f := range result.Breakdown.Factors() {
+		if f.Name == FactorTabs && (f.Weight != 0 || f.Points != 0) {
+			t.Errorf("tabs factor = %+v, want no weight", f)
+		}
+	}
+}
+
 func TestFragmentationBreakdownPopulation(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -0,0 +1,47 @@
+package collectors
+
+import "github.com/alexinslc/rekap/internal/config"
+
+// LearningResult is the time spent learning: in apps listed under
+// learning.apps plus a share of browser time on learning sites
+type LearningResult struct {
+	Minutes        int // AppMinutes + BrowserMinutes
+	AppMinutes     int
+	BrowserMinutes int // Browser time split by the share of page visits to learning sites
+	Visits         int // Page visits to learning sites
+	Available      bool
+	Error          error
+}
+
+// CalculateLearning attributes app and browser time to learning the same
+// way CalculateProjects attributes it to projects
+func CalculateLearning(apps AppsResult, browsers BrowsersResult, cfg *config.Config) LearningResult {
+	result := LearningResult{Available: false}
+	if cfg == nil || !apps.Available || (len(cfg.Learning.Domains) == 0 && len(cfg.Learning.Apps) == 0) {
+		return result
+	}
+
+	browserMinutes := 0
+	for _, app := range apps.TopApps {
+		if cfg.IsLearningApp(app.Name, app.BundleID) {
+			result.AppMinutes += app.Minutes
+		} else if isBrowserApp(app.BundleID) {
+			browserMinutes += app.Minutes
+		}
+	}
+
+	totalVisits := 0
+	for url, count := range browsers.URLVisits {
+		totalVisits += count
+		if cfg.IsLearningURL(url) {
+			result.Visits += count
+		}
+	}
+	if browserMinutes > 0 && totalVisits > 0 {
+		result.BrowserMinutes = browserMinutes * result.Visits / totalVisits
+	}
+
+	result.Minutes = result.AppMinutes + result.BrowserMinutes
+	result.Available = true
+	return result
+}
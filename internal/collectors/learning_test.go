@@ -0,0 +1,45 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestCalculateLearning(t *testing.T) {
+	t.Parallel()
+	cfg := config.Default()
+	cfg.Learning.Apps = []string{"Anki"}
+	cfg.Learning.Domains = append(cfg.Learning.Domains, "youtube.com/playlist?list=PL*")
+
+	apps := AppsResult{
+		TopApps: []AppUsage{
+			{Name: "Google Chrome", Minutes: 120, BundleID: "com.google.Chrome"},
+			{Name: "Anki", Minutes: 20, BundleID: "net.ankiweb.dtop"},
+			{Name: "Terminal", Minutes: 30, BundleID: "com.apple.Terminal"},
+		},
+		Available: true,
+	}
+	browsers := BrowsersResult{
+		URLVisits: map[string]int{
+			"https://www.coursera.org/learn/ml":           2,
+			"https://www.youtube.com/playlist?list=PLabc": 1,
+			"https://www.youtube.com/watch?v=dQw4w9WgXcQ": 1,
+			"https://github.com/alexinslc/rekap/pull/12":  4,
+		},
+		Available: true,
+	}
+
+	result := CalculateLearning(apps, browsers, cfg)
+	if !result.Available {
+		t.Fatal("expected result to be available")
+	}
+	// 20m of Anki plus 3 of 8 visits' share of 120m of browser time
+	if result.AppMinutes != 20 || result.BrowserMinutes != 45 || result.Minutes != 65 || result.Visits != 3 {
+		t.Errorf("got %+v, want 20 app + 45 browser over 3 visits", result)
+	}
+
+	if result := CalculateLearning(AppsResult{}, browsers, cfg); result.Available {
+		t.Error("expected unavailable result without app usage")
+	}
+}
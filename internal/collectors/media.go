@@ -5,19 +5,46 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/alexinslc/rekap/internal/config"
 )
 
 // MediaResult contains now playing information
 type MediaResult struct {
 	Track     string
 	App       string
+	Source    string // "applescript" or "nowplaying"
 	Available bool
 	Error     error
 }
 
-// CollectMedia retrieves currently or last played media information
-func CollectMedia(ctx context.Context) MediaResult {
-	result := MediaResult{Available: false}
+// CollectMedia retrieves currently or last played media information from
+// source (see config.SourcesConfig). With config.SourceAuto it asks Music
+// and Spotify first, then nowplaying-cli. Players only know what's playing
+// now, so past windows have no media.
+func CollectMedia(ctx context.Context, source string, w Window) MediaResult {
+	if !w.Live() {
+		return MediaResult{Available: false, Error: fmt.Errorf("now playing is only known for the current moment")}
+	}
+	switch source {
+	case config.SourceAppleScript:
+		return collectMediaAppleScript(ctx)
+	case config.SourceNowPlaying:
+		return collectMediaNowPlaying(ctx)
+	}
+	if result := collectMediaAppleScript(ctx); result.Available {
+		return result
+	}
+	result := collectMediaNowPlaying(ctx)
+	if !result.Available {
+		result.Source = ""
+	}
+	return result
+}
+
+// collectMediaAppleScript asks the Music and Spotify apps what's playing
+func collectMediaAppleScript(ctx context.Context) MediaResult {
+	result := MediaResult{Available: false, Source: config.SourceAppleScript}
 
 	// Try using osascript to query Music app
 	cmd := exec.CommandContext(ctx, "osascript", "-e", `
@@ -75,8 +102,15 @@ func CollectMedia(ctx context.Context) MediaResult {
 		}
 	}
 
-	// Check if nowplaying-cli is available
-	cmd = exec.CommandContext(ctx, "nowplaying-cli", "get", "title")
+	result.Error = fmt.Errorf("Music and Spotify aren't playing")
+	return result
+}
+
+// collectMediaNowPlaying reads the system Now Playing info via nowplaying-cli
+func collectMediaNowPlaying(ctx context.Context) MediaResult {
+	result := MediaResult{Available: false, Source: config.SourceNowPlaying}
+
+	cmd := exec.CommandContext(ctx, "nowplaying-cli", "get", "title")
 	titleOutput, titleErr := cmd.Output()
 
 	cmd = exec.CommandContext(ctx, "nowplaying-cli", "get", "artist")
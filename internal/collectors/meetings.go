@@ -0,0 +1,239 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar sources for MeetingsResult
+const (
+	MeetingSourceICalBuddy = "icalbuddy" // icalBuddy, if installed (brew install ical-buddy)
+	MeetingSourceEventKit  = "eventkit"  // EventKit through osascript, needs Calendars access
+)
+
+// Meeting is one timed calendar event, clipped to the window
+type Meeting struct {
+	Title string
+	Period
+}
+
+// MeetingsResult is the day's calendar: timed events (all-day events
+// aren't meetings), their total length, and the longest stretch without one
+type MeetingsResult struct {
+	Meetings           []Meeting // Oldest first
+	Count              int
+	TotalMinutes       int    // Overlapping meetings are counted once
+	LongestFreeMinutes int    // Set by CalculateMeetingFree
+	LongestFree        Period // The longest meeting-free block
+	Source             string // MeetingSourceICalBuddy or MeetingSourceEventKit
+	Available          bool
+	Error              error
+}
+
+// icalBuddyLayout is the date and time layout icalBuddy is asked to print
+const icalBuddyLayout = "2006-01-02 15:04"
+
+// eventKitScript prints the timed events between two Unix times as
+// "start<TAB>end<TAB>title" lines, in seconds
+const eventKitScript = `
+ObjC.import('EventKit');
+function run(argv) {
+	const store = $.EKEventStore.alloc.init;
+	if ($.EKEventStore.authorizationStatusForEntityType($.EKEntityTypeEvent) !== 3) {
+		throw new Error('no access to Calendars');
+	}
+	const start = $.NSDate.dateWithTimeIntervalSince1970(Number(argv[0]));
+	const end = $.NSDate.dateWithTimeIntervalSince1970(Number(argv[1]));
+	const events = store.eventsMatchingPredicate(store.predicateForEventsWithStartDateEndDateCalendars(start, end, null));
+	const lines = [];
+	for (let i = 0; i < events.count; i++) {
+		const e = events.objectAtIndex(i);
+		if (e.allDay) continue;
+		lines.push([Math.round(e.startDate.timeIntervalSince1970), Math.round(e.endDate.timeIntervalSince1970), ObjC.unwrap(e.title)].join('\t'));
+	}
+	return lines.join('\n');
+}`
+
+// CollectMeetings reads the timed calendar events overlapping w from
+// icalBuddy, or from EventKit when icalBuddy isn't installed
+func CollectMeetings(ctx context.Context, w Window) MeetingsResult {
+	result := MeetingsResult{Available: false}
+
+	meetings, err := meetingsFromICalBuddy(ctx, w)
+	result.Source = MeetingSourceICalBuddy
+	if err != nil {
+		var ekErr error
+		if meetings, ekErr = meetingsFromEventKit(ctx, w); ekErr != nil {
+			result.Source = ""
+			result.Error = fmt.Errorf("calendar unavailable: %v; %v", err, ekErr)
+			return result
+		}
+		result.Source = MeetingSourceEventKit
+	}
+
+	result.Meetings = clipMeetings(meetings, w)
+	result.Count = len(result.Meetings)
+	result.TotalMinutes = busyMinutes(result.Meetings)
+	result.Available = true
+	return result
+}
+
+func meetingsFromICalBuddy(ctx context.Context, w Window) ([]Meeting, error) {
+	path, err := exec.LookPath("icalBuddy")
+	if err != nil {
+		return nil, fmt.Errorf("icalBuddy not installed")
+	}
+	const stamp = "2006-01-02 15:04:05 -0700"
+	cmd := exec.CommandContext(ctx, path,
+		"-nc", "-nrd", "-npn", "-ea", "-b", "", "-ps", "|\t|",
+		"-iep", "title,datetime", "-po", "title,datetime",
+		"-df", "%Y-%m-%d", "-tf", "%H:%M",
+		"eventsFrom:"+w.Start.Format(stamp), "to:"+w.End.Format(stamp))
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("icalBuddy: %w", err)
+	}
+	return parseICalBuddy(string(out), w.Start.Location()), nil
+}
+
+// parseICalBuddy reads "title<TAB>2026-02-16 at 09:00 - 09:30" lines. An
+// event crossing midnight repeats the date: "... at 23:00 - 2026-02-17 at
+// 01:00". Lines that don't parse are skipped.
+func parseICalBuddy(out string, loc *time.Location) []Meeting {
+	var meetings []Meeting
+	for line := range strings.SplitSeq(out, "\n") {
+		title, when, ok := strings.Cut(strings.TrimRight(line, "\r"), "\t")
+		if !ok {
+			continue
+		}
+		from, to, ok := strings.Cut(when, " - ")
+		if !ok {
+			continue
+		}
+		date, startClock, ok := strings.Cut(strings.TrimSpace(from), " at ")
+		if !ok {
+			continue
+		}
+		start, err := time.ParseInLocation(icalBuddyLayout, date+" "+startClock, loc)
+		if err != nil {
+			continue
+		}
+		to = strings.TrimSpace(to)
+		if endDate, endClock, ok := strings.Cut(to, " at "); ok {
+			date, to = endDate, endClock
+		}
+		end, err := time.ParseInLocation(icalBuddyLayout, date+" "+to, loc)
+		if err != nil {
+			continue
+		}
+		meetings = append(meetings, Meeting{Title: strings.TrimSpace(title), Period: Period{Start: start, End: end}})
+	}
+	return meetings
+}
+
+func meetingsFromEventKit(ctx context.Context, w Window) ([]Meeting, error) {
+	cmd := exec.CommandContext(ctx, "osascript", "-l", "JavaScript", "-e", eventKitScript,
+		strconv.FormatInt(w.Start.Unix(), 10), strconv.FormatInt(w.End.Unix(), 10))
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("EventKit: %w", err)
+	}
+
+	var meetings []Meeting
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		start, err1 := strconv.ParseInt(fields[0], 10, 64)
+		end, err2 := strconv.ParseInt(fields[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		meetings = append(meetings, Meeting{Title: fields[2], Period: Period{Start: time.Unix(start, 0), End: time.Unix(end, 0)}})
+	}
+	return meetings, nil
+}
+
+// clipMeetings trims meetings to w, drops those outside it or with no
+// length, and sorts them by start
+func clipMeetings(meetings []Meeting, w Window) []Meeting {
+	var clipped []Meeting
+	for _, m := range meetings {
+		if m.Start.Before(w.Start) {
+			m.Start = w.Start
+		}
+		if m.End.After(w.End) {
+			m.End = w.End
+		}
+		if m.End.After(m.Start) {
+			clipped = append(clipped, m)
+		}
+	}
+	slices.SortStableFunc(clipped, func(a, b Meeting) int { return a.Start.Compare(b.Start) })
+	return clipped
+}
+
+// busyPeriods merges overlapping meetings (sorted by start) into the
+// periods spent in at least one
+func busyPeriods(meetings []Meeting) []Period {
+	var busy []Period
+	for _, m := range meetings {
+		if n := len(busy); n > 0 && !m.Start.After(busy[n-1].End) {
+			if m.End.After(busy[n-1].End) {
+				busy[n-1].End = m.End
+			}
+			continue
+		}
+		busy = append(busy, m.Period)
+	}
+	return busy
+}
+
+func busyMinutes(meetings []Meeting) int {
+	total := 0
+	for _, p := range busyPeriods(meetings) {
+		total += p.Minutes()
+	}
+	return total
+}
+
+// CalculateMeetingFree finds the longest block without meetings in the
+// workday: from arrival to wrap-up when the day's bounds are known, and
+// across the whole window otherwise
+func CalculateMeetingFree(meetings MeetingsResult, bounds DayBoundsResult, w Window) MeetingsResult {
+	if !meetings.Available {
+		return meetings
+	}
+	span := Period{Start: w.Start, End: w.End}
+	if bounds.Available {
+		span = Period{Start: bounds.Arrival, End: bounds.WrapUp}
+	}
+
+	var longest Period
+	cursor := span.Start
+	consider := func(end time.Time) {
+		if end.After(span.End) {
+			end = span.End
+		}
+		if end.Sub(cursor) > longest.End.Sub(longest.Start) {
+			longest = Period{Start: cursor, End: end}
+		}
+	}
+	for _, p := range busyPeriods(meetings.Meetings) {
+		consider(p.Start)
+		if p.End.After(cursor) {
+			cursor = p.End
+		}
+	}
+	consider(span.End)
+
+	meetings.LongestFree = longest
+	meetings.LongestFreeMinutes = longest.Minutes()
+	return meetings
+}
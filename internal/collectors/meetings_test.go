@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseICalBuddy(t *testing.T) {
+	t.Parallel()
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 2, day, hour, min, 0, 0, time.Local)
+	}
+
+	out := "Standup\t2026-02-16 at 09:00 - 09:15\n" +
+		"1:1 with Sam\t2026-02-16 at 14:00 - 14:30\n" +
+		"Release night\t2026-02-16 at 23:00 - 2026-02-17 at 01:00\n" +
+		"no times here\n" +
+		"Broken\t2026-02-16 at 9am - 10am\n"
+
+	got := parseICalBuddy(out, time.Local)
+	want := []Meeting{
+		{"Standup", Period{at(16, 9, 0), at(16, 9, 15)}},
+		{"1:1 with Sam", Period{at(16, 14, 0), at(16, 14, 30)}},
+		{"Release night", Period{at(16, 23, 0), at(17, 1, 0)}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parsed %d meetings, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Title != want[i].Title || !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("meeting %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMeetingTotalsAndFreeBlock(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 16, hour, min, 0, 0, time.Local)
+	}
+	w := Window{Start: at(0, 0), End: at(23, 59)}
+
+	meetings := clipMeetings([]Meeting{
+		{"Planning", Period{at(13, 0), at(14, 0)}},
+		{"Standup", Period{at(9, 30), at(9, 45)}},
+		{"Overlaps planning", Period{at(13, 30), at(14, 30)}},
+		{"Empty", Period{at(16, 0), at(16, 0)}},
+	}, w)
+	result := MeetingsResult{Meetings: meetings, Count: len(meetings), TotalMinutes: busyMinutes(meetings), Available: true}
+
+	if result.Count != 3 || result.Meetings[0].Title != "Standup" {
+		t.Fatalf("meetings = %+v, want 3 sorted by start", result.Meetings)
+	}
+	// 15 min standup + 13:00-14:30 counted once
+	if result.TotalMinutes != 105 {
+		t.Errorf("TotalMinutes = %d, want 105", result.TotalMinutes)
+	}
+
+	// Workday 9:00-18:00: 14:30-18:00 (210 min) beats 9:45-13:00 (195 min)
+	bounds := DayBoundsResult{Arrival: at(9, 0), WrapUp: at(18, 0), Available: true}
+	result = CalculateMeetingFree(result, bounds, w)
+	if result.LongestFreeMinutes != 210 || !result.LongestFree.Start.Equal(at(14, 30)) {
+		t.Errorf("longest free = %d min from %v, want 210 from 14:30", result.LongestFreeMinutes, result.LongestFree.Start)
+	}
+
+	// No meetings: the whole workday is free
+	empty := CalculateMeetingFree(MeetingsResult{Available: true}, bounds, w)
+	if empty.LongestFreeMinutes != 540 {
+		t.Errorf("LongestFreeMinutes with no meetings = %d, want 540", empty.LongestFreeMinutes)
+	}
+}
@@ -0,0 +1,212 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// Memory pressure levels, as the kernel reports them
+const (
+	MemoryPressureNormal   = "normal"
+	MemoryPressureWarn     = "warn"
+	MemoryPressureCritical = "critical"
+)
+
+// HeavySwapBytes is the swap use that makes a heavy memory day even without
+// a pressure warning
+const HeavySwapBytes = 1 << 30
+
+// MemoryResult contains the day's memory pressure and swap use, built from
+// samples taken by each run and by "rekap sample"
+type MemoryResult struct {
+	PeakPressure  string // MemoryPressureNormal, MemoryPressureWarn, or MemoryPressureCritical
+	PeakUsedPct   int    // Highest share of memory in use, from memory_pressure's free percentage
+	SwapUsedBytes int64  // Swap in use at the last sample
+	PeakSwapBytes int64
+	Samples       int
+	Available     bool
+	Error         error
+}
+
+// Heavy reports whether the system ran short of memory: the kernel raised
+// a pressure warning, or swap grew past HeavySwapBytes
+func (m MemoryResult) Heavy() bool {
+	return m.PeakPressure == MemoryPressureWarn || m.PeakPressure == MemoryPressureCritical || m.PeakSwapBytes >= HeavySwapBytes
+}
+
+// memorySample is a single memory reading persisted to the daily log
+type memorySample struct {
+	Timestamp string `json:"timestamp"`
+	Level     int    `json:"level"`    // kern.memorystatus_vm_pressure_level: 1 normal, 2 warn, 4 critical
+	FreePct   int    `json:"free_pct"` // memory_pressure's system-wide free percentage
+	SwapBytes int64  `json:"swap_bytes"`
+}
+
+// pressureLevels maps kern.memorystatus_vm_pressure_level values to names
+var pressureLevels = map[int]string{
+	1: MemoryPressureNormal,
+	2: MemoryPressureWarn,
+	4: MemoryPressureCritical,
+}
+
+// RecordMemorySample reads memory pressure and swap and appends them to
+// today's sample log
+func RecordMemorySample(ctx context.Context) error {
+	sample, err := readMemory(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadMemorySamples(DayKey(now))
+	if shouldRecordMemorySample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindMemory, DayKey(now), samples); err != nil {
+			return fmt.Errorf("failed to save memory sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// CollectMemory reports the peak memory pressure and swap use in w. Pressure
+// is only seen when something samples it, so a single run shows the moment
+// it ran; "rekap sample --every" fills in the rest of the day.
+func CollectMemory(ctx context.Context, w Window) MemoryResult {
+	var sampleErr error
+	if w.Live() {
+		sampleErr = RecordMemorySample(ctx)
+	}
+
+	var samples []memorySample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadMemorySamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeMemorySamples(samples, w)
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("memory pressure unavailable: %w", sampleErr)
+	}
+	return result
+}
+
+// summarizeMemorySamples finds the peaks among the samples in w and the
+// swap in use at the last one
+func summarizeMemorySamples(samples []memorySample, w Window) MemoryResult {
+	result := MemoryResult{Available: false}
+
+	var last time.Time
+	peakLevel := 0
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		result.Samples++
+		peakLevel = max(peakLevel, s.Level)
+		result.PeakUsedPct = max(result.PeakUsedPct, 100-s.FreePct)
+		result.PeakSwapBytes = max(result.PeakSwapBytes, s.SwapBytes)
+		if !at.Before(last) {
+			last = at
+			result.SwapUsedBytes = s.SwapBytes
+		}
+	}
+
+	if result.Samples == 0 {
+		result.Error = fmt.Errorf("no memory samples recorded in this window")
+		return result
+	}
+	result.PeakPressure = pressureLevels[peakLevel]
+	if result.PeakPressure == "" {
+		result.PeakPressure = MemoryPressureNormal
+	}
+	result.Available = true
+	return result
+}
+
+// readMemory reads the kernel's pressure level, memory_pressure's free
+// percentage, and swap in use
+func readMemory(ctx context.Context) (memorySample, error) {
+	var sample memorySample
+
+	out, err := exec.CommandContext(ctx, "sysctl", "-n", "kern.memorystatus_vm_pressure_level").Output()
+	if err != nil {
+		return sample, fmt.Errorf("failed to read memory pressure level: %w", err)
+	}
+	if sample.Level, err = strconv.Atoi(strings.TrimSpace(string(out))); err != nil {
+		return sample, fmt.Errorf("failed to parse memory pressure level: %w", err)
+	}
+
+	if out, err := exec.CommandContext(ctx, "memory_pressure", "-Q").Output(); err == nil {
+		sample.FreePct, _ = parseMemoryFreePct(string(out))
+	}
+
+	out, err = exec.CommandContext(ctx, "sysctl", "-n", "vm.swapusage").Output()
+	if err != nil {
+		return sample, fmt.Errorf("failed to read swap usage: %w", err)
+	}
+	if sample.SwapBytes, err = parseSwapUsed(string(out)); err != nil {
+		return sample, err
+	}
+	return sample, nil
+}
+
+var memoryFreePattern = regexp.MustCompile(`free percentage:\s*(\d+)%`)
+
+// parseMemoryFreePct reads "System-wide memory free percentage: 43%" from
+// memory_pressure -Q
+func parseMemoryFreePct(output string) (int, error) {
+	m := memoryFreePattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0, fmt.Errorf("no free percentage in memory_pressure output")
+	}
+	return strconv.Atoi(m[1])
+}
+
+var swapUsedPattern = regexp.MustCompile(`used = ([\d.]+)([KMG])`)
+
+// parseSwapUsed reads the used figure from vm.swapusage, e.g.
+// "total = 2048.00M  used = 1187.25M  free = 860.75M  (encrypted)"
+func parseSwapUsed(output string) (int64, error) {
+	m := swapUsedPattern.FindStringSubmatch(output)
+	if m == nil {
+		return 0, fmt.Errorf("failed to parse swap usage: %q", strings.TrimSpace(output))
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse swap usage: %w", err)
+	}
+	unit := map[string]float64{"K": 1 << 10, "M": 1 << 20, "G": 1 << 30}[m[2]]
+	return int64(n * unit), nil
+}
+
+func shouldRecordMemorySample(samples []memorySample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadMemorySamples returns the samples recorded on date (YYYY-MM-DD)
+func loadMemorySamples(date string) ([]memorySample, error) {
+	var samples []memorySample
+	if _, err := state.Load(state.KindMemory, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,69 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMemory(t *testing.T) {
+	t.Parallel()
+	out := "The system has 17179869184 (1048576 pages with a page size of 16384).\n\nSystem-wide memory free percentage: 43%\n"
+	if got, err := parseMemoryFreePct(out); err != nil || got != 43 {
+		t.Errorf("parseMemoryFreePct = %d, %v, want 43", got, err)
+	}
+	if _, err := parseMemoryFreePct("nothing"); err == nil {
+		t.Error("parseMemoryFreePct accepted output with no percentage")
+	}
+
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"total = 2048.00M  used = 1187.25M  free = 860.75M  (encrypted)", 1244921856},
+		{"total = 4.00G  used = 1.50G  free = 2.50G  (encrypted)", 1610612736},
+		{"total = 0.00M  used = 0.00M  free = 0.00M  (encrypted)", 0},
+	}
+	for _, tt := range tests {
+		if got, err := parseSwapUsed(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseSwapUsed(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseSwapUsed("garbage"); err == nil {
+		t.Error("parseSwapUsed accepted garbage")
+	}
+}
+
+func TestSummarizeMemorySamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour int) string {
+		return time.Date(2026, 2, 16, hour, 0, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	w := Window{Start: time.Date(2026, 2, 16, 0, 0, 0, 0, time.Local), End: time.Date(2026, 2, 17, 0, 0, 0, 0, time.Local)}
+
+	got := summarizeMemorySamples([]memorySample{
+		{Timestamp: at(9), Level: 1, FreePct: 60, SwapBytes: 0},
+		{Timestamp: at(14), Level: 2, FreePct: 12, SwapBytes: 2 << 30},
+		{Timestamp: at(17), Level: 1, FreePct: 40, SwapBytes: 512 << 20},
+		{Timestamp: at(-1), Level: 4, FreePct: 1}, // The night before
+	}, w)
+	if !got.Available || got.Samples != 3 {
+		t.Fatalf("summarizeMemorySamples = %+v, want 3 samples", got)
+	}
+	if got.PeakPressure != MemoryPressureWarn || got.PeakUsedPct != 88 {
+		t.Errorf("peak = %s at %d%% used, want warn at 88%%", got.PeakPressure, got.PeakUsedPct)
+	}
+	if got.SwapUsedBytes != 512<<20 || got.PeakSwapBytes != 2<<30 {
+		t.Errorf("swap = %d now, %d peak, want the 17:00 reading and the 14:00 peak", got.SwapUsedBytes, got.PeakSwapBytes)
+	}
+	if !got.Heavy() {
+		t.Error("a day with a pressure warning isn't heavy")
+	}
+
+	calm := summarizeMemorySamples([]memorySample{{Timestamp: at(9), Level: 1, FreePct: 70}}, w)
+	if calm.Heavy() || calm.PeakPressure != MemoryPressureNormal {
+		t.Errorf("calm day = %+v, want normal and not heavy", calm)
+	}
+	if empty := summarizeMemorySamples(nil, w); empty.Available || empty.Error == nil {
+		t.Errorf("no samples = %+v, want unavailable", empty)
+	}
+}
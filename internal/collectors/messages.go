@@ -0,0 +1,168 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MessageCount is how many messages were sent and received on one service
+type MessageCount struct {
+	Service  string // "Messages" (iMessage, SMS, and RCS) or "Slack"
+	Sent     int
+	Received int
+}
+
+// MessagesResult measures communication load. Messages are private, so
+// they're only counted when tracking.messages is on, and no text, sender,
+// or conversation ever leaves this collector.
+type MessagesResult struct {
+	Services  []MessageCount // Services that could be read, Messages first
+	Sent      int
+	Received  int
+	Available bool
+	Error     error
+}
+
+// slackAPI is the Slack Web API base URL; tests point it at a local server
+var slackAPI = "https://slack.com/api/"
+
+// CollectMessages counts the messages sent and received in w from the
+// Messages database and, with a user token, from Slack
+func CollectMessages(ctx context.Context, enabled bool, slackToken string, w Window) MessagesResult {
+	if !enabled {
+		return MessagesResult{Error: fmt.Errorf("message counts are off (set tracking.messages: true)")}
+	}
+
+	var result MessagesResult
+	var errs []error
+	if count, err := countIMessages(ctx, w); err != nil {
+		errs = append(errs, err)
+	} else {
+		result.Services = append(result.Services, count)
+	}
+	if slackToken != "" {
+		if count, err := countSlackMessages(ctx, slackToken, w); err != nil {
+			errs = append(errs, err)
+		} else {
+			result.Services = append(result.Services, count)
+		}
+	}
+
+	for _, s := range result.Services {
+		result.Sent += s.Sent
+		result.Received += s.Received
+	}
+	result.Available = len(result.Services) > 0
+	result.Error = errors.Join(errs...)
+	return result
+}
+
+// countIMessages counts messages in w from the Messages database, leaving
+// out tapbacks and group events like renames
+func countIMessages(ctx context.Context, w Window) (MessageCount, error) {
+	count := MessageCount{Service: "Messages"}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return count, err
+	}
+	dbPath := filepath.Join(home, "Library", "Messages", "chat.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return count, fmt.Errorf("messages database not readable (requires Full Disk Access): %w", err)
+	}
+
+	db, cleanup, err := openWALCopy(dbPath)
+	if err != nil {
+		return count, fmt.Errorf("messages database not readable (requires Full Disk Access): %w", err)
+	}
+	defer cleanup()
+
+	// Dates are Core Data nanoseconds since High Sierra, seconds before it
+	start, end := timestampRange(w)
+	rows, err := db.QueryContext(ctx, `
+		SELECT is_from_me, COUNT(*)
+		FROM message
+		WHERE (CASE WHEN date > 1000000000000 THEN date / 1000000000.0 ELSE date END) >= ?
+			AND (CASE WHEN date > 1000000000000 THEN date / 1000000000.0 ELSE date END) < ?
+			AND item_type = 0 AND associated_message_type = 0
+		GROUP BY is_from_me
+	`, start, end)
+	if err != nil {
+		return count, fmt.Errorf("failed to query messages: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fromMe bool
+		var n int
+		if err := rows.Scan(&fromMe, &n); err != nil {
+			continue
+		}
+		if fromMe {
+			count.Sent = n
+		} else {
+			count.Received = n
+		}
+	}
+	return count, rows.Err()
+}
+
+// countSlackMessages counts the messages you sent in w's day and the direct
+// messages sent to you, using Slack search with a user token (search:read
+// scope). Search only narrows by day, so partial windows skip Slack.
+func countSlackMessages(ctx context.Context, token string, w Window) (MessageCount, error) {
+	count := MessageCount{Service: "Slack"}
+	if !w.WholeDay() {
+		return count, fmt.Errorf("slack counts whole days only")
+	}
+	day := w.Start.Format("2006-01-02")
+
+	var err error
+	if count.Sent, err = slackSearchTotal(ctx, token, "from:me on:"+day); err != nil {
+		return count, err
+	}
+	if count.Received, err = slackSearchTotal(ctx, token, "to:me on:"+day); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// slackSearchTotal returns how many messages match query, fetching one
+func slackSearchTotal(ctx context.Context, token, query string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	endpoint := slackAPI + "search.messages?" + url.Values{"query": {query}, "count": {"1"}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("slack search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		OK       bool   `json:"ok"`
+		Error    string `json:"error"`
+		Messages struct {
+			Total int `json:"total"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("slack search: %w", err)
+	}
+	if !body.OK {
+		return 0, fmt.Errorf("slack search: %s", body.Error)
+	}
+	return body.Messages.Total, nil
+}
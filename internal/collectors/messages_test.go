@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Not parallel: it sets $HOME and points slackAPI at a test server
+func TestCollectMessages(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	day := time.Date(2026, 2, 16, 0, 0, 0, 0, time.Local)
+
+	dir := filepath.Join(home, "Library", "Messages")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", filepath.Join(dir, "chat.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE message (ROWID INTEGER PRIMARY KEY, text TEXT, date INTEGER, is_from_me INTEGER, item_type INTEGER, associated_message_type INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	nanos := func(at time.Time) int64 { return int64(at.Sub(coreDataEpoch)) }
+	rows := []struct {
+		at                    time.Time
+		fromMe, item, tapback int
+	}{
+		{day.Add(9 * time.Hour), 1, 0, 0},
+		{day.Add(10 * time.Hour), 0, 0, 0},
+		{day.Add(11 * time.Hour), 0, 0, 0},
+		{day.Add(11 * time.Hour), 0, 0, 2000}, // A tapback
+		{day.Add(12 * time.Hour), 0, 2, 0},    // A group rename
+		{day.Add(-time.Hour), 1, 0, 0},        // The day before
+	}
+	for _, r := range rows {
+		if _, err := db.Exec(`INSERT INTO message (text, date, is_from_me, item_type, associated_message_type) VALUES ('hi', ?, ?, ?, ?)`,
+			nanos(r.at), r.fromMe, r.item, r.tapback); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxp-test" {
+			w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+			return
+		}
+		switch r.URL.Query().Get("query") {
+		case "from:me on:2026-02-16":
+			w.Write([]byte(`{"ok":true,"messages":{"total":7}}`))
+		case "to:me on:2026-02-16":
+			w.Write([]byte(`{"ok":true,"messages":{"total":3}}`))
+		default:
+			w.Write([]byte(`{"ok":false,"error":"bad query"}`))
+		}
+	}))
+	defer server.Close()
+	defer func(api string) { slackAPI = api }(slackAPI)
+	slackAPI = server.URL + "/"
+
+	w := Window{Start: day, End: day.Add(24 * time.Hour)}
+	if got := CollectMessages(t.Context(), false, "", w); got.Available {
+		t.Errorf("collected while off: %+v", got)
+	}
+
+	got := CollectMessages(t.Context(), true, "xoxp-test", w)
+	if got.Error != nil || !got.Available || len(got.Services) != 2 {
+		t.Fatalf("CollectMessages = %+v", got)
+	}
+	if s := got.Services[0]; s.Service != "Messages" || s.Sent != 1 || s.Received != 2 {
+		t.Errorf("Messages = %+v, want 1 sent, 2 received", s)
+	}
+	if s := got.Services[1]; s.Service != "Slack" || s.Sent != 7 || s.Received != 3 {
+		t.Errorf("Slack = %+v, want 7 sent, 3 received", s)
+	}
+	if got.Sent != 8 || got.Received != 5 {
+		t.Errorf("totals = %d sent, %d received, want 8 and 5", got.Sent, got.Received)
+	}
+
+	// A bad token leaves Slack out but keeps Messages
+	got = CollectMessages(t.Context(), true, "xoxp-wrong", w)
+	if !got.Available || len(got.Services) != 1 || got.Error == nil {
+		t.Errorf("with a bad token = %+v", got)
+	}
+}
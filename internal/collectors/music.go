@@ -0,0 +1,268 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// Listening kinds in musicSample. Music is the default, an empty kind.
+const (
+	listenMusic     = ""
+	listenPodcast   = "podcast"
+	listenAudiobook = "audiobook"
+)
+
+// musicPlayers are the apps asked what's playing, in order. Each script
+// prints "title|artist|id"; Spotify's id tells episodes from songs.
+var musicPlayers = []struct {
+	App    string
+	Script string
+}{
+	{"Music", `(name of current track) & "|" & (artist of current track) & "|"`},
+	{"Spotify", `(name of current track) & "|" & (artist of current track) & "|" & (spotify url of current track)`},
+}
+
+// MusicArtist is the time spent listening to one artist in the window
+type MusicArtist struct {
+	Name    string
+	Minutes int
+}
+
+// MusicResult contains what was listened to in the window, built from
+// samples of what was playing taken by each run and by "rekap sample".
+// Podcasts and audiobooks are counted apart from music.
+type MusicResult struct {
+	Minutes          int           // Music only
+	Tracks           int           // Track changes seen; a track on repeat counts once
+	Artists          []MusicArtist // Most time first
+	PodcastMinutes   int
+	Episodes         int // Episode changes seen, as for Tracks
+	AudiobookMinutes int
+	Samples          int
+	Available        bool
+	Error            error
+}
+
+// musicSample is what was playing at one moment, persisted to the daily
+// log. An empty Track means nothing was.
+type musicSample struct {
+	Timestamp string `json:"timestamp"`
+	Track     string `json:"track,omitempty"`
+	Artist    string `json:"artist,omitempty"`
+	App       string `json:"app,omitempty"`
+	Kind      string `json:"kind,omitempty"` // listenMusic, listenPodcast, or listenAudiobook
+}
+
+// RecordMusicSample asks Music and Spotify, then the system's Now Playing
+// info, what's playing and appends it to today's sample log. Players that
+// aren't running aren't launched.
+func RecordMusicSample(ctx context.Context) error {
+	sample := readPlayingTrack(ctx)
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadMusicSamples(DayKey(now))
+	if shouldRecordMusicSample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindMusic, DayKey(now), samples); err != nil {
+			return fmt.Errorf("failed to save music sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// CollectMusic adds up the listening time, tracks, and artists in w
+func CollectMusic(ctx context.Context, w Window) MusicResult {
+	var sampleErr error
+	if w.Live() {
+		sampleErr = RecordMusicSample(ctx)
+	}
+
+	var samples []musicSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadMusicSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeMusicSamples(samples, w)
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("music history unavailable: %w", sampleErr)
+	}
+	return result
+}
+
+// summarizeMusicSamples credits each sample with something playing with
+// the time until the next sample (or the end of w), capped at
+// appSampleMaxCredit. A track or episode counts when it differs from the
+// one before.
+func summarizeMusicSamples(samples []musicSample, w Window) MusicResult {
+	result := MusicResult{Available: false}
+
+	type timedSample struct {
+		musicSample
+		at time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s, at})
+	}
+	if len(timed) == 0 {
+		result.Error = fmt.Errorf("no music samples recorded in this window")
+		return result
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := sampleCredits(times, w.End)
+
+	var music, podcasts, audiobooks time.Duration
+	artists := make(map[string]time.Duration)
+	previous := ""
+	for i, s := range timed {
+		key := s.Kind + "\x00" + s.Track + "\x00" + s.Artist
+		if s.Track == "" {
+			previous = ""
+			continue
+		}
+		changed := key != previous
+		previous = key
+
+		switch s.Kind {
+		case listenPodcast:
+			podcasts += credits[i]
+			if changed {
+				result.Episodes++
+			}
+		case listenAudiobook:
+			audiobooks += credits[i]
+		default:
+			music += credits[i]
+			if changed {
+				result.Tracks++
+			}
+			if s.Artist != "" {
+				artists[s.Artist] += credits[i]
+			}
+		}
+	}
+
+	result.Minutes = int(music.Minutes())
+	result.PodcastMinutes = int(podcasts.Minutes())
+	result.AudiobookMinutes = int(audiobooks.Minutes())
+	for name, d := range artists {
+		result.Artists = append(result.Artists, MusicArtist{Name: name, Minutes: int(d.Minutes())})
+	}
+	sort.Slice(result.Artists, func(i, j int) bool {
+		if result.Artists[i].Minutes != result.Artists[j].Minutes {
+			return result.Artists[i].Minutes > result.Artists[j].Minutes
+		}
+		return result.Artists[i].Name < result.Artists[j].Name
+	})
+
+	result.Samples = len(timed)
+	result.Available = true
+	return result
+}
+
+// readPlayingTrack returns what the first of musicPlayers is playing, then
+// what Now Playing reports for other apps such as Podcasts, Audible, and
+// Books, or an empty sample when nothing is playing
+func readPlayingTrack(ctx context.Context) musicSample {
+	for _, player := range musicPlayers {
+		output, err := exec.CommandContext(ctx, "osascript", "-e", fmt.Sprintf(`
+			tell application %q
+				if it is running then
+					if player state is playing then
+						return %s
+					end if
+				end if
+			end tell
+			return ""
+		`, player.App, player.Script)).Output()
+		if err != nil {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimSpace(string(output)), "|", 3)
+		if fields[0] == "" || len(fields) < 3 {
+			continue
+		}
+		return musicSample{Track: fields[0], Artist: fields[1], App: player.App, Kind: listenKind(player.App, fields[2])}
+	}
+
+	output, err := exec.CommandContext(ctx, "nowplaying-cli", "get", "title", "artist", "app", "playbackRate").Output()
+	if err != nil {
+		return musicSample{}
+	}
+	return parseNowPlayingSample(string(output))
+}
+
+// parseNowPlayingSample reads "nowplaying-cli get title artist app
+// playbackRate" output, one value per line with "null" for missing ones.
+// A zero playback rate means paused.
+func parseNowPlayingSample(output string) musicSample {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 4 {
+		return musicSample{}
+	}
+	value := func(i int) string {
+		v := strings.TrimSpace(lines[i])
+		if v == "null" {
+			return ""
+		}
+		return v
+	}
+	title, artist, app := value(0), value(1), value(2)
+	if title == "" || value(3) == "" || value(3) == "0" {
+		return musicSample{}
+	}
+	return musicSample{Track: title, Artist: artist, App: app, Kind: listenKind(app, "")}
+}
+
+// listenKind tells podcasts and audiobooks from music by the app playing
+// them, given by name or bundle ID, or for Spotify by the item's URL
+func listenKind(app, id string) string {
+	app = strings.ToLower(app)
+	switch {
+	case strings.Contains(app, "podcast"), strings.HasPrefix(id, "spotify:episode:"):
+		return listenPodcast
+	case strings.Contains(app, "audible"), app == "books", strings.Contains(app, "ibooks"):
+		return listenAudiobook
+	}
+	return listenMusic
+}
+
+func shouldRecordMusicSample(samples []musicSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadMusicSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadMusicSamples(date string) ([]musicSample, error) {
+	var samples []musicSample
+	if _, err := state.Load(state.KindMusic, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,107 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeMusicSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, minute int) string {
+		return time.Date(2026, 2, 18, hour, minute, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	w := Window{Start: time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local), End: time.Date(2026, 2, 18, 12, 0, 0, 0, time.Local)}
+
+	result := summarizeMusicSamples([]musicSample{
+		{Timestamp: at(9, 0), Track: "Blinding Lights", Artist: "The Weeknd", App: "Music"},
+		{Timestamp: at(9, 4), Track: "Blinding Lights", Artist: "The Weeknd", App: "Music"}, // Same track
+		{Timestamp: at(9, 8), Track: "Save Your Tears", Artist: "The Weeknd", App: "Music"},
+		{Timestamp: at(9, 12)}, // Paused
+		{Timestamp: at(9, 30), Track: "One More Time", Artist: "Daft Punk", App: "Spotify"},
+		{Timestamp: at(9, 33)},
+		{Timestamp: at(11, 50), Track: "One More Time", Artist: "Daft Punk", App: "Spotify"}, // Played again
+		{Timestamp: at(13, 0), Track: "Outside", Artist: "Nobody"},
+	}, w)
+	if !result.Available || result.Samples != 7 {
+		t.Fatalf("got %+v, want 7 samples available", result)
+	}
+	// 4 + 4 + 4 + 3, then 5 capped for the last
+	if result.Minutes != 20 || result.Tracks != 4 {
+		t.Errorf("got %d minutes, %d tracks, want 20 and 4", result.Minutes, result.Tracks)
+	}
+	want := []MusicArtist{{"The Weeknd", 12}, {"Daft Punk", 8}}
+	if len(result.Artists) != len(want) {
+		t.Fatalf("Artists = %+v, want %+v", result.Artists, want)
+	}
+	for i := range want {
+		if result.Artists[i] != want[i] {
+			t.Errorf("Artists[%d] = %+v, want %+v", i, result.Artists[i], want[i])
+		}
+	}
+
+	if empty := summarizeMusicSamples(nil, w); empty.Available || empty.Error == nil {
+		t.Errorf("no samples = %+v, want an error", empty)
+	}
+}
+
+func TestSummarizeMusicSamplesSpoken(t *testing.T) {
+	t.Parallel()
+	at := func(hour, minute int) string {
+		return time.Date(2026, 2, 18, hour, minute, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	w := Window{Start: time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local), End: time.Date(2026, 2, 18, 12, 0, 0, 0, time.Local)}
+
+	result := summarizeMusicSamples([]musicSample{
+		{Timestamp: at(8, 0), Track: "Episode 12", Artist: "Hard Fork", App: "Podcasts", Kind: listenPodcast},
+		{Timestamp: at(8, 5), Track: "Episode 12", Artist: "Hard Fork", App: "Podcasts", Kind: listenPodcast},
+		{Timestamp: at(8, 10), Track: "Chapter 3", Artist: "Project Hail Mary", App: "Audible", Kind: listenAudiobook},
+		{Timestamp: at(8, 14), Track: "Daily Stand-up", Artist: "The Daily", App: "Spotify", Kind: listenPodcast},
+		{Timestamp: at(8, 16), Track: "Get Lucky", Artist: "Daft Punk", App: "Spotify"},
+		{Timestamp: at(8, 19)},
+	}, w)
+	if result.PodcastMinutes != 12 || result.Episodes != 2 {
+		t.Errorf("podcasts = %dm, %d episodes, want 12m and 2", result.PodcastMinutes, result.Episodes)
+	}
+	if result.AudiobookMinutes != 4 {
+		t.Errorf("AudiobookMinutes = %d, want 4", result.AudiobookMinutes)
+	}
+	if result.Minutes != 3 || result.Tracks != 1 || len(result.Artists) != 1 || result.Artists[0].Name != "Daft Punk" {
+		t.Errorf("music = %dm, %d tracks, %+v; want only Daft Punk's 3m", result.Minutes, result.Tracks, result.Artists)
+	}
+}
+
+func TestParseNowPlayingSample(t *testing.T) {
+	t.Parallel()
+	got := parseNowPlayingSample("The Martian, Chapter 4\nAndy Weir\ncom.audible.iphone\n1\n")
+	if got.Track != "The Martian, Chapter 4" || got.Artist != "Andy Weir" || got.Kind != listenAudiobook {
+		t.Errorf("parseNowPlayingSample = %+v, want an audiobook by Andy Weir", got)
+	}
+	if got := parseNowPlayingSample("Episode 12\nnull\ncom.apple.podcasts\n1.5\n"); got.Kind != listenPodcast || got.Artist != "" {
+		t.Errorf("podcast = %+v, want a podcast with no artist", got)
+	}
+	if got := parseNowPlayingSample("Episode 12\nHard Fork\ncom.apple.podcasts\n0\n"); got.Track != "" {
+		t.Errorf("paused = %+v, want nothing playing", got)
+	}
+	if got := parseNowPlayingSample("null\nnull\nnull\nnull\n"); got.Track != "" {
+		t.Errorf("nothing = %+v, want nothing playing", got)
+	}
+}
+
+func TestListenKind(t *testing.T) {
+	t.Parallel()
+	tests := []struct{ app, id, want string }{
+		{"Music", "", listenMusic},
+		{"Spotify", "spotify:track:4uLU6hMCjMI75M1A2tKUQC", listenMusic},
+		{"Spotify", "spotify:episode:512ojhOuo1ktJprKbVcKyQ", listenPodcast},
+		{"Podcasts", "", listenPodcast},
+		{"com.apple.podcasts", "", listenPodcast},
+		{"Books", "", listenAudiobook},
+		{"com.apple.iBooksX", "", listenAudiobook},
+		{"Audible", "", listenAudiobook},
+	}
+	for _, tt := range tests {
+		if got := listenKind(tt.app, tt.id); got != tt.want {
+			t.Errorf("listenKind(%q, %q) = %q, want %q", tt.app, tt.id, got, tt.want)
+		}
+	}
+}
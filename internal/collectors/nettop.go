@@ -0,0 +1,170 @@
+package collectors
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// MaxAppTraffic caps the apps listed in NetworkResult.TopApps
+const MaxAppTraffic = 5
+
+// AppTraffic is the data one app transferred today
+type AppTraffic struct {
+	Name          string
+	BytesReceived int64
+	BytesSent     int64
+}
+
+// Bytes returns the data transferred in both directions
+func (a AppTraffic) Bytes() int64 {
+	return a.BytesReceived + a.BytesSent
+}
+
+// processTraffic is a process's byte counters as nettop reports them, keyed
+// by "name.pid"
+type processTraffic struct {
+	In  int64 `json:"in"`
+	Out int64 `json:"out"`
+}
+
+// appTrafficBaseline stores every process's counters at the first run of
+// the day
+type appTrafficBaseline struct {
+	Processes map[string]processTraffic `json:"processes"`
+	Timestamp string                    `json:"timestamp"`
+}
+
+// collectAppTraffic returns the apps that transferred the most today. nettop
+// counts each process's bytes since it started, so the first run of the day
+// saves every process's counters as a baseline and later runs subtract it.
+// Processes started after the baseline count in full. The boolean is true
+// when there was no baseline yet and the counts are since each process
+// started.
+func collectAppTraffic(ctx context.Context) ([]AppTraffic, bool, error) {
+	current, err := readNettop(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var baseline appTrafficBaseline
+	found, err := state.Load(state.KindAppNetwork, DayKey(time.Now()), &baseline)
+	if err != nil || !found {
+		baseline = appTrafficBaseline{Processes: current, Timestamp: time.Now().Format(time.RFC3339)}
+		_ = state.Save(state.KindAppNetwork, DayKey(time.Now()), baseline)
+		return topAppTraffic(current, nil), true, nil
+	}
+	return topAppTraffic(current, baseline.Processes), false, nil
+}
+
+// topAppTraffic subtracts baseline from current, totals the processes of
+// each app, and returns the MaxAppTraffic apps that transferred the most
+func topAppTraffic(current, baseline map[string]processTraffic) []AppTraffic {
+	byName := make(map[string]*AppTraffic)
+	for key, now := range current {
+		before := baseline[key]
+		in, out := now.In-before.In, now.Out-before.Out
+		if in < 0 || out < 0 {
+			// The pid was reused by a new process; all its traffic is new
+			in, out = now.In, now.Out
+		}
+		if in == 0 && out == 0 {
+			continue
+		}
+		name := processName(key)
+		a, ok := byName[name]
+		if !ok {
+			a = &AppTraffic{Name: name}
+			byName[name] = a
+		}
+		a.BytesReceived += in
+		a.BytesSent += out
+	}
+
+	apps := make([]AppTraffic, 0, len(byName))
+	for _, a := range byName {
+		apps = append(apps, *a)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].Bytes() != apps[j].Bytes() {
+			return apps[i].Bytes() > apps[j].Bytes()
+		}
+		return apps[i].Name < apps[j].Name
+	})
+	if len(apps) > MaxAppTraffic {
+		apps = apps[:MaxAppTraffic]
+	}
+	return apps
+}
+
+// processName strips the pid from nettop's "name.pid", so "Google Chrome
+// H.4412" is "Google Chrome H"
+func processName(key string) string {
+	if i := strings.LastIndex(key, "."); i > 0 {
+		if _, err := strconv.Atoi(key[i+1:]); err == nil {
+			return key[:i]
+		}
+	}
+	return key
+}
+
+// readNettop takes one nettop sample of every process's byte counters.
+// -L is -l's CSV form, which keeps process names with spaces in one field.
+func readNettop(ctx context.Context) (map[string]processTraffic, error) {
+	cmd := exec.CommandContext(ctx, "nettop", "-P", "-x", "-L", "1", "-J", "bytes_in,bytes_out")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("nettop failed: %w", err)
+	}
+	return parseNettop(string(output))
+}
+
+// parseNettop reads nettop's CSV output:
+//
+//	time,,bytes_in,bytes_out,
+//	14:03:12.511498,Google Chrome H.4412,1843200,204800,
+func parseNettop(output string) (map[string]processTraffic, error) {
+	r := csv.NewReader(strings.NewReader(output))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse nettop output: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("nettop printed nothing")
+	}
+
+	inIdx, outIdx := -1, -1
+	for i, field := range records[0] {
+		switch field {
+		case "bytes_in":
+			inIdx = i
+		case "bytes_out":
+			outIdx = i
+		}
+	}
+	if inIdx < 0 || outIdx < 0 {
+		return nil, fmt.Errorf("nettop output has no byte counters")
+	}
+
+	processes := make(map[string]processTraffic)
+	for _, rec := range records[1:] {
+		if len(rec) <= max(inIdx, outIdx) || rec[1] == "" {
+			continue
+		}
+		in, errIn := strconv.ParseInt(rec[inIdx], 10, 64)
+		out, errOut := strconv.ParseInt(rec[outIdx], 10, 64)
+		if errIn != nil || errOut != nil {
+			continue
+		}
+		processes[rec[1]] = processTraffic{In: in, Out: out}
+	}
+	return processes, nil
+}
@@ -2,26 +2,33 @@ package collectors
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
-	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/alexinslc/rekap/internal/compat"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/state"
 )
 
 // NetworkResult contains network usage information
 type NetworkResult struct {
-	InterfaceName string
-	NetworkName   string // WiFi SSID or "Ethernet"
-	BytesReceived int64
-	BytesSent     int64
-	SinceBoot     bool // true if stats are since boot (no baseline available)
-	Available     bool
-	Error         error
+	InterfaceName        string
+	NetworkName          string // WiFi SSID or "Ethernet"
+	BytesReceived        int64
+	BytesSent            int64
+	SinceBoot            bool         // true if stats are since boot (no baseline available)
+	Metered              bool         // true if the active connection is metered
+	MeteredReason        string       // "iPhone hotspot", "Low Data Mode", or "configured"
+	MeteredBytesReceived int64        // Bytes received today while on metered connections
+	MeteredBytesSent     int64        // Bytes sent today while on metered connections
+	TopApps              []AppTraffic // Apps that transferred the most today, with network.per_app
+	AppsSinceStart       bool         // true if TopApps counts since each process started (no baseline yet)
+	Available            bool
+	Error                error
 }
 
 // networkBaseline stores the first-of-day network stats for delta calculation
@@ -30,32 +37,70 @@ type networkBaseline struct {
 	BytesReceived int64  `json:"bytes_received"`
 	BytesSent     int64  `json:"bytes_sent"`
 	Timestamp     string `json:"timestamp"`
+
+	// Metered usage is accumulated between runs: traffic counts as metered
+	// when both the previous and the current run saw a metered connection.
+	MeteredActive       bool  `json:"metered_active,omitempty"`
+	MeteredLastReceived int64 `json:"metered_last_received,omitempty"`
+	MeteredLastSent     int64 `json:"metered_last_sent,omitempty"`
+	MeteredReceived     int64 `json:"metered_received,omitempty"`
+	MeteredSent         int64 `json:"metered_sent,omitempty"`
+}
+
+// activeInterface describes the interface carrying the default route
+type activeInterface struct {
+	Name    string // e.g. "en0"
+	Type    string // "WiFi", "Ethernet", "Bridge", or "VPN"
+	Port    string // Hardware port name, e.g. "Wi-Fi" or "iPhone USB"
+	Gateway string // Default gateway address
 }
 
-// CollectNetwork retrieves current network usage statistics
-func CollectNetwork(ctx context.Context) NetworkResult {
+// iPhoneHotspotGateway is the router address iOS Personal Hotspot hands out
+const iPhoneHotspotGateway = "172.20.10.1"
+
+// CollectNetwork retrieves current network usage statistics. Connections are
+// flagged as metered when they look like an iPhone hotspot, the Wi-Fi network
+// has Low Data Mode enabled, or the SSID is listed in meteredNetworks.
+// Interface counters only measure from today's baseline to now, so other
+// windows report the section unavailable. With perApp, nettop adds the apps
+// that transferred the most.
+func CollectNetwork(ctx context.Context, meteredNetworks []string, perApp bool, w Window) NetworkResult {
 	result := NetworkResult{Available: false}
+	if !w.WholeDay() || !w.Live() {
+		result.Error = fmt.Errorf("network usage is only tracked for today so far")
+		return result
+	}
 
 	// Get active network interface
-	iface, ifaceType, err := getActiveInterface(ctx)
+	active, err := getActiveInterface(ctx)
 	if err != nil {
 		result.Error = fmt.Errorf("failed to get active interface: %w", err)
 		return result
 	}
+	iface := active.Name
 
 	result.InterfaceName = iface
 
 	// Get WiFi SSID if on WiFi
-	if ifaceType == "WiFi" {
-		ssid, err := getWiFiSSID(ctx, iface)
+	ssid := ""
+	if active.Type == "WiFi" {
+		ssid, err = getWiFiSSID(ctx, iface)
 		if err == nil && ssid != "" {
 			result.NetworkName = ssid
 		} else {
+			ssid = ""
 			result.NetworkName = "WiFi"
 		}
 	} else {
-		result.NetworkName = ifaceType
+		result.NetworkName = active.Type
+	}
+
+	lowData := false
+	if ssid != "" {
+		lowData = isLowDataModeNetwork(ctx, ssid)
 	}
+	result.MeteredReason = meteredReason(active, ssid, meteredNetworks, lowData)
+	result.Metered = result.MeteredReason != ""
 
 	// Get network statistics for the interface
 	bytesRecv, bytesSent, err := getInterfaceStats(ctx, iface)
@@ -66,194 +111,273 @@ func CollectNetwork(ctx context.Context) NetworkResult {
 
 	result.Available = true
 
+	if perApp {
+		// A failed breakdown still leaves the interface totals
+		result.TopApps, result.AppsSinceStart, _ = collectAppTraffic(ctx)
+	}
+
 	// Try to compute today-only delta from baseline
 	baseline, err := loadNetworkBaseline()
-	if err != nil || baseline.Interface != iface {
-		// No baseline or different interface -- save current as baseline, show since-boot
-		_ = saveNetworkBaseline(iface, bytesRecv, bytesSent)
-		result.BytesReceived = bytesRecv
-		result.BytesSent = bytesSent
-		result.SinceBoot = true
-		return result
+	if err != nil {
+		baseline = networkBaseline{}
 	}
 
 	// Compute delta. If current < baseline, counters reset (reboot) -- use current as-is
 	recvDelta := bytesRecv - baseline.BytesReceived
 	sentDelta := bytesSent - baseline.BytesSent
-	if recvDelta < 0 || sentDelta < 0 {
-		// Counter reset (reboot). Save new baseline, show current values.
-		_ = saveNetworkBaseline(iface, bytesRecv, bytesSent)
+	if err != nil || baseline.Interface != iface || recvDelta < 0 || sentDelta < 0 {
+		// No baseline, different interface, or counter reset -- save current
+		// as baseline and show since-boot. Metered totals from earlier today
+		// carry over; any open metered session restarts from here.
+		fresh := networkBaseline{
+			Interface:       iface,
+			BytesReceived:   bytesRecv,
+			BytesSent:       bytesSent,
+			MeteredReceived: baseline.MeteredReceived,
+			MeteredSent:     baseline.MeteredSent,
+		}
+		trackMeteredUsage(&fresh, result.Metered, bytesRecv, bytesSent)
+		_ = saveNetworkBaseline(fresh)
 		result.BytesReceived = bytesRecv
 		result.BytesSent = bytesSent
 		result.SinceBoot = true
+		result.MeteredBytesReceived = fresh.MeteredReceived
+		result.MeteredBytesSent = fresh.MeteredSent
 		return result
 	}
 
+	wasMetered := baseline.MeteredActive
+	trackMeteredUsage(&baseline, result.Metered, bytesRecv, bytesSent)
+	if result.Metered || wasMetered {
+		_ = saveNetworkBaseline(baseline)
+	}
+
 	result.BytesReceived = recvDelta
 	result.BytesSent = sentDelta
 	result.SinceBoot = false
+	result.MeteredBytesReceived = baseline.MeteredReceived
+	result.MeteredBytesSent = baseline.MeteredSent
 
 	return result
 }
 
-func baselinePath() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return ""
+// trackMeteredUsage folds the traffic since the previous run into b's metered
+// totals when the connection was metered on both runs, then records the
+// current counters as the start of the next interval.
+func trackMeteredUsage(b *networkBaseline, metered bool, bytesRecv, bytesSent int64) {
+	if metered && b.MeteredActive && bytesRecv >= b.MeteredLastReceived && bytesSent >= b.MeteredLastSent {
+		b.MeteredReceived += bytesRecv - b.MeteredLastReceived
+		b.MeteredSent += bytesSent - b.MeteredLastSent
 	}
-	date := time.Now().Format("2006-01-02")
-	return filepath.Join(homeDir, ".local", "share", "rekap", fmt.Sprintf("network-%s.json", date))
-}
 
-func loadNetworkBaseline() (networkBaseline, error) {
-	path := baselinePath()
-	if path == "" {
-		return networkBaseline{}, fmt.Errorf("no home directory")
+	b.MeteredActive = metered
+	if metered {
+		b.MeteredLastReceived = bytesRecv
+		b.MeteredLastSent = bytesSent
+	} else {
+		b.MeteredLastReceived = 0
+		b.MeteredLastSent = 0
 	}
+}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return networkBaseline{}, err
+// meteredReason returns why the active connection is metered, or "" if it is not
+func meteredReason(active activeInterface, ssid string, meteredNetworks []string, lowData bool) string {
+	if active.Port == "iPhone USB" || active.Gateway == iPhoneHotspotGateway {
+		return "iPhone hotspot"
 	}
-
-	var b networkBaseline
-	if err := json.Unmarshal(data, &b); err != nil {
-		return networkBaseline{}, err
+	if lowData {
+		return "Low Data Mode"
 	}
-	return b, nil
+	if ssid != "" {
+		for _, name := range meteredNetworks {
+			if strings.EqualFold(name, ssid) {
+				return "configured"
+			}
+		}
+	}
+	return ""
 }
 
-func saveNetworkBaseline(iface string, bytesRecv, bytesSent int64) error {
-	path := baselinePath()
-	if path == "" {
-		return fmt.Errorf("no home directory")
+// isLowDataModeNetwork reports whether Low Data Mode is enabled for the given
+// Wi-Fi network. The known-networks plist may not be readable without extra
+// permissions, in which case the network is assumed unmetered.
+func isLowDataModeNetwork(ctx context.Context, ssid string) bool {
+	cmd := exec.CommandContext(ctx, "defaults", "read", "/Library/Preferences/com.apple.wifi.known-networks")
+	output, err := cmd.Output()
+	if err != nil {
+		return false
 	}
+	return parseKnownNetworksLowData(string(output), ssid)
+}
 
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
+// parseKnownNetworksLowData scans `defaults read` output of the known-networks
+// plist for the SSID's entry and reports whether it has LowDataMode set.
+func parseKnownNetworksLowData(output, ssid string) bool {
+	key := "wifi.network.ssid." + ssid
+	depth := 0
+	inEntry := false
+	entryDepth := 0
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if !inEntry && strings.Contains(trimmed, "=") {
+			name := strings.Trim(strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]), `"`)
+			if name == key {
+				inEntry = true
+				entryDepth = depth + 1
+			}
+		}
 
-	b := networkBaseline{
-		Interface:     iface,
-		BytesReceived: bytesRecv,
-		BytesSent:     bytesSent,
-		Timestamp:     time.Now().Format(time.RFC3339),
-	}
+		if inEntry && depth >= entryDepth {
+			compact := strings.ReplaceAll(trimmed, " ", "")
+			if compact == "LowDataMode=1;" {
+				return true
+			}
+		}
 
-	data, err := json.Marshal(b)
-	if err != nil {
-		return err
+		depth += strings.Count(trimmed, "{") - strings.Count(trimmed, "}")
+		if inEntry && depth < entryDepth {
+			return false
+		}
 	}
+	return false
+}
 
-	// Atomic write: write to temp file, then rename into place
-	tmpFile, err := os.CreateTemp(dir, "network-baseline-*.tmp")
+func loadNetworkBaseline() (networkBaseline, error) {
+	var b networkBaseline
+	found, err := state.Load(state.KindNetwork, DayKey(time.Now()), &b)
 	if err != nil {
-		return err
-	}
-	tmpName := tmpFile.Name()
-
-	if _, err := tmpFile.Write(data); err != nil {
-		tmpFile.Close()
-		os.Remove(tmpName)
-		return err
-	}
-	if err := tmpFile.Close(); err != nil {
-		os.Remove(tmpName)
-		return err
+		return networkBaseline{}, err
 	}
-	if err := os.Rename(tmpName, path); err != nil {
-		os.Remove(tmpName)
-		return err
+	if !found {
+		return networkBaseline{}, fmt.Errorf("no baseline for today")
 	}
-
-	// Clean up old baseline files (older than 7 days)
-	cleanOldBaselines(dir)
-
-	return nil
+	return b, nil
 }
 
-func cleanOldBaselines(dir string) {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		return
-	}
-	cutoff := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
-	for _, e := range entries {
-		name := e.Name()
-		if strings.HasPrefix(name, "network-") && strings.HasSuffix(name, ".json") {
-			// Extract date from "network-YYYY-MM-DD.json"
-			date := strings.TrimPrefix(name, "network-")
-			date = strings.TrimSuffix(date, ".json")
-			if len(date) == 10 && date < cutoff {
-				os.Remove(filepath.Join(dir, name))
-			}
-		}
-	}
+func saveNetworkBaseline(b networkBaseline) error {
+	b.Timestamp = time.Now().Format(time.RFC3339)
+	return state.Save(state.KindNetwork, DayKey(time.Now()), b)
 }
 
-// getActiveInterface returns the active network interface name and type
-func getActiveInterface(ctx context.Context) (string, string, error) {
+// getActiveInterface returns the interface carrying the default route
+func getActiveInterface(ctx context.Context) (activeInterface, error) {
 	// Use route get to find the interface for default route
 	cmd := exec.CommandContext(ctx, "route", "-n", "get", "default")
 	output, err := cmd.Output()
 	if err != nil {
-		return "", "", fmt.Errorf("route command failed: %w", err)
+		return activeInterface{}, fmt.Errorf("route command failed: %w", err)
 	}
 
-	// Parse output to find interface
-	re := regexp.MustCompile(`interface:\s*(\w+)`)
-	matches := re.FindStringSubmatch(string(output))
-	if len(matches) < 2 {
-		return "", "", fmt.Errorf("failed to parse interface from route output")
+	active, err := parseRouteOutput(string(output))
+	if err != nil {
+		return activeInterface{}, err
 	}
-
-	iface := matches[1]
+	iface := active.Name
 
 	// Determine interface type based on name
-	ifaceType := "Ethernet"
+	active.Type = "Ethernet"
 	if strings.HasPrefix(iface, "en") {
 		cmd := exec.CommandContext(ctx, "networksetup", "-listallhardwareports")
 		output, err := cmd.Output()
 		if err == nil {
-			if strings.Contains(string(output), "Wi-Fi") && strings.Contains(string(output), iface) {
-				ifaceType = "WiFi"
+			active.Port = parseHardwarePort(string(output), iface)
+			if active.Port == "Wi-Fi" {
+				active.Type = "WiFi"
 			}
 		}
 	} else if strings.HasPrefix(iface, "bridge") {
-		ifaceType = "Bridge"
+		active.Type = "Bridge"
 	} else if strings.HasPrefix(iface, "utun") || strings.HasPrefix(iface, "ipsec") {
-		ifaceType = "VPN"
+		active.Type = "VPN"
 	}
 
-	return iface, ifaceType, nil
+	return active, nil
 }
 
-// getWiFiSSID returns the current WiFi SSID for the given interface
+var (
+	routeInterfaceRe = regexp.MustCompile(`interface:\s*(\w+)`)
+	routeGatewayRe   = regexp.MustCompile(`gateway:\s*(\S+)`)
+)
+
+// parseRouteOutput extracts the interface and gateway from `route -n get default`
+func parseRouteOutput(output string) (activeInterface, error) {
+	matches := routeInterfaceRe.FindStringSubmatch(output)
+	if len(matches) < 2 {
+		return activeInterface{}, fmt.Errorf("failed to parse interface from route output")
+	}
+
+	active := activeInterface{Name: matches[1]}
+	if m := routeGatewayRe.FindStringSubmatch(output); len(m) >= 2 {
+		active.Gateway = m[1]
+	}
+	return active, nil
+}
+
+// parseHardwarePort returns the hardware port name for a device from
+// `networksetup -listallhardwareports` output, or "" if not listed
+func parseHardwarePort(output, iface string) string {
+	port := ""
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(line, "Hardware Port:"); ok {
+			port = strings.TrimSpace(name)
+		} else if device, ok := strings.CutPrefix(line, "Device:"); ok {
+			if strings.TrimSpace(device) == iface {
+				return port
+			}
+		}
+	}
+	return ""
+}
+
+var airportSSIDRe = regexp.MustCompile(`(?m)^\s*SSID:\s*(.+)`)
+
+// getWiFiSSID returns the current WiFi SSID for the given interface, with
+// the tools compat picks for this macOS version
 func getWiFiSSID(ctx context.Context, iface string) (string, error) {
-	airportPath := "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
-	cmd := exec.CommandContext(ctx, airportPath, "-I")
-	output, err := cmd.Output()
-	if err != nil {
-		cmd = exec.CommandContext(ctx, "networksetup", "-getairportnetwork", iface)
-		output, err = cmd.Output()
-		if err != nil {
-			return "", err
+	for _, tool := range compat.WiFiNameTools(compat.Current()) {
+		var ssid string
+		switch tool {
+		case compat.ToolAirport:
+			if output, err := exec.CommandContext(ctx, airportPath, "-I").Output(); err == nil {
+				if m := airportSSIDRe.FindStringSubmatch(string(output)); len(m) >= 2 {
+					ssid = strings.TrimSpace(m[1])
+				}
+			}
+		case compat.ToolNetworksetup:
+			// "Current Wi-Fi Network: Home-5GHz"
+			if output, err := exec.CommandContext(ctx, "networksetup", "-getairportnetwork", iface).Output(); err == nil {
+				if _, name, ok := strings.Cut(string(output), "Network: "); ok {
+					ssid = strings.TrimSpace(name)
+				}
+			}
+		case compat.ToolSystemProfiler:
+			if output, err := exec.CommandContext(ctx, "system_profiler", "SPAirPortDataType").Output(); err == nil {
+				ssid = parseSystemProfilerSSID(string(output))
+			}
 		}
-		parts := strings.Split(string(output), ":")
-		if len(parts) >= 2 {
-			return strings.TrimSpace(parts[1]), nil
+		if ssid != "" {
+			return ssid, nil
 		}
-		return "", fmt.Errorf("failed to parse SSID")
 	}
+	return "", fmt.Errorf("SSID not found")
+}
 
-	re := regexp.MustCompile(`\s*SSID:\s*(.+)`)
-	matches := re.FindStringSubmatch(string(output))
-	if len(matches) >= 2 {
-		return strings.TrimSpace(matches[1]), nil
+// parseSystemProfilerSSID returns the network name under "Current Network
+// Information:" in `system_profiler SPAirPortDataType` output, or ""
+func parseSystemProfilerSSID(output string) string {
+	_, rest, ok := strings.Cut(output, "Current Network Information:")
+	if !ok {
+		return ""
 	}
-
-	return "", fmt.Errorf("SSID not found in airport output")
+	for line := range strings.SplitSeq(rest, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return strings.TrimSuffix(line, ":")
+		}
+	}
+	return ""
 }
 
 // getInterfaceStats returns bytes received and sent for an interface
@@ -343,5 +467,5 @@ func FormatBytes(bytes int64) string {
 	if exp >= len(units) {
 		exp = len(units) - 1
 	}
-	return fmt.Sprintf("%.1f %s", float64(bytes)/float64(div), units[exp])
+	return locale.Current().FormatFloat(float64(bytes)/float64(div), 1) + " " + units[exp]
 }
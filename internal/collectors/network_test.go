@@ -6,13 +6,18 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
 )
 
 func TestBaselineRoundTrip(t *testing.T) {
-	// Use a temp dir to avoid polluting the real baseline directory
-	tmpDir := t.TempDir()
+	// Use a temp database to avoid polluting the real state store
+	store, err := state.OpenPath(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("OpenPath: %v", err)
+	}
+	defer store.Close()
 	date := time.Now().Format("2006-01-02")
-	path := filepath.Join(tmpDir, "network-"+date+".json")
 
 	b := networkBaseline{
 		Interface:     "en0",
@@ -21,23 +26,14 @@ func TestBaselineRoundTrip(t *testing.T) {
 		Timestamp:     time.Now().Format(time.RFC3339),
 	}
 
-	data, err := json.Marshal(b)
-	if err != nil {
-		t.Fatalf("failed to marshal: %v", err)
-	}
-
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		t.Fatalf("failed to write: %v", err)
-	}
-
-	readData, err := os.ReadFile(path)
-	if err != nil {
-		t.Fatalf("failed to read: %v", err)
+	if err := store.Put(state.KindNetwork, date, b); err != nil {
+		t.Fatalf("failed to save: %v", err)
 	}
 
 	var loaded networkBaseline
-	if err := json.Unmarshal(readData, &loaded); err != nil {
-		t.Fatalf("failed to unmarshal: %v", err)
+	found, err := store.Get(state.KindNetwork, date, &loaded)
+	if err != nil || !found {
+		t.Fatalf("failed to load: found=%v err=%v", found, err)
 	}
 
 	if loaded.Interface != "en0" {
@@ -71,34 +67,194 @@ func TestBaselineCorruptedJSON(t *testing.T) {
 	}
 }
 
-func TestCleanOldBaselines(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestParseRouteOutput(t *testing.T) {
+	t.Parallel()
+	output := `   route to: default
+destination: default
+       mask: default
+    gateway: 172.20.10.1
+  interface: en0
+      flags: <UP,GATEWAY,DONE,STATIC,PRCLONING,GLOBAL>
+`
+	active, err := parseRouteOutput(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if active.Name != "en0" || active.Gateway != "172.20.10.1" {
+		t.Errorf("got %+v, want Name=en0 Gateway=172.20.10.1", active)
+	}
 
-	// Create files with old and recent dates
-	oldFile := filepath.Join(tmpDir, "network-2020-01-01.json")
-	recentFile := filepath.Join(tmpDir, "network-"+time.Now().Format("2006-01-02")+".json")
-	unrelatedFile := filepath.Join(tmpDir, "other-file.txt")
+	if _, err := parseRouteOutput("route: writing to routing socket: not in table\n"); err == nil {
+		t.Error("expected error when no interface is present")
+	}
+}
 
-	for _, f := range []string{oldFile, recentFile, unrelatedFile} {
-		if err := os.WriteFile(f, []byte("{}"), 0644); err != nil {
-			t.Fatalf("failed to create %s: %v", f, err)
+func TestParseHardwarePort(t *testing.T) {
+	t.Parallel()
+	output := `
+Hardware Port: Ethernet Adapter (en4)
+Device: en4
+Ethernet Address: 00:00:00:00:00:01
+
+Hardware Port: Wi-Fi
+Device: en0
+Ethernet Address: 00:00:00:00:00:02
+
+Hardware Port: iPhone USB
+Device: en8
+Ethernet Address: 00:00:00:00:00:03
+`
+	tests := map[string]string{
+		"en0": "Wi-Fi",
+		"en8": "iPhone USB",
+		"en4": "Ethernet Adapter (en4)",
+		"en9": "",
+	}
+	for iface, want := range tests {
+		if got := parseHardwarePort(output, iface); got != want {
+			t.Errorf("parseHardwarePort(%q) = %q, want %q", iface, got, want)
 		}
 	}
+}
 
-	cleanOldBaselines(tmpDir)
+func TestParseSystemProfilerSSID(t *testing.T) {
+	t.Parallel()
+	output := `Wi-Fi:
 
-	// Old file should be deleted
-	if _, err := os.Stat(oldFile); !os.IsNotExist(err) {
-		t.Error("old baseline file should have been deleted")
+      Interfaces:
+        en0:
+          Status: Connected
+          Current Network Information:
+            Home-5GHz:
+              PHY Mode: 802.11ax
+              Signal / Noise: -52 dBm / -94 dBm
+`
+	if got := parseSystemProfilerSSID(output); got != "Home-5GHz" {
+		t.Errorf("parseSystemProfilerSSID = %q, want Home-5GHz", got)
+	}
+	if got := parseSystemProfilerSSID("Wi-Fi:\n  Status: Off\n"); got != "" {
+		t.Errorf("parseSystemProfilerSSID when disconnected = %q, want empty", got)
 	}
+}
 
-	// Recent file should still exist
-	if _, err := os.Stat(recentFile); err != nil {
-		t.Error("recent baseline file should still exist")
+func TestMeteredReason(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		active  activeInterface
+		ssid    string
+		lowData bool
+		want    string
+	}{
+		{"home wifi", activeInterface{Port: "Wi-Fi", Gateway: "192.168.1.1"}, "Home", false, ""},
+		{"hotspot over wifi", activeInterface{Port: "Wi-Fi", Gateway: "172.20.10.1"}, "Alex's iPhone", false, "iPhone hotspot"},
+		{"usb tethering", activeInterface{Port: "iPhone USB", Gateway: "172.20.10.1"}, "", false, "iPhone hotspot"},
+		{"low data mode", activeInterface{Port: "Wi-Fi", Gateway: "10.0.0.1"}, "Home", true, "Low Data Mode"},
+		{"configured", activeInterface{Port: "Wi-Fi", Gateway: "10.0.0.1"}, "cafe-guest", false, "configured"},
 	}
+	for _, tt := range tests {
+		if got := meteredReason(tt.active, tt.ssid, []string{"Cafe-Guest"}, tt.lowData); got != tt.want {
+			t.Errorf("%s: meteredReason() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
 
-	// Unrelated file should still exist
-	if _, err := os.Stat(unrelatedFile); err != nil {
-		t.Error("unrelated file should still exist")
+func TestParseKnownNetworksLowData(t *testing.T) {
+	t.Parallel()
+	output := `{
+    "wifi.network.ssid.Home" =     {
+        AddedAt = "2025-01-01 00:00:00 +0000";
+        JoinedBySystemAt = "2026-02-18 08:00:00 +0000";
+        SSID = {length = 4, bytes = 0x486f6d65};
+    };
+    "wifi.network.ssid.Travel Router" =     {
+        AddedAt = "2025-06-01 00:00:00 +0000";
+        LowDataMode = 1;
+        SSID = {length = 13, bytes = 0x54726176656c20526f75746572};
+    };
+}
+`
+	if parseKnownNetworksLowData(output, "Home") {
+		t.Error("expected Home to not be in Low Data Mode")
+	}
+	if !parseKnownNetworksLowData(output, "Travel Router") {
+		t.Error("expected Travel Router to be in Low Data Mode")
+	}
+	if parseKnownNetworksLowData(output, "Unknown") {
+		t.Error("expected unknown network to not be in Low Data Mode")
+	}
+}
+
+func TestTrackMeteredUsage(t *testing.T) {
+	t.Parallel()
+	var b networkBaseline
+
+	// First metered reading only starts the interval
+	trackMeteredUsage(&b, true, 1000, 100)
+	if b.MeteredReceived != 0 || !b.MeteredActive {
+		t.Fatalf("after start: got %+v", b)
+	}
+
+	// Second metered reading accumulates the traffic in between
+	trackMeteredUsage(&b, true, 5000, 300)
+	if b.MeteredReceived != 4000 || b.MeteredSent != 200 {
+		t.Errorf("after metered interval: received=%d sent=%d, want 4000/200", b.MeteredReceived, b.MeteredSent)
+	}
+
+	// Leaving the metered network stops counting but keeps the totals
+	trackMeteredUsage(&b, false, 9000, 900)
+	if b.MeteredActive || b.MeteredReceived != 4000 {
+		t.Errorf("after leaving: got %+v", b)
+	}
+
+	// Rejoining starts a new interval without counting the unmetered gap
+	trackMeteredUsage(&b, true, 10000, 1000)
+	trackMeteredUsage(&b, true, 10500, 1100)
+	if b.MeteredReceived != 4500 || b.MeteredSent != 300 {
+		t.Errorf("after rejoin: received=%d sent=%d, want 4500/300", b.MeteredReceived, b.MeteredSent)
+	}
+}
+
+func TestParseNettop(t *testing.T) {
+	t.Parallel()
+	out := "time,,bytes_in,bytes_out,\n" +
+		"14:03:12.511498,Google Chrome H.4412,1843200,204800,\n" +
+		"14:03:12.511530,Google Chrome H.4415,100,50,\n" +
+		"14:03:12.511601,Dropbox.812,9000000,1000,\n" +
+		"14:03:12.511700,zoom.us.990,300,300,\n" +
+		"14:03:12.511800,broken.1,x,1,\n"
+
+	got, err := parseNettop(out)
+	if err != nil {
+		t.Fatalf("parseNettop: %v", err)
+	}
+	if len(got) != 4 || got["Dropbox.812"] != (processTraffic{In: 9000000, Out: 1000}) {
+		t.Errorf("parseNettop = %+v", got)
+	}
+	if _, err := parseNettop("time,,packets_in,\n"); err == nil {
+		t.Error("parseNettop accepted output without byte counters")
+	}
+
+	// Chrome's helpers add up; Dropbox had moved most of its data before the
+	// baseline; zoom.us keeps the dots in its name
+	baseline := map[string]processTraffic{
+		"Dropbox.812": {In: 8000000, Out: 1000},
+		"zoom.us.990": {In: 300, Out: 300},
+	}
+	apps := topAppTraffic(got, baseline)
+	want := []AppTraffic{
+		{Name: "Google Chrome H", BytesReceived: 1843300, BytesSent: 204850},
+		{Name: "Dropbox", BytesReceived: 1000000, BytesSent: 0},
+	}
+	if len(apps) != len(want) {
+		t.Fatalf("topAppTraffic = %+v, want %+v", apps, want)
+	}
+	for i := range want {
+		if apps[i] != want[i] {
+			t.Errorf("app %d = %+v, want %+v", i, apps[i], want[i])
+		}
+	}
+	if name := processName("zoom.us.990"); name != "zoom.us" {
+		t.Errorf("processName = %q, want zoom.us", name)
 	}
 }
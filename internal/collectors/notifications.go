@@ -16,12 +16,28 @@ type NotificationApp struct {
 type NotificationsResult struct {
 	TotalNotifications int
 	TopApps            []NotificationApp
+	AfterHours         int // Notifications outside schedule.work_hours; 0 when work hours aren't set
 	Available          bool
 	Error              error
 }
 
-// CollectNotifications retrieves notification counts from Screen Time database
-func CollectNotifications(ctx context.Context) NotificationsResult {
+// CollectNotifications retrieves notification counts in w from the Screen
+// Time database, leaving out excludedApps
+func CollectNotifications(ctx context.Context, excludedApps []string, w Window) NotificationsResult {
+	startTimestamp, endTimestamp := timestampRange(w)
+	result := collectNotifications(ctx, excludedApps, startTimestamp, endTimestamp)
+	if h := workHours.Load(); h != nil && result.Available {
+		// Whatever didn't arrive during a shift arrived after hours
+		result.AfterHours = result.TotalNotifications
+		for _, p := range h.periods(w) {
+			start, end := timestampRange(Window(p))
+			result.AfterHours -= collectNotifications(ctx, excludedApps, start, end).TotalNotifications
+		}
+	}
+	return result
+}
+
+func collectNotifications(ctx context.Context, excludedApps []string, startTimestamp, endTimestamp float64) NotificationsResult {
 	result := NotificationsResult{Available: false}
 
 	db, err := openKnowledgeDB()
@@ -35,8 +51,6 @@ func CollectNotifications(ctx context.Context) NotificationsResult {
 		}
 	}()
 
-	startTimestamp, endTimestamp := todayTimestampRange()
-
 	// Query for notification events
 	// ZSTREAMNAME = '/notification/usage' contains notification events
 	// ZVALUESTRING contains event types like 'Receive', 'DefaultAction', etc.
@@ -77,11 +91,16 @@ func CollectNotifications(ctx context.Context) NotificationsResult {
 			continue
 		}
 
-		totalCount += count
-
 		// Resolve bundle ID to app name
 		appName := resolveAppName(bundleID)
 
+		// Skip if app is in exclusion list
+		if isExcluded(appName, bundleID, excludedApps) {
+			continue
+		}
+
+		totalCount += count
+
 		apps = append(apps, NotificationApp{
 			Name:     appName,
 			Count:    count,
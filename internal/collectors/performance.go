@@ -0,0 +1,60 @@
+package collectors
+
+import "sync/atomic"
+
+// FastHistoryRows is how many of the most recent visits each browser
+// history query reads in fast mode
+const FastHistoryRows = 50000
+
+var fastMode atomic.Bool
+
+// SetFast turns fast mode on or off for all collectors. Fast mode trades
+// detail for speed on machines with years of browser history and hundreds
+// of tabs: history queries read only the most recent FastHistoryRows
+// visits, open tabs are counted without reading their titles or URLs, and
+// app names not resolved yet come from bundle IDs instead of Finder.
+func SetFast(on bool) {
+	fastMode.Store(on)
+}
+
+// DefaultMemoryMB is the memory budget for browser history counters when
+// none is set
+const DefaultMemoryMB = 64
+
+// counterEntryBytes is a rough size of one counted URL or domain: the
+// string, its map slot, and its heap entry
+const counterEntryBytes = 256
+
+// historyCounters is how many counters share the budget: a URL and a domain
+// counter for each of the four browsers, plus the two that merge them
+const historyCounters = 10
+
+var memoryMB atomic.Int64
+
+// SetMemoryBudget sets the memory, in megabytes, that browser history
+// counters may use together. Each counter tracks a share of the budget's
+// worth of URLs or domains; past that, the least-visited ones give way, so
+// top domains stay right while a long tail of one-off URLs is dropped. Zero
+// or less means DefaultMemoryMB.
+func SetMemoryBudget(mb int) {
+	memoryMB.Store(int64(mb))
+}
+
+// counterCapacity is how many keys each history counter tracks under the
+// memory budget
+func counterCapacity() int {
+	mb := memoryMB.Load()
+	if mb <= 0 {
+		mb = DefaultMemoryMB
+	}
+	return int(mb << 20 / counterEntryBytes / historyCounters)
+}
+
+// historyRowLimit is the LIMIT for history queries: FastHistoryRows in fast
+// mode, otherwise -1, which SQLite treats as no limit
+func historyRowLimit() int {
+	if fastMode.Load() {
+		return FastHistoryRows
+	}
+	return -1
+}
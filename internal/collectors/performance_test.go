@@ -0,0 +1,32 @@
+package collectors
+
+import "testing"
+
+// Not parallel: fast mode is process-wide, and parallel tests only start
+// once the sequential ones are done
+func TestFastMode(t *testing.T) {
+	if got := historyRowLimit(); got != -1 {
+		t.Errorf("historyRowLimit() = %d, want -1 (no limit)", got)
+	}
+
+	SetFast(true)
+	defer SetFast(false)
+
+	if got := historyRowLimit(); got != FastHistoryRows {
+		t.Errorf("fast historyRowLimit() = %d, want %d", got, FastHistoryRows)
+	}
+
+	// Uncached names come from the bundle ID and stay uncached
+	if got := resolveAppName("com.example.FastModeApp"); got != "FastModeApp" {
+		t.Errorf("resolveAppName = %q, want FastModeApp", got)
+	}
+	if _, ok := appNameCache.Load("com.example.FastModeApp"); ok {
+		t.Error("fast mode cached a guessed name")
+	}
+
+	appNameCache.Store("com.example.Cached", "Cached App")
+	defer appNameCache.Delete("com.example.Cached")
+	if got := resolveAppName("com.example.Cached"); got != "Cached App" {
+		t.Errorf("resolveAppName = %q, want the cached name", got)
+	}
+}
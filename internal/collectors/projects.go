@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+// ProjectTime is the time attributed to a single project
+type ProjectTime struct {
+	Name           string
+	Minutes        int // AppMinutes + BrowserMinutes + WindowMinutes
+	AppMinutes     int // Time in apps mapped to the project
+	BrowserMinutes int // Share of browser time, split by matching page visits
+	WindowMinutes  int // Share of sampled apps' time, split by matching window titles
+}
+
+// ProjectsResult contains the per-project time breakdown for timesheets
+type ProjectsResult struct {
+	Projects            []ProjectTime // Most time first
+	TotalMinutes        int           // All tracked app time
+	UnattributedMinutes int           // Tracked time that matched no project
+	Available           bool
+	Error               error
+}
+
+// browserBundlePrefixes identifies browser apps whose time is split by page visits
+var browserBundlePrefixes = []string{
+	"com.google.Chrome",
+	"com.apple.Safari",
+	"com.microsoft.edgemac",
+	"org.mozilla.firefox",
+}
+
+func isBrowserApp(bundleID string) bool {
+	for _, prefix := range browserBundlePrefixes {
+		if strings.HasPrefix(bundleID, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculateProjects attributes today's app time to the projects in cfg.
+// Apps mapped to a project count in full. Time in browsers that aren't
+// themselves mapped is split across projects in proportion to today's page
+// visits whose domain, URL, or issue key matches each project. Time in
+// other apps with sampled window titles is split the same way, by the
+// sampled time in windows whose titles match each project.
+func CalculateProjects(apps AppsResult, browsers BrowsersResult, windows WindowTitlesResult, cfg *config.Config) ProjectsResult {
+	result := ProjectsResult{Available: false}
+	if cfg == nil || len(cfg.Projects) == 0 || !apps.Available {
+		return result
+	}
+
+	byName := make(map[string]*ProjectTime)
+	project := func(name string) *ProjectTime {
+		p, ok := byName[name]
+		if !ok {
+			p = &ProjectTime{Name: name}
+			byName[name] = p
+		}
+		return p
+	}
+
+	browserMinutes := 0
+	for _, app := range apps.TopApps {
+		result.TotalMinutes += app.Minutes
+		if name := cfg.ProjectForApp(app.Name, app.BundleID); name != "" {
+			project(name).AppMinutes += app.Minutes
+		} else if isBrowserApp(app.BundleID) {
+			browserMinutes += app.Minutes
+		} else if windows.Available {
+			for name, minutes := range splitByWindowTitle(app, windows.Titles, cfg) {
+				project(name).WindowMinutes += minutes
+			}
+		}
+	}
+
+	if browserMinutes > 0 {
+		visits := make(map[string]int)
+		totalVisits := 0
+		for url, count := range browsers.URLVisits {
+			totalVisits += count
+			issueID := ""
+			if isIssueURL(url) {
+				issueID = extractIssueIdentifier(url)
+			}
+			if name := cfg.ProjectForURL(url, issueID); name != "" {
+				visits[name] += count
+			}
+		}
+		for name, count := range visits {
+			project(name).BrowserMinutes += browserMinutes * count / totalVisits
+		}
+	}
+
+	attributed := 0
+	for _, p := range byName {
+		p.Minutes = p.AppMinutes + p.BrowserMinutes + p.WindowMinutes
+		if p.Minutes == 0 {
+			continue
+		}
+		attributed += p.Minutes
+		result.Projects = append(result.Projects, *p)
+	}
+	sort.Slice(result.Projects, func(i, j int) bool {
+		if result.Projects[i].Minutes != result.Projects[j].Minutes {
+			return result.Projects[i].Minutes > result.Projects[j].Minutes
+		}
+		return result.Projects[i].Name < result.Projects[j].Name
+	})
+
+	result.UnattributedMinutes = result.TotalMinutes - attributed
+	result.Available = true
+	return result
+}
+
+// splitByWindowTitle splits app's time across projects in proportion to the
+// sampled time in its windows whose titles match each project
+func splitByWindowTitle(app AppUsage, titles []WindowTitle, cfg *config.Config) map[string]int {
+	sampled := 0
+	matched := make(map[string]int)
+	for _, t := range titles {
+		if t.App != app.Name && (t.BundleID == "" || t.BundleID != app.BundleID) {
+			continue
+		}
+		sampled += t.Minutes
+		if name := cfg.ProjectForTitle(t.Title); name != "" {
+			matched[name] += t.Minutes
+		}
+	}
+	if sampled == 0 {
+		return nil
+	}
+	for name, minutes := range matched {
+		matched[name] = app.Minutes * minutes / sampled
+	}
+	return matched
+}
@@ -0,0 +1,111 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestCalculateProjects(t *testing.T) {
+	t.Parallel()
+	cfg := config.Default()
+	cfg.Projects = []config.ProjectConfig{
+		{Name: "Client A", Apps: []string{"Figma"}, Domains: []string{"github.com/clienta/*"}, Issues: []string{"PROJ-*"}},
+		{Name: "Internal", Apps: []string{"com.tinyspeck.slackmacgap"}},
+	}
+
+	apps := AppsResult{
+		TopApps: []AppUsage{
+			{Name: "Google Chrome", Minutes: 120, BundleID: "com.google.Chrome"},
+			{Name: "Figma", Minutes: 60, BundleID: "com.figma.Desktop"},
+			{Name: "Slack", Minutes: 30, BundleID: "com.tinyspeck.slackmacgap"},
+			{Name: "Terminal", Minutes: 30, BundleID: "com.apple.Terminal"},
+		},
+		Available: true,
+	}
+	browsers := BrowsersResult{
+		URLVisits: map[string]int{
+			"https://github.com/clienta/app/pull/12":       3,
+			"https://clienta.atlassian.net/browse/PROJ-42": 3,
+			"https://news.ycombinator.com/":                6,
+		},
+		Available: true,
+	}
+
+	result := CalculateProjects(apps, browsers, WindowTitlesResult{}, cfg)
+	if !result.Available {
+		t.Fatal("expected result to be available")
+	}
+	if result.TotalMinutes != 240 {
+		t.Errorf("TotalMinutes = %d, want 240", result.TotalMinutes)
+	}
+	if len(result.Projects) != 2 {
+		t.Fatalf("got %d projects, want 2: %+v", len(result.Projects), result.Projects)
+	}
+
+	// Client A: 60m of Figma plus half of the 120m of browser time
+	a := result.Projects[0]
+	if a.Name != "Client A" || a.AppMinutes != 60 || a.BrowserMinutes != 60 || a.Minutes != 120 {
+		t.Errorf("Client A = %+v, want 60 app + 60 browser", a)
+	}
+	if b := result.Projects[1]; b.Name != "Internal" || b.Minutes != 30 {
+		t.Errorf("Internal = %+v, want 30 minutes", b)
+	}
+	// Terminal plus the unmatched half of browser time
+	if result.UnattributedMinutes != 90 {
+		t.Errorf("UnattributedMinutes = %d, want 90", result.UnattributedMinutes)
+	}
+}
+
+func TestCalculateProjectsWindowTitles(t *testing.T) {
+	t.Parallel()
+	cfg := config.Default()
+	cfg.Projects = []config.ProjectConfig{
+		{Name: "rekap", Titles: []string{"rekap"}},
+		{Name: "Client A", Apps: []string{"Figma"}, Titles: []string{"* — clienta-api"}},
+	}
+
+	apps := AppsResult{
+		TopApps: []AppUsage{
+			{Name: "Code", Minutes: 120, BundleID: "com.microsoft.VSCode"},
+			{Name: "Figma", Minutes: 30, BundleID: "com.figma.Desktop"},
+			{Name: "Terminal", Minutes: 30, BundleID: "com.apple.Terminal"},
+		},
+		Available: true,
+	}
+	windows := WindowTitlesResult{
+		Titles: []WindowTitle{
+			{App: "Code", BundleID: "com.microsoft.VSCode", Title: "main.go — rekap", Minutes: 50},
+			{App: "Code", BundleID: "com.microsoft.VSCode", Title: "api.go — clienta-api", Minutes: 25},
+			{App: "Code", BundleID: "com.microsoft.VSCode", Title: "Welcome", Minutes: 25},
+			// A mapped app counts in full whatever its titles say
+			{App: "Figma", BundleID: "com.figma.Desktop", Title: "rekap logo", Minutes: 30},
+		},
+		Available: true,
+	}
+
+	result := CalculateProjects(apps, BrowsersResult{}, windows, cfg)
+	if len(result.Projects) != 2 {
+		t.Fatalf("got %d projects, want 2: %+v", len(result.Projects), result.Projects)
+	}
+	// Code's 120m split by sampled titles: half rekap, a quarter Client A.
+	// Both total an hour, so they sort by name.
+	if a := result.Projects[0]; a.Name != "Client A" || a.AppMinutes != 30 || a.WindowMinutes != 30 || a.Minutes != 60 {
+		t.Errorf("Client A = %+v, want 30 app + 30 window", a)
+	}
+	if r := result.Projects[1]; r.Name != "rekap" || r.WindowMinutes != 60 || r.Minutes != 60 {
+		t.Errorf("rekap = %+v, want 60 window minutes", r)
+	}
+	// Terminal plus Code's untitled quarter
+	if result.UnattributedMinutes != 60 {
+		t.Errorf("UnattributedMinutes = %d, want 60", result.UnattributedMinutes)
+	}
+}
+
+func TestCalculateProjectsNoConfig(t *testing.T) {
+	t.Parallel()
+	apps := AppsResult{TopApps: []AppUsage{{Name: "Figma", Minutes: 60}}, Available: true}
+	if result := CalculateProjects(apps, BrowsersResult{}, WindowTitlesResult{}, config.Default()); result.Available {
+		t.Error("expected unavailable result with no projects configured")
+	}
+}
@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// remindersScript prints the reminders completed between two Unix times as
+// "completed<TAB>list" lines, in seconds. Titles are never read.
+const remindersScript = `
+function run(argv) {
+	const start = new Date(Number(argv[0]) * 1000);
+	const end = new Date(Number(argv[1]) * 1000);
+	const lines = [];
+	for (const list of Application('Reminders').lists()) {
+		const done = list.reminders.whose({_and: [
+			{completed: true},
+			{completionDate: {_greaterThanEquals: start}},
+			{completionDate: {_lessThan: end}},
+		]});
+		const name = list.name();
+		for (const d of done.completionDate()) {
+			lines.push(Math.round(d.getTime() / 1000) + '\t' + name);
+		}
+	}
+	return lines.join('\n');
+}`
+
+// ReminderList is the reminders completed in one list in the window
+type ReminderList struct {
+	Name      string
+	Completed int
+}
+
+// RemindersResult contains the reminders completed in the window
+type RemindersResult struct {
+	Completed int
+	Lists     []ReminderList // Most completed first
+	Available bool
+	Error     error
+}
+
+// CollectReminders asks Reminders.app for the reminders completed in w.
+// The first run prompts for permission to control Reminders.
+func CollectReminders(ctx context.Context, enabled bool, w Window) RemindersResult {
+	if !enabled {
+		return RemindersResult{Error: fmt.Errorf("reminders are off (set tracking.reminders: true)")}
+	}
+
+	cmd := exec.CommandContext(ctx, "osascript", "-l", "JavaScript", "-e", remindersScript,
+		strconv.FormatInt(w.Start.Unix(), 10), strconv.FormatInt(w.End.Unix(), 10))
+	output, err := cmd.Output()
+	if err != nil {
+		return RemindersResult{Error: fmt.Errorf("failed to read Reminders: %w", err)}
+	}
+	return parseReminders(string(output), w)
+}
+
+// parseReminders counts the "completed<TAB>list" lines completed in w, per
+// list. Lines that don't parse are skipped.
+func parseReminders(output string, w Window) RemindersResult {
+	result := RemindersResult{Available: true}
+	byList := map[string]int{}
+	for line := range strings.SplitSeq(strings.TrimSpace(output), "\n") {
+		stamp, list, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		unix, err := strconv.ParseInt(stamp, 10, 64)
+		if err != nil {
+			continue
+		}
+		if at := time.Unix(unix, 0); at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		byList[list]++
+		result.Completed++
+	}
+
+	for name, n := range byList {
+		result.Lists = append(result.Lists, ReminderList{Name: name, Completed: n})
+	}
+	sort.Slice(result.Lists, func(i, j int) bool {
+		a, b := result.Lists[i], result.Lists[j]
+		if a.Completed != b.Completed {
+			return a.Completed > b.Completed
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
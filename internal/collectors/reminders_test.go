@@ -0,0 +1,33 @@
+package collectors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseReminders(t *testing.T) {
+	t.Parallel()
+	w := Window{Start: time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local), End: time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)}
+	at := func(hour int) int64 { return w.Start.Add(time.Duration(hour) * time.Hour).Unix() }
+
+	output := fmt.Sprintf("%d\tWork\n%d\tWork\n%d\tHome\n%d\tGroceries\n%d\tWork\ngarbage\n%d\tYesterday\n",
+		at(9), at(11), at(12), at(18), at(20), at(-2))
+	got := parseReminders(output, w)
+	if !got.Available || got.Completed != 5 {
+		t.Fatalf("got %+v, want 5 completed", got)
+	}
+	want := []ReminderList{{"Work", 3}, {"Groceries", 1}, {"Home", 1}}
+	if len(got.Lists) != len(want) {
+		t.Fatalf("Lists = %+v, want %+v", got.Lists, want)
+	}
+	for i := range want {
+		if got.Lists[i] != want[i] {
+			t.Errorf("Lists[%d] = %+v, want %+v", i, got.Lists[i], want[i])
+		}
+	}
+
+	if empty := parseReminders("", w); !empty.Available || empty.Completed != 0 {
+		t.Errorf("no reminders = %+v, want available with 0", empty)
+	}
+}
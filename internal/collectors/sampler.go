@@ -0,0 +1,209 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// appSample is a single frontmost-app reading persisted to the daily log
+type appSample struct {
+	Timestamp string `json:"timestamp"`
+	Name      string `json:"name"`
+	BundleID  string `json:"bundle_id"`
+}
+
+const (
+	// appSampleMinInterval keeps rapid back-to-back runs from double counting
+	appSampleMinInterval = 30 * time.Second
+
+	// appSampleMaxCredit caps the time a sample stands for, so a gap between
+	// runs isn't all credited to whatever app was frontmost before it
+	appSampleMaxCredit = 5 * time.Minute
+)
+
+// RecordAppSample reads the frontmost app and appends it to today's sample
+// log. "rekap sample" calls it on a timer; each summary run adds one too.
+func RecordAppSample(ctx context.Context) (string, error) {
+	sample, err := readFrontmostApp(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadAppSamples(DayKey(now))
+	if shouldRecordAppSample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindApps, DayKey(now), samples); err != nil {
+			return sample.Name, fmt.Errorf("failed to save app sample: %w", err)
+		}
+	}
+	return sample.Name, nil
+}
+
+// collectAppsSampling builds app usage in w from frontmost-app samples: one
+// taken now (for live windows) plus any recorded by earlier runs or "rekap
+// sample". It only needs Accessibility, so it works without Full Disk Access.
+func collectAppsSampling(ctx context.Context, excludedApps []string, w Window) AppsResult {
+	var sampleErr error
+	if w.Live() {
+		_, sampleErr = RecordAppSample(ctx)
+	}
+
+	var samples []appSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadAppSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeAppSamples(samples, excludedApps, w)
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("frontmost app unavailable (requires Accessibility): %w", sampleErr)
+	}
+	return result
+}
+
+// readFrontmostApp asks System Events for the frontmost app
+func readFrontmostApp(ctx context.Context) (appSample, error) {
+	cmd := exec.CommandContext(ctx, "osascript", "-e", `
+		tell application "System Events"
+			set frontApp to first application process whose frontmost is true
+			return (name of frontApp) & "|" & (bundle identifier of frontApp)
+		end tell
+	`)
+	output, err := cmd.Output()
+	if err != nil {
+		return appSample{}, err
+	}
+
+	name, bundleID, _ := strings.Cut(strings.TrimSpace(string(output)), "|")
+	if name == "" {
+		return appSample{}, fmt.Errorf("no frontmost app")
+	}
+	if bundleID == "missing value" {
+		bundleID = ""
+	}
+	return appSample{Name: name, BundleID: bundleID}, nil
+}
+
+// summarizeAppSamples credits each sample in w with the time until the next
+// one (or the end of w), capped at appSampleMaxCredit. Every sample counts
+// for at least a minute, so a single in-run sample still shows up.
+func summarizeAppSamples(samples []appSample, excludedApps []string, w Window) AppsResult {
+	result := AppsResult{Available: false, Source: config.SourceSampling, ExcludedApps: excludedApps}
+
+	type timedSample struct {
+		appSample
+		at time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s, at})
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := sampleCredits(times, w.End)
+
+	credit := make(map[string]time.Duration)
+	bundleIDs := make(map[string]string)
+	var names []string
+	for i, s := range timed {
+		d := credits[i]
+		if isExcluded(s.Name, s.BundleID, excludedApps) {
+			continue
+		}
+		credit[s.Name] += d
+		names = append(names, s.Name)
+		if s.BundleID != "" {
+			bundleIDs[s.Name] = s.BundleID
+		}
+	}
+	// Samples miss quick trips to another app, so these are a floor
+	activations := countAppActivations(names)
+
+	for name, d := range credit {
+		result.TopApps = append(result.TopApps, AppUsage{
+			Name:        name,
+			Minutes:     int(d.Minutes()),
+			BundleID:    bundleIDs[name],
+			Activations: activations[name],
+		})
+	}
+	sort.Slice(result.TopApps, func(i, j int) bool {
+		if result.TopApps[i].Minutes != result.TopApps[j].Minutes {
+			return result.TopApps[i].Minutes > result.TopApps[j].Minutes
+		}
+		return result.TopApps[i].Name < result.TopApps[j].Name
+	})
+	if len(result.TopApps) > 10 {
+		result.TopApps = result.TopApps[:10]
+	}
+
+	if len(timed) > 0 {
+		result.FirstActivity = timed[0].at
+		result.LastActivity = timed[len(timed)-1].at
+	}
+	result.Available = len(result.TopApps) > 0
+	if !result.Available {
+		result.Error = fmt.Errorf("no app samples recorded in this window")
+	}
+	return result
+}
+
+// sampleCredits returns how long each of the sorted sample times stands for:
+// the time until the next sample (or end), capped at appSampleMaxCredit and
+// never less than a minute
+func sampleCredits(times []time.Time, end time.Time) []time.Duration {
+	return cappedCredits(times, end, appSampleMaxCredit)
+}
+
+// cappedCredits is sampleCredits with the cap given
+func cappedCredits(times []time.Time, end time.Time, limit time.Duration) []time.Duration {
+	credits := make([]time.Duration, len(times))
+	for i, at := range times {
+		next := end
+		if i+1 < len(times) {
+			next = times[i+1]
+		}
+		credits[i] = max(min(next.Sub(at), limit), time.Minute)
+	}
+	return credits
+}
+
+func shouldRecordAppSample(samples []appSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadAppSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadAppSamples(date string) ([]appSample, error) {
+	var samples []appSample
+	if _, err := state.Load(state.KindApps, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
-	"regexp"
 	"strings"
 	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/state"
 )
 
 // ScreenResult contains screen-on time and lock event information
@@ -14,32 +16,78 @@ type ScreenResult struct {
 	ScreenOnMinutes    int
 	LockCount          int
 	AvgMinsBetweenLock int
+	OnPeriods          []Period // Screen-on spans, oldest first
+	Source             string   // "pmset", "knowledgec", or "estimate"
 	Available          bool
 	Error              error
 }
 
-// CollectScreen retrieves screen-on time and lock events since midnight
-func CollectScreen(ctx context.Context) ScreenResult {
-	result := ScreenResult{Available: false}
+// ScreenSourceEstimate marks a screen result guessed from the time of day
+const ScreenSourceEstimate = "estimate"
+
+// CollectScreen retrieves screen-on time and lock events in w from source
+// (see config.SourcesConfig). With config.SourceAuto it tries the pmset log,
+// then the Screen Time database, then a rough estimate.
+func CollectScreen(ctx context.Context, source string, w Window) ScreenResult {
+	var result ScreenResult
+	switch source {
+	case config.SourcePmset:
+		result = collectScreenPmset(ctx, w)
+	case config.SourceKnowledgeC:
+		result = collectScreenKnowledgeC(ctx, w)
+	default:
+		result = collectScreenPmset(ctx, w)
+		if !result.Available {
+			if kc := collectScreenKnowledgeC(ctx, w); kc.Available {
+				result = kc
+			} else {
+				return estimateScreen(w, result.Error)
+			}
+		}
+	}
+
+	// Checkpoints are running totals for the day, so only a whole day up to
+	// now can be saved as one
+	if result.Available && w.WholeDay() && w.Live() {
+		_ = state.Save(state.KindScreen, w.Day(), screenCheckpoint{
+			ScreenOnMinutes: result.ScreenOnMinutes,
+			LockCount:       result.LockCount,
+			Timestamp:       w.End.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+// estimateScreen guesses screen time as the whole window, tightened by
+// today's last checkpoint when the window is today so far
+func estimateScreen(w Window, cause error) ScreenResult {
+	result := ScreenResult{
+		ScreenOnMinutes: w.Minutes(),
+		Source:          ScreenSourceEstimate,
+		Available:       true,
+		Error:           fmt.Errorf("%w, using rough estimate", cause),
+	}
+	if w.WholeDay() && w.Live() {
+		applyScreenCheckpoint(&result, w.End)
+	}
+	return result
+}
 
-	now := time.Now()
-	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+// collectScreenPmset parses display on/off events from the pmset log
+func collectScreenPmset(ctx context.Context, w Window) ScreenResult {
+	result := ScreenResult{Available: false, Source: config.SourcePmset}
 
 	// Get pmset log and filter for display events in Go (avoids sh -c)
 	cmd := exec.CommandContext(ctx, "pmset", "-g", "log")
 	output, err := cmd.Output()
 	if err != nil {
-		result.ScreenOnMinutes = int(time.Since(midnight).Minutes())
-		result.Available = true
-		result.Error = fmt.Errorf("pmset log unavailable, using rough estimate: %w", err)
+		result.Error = fmt.Errorf("pmset log unavailable: %w", err)
 		return result
 	}
 
-	todayStr := midnight.Format("2006-01-02")
 	var lines []string
 	for _, line := range strings.Split(string(output), "\n") {
-		lower := strings.ToLower(line)
-		if strings.Contains(lower, "display") && strings.Contains(line, todayStr) {
+		if strings.Contains(strings.ToLower(line), "display") {
 			lines = append(lines, line)
 		}
 	}
@@ -58,21 +106,17 @@ func CollectScreen(ctx context.Context) ScreenResult {
 	var lastSleepTime time.Time
 
 	// Parse display on/off events
-	timeRe := regexp.MustCompile(`(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})`)
-
 	for _, line := range lines {
 		if line == "" {
 			continue
 		}
 
-		matches := timeRe.FindStringSubmatch(line)
-		if len(matches) < 2 {
+		eventTime, ok := parsePmsetTime(line, time.Local)
+		if !ok || eventTime.Before(w.Start) {
 			continue
 		}
-
-		eventTime, err := time.ParseInLocation("2006-01-02 15:04:05", matches[1], time.Local)
-		if err != nil {
-			continue
+		if !eventTime.Before(w.End) {
+			break
 		}
 
 		// Detect display on/off from log entries
@@ -85,9 +129,9 @@ func CollectScreen(ctx context.Context) ScreenResult {
 
 				// Track wake event (end of lock)
 				if !lastSleepTime.IsZero() {
-					// Only count locks that started on or after midnight (today)
-					if lastSleepTime.Before(midnight) {
-						// Sleep started before today, skip this lock event
+					// Only count locks that started inside the window
+					if lastSleepTime.Before(w.Start) {
+						// Sleep started before the window, skip this lock event
 						lastSleepTime = time.Time{}
 					} else {
 						duration := eventTime.Sub(lastSleepTime)
@@ -108,6 +152,7 @@ func CollectScreen(ctx context.Context) ScreenResult {
 			if isOn && !lastOnTime.IsZero() {
 				duration := eventTime.Sub(lastOnTime)
 				totalMinutes += int(duration.Minutes())
+				result.OnPeriods = append(result.OnPeriods, Period{Start: lastOnTime, End: eventTime})
 				isOn = false
 			}
 			// Track sleep event (start of lock)
@@ -115,10 +160,11 @@ func CollectScreen(ctx context.Context) ScreenResult {
 		}
 	}
 
-	// If display is currently on, add time until now
+	// If display is still on at the end of the window, count it until then
 	if isOn && !lastOnTime.IsZero() {
-		duration := now.Sub(lastOnTime)
+		duration := w.End.Sub(lastOnTime)
 		totalMinutes += int(duration.Minutes())
+		result.OnPeriods = append(result.OnPeriods, Period{Start: lastOnTime, End: w.End})
 	}
 
 	// Calculate lock statistics
@@ -140,13 +186,130 @@ func CollectScreen(ctx context.Context) ScreenResult {
 		}
 	}
 
-	// If we have no data, fall back to rough estimate
 	if totalMinutes == 0 {
-		totalMinutes = int(time.Since(midnight).Minutes())
-		result.Error = fmt.Errorf("no display events parsed, using estimate")
+		result.OnPeriods = nil
+		result.Error = fmt.Errorf("no display events parsed")
+		return result
 	}
 
 	result.ScreenOnMinutes = totalMinutes
 	result.Available = true
 	return result
 }
+
+// collectScreenKnowledgeC reads backlight periods in w from the Screen Time
+// database
+func collectScreenKnowledgeC(ctx context.Context, w Window) ScreenResult {
+	result := ScreenResult{Available: false, Source: config.SourceKnowledgeC}
+
+	db, err := openKnowledgeDB()
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer db.Close()
+
+	startTimestamp, endTimestamp := timestampRange(w)
+	query := `
+		SELECT ZSTARTDATE, ZENDDATE
+		FROM ZOBJECT
+		WHERE ZSTREAMNAME = '/display/isBacklit'
+			AND ZVALUEINTEGER = 1
+			AND ZENDDATE >= ?
+			AND ZSTARTDATE <= ?
+		ORDER BY ZSTARTDATE ASC
+	`
+	rows, err := db.QueryContext(ctx, query, startTimestamp, endTimestamp)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to query Screen Time backlight data: %w", err)
+		return result
+	}
+	defer rows.Close()
+
+	var periods []Period
+	for rows.Next() {
+		var start, end float64
+		if err := rows.Scan(&start, &end); err != nil {
+			continue
+		}
+		periods = append(periods, Period{Start: coreDataTime(start), End: coreDataTime(end)})
+	}
+
+	result = screenFromPeriods(periods, w)
+	result.Source = config.SourceKnowledgeC
+	return result
+}
+
+// screenFromPeriods builds a screen result from backlight periods (oldest
+// first), clipped to w. Periods less than a minute apart are merged,
+// matching the pmset parser's one-minute lock threshold.
+func screenFromPeriods(periods []Period, w Window) ScreenResult {
+	result := ScreenResult{Available: false}
+
+	var merged []Period
+	for _, p := range periods {
+		var ok bool
+		if p.Start, p.End, ok = w.clip(p.Start, p.End); !ok {
+			continue
+		}
+		if n := len(merged); n > 0 && p.Start.Sub(merged[n-1].End) < time.Minute {
+			if p.End.After(merged[n-1].End) {
+				merged[n-1].End = p.End
+			}
+			continue
+		}
+		merged = append(merged, p)
+	}
+
+	total := 0
+	for _, p := range merged {
+		total += p.Minutes()
+	}
+	if total == 0 {
+		result.Error = fmt.Errorf("no backlight events recorded in this window")
+		return result
+	}
+
+	result.ScreenOnMinutes = total
+	result.OnPeriods = merged
+	result.LockCount = len(merged) - 1
+	if result.LockCount > 1 {
+		// On-time between locks: every period except the first and last
+		between := 0
+		for _, p := range merged[1 : len(merged)-1] {
+			between += p.Minutes()
+		}
+		result.AvgMinsBetweenLock = between / (result.LockCount - 1)
+	}
+	result.Available = true
+	return result
+}
+
+// screenCheckpoint is the last screen-time figure parsed from the pmset log
+type screenCheckpoint struct {
+	ScreenOnMinutes int    `json:"screen_on_minutes"`
+	LockCount       int    `json:"lock_count"`
+	Timestamp       string `json:"timestamp"`
+}
+
+// applyScreenCheckpoint replaces a rough whole-day estimate with today's last
+// checkpoint plus the time since it was taken, which is still an upper bound
+// but a much tighter one.
+func applyScreenCheckpoint(result *ScreenResult, now time.Time) {
+	var cp screenCheckpoint
+	found, err := state.Load(state.KindScreen, DayKey(now), &cp)
+	if err != nil || !found {
+		return
+	}
+	at, err := time.Parse(time.RFC3339, cp.Timestamp)
+	if err != nil || at.After(now) {
+		return
+	}
+
+	estimate := cp.ScreenOnMinutes + int(now.Sub(at).Minutes())
+	if estimate < result.ScreenOnMinutes {
+		result.ScreenOnMinutes = estimate
+		result.LockCount = cp.LockCount
+		result.Error = fmt.Errorf("%w (from checkpoint at %s)", result.Error, at.Format("15:04"))
+	}
+}
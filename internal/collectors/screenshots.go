@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// screenshotPrefixes are the names macOS gives screen captures: "Screenshot"
+// since Mojave, "Screen Shot" before it
+var screenshotPrefixes = []string{"Screenshot", "Screen Shot"}
+
+// screenRecordingPrefix names screen recordings made with ⇧⌘5
+const screenRecordingPrefix = "Screen Recording"
+
+// screenRecordingExts are the formats screen recordings are saved in
+var screenRecordingExts = map[string]bool{"mov": true, "mp4": true}
+
+// ScreenshotsResult contains the screen captures saved in the window
+type ScreenshotsResult struct {
+	Screenshots int
+	Recordings  int
+	Bytes       int64
+	Folder      string // Where captures are saved
+	Available   bool
+	Error       error
+}
+
+// CollectScreenshots counts the screenshots and screen recordings saved in
+// w to the folder macOS saves captures to, the Desktop unless changed.
+// Captures already moved or deleted aren't seen.
+func CollectScreenshots(ctx context.Context, w Window) ScreenshotsResult {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ScreenshotsResult{Error: err}
+	}
+
+	folder := filepath.Join(homeDir, "Desktop")
+	if location := readScreencapturePref(ctx, "location"); location != "" {
+		folder = expandHome(location)
+	}
+	prefixes := screenshotPrefixes
+	if name := readScreencapturePref(ctx, "name"); name != "" {
+		prefixes = append([]string{name}, prefixes...)
+	}
+	return scanScreenshots(ctx, folder, prefixes, w)
+}
+
+// scanScreenshots counts the captures directly in folder created in w whose
+// names start with one of prefixes or with screenRecordingPrefix
+func scanScreenshots(ctx context.Context, folder string, prefixes []string, w Window) ScreenshotsResult {
+	result := ScreenshotsResult{Folder: folder}
+
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		result.Error = fmt.Errorf("failed to read %s: %w", folder, err)
+		return result
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			result.Error = ctx.Err()
+			return result
+		}
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		recording := strings.HasPrefix(name, screenRecordingPrefix)
+		if !recording && !hasAnyPrefix(name, prefixes) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		created := fileCreated(info)
+		if created.Before(w.Start) || !created.Before(w.End) {
+			continue
+		}
+
+		// A custom capture name covers recordings too, so tell them by format
+		if recording || screenRecordingExts[strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))] {
+			result.Recordings++
+		} else {
+			result.Screenshots++
+		}
+		result.Bytes += info.Size()
+	}
+
+	result.Available = true
+	return result
+}
+
+// readScreencapturePref reads a screencapture setting, or "" when unset
+func readScreencapturePref(ctx context.Context, key string) string {
+	output, err := exec.CommandContext(ctx, "defaults", "read", "com.apple.screencapture", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// hasAnyPrefix reports whether s starts with any of prefixes
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
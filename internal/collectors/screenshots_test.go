@@ -0,0 +1,48 @@
+package collectors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanScreenshots(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	now := time.Now()
+	w := Window{Start: now.Add(-time.Hour), End: now.Add(time.Minute)}
+
+	write := func(name string, size int, at time.Time) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("Screenshot 2024-11-06 at 10.15.32.png", 100, now)
+	write("Screen Shot 2018-03-01 at 9.00.00 AM.png", 100, now)
+	write("Screen Recording 2024-11-06 at 10.20.00.mov", 1000, now)
+	write("Capture 2024-11-06 at 11.00.00.png", 50, now)
+	write("Capture 2024-11-06 at 11.05.00.mov", 500, now)
+	write("Screenshot 2024-11-04 at 16.00.00.png", 100, now.Add(-48*time.Hour))
+	write("notes.txt", 10, now)
+	if err := os.Mkdir(filepath.Join(dir, "Screenshots"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got := scanScreenshots(t.Context(), dir, []string{"Capture", "Screenshot", "Screen Shot"}, w)
+	if !got.Available || got.Error != nil {
+		t.Fatalf("scanScreenshots = %+v", got)
+	}
+	if got.Screenshots != 3 || got.Recordings != 2 || got.Bytes != 1750 {
+		t.Errorf("got %d screenshots, %d recordings, %d bytes, want 3, 2 and 1750", got.Screenshots, got.Recordings, got.Bytes)
+	}
+
+	if missing := scanScreenshots(t.Context(), filepath.Join(dir, "nope"), screenshotPrefixes, w); missing.Available || missing.Error == nil {
+		t.Errorf("missing folder = %+v, want an error", missing)
+	}
+}
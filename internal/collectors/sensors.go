@@ -0,0 +1,168 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sensorLogPredicate selects Control Center's camera and microphone
+// indicator updates. Each one lists every app using a sensor from then on.
+const sensorLogPredicate = `subsystem == "com.apple.controlcenter" AND eventMessage CONTAINS "Active activity attributions changed"`
+
+// logShowTimeLayout is the timestamp layout of "log show --style ndjson"
+const logShowTimeLayout = "2006-01-02 15:04:05.000000-0700"
+
+// sensorAttribution matches one entry of an attributions list, e.g.
+// "cam:us.zoom.xos" or "mic:com.apple.FaceTime"
+var sensorAttribution = regexp.MustCompile(`"(cam|mic):([^"]+)"`)
+
+// SensorApp is an app's camera and microphone time in the window
+type SensorApp struct {
+	Name          string
+	BundleID      string
+	CameraMinutes int
+	MicMinutes    int
+}
+
+// SensorsResult contains which apps used the camera and microphone in the
+// window, and for how long
+type SensorsResult struct {
+	CameraMinutes  int         // Time any app had the camera on
+	MicMinutes     int         // Time any app had the microphone on
+	CameraSessions int         // Times the camera turned on
+	Apps           []SensorApp // Most camera and mic time first
+	Available      bool
+	Error          error
+}
+
+// CollectSensors reads the camera and microphone indicator changes in w
+// from the unified log
+func CollectSensors(ctx context.Context, w Window) SensorsResult {
+	cmd := exec.CommandContext(ctx, "log", "show",
+		"--start", w.Start.Format("2006-01-02 15:04:05"),
+		"--end", w.End.Format("2006-01-02 15:04:05"),
+		"--style", "ndjson",
+		"--predicate", sensorLogPredicate)
+	output, err := cmd.Output()
+	if err != nil {
+		return SensorsResult{Error: fmt.Errorf("failed to read unified log: %w", err)}
+	}
+
+	result := parseSensorLog(string(output), w)
+	for i := range result.Apps {
+		result.Apps[i].Name = resolveAppName(result.Apps[i].BundleID)
+	}
+	return result
+}
+
+// parseSensorLog replays the attribution changes in output. An app is
+// credited from the change that lists it to the next change that doesn't,
+// or to the end of w.
+func parseSensorLog(output string, w Window) SensorsResult {
+	type use struct{ camera, mic time.Duration }
+	uses := map[string]*use{}
+	var camera, mic time.Duration
+	sessions := 0
+
+	var active []string // "cam:<bundle ID>" and "mic:<bundle ID>"
+	var since time.Time
+	credit := func(until time.Time) {
+		if since.IsZero() {
+			return
+		}
+		start, end, ok := w.clip(since, until)
+		if !ok {
+			return
+		}
+		d := end.Sub(start)
+		var anyCamera, anyMic bool
+		for _, key := range active {
+			sensor, bundleID, _ := strings.Cut(key, ":")
+			u := uses[bundleID]
+			if u == nil {
+				u = &use{}
+				uses[bundleID] = u
+			}
+			if sensor == "cam" {
+				u.camera += d
+				anyCamera = true
+			} else {
+				u.mic += d
+				anyMic = true
+			}
+		}
+		if anyCamera {
+			camera += d
+		}
+		if anyMic {
+			mic += d
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry struct {
+			Timestamp    string `json:"timestamp"`
+			EventMessage string `json:"eventMessage"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry.EventMessage == "" {
+			continue
+		}
+		at, err := time.Parse(logShowTimeLayout, entry.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		var next []string
+		for _, m := range sensorAttribution.FindAllStringSubmatch(entry.EventMessage, -1) {
+			next = append(next, m[1]+":"+m[2])
+		}
+		credit(at)
+		if !hasCamera(active) && hasCamera(next) && !at.Before(w.Start) && at.Before(w.End) {
+			sessions++
+		}
+		active, since = next, at
+	}
+	credit(w.End)
+
+	result := SensorsResult{
+		CameraMinutes:  int(camera.Minutes()),
+		MicMinutes:     int(mic.Minutes()),
+		CameraSessions: sessions,
+		Available:      true,
+	}
+	for bundleID, u := range uses {
+		result.Apps = append(result.Apps, SensorApp{
+			Name:          appNameFromBundleID(bundleID),
+			BundleID:      bundleID,
+			CameraMinutes: int(u.camera.Minutes()),
+			MicMinutes:    int(u.mic.Minutes()),
+		})
+	}
+	sort.Slice(result.Apps, func(i, j int) bool {
+		a, b := result.Apps[i], result.Apps[j]
+		if ta, tb := a.CameraMinutes+a.MicMinutes, b.CameraMinutes+b.MicMinutes; ta != tb {
+			return ta > tb
+		}
+		return a.BundleID < b.BundleID
+	})
+	return result
+}
+
+// hasCamera reports whether an attributions list includes the camera
+func hasCamera(active []string) bool {
+	for _, key := range active {
+		if strings.HasPrefix(key, "cam:") {
+			return true
+		}
+	}
+	return false
+}
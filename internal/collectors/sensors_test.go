@@ -0,0 +1,59 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSensorLog(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 3, 9, hour, min, 0, 0, time.Local)
+	}
+	w := Window{Start: at(8, 0), End: at(18, 0)}
+	event := func(ts time.Time, attributions string) string {
+		return `{"timestamp":"` + ts.Format(logShowTimeLayout) + `","eventMessage":"Active activity attributions changed to [` + attributions + `]"}` + "\n"
+	}
+
+	output := `{"timestamp":"bad","eventMessage":"x"}` + "\n" +
+		event(at(9, 0), `\"cam:us.zoom.xos\", \"mic:us.zoom.xos\"`) +
+		event(at(9, 30), `\"mic:us.zoom.xos\"`) +
+		event(at(9, 45), ``) +
+		event(at(14, 0), `\"mic:com.apple.FaceTime\", \"cam:com.apple.FaceTime\"`) +
+		event(at(14, 20), `\"mic:com.apple.FaceTime\", \"cam:com.apple.FaceTime\", \"mic:com.apple.VoiceMemos\"`) +
+		event(at(14, 30), ``) +
+		event(at(17, 50), `\"mic:com.apple.VoiceMemos\"`) +
+		`{"count":7,"finished":1}` + "\n"
+
+	got := parseSensorLog(output, w)
+	if !got.Available {
+		t.Fatal("result not available")
+	}
+	// Zoom 9:00-9:30 and FaceTime 14:00-14:30
+	if got.CameraMinutes != 60 || got.CameraSessions != 2 {
+		t.Errorf("camera = %d min in %d sessions, want 60 in 2", got.CameraMinutes, got.CameraSessions)
+	}
+	// Zoom 9:00-9:45, FaceTime 14:00-14:30 (overlapping Voice Memos counted once), Voice Memos until the window ends
+	if got.MicMinutes != 85 {
+		t.Errorf("MicMinutes = %d, want 85", got.MicMinutes)
+	}
+
+	want := []SensorApp{
+		{Name: "xos", BundleID: "us.zoom.xos", CameraMinutes: 30, MicMinutes: 45},
+		{Name: "FaceTime", BundleID: "com.apple.FaceTime", CameraMinutes: 30, MicMinutes: 30},
+		{Name: "VoiceMemos", BundleID: "com.apple.VoiceMemos", MicMinutes: 20},
+	}
+	if len(got.Apps) != len(want) {
+		t.Fatalf("apps = %+v, want %+v", got.Apps, want)
+	}
+	for i := range want {
+		if got.Apps[i] != want[i] {
+			t.Errorf("app %d = %+v, want %+v", i, got.Apps[i], want[i])
+		}
+	}
+
+	// Nothing in the log
+	if empty := parseSensorLog("", w); !empty.Available || empty.MicMinutes != 0 || len(empty.Apps) != 0 {
+		t.Errorf("empty log = %+v, want available with no use", empty)
+	}
+}
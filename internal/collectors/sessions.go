@@ -0,0 +1,71 @@
+package collectors
+
+import (
+	"fmt"
+	"time"
+)
+
+// SessionGap is the idle or sleep gap that ends a work session
+const SessionGap = 30 * time.Minute
+
+// Period is a span of time
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Minutes returns the length of the period in whole minutes
+func (p Period) Minutes() int {
+	return int(p.End.Sub(p.Start).Minutes())
+}
+
+// SessionsResult splits the day into work sessions: runs of screen-on time
+// separated by gaps of at least SessionGap
+type SessionsResult struct {
+	Sessions       []Period // Oldest first
+	Count          int
+	LongestMinutes int
+	AvgMinutes     int
+	Available      bool
+	Error          error
+}
+
+// CalculateSessions groups the screen-on periods of screen into sessions
+func CalculateSessions(screen ScreenResult) SessionsResult {
+	result := SessionsResult{Available: false}
+	if len(screen.OnPeriods) == 0 {
+		result.Error = fmt.Errorf("no display events to build sessions from")
+		return result
+	}
+
+	result.Sessions = groupSessions(screen.OnPeriods, SessionGap)
+	result.Count = len(result.Sessions)
+	total := 0
+	for _, s := range result.Sessions {
+		m := s.Minutes()
+		total += m
+		if m > result.LongestMinutes {
+			result.LongestMinutes = m
+		}
+	}
+	result.AvgMinutes = total / result.Count
+	result.Available = true
+	return result
+}
+
+// groupSessions merges periods (oldest first) whose gap is shorter than gap.
+// A session spans from its first period's start to its last period's end,
+// so short breaks inside it count toward its length.
+func groupSessions(periods []Period, gap time.Duration) []Period {
+	var sessions []Period
+	for _, p := range periods {
+		if n := len(sessions); n > 0 && p.Start.Sub(sessions[n-1].End) < gap {
+			if p.End.After(sessions[n-1].End) {
+				sessions[n-1].End = p.End
+			}
+			continue
+		}
+		sessions = append(sessions, p)
+	}
+	return sessions
+}
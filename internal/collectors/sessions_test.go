@@ -0,0 +1,47 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateSessions(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 17, hour, min, 0, 0, time.Local)
+	}
+
+	screen := ScreenResult{OnPeriods: []Period{
+		{at(9, 0), at(10, 0)},
+		{at(10, 10), at(11, 0)},  // 10 min break: same session
+		{at(11, 30), at(12, 0)},  // exactly 30 min gap: new session
+		{at(14, 0), at(14, 20)},  // after lunch
+		{at(14, 45), at(15, 40)}, // 25 min gap: same session
+	}}
+
+	result := CalculateSessions(screen)
+	if !result.Available {
+		t.Fatalf("expected sessions to be available, got error %v", result.Error)
+	}
+	if result.Count != 3 {
+		t.Fatalf("Count = %d, want 3 (sessions: %+v)", result.Count, result.Sessions)
+	}
+	if got := result.Sessions[0]; !got.Start.Equal(at(9, 0)) || !got.End.Equal(at(11, 0)) {
+		t.Errorf("first session = %v-%v, want 9:00-11:00", got.Start, got.End)
+	}
+	if result.LongestMinutes != 120 {
+		t.Errorf("LongestMinutes = %d, want 120", result.LongestMinutes)
+	}
+	// (120 + 30 + 100) / 3
+	if result.AvgMinutes != 83 {
+		t.Errorf("AvgMinutes = %d, want 83", result.AvgMinutes)
+	}
+}
+
+func TestCalculateSessionsNoPeriods(t *testing.T) {
+	t.Parallel()
+	result := CalculateSessions(ScreenResult{ScreenOnMinutes: 300, Available: true})
+	if result.Available || result.Error == nil {
+		t.Errorf("expected unavailable sessions with an error, got %+v", result)
+	}
+}
@@ -0,0 +1,124 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxShellTopCommands is how many programs ShellResult.TopCommands keeps
+const maxShellTopCommands = 10
+
+// ShellCommandCount is how often one program was run
+type ShellCommandCount struct {
+	Name  string // The program, e.g. "git"; arguments are never kept
+	Count int
+}
+
+// ShellResult summarizes the commands typed in the shell. Shell history
+// can hold secrets, so it's only read when tracking.shell_history is on,
+// and only program names leave this collector.
+type ShellResult struct {
+	Commands            int
+	TopCommands         []ShellCommandCount // Most run first
+	BusiestHour         int                 // Hour of day (0-23) with the most commands
+	BusiestHourCommands int
+	Available           bool
+	Error               error
+}
+
+// CollectShell counts the commands run in w from zsh, bash, and fish
+// history, when enabled
+func CollectShell(ctx context.Context, enabled bool, w Window) ShellResult {
+	if !enabled {
+		return ShellResult{Error: fmt.Errorf("shell history is off (set tracking.shell_history: true)")}
+	}
+	if err := ctx.Err(); err != nil {
+		return ShellResult{Error: err}
+	}
+
+	commands, err := readShellHistory(w)
+	if err != nil {
+		return ShellResult{Error: err}
+	}
+	result := summarizeShell(commands)
+	if !result.Available {
+		result.Error = fmt.Errorf("no timestamped commands found")
+	}
+	return result
+}
+
+// summarizeShell counts programs and commands per hour
+func summarizeShell(commands []shellCommand) ShellResult {
+	var result ShellResult
+	counts := make(map[string]int)
+	var perHour [24]int
+	for _, c := range commands {
+		name := commandProgram(c.Command)
+		if name == "" {
+			continue
+		}
+		counts[name]++
+		perHour[c.At.Hour()]++
+		result.Commands++
+	}
+	if result.Commands == 0 {
+		return result
+	}
+
+	for name, count := range counts {
+		result.TopCommands = append(result.TopCommands, ShellCommandCount{Name: name, Count: count})
+	}
+	sort.Slice(result.TopCommands, func(i, j int) bool {
+		if result.TopCommands[i].Count != result.TopCommands[j].Count {
+			return result.TopCommands[i].Count > result.TopCommands[j].Count
+		}
+		return result.TopCommands[i].Name < result.TopCommands[j].Name
+	})
+	if len(result.TopCommands) > maxShellTopCommands {
+		result.TopCommands = result.TopCommands[:maxShellTopCommands]
+	}
+
+	for hour, n := range perHour {
+		if n > result.BusiestHourCommands {
+			result.BusiestHour, result.BusiestHourCommands = hour, n
+		}
+	}
+	result.Available = true
+	return result
+}
+
+// commandWrappers run the next word as the command
+var commandWrappers = map[string]bool{
+	"sudo": true, "time": true, "nohup": true, "exec": true, "command": true, "builtin": true, "noglob": true, "env": true,
+}
+
+// commandProgram returns the program a command line runs: the first word
+// after environment assignments and wrappers like sudo, without its path.
+// "FOO=1 sudo /usr/bin/make -j4" is "make".
+func commandProgram(command string) string {
+	words := commandWords(command)
+	if len(words) == 0 {
+		return ""
+	}
+	return filepath.Base(words[0])
+}
+
+// commandWords returns the first line of a command from the program on,
+// skipping environment assignments and wrappers
+func commandWords(command string) []string {
+	first, _, _ := strings.Cut(command, "\n")
+	words := strings.Fields(first)
+	for i, word := range words {
+		if strings.HasPrefix(word, "-") || commandWrappers[word] {
+			continue
+		}
+		if j := strings.IndexByte(word, '='); j > 0 && !strings.ContainsAny(word[:j], "/.") {
+			continue
+		}
+		return words[i:]
+	}
+	return nil
+}
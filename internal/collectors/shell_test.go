@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommandProgram(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"git status":                       "git",
+		"  ls -la":                         "ls",
+		"FOO=1 BAR=2 make test":            "make",
+		"sudo -E /usr/local/bin/brew up":   "brew",
+		"env GOOS=linux go build ./...":    "go",
+		"time go test ./...":               "go",
+		"docker run \\\n  --rm alpine":     "docker",
+		"":                                 "",
+		"sudo":                             "",
+		"./scripts/release.sh v1.2":        "release.sh",
+		"git commit -m \"fix: a=b thing\"": "git",
+	}
+	for in, want := range tests {
+		if got := commandProgram(in); got != want {
+			t.Errorf("commandProgram(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSummarizeShell(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 16, hour, min, 0, 0, time.Local)
+	}
+	commands := []shellCommand{
+		{At: at(9, 5), Command: "git pull"},
+		{At: at(14, 0), Command: "go test ./..."},
+		{At: at(14, 10), Command: "git status"},
+		{At: at(14, 20), Command: "git commit -m wip"},
+		{At: at(14, 30), Command: "go build"},
+		{At: at(16, 0), Command: "   "},
+	}
+	result := summarizeShell(commands)
+	if !result.Available || result.Commands != 5 {
+		t.Fatalf("result = %+v, want 5 commands", result)
+	}
+	if result.TopCommands[0] != (ShellCommandCount{"git", 3}) || result.TopCommands[1] != (ShellCommandCount{"go", 2}) {
+		t.Errorf("TopCommands = %+v", result.TopCommands)
+	}
+	if result.BusiestHour != 14 || result.BusiestHourCommands != 4 {
+		t.Errorf("busiest hour = %d (%d commands), want 14 (4)", result.BusiestHour, result.BusiestHourCommands)
+	}
+
+	if empty := summarizeShell(nil); empty.Available {
+		t.Error("no commands should be unavailable")
+	}
+}
+
+func TestCollectShellDisabled(t *testing.T) {
+	t.Parallel()
+	result := CollectShell(t.Context(), false, Today(time.Now()))
+	if result.Available || result.Error == nil {
+		t.Errorf("disabled collector = %+v, want unavailable with an error", result)
+	}
+}
@@ -0,0 +1,176 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// shellCommand is one timestamped command from the user's shell history
+type shellCommand struct {
+	At       time.Time
+	Duration time.Duration // How long it ran, when the shell records it (zsh EXTENDED_HISTORY)
+	Command  string
+}
+
+// readShellHistory returns the timestamped commands in w from the zsh, bash,
+// and fish history files, oldest first. Histories without timestamps (bash
+// without HISTTIMEFORMAT, zsh without EXTENDED_HISTORY) can't be placed in
+// time and are skipped.
+func readShellHistory(w Window) ([]shellCommand, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []struct {
+		path  string
+		parse func(io.Reader) []shellCommand
+	}{
+		{filepath.Join(homeDir, ".zsh_history"), parseZshHistory},
+		{filepath.Join(homeDir, ".bash_history"), parseBashHistory},
+		{filepath.Join(homeDir, ".local", "share", "fish", "fish_history"), parseFishHistory},
+	}
+
+	var commands []shellCommand
+	read := 0
+	for _, src := range sources {
+		f, err := os.Open(src.path)
+		if err != nil {
+			continue
+		}
+		read++
+		for _, c := range src.parse(f) {
+			if !c.At.Before(w.Start) && c.At.Before(w.End) {
+				commands = append(commands, c)
+			}
+		}
+		f.Close()
+	}
+	if read == 0 {
+		return nil, fmt.Errorf("no shell history found")
+	}
+
+	sort.SliceStable(commands, func(i, j int) bool { return commands[i].At.Before(commands[j].At) })
+	return commands, nil
+}
+
+// historyScanner returns a line scanner that tolerates very long commands
+func historyScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return scanner
+}
+
+// parseZshHistory reads EXTENDED_HISTORY lines, ": <start>:<elapsed>;<command>".
+// Commands continued over several lines end each line with a backslash.
+func parseZshHistory(r io.Reader) []shellCommand {
+	var commands []shellCommand
+	scanner := historyScanner(r)
+	continuing := false
+	for scanner.Scan() {
+		line := unmetafyZsh(scanner.Bytes())
+		if continuing && len(commands) > 0 {
+			last := &commands[len(commands)-1]
+			last.Command += "\n" + strings.TrimSuffix(line, "\\")
+			continuing = strings.HasSuffix(line, "\\")
+			continue
+		}
+		continuing = false
+
+		rest, ok := strings.CutPrefix(line, ": ")
+		if !ok {
+			continue
+		}
+		meta, command, ok := strings.Cut(rest, ";")
+		if !ok {
+			continue
+		}
+		startStr, elapsedStr, _ := strings.Cut(meta, ":")
+		start, err := strconv.ParseInt(strings.TrimSpace(startStr), 10, 64)
+		if err != nil {
+			continue
+		}
+		elapsed, _ := strconv.ParseInt(strings.TrimSpace(elapsedStr), 10, 64)
+
+		continuing = strings.HasSuffix(command, "\\")
+		commands = append(commands, shellCommand{
+			At:       time.Unix(start, 0),
+			Duration: time.Duration(elapsed) * time.Second,
+			Command:  strings.TrimSuffix(command, "\\"),
+		})
+	}
+	return commands
+}
+
+// unmetafyZsh undoes zsh's history encoding, which writes some bytes as
+// 0x83 followed by the byte XOR 32
+func unmetafyZsh(line []byte) string {
+	const meta = 0x83
+	if bytes.IndexByte(line, meta) < 0 {
+		return string(line)
+	}
+	out := make([]byte, 0, len(line))
+	for i := 0; i < len(line); i++ {
+		if line[i] == meta && i+1 < len(line) {
+			i++
+			out = append(out, line[i]^32)
+			continue
+		}
+		out = append(out, line[i])
+	}
+	return string(out)
+}
+
+// parseBashHistory reads history written with HISTTIMEFORMAT set, where each
+// command follows a "#<unix time>" comment line
+func parseBashHistory(r io.Reader) []shellCommand {
+	var commands []shellCommand
+	var at time.Time
+	scanner := historyScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if ts, ok := strings.CutPrefix(line, "#"); ok {
+			if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+				at = time.Unix(sec, 0)
+				continue
+			}
+		}
+		if at.IsZero() || strings.TrimSpace(line) == "" {
+			continue
+		}
+		commands = append(commands, shellCommand{At: at, Command: line})
+		at = time.Time{}
+	}
+	return commands
+}
+
+// parseFishHistory reads fish's YAML-like history: "- cmd: <command>"
+// followed by "  when: <unix time>"
+func parseFishHistory(r io.Reader) []shellCommand {
+	var commands []shellCommand
+	var pending string
+	scanner := historyScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if cmd, ok := strings.CutPrefix(line, "- cmd: "); ok {
+			// fish escapes newlines and backslashes in commands
+			pending = strings.NewReplacer(`\n`, "\n", `\\`, `\`).Replace(cmd)
+			continue
+		}
+		if when, ok := strings.CutPrefix(line, "  when: "); ok && pending != "" {
+			if sec, err := strconv.ParseInt(when, 10, 64); err == nil {
+				commands = append(commands, shellCommand{At: time.Unix(sec, 0), Command: pending})
+			}
+			pending = ""
+		}
+	}
+	return commands
+}
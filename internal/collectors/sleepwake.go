@@ -0,0 +1,59 @@
+package collectors
+
+import "time"
+
+// SleepWakeResult summarizes the sleep and wake cycle in the window from
+// the sleep periods CollectUptime read from the pmset log
+type SleepWakeResult struct {
+	FirstWake      time.Time // When the machine first woke or booted in the window; zero when awake since before it
+	Sleeps         int       // Sleeps that began in the window
+	SleepMinutes   int
+	LongestAwake   Period // Longest stretch awake without sleeping
+	AwakeStretches int
+	Available      bool
+}
+
+// CalculateSleepWake finds the first wake, the sleeps, and the longest
+// awake stretch in w. The machine is taken to be awake from boot (or the
+// start of w) except in the sleep periods.
+func CalculateSleepWake(uptime UptimeResult, w Window) SleepWakeResult {
+	if !uptime.Available {
+		return SleepWakeResult{}
+	}
+	start, end, ok := w.clip(uptime.BootTime, w.End)
+	if !ok {
+		return SleepWakeResult{}
+	}
+
+	result := SleepWakeResult{Available: true}
+	if start.After(w.Start) {
+		result.FirstWake = start // Booted in the window
+	}
+
+	var asleep time.Duration
+	awakeFrom := start
+	addStretch := func(until time.Time) {
+		if !until.After(awakeFrom) {
+			return
+		}
+		result.AwakeStretches++
+		if until.Sub(awakeFrom) > result.LongestAwake.End.Sub(result.LongestAwake.Start) {
+			result.LongestAwake = Period{Start: awakeFrom, End: until}
+		}
+	}
+	for _, p := range uptime.SleepPeriods {
+		asleep += p.End.Sub(p.Start)
+		// A sleep clipped to the start began before the window
+		if p.Start.After(start) {
+			result.Sleeps++
+		}
+		addStretch(p.Start)
+		if result.FirstWake.IsZero() && p.Start.Equal(start) && p.End.Before(end) {
+			result.FirstWake = p.End
+		}
+		awakeFrom = p.End
+	}
+	addStretch(end)
+	result.SleepMinutes = int(asleep.Minutes())
+	return result
+}
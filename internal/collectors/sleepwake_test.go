@@ -0,0 +1,50 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateSleepWake(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 3, 4, hour, min, 0, 0, time.Local)
+	}
+	w := Window{Start: at(0, 0), End: at(18, 0)}
+
+	// Asleep overnight until 7:30, then a lunch nap and a closed lid at 15:00
+	uptime := UptimeResult{
+		BootTime: at(0, 0).AddDate(0, 0, -3),
+		SleepPeriods: []Period{
+			{Start: at(0, 0), End: at(7, 30)},
+			{Start: at(12, 0), End: at(12, 45)},
+			{Start: at(15, 0), End: at(15, 20)},
+		},
+		Available: true,
+	}
+	got := CalculateSleepWake(uptime, w)
+	if !got.Available || !got.FirstWake.Equal(at(7, 30)) {
+		t.Fatalf("got %+v, want first wake at 7:30", got)
+	}
+	if got.Sleeps != 2 || got.AwakeStretches != 3 || got.SleepMinutes != 450+45+20 {
+		t.Errorf("Sleeps = %d, AwakeStretches = %d, SleepMinutes = %d; want 2, 3, 515", got.Sleeps, got.AwakeStretches, got.SleepMinutes)
+	}
+	if !got.LongestAwake.Start.Equal(at(7, 30)) || !got.LongestAwake.End.Equal(at(12, 0)) {
+		t.Errorf("LongestAwake = %v-%v, want 7:30-12:00", got.LongestAwake.Start, got.LongestAwake.End)
+	}
+
+	// Booted mid-morning and never slept
+	booted := CalculateSleepWake(UptimeResult{BootTime: at(9, 15), Available: true}, w)
+	if !booted.FirstWake.Equal(at(9, 15)) || booted.Sleeps != 0 || booted.AwakeStretches != 1 {
+		t.Errorf("booted = %+v, want one stretch from 9:15", booted)
+	}
+
+	// Awake through midnight: no first wake
+	if up := CalculateSleepWake(UptimeResult{BootTime: at(0, 0).AddDate(0, 0, -1), Available: true}, w); !up.FirstWake.IsZero() {
+		t.Errorf("FirstWake = %v, want zero when awake since before the window", up.FirstWake)
+	}
+
+	if none := CalculateSleepWake(UptimeResult{}, w); none.Available {
+		t.Error("expected nothing without uptime")
+	}
+}
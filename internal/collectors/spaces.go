@@ -0,0 +1,201 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// SpaceUsage is the time spent on one Mission Control Space
+type SpaceUsage struct {
+	Number  int    // Desktop number as shown in Mission Control, 1-based
+	Name    string // Configured name, or "Desktop N"
+	Minutes int
+}
+
+// SpacesResult contains per-Space time built from sampling the current Space
+type SpacesResult struct {
+	Spaces    []SpaceUsage // Most used first
+	Samples   int
+	Available bool
+	Error     error
+}
+
+// spaceSample is a single current-Space reading persisted to the daily log
+type spaceSample struct {
+	Timestamp string `json:"timestamp"`
+	Number    int    `json:"number"`
+}
+
+// RecordSpaceSample reads the current Space and appends it to today's
+// sample log. It returns the Space's desktop number.
+func RecordSpaceSample(ctx context.Context) (int, error) {
+	number, err := readCurrentSpace(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	samples, _ := loadSpaceSamples(DayKey(now))
+	if shouldRecordSpaceSample(samples, now) {
+		samples = append(samples, spaceSample{Timestamp: now.Format(time.RFC3339), Number: number})
+		if err := state.Save(state.KindSpaces, DayKey(now), samples); err != nil {
+			return number, fmt.Errorf("failed to save Space sample: %w", err)
+		}
+	}
+	return number, nil
+}
+
+// CollectSpaces builds time per Space in w from current-Space samples: one
+// taken now (for live windows) plus any recorded by earlier runs or "rekap
+// sample". names maps desktop numbers to labels such as project names.
+func CollectSpaces(ctx context.Context, names map[int]string, w Window) SpacesResult {
+	var sampleErr error
+	if w.Live() {
+		_, sampleErr = RecordSpaceSample(ctx)
+	}
+
+	var samples []spaceSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadSpaceSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeSpaceSamples(samples, names, w)
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("current Space unavailable: %w", sampleErr)
+	}
+	return result
+}
+
+// summarizeSpaceSamples credits each sample in w the same way app samples
+// are credited and totals the time per Space
+func summarizeSpaceSamples(samples []spaceSample, names map[int]string, w Window) SpacesResult {
+	result := SpacesResult{Available: false}
+
+	type timedSample struct {
+		number int
+		at     time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || s.Number < 1 || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s.Number, at})
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := sampleCredits(times, w.End)
+
+	credit := make(map[int]time.Duration)
+	for i, s := range timed {
+		credit[s.number] += credits[i]
+	}
+
+	for number, d := range credit {
+		name := names[number]
+		if name == "" {
+			name = fmt.Sprintf("Desktop %d", number)
+		}
+		result.Spaces = append(result.Spaces, SpaceUsage{Number: number, Name: name, Minutes: int(d.Minutes())})
+	}
+	sort.Slice(result.Spaces, func(i, j int) bool {
+		if result.Spaces[i].Minutes != result.Spaces[j].Minutes {
+			return result.Spaces[i].Minutes > result.Spaces[j].Minutes
+		}
+		return result.Spaces[i].Number < result.Spaces[j].Number
+	})
+
+	result.Samples = len(timed)
+	result.Available = len(result.Spaces) > 0
+	if !result.Available {
+		result.Error = fmt.Errorf("no Space samples recorded in this window")
+	}
+	return result
+}
+
+// spacesMonitor is one display's entry in the Spaces preferences
+type spacesMonitor struct {
+	DisplayIdentifier string `json:"Display Identifier"`
+	CurrentSpace      struct {
+		ManagedSpaceID int `json:"ManagedSpaceID"`
+	} `json:"Current Space"`
+	Spaces []struct {
+		ManagedSpaceID int `json:"ManagedSpaceID"`
+	} `json:"Spaces"`
+}
+
+// readCurrentSpace reads the main display's current Space from the Spaces
+// preferences that Mission Control keeps up to date
+func readCurrentSpace(ctx context.Context) (int, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return 0, err
+	}
+	plist := filepath.Join(homeDir, "Library", "Preferences", "com.apple.spaces.plist")
+
+	cmd := exec.CommandContext(ctx, "plutil", "-extract", "SpacesDisplayConfiguration.Management Data.Monitors", "json", "-o", "-", plist)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read Spaces preferences: %w", err)
+	}
+	return parseCurrentSpace(output)
+}
+
+// parseCurrentSpace returns the 1-based desktop number of the current Space
+// on the main display from the Monitors array of the Spaces preferences
+func parseCurrentSpace(data []byte) (int, error) {
+	var monitors []spacesMonitor
+	if err := json.Unmarshal(data, &monitors); err != nil {
+		return 0, fmt.Errorf("failed to parse Spaces preferences: %w", err)
+	}
+
+	for _, m := range monitors {
+		// With "Displays have separate Spaces" off, the only entry is "Main"
+		if m.DisplayIdentifier != "Main" && len(monitors) > 1 {
+			continue
+		}
+		for i, space := range m.Spaces {
+			if space.ManagedSpaceID == m.CurrentSpace.ManagedSpaceID {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("current Space not found")
+}
+
+func shouldRecordSpaceSample(samples []spaceSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadSpaceSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadSpaceSamples(date string) ([]spaceSample, error) {
+	var samples []spaceSample
+	if _, err := state.Load(state.KindSpaces, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,309 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// sshEstimateCap bounds the time credited to an ssh command whose
+	// duration the shell didn't record, since the next history entry may
+	// come from another terminal or much later
+	sshEstimateCap = time.Hour
+
+	// sshSameInvocation is how close a running connection's start must be to
+	// a history entry to be treated as the same ssh invocation
+	sshSameInvocation = 90 * time.Second
+)
+
+// SSHHost is the time spent connected to one remote host
+type SSHHost struct {
+	Host     string // Destination as typed: an ~/.ssh/config alias or hostname, without user@
+	Sessions int
+	Minutes  int
+	Active   bool // A connection is still open
+}
+
+// SSHResult contains time spent in ssh sessions, which Screen Time only
+// sees as time in the terminal app
+type SSHResult struct {
+	Hosts        []SSHHost // Most time first
+	TotalMinutes int
+	Sessions     int
+	Available    bool
+	Error        error
+}
+
+// sshSession is one ssh invocation and the time it covers
+type sshSession struct {
+	Host   string
+	Period Period
+	Active bool
+}
+
+// CollectSSH finds ssh sessions in w from shell history and, for live
+// windows, the ssh connections open now
+func CollectSSH(ctx context.Context, w Window) SSHResult {
+	result := SSHResult{Available: false}
+
+	commands, histErr := readShellHistory(w)
+	sessions := sshSessionsFromHistory(commands, w.End)
+
+	if w.Live() {
+		if active, err := activeSSHSessions(ctx, w.End); err == nil {
+			sessions = mergeActiveSSH(sessions, active)
+		}
+	}
+
+	result = summarizeSSH(sessions, w)
+	if !result.Available && histErr != nil {
+		result.Error = fmt.Errorf("no ssh activity found: %w", histErr)
+	}
+	return result
+}
+
+// sshSessionsFromHistory turns ssh commands in history into sessions. A
+// command with a recorded duration covers exactly that; otherwise it's
+// credited until the next history entry (or end), capped at sshEstimateCap.
+func sshSessionsFromHistory(commands []shellCommand, end time.Time) []sshSession {
+	var sessions []sshSession
+	for i, c := range commands {
+		host, ok := parseSSHCommand(c.Command)
+		if !ok {
+			continue
+		}
+		stop := c.At.Add(c.Duration)
+		if c.Duration <= 0 {
+			next := end
+			if i+1 < len(commands) {
+				next = commands[i+1].At
+			}
+			stop = c.At.Add(max(min(next.Sub(c.At), sshEstimateCap), time.Minute))
+		}
+		sessions = append(sessions, sshSession{Host: host, Period: Period{Start: c.At, End: stop}})
+	}
+	return sessions
+}
+
+// mergeActiveSSH adds the open connections to sessions, replacing the
+// history entry each one came from, whose length was only a guess
+func mergeActiveSSH(sessions, active []sshSession) []sshSession {
+	for _, a := range active {
+		for i, s := range sessions {
+			if s.Host == a.Host && !s.Active && absDuration(s.Period.Start.Sub(a.Period.Start)) <= sshSameInvocation {
+				sessions = append(sessions[:i], sessions[i+1:]...)
+				break
+			}
+		}
+		sessions = append(sessions, a)
+	}
+	return sessions
+}
+
+// summarizeSSH totals sessions per host, counting overlapping connections
+// to the same host once
+func summarizeSSH(sessions []sshSession, w Window) SSHResult {
+	result := SSHResult{Available: false}
+
+	byHost := make(map[string][]Period)
+	hosts := make(map[string]*SSHHost)
+	for _, s := range sessions {
+		start, end, ok := w.clip(s.Period.Start, s.Period.End)
+		if !ok {
+			continue
+		}
+		h := hosts[s.Host]
+		if h == nil {
+			h = &SSHHost{Host: s.Host}
+			hosts[s.Host] = h
+		}
+		h.Sessions++
+		h.Active = h.Active || s.Active
+		byHost[s.Host] = append(byHost[s.Host], Period{Start: start, End: end})
+	}
+
+	for host, periods := range byHost {
+		sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+		h := hosts[host]
+		for _, p := range groupSessions(periods, 0) {
+			h.Minutes += p.Minutes()
+		}
+		result.Hosts = append(result.Hosts, *h)
+		result.TotalMinutes += h.Minutes
+		result.Sessions += h.Sessions
+	}
+	sort.Slice(result.Hosts, func(i, j int) bool {
+		if result.Hosts[i].Minutes != result.Hosts[j].Minutes {
+			return result.Hosts[i].Minutes > result.Hosts[j].Minutes
+		}
+		return result.Hosts[i].Host < result.Hosts[j].Host
+	})
+
+	result.Available = len(result.Hosts) > 0
+	return result
+}
+
+// activeSSHSessions lists the ssh client processes running now, with their
+// start times from ps
+func activeSSHSessions(ctx context.Context, now time.Time) ([]sshSession, error) {
+	cmd := exec.CommandContext(ctx, "ps", "-axo", "etime=,command=")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	var sessions []sshSession
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		etime, command, ok := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !ok {
+			continue
+		}
+		host, ok := parseSSHCommand(strings.TrimSpace(command))
+		if !ok {
+			continue
+		}
+		elapsed, err := parseEtime(etime)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, sshSession{Host: host, Period: Period{Start: now.Add(-elapsed), End: now}, Active: true})
+	}
+	return sessions, nil
+}
+
+// parseEtime parses ps elapsed time, [[dd-]hh:]mm:ss
+func parseEtime(s string) (time.Duration, error) {
+	var days int
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, fmt.Errorf("invalid elapsed time %q", s)
+		}
+		days, s = n, rest
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid elapsed time %q", s)
+	}
+	var total time.Duration
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, fmt.Errorf("invalid elapsed time %q", s)
+		}
+		total = total*60 + time.Duration(n)
+	}
+	return total*time.Second + time.Duration(days)*24*time.Hour, nil
+}
+
+// sshFlagsWithArg are the ssh options that take a value
+const sshFlagsWithArg = "BbcDEeFIiJLlmOopQRSWw"
+
+// sshToolCommands are remote commands run by tools that use ssh as a
+// transport; those connections aren't interactive remote work
+var sshToolCommands = []string{"git-upload-pack", "git-receive-pack", "git-upload-archive", "git-lfs-authenticate", "rsync", "scp"}
+
+// parseSSHCommand finds an interactive ssh invocation in a shell command
+// line and returns its destination host. Tunnels (-N), subsystems (-s),
+// stdio forwarding (-W), and transports for git, rsync, and scp are skipped.
+func parseSSHCommand(command string) (string, bool) {
+	for _, segment := range splitShellSegments(command) {
+		fields := strings.Fields(segment)
+		// Skip environment assignments and wrappers in front of the command
+		for len(fields) > 0 && (strings.Contains(fields[0], "=") && !strings.HasPrefix(fields[0], "-") ||
+			fields[0] == "sudo" || fields[0] == "command" || fields[0] == "exec" || fields[0] == "time" || fields[0] == "nohup") {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 || filepath.Base(fields[0]) != "ssh" {
+			continue
+		}
+		if host, ok := sshDestination(fields[1:]); ok {
+			return host, true
+		}
+	}
+	return "", false
+}
+
+// sshDestination parses ssh's arguments and returns the destination host
+func sshDestination(args []string) (string, bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			i++
+			if i >= len(args) {
+				return "", false
+			}
+			arg = args[i]
+		} else if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+			for j := 1; j < len(arg); j++ {
+				flag := arg[j]
+				switch flag {
+				case 'N', 's', 'G', 'V':
+					return "", false
+				}
+				if strings.IndexByte(sshFlagsWithArg, flag) >= 0 {
+					if flag == 'W' || flag == 'O' || flag == 'Q' {
+						return "", false
+					}
+					if j == len(arg)-1 {
+						i++ // The value is the next argument
+					}
+					break
+				}
+			}
+			continue
+		}
+
+		host := arg
+		if rest, ok := strings.CutPrefix(host, "ssh://"); ok {
+			host = rest
+			if h, _, ok := strings.Cut(host, "/"); ok {
+				host = h
+			}
+			if h, port, ok := strings.Cut(host, ":"); ok && port != "" {
+				host = h
+			}
+		}
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		if host == "" {
+			return "", false
+		}
+
+		if remote := args[i+1:]; len(remote) > 0 {
+			tool := strings.Trim(filepath.Base(remote[0]), `'"`)
+			for _, t := range sshToolCommands {
+				if tool == t {
+					return "", false
+				}
+			}
+		}
+		return host, true
+	}
+	return "", false
+}
+
+// splitShellSegments splits a command line on ;, &&, ||, and | so each
+// piece starts with a command name. Quoting isn't interpreted.
+func splitShellSegments(command string) []string {
+	return strings.FieldsFunc(strings.NewReplacer("&&", ";", "||", ";", "|", ";", "\n", ";").Replace(command), func(r rune) bool {
+		return r == ';'
+	})
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
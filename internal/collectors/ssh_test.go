@@ -0,0 +1,123 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSSHCommand(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		command string
+		want    string // "" for not an interactive ssh session
+	}{
+		{"ssh prod-db", "prod-db"},
+		{"ssh deploy@web-1.example.com", "web-1.example.com"},
+		{"ssh -p 2222 -i ~/.ssh/id_work dev-box", "dev-box"},
+		{"ssh -A -J bastion admin@10.0.0.5 htop", "10.0.0.5"},
+		{"ssh -oStrictHostKeyChecking=no pi", "pi"},
+		{"ssh ssh://alex@build.example.com:2200", "build.example.com"},
+		{"cd ~/work && TERM=xterm-256color ssh -t staging tmux attach", "staging"},
+		{"/usr/bin/ssh -- gpu-box", "gpu-box"},
+		{"ssh -N -L 5432:localhost:5432 prod-db", ""},
+		{"ssh -NL 8080:localhost:80 prod-db", ""},
+		{"ssh -W %h:%p bastion", ""},
+		{"ssh git@github.com git-upload-pack 'alex/rekap.git'", ""},
+		{"ssh -x -oForwardAgent=no backup rsync --server -vlogDtpre.iLsfxC . /srv", ""},
+		{"ssh -s nas sftp", ""},
+		{"ssh-add ~/.ssh/id_ed25519", ""},
+		{"git push", ""},
+		{"echo ssh is great", ""},
+		{"ssh", ""},
+	}
+	for _, tt := range tests {
+		got, ok := parseSSHCommand(tt.command)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("parseSSHCommand(%q) = %q, %v; want %q", tt.command, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseShellHistories(t *testing.T) {
+	t.Parallel()
+	zsh := parseZshHistory(strings.NewReader(`: 1773150000:0;ls
+: 1773150060:1800;ssh prod-db
+: 1773152000:0;for f in *; do\
+echo $f\
+done
+not an extended history line
+`))
+	if len(zsh) != 3 {
+		t.Fatalf("zsh: got %d commands, want 3: %+v", len(zsh), zsh)
+	}
+	if zsh[1].Command != "ssh prod-db" || zsh[1].Duration != 30*time.Minute || !zsh[1].At.Equal(time.Unix(1773150060, 0)) {
+		t.Errorf("zsh[1] = %+v", zsh[1])
+	}
+	if want := "for f in *; do\necho $f\ndone"; zsh[2].Command != want {
+		t.Errorf("zsh multi-line command = %q, want %q", zsh[2].Command, want)
+	}
+
+	bash := parseBashHistory(strings.NewReader("ls\n#1773150000\nssh dev-box\n#1773150100\ngit status\n"))
+	if len(bash) != 2 || bash[0].Command != "ssh dev-box" || !bash[0].At.Equal(time.Unix(1773150000, 0)) {
+		t.Errorf("bash = %+v, want the two timestamped commands", bash)
+	}
+
+	fish := parseFishHistory(strings.NewReader("- cmd: ssh pi\n  when: 1773150000\n  paths:\n    - ~/x\n- cmd: ls\n  when: 1773150100\n"))
+	if len(fish) != 2 || fish[0].Command != "ssh pi" || !fish[1].At.Equal(time.Unix(1773150100, 0)) {
+		t.Errorf("fish = %+v", fish)
+	}
+}
+
+func TestParseEtime(t *testing.T) {
+	t.Parallel()
+	tests := map[string]time.Duration{
+		"05:03":       5*time.Minute + 3*time.Second,
+		"02:05:03":    2*time.Hour + 5*time.Minute + 3*time.Second,
+		"1-00:00:10":  24*time.Hour + 10*time.Second,
+		"12-01:00:00": 12*24*time.Hour + time.Hour,
+	}
+	for in, want := range tests {
+		if got, err := parseEtime(in); err != nil || got != want {
+			t.Errorf("parseEtime(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseEtime("soon"); err == nil {
+		t.Error("expected an error for a malformed elapsed time")
+	}
+}
+
+func TestSummarizeSSH(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	w := Window{Start: day, End: day.Add(24 * time.Hour)}
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+
+	commands := []shellCommand{
+		{At: at(9, 0), Command: "ssh prod-db", Duration: 45 * time.Minute},
+		{At: at(9, 30), Command: "ssh prod-db", Duration: 30 * time.Minute}, // Overlaps the first
+		{At: at(14, 0), Command: "ssh dev-box"},                             // No duration: until the next entry
+		{At: at(14, 20), Command: "git status"},
+		{At: at(16, 0), Command: "ssh dev-box"}, // Still open, replaced by the live connection
+		{At: at(16, 5), Command: "ls"},
+	}
+	sessions := sshSessionsFromHistory(commands, w.End)
+	sessions = mergeActiveSSH(sessions, []sshSession{
+		{Host: "dev-box", Period: Period{Start: at(16, 0).Add(30 * time.Second), End: at(17, 0)}, Active: true},
+	})
+
+	result := summarizeSSH(sessions, w)
+	if !result.Available || len(result.Hosts) != 2 {
+		t.Fatalf("got %+v, want 2 hosts", result)
+	}
+	devBox, prodDB := result.Hosts[0], result.Hosts[1]
+	if devBox.Host != "dev-box" || devBox.Minutes != 79 || devBox.Sessions != 2 || !devBox.Active {
+		t.Errorf("dev-box = %+v, want 79 minutes over 2 sessions, active", devBox)
+	}
+	if prodDB.Host != "prod-db" || prodDB.Minutes != 60 || prodDB.Sessions != 2 || prodDB.Active {
+		t.Errorf("prod-db = %+v, want 60 minutes over 2 sessions", prodDB)
+	}
+	if result.TotalMinutes != 139 || result.Sessions != 4 {
+		t.Errorf("totals = %d minutes, %d sessions; want 139, 4", result.TotalMinutes, result.Sessions)
+	}
+}
@@ -0,0 +1,196 @@
+package collectors
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// pmsetTimePattern matches the "2006-01-02 15:04:05 -0700" stamp that starts
+// pmset log and thermlog lines. The offset is what the clock was on when the
+// line was written, so it tells where the Mac was.
+var pmsetTimePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})(?: ([+-]\d{4}))?`)
+
+// parsePmsetTime reads the stamp at the start of a pmset line in loc. Lines
+// written before a time zone change carry the old offset, so they land at
+// the right instant instead of hours off; lines without one are taken as
+// loc's wall clock.
+func parsePmsetTime(line string, loc *time.Location) (time.Time, bool) {
+	m := pmsetTimePattern.FindStringSubmatch(line)
+	if m == nil {
+		return time.Time{}, false
+	}
+	if m[2] != "" {
+		if t, err := time.Parse("2006-01-02 15:04:05 -0700", m[1]+" "+m[2]); err == nil {
+			return t.In(loc), true
+		}
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", m[1], loc)
+	return t, err == nil
+}
+
+// TimeZoneChange is a change of the system time zone, such as landing after
+// a flight. Daylight saving changes aren't counted.
+type TimeZoneChange struct {
+	At         time.Time // First pmset line written in the new zone
+	From       string    // Abbreviation such as "PST", or "UTC-8" when unknown
+	To         string
+	FromOffset int // Seconds east of UTC
+	ToOffset   int
+}
+
+// TimeZoneResult contains the time zone changes in the window
+type TimeZoneResult struct {
+	Changes   []TimeZoneChange // Oldest first
+	Available bool
+	Error     error
+}
+
+// Traveled reports whether the time zone changed in the window
+func (t TimeZoneResult) Traveled() bool {
+	return len(t.Changes) > 0
+}
+
+// timeZoneSample is the system time zone seen by one run, persisted to the
+// daily log so a zone left behind can still be named
+type timeZoneSample struct {
+	Timestamp string `json:"timestamp"`
+	Zone      string `json:"zone"` // IANA name, e.g. "America/Los_Angeles"
+}
+
+// CollectTimeZone finds time zone changes in w from the UTC offsets stamped
+// on pmset log lines, naming the zones from those seen by earlier runs
+func CollectTimeZone(ctx context.Context, w Window) TimeZoneResult {
+	if w.Live() {
+		recordTimeZone(time.Now())
+	}
+
+	output, err := exec.CommandContext(ctx, "pmset", "-g", "log").Output()
+	if err != nil {
+		return TimeZoneResult{Error: fmt.Errorf("pmset log unavailable: %w", err)}
+	}
+
+	// The day before shows which zone the window started in
+	zones := []*time.Location{time.Local}
+	for _, day := range (Window{Start: w.Start.AddDate(0, 0, -1), End: w.End}).dayKeys() {
+		var samples []timeZoneSample
+		if _, err := state.Load(state.KindTimeZone, day, &samples); err != nil {
+			continue
+		}
+		for _, s := range samples {
+			if loc, err := time.LoadLocation(s.Zone); err == nil {
+				zones = append(zones, loc)
+			}
+		}
+	}
+	return parseTimeZoneChanges(string(output), w, zones)
+}
+
+// parseTimeZoneChanges finds where the offset on pmset lines changes in w,
+// skipping changes that are daylight saving in one of zones. zones also
+// name the offsets; the first that matches wins.
+func parseTimeZoneChanges(output string, w Window, zones []*time.Location) TimeZoneResult {
+	result := TimeZoneResult{Available: true}
+	var last time.Time
+	lastOffset, seen := 0, false
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		m := pmsetTimePattern.FindStringSubmatch(scanner.Text())
+		if m == nil || m[2] == "" {
+			continue
+		}
+		at, err := time.Parse("2006-01-02 15:04:05 -0700", m[1]+" "+m[2])
+		if err != nil || !at.Before(w.End) {
+			continue
+		}
+		_, offset := at.Zone()
+		if seen && offset != lastOffset && !at.Before(w.Start) && !daylightSaving(last, at, lastOffset, offset, zones) {
+			result.Changes = append(result.Changes, TimeZoneChange{
+				At:         at.In(w.Start.Location()),
+				From:       zoneName(last, lastOffset, zones),
+				To:         zoneName(at, offset, zones),
+				FromOffset: lastOffset,
+				ToOffset:   offset,
+			})
+		}
+		last, lastOffset, seen = at, offset, true
+	}
+	return result
+}
+
+// daylightSaving reports whether going from offset before to offset after
+// is a daylight saving change in one of zones
+func daylightSaving(before, after time.Time, beforeOffset, afterOffset int, zones []*time.Location) bool {
+	for _, loc := range zones {
+		_, b := before.In(loc).Zone()
+		_, a := after.In(loc).Zone()
+		if b == beforeOffset && a == afterOffset {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneName returns the abbreviation of the first of zones that was at
+// offset at t, or the offset itself ("UTC-8", "UTC+5:30")
+func zoneName(t time.Time, offset int, zones []*time.Location) string {
+	for _, loc := range zones {
+		name, o := t.In(loc).Zone()
+		// Go names zones without an abbreviation by their offset, e.g. "+03"
+		if o == offset && name != "" && !strings.ContainsAny(name[:1], "+-") {
+			return name
+		}
+	}
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	name := fmt.Sprintf("UTC%s%d", sign, offset/3600)
+	if minutes := offset % 3600 / 60; minutes != 0 {
+		name += fmt.Sprintf(":%02d", minutes)
+	}
+	return name
+}
+
+// recordTimeZone adds the system time zone to today's log when it differs
+// from the last one recorded
+func recordTimeZone(now time.Time) {
+	zone := systemTimeZone()
+	if zone == "" {
+		return
+	}
+	var samples []timeZoneSample
+	if _, err := state.Load(state.KindTimeZone, DayKey(now), &samples); err != nil {
+		return
+	}
+	if len(samples) > 0 && samples[len(samples)-1].Zone == zone {
+		return
+	}
+	samples = append(samples, timeZoneSample{Timestamp: now.Format(time.RFC3339), Zone: zone})
+	_ = state.Save(state.KindTimeZone, DayKey(now), samples)
+}
+
+// systemTimeZone returns the IANA name of the system time zone, from TZ or
+// the /etc/localtime link, or "" when neither names one
+func systemTimeZone() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return strings.TrimPrefix(tz, ":")
+	}
+	target, err := os.Readlink("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	_, zone, ok := strings.Cut(target, "zoneinfo/")
+	if !ok {
+		return ""
+	}
+	return zone
+}
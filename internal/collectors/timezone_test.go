@@ -0,0 +1,89 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePmsetTime(t *testing.T) {
+	t.Parallel()
+	est := time.FixedZone("EST", -5*3600)
+
+	// Written in Pacific time before a flight east: 9:00 PST is 12:00 EST
+	got, ok := parsePmsetTime("2026-02-16 09:00:00 -0800 Sleep  \tEntering Sleep state", est)
+	if !ok || !got.Equal(time.Date(2026, 2, 16, 12, 0, 0, 0, est)) || got.Location() != est {
+		t.Errorf("parsePmsetTime = %v, %v, want 12:00 EST", got, ok)
+	}
+
+	// Without an offset the stamp is wall clock time in loc
+	got, ok = parsePmsetTime("2026-02-16 09:00:00 Sleep", est)
+	if !ok || !got.Equal(time.Date(2026, 2, 16, 9, 0, 0, 0, est)) {
+		t.Errorf("parsePmsetTime without offset = %v, %v, want 09:00 EST", got, ok)
+	}
+
+	if _, ok := parsePmsetTime("Total Sleep/Wakes since boot:12", est); ok {
+		t.Error("parsePmsetTime accepted a line without a stamp")
+	}
+}
+
+func TestParseTimeZoneChanges(t *testing.T) {
+	t.Parallel()
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	w := Window{Start: time.Date(2026, 2, 16, 0, 0, 0, 0, newYork), End: time.Date(2026, 2, 16, 23, 0, 0, 0, newYork)}
+
+	output := "2026-02-15 23:10:00 -0800 Sleep  \tEntering Sleep state\n" +
+		"2026-02-16 06:00:00 -0800 Wake   \tWake from Normal Sleep\n" +
+		"2026-02-16 08:30:00 -0800 Sleep  \tEntering Sleep state\n" +
+		"Some line without a stamp\n" +
+		"2026-02-16 17:05:00 -0500 Wake   \tWake from Normal Sleep\n" + // Landed in New York
+		"2026-02-16 18:00:00 -0500 Sleep  \tEntering Sleep state\n"
+
+	got := parseTimeZoneChanges(output, w, []*time.Location{newYork, losAngeles})
+	if !got.Available || len(got.Changes) != 1 {
+		t.Fatalf("changes = %+v, want one", got.Changes)
+	}
+	c := got.Changes[0]
+	if c.From != "PST" || c.To != "EST" || c.FromOffset != -8*3600 || c.ToOffset != -5*3600 {
+		t.Errorf("change = %s (%d) → %s (%d), want PST → EST", c.From, c.FromOffset, c.To, c.ToOffset)
+	}
+	if !c.At.Equal(time.Date(2026, 2, 16, 17, 5, 0, 0, newYork)) {
+		t.Errorf("At = %v, want 17:05 EST", c.At)
+	}
+
+	// Without the zone left behind, it's named by its offset
+	got = parseTimeZoneChanges(output, w, []*time.Location{newYork})
+	if len(got.Changes) != 1 || got.Changes[0].From != "UTC-8" {
+		t.Errorf("changes = %+v, want one from UTC-8", got.Changes)
+	}
+
+	// The spring daylight saving change isn't travel
+	dst := Window{Start: time.Date(2026, 3, 8, 0, 0, 0, 0, newYork), End: time.Date(2026, 3, 8, 12, 0, 0, 0, newYork)}
+	output = "2026-03-08 01:30:00 -0500 Sleep  \tEntering Sleep state\n" +
+		"2026-03-08 08:00:00 -0400 Wake   \tWake from Normal Sleep\n"
+	if got := parseTimeZoneChanges(output, dst, []*time.Location{newYork}); len(got.Changes) != 0 {
+		t.Errorf("daylight saving counted as travel: %+v", got.Changes)
+	}
+}
+
+func TestParseSleepPeriodsAcrossTimeZones(t *testing.T) {
+	t.Parallel()
+	est := time.FixedZone("EST", -5*3600)
+	start := time.Date(2026, 2, 16, 0, 0, 0, 0, est)
+	end := time.Date(2026, 2, 16, 20, 0, 0, 0, est)
+
+	// Slept 8:30 PST (11:30 EST) through the flight, woke 17:05 EST
+	output := "2026-02-16 08:30:00 -0800 Sleep  \tEntering Sleep state\n" +
+		"2026-02-16 17:05:00 -0500 Wake   \tWake from Normal Sleep\n"
+	periods := parseSleepPeriods(output, start, end)
+	want := Period{Start: time.Date(2026, 2, 16, 11, 30, 0, 0, est), End: time.Date(2026, 2, 16, 17, 5, 0, 0, est)}
+	if len(periods) != 1 || !periods[0].Start.Equal(want.Start) || !periods[0].End.Equal(want.End) {
+		t.Errorf("periods = %+v, want %v-%v", periods, want.Start, want.End)
+	}
+}
@@ -0,0 +1,79 @@
+package collectors
+
+import "container/heap"
+
+// topCounter counts keys in bounded memory. Up to its capacity it counts
+// exactly; past that, a new key replaces the least-counted one and inherits
+// its count (the Space-Saving algorithm), so frequent keys keep their place
+// and any count is too high by at most the smallest count tracked.
+type topCounter struct {
+	capacity int
+	index    map[string]int // key -> position in entries
+	entries  counterHeap
+}
+
+type counterEntry struct {
+	key   string
+	count int
+}
+
+func newTopCounter(capacity int) *topCounter {
+	c := &topCounter{capacity: max(capacity, 1), index: make(map[string]int)}
+	c.entries.index = c.index
+	return c
+}
+
+// Add counts n more for key
+func (c *topCounter) Add(key string, n int) {
+	if i, ok := c.index[key]; ok {
+		c.entries.items[i].count += n
+		heap.Fix(&c.entries, i)
+		return
+	}
+	if len(c.entries.items) < c.capacity {
+		heap.Push(&c.entries, counterEntry{key: key, count: n})
+		return
+	}
+	least := c.entries.items[0]
+	delete(c.index, least.key)
+	c.entries.items[0] = counterEntry{key: key, count: least.count + n}
+	c.index[key] = 0
+	heap.Fix(&c.entries, 0)
+}
+
+// Counts returns the tracked keys and their counts
+func (c *topCounter) Counts() map[string]int {
+	counts := make(map[string]int, len(c.entries.items))
+	for _, e := range c.entries.items {
+		counts[e.key] = e.count
+	}
+	return counts
+}
+
+// counterHeap is a min-heap of entries by count that keeps index current
+type counterHeap struct {
+	items []counterEntry
+	index map[string]int
+}
+
+func (h counterHeap) Len() int           { return len(h.items) }
+func (h counterHeap) Less(i, j int) bool { return h.items[i].count < h.items[j].count }
+
+func (h counterHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].key] = i
+	h.index[h.items[j].key] = j
+}
+
+func (h *counterHeap) Push(x any) {
+	e := x.(counterEntry)
+	h.index[e.key] = len(h.items)
+	h.items = append(h.items, e)
+}
+
+func (h *counterHeap) Pop() any {
+	e := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, e.key)
+	return e
+}
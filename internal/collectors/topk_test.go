@@ -0,0 +1,37 @@
+package collectors
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+)
+
+func TestTopCounter(t *testing.T) {
+	t.Parallel()
+
+	// Under capacity, counts are exact
+	c := newTopCounter(3)
+	c.Add("a.com", 2)
+	c.Add("b.com", 1)
+	c.Add("a.com", 1)
+	if got, want := c.Counts(), map[string]int{"a.com": 3, "b.com": 1}; !maps.Equal(got, want) {
+		t.Errorf("Counts() = %v, want %v", got, want)
+	}
+
+	// A long tail of one-off keys can't push out the frequent ones
+	c = newTopCounter(4)
+	for i := range 1000 {
+		c.Add("github.com", 1)
+		if i%2 == 0 {
+			c.Add("docs.go.dev", 1)
+		}
+		c.Add(fmt.Sprintf("once-%d.example", i), 1)
+	}
+	got := c.Counts()
+	if len(got) != 4 {
+		t.Errorf("tracked %d keys, want 4", len(got))
+	}
+	if got["github.com"] < 1000 || got["docs.go.dev"] < 500 {
+		t.Errorf("frequent keys lost: %v", got)
+	}
+}
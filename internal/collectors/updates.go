@@ -0,0 +1,239 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// softwareUpdatePlist is where macOS caches the updates its last background
+// check found, so pending updates can be listed without a slow network check
+const softwareUpdatePlist = "/Library/Preferences/com.apple.SoftwareUpdate.plist"
+
+// caskTimestampLayout is how Homebrew names a cask's install metadata
+// folder, in UTC
+const caskTimestampLayout = "20060102150405.000"
+
+// BrewChange is a Homebrew package installed or upgraded in the window
+type BrewChange struct {
+	Name    string
+	Version string
+	From    string // Version before an upgrade; empty for installs and when unknown
+	Cask    bool
+	At      time.Time
+}
+
+// SoftwareUpdate is a macOS update waiting to be installed
+type SoftwareUpdate struct {
+	Name    string
+	Version string
+}
+
+// UpdatesResult contains the Homebrew packages changed in the window and
+// the macOS updates pending now
+type UpdatesResult struct {
+	Brew      []BrewChange // Oldest first
+	Installed int
+	Upgraded  int
+	Pending   []SoftwareUpdate // Live windows only
+	Available bool
+	Error     error
+}
+
+// brewPackage is an installed Homebrew formula or cask
+type brewPackage struct {
+	Name    string
+	Version string
+	Cask    bool
+	At      time.Time // When this version was installed
+}
+
+// key names the package in snapshots; a formula and a cask can share a name
+func (p brewPackage) key() string {
+	if p.Cask {
+		return "cask:" + p.Name
+	}
+	return p.Name
+}
+
+// CollectUpdates finds the Homebrew packages installed or upgraded in w
+// from their install receipts and, in live windows, the pending macOS
+// updates. Telling an upgrade from a new install needs the versions a run
+// on an earlier day saw, so each live run saves what's installed.
+func CollectUpdates(ctx context.Context, w Window) UpdatesResult {
+	var result UpdatesResult
+	if prefix := brewPrefix(); prefix != "" {
+		packages := scanBrew(prefix)
+		result = summarizeBrewChanges(packages, previousBrewVersions(w.Start), w)
+		if w.Live() {
+			state.Save(state.KindBrew, DayKey(time.Now()), brewVersions(packages))
+		}
+	}
+
+	if w.Live() {
+		pending, err := readPendingUpdates(ctx)
+		if err == nil {
+			result.Pending = pending
+			result.Available = true
+		}
+	}
+
+	if !result.Available {
+		result.Error = fmt.Errorf("neither Homebrew nor the software update cache was found")
+	}
+	return result
+}
+
+// brewPrefix returns where Homebrew is installed: $HOMEBREW_PREFIX, then
+// the default for Apple silicon, then for Intel
+func brewPrefix() string {
+	for _, prefix := range []string{os.Getenv("HOMEBREW_PREFIX"), "/opt/homebrew", "/usr/local"} {
+		if prefix == "" {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(prefix, "Cellar")); err == nil && info.IsDir() {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// scanBrew lists installed formulae from their install receipts and casks
+// from their metadata folders, keeping the newest version of each
+func scanBrew(prefix string) []brewPackage {
+	newest := make(map[string]brewPackage)
+	keep := func(p brewPackage) {
+		if old, ok := newest[p.key()]; !ok || p.At.After(old.At) {
+			newest[p.key()] = p
+		}
+	}
+
+	receipts, _ := filepath.Glob(filepath.Join(prefix, "Cellar", "*", "*", "INSTALL_RECEIPT.json"))
+	for _, path := range receipts {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var receipt struct {
+			Time int64 `json:"time"`
+		}
+		if json.Unmarshal(data, &receipt) != nil || receipt.Time == 0 {
+			continue
+		}
+		versionDir := filepath.Dir(path)
+		keep(brewPackage{
+			Name:    filepath.Base(filepath.Dir(versionDir)),
+			Version: filepath.Base(versionDir),
+			At:      time.Unix(receipt.Time, 0),
+		})
+	}
+
+	// Caskroom/<cask>/.metadata/<version>/<timestamp>
+	installs, _ := filepath.Glob(filepath.Join(prefix, "Caskroom", "*", ".metadata", "*", "*"))
+	for _, path := range installs {
+		at, err := time.ParseInLocation(caskTimestampLayout, filepath.Base(path), time.UTC)
+		if err != nil {
+			continue
+		}
+		versionDir := filepath.Dir(path)
+		keep(brewPackage{
+			Name:    filepath.Base(filepath.Dir(filepath.Dir(versionDir))),
+			Version: filepath.Base(versionDir),
+			Cask:    true,
+			At:      at.Local(),
+		})
+	}
+
+	packages := make([]brewPackage, 0, len(newest))
+	for _, p := range newest {
+		packages = append(packages, p)
+	}
+	return packages
+}
+
+// brewVersions maps each package's snapshot key to its version
+func brewVersions(packages []brewPackage) map[string]string {
+	versions := make(map[string]string, len(packages))
+	for _, p := range packages {
+		versions[p.key()] = p.Version
+	}
+	return versions
+}
+
+// previousBrewVersions returns the newest snapshot saved before the day
+// that starts the window, or nil when there's none
+func previousBrewVersions(start time.Time) map[string]string {
+	day := DayStart(start)
+	for i := 1; i <= state.KeepDays; i++ {
+		var versions map[string]string
+		if found, err := state.Load(state.KindBrew, DayKey(day.AddDate(0, 0, -i)), &versions); err == nil && found {
+			return versions
+		}
+	}
+	return nil
+}
+
+// summarizeBrewChanges keeps the packages installed in w. Against an
+// earlier snapshot, a package it lacked is new and one at another version
+// was upgraded; without one, every change counts as an install.
+func summarizeBrewChanges(packages []brewPackage, previous map[string]string, w Window) UpdatesResult {
+	result := UpdatesResult{Available: true}
+	for _, p := range packages {
+		if p.At.Before(w.Start) || !p.At.Before(w.End) {
+			continue
+		}
+		change := BrewChange{Name: p.Name, Version: p.Version, Cask: p.Cask, At: p.At}
+		from, known := previous[p.key()]
+		switch {
+		case known && from != p.Version:
+			change.From = from
+			result.Upgraded++
+		case known:
+			// Reinstalled at the same version
+			result.Upgraded++
+		default:
+			result.Installed++
+		}
+		result.Brew = append(result.Brew, change)
+	}
+	sort.Slice(result.Brew, func(i, j int) bool { return result.Brew[i].At.Before(result.Brew[j].At) })
+	return result
+}
+
+// readPendingUpdates lists the updates from the last background check
+func readPendingUpdates(ctx context.Context) ([]SoftwareUpdate, error) {
+	output, err := exec.CommandContext(ctx, "plutil", "-extract", "RecommendedUpdates", "json", "-o", "-", softwareUpdatePlist).Output()
+	if err != nil {
+		// No updates pending leaves the key out
+		if _, statErr := os.Stat(softwareUpdatePlist); statErr == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pending updates: %w", err)
+	}
+	return parsePendingUpdates(output)
+}
+
+// parsePendingUpdates reads the RecommendedUpdates array as JSON
+func parsePendingUpdates(data []byte) ([]SoftwareUpdate, error) {
+	var recommended []struct {
+		Name    string `json:"Display Name"`
+		Version string `json:"Display Version"`
+	}
+	if err := json.Unmarshal(data, &recommended); err != nil {
+		return nil, fmt.Errorf("failed to parse pending updates: %w", err)
+	}
+	var updates []SoftwareUpdate
+	for _, r := range recommended {
+		if r.Name != "" {
+			updates = append(updates, SoftwareUpdate{Name: r.Name, Version: r.Version})
+		}
+	}
+	return updates, nil
+}
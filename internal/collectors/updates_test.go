@@ -0,0 +1,86 @@
+package collectors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanBrewAndChanges(t *testing.T) {
+	t.Parallel()
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.Local)
+	}
+	prefix := t.TempDir()
+	receipt := func(name, version string, installed time.Time) {
+		dir := filepath.Join(prefix, "Cellar", name, version)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		data := fmt.Sprintf(`{"time": %d, "installed_on_request": true}`, installed.Unix())
+		if err := os.WriteFile(filepath.Join(dir, "INSTALL_RECEIPT.json"), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cask := func(name, version string, installed time.Time) {
+		dir := filepath.Join(prefix, "Caskroom", name, ".metadata", version, installed.UTC().Format(caskTimestampLayout))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	receipt("node", "21.7.3", at(2, 10))
+	receipt("node", "22.1.0", at(9, 9)) // Upgraded, old keg not cleaned up yet
+	receipt("ripgrep", "14.1.0", at(9, 11))
+	receipt("git", "2.44.0", at(1, 8))
+	cask("firefox", "124.0", at(9, 14))
+	if err := os.MkdirAll(filepath.Join(prefix, "Cellar", "broken", "1.0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	packages := scanBrew(prefix)
+	versions := brewVersions(packages)
+	if len(versions) != 4 || versions["node"] != "22.1.0" || versions["cask:firefox"] != "124.0" {
+		t.Fatalf("versions = %v, want node 22.1.0, ripgrep, git, and the firefox cask", versions)
+	}
+
+	w := Window{Start: at(9, 0), End: at(9, 23)}
+	previous := map[string]string{"node": "21.7.3", "git": "2.44.0", "cask:firefox": "123.0"}
+	got := summarizeBrewChanges(packages, previous, w)
+	if got.Installed != 1 || got.Upgraded != 2 || len(got.Brew) != 3 {
+		t.Fatalf("got %d installed, %d upgraded: %+v; want 1 and 2", got.Installed, got.Upgraded, got.Brew)
+	}
+	want := []BrewChange{
+		{Name: "node", Version: "22.1.0", From: "21.7.3", At: at(9, 9)},
+		{Name: "ripgrep", Version: "14.1.0", At: at(9, 11)},
+		{Name: "firefox", Version: "124.0", From: "123.0", Cask: true, At: at(9, 14)},
+	}
+	for i := range want {
+		if g := got.Brew[i]; g.Name != want[i].Name || g.Version != want[i].Version || g.From != want[i].From || g.Cask != want[i].Cask || !g.At.Equal(want[i].At) {
+			t.Errorf("change %d = %+v, want %+v", i, g, want[i])
+		}
+	}
+
+	// Without an earlier snapshot every change counts as an install
+	if got := summarizeBrewChanges(packages, nil, w); got.Installed != 3 || got.Upgraded != 0 {
+		t.Errorf("without a snapshot: %d installed, %d upgraded; want 3 and 0", got.Installed, got.Upgraded)
+	}
+}
+
+func TestParsePendingUpdates(t *testing.T) {
+	t.Parallel()
+	data := []byte(`[
+		{"Display Name": "macOS Sequoia 15.1", "Display Version": "15.1", "Identifier": "MSU_UPDATE_24B83_patch_15.1"},
+		{"Display Name": "Safari", "Display Version": "18.1"},
+		{"Identifier": "nameless"}
+	]`)
+	got, err := parsePendingUpdates(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != (SoftwareUpdate{"macOS Sequoia 15.1", "15.1"}) || got[1].Name != "Safari" {
+		t.Errorf("updates = %+v, want macOS Sequoia 15.1 and Safari", got)
+	}
+}
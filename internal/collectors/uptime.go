@@ -16,12 +16,20 @@ type UptimeResult struct {
 	BootTime      time.Time
 	AwakeMinutes  int
 	FormattedTime string
-	Available     bool
-	Error         error
+	SleepPeriods  []Period      // Sleep recorded in the pmset log, clipped to the window
+	InputIdle     time.Duration // Time since the last keyboard or mouse input; live windows only
+
+	// Set by ReconcileAwake
+	RawAwakeMinutes  int    // Awake time before idle stretches were taken out
+	IdleAwakeMinutes int    // Awake with the display off or no input for SessionGap or longer, such as a closed lid that didn't sleep
+	Confidence       string // AwakeConfidenceHigh, AwakeConfidenceMedium, or AwakeConfidenceLow; empty until reconciled
+
+	Available bool
+	Error     error
 }
 
-// CollectUptime retrieves system boot time and calculates awake time since midnight
-func CollectUptime(ctx context.Context) UptimeResult {
+// CollectUptime retrieves system boot time and calculates awake time in w
+func CollectUptime(ctx context.Context, w Window) UptimeResult {
 	result := UptimeResult{Available: false}
 
 	// Read kernel boot time via sysctl
@@ -48,63 +56,71 @@ func CollectUptime(ctx context.Context) UptimeResult {
 
 	result.BootTime = time.Unix(bootTimeSec, 0)
 
-	// Calculate awake time since midnight, subtracting sleep periods
-	now := time.Now()
-	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Calculate awake time in the window since boot, subtracting sleep periods
+	var awakeDuration time.Duration
+	if awakeStart, awakeEnd, ok := w.clip(result.BootTime, w.End); ok {
+		awakeDuration = awakeEnd.Sub(awakeStart)
 
-	var awakeStart time.Time
-	if result.BootTime.Before(midnight) {
-		awakeStart = midnight
-	} else {
-		awakeStart = result.BootTime
+		// Subtract sleep time from awake duration
+		result.SleepPeriods = collectSleepPeriods(ctx, awakeStart, awakeEnd)
+		for _, p := range result.SleepPeriods {
+			awakeDuration -= p.End.Sub(p.Start)
+		}
+		if awakeDuration < 0 {
+			awakeDuration = 0
+		}
 	}
 
-	awakeDuration := now.Sub(awakeStart)
-
-	// Subtract sleep time from awake duration
-	sleepDuration := collectSleepDuration(ctx, awakeStart, now)
-	awakeDuration -= sleepDuration
-	if awakeDuration < 0 {
-		awakeDuration = 0
+	if w.Live() {
+		if idle, err := readInputIdle(ctx); err == nil {
+			result.InputIdle = idle
+		}
 	}
 
 	result.AwakeMinutes = int(awakeDuration.Minutes())
+	result.FormattedTime = formatAwake(result.AwakeMinutes)
+	result.Available = true
+	return result
+}
 
-	// Format time
-	hours := result.AwakeMinutes / 60
-	mins := result.AwakeMinutes % 60
+// formatAwake formats awake minutes, e.g. "7h 12m awake"
+func formatAwake(minutes int) string {
+	hours := minutes / 60
+	mins := minutes % 60
 	if hours > 0 {
-		result.FormattedTime = fmt.Sprintf("%dh %dm awake", hours, mins)
-	} else {
-		result.FormattedTime = fmt.Sprintf("%dm awake", mins)
+		return fmt.Sprintf("%dh %dm awake", hours, mins)
 	}
-
-	result.Available = true
-	return result
+	return fmt.Sprintf("%dm awake", mins)
 }
 
 var sleepPattern = regexp.MustCompile(`\bSleep\b`)
 var wakePattern = regexp.MustCompile(`\bWake\b`)
 
-// uptimeTimestampPattern is a local copy to avoid cross-file dependency on battery.go
-var uptimeTimestampPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})`)
-
-// collectSleepDuration runs pmset -g log and returns total sleep time between start and end.
-func collectSleepDuration(ctx context.Context, start, end time.Time) time.Duration {
+// collectSleepPeriods runs pmset -g log and returns the sleep periods between start and end.
+func collectSleepPeriods(ctx context.Context, start, end time.Time) []Period {
 	cmd := exec.CommandContext(ctx, "pmset", "-g", "log")
 	output, err := cmd.Output()
 	if err != nil {
-		return 0
+		return nil
 	}
-	return parseSleepWakeEvents(string(output), start, end)
+	return parseSleepPeriods(string(output), start, end)
 }
 
 // parseSleepWakeEvents parses pmset log output and returns total sleep duration
 // between start and end times. Internal helper, tested via same-package tests.
 func parseSleepWakeEvents(output string, start, end time.Time) time.Duration {
-	yesterday := start.Add(-24 * time.Hour).Format("2006-01-02")
-	today := start.Format("2006-01-02")
 	var totalSleep time.Duration
+	for _, p := range parseSleepPeriods(output, start, end) {
+		totalSleep += p.End.Sub(p.Start)
+	}
+	return totalSleep
+}
+
+// parseSleepPeriods parses pmset log output and returns the sleep periods,
+// oldest first, clipped to start and end
+func parseSleepPeriods(output string, start, end time.Time) []Period {
+	earliest := start.Add(-24 * time.Hour)
+	var periods []Period
 	var sleepStart time.Time
 	inSleep := false
 
@@ -119,23 +135,13 @@ func parseSleepWakeEvents(output string, start, end time.Time) time.Duration {
 			continue
 		}
 
-		tsMatches := uptimeTimestampPattern.FindStringSubmatch(line)
-		if len(tsMatches) < 2 {
-			continue
-		}
-
-		// Allow yesterday's date (for cross-midnight sleep) and today's date
-		datePrefix := tsMatches[1][:10]
-		if datePrefix != today && datePrefix != yesterday {
-			continue
-		}
-
-		ts, err := time.ParseInLocation("2006-01-02 15:04:05", tsMatches[1], start.Location())
-		if err != nil {
+		ts, ok := parsePmsetTime(line, start.Location())
+		if !ok {
 			continue
 		}
 
-		if ts.After(end) {
+		// Allow up to a day before start (for sleep spanning the day boundary)
+		if ts.Before(earliest) || ts.After(end) {
 			continue
 		}
 
@@ -149,7 +155,7 @@ func parseSleepWakeEvents(output string, start, end time.Time) time.Duration {
 				effectiveStart = start
 			}
 			if ts.After(start) {
-				totalSleep += ts.Sub(effectiveStart)
+				periods = append(periods, Period{Start: effectiveStart, End: ts})
 			}
 			inSleep = false
 		}
@@ -161,8 +167,8 @@ func parseSleepWakeEvents(output string, start, end time.Time) time.Duration {
 		if effectiveStart.Before(start) {
 			effectiveStart = start
 		}
-		totalSleep += end.Sub(effectiveStart)
+		periods = append(periods, Period{Start: effectiveStart, End: end})
 	}
 
-	return totalSleep
+	return periods
 }
@@ -0,0 +1,256 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// vpnSampleMaxCredit caps the time a connected sample stands for. Without
+// "rekap sample" running, VPN state is only seen when rekap runs, so the cap
+// allows for gaps between runs without counting a night asleep.
+const vpnSampleMaxCredit = 30 * time.Minute
+
+// VPNResult contains the time connected to a VPN in the window
+type VPNResult struct {
+	Minutes   int
+	Connected bool   // Connected at this run; live windows only
+	Name      string // The VPN connected now, or the one used most in the window
+	Samples   int
+	Available bool
+	Error     error
+}
+
+// vpnSample is a single VPN reading persisted to the daily log
+type vpnSample struct {
+	Timestamp string `json:"timestamp"`
+	Connected bool   `json:"connected"`
+	Name      string `json:"name,omitempty"`
+}
+
+// vpnApps maps the processes of VPN clients to their names. A tunnel
+// interface with an address is named after whichever of them is running.
+var vpnApps = map[string]string{
+	"PanGPS":               "GlobalProtect",
+	"GlobalProtect":        "GlobalProtect",
+	"vpnagentd":            "Cisco Secure Client",
+	"Cisco AnyConnect":     "Cisco Secure Client",
+	"FortiClient":          "FortiClient",
+	"fctservctl2":          "FortiClient",
+	"ZscalerTunnel":        "Zscaler",
+	"Tailscale":            "Tailscale",
+	"tailscaled":           "Tailscale",
+	"WireGuard":            "WireGuard",
+	"openvpn":              "OpenVPN",
+	"Tunnelblick":          "Tunnelblick",
+	"NordVPN":              "NordVPN",
+	"Mullvad VPN":          "Mullvad",
+	"mullvad-daemon":       "Mullvad",
+	"Cloudflare WARP":      "Cloudflare WARP",
+	"ExpressVPN":           "ExpressVPN",
+	"Pulse Secure":         "Ivanti Secure Access",
+	"Ivanti Secure Access": "Ivanti Secure Access",
+}
+
+// RecordVPNSample checks whether a VPN is connected and appends it to
+// today's sample log
+func RecordVPNSample(ctx context.Context) (vpnSample, error) {
+	sample, err := readVPN(ctx)
+	if err != nil {
+		return sample, err
+	}
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadVPNSamples(DayKey(now))
+	if shouldRecordVPNSample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindVPN, DayKey(now), samples); err != nil {
+			return sample, fmt.Errorf("failed to save VPN sample: %w", err)
+		}
+	}
+	return sample, nil
+}
+
+// CollectVPN reports the time connected to a VPN in w, from samples taken by
+// each run and by "rekap sample"
+func CollectVPN(ctx context.Context, w Window) VPNResult {
+	var current vpnSample
+	var sampleErr error
+	if w.Live() {
+		current, sampleErr = RecordVPNSample(ctx)
+	}
+
+	var samples []vpnSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadVPNSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeVPNSamples(samples, w)
+	if w.Live() && sampleErr == nil {
+		result.Connected = current.Connected
+		if current.Connected {
+			result.Name = current.Name
+		}
+	}
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("VPN state unavailable: %w", sampleErr)
+	}
+	return result
+}
+
+// summarizeVPNSamples credits each connected sample in w with the time until
+// the next sample (or the end of w), capped at vpnSampleMaxCredit
+func summarizeVPNSamples(samples []vpnSample, w Window) VPNResult {
+	result := VPNResult{Available: false}
+
+	type timedSample struct {
+		vpnSample
+		at time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s, at})
+	}
+	if len(timed) == 0 {
+		result.Error = fmt.Errorf("no VPN samples recorded in this window")
+		return result
+	}
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := cappedCredits(times, w.End, vpnSampleMaxCredit)
+
+	var connected time.Duration
+	byName := make(map[string]time.Duration)
+	for i, s := range timed {
+		if !s.Connected {
+			continue
+		}
+		connected += credits[i]
+		byName[s.Name] += credits[i]
+	}
+	var most time.Duration
+	for name, d := range byName {
+		if d > most || (d == most && name < result.Name) {
+			result.Name, most = name, d
+		}
+	}
+
+	result.Minutes = int(connected.Minutes())
+	result.Samples = len(timed)
+	result.Available = true
+	return result
+}
+
+// readVPN checks the VPN services macOS manages, then tunnel interfaces that
+// have an IPv4 address, which is how client apps with their own tunnels
+// show up. macOS keeps a few utun interfaces for its own use, but they only
+// carry IPv6 link-local addresses.
+func readVPN(ctx context.Context) (vpnSample, error) {
+	if out, err := exec.CommandContext(ctx, "scutil", "--nc", "list").Output(); err == nil {
+		if name := parseConnectedServices(string(out)); name != "" {
+			return vpnSample{Connected: true, Name: name}, nil
+		}
+	}
+
+	out, err := exec.CommandContext(ctx, "ifconfig").Output()
+	if err != nil {
+		return vpnSample{}, fmt.Errorf("failed to list interfaces: %w", err)
+	}
+	tunnel := parseTunnelInterface(string(out))
+	if tunnel == "" {
+		return vpnSample{Connected: false}, nil
+	}
+
+	name := "VPN (" + tunnel + ")"
+	if ps, err := exec.CommandContext(ctx, "ps", "-axo", "comm=").Output(); err == nil {
+		if app := runningVPNApp(string(ps)); app != "" {
+			name = app
+		}
+	}
+	return vpnSample{Connected: true, Name: name}, nil
+}
+
+var connectedServiceRe = regexp.MustCompile(`\(Connected\).*"([^"]+)"`)
+
+// parseConnectedServices returns the first connected service in `scutil --nc
+// list` output:
+//
+//   - (Connected)      9A8B... IPSec              "Work VPN"                       [IPSec]
+func parseConnectedServices(output string) string {
+	for line := range strings.SplitSeq(output, "\n") {
+		if m := connectedServiceRe.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+var interfaceHeaderRe = regexp.MustCompile(`^([a-z]+\d+): flags=`)
+
+// parseTunnelInterface returns the first utun, ipsec, or ppp interface in
+// ifconfig output that is up with an IPv4 address, or ""
+func parseTunnelInterface(output string) string {
+	current := ""
+	up := false
+	for line := range strings.SplitSeq(output, "\n") {
+		if m := interfaceHeaderRe.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			up = strings.Contains(line, "<UP")
+			continue
+		}
+		isTunnel := strings.HasPrefix(current, "utun") || strings.HasPrefix(current, "ipsec") || strings.HasPrefix(current, "ppp")
+		if isTunnel && up && strings.HasPrefix(strings.TrimSpace(line), "inet ") {
+			return current
+		}
+	}
+	return ""
+}
+
+// runningVPNApp returns the name of the first known VPN client among the
+// process paths in `ps -axo comm=` output
+func runningVPNApp(output string) string {
+	for line := range strings.SplitSeq(output, "\n") {
+		if name, ok := vpnApps[filepath.Base(strings.TrimSpace(line))]; ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func shouldRecordVPNSample(samples []vpnSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadVPNSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadVPNSamples(date string) ([]vpnSample, error) {
+	var samples []vpnSample
+	if _, err := state.Load(state.KindVPN, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,73 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseVPN(t *testing.T) {
+	t.Parallel()
+	scutil := `Available network connection services in the current set (*=enabled):
+* (Disconnected)   1F2E3D4C-0000-0000-0000-000000000001 PPP --> L2TP       "Old L2TP"                       [PPP/L2TP]
+* (Connected)      9A8B7C6D-0000-0000-0000-000000000002 IPSec              "Work VPN"                       [IPSec]
+`
+	if got := parseConnectedServices(scutil); got != "Work VPN" {
+		t.Errorf("parseConnectedServices = %q, want Work VPN", got)
+	}
+	if got := parseConnectedServices("* (Disconnected)   ABC IPSec \"Work VPN\" [IPSec]\n"); got != "" {
+		t.Errorf("parseConnectedServices with nothing connected = %q", got)
+	}
+
+	ifconfig := `lo0: flags=8049<UP,LOOPBACK,RUNNING,MULTICAST> mtu 16384
+	inet 127.0.0.1 netmask 0xff000000
+en0: flags=8863<UP,BROADCAST,SMART,RUNNING,SIMPLEX,MULTICAST> mtu 1500
+	inet 192.168.1.20 netmask 0xffffff00 broadcast 192.168.1.255
+utun0: flags=8051<UP,POINTOPOINT,RUNNING,MULTICAST> mtu 1380
+	inet6 fe80::1%utun0 prefixlen 64 scopeid 0x10
+utun4: flags=8051<UP,POINTOPOINT,RUNNING,MULTICAST> mtu 1280
+	inet 100.101.102.103 --> 100.101.102.103 netmask 0xffffffff
+`
+	if got := parseTunnelInterface(ifconfig); got != "utun4" {
+		t.Errorf("parseTunnelInterface = %q, want utun4", got)
+	}
+	systemOnly, _, _ := strings.Cut(ifconfig, "utun4:")
+	if got := parseTunnelInterface(systemOnly); got != "" {
+		t.Errorf("parseTunnelInterface with only system tunnels = %q", got)
+	}
+
+	ps := "/sbin/launchd\n/Applications/Tailscale.app/Contents/MacOS/Tailscale\n/usr/libexec/UserEventAgent\n"
+	if got := runningVPNApp(ps); got != "Tailscale" {
+		t.Errorf("runningVPNApp = %q, want Tailscale", got)
+	}
+}
+
+func TestSummarizeVPNSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 2, 18, hour, minute, 0, 0, time.Local)
+	}
+	sample := func(hour, minute int, name string) vpnSample {
+		return vpnSample{Timestamp: at(hour, minute).Format(time.RFC3339), Connected: name != "", Name: name}
+	}
+	w := Window{Start: at(0, 0), End: at(17, 0)}
+
+	result := summarizeVPNSamples([]vpnSample{
+		sample(9, 0, "GlobalProtect"),
+		sample(9, 20, "GlobalProtect"),
+		sample(13, 0, ""), // GlobalProtect's 9:20 credit stops at 30 minutes
+		sample(14, 0, "Tailscale"),
+		sample(14, 10, ""),
+		sample(16, 50, "GlobalProtect"),
+	}, w)
+	if !result.Available || result.Samples != 6 {
+		t.Fatalf("summarizeVPNSamples = %+v", result)
+	}
+	if result.Minutes != 70 || result.Name != "GlobalProtect" {
+		t.Errorf("got %d minutes on %q, want 70 on GlobalProtect", result.Minutes, result.Name)
+	}
+
+	if empty := summarizeVPNSamples(nil, w); empty.Available {
+		t.Error("expected unavailable with no samples")
+	}
+}
@@ -0,0 +1,337 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/compat"
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// WiFiResult contains Wi-Fi link quality aggregated over a window's samples
+type WiFiResult struct {
+	AvgRSSI          int    // Average signal strength in dBm
+	AvgNoise         int    // Average noise floor in dBm
+	AvgSNR           int    // Average signal-to-noise ratio in dB
+	AvgTxRate        int    // Average transmit rate in Mbps
+	Quality          string // "excellent", "good", "fair", or "poor"
+	Samples          int    // Number of samples recorded in the window
+	WorstPeriodStart time.Time
+	WorstPeriodSNR   int           // Average SNR during the worst hour
+	FirstSeen        time.Time     // First sample in the window: the earliest run on Wi-Fi
+	Networks         []WiFiNetwork // Most time first; samples from before SSIDs were recorded don't count
+	Switches         int           // Times the network changed between samples
+	Available        bool
+	Error            error
+}
+
+// WiFiNetwork is the time spent on one Wi-Fi network
+type WiFiNetwork struct {
+	SSID    string
+	Minutes int
+}
+
+// wifiSample is a single link-quality reading persisted to the daily log
+type wifiSample struct {
+	Timestamp string `json:"timestamp"`
+	RSSI      int    `json:"rssi"`
+	Noise     int    `json:"noise"`
+	TxRate    int    `json:"tx_rate"`
+	SSID      string `json:"ssid,omitempty"`
+}
+
+const (
+	// wifiSampleMinInterval keeps rapid back-to-back runs from skewing averages
+	wifiSampleMinInterval = time.Minute
+
+	// wifiSampleMaxCredit caps the time a sample stands for on its network,
+	// as for app samples, so a gap between samples isn't credited to the
+	// network joined before it
+	wifiSampleMaxCredit = 5 * time.Minute
+)
+
+// RecordWiFiSample reads the current Wi-Fi network and link quality (RSSI,
+// noise, and transmit rate) and appends it to today's sample log
+func RecordWiFiSample(ctx context.Context) (wifiSample, error) {
+	sample, err := readWiFiSample(ctx)
+	if err != nil {
+		return sample, err
+	}
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadWiFiSamples(DayKey(now))
+	if shouldRecordWiFiSample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindWiFi, DayKey(now), samples); err != nil {
+			return sample, fmt.Errorf("failed to save Wi-Fi sample: %w", err)
+		}
+	}
+	return sample, nil
+}
+
+// CollectWiFi samples the current Wi-Fi link quality (for live windows) and
+// summarizes all samples recorded in w. Each run (or watch refresh) and each
+// "rekap sample" contributes one, so with sampling running the hourly
+// buckets, and the worst hour picked from them, cover the whole day.
+func CollectWiFi(ctx context.Context, w Window) WiFiResult {
+	var err error
+	if w.Live() {
+		_, err = RecordWiFiSample(ctx)
+	}
+
+	var samples []wifiSample
+	for _, day := range w.dayKeys() {
+		daySamples, _ := loadWiFiSamples(day)
+		for _, s := range daySamples {
+			if ts, perr := time.Parse(time.RFC3339, s.Timestamp); perr == nil && !ts.Before(w.Start) && !ts.After(w.End) {
+				samples = append(samples, s)
+			}
+		}
+	}
+
+	result := summarizeWiFiSamples(samples, w.End)
+	if !result.Available && err != nil {
+		result.Error = fmt.Errorf("failed to read Wi-Fi link quality: %w", err)
+	}
+	return result
+}
+
+// airportPath is the airport tool, present before macOS 14.4
+const airportPath = "/System/Library/PrivateFrameworks/Apple80211.framework/Versions/Current/Resources/airport"
+
+// readWiFiSample reads RSSI, noise, and transmit rate for the current Wi-Fi
+// link, with the tools compat picks for this macOS version
+func readWiFiSample(ctx context.Context) (wifiSample, error) {
+	err := fmt.Errorf("not connected to Wi-Fi")
+	for _, tool := range compat.WiFiSignalTools(compat.Current()) {
+		switch tool {
+		case compat.ToolAirport:
+			if output, aerr := exec.CommandContext(ctx, airportPath, "-I").Output(); aerr == nil {
+				if sample, ok := parseAirportInfo(string(output)); ok {
+					if m := airportSSIDRe.FindStringSubmatch(string(output)); len(m) >= 2 {
+						sample.SSID = strings.TrimSpace(m[1])
+					}
+					return sample, nil
+				}
+			}
+		case compat.ToolSystemProfiler:
+			output, perr := exec.CommandContext(ctx, "system_profiler", "SPAirPortDataType").Output()
+			if perr != nil {
+				err = perr
+				continue
+			}
+			if sample, ok := parseSystemProfilerWiFi(string(output)); ok {
+				sample.SSID = parseSystemProfilerSSID(string(output))
+				return sample, nil
+			}
+		}
+	}
+	return wifiSample{}, err
+}
+
+var (
+	airportRSSIRe   = regexp.MustCompile(`agrCtlRSSI:\s*(-?\d+)`)
+	airportNoiseRe  = regexp.MustCompile(`agrCtlNoise:\s*(-?\d+)`)
+	airportTxRateRe = regexp.MustCompile(`lastTxRate:\s*(\d+)`)
+
+	profilerSignalRe = regexp.MustCompile(`Signal / Noise:\s*(-?\d+) dBm / (-?\d+) dBm`)
+	profilerTxRateRe = regexp.MustCompile(`Transmit Rate:\s*(\d+)`)
+)
+
+// parseAirportInfo parses `airport -I` output. Returns false when not associated.
+func parseAirportInfo(output string) (wifiSample, bool) {
+	var sample wifiSample
+
+	rssi := airportRSSIRe.FindStringSubmatch(output)
+	noise := airportNoiseRe.FindStringSubmatch(output)
+	if len(rssi) < 2 || len(noise) < 2 {
+		return sample, false
+	}
+	sample.RSSI, _ = strconv.Atoi(rssi[1])
+	sample.Noise, _ = strconv.Atoi(noise[1])
+	if m := airportTxRateRe.FindStringSubmatch(output); len(m) >= 2 {
+		sample.TxRate, _ = strconv.Atoi(m[1])
+	}
+
+	// airport reports zeros when Wi-Fi is on but not associated
+	if sample.RSSI == 0 {
+		return sample, false
+	}
+	return sample, true
+}
+
+// parseSystemProfilerWiFi parses the "Current Network Information" block of
+// `system_profiler SPAirPortDataType` output.
+func parseSystemProfilerWiFi(output string) (wifiSample, bool) {
+	var sample wifiSample
+
+	m := profilerSignalRe.FindStringSubmatch(output)
+	if len(m) < 3 {
+		return sample, false
+	}
+	sample.RSSI, _ = strconv.Atoi(m[1])
+	sample.Noise, _ = strconv.Atoi(m[2])
+	if tx := profilerTxRateRe.FindStringSubmatch(output); len(tx) >= 2 {
+		sample.TxRate, _ = strconv.Atoi(tx[1])
+	}
+	return sample, sample.RSSI != 0
+}
+
+// shouldRecordWiFiSample reports whether enough time has passed since the last sample
+func shouldRecordWiFiSample(samples []wifiSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= wifiSampleMinInterval
+}
+
+// summarizeWiFiSamples averages samples, finds the hour with the worst SNR,
+// and credits each sample's network with the time until the next sample (or
+// end), capped at wifiSampleMaxCredit
+func summarizeWiFiSamples(samples []wifiSample, end time.Time) WiFiResult {
+	result := WiFiResult{Available: false}
+	if len(samples) == 0 {
+		return result
+	}
+
+	type hourBucket struct {
+		start    time.Time
+		snrTotal int
+		count    int
+	}
+	buckets := make(map[int64]*hourBucket)
+
+	var rssiTotal, noiseTotal, txTotal int
+	for _, s := range samples {
+		rssiTotal += s.RSSI
+		noiseTotal += s.Noise
+		txTotal += s.TxRate
+
+		ts, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil {
+			continue
+		}
+		ts = ts.Local()
+		if result.FirstSeen.IsZero() || ts.Before(result.FirstSeen) {
+			result.FirstSeen = ts
+		}
+		hour := time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), 0, 0, 0, ts.Location())
+		b, ok := buckets[hour.Unix()]
+		if !ok {
+			b = &hourBucket{start: hour}
+			buckets[hour.Unix()] = b
+		}
+		b.snrTotal += s.RSSI - s.Noise
+		b.count++
+	}
+
+	n := len(samples)
+	result.Samples = n
+	result.AvgRSSI = rssiTotal / n
+	result.AvgNoise = noiseTotal / n
+	result.AvgTxRate = txTotal / n
+	result.AvgSNR = result.AvgRSSI - result.AvgNoise
+	result.Quality = wifiQuality(result.AvgSNR)
+
+	// A worst period is only meaningful once there is more than one hour to compare
+	if len(buckets) > 1 {
+		sorted := make([]*hourBucket, 0, len(buckets))
+		for _, b := range buckets {
+			sorted = append(sorted, b)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			ai := sorted[i].snrTotal / sorted[i].count
+			aj := sorted[j].snrTotal / sorted[j].count
+			if ai != aj {
+				return ai < aj
+			}
+			return sorted[i].start.Before(sorted[j].start)
+		})
+		result.WorstPeriodStart = sorted[0].start
+		result.WorstPeriodSNR = sorted[0].snrTotal / sorted[0].count
+	}
+
+	result.Networks, result.Switches = wifiNetworkTime(samples, end)
+	result.Available = true
+	return result
+}
+
+// wifiNetworkTime returns the time per network, most first, and how many
+// times the network changed from one sample to the next
+func wifiNetworkTime(samples []wifiSample, end time.Time) ([]WiFiNetwork, int) {
+	type timedSample struct {
+		ssid string
+		at   time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || s.SSID == "" {
+			continue
+		}
+		timed = append(timed, timedSample{s.SSID, at})
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := cappedCredits(times, end, wifiSampleMaxCredit)
+
+	credit := make(map[string]time.Duration)
+	switches := 0
+	for i, s := range timed {
+		credit[s.ssid] += credits[i]
+		if i > 0 && s.ssid != timed[i-1].ssid {
+			switches++
+		}
+	}
+
+	networks := make([]WiFiNetwork, 0, len(credit))
+	for ssid, d := range credit {
+		networks = append(networks, WiFiNetwork{SSID: ssid, Minutes: int(d.Minutes())})
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		if networks[i].Minutes != networks[j].Minutes {
+			return networks[i].Minutes > networks[j].Minutes
+		}
+		return networks[i].SSID < networks[j].SSID
+	})
+	return networks, switches
+}
+
+// wifiQuality maps a signal-to-noise ratio to a coarse quality label.
+// 40+ dB is excellent, 25+ is good for calls, 15+ is usable, below that calls drop.
+func wifiQuality(snr int) string {
+	switch {
+	case snr >= 40:
+		return "excellent"
+	case snr >= 25:
+		return "good"
+	case snr >= 15:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
+
+// loadWiFiSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadWiFiSamples(date string) ([]wifiSample, error) {
+	var samples []wifiSample
+	if _, err := state.Load(state.KindWiFi, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
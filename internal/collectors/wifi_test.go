@@ -0,0 +1,151 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAirportInfo(t *testing.T) {
+	t.Parallel()
+	output := `     agrCtlRSSI: -58
+     agrExtRSSI: 0
+    agrCtlNoise: -92
+    agrExtNoise: 0
+          state: running
+        op mode: station
+     lastTxRate: 866
+        maxRate: 1300
+           SSID: Home-5GHz
+`
+	sample, ok := parseAirportInfo(output)
+	if !ok {
+		t.Fatal("expected sample to be parsed")
+	}
+	if sample.RSSI != -58 || sample.Noise != -92 || sample.TxRate != 866 {
+		t.Errorf("got %+v, want RSSI=-58 Noise=-92 TxRate=866", sample)
+	}
+
+	// Not associated: airport reports zeros
+	if _, ok := parseAirportInfo("     agrCtlRSSI: 0\n    agrCtlNoise: 0\n"); ok {
+		t.Error("expected no sample when RSSI is 0")
+	}
+	if _, ok := parseAirportInfo("AirPort: Off\n"); ok {
+		t.Error("expected no sample when Wi-Fi is off")
+	}
+}
+
+func TestParseSystemProfilerWiFi(t *testing.T) {
+	t.Parallel()
+	output := `Wi-Fi:
+      Interfaces:
+        en0:
+          Current Network Information:
+            Home-5GHz:
+              PHY Mode: 802.11ac
+              Channel: 149 (5GHz, 80MHz)
+              Signal / Noise: -52 dBm / -95 dBm
+              Transmit Rate: 780
+`
+	sample, ok := parseSystemProfilerWiFi(output)
+	if !ok {
+		t.Fatal("expected sample to be parsed")
+	}
+	if sample.RSSI != -52 || sample.Noise != -95 || sample.TxRate != 780 {
+		t.Errorf("got %+v, want RSSI=-52 Noise=-95 TxRate=780", sample)
+	}
+}
+
+func TestSummarizeWiFiSamples(t *testing.T) {
+	t.Parallel()
+	if result := summarizeWiFiSamples(nil, time.Now()); result.Available {
+		t.Error("expected unavailable result for no samples")
+	}
+
+	at := func(hour, minute int) string {
+		return time.Date(2026, 2, 18, hour, minute, 0, 0, time.Local).Format(time.RFC3339)
+	}
+	samples := []wifiSample{
+		{Timestamp: at(9, 0), RSSI: -50, Noise: -95, TxRate: 800},
+		{Timestamp: at(9, 30), RSSI: -52, Noise: -95, TxRate: 780},
+		{Timestamp: at(14, 0), RSSI: -80, Noise: -90, TxRate: 100},
+		{Timestamp: at(14, 20), RSSI: -78, Noise: -90, TxRate: 120},
+	}
+
+	result := summarizeWiFiSamples(samples, time.Now())
+	if !result.Available {
+		t.Fatal("expected result to be available")
+	}
+	if result.Samples != 4 {
+		t.Errorf("Samples = %d, want 4", result.Samples)
+	}
+	if result.AvgRSSI != -65 {
+		t.Errorf("AvgRSSI = %d, want -65", result.AvgRSSI)
+	}
+	if result.AvgTxRate != 450 {
+		t.Errorf("AvgTxRate = %d, want 450", result.AvgTxRate)
+	}
+	if result.WorstPeriodStart.Hour() != 14 {
+		t.Errorf("WorstPeriodStart hour = %d, want 14", result.WorstPeriodStart.Hour())
+	}
+	if result.WorstPeriodSNR != 11 {
+		t.Errorf("WorstPeriodSNR = %d, want 11", result.WorstPeriodSNR)
+	}
+
+	// A single hour of data has no meaningful worst period
+	single := summarizeWiFiSamples(samples[:2], time.Now())
+	if !single.WorstPeriodStart.IsZero() {
+		t.Error("expected no worst period with only one hour of samples")
+	}
+}
+
+func TestWiFiQuality(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		snr  int
+		want string
+	}{
+		{45, "excellent"},
+		{40, "excellent"},
+		{30, "good"},
+		{15, "fair"},
+		{10, "poor"},
+	}
+	for _, tt := range tests {
+		if got := wifiQuality(tt.snr); got != tt.want {
+			t.Errorf("wifiQuality(%d) = %q, want %q", tt.snr, got, tt.want)
+		}
+	}
+}
+
+func TestWiFiNetworkTime(t *testing.T) {
+	t.Parallel()
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 2, 18, hour, minute, 0, 0, time.Local)
+	}
+	sample := func(hour, minute int, ssid string) wifiSample {
+		return wifiSample{Timestamp: at(hour, minute).Format(time.RFC3339), RSSI: -60, Noise: -90, SSID: ssid}
+	}
+	samples := []wifiSample{
+		sample(9, 0, "Office"),
+		sample(9, 20, "Office"),
+		{Timestamp: at(9, 25).Format(time.RFC3339), RSSI: -60, Noise: -90}, // Recorded before SSIDs were
+		sample(9, 40, "Office"),
+		sample(12, 0, "Blue Bottle"), // Office's credit stops at 5 minutes
+		sample(12, 10, "Blue Bottle"),
+		sample(12, 30, "Office"),
+	}
+
+	result := summarizeWiFiSamples(samples, at(12, 45))
+	want := []WiFiNetwork{{"Office", 20}, {"Blue Bottle", 10}}
+	if len(result.Networks) != len(want) {
+		t.Fatalf("Networks = %+v, want %+v", result.Networks, want)
+	}
+	for i := range want {
+		if result.Networks[i] != want[i] {
+			t.Errorf("network %d = %+v, want %+v", i, result.Networks[i], want[i])
+		}
+	}
+	if result.Switches != 2 {
+		t.Errorf("Switches = %d, want 2", result.Switches)
+	}
+}
@@ -0,0 +1,214 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// windowTitleScript prints "name<TAB>bundle ID<TAB>title" for the frontmost
+// app when it's one of the apps passed as arguments, and nothing otherwise,
+// so the titles of other apps are never read
+const windowTitleScript = `
+on run argv
+	tell application "System Events"
+		set frontApp to first application process whose frontmost is true
+		set appName to name of frontApp
+		set bundleID to bundle identifier of frontApp
+		if appName is not in argv and bundleID is not in argv then return ""
+		set windowTitle to ""
+		try
+			set windowTitle to name of window 1 of frontApp
+		end try
+		return appName & tab & bundleID & tab & windowTitle
+	end tell
+end run`
+
+// WindowTitle is the time spent in one window of a sampled app
+type WindowTitle struct {
+	App      string
+	BundleID string
+	Title    string
+	Minutes  int
+}
+
+// WindowTitlesResult contains the front window titles of the apps in
+// tracking.window_titles, built from samples taken by each run and by
+// "rekap sample"
+type WindowTitlesResult struct {
+	Titles    []WindowTitle // Most time first
+	Samples   int
+	Available bool
+	Error     error
+}
+
+// windowSample is the front window of an allowlisted app at one moment,
+// persisted to the daily log. An empty Title means another app was in
+// front.
+type windowSample struct {
+	Timestamp string `json:"timestamp"`
+	App       string `json:"app,omitempty"`
+	BundleID  string `json:"bundle_id,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// RecordWindowSample reads the front window title when the frontmost app is
+// in tracking.window_titles and appends it to today's sample log. It needs
+// Accessibility permission and does nothing when no apps are listed.
+func RecordWindowSample(ctx context.Context, cfg *config.Config) error {
+	if len(cfg.Tracking.WindowTitles) == 0 {
+		return nil
+	}
+	sample, err := readWindowTitle(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	sample.Timestamp = now.Format(time.RFC3339)
+	samples, _ := loadWindowSamples(DayKey(now))
+	if shouldRecordWindowSample(samples, now) {
+		samples = append(samples, sample)
+		if err := state.Save(state.KindWindows, DayKey(now), samples); err != nil {
+			return fmt.Errorf("failed to save window sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// CollectWindowTitles adds up the time in each sampled window title in w
+func CollectWindowTitles(ctx context.Context, cfg *config.Config, w Window) WindowTitlesResult {
+	if len(cfg.Tracking.WindowTitles) == 0 {
+		return WindowTitlesResult{Error: fmt.Errorf("window titles are off (list apps in tracking.window_titles)")}
+	}
+
+	var sampleErr error
+	if w.Live() {
+		sampleErr = RecordWindowSample(ctx, cfg)
+	}
+
+	var samples []windowSample
+	for _, day := range w.dayKeys() {
+		daySamples, err := loadWindowSamples(day)
+		if err != nil && sampleErr == nil {
+			sampleErr = err
+		}
+		samples = append(samples, daySamples...)
+	}
+
+	result := summarizeWindowSamples(samples, w)
+	if !result.Available && sampleErr != nil {
+		result.Error = fmt.Errorf("window titles unavailable (requires Accessibility): %w", sampleErr)
+	}
+	return result
+}
+
+// readWindowTitle asks System Events for the front window title of the
+// frontmost app, leaving Title empty when it isn't allowlisted
+func readWindowTitle(ctx context.Context, cfg *config.Config) (windowSample, error) {
+	args := append([]string{"-e", windowTitleScript}, cfg.Tracking.WindowTitles...)
+	output, err := exec.CommandContext(ctx, "osascript", args...).Output()
+	if err != nil {
+		return windowSample{}, err
+	}
+	return parseWindowTitle(string(output), cfg), nil
+}
+
+// parseWindowTitle reads the script's "name<TAB>bundle ID<TAB>title" line.
+// The allowlist is checked again so a title only lands in the log for an
+// app the config lists, whatever AppleScript's matching allowed.
+func parseWindowTitle(output string, cfg *config.Config) windowSample {
+	fields := strings.SplitN(strings.TrimRight(output, "\r\n"), "\t", 3)
+	if len(fields) != 3 {
+		return windowSample{}
+	}
+	name, bundleID, title := fields[0], fields[1], strings.TrimSpace(fields[2])
+	if bundleID == "missing value" {
+		bundleID = ""
+	}
+	if title == "" || !cfg.SamplesWindowTitle(name, bundleID) {
+		return windowSample{}
+	}
+	return windowSample{App: name, BundleID: bundleID, Title: title}
+}
+
+// summarizeWindowSamples credits each sample with a title with the time
+// until the next sample (or the end of w), capped at appSampleMaxCredit
+func summarizeWindowSamples(samples []windowSample, w Window) WindowTitlesResult {
+	result := WindowTitlesResult{Available: false}
+
+	type timedSample struct {
+		windowSample
+		at time.Time
+	}
+	var timed []timedSample
+	for _, s := range samples {
+		at, err := time.Parse(time.RFC3339, s.Timestamp)
+		if err != nil || at.Before(w.Start) || !at.Before(w.End) {
+			continue
+		}
+		timed = append(timed, timedSample{s, at})
+	}
+	if len(timed) == 0 {
+		result.Error = fmt.Errorf("no window samples recorded in this window")
+		return result
+	}
+	sort.Slice(timed, func(i, j int) bool { return timed[i].at.Before(timed[j].at) })
+
+	times := make([]time.Time, len(timed))
+	for i, s := range timed {
+		times[i] = s.at
+	}
+	credits := sampleCredits(times, w.End)
+
+	type windowKey struct{ app, bundleID, title string }
+	credit := make(map[windowKey]time.Duration)
+	for i, s := range timed {
+		if s.Title == "" {
+			continue
+		}
+		credit[windowKey{s.App, s.BundleID, s.Title}] += credits[i]
+	}
+
+	for k, d := range credit {
+		result.Titles = append(result.Titles, WindowTitle{App: k.app, BundleID: k.bundleID, Title: k.title, Minutes: int(d.Minutes())})
+	}
+	sort.Slice(result.Titles, func(i, j int) bool {
+		a, b := result.Titles[i], result.Titles[j]
+		if a.Minutes != b.Minutes {
+			return a.Minutes > b.Minutes
+		}
+		return a.Title < b.Title
+	})
+	result.Samples = len(timed)
+	result.Available = true
+	return result
+}
+
+// shouldRecordWindowSample reports whether enough time has passed since the
+// last sample
+func shouldRecordWindowSample(samples []windowSample, now time.Time) bool {
+	if len(samples) == 0 {
+		return true
+	}
+	last, err := time.Parse(time.RFC3339, samples[len(samples)-1].Timestamp)
+	if err != nil {
+		return true
+	}
+	return now.Sub(last) >= appSampleMinInterval
+}
+
+// loadWindowSamples returns the samples recorded on date (YYYY-MM-DD)
+func loadWindowSamples(date string) ([]windowSample, error) {
+	var samples []windowSample
+	if _, err := state.Load(state.KindWindows, date, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
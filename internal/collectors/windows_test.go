@@ -0,0 +1,69 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestParseWindowTitle(t *testing.T) {
+	t.Parallel()
+	cfg := config.Default()
+	cfg.Tracking.WindowTitles = []string{"Code", "com.apple.Terminal"}
+
+	got := parseWindowTitle("Code\tcom.microsoft.VSCode\tmain.go — rekap\n", cfg)
+	if got.App != "Code" || got.BundleID != "com.microsoft.VSCode" || got.Title != "main.go — rekap" {
+		t.Errorf("parseWindowTitle = %+v, want Code's main.go — rekap", got)
+	}
+	if got := parseWindowTitle("Terminal\tcom.apple.Terminal\tzsh — ~/src/api\n", cfg); got.Title != "zsh — ~/src/api" {
+		t.Errorf("parseWindowTitle(Terminal) = %+v, want its title", got)
+	}
+
+	// Apps off the allowlist, empty output, and windowless apps record nothing
+	for _, output := range []string{"Slack\tcom.tinyspeck.slackmacgap\t#general\n", "", "\n", "Code\tcom.microsoft.VSCode\t\n"} {
+		if got := parseWindowTitle(output, cfg); got != (windowSample{}) {
+			t.Errorf("parseWindowTitle(%q) = %+v, want an empty sample", output, got)
+		}
+	}
+}
+
+func TestSummarizeWindowSamples(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 2, 17, hour, min, 0, 0, time.Local)
+	}
+	sample := func(tm time.Time, title string) windowSample {
+		if title == "" {
+			return windowSample{Timestamp: tm.Format(time.RFC3339)}
+		}
+		return windowSample{Timestamp: tm.Format(time.RFC3339), App: "Code", BundleID: "com.microsoft.VSCode", Title: title}
+	}
+
+	samples := []windowSample{
+		sample(at(8, 55), "old.go — rekap"), // Before the window
+		sample(at(9, 0), "main.go — rekap"),
+		sample(at(9, 2), "main.go — rekap"),
+		sample(at(9, 4), "api.go — clienta-api"),
+		sample(at(9, 5), ""), // Another app in front
+		sample(at(9, 9), "main.go — rekap"),
+	}
+	w := Window{Start: at(9, 0), End: at(9, 10)}
+	result := summarizeWindowSamples(samples, w)
+	if !result.Available || result.Samples != 5 {
+		t.Fatalf("got %+v, want 5 samples available", result)
+	}
+	if len(result.Titles) != 2 {
+		t.Fatalf("Titles = %+v, want 2", result.Titles)
+	}
+	if got := result.Titles[0]; got.Title != "main.go — rekap" || got.Minutes != 5 {
+		t.Errorf("Titles[0] = %+v, want main.go — rekap for 5 minutes", got)
+	}
+	if got := result.Titles[1]; got.Title != "api.go — clienta-api" || got.Minutes != 1 {
+		t.Errorf("Titles[1] = %+v, want api.go — clienta-api for 1 minute", got)
+	}
+
+	if empty := summarizeWindowSamples(nil, w); empty.Available || empty.Error == nil {
+		t.Errorf("expected no titles without samples, got %+v", empty)
+	}
+}
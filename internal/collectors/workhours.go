@@ -0,0 +1,124 @@
+package collectors
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// WorkHours is the part of the week the user works, from
+// schedule.work_hours. Activity outside it counts as after hours.
+type WorkHours struct {
+	Start int            // Minutes after midnight
+	End   int            // Minutes after midnight; before Start wraps past midnight
+	Days  []time.Weekday // Days a shift starts on
+}
+
+// workHours is the configured schedule; nil when work hours aren't set
+var workHours atomic.Pointer[WorkHours]
+
+// SetWorkHours configures the work hours collectors split their metrics
+// by. nil turns the split off.
+func SetWorkHours(h *WorkHours) {
+	workHours.Store(h)
+}
+
+// String returns the hours as "HH:MM-HH:MM"
+func (h WorkHours) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", h.Start/60, h.Start%60, h.End/60, h.End%60)
+}
+
+// periods returns the stretches of work hours that overlap w, oldest first
+func (h WorkHours) periods(w Window) []Period {
+	var out []Period
+	// Start a day early so a shift wrapping past midnight into w is found
+	day := time.Date(w.Start.Year(), w.Start.Month(), w.Start.Day()-1, 0, 0, 0, 0, w.Start.Location())
+	for ; day.Before(w.End); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(h.Days, day.Weekday()) {
+			continue
+		}
+		end := h.End
+		if end <= h.Start {
+			end += 24 * 60
+		}
+		start, stop, ok := w.clip(day.Add(time.Duration(h.Start)*time.Minute), day.Add(time.Duration(end)*time.Minute))
+		if ok {
+			out = append(out, Period{Start: start, End: stop})
+		}
+	}
+	return out
+}
+
+// contains reports whether t falls in work hours
+func (h WorkHours) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if h.End > h.Start {
+		return minute >= h.Start && minute < h.End && slices.Contains(h.Days, t.Weekday())
+	}
+	if minute < h.End {
+		// The early hours of a shift that started the day before
+		return slices.Contains(h.Days, (t.Weekday()+6)%7)
+	}
+	return minute >= h.Start && slices.Contains(h.Days, t.Weekday())
+}
+
+// WorkHoursResult splits the window's activity into work hours and after
+// hours
+type WorkHoursResult struct {
+	Hours                       WorkHours
+	ScreenMinutes               int
+	AfterHoursMinutes           int // Screen-on time outside work hours
+	Notifications               int
+	AfterHoursNotifications     int
+	DistractionVisits           int // Visits to distraction domains in browser history
+	AfterHoursDistractionVisits int
+	Available                   bool
+	Error                       error
+}
+
+// WorkMinutes returns the screen-on time inside work hours
+func (r WorkHoursResult) WorkMinutes() int {
+	return r.ScreenMinutes - r.AfterHoursMinutes
+}
+
+// CalculateWorkHours splits screen time, notifications, and distraction
+// visits in w by the configured work hours
+func CalculateWorkHours(screen ScreenResult, notifications NotificationsResult, browsers BrowsersResult, w Window) WorkHoursResult {
+	h := workHours.Load()
+	if h == nil {
+		return WorkHoursResult{}
+	}
+	return h.Split(screen, notifications, browsers, w)
+}
+
+// Split splits screen time, notifications, and distraction visits in w by
+// h rather than the configured work hours. Notifications and browsers
+// must have been collected with h configured for their after-hours counts.
+func (h WorkHours) Split(screen ScreenResult, notifications NotificationsResult, browsers BrowsersResult, w Window) WorkHoursResult {
+	result := WorkHoursResult{Hours: h, Available: true}
+
+	if screen.Available {
+		work := h.periods(w)
+		var on, after time.Duration
+		for _, p := range screen.OnPeriods {
+			start, end, ok := w.clip(p.Start, p.End)
+			if !ok {
+				continue
+			}
+			on += end.Sub(start)
+			after += end.Sub(start) - overlap(Period{Start: start, End: end}, work)
+		}
+		result.ScreenMinutes = int(on.Minutes())
+		result.AfterHoursMinutes = int(after.Minutes())
+	}
+
+	if notifications.Available {
+		result.Notifications = notifications.TotalNotifications
+		result.AfterHoursNotifications = notifications.AfterHours
+	}
+
+	result.DistractionVisits = browsers.HistoryCategories["distraction"]
+	result.AfterHoursDistractionVisits = browsers.AfterHoursCategories["distraction"]
+	return result
+}
@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+)
+
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+func TestWorkHoursContains(t *testing.T) {
+	t.Parallel()
+	// 2026-10-16 is a Friday
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, 10, day, hour, min, 0, 0, time.UTC)
+	}
+	office := WorkHours{Start: 9 * 60, End: 18 * 60, Days: weekdays}
+	night := WorkHours{Start: 22 * 60, End: 6 * 60, Days: weekdays}
+
+	tests := []struct {
+		name  string
+		hours WorkHours
+		at    time.Time
+		want  bool
+	}{
+		{"office morning", office, at(16, 9, 0), true},
+		{"office end is after hours", office, at(16, 18, 0), false},
+		{"office early", office, at(16, 8, 59), false},
+		{"office Saturday", office, at(17, 12, 0), false},
+		{"night shift evening", night, at(16, 23, 0), true},
+		{"night shift carries into Saturday", night, at(17, 5, 0), true},
+		{"night shift Monday morning", night, at(19, 5, 0), false},
+		{"night shift afternoon", night, at(16, 12, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.hours.contains(tt.at); got != tt.want {
+			t.Errorf("%s: contains(%s) = %v, want %v", tt.name, tt.at.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestCalculateWorkHours(t *testing.T) {
+	t.Parallel()
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 10, 16, hour, min, 0, 0, time.UTC)
+	}
+	hours := WorkHours{Start: 9 * 60, End: 18 * 60, Days: weekdays}
+	w := Window{Start: at(0, 0), End: at(23, 0)}
+	screen := ScreenResult{
+		OnPeriods: []Period{
+			{Start: at(8, 0), End: at(10, 0)},    // An hour before work
+			{Start: at(13, 0), End: at(14, 0)},   // Work
+			{Start: at(17, 30), End: at(19, 10)}, // 1h 10m after work
+		},
+		Available: true,
+	}
+	notifications := NotificationsResult{TotalNotifications: 30, AfterHours: 12, Available: true}
+	browsers := BrowsersResult{
+		HistoryCategories:    map[string]int{"distraction": 9, "work": 20},
+		AfterHoursCategories: map[string]int{"distraction": 4, "work": 2},
+	}
+
+	got := hours.Split(screen, notifications, browsers, w)
+	if !got.Available {
+		t.Fatal("expected result to be available")
+	}
+	if got.ScreenMinutes != 280 || got.AfterHoursMinutes != 130 || got.WorkMinutes() != 150 {
+		t.Errorf("screen %d, after hours %d, work %d; want 280, 130, 150", got.ScreenMinutes, got.AfterHoursMinutes, got.WorkMinutes())
+	}
+	if got.Notifications != 30 || got.AfterHoursNotifications != 12 {
+		t.Errorf("notifications %d, after hours %d; want 30, 12", got.Notifications, got.AfterHoursNotifications)
+	}
+	if got.DistractionVisits != 9 || got.AfterHoursDistractionVisits != 4 {
+		t.Errorf("distraction visits %d, after hours %d; want 9, 4", got.DistractionVisits, got.AfterHoursDistractionVisits)
+	}
+
+	burnout := CheckAfterHours(BurnoutResult{Available: true}, got, DefaultBurnoutConfig())
+	if len(burnout.Warnings) != 1 || burnout.Warnings[0].Message != "After hours: 2h 10m of screen time outside work hours" {
+		t.Errorf("warnings = %+v", burnout.Warnings)
+	}
+}
+
+func TestCalculateWorkHoursUnset(t *testing.T) {
+	t.Parallel()
+	if got := CalculateWorkHours(ScreenResult{Available: true}, NotificationsResult{}, BrowsersResult{}, Today(time.Now())); got.Available {
+		t.Errorf("expected no result without work hours, got %+v", got)
+	}
+}
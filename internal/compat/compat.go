@@ -0,0 +1,183 @@
+// Package compat detects the macOS version and picks the query strategy
+// each collector should use on it: which Screen Time stream to read, where
+// the database lives, and which command still reports Wi-Fi details. The
+// version checks live here instead of being rediscovered by failing
+// commands on every run.
+package compat
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Version is a macOS version. The zero Version means it couldn't be read,
+// and strategies fall back to trying everything.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+var current = sync.OnceValue(func() Version {
+	out, err := exec.Command("sw_vers", "-productVersion").Output()
+	if err != nil {
+		return Version{}
+	}
+	return ParseVersion(string(out))
+})
+
+// Current returns the running macOS version, read once per process
+func Current() Version {
+	return current()
+}
+
+// ParseVersion reads "14.4.1" style versions; anything else is unknown
+func ParseVersion(s string) Version {
+	var parts [3]int
+	for i, field := range strings.SplitN(strings.TrimSpace(s), ".", 3) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return Version{}
+		}
+		parts[i] = n
+	}
+	return Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}
+}
+
+// Known reports whether the version was read
+func (v Version) Known() bool {
+	return v.Major > 0
+}
+
+// AtLeast reports whether v is major.minor or later. An unknown version is
+// never at least anything.
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+func (v Version) String() string {
+	if !v.Known() {
+		return "unknown"
+	}
+	s := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	if v.Patch > 0 {
+		s += fmt.Sprintf(".%d", v.Patch)
+	}
+	if name := v.Name(); name != "" {
+		s += " (" + name + ")"
+	}
+	return s
+}
+
+// releaseNames are macOS release names by major version, from Big Sur on
+var releaseNames = map[int]string{
+	11: "Big Sur", 12: "Monterey", 13: "Ventura", 14: "Sonoma", 15: "Sequoia", 26: "Tahoe",
+}
+
+// Name returns the release name, e.g. "Sonoma", or "" when not known
+func (v Version) Name() string {
+	switch {
+	case v.Major == 10 && v.Minor == 15:
+		return "Catalina"
+	case v.Major == 10 && v.Minor == 14:
+		return "Mojave"
+	}
+	return releaseNames[v.Major]
+}
+
+// Stream names in knowledgeC.db's ZOBJECT table
+const (
+	StreamAppUsage   = "/app/usage"   // Big Sur and later
+	StreamAppInFocus = "/app/inFocus" // Catalina and earlier
+)
+
+// AppUsageStream returns the knowledgeC.db stream that records which app
+// was in use. Big Sur renamed it.
+func AppUsageStream(v Version) string {
+	if v.Known() && !v.AtLeast(11, 0) {
+		return StreamAppInFocus
+	}
+	return StreamAppUsage
+}
+
+// systemKnowledgeDB is the system-wide copy of the Screen Time database,
+// readable only as root (e.g. from a LaunchDaemon)
+const systemKnowledgeDB = "/private/var/db/CoreDuet/Knowledge/knowledgeC.db"
+
+// KnowledgeDBPaths returns where to look for the Screen Time database, in
+// order: the per-user copy, then the system-wide one
+func KnowledgeDBPaths(home string) []string {
+	return []string{
+		filepath.Join(home, "Library", "Application Support", "Knowledge", "knowledgeC.db"),
+		systemKnowledgeDB,
+	}
+}
+
+// Commands that report Wi-Fi details
+const (
+	ToolAirport        = "airport"         // Removed in macOS 14.4
+	ToolNetworksetup   = "networksetup"    // -getairportnetwork; stopped reporting the network in Sequoia
+	ToolSystemProfiler = "system_profiler" // SPAirPortDataType; slow but always present
+)
+
+// WiFiSignalTools returns the commands to read signal, noise, and transmit
+// rate from, in order
+func WiFiSignalTools(v Version) []string {
+	if v.AtLeast(14, 4) {
+		return []string{ToolSystemProfiler}
+	}
+	return []string{ToolAirport, ToolSystemProfiler}
+}
+
+// WiFiNameTools returns the commands to read the current network's name
+// from, in order
+func WiFiNameTools(v Version) []string {
+	switch {
+	case !v.Known():
+		return []string{ToolAirport, ToolNetworksetup, ToolSystemProfiler}
+	case v.AtLeast(15, 0):
+		return []string{ToolSystemProfiler}
+	case v.AtLeast(14, 4):
+		return []string{ToolNetworksetup, ToolSystemProfiler}
+	}
+	return []string{ToolAirport, ToolNetworksetup}
+}
+
+// Strategy is one choice the compatibility layer made for a macOS version
+type Strategy struct {
+	Area   string // What's being read, e.g. "Wi-Fi signal"
+	Choice string // The source or commands used, in order
+	Reason string
+}
+
+// Strategies lists the choices made for v, for "rekap doctor compat"
+func Strategies(v Version, home string) []Strategy {
+	streamReason := "Big Sur and later record app use here"
+	if AppUsageStream(v) == StreamAppInFocus {
+		streamReason = "Catalina and earlier used the older stream name"
+	}
+
+	signalReason := "airport first, falling back to system_profiler"
+	if v.AtLeast(14, 4) {
+		signalReason = "airport was removed in macOS 14.4"
+	}
+
+	nameReason := "airport, then networksetup"
+	switch {
+	case !v.Known():
+		nameReason = "version unknown, so every tool is tried"
+	case v.AtLeast(15, 0):
+		nameReason = "networksetup no longer reports the network in Sequoia"
+	case v.AtLeast(14, 4):
+		nameReason = "airport was removed in macOS 14.4"
+	}
+
+	return []Strategy{
+		{Area: "Screen Time stream", Choice: AppUsageStream(v), Reason: streamReason},
+		{Area: "Screen Time database", Choice: strings.Join(KnowledgeDBPaths(home), ", then "), Reason: "per-user copy, then the system copy when run as root"},
+		{Area: "Wi-Fi signal", Choice: strings.Join(WiFiSignalTools(v), ", then "), Reason: signalReason},
+		{Area: "Wi-Fi name", Choice: strings.Join(WiFiNameTools(v), ", then "), Reason: nameReason},
+	}
+}
@@ -0,0 +1,95 @@
+package compat
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"14.4.1\n", Version{14, 4, 1}},
+		{"15.0", Version{15, 0, 0}},
+		{"26", Version{26, 0, 0}},
+		{"", Version{}},
+		{"fourteen", Version{}},
+	}
+	for _, tt := range tests {
+		if got := ParseVersion(tt.in); got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	if got := (Version{14, 4, 1}).String(); got != "14.4.1 (Sonoma)" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Version{}).String(); got != "unknown" {
+		t.Errorf("unknown String() = %q", got)
+	}
+	if got := (Version{10, 15, 7}).Name(); got != "Catalina" {
+		t.Errorf("Name() = %q, want Catalina", got)
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	t.Parallel()
+	v := Version{14, 4, 0}
+	if !v.AtLeast(14, 4) || !v.AtLeast(13, 9) || v.AtLeast(14, 5) || v.AtLeast(15, 0) {
+		t.Errorf("AtLeast wrong for %v", v)
+	}
+	if (Version{}).AtLeast(10, 0) {
+		t.Error("unknown version should not be at least 10.0")
+	}
+}
+
+func TestStrategiesByVersion(t *testing.T) {
+	t.Parallel()
+	ventura := Version{13, 6, 0}
+	sonoma := Version{14, 4, 0}
+	sequoia := Version{15, 1, 0}
+	catalina := Version{10, 15, 7}
+	unknown := Version{}
+
+	if AppUsageStream(catalina) != StreamAppInFocus || AppUsageStream(sonoma) != StreamAppUsage || AppUsageStream(unknown) != StreamAppUsage {
+		t.Error("AppUsageStream picked the wrong stream")
+	}
+
+	signal := []struct {
+		v    Version
+		want []string
+	}{
+		{ventura, []string{ToolAirport, ToolSystemProfiler}},
+		{sonoma, []string{ToolSystemProfiler}},
+		{unknown, []string{ToolAirport, ToolSystemProfiler}},
+	}
+	for _, tt := range signal {
+		if got := WiFiSignalTools(tt.v); !slices.Equal(got, tt.want) {
+			t.Errorf("WiFiSignalTools(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+
+	name := []struct {
+		v    Version
+		want []string
+	}{
+		{ventura, []string{ToolAirport, ToolNetworksetup}},
+		{sonoma, []string{ToolNetworksetup, ToolSystemProfiler}},
+		{sequoia, []string{ToolSystemProfiler}},
+		{unknown, []string{ToolAirport, ToolNetworksetup, ToolSystemProfiler}},
+	}
+	for _, tt := range name {
+		if got := WiFiNameTools(tt.v); !slices.Equal(got, tt.want) {
+			t.Errorf("WiFiNameTools(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+
+	if got := KnowledgeDBPaths("/Users/sam")[0]; got != "/Users/sam/Library/Application Support/Knowledge/knowledgeC.db" {
+		t.Errorf("per-user path = %q", got)
+	}
+	if got := len(Strategies(sequoia, "/Users/sam")); got != 4 {
+		t.Errorf("Strategies returned %d entries, want 4", got)
+	}
+}
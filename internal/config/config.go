@@ -2,22 +2,48 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"text/template"
+	"time"
 
+	"github.com/alexinslc/rekap/internal/keychain"
+	"github.com/alexinslc/rekap/internal/locale"
 	"github.com/alexinslc/rekap/internal/theme"
-	"gopkg.in/yaml.v3"
+	"github.com/alexinslc/rekap/internal/xdg"
 )
 
 // Config holds all user preferences
 type Config struct {
+	Version       int                           `yaml:"version"` // Schema version, see SchemaVersion; files without one are version 1
+	Include       []string                      `yaml:"include"` // Config files layered under this one, see DecodeFile
 	Colors        ColorConfig                   `yaml:"colors"`
 	Display       DisplayConfig                 `yaml:"display"`
+	Schedule      ScheduleConfig                `yaml:"schedule"`
+	Location      LocationConfig                `yaml:"location"`
 	Tracking      TrackingConfig                `yaml:"tracking"`
 	Accessibility AccessibilityConfig           `yaml:"accessibility"`
 	Domains       DomainsConfig                 `yaml:"domains"`
+	Learning      LearningConfig                `yaml:"learning"`
+	Privacy       PrivacyConfig                 `yaml:"privacy"`
 	Fragmentation FragmentationThresholdsConfig `yaml:"fragmentation"`
+	Wellness      WellnessConfig                `yaml:"wellness"`
+	Network       NetworkConfig                 `yaml:"network"`
+	History       HistoryConfig                 `yaml:"history"`
+	Projects      []ProjectConfig               `yaml:"projects"`
+	Sections      SectionsConfig                `yaml:"sections"`
+	Snooze        []SnoozeRule                  `yaml:"snooze"`
+	Hooks         HooksConfig                   `yaml:"hooks"`
+	Custom        []CustomSectionConfig         `yaml:"custom_sections"`
+	Sources       SourcesConfig                 `yaml:"sources"`
+	Performance   PerformanceConfig             `yaml:"performance"`
+	Integrations  IntegrationsConfig            `yaml:"integrations"`
+	Narrate       NarrateConfig                 `yaml:"narrate"`
 }
 
 // ColorConfig holds color customization settings
@@ -29,18 +55,215 @@ type ColorConfig struct {
 	Warning   string `yaml:"warning"`
 	Muted     string `yaml:"muted"`
 	Text      string `yaml:"text"`
+
+	Chart    []string             `yaml:"chart"`    // Series colors for bars and sparklines; empty derives them from the colors above
+	Severity theme.SeverityColors `yaml:"severity"` // Warning colors by severity; unset ones are derived
 }
 
 // DisplayConfig holds display preferences
 type DisplayConfig struct {
-	ShowMedia   *bool  `yaml:"show_media"`   // pointer to distinguish unset from false
-	ShowBattery *bool  `yaml:"show_battery"` // pointer to distinguish unset from false
-	TimeFormat  string `yaml:"time_format"`  // "12h" or "24h"
+	ShowMedia    *bool  `yaml:"show_media"`     // pointer to distinguish unset from false
+	ShowBattery  *bool  `yaml:"show_battery"`   // pointer to distinguish unset from false
+	TimeFormat   string `yaml:"time_format"`    // "12h" or "24h"; empty follows the locale
+	Locale       string `yaml:"locale"`         // e.g. "en_GB" or "de_DE"; empty uses the system's
+	DayStartHour int    `yaml:"day_start_hour"` // Deprecated: use schedule.day_starts_at
+
+	SectionOrder   []string `yaml:"section_order"`   // Section keys to show first, in this order; the rest follow in the usual order
+	HiddenSections []string `yaml:"hidden_sections"` // Section keys that still run, for --json and --quiet, but are never shown
+}
+
+// ScheduleConfig holds the shape of the user's day
+type ScheduleConfig struct {
+	DayStartsAt string   `yaml:"day_starts_at"` // "HH:MM" at which "today" begins; empty means midnight
+	WorkHours   string   `yaml:"work_hours"`    // "HH:MM-HH:MM", may wrap past midnight; empty turns the work/after-hours split off
+	WorkDays    []string `yaml:"work_days"`     // "mon".."sun" that work_hours applies on; empty means mon-fri
+}
+
+// LocationConfig places the Mac for sunrise and sunset, worked out offline.
+// Both coordinates must be set; pointers tell unset from 0.
+type LocationConfig struct {
+	Latitude  *float64 `yaml:"latitude"`  // Degrees north; negative is south
+	Longitude *float64 `yaml:"longitude"` // Degrees east; negative is west
 }
 
 // TrackingConfig holds tracking preferences
 type TrackingConfig struct {
-	ExcludeApps []string `yaml:"exclude_apps"`
+	ExcludeApps []string       `yaml:"exclude_apps"`
+	SpaceNames  map[int]string `yaml:"space_names"` // Desktop number -> label, e.g. 2: "Client A"
+
+	ShellHistory bool `yaml:"shell_history"` // Count commands from zsh, bash, and fish history; off by default since history can hold secrets
+	Messages     bool `yaml:"messages"`      // Count messages sent and received in Messages and Slack; counts only, never content
+	Clipboard    bool `yaml:"clipboard"`     // Count clipboard changes from sampling; never reads what was copied
+	Reminders    bool `yaml:"reminders"`     // Count reminders completed in Reminders.app; never reads titles
+
+	WindowTitles []string `yaml:"window_titles"` // App names or bundle IDs whose front window title is sampled for projects; no others are read
+}
+
+// PerformanceConfig trades detail for speed on large datasets
+type PerformanceConfig struct {
+	Fast     bool `yaml:"fast"`      // Read only recent browser history, count tabs without titles, skip app-name lookups
+	MemoryMB int  `yaml:"memory_mb"` // Memory for counting browser history; past it the least-visited URLs are dropped
+}
+
+// NetworkConfig holds network usage preferences
+type NetworkConfig struct {
+	MeteredNetworks  []string `yaml:"metered_networks"`   // Wi-Fi SSIDs to treat as metered
+	MeteredWarningMB int      `yaml:"metered_warning_mb"` // Warn when metered downloads exceed this
+	PerApp           bool     `yaml:"per_app"`            // Break traffic down by app with nettop
+}
+
+// HistoryConfig holds history store preferences
+type HistoryConfig struct {
+	Enabled       *bool `yaml:"enabled"`        // pointer to distinguish unset from false
+	RetentionDays int   `yaml:"retention_days"` // Keep daily snapshots this long, then compact into weekly aggregates
+	KeepWeeks     int   `yaml:"keep_weeks"`     // Delete weekly aggregates older than this; 0 keeps them forever
+	RunLog        bool  `yaml:"run_log"`        // Append every run's JSON to runs.jsonl
+	RunLogMaxMB   int   `yaml:"run_log_max_mb"` // Rotate runs.jsonl when it would grow past this
+}
+
+// MinRetentionDays is the shortest daily retention, so weekly reports stay complete
+const MinRetentionDays = 7
+
+// ProjectConfig maps apps, URLs, and issue keys to a named project for timesheets
+type ProjectConfig struct {
+	Name    string   `yaml:"name"`
+	Apps    []string `yaml:"apps"`    // App names or bundle IDs
+	Domains []string `yaml:"domains"` // Domains ("clienta.com", "*.clienta.com") or URL patterns ("github.com/org/*")
+	Issues  []string `yaml:"issues"`  // Issue key patterns, e.g. "PROJ-*" or "org/repo#*"
+	Titles  []string `yaml:"titles"`  // Window title patterns, e.g. "rekap" or "* — clienta-api"; needs tracking.window_titles
+}
+
+// HooksConfig holds shell commands run around collection
+type HooksConfig struct {
+	PreCollect     []string `yaml:"pre_collect"`     // Run before collectors start
+	PostCollect    []string `yaml:"post_collect"`    // Run after collection, with the JSON output on stdin
+	TimeoutSeconds int      `yaml:"timeout_seconds"` // Per command
+}
+
+// CustomSectionConfig adds a section to the summary, filled from the JSON a
+// command prints or a file holds: {"lines": ["..."]}, with optional "title"
+// and "icon" overriding the config
+type CustomSectionConfig struct {
+	Name    string `yaml:"name"`    // Header, and the key snooze rules use
+	Icon    string `yaml:"icon"`    // Shown before each line; empty means 🧩
+	Command string `yaml:"command"` // Run with /bin/sh -c each time rekap collects
+	File    string `yaml:"file"`    // Or read this file, kept current by a plugin or webhook receiver
+}
+
+// NarrateConfig configures "rekap narrate", which is off until enabled
+type NarrateConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	Command        string `yaml:"command"`         // Local model command, e.g. "ollama run llama3"; gets the prompt on stdin
+	Prompt         string `yaml:"prompt"`          // Go template over .Date, .Summary, and .JSON; empty uses the built-in prompt
+	TimeoutSeconds int    `yaml:"timeout_seconds"` // Local models can be slow to load
+}
+
+// IntegrationsConfig holds settings for "rekap send" destinations
+type IntegrationsConfig struct {
+	Slack    SlackConfig              `yaml:"slack"`
+	Webhooks map[string]WebhookConfig `yaml:"webhooks"` // Keyed by the name passed to "rekap send webhook"
+}
+
+// SlackConfig configures "rekap send slack"
+type SlackConfig struct {
+	WebhookURL string `yaml:"webhook_url"` // Incoming webhook, https://hooks.slack.com/services/...; "keychain:NAME" or $VARS
+	Token      string `yaml:"token"`       // User token with search:read, for tracking.messages; "keychain:NAME" or $VARS
+}
+
+// SlackToken returns integrations.slack.token with its secret filled in
+// from the Keychain or the environment, so the token itself can stay out
+// of the config file
+func (c *Config) SlackToken() (string, error) {
+	return keychain.Resolve(c.Integrations.Slack.Token)
+}
+
+// SlackWebhookURL returns integrations.slack.webhook_url with its secret
+// filled in, like SlackToken
+func (c *Config) SlackWebhookURL() (string, error) {
+	return keychain.Resolve(c.Integrations.Slack.WebhookURL)
+}
+
+// secretRef reports whether a config value names its secret with
+// "keychain:NAME" or a $VAR rather than holding it
+func secretRef(value string) bool {
+	_, ok := keychain.Ref(value)
+	return ok || strings.Contains(value, "$")
+}
+
+// credentialHeader reports whether an HTTP header carries a credential
+// (Authorization, Cookie, X-Api-Key, ...) rather than something like
+// Content-Type that's fine to keep in the config file
+func credentialHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range []string{"auth", "cookie", "token", "key", "secret", "password", "signature"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookConfig configures one "rekap send webhook" endpoint
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Method  string            `yaml:"method"`  // POST (default), PUT, or PATCH
+	Headers map[string]string `yaml:"headers"` // Values expand $VARS, so tokens can stay out of this file
+	Body    string            `yaml:"body"`    // Go template over the JSON output; empty sends the JSON as is
+}
+
+// webhookMethods are the HTTP methods a webhook may use
+var webhookMethods = []string{"POST", "PUT", "PATCH"}
+
+// Data source names for SourcesConfig
+const (
+	SourceAuto        = "auto"        // Try each source in order, use the first that works
+	SourcePmset       = "pmset"       // Screen: display events from "pmset -g log"
+	SourceKnowledgeC  = "knowledgec"  // Screen: backlight stream in the Screen Time database
+	SourceScreenTime  = "screentime"  // Apps: app usage stream in the Screen Time database
+	SourceSampling    = "sampling"    // Apps: frontmost-app samples taken by each run and "rekap sample"
+	SourceAppleScript = "applescript" // Media: ask Music and Spotify via osascript
+	SourceNowPlaying  = "nowplaying"  // Media: system Now Playing via nowplaying-cli
+)
+
+// SourcesConfig pins the data source a collector reads from. A pinned
+// collector never falls back to another source, so a broken source shows up
+// as unavailable instead of as different numbers.
+type SourcesConfig struct {
+	Screen string `yaml:"screen"` // "auto", "pmset", or "knowledgec"
+	Apps   string `yaml:"apps"`   // "auto", "screentime", or "sampling"
+	Media  string `yaml:"media"`  // "auto", "applescript", or "nowplaying"
+}
+
+// sourceChoices lists the valid values for each SourcesConfig field
+var sourceChoices = map[string][]string{
+	"screen": {SourceAuto, SourcePmset, SourceKnowledgeC},
+	"apps":   {SourceAuto, SourceScreenTime, SourceSampling},
+	"media":  {SourceAuto, SourceAppleScript, SourceNowPlaying},
+}
+
+// SectionsConfig picks which sections run at all. A section that's off is
+// never shown, and collectors that only feed off sections are skipped.
+type SectionsConfig struct {
+	Only []string `yaml:"only"` // Section keys to run; empty means every section
+	Skip []string `yaml:"skip"` // Section keys never to run, applied after only
+}
+
+// SnoozeRule hides sections of the summary during a window of the day
+type SnoozeRule struct {
+	Sections []string `yaml:"sections"` // Section keys, see SectionKeys
+	Hours    string   `yaml:"hours"`    // "HH:MM-HH:MM", may wrap past midnight; empty means all day
+	Days     []string `yaml:"days"`     // "mon".."sun"; empty means every day
+}
+
+// SectionKeys are the summary sections that snooze rules can hide
+var SectionKeys = []string{
+	"system", "sleep", "productivity", "meetings", "timesheet", "dev", "media", "network",
+	"browser", "notifications", "privacy", "fragmentation", "issues", "wellness", "notes",
+}
+
+var weekdayKeys = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
 }
 
 // AccessibilityConfig holds accessibility preferences
@@ -52,9 +275,68 @@ type AccessibilityConfig struct {
 
 // DomainsConfig holds domain categorization configuration
 type DomainsConfig struct {
-	Work        []string `yaml:"work"`
-	Distraction []string `yaml:"distraction"`
-	Neutral     []string `yaml:"neutral"`
+	Work        []string     `yaml:"work"`
+	Distraction []string     `yaml:"distraction"`
+	Neutral     []string     `yaml:"neutral"`
+	Rules       []DomainRule `yaml:"rules"` // Checked in order before the lists; the first match wins
+}
+
+// DomainRule categorizes visits more finely than the domain lists: by
+// path or regular expression, and optionally only at certain times
+type DomainRule struct {
+	Match    string   `yaml:"match"`    // Domain pattern or "domain/path" prefix, as in projects
+	Regex    string   `yaml:"regex"`    // Regular expression over "host/path?query", host without "www."
+	Category string   `yaml:"category"` // "work", "distraction", or "neutral"
+	Hours    string   `yaml:"hours"`    // "HH:MM-HH:MM", may wrap past midnight; empty means all day
+	Days     []string `yaml:"days"`     // "mon".."sun"; empty means every day
+}
+
+// domainCategories are the categories a domain rule can give
+var domainCategories = []string{"work", "distraction", "neutral"}
+
+// ruleRegexps caches the compiled domain rule expressions, since rules are
+// checked against every history visit
+var ruleRegexps sync.Map // string -> *regexp.Regexp
+
+// matches reports whether the rule applies to a visit to host+path with
+// the given query at at. A zero at only matches rules without hours or days.
+func (r DomainRule) matches(host, path, query string, at time.Time) bool {
+	if r.Hours != "" || len(r.Days) > 0 {
+		if at.IsZero() || !activeAt(r.Hours, r.Days, at) {
+			return false
+		}
+	}
+	if r.Regex != "" {
+		re, ok := ruleRegexps.Load(r.Regex)
+		if !ok {
+			compiled, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return false
+			}
+			re, _ = ruleRegexps.LoadOrStore(r.Regex, compiled)
+		}
+		target := host + path
+		if query != "" {
+			target += "?" + query
+		}
+		return re.(*regexp.Regexp).MatchString(target)
+	}
+	return r.Match != "" && matchURLPattern(host, path, strings.ToLower(r.Match))
+}
+
+// LearningConfig defines what counts as learning time, tracked apart from
+// work and distraction
+type LearningConfig struct {
+	Domains         []string `yaml:"domains"`           // Domain patterns, "domain/path" prefixes, or URL globs with a query ("youtube.com/watch?*list=PL123*")
+	Apps            []string `yaml:"apps"`              // App names or bundle IDs
+	WeeklyGoalHours float64  `yaml:"weekly_goal_hours"` // 0 for no goal
+}
+
+// PrivacyConfig keeps sensitive browsing, like banking or health sites, out
+// of everything rekap shows, sends, and stores
+type PrivacyConfig struct {
+	RedactDomains []string `yaml:"redact_domains"` // Patterns as in learning.domains; matching tabs and visits are dropped
+	IssueDenylist []string `yaml:"issue_denylist"` // Issue ID globs ("SEC-*") or URL patterns ("github.com/acme/security") never recorded
 }
 
 // FragmentationThresholdsConfig holds configurable thresholds for fragmentation scoring
@@ -62,14 +344,62 @@ type FragmentationThresholdsConfig struct {
 	FocusedMax    int `yaml:"focused_max"`    // 0-30 = Focused
 	ModerateMax   int `yaml:"moderate_max"`   // 31-60 = Moderate
 	FragmentedMin int `yaml:"fragmented_min"` // 61-100 = Fragmented
+
+	Weights FragmentationWeightsConfig `yaml:"weights"` // Out of 100
+	Disable []string                   `yaml:"disable"` // Factors left out of the score; the rest are scaled back up to 100
+}
+
+// FragmentationWeightsConfig is the most each factor can add to the
+// fragmentation score
+type FragmentationWeightsConfig struct {
+	Apps     int `yaml:"apps"`     // Unique apps used
+	Tabs     int `yaml:"tabs"`     // Open browser tabs
+	Domains  int `yaml:"domains"`  // Unique domains in open tabs
+	Switches int `yaml:"switches"` // App switches per hour
+}
+
+// FragmentationFactors are the factors fragmentation.disable can name
+var FragmentationFactors = []string{"apps", "tabs", "domains", "switches"}
+
+// sum adds up the weights
+func (w FragmentationWeightsConfig) sum() int {
+	return w.Apps + w.Tabs + w.Domains + w.Switches
+}
+
+// field returns the weight of the named factor
+func (w *FragmentationWeightsConfig) field(factor string) *int {
+	switch factor {
+	case "apps":
+		return &w.Apps
+	case "tabs":
+		return &w.Tabs
+	case "domains":
+		return &w.Domains
+	case "switches":
+		return &w.Switches
+	}
+	return nil
+}
+
+// WellnessConfig holds the thresholds the wellness check's burnout
+// warnings trigger at
+type WellnessConfig struct {
+	LongDayHours      int    `yaml:"long_day_hours"`      // Hours from first to last activity
+	SwitchesPerHour   int    `yaml:"switches_per_hour"`   // App switches per hour
+	MaxTabs           int    `yaml:"max_tabs"`            // Open browser tabs
+	NoBreakHours      int    `yaml:"no_break_hours"`      // Hours of activity without a 15-minute break
+	LateNight         string `yaml:"late_night"`          // "HH:MM-HH:MM" counted as late-night work
+	AfterHoursMinutes int    `yaml:"after_hours_minutes"` // Screen time outside schedule.work_hours
 }
 
 // Default returns a config with sensible defaults
 func Default() *Config {
 	showMedia := true
 	showBattery := true
+	historyEnabled := true
 
 	return &Config{
+		Version: SchemaVersion,
 		Colors: ColorConfig{
 			Primary:   "13",  // Bright magenta/pink
 			Secondary: "14",  // Cyan
@@ -82,7 +412,6 @@ func Default() *Config {
 		Display: DisplayConfig{
 			ShowMedia:   &showMedia,
 			ShowBattery: &showBattery,
-			TimeFormat:  "12h",
 		},
 		Tracking: TrackingConfig{
 			ExcludeApps: []string{},
@@ -122,62 +451,153 @@ func Default() *Config {
 			},
 			Neutral: []string{},
 		},
+		Learning: LearningConfig{
+			Domains: []string{
+				"coursera.org",
+				"udemy.com",
+				"edx.org",
+				"oreilly.com",
+				"pluralsight.com",
+				"khanacademy.org",
+				"frontendmasters.com",
+				"egghead.io",
+				"educative.io",
+				"exercism.org",
+			},
+			Apps: []string{},
+		},
 		Fragmentation: FragmentationThresholdsConfig{
 			FocusedMax:    30,
 			ModerateMax:   60,
 			FragmentedMin: 61,
+			Weights:       FragmentationWeightsConfig{Apps: 30, Tabs: 25, Domains: 25, Switches: 20},
+		},
+		Wellness: WellnessConfig{
+			LongDayHours:      10,
+			SwitchesPerHour:   50,
+			MaxTabs:           100,
+			NoBreakHours:      4,
+			LateNight:         "00:00-06:00",
+			AfterHoursMinutes: 60,
+		},
+		Network: NetworkConfig{
+			MeteredNetworks:  []string{},
+			MeteredWarningMB: 500,
+		},
+		History: HistoryConfig{
+			Enabled:       &historyEnabled,
+			RetentionDays: 30,
+			RunLogMaxMB:   10,
+		},
+		Hooks: HooksConfig{
+			TimeoutSeconds: 10,
+		},
+		Narrate: NarrateConfig{
+			TimeoutSeconds: 120,
+		},
+		Sources: SourcesConfig{
+			Screen: SourceAuto,
+			Apps:   SourceAuto,
+			Media:  SourceAuto,
+		},
+		Performance: PerformanceConfig{
+			MemoryMB: 64,
 		},
 	}
 }
 
-// Load reads config from ~/.config/rekap/config.yaml
-// If file doesn't exist, returns default config
+// Load reads config from GetConfigPath, with the active profile layered
+// over it. If file doesn't exist, returns default config; on an error it
+// returns Fallback along with it
 func Load() (*Config, error) {
 	cfg := Default()
 
 	// Get config file path
 	configPath, err := GetConfigPath()
 	if err != nil {
-		return cfg, nil // Use defaults if we can't determine path
+		return cfg.resolve(), nil // Use defaults if we can't determine path
 	}
 
-	// If config file doesn't exist, use defaults
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return cfg, nil
+	// Without a config file, the defaults stand. Older files are brought up
+	// to date as they're read, then merged with the defaults.
+	if _, err := os.Stat(configPath); err == nil {
+		if err := DecodeFile(cfg, configPath); err != nil {
+			return Fallback(), err
+		}
 	}
 
-	// Read config file
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return cfg, err
+	if err := applyProfile(cfg); err != nil {
+		return Fallback(), err
 	}
+	return cfg.resolve(), nil
+}
 
-	// Parse YAML, merging with defaults
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return cfg, err
+// Fallback returns the defaults resolved the way Load resolves a config,
+// with the system locale in effect, for callers to use when Load fails
+func Fallback() *Config {
+	return Default().resolve()
+}
+
+// resolve validates c, applying defaults for unset values, and makes its
+// locale the one every formatter uses
+func (c *Config) resolve() *Config {
+	c.Validate()
+	locale.Set(c.Locale())
+	return c
+}
+
+// Locale returns the formatting for display.locale, or for the system
+// locale when it isn't set
+func (c *Config) Locale() locale.Locale {
+	tag := c.Display.Locale
+	if tag == "" {
+		tag = locale.Detect()
 	}
+	return locale.Parse(tag)
+}
 
-	// Validate and apply defaults for unset values
-	cfg.Validate()
+// configPath is the config file set with SetPath; empty uses the default
+var configPath string
 
-	return cfg, nil
+// SetPath makes the config file path, as --config does, instead of
+// config.yaml in the config directory
+func SetPath(path string) {
+	configPath = path
 }
 
-// GetConfigPath returns the path to the config file
+// GetConfigPath returns the path to the config file: the one set with
+// SetPath, or $XDG_CONFIG_HOME/rekap/config.yaml (~/.config/rekap by default)
 func GetConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	if configPath != "" {
+		return configPath, nil
+	}
+	dir, err := xdg.ConfigDir()
 	if err != nil {
 		return "", err
 	}
-
-	return filepath.Join(homeDir, ".config", "rekap", "config.yaml"), nil
+	return filepath.Join(dir, "config.yaml"), nil
 }
 
 // Validate ensures config values are valid, applying defaults where needed
 func (c *Config) Validate() {
-	// Ensure time format is valid
+	// Ensure time format is valid, following the locale when unset
 	if c.Display.TimeFormat != "12h" && c.Display.TimeFormat != "24h" {
-		c.Display.TimeFormat = "12h"
+		c.Display.TimeFormat = c.Locale().TimeFormat()
+	}
+
+	// Ensure day boundary is a valid time of day
+	if c.Display.DayStartHour < 0 || c.Display.DayStartHour > 23 {
+		c.Display.DayStartHour = 0
+	}
+	if c.Schedule.DayStartsAt != "" {
+		if _, err := parseDayStart(c.Schedule.DayStartsAt); err != nil {
+			c.Schedule.DayStartsAt = ""
+		}
+	}
+	if c.Schedule.WorkHours != "" {
+		if _, _, err := parseHoursRange(c.Schedule.WorkHours); err != nil {
+			c.Schedule.WorkHours = ""
+		}
 	}
 
 	// Ensure display booleans have defaults if not set
@@ -189,6 +609,10 @@ func (c *Config) Validate() {
 		showBattery := true
 		c.Display.ShowBattery = &showBattery
 	}
+	if c.History.Enabled == nil {
+		historyEnabled := true
+		c.History.Enabled = &historyEnabled
+	}
 
 	// Color validation - ensure they're not empty
 	defaults := Default()
@@ -231,6 +655,76 @@ func (c *Config) Validate() {
 		c.Fragmentation.ModerateMax = defaults.Fragmentation.ModerateMax
 		c.Fragmentation.FragmentedMin = defaults.Fragmentation.FragmentedMin
 	}
+	w := c.Fragmentation.Weights
+	if w.Apps < 0 || w.Tabs < 0 || w.Domains < 0 || w.Switches < 0 || w.sum() != 100 {
+		c.Fragmentation.Weights = defaults.Fragmentation.Weights
+	}
+
+	// Burnout thresholds fall back one by one
+	if c.Wellness.LongDayHours <= 0 {
+		c.Wellness.LongDayHours = defaults.Wellness.LongDayHours
+	}
+	if c.Wellness.SwitchesPerHour <= 0 {
+		c.Wellness.SwitchesPerHour = defaults.Wellness.SwitchesPerHour
+	}
+	if c.Wellness.MaxTabs <= 0 {
+		c.Wellness.MaxTabs = defaults.Wellness.MaxTabs
+	}
+	if c.Wellness.NoBreakHours <= 0 {
+		c.Wellness.NoBreakHours = defaults.Wellness.NoBreakHours
+	}
+	if _, _, err := parseHoursRange(c.Wellness.LateNight); err != nil {
+		c.Wellness.LateNight = defaults.Wellness.LateNight
+	}
+	if c.Wellness.AfterHoursMinutes <= 0 {
+		c.Wellness.AfterHoursMinutes = defaults.Wellness.AfterHoursMinutes
+	}
+
+	// Ensure metered warning threshold is positive
+	if c.Network.MeteredWarningMB <= 0 {
+		c.Network.MeteredWarningMB = defaults.Network.MeteredWarningMB
+	}
+
+	// Ensure history retention covers at least a week of daily snapshots
+	if c.History.RetentionDays <= 0 {
+		c.History.RetentionDays = defaults.History.RetentionDays
+	} else if c.History.RetentionDays < MinRetentionDays {
+		c.History.RetentionDays = MinRetentionDays
+	}
+	if c.History.RunLogMaxMB <= 0 {
+		c.History.RunLogMaxMB = defaults.History.RunLogMaxMB
+	}
+	if c.History.KeepWeeks < 0 {
+		c.History.KeepWeeks = 0
+	}
+	if c.Performance.MemoryMB <= 0 {
+		c.Performance.MemoryMB = defaults.Performance.MemoryMB
+	}
+
+	// Ensure hooks can't hang a run indefinitely
+	if c.Hooks.TimeoutSeconds <= 0 {
+		c.Hooks.TimeoutSeconds = defaults.Hooks.TimeoutSeconds
+	}
+	if c.Narrate.TimeoutSeconds <= 0 {
+		c.Narrate.TimeoutSeconds = defaults.Narrate.TimeoutSeconds
+	}
+
+	// Unknown source names fall back to trying every source
+	c.Sources.Screen = validSource("screen", c.Sources.Screen)
+	c.Sources.Apps = validSource("apps", c.Sources.Apps)
+	c.Sources.Media = validSource("media", c.Sources.Media)
+}
+
+// validSource returns name lowercased if it's a valid source for
+// collector, and SourceAuto otherwise
+func validSource(collector, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, choice := range sourceChoices[collector] {
+		if name == choice {
+			return name
+		}
+	}
+	return SourceAuto
 }
 
 // ShouldShowMedia returns whether to show media section
@@ -249,6 +743,239 @@ func (c *Config) ShouldShowBattery() bool {
 	return *c.Display.ShowBattery
 }
 
+// ShouldRecordHistory returns whether daily snapshots are saved for reports
+func (c *Config) ShouldRecordHistory() bool {
+	if c.History.Enabled == nil {
+		return true
+	}
+	return *c.History.Enabled
+}
+
+// MeteredWarningBytes returns the metered download size that triggers a warning
+func (c *Config) MeteredWarningBytes() int64 {
+	mb := c.Network.MeteredWarningMB
+	if mb <= 0 {
+		mb = Default().Network.MeteredWarningMB
+	}
+	return int64(mb) * 1024 * 1024
+}
+
+// SectionEnabled reports whether sections.only and sections.skip let
+// section run
+func (c *Config) SectionEnabled(section string) bool {
+	if len(c.Sections.Only) > 0 && !containsFold(c.Sections.Only, section) {
+		return false
+	}
+	return !containsFold(c.Sections.Skip, section)
+}
+
+// SectionShown reports whether section appears in the summary at now: it
+// is enabled, not hidden, and no snooze rule hides it
+func (c *Config) SectionShown(section string, now time.Time) bool {
+	return c.SectionEnabled(section) && !c.SectionHidden(section) && !c.SectionSnoozed(section, now)
+}
+
+// SectionHidden reports whether display.hidden_sections keeps section out
+// of the summary and the TUI
+func (c *Config) SectionHidden(section string) bool {
+	return containsFold(c.Display.HiddenSections, section)
+}
+
+// OrderSections returns keys in display order: those listed in
+// display.section_order first, in that order, then the rest as given
+func (c *Config) OrderSections(keys []string) []string {
+	rank := func(key string) int {
+		for i, s := range c.Display.SectionOrder {
+			if strings.EqualFold(s, key) {
+				return i
+			}
+		}
+		return len(c.Display.SectionOrder)
+	}
+	ordered := slices.Clone(keys)
+	slices.SortStableFunc(ordered, func(a, b string) int { return rank(a) - rank(b) })
+	return ordered
+}
+
+// SectionSnoozed reports whether a snooze rule hides section at now
+func (c *Config) SectionSnoozed(section string, now time.Time) bool {
+	for _, rule := range c.Snooze {
+		if rule.matches(section, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r SnoozeRule) matches(section string, now time.Time) bool {
+	listed := false
+	for _, s := range r.Sections {
+		if strings.EqualFold(s, section) {
+			listed = true
+			break
+		}
+	}
+	return listed && activeAt(r.Hours, r.Days, now)
+}
+
+// activeAt reports whether now falls in an hours window like
+// "22:00-06:00" on one of days; empty hours mean all day and no days
+// every day
+func activeAt(hours string, days []string, now time.Time) bool {
+	if len(days) > 0 {
+		today := false
+		for _, d := range days {
+			if wd, ok := weekdayKeys[strings.ToLower(d)]; ok && wd == now.Weekday() {
+				today = true
+				break
+			}
+		}
+		if !today {
+			return false
+		}
+	}
+
+	if hours == "" {
+		return true
+	}
+	start, end, err := parseHoursRange(hours)
+	if err != nil {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	// Window wraps past midnight, e.g. 22:00-06:00
+	return minute >= start || minute < end
+}
+
+// DayStartMinute returns when "today" begins, in minutes after midnight.
+// schedule.day_starts_at wins over the older display.day_start_hour.
+func (c *Config) DayStartMinute() int {
+	if c.Schedule.DayStartsAt != "" {
+		if minute, err := parseDayStart(c.Schedule.DayStartsAt); err == nil {
+			return minute
+		}
+	}
+	if c.Display.DayStartHour < 0 || c.Display.DayStartHour > 23 {
+		return 0
+	}
+	return c.Display.DayStartHour * 60
+}
+
+// WorkHours returns schedule.work_hours in minutes after midnight and the
+// days it applies on. ok is false when work hours aren't set.
+func (c *Config) WorkHours() (start, end int, days []time.Weekday, ok bool) {
+	if c.Schedule.WorkHours == "" {
+		return 0, 0, nil, false
+	}
+	start, end, err := parseHoursRange(c.Schedule.WorkHours)
+	if err != nil {
+		return 0, 0, nil, false
+	}
+	for _, d := range c.Schedule.WorkDays {
+		if wd, known := weekdayKeys[strings.ToLower(d)]; known {
+			days = append(days, wd)
+		}
+	}
+	if len(days) == 0 {
+		days = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	return start, end % (24 * 60), days, true
+}
+
+// FragmentationWeights returns the factor weights the fragmentation score
+// uses: fragmentation.weights without the disabled factors, scaled so the
+// rest still add up to 100. Disabling every factor disables none.
+func (c *Config) FragmentationWeights() FragmentationWeightsConfig {
+	w := c.Fragmentation.enabledWeights()
+	sum := w.sum()
+	if sum <= 0 {
+		return c.Fragmentation.Weights
+	}
+	if sum == 100 {
+		return w
+	}
+
+	// Scale up, handing the rounding leftovers to the heaviest factor
+	scaled, total, heaviest := w, 0, ""
+	for _, factor := range FragmentationFactors {
+		f := scaled.field(factor)
+		*f = *f * 100 / sum
+		total += *f
+		if heaviest == "" || *f > *scaled.field(heaviest) {
+			heaviest = factor
+		}
+	}
+	*scaled.field(heaviest) += 100 - total
+	return scaled
+}
+
+// enabledWeights returns the weights with the disabled factors zeroed
+func (f FragmentationThresholdsConfig) enabledWeights() FragmentationWeightsConfig {
+	w := f.Weights
+	for _, factor := range f.Disable {
+		if weight := w.field(strings.ToLower(factor)); weight != nil {
+			*weight = 0
+		}
+	}
+	return w
+}
+
+// LateNightWindow returns wellness.late_night in minutes after midnight.
+// end is before start when the window wraps past midnight.
+func (c *Config) LateNightWindow() (start, end int) {
+	start, end, err := parseHoursRange(c.Wellness.LateNight)
+	if err != nil {
+		start, end, _ = parseHoursRange(Default().Wellness.LateNight)
+	}
+	return start, end % (24 * 60)
+}
+
+// parseDayStart parses a day boundary, "HH:MM" before 24:00
+func parseDayStart(s string) (int, error) {
+	minute, err := parseClock(strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	if minute >= 24*60 {
+		return 0, fmt.Errorf("invalid time %q, must be before 24:00", s)
+	}
+	return minute, nil
+}
+
+// parseHoursRange parses "HH:MM-HH:MM" into minutes since midnight
+func parseHoursRange(s string) (start, end int, err error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected HH:MM-HH:MM, got %q", s)
+	}
+	if start, err = parseClock(strings.TrimSpace(from)); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(strings.TrimSpace(to)); err != nil {
+		return 0, 0, err
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("empty window %q", s)
+	}
+	return start, end, nil
+}
+
+// parseClock parses "HH:MM" (24h) into minutes since midnight. "24:00" is
+// accepted as the end of the day.
+func parseClock(s string) (int, error) {
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
 // ApplyTheme applies a theme's colors to the config, overriding existing colors
 func (c *Config) ApplyTheme(t theme.Theme) {
 	c.Colors.Primary = t.Colors.Primary
@@ -258,14 +985,88 @@ func (c *Config) ApplyTheme(t theme.Theme) {
 	c.Colors.Warning = t.Colors.Warning
 	c.Colors.Muted = t.Colors.Muted
 	c.Colors.Text = t.Colors.Text
+	c.Colors.Chart = t.Colors.Chart
+	c.Colors.Severity = t.Colors.Severity
+}
+
+// themeColors returns the configured colors as theme colors
+func (c *Config) themeColors() theme.ThemeColors {
+	return theme.ThemeColors{
+		Primary:   c.Colors.Primary,
+		Secondary: c.Colors.Secondary,
+		Accent:    c.Colors.Accent,
+		Success:   c.Colors.Success,
+		Warning:   c.Colors.Warning,
+		Muted:     c.Colors.Muted,
+		Text:      c.Colors.Text,
+		Chart:     c.Colors.Chart,
+		Severity:  c.Colors.Severity,
+	}
+}
+
+// ChartPalette returns the colors for chart series, in order
+func (c *Config) ChartPalette() []string {
+	return c.themeColors().ChartPalette()
+}
+
+// SeverityPalette returns the colors for low, medium, and high severity
+// warnings
+func (c *Config) SeverityPalette() theme.SeverityColors {
+	return c.themeColors().SeverityPalette()
 }
 
-// CategorizeDomain returns "work", "distraction", "neutral", or "" (uncategorized)
+// CategorizeDomain returns "work", "distraction", "neutral", or "" (uncategorized).
+// Only domain rules without hours or days apply, since there's no visit
+// time; see CategorizeURL.
 func (c *Config) CategorizeDomain(domain string) string {
 	if domain == "" {
 		return ""
 	}
 
+	// Learning comes first so a course on a work domain counts as learning
+	for _, pattern := range c.Learning.Domains {
+		if !strings.ContainsAny(pattern, "/?") && matchDomainPattern(domain, strings.ToLower(pattern)) {
+			return "learning"
+		}
+	}
+
+	for _, rule := range c.Domains.Rules {
+		if rule.matches(domain, "", "", time.Time{}) {
+			return rule.Category
+		}
+	}
+
+	return c.categorizeByLists(domain)
+}
+
+// CategorizeURL returns the category of a visit to rawURL at at, like
+// CategorizeDomain but with domain rules on paths, queries, and times of
+// day applied too
+func (c *Config) CategorizeURL(rawURL string, at time.Time) string {
+	host, path := splitURL(rawURL)
+	if host == "" {
+		return ""
+	}
+	if c.IsLearningURL(rawURL) {
+		return "learning"
+	}
+
+	query := ""
+	if _, q, ok := strings.Cut(rawURL, "?"); ok {
+		query, _, _ = strings.Cut(q, "#")
+	}
+	for _, rule := range c.Domains.Rules {
+		if rule.matches(host, path, query, at) {
+			return rule.Category
+		}
+	}
+
+	return c.categorizeByLists(host)
+}
+
+// categorizeByLists checks domain against the work, distraction, and
+// neutral lists, in that order
+func (c *Config) categorizeByLists(domain string) string {
 	// Check work domains
 	for _, pattern := range c.Domains.Work {
 		if matchDomainPattern(domain, pattern) {
@@ -291,6 +1092,241 @@ func (c *Config) CategorizeDomain(domain string) string {
 	return "neutral"
 }
 
+// Coordinates returns the configured latitude and longitude, and false
+// when either is unset or out of range
+func (c *Config) Coordinates() (lat, lon float64, ok bool) {
+	if c.Location.Latitude == nil || c.Location.Longitude == nil {
+		return 0, 0, false
+	}
+	lat, lon = *c.Location.Latitude, *c.Location.Longitude
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// ProjectForApp returns the project an app is mapped to, or "" if none.
+// Apps match by name or bundle ID, case-insensitively.
+func (c *Config) ProjectForApp(name, bundleID string) string {
+	for _, p := range c.Projects {
+		for _, app := range p.Apps {
+			if strings.EqualFold(app, name) || (bundleID != "" && strings.EqualFold(app, bundleID)) {
+				return p.Name
+			}
+		}
+	}
+	return ""
+}
+
+// ProjectForURL returns the project a visited URL belongs to, or "" if none.
+// issueID is the issue key extracted from the URL ("" if it isn't an issue page).
+func (c *Config) ProjectForURL(rawURL, issueID string) string {
+	host, path := splitURL(rawURL)
+	if host == "" {
+		return ""
+	}
+
+	for _, p := range c.Projects {
+		if issueID != "" {
+			for _, pattern := range p.Issues {
+				if matchGlob(strings.ToLower(issueID), strings.ToLower(pattern)) {
+					return p.Name
+				}
+			}
+		}
+		for _, pattern := range p.Domains {
+			if matchURLPattern(host, path, strings.ToLower(pattern)) {
+				return p.Name
+			}
+		}
+	}
+	return ""
+}
+
+// ProjectForTitle returns the project a window title belongs to, or "" if
+// none. Patterns without "*" match anywhere in the title; with one they're
+// globs over the whole title. Both ignore case.
+func (c *Config) ProjectForTitle(title string) string {
+	title = strings.ToLower(title)
+	for _, p := range c.Projects {
+		for _, pattern := range p.Titles {
+			pattern = strings.ToLower(pattern)
+			if pattern == "" {
+				continue
+			}
+			if strings.Contains(pattern, "*") {
+				if matchGlob(title, pattern) {
+					return p.Name
+				}
+			} else if strings.Contains(title, pattern) {
+				return p.Name
+			}
+		}
+	}
+	return ""
+}
+
+// MatchApp reports whether an app matches one of patterns, as listed in
+// tracking.exclude_apps. Patterns match the app's name or bundle ID,
+// case-insensitively, and "*" matches any run of characters, so
+// "com.apple.*" covers every Apple app.
+func MatchApp(patterns []string, name, bundleID string) bool {
+	name, bundleID = strings.ToLower(name), strings.ToLower(bundleID)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if matchGlob(name, pattern) || (bundleID != "" && matchGlob(bundleID, pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SamplesWindowTitle reports whether an app is listed in
+// tracking.window_titles. Apps match by name or bundle ID,
+// case-insensitively.
+func (c *Config) SamplesWindowTitle(name, bundleID string) bool {
+	for _, app := range c.Tracking.WindowTitles {
+		if strings.EqualFold(app, name) || (bundleID != "" && strings.EqualFold(app, bundleID)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLearningApp reports whether an app is listed in learning.apps. Apps
+// match by name or bundle ID, case-insensitively.
+func (c *Config) IsLearningApp(name, bundleID string) bool {
+	for _, app := range c.Learning.Apps {
+		if strings.EqualFold(app, name) || (bundleID != "" && strings.EqualFold(app, bundleID)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLearningURL reports whether a visited URL matches learning.domains.
+// Patterns containing "?" are globs over the URL including its query, so
+// a single YouTube playlist can count without all of YouTube.
+func (c *Config) IsLearningURL(rawURL string) bool {
+	return matchURLPatterns(rawURL, c.Learning.Domains)
+}
+
+// RedactsURL reports whether a URL matches privacy.redact_domains.
+// Collectors drop redacted tabs and visits as they read them, so they
+// never reach output, integrations, or the history store.
+func (c *Config) RedactsURL(rawURL string) bool {
+	return matchURLPatterns(rawURL, c.Privacy.RedactDomains)
+}
+
+// RedactsDomain reports whether a domain matches privacy.redact_domains.
+// Only domain patterns apply; ones with a path need the whole URL.
+func (c *Config) RedactsDomain(domain string) bool {
+	domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+	for _, pattern := range c.Privacy.RedactDomains {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" && !strings.ContainsAny(pattern, "/?") && matchDomainPattern(domain, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniesIssue reports whether an issue matches privacy.issue_denylist, by
+// its ID ("PROJ-123", "org/repo#89") or the URL it was visited at
+func (c *Config) DeniesIssue(issueID, rawURL string) bool {
+	for _, pattern := range c.Privacy.IssueDenylist {
+		if issueID != "" && matchGlob(strings.ToLower(issueID), strings.ToLower(strings.TrimSpace(pattern))) {
+			return true
+		}
+	}
+	return matchURLPatterns(rawURL, c.Privacy.IssueDenylist)
+}
+
+// matchURLPatterns reports whether a URL matches one of patterns: domain
+// patterns, "domain/path" prefixes, or, for patterns containing "?", globs
+// over the URL including its query
+func matchURLPatterns(rawURL string, patterns []string) bool {
+	host, path := splitURL(rawURL)
+	if host == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if strings.Contains(pattern, "?") {
+			query := ""
+			if _, q, ok := strings.Cut(rawURL, "?"); ok {
+				query, _, _ = strings.Cut(q, "#")
+			}
+			if matchGlob(strings.ToLower(host+path+"?"+query), pattern) {
+				return true
+			}
+			continue
+		}
+		if matchURLPattern(host, path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitURL returns the lowercased host (without "www.") and the path of a URL
+func splitURL(rawURL string) (host, path string) {
+	s := rawURL
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+	host, path = s, ""
+	if i := strings.Index(s, "/"); i >= 0 {
+		host, path = s[:i], s[i:]
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	return host, path
+}
+
+// matchURLPattern matches a URL against a project domain pattern. Patterns
+// without a path use domain matching; patterns with a path match host+path,
+// where "*" matches any run of characters and a plain path matches its subpaths.
+func matchURLPattern(host, path, pattern string) bool {
+	slash := strings.Index(pattern, "/")
+	if slash < 0 {
+		return matchDomainPattern(host, pattern)
+	}
+
+	target := host + path
+	if strings.Contains(pattern, "*") {
+		return matchGlob(target, pattern)
+	}
+	pattern = strings.TrimSuffix(pattern, "/")
+	return target == pattern || strings.HasPrefix(target, pattern+"/")
+}
+
+// matchGlob reports whether s matches pattern, where "*" matches any run of
+// characters (including none) and everything else matches literally
+func matchGlob(s, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return s == pattern
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
 // ValidateStrict checks config values and returns a list of issues
 // Unlike Validate(), it does not silently fix invalid values
 func ValidateStrict(c *Config) []string {
@@ -299,6 +1335,30 @@ func ValidateStrict(c *Config) []string {
 	if c.Display.TimeFormat != "" && c.Display.TimeFormat != "12h" && c.Display.TimeFormat != "24h" {
 		errors = append(errors, fmt.Sprintf("display.time_format: invalid value %q (must be \"12h\" or \"24h\")", c.Display.TimeFormat))
 	}
+	if c.Display.Locale != "" && !locale.Valid(c.Display.Locale) {
+		errors = append(errors, fmt.Sprintf("display.locale: invalid value %q (e.g. \"en_GB\" or \"de_DE\")", c.Display.Locale))
+	}
+
+	if c.Display.DayStartHour < 0 || c.Display.DayStartHour > 23 {
+		errors = append(errors, fmt.Sprintf("display.day_start_hour: must be 0-23, got %d", c.Display.DayStartHour))
+	}
+	if c.Schedule.DayStartsAt != "" {
+		if _, err := parseDayStart(c.Schedule.DayStartsAt); err != nil {
+			errors = append(errors, fmt.Sprintf("schedule.day_starts_at: %v", err))
+		} else if c.Display.DayStartHour != 0 {
+			errors = append(errors, "display.day_start_hour: ignored because schedule.day_starts_at is set; remove it")
+		}
+	}
+	if c.Schedule.WorkHours != "" {
+		if _, _, err := parseHoursRange(c.Schedule.WorkHours); err != nil {
+			errors = append(errors, fmt.Sprintf("schedule.work_hours: %v", err))
+		}
+	}
+	for _, d := range c.Schedule.WorkDays {
+		if _, ok := weekdayKeys[strings.ToLower(d)]; !ok {
+			errors = append(errors, fmt.Sprintf("schedule.work_days: unknown day %q (use mon..sun)", d))
+		}
+	}
 
 	if c.Fragmentation.FocusedMax <= 0 {
 		errors = append(errors, fmt.Sprintf("fragmentation.focused_max: must be > 0, got %d", c.Fragmentation.FocusedMax))
@@ -321,9 +1381,305 @@ func ValidateStrict(c *Config) []string {
 		}
 	}
 
+	for _, t := range []struct {
+		key   string
+		value int
+	}{
+		{"long_day_hours", c.Wellness.LongDayHours},
+		{"switches_per_hour", c.Wellness.SwitchesPerHour},
+		{"max_tabs", c.Wellness.MaxTabs},
+		{"no_break_hours", c.Wellness.NoBreakHours},
+		{"after_hours_minutes", c.Wellness.AfterHoursMinutes},
+	} {
+		if t.value <= 0 {
+			errors = append(errors, fmt.Sprintf("wellness.%s: must be > 0, got %d", t.key, t.value))
+		}
+	}
+	if c.Wellness.LongDayHours > 24 {
+		errors = append(errors, fmt.Sprintf("wellness.long_day_hours: must be at most 24, got %d", c.Wellness.LongDayHours))
+	}
+	if c.Wellness.NoBreakHours > 24 {
+		errors = append(errors, fmt.Sprintf("wellness.no_break_hours: must be at most 24, got %d", c.Wellness.NoBreakHours))
+	}
+	if _, _, err := parseHoursRange(c.Wellness.LateNight); err != nil {
+		errors = append(errors, fmt.Sprintf("wellness.late_night: %v", err))
+	}
+
+	w := c.Fragmentation.Weights
+	for _, factor := range FragmentationFactors {
+		if weight := *w.field(factor); weight < 0 {
+			errors = append(errors, fmt.Sprintf("fragmentation.weights.%s: must be >= 0, got %d", factor, weight))
+		}
+	}
+	if w.sum() != 100 {
+		errors = append(errors, fmt.Sprintf("fragmentation.weights: must add up to 100, got %d", w.sum()))
+	}
+	for _, factor := range c.Fragmentation.Disable {
+		if w.field(strings.ToLower(factor)) == nil {
+			errors = append(errors, fmt.Sprintf("fragmentation.disable: unknown factor %q (use %s)", factor, strings.Join(FragmentationFactors, ", ")))
+		}
+	}
+	if len(c.Fragmentation.Disable) > 0 && c.Fragmentation.enabledWeights().sum() == 0 {
+		errors = append(errors, "fragmentation.disable: leaves no factor to score")
+	}
+
+	seenProjects := make(map[string]bool)
+	for i, p := range c.Projects {
+		if strings.TrimSpace(p.Name) == "" {
+			errors = append(errors, fmt.Sprintf("projects[%d]: name is required", i))
+			continue
+		}
+		if seenProjects[p.Name] {
+			errors = append(errors, fmt.Sprintf("projects[%d]: duplicate project name %q", i, p.Name))
+		}
+		seenProjects[p.Name] = true
+		if len(p.Apps) == 0 && len(p.Domains) == 0 && len(p.Issues) == 0 && len(p.Titles) == 0 {
+			errors = append(errors, fmt.Sprintf("projects[%d] (%s): needs at least one of apps, domains, issues, or titles", i, p.Name))
+		}
+	}
+
+	seenSections := make(map[string]bool)
+	for i, section := range c.Custom {
+		name := strings.ToLower(strings.TrimSpace(section.Name))
+		if name == "" {
+			errors = append(errors, fmt.Sprintf("custom_sections[%d]: name is required", i))
+			continue
+		}
+		if isSectionKey(name) || seenSections[name] {
+			errors = append(errors, fmt.Sprintf("custom_sections[%d]: section name %q is already taken", i, section.Name))
+		}
+		seenSections[name] = true
+		if (section.Command == "") == (section.File == "") {
+			errors = append(errors, fmt.Sprintf("custom_sections[%d] (%s): needs exactly one of command or file", i, section.Name))
+		}
+	}
+
+	if c.Network.MeteredWarningMB < 0 {
+		errors = append(errors, fmt.Sprintf("network.metered_warning_mb: must be > 0, got %d", c.Network.MeteredWarningMB))
+	}
+
+	if c.History.RetentionDays < 0 || (c.History.RetentionDays > 0 && c.History.RetentionDays < MinRetentionDays) {
+		errors = append(errors, fmt.Sprintf("history.retention_days: must be >= %d, got %d", MinRetentionDays, c.History.RetentionDays))
+	}
+	if c.History.KeepWeeks < 0 {
+		errors = append(errors, fmt.Sprintf("history.keep_weeks: must be >= 0, got %d", c.History.KeepWeeks))
+	}
+	if c.Performance.MemoryMB < 0 {
+		errors = append(errors, fmt.Sprintf("performance.memory_mb: must be > 0, got %d", c.Performance.MemoryMB))
+	}
+	if c.History.RunLogMaxMB < 0 {
+		errors = append(errors, fmt.Sprintf("history.run_log_max_mb: must be > 0, got %d", c.History.RunLogMaxMB))
+	}
+
+	for i, command := range c.Hooks.PreCollect {
+		if strings.TrimSpace(command) == "" {
+			errors = append(errors, fmt.Sprintf("hooks.pre_collect[%d]: command is empty", i))
+		}
+	}
+	for i, command := range c.Hooks.PostCollect {
+		if strings.TrimSpace(command) == "" {
+			errors = append(errors, fmt.Sprintf("hooks.post_collect[%d]: command is empty", i))
+		}
+	}
+	if c.Hooks.TimeoutSeconds < 0 {
+		errors = append(errors, fmt.Sprintf("hooks.timeout_seconds: must be > 0, got %d", c.Hooks.TimeoutSeconds))
+	}
+
+	if c.Narrate.Enabled && strings.TrimSpace(c.Narrate.Command) == "" {
+		errors = append(errors, "narrate.command: required when narrate is enabled")
+	}
+	if c.Narrate.Prompt != "" {
+		if _, err := template.New("prompt").Parse(c.Narrate.Prompt); err != nil {
+			errors = append(errors, fmt.Sprintf("narrate.prompt: %v", err))
+		}
+	}
+	if c.Narrate.TimeoutSeconds < 0 {
+		errors = append(errors, fmt.Sprintf("narrate.timeout_seconds: must be > 0, got %d", c.Narrate.TimeoutSeconds))
+	}
+
+	if c.Learning.WeeklyGoalHours < 0 {
+		errors = append(errors, fmt.Sprintf("learning.weekly_goal_hours: must be >= 0, got %g", c.Learning.WeeklyGoalHours))
+	}
+
+	for _, list := range []struct {
+		key      string
+		patterns []string
+	}{
+		{"privacy.redact_domains", c.Privacy.RedactDomains},
+		{"privacy.issue_denylist", c.Privacy.IssueDenylist},
+	} {
+		if slices.ContainsFunc(list.patterns, func(p string) bool { return strings.TrimSpace(p) == "" }) {
+			errors = append(errors, list.key+": empty pattern")
+		}
+	}
+
+	// Secrets can be named by "keychain:NAME" or a $VAR, which are checked
+	// when they're used
+	type secret struct{ key, value, item string }
+	secrets := []secret{
+		{"integrations.slack.webhook_url", c.Integrations.Slack.WebhookURL, "rekap-slack-webhook"},
+		{"integrations.slack.token", c.Integrations.Slack.Token, "rekap-slack-token"},
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Integrations.Webhooks)) {
+		hook := c.Integrations.Webhooks[name]
+		secrets = append(secrets, secret{"integrations.webhooks." + name + ".url", hook.URL, "rekap-webhook-" + name})
+		for _, header := range slices.Sorted(maps.Keys(hook.Headers)) {
+			if credentialHeader(header) {
+				key := "integrations.webhooks." + name + ".headers." + header
+				secrets = append(secrets, secret{key, hook.Headers[header], "rekap-webhook-" + name + "-" + strings.ToLower(header)})
+			}
+		}
+	}
+	for _, secret := range secrets {
+		if name, ok := keychain.Ref(secret.value); ok && !keychain.ValidName(name) {
+			errors = append(errors, fmt.Sprintf("%s: invalid Keychain item name %q (use letters, digits, '.', '_', and '-')", secret.key, name))
+		} else if secret.value != "" && !secretRef(secret.value) {
+			errors = append(errors, fmt.Sprintf("%s: secret is in plain text; save it with 'rekap secrets set %s' and use \"keychain:%s\"", secret.key, secret.item, secret.item))
+		}
+	}
+	if u := c.Integrations.Slack.WebhookURL; u != "" && !secretRef(u) && !strings.HasPrefix(u, "https://") {
+		errors = append(errors, "integrations.slack.webhook_url: must be an https:// URL")
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Integrations.Webhooks)) {
+		hook := c.Integrations.Webhooks[name]
+		if !secretRef(hook.URL) && !strings.HasPrefix(hook.URL, "https://") && !strings.HasPrefix(hook.URL, "http://") {
+			errors = append(errors, fmt.Sprintf("integrations.webhooks.%s.url: must be an http:// or https:// URL", name))
+		}
+		if m := strings.ToUpper(strings.TrimSpace(hook.Method)); m != "" && !slices.Contains(webhookMethods, m) {
+			errors = append(errors, fmt.Sprintf("integrations.webhooks.%s.method: unknown method %q (valid: %s)",
+				name, hook.Method, strings.Join(webhookMethods, ", ")))
+		}
+	}
+
+	for _, src := range []struct{ collector, name string }{
+		{"screen", c.Sources.Screen},
+		{"apps", c.Sources.Apps},
+		{"media", c.Sources.Media},
+	} {
+		if src.name != "" && validSource(src.collector, src.name) != strings.ToLower(strings.TrimSpace(src.name)) {
+			errors = append(errors, fmt.Sprintf("sources.%s: unknown source %q (valid: %s)",
+				src.collector, src.name, strings.Join(sourceChoices[src.collector], ", ")))
+		}
+	}
+
+	if lat, lon := c.Location.Latitude, c.Location.Longitude; (lat == nil) != (lon == nil) {
+		errors = append(errors, "location: set both latitude and longitude")
+	} else if lat != nil {
+		if *lat < -90 || *lat > 90 {
+			errors = append(errors, fmt.Sprintf("location.latitude: %g is out of range (-90 to 90)", *lat))
+		}
+		if *lon < -180 || *lon > 180 {
+			errors = append(errors, fmt.Sprintf("location.longitude: %g is out of range (-180 to 180)", *lon))
+		}
+	}
+
+	for number := range c.Tracking.SpaceNames {
+		if number < 1 {
+			errors = append(errors, fmt.Sprintf("tracking.space_names: desktop %d is invalid (desktops are numbered from 1)", number))
+		}
+	}
+
+	for _, section := range c.Sections.Only {
+		if !isSectionKey(section) && !c.isCustomSection(section) {
+			errors = append(errors, fmt.Sprintf("sections.only: unknown section %q (valid: %s)", section, strings.Join(SectionKeys, ", ")))
+		}
+	}
+	for _, section := range c.Sections.Skip {
+		if !isSectionKey(section) && !c.isCustomSection(section) {
+			errors = append(errors, fmt.Sprintf("sections.skip: unknown section %q (valid: %s)", section, strings.Join(SectionKeys, ", ")))
+		}
+	}
+
+	for i, rule := range c.Domains.Rules {
+		key := fmt.Sprintf("domains.rules[%d]", i)
+		switch {
+		case rule.Match == "" && rule.Regex == "":
+			errors = append(errors, key+": set match or regex")
+		case rule.Match != "" && rule.Regex != "":
+			errors = append(errors, key+": set match or regex, not both")
+		case rule.Regex != "":
+			if _, err := regexp.Compile(rule.Regex); err != nil {
+				errors = append(errors, fmt.Sprintf("%s.regex: %v", key, err))
+			}
+		}
+		if !slices.Contains(domainCategories, rule.Category) {
+			errors = append(errors, fmt.Sprintf("%s.category: invalid value %q (must be %s)", key, rule.Category, strings.Join(domainCategories, ", ")))
+		}
+		if rule.Hours != "" {
+			if _, _, err := parseHoursRange(rule.Hours); err != nil {
+				errors = append(errors, fmt.Sprintf("%s.hours: %v", key, err))
+			}
+		}
+		for _, d := range rule.Days {
+			if _, ok := weekdayKeys[strings.ToLower(d)]; !ok {
+				errors = append(errors, fmt.Sprintf("%s.days: unknown day %q (use mon..sun)", key, d))
+			}
+		}
+	}
+
+	for _, section := range c.Display.SectionOrder {
+		if !isSectionKey(section) && !c.isCustomSection(section) {
+			errors = append(errors, fmt.Sprintf("display.section_order: unknown section %q (valid: %s)", section, strings.Join(SectionKeys, ", ")))
+		}
+	}
+	for _, section := range c.Display.HiddenSections {
+		if !isSectionKey(section) && !c.isCustomSection(section) {
+			errors = append(errors, fmt.Sprintf("display.hidden_sections: unknown section %q (valid: %s)", section, strings.Join(SectionKeys, ", ")))
+		}
+	}
+
+	for i, rule := range c.Snooze {
+		if len(rule.Sections) == 0 {
+			errors = append(errors, fmt.Sprintf("snooze[%d]: sections is required", i))
+		}
+		for _, section := range rule.Sections {
+			if !isSectionKey(section) && !c.isCustomSection(section) {
+				errors = append(errors, fmt.Sprintf("snooze[%d]: unknown section %q (valid: %s)", i, section, strings.Join(SectionKeys, ", ")))
+			}
+		}
+		if rule.Hours != "" {
+			if _, _, err := parseHoursRange(rule.Hours); err != nil {
+				errors = append(errors, fmt.Sprintf("snooze[%d].hours: %v", i, err))
+			}
+		}
+		for _, d := range rule.Days {
+			if _, ok := weekdayKeys[strings.ToLower(d)]; !ok {
+				errors = append(errors, fmt.Sprintf("snooze[%d].days: unknown day %q (use mon..sun)", i, d))
+			}
+		}
+	}
+
 	return errors
 }
 
+// IsSection reports whether name is a section key or a custom section
+func (c *Config) IsSection(name string) bool {
+	return isSectionKey(name) || c.isCustomSection(name)
+}
+
+func (c *Config) isCustomSection(name string) bool {
+	for _, section := range c.Custom {
+		if strings.EqualFold(section.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSectionKey(name string) bool {
+	return containsFold(SectionKeys, name)
+}
+
+// containsFold reports whether list has s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchDomainPattern matches a domain against a pattern
 // Supports wildcards like "docs.*" or "*.google.com"
 func matchDomainPattern(domain, pattern string) bool {
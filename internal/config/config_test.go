@@ -3,7 +3,12 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/locale"
 )
 
 func TestDefault(t *testing.T) {
@@ -14,8 +19,8 @@ func TestDefault(t *testing.T) {
 		t.Errorf("Expected primary color 13, got %s", cfg.Colors.Primary)
 	}
 
-	if cfg.Display.TimeFormat != "12h" {
-		t.Errorf("Expected 12h time format, got %s", cfg.Display.TimeFormat)
+	if cfg.Display.TimeFormat != "" {
+		t.Errorf("Expected time format to follow the locale, got %s", cfg.Display.TimeFormat)
 	}
 
 	if !cfg.ShouldShowMedia() {
@@ -46,9 +51,38 @@ func TestLoadNonExistent(t *testing.T) {
 		t.Fatalf("Load() should not error for non-existent config: %v", err)
 	}
 
-	// Should return defaults
-	if cfg.Display.TimeFormat != "12h" {
-		t.Errorf("Expected default time format 12h, got %s", cfg.Display.TimeFormat)
+	// Should return defaults, with the clock from the system locale
+	if want := cfg.Locale().TimeFormat(); cfg.Display.TimeFormat != want {
+		t.Errorf("Expected default time format %s, got %s", want, cfg.Display.TimeFormat)
+	}
+}
+
+func TestLoadInvalidFallsBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, ".config", "rekap")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte("display:\n  time_format: [24h\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	// A locale left over from elsewhere shouldn't survive the fallback
+	locale.Set(locale.Parse("fr_FR"))
+	cfg, err := Load()
+	if err == nil {
+		t.Fatal("Load() should fail on a YAML syntax error")
+	}
+	if want := cfg.Locale().TimeFormat(); cfg.Display.TimeFormat != want {
+		t.Errorf("fallback time format = %q, want %q from the system locale", cfg.Display.TimeFormat, want)
+	}
+	if got, want := locale.Current().Tag, Default().Locale().Tag; got != want {
+		t.Errorf("locale after a failed Load = %s, want the system locale %s", got, want)
 	}
 }
 
@@ -124,15 +158,21 @@ func TestValidate(t *testing.T) {
 	cfg := &Config{
 		Display: DisplayConfig{
 			TimeFormat: "invalid",
+			Locale:     "en_US",
 		},
 	}
 
 	cfg.Validate()
 
-	// Should default to 12h for invalid format
+	// Should default to the locale's clock for invalid format
 	if cfg.Display.TimeFormat != "12h" {
 		t.Errorf("Expected time format to default to 12h, got %s", cfg.Display.TimeFormat)
 	}
+	german := &Config{Display: DisplayConfig{Locale: "de_DE"}}
+	german.Validate()
+	if german.Display.TimeFormat != "24h" {
+		t.Errorf("Expected de_DE to default to 24h, got %s", german.Display.TimeFormat)
+	}
 
 	// Should have default colors
 	if cfg.Colors.Primary == "" {
@@ -140,6 +180,477 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateDayStartHour(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Display.DayStartHour = 4
+	cfg.Validate()
+	if cfg.Display.DayStartHour != 4 {
+		t.Errorf("Expected day start hour 4 to be kept, got %d", cfg.Display.DayStartHour)
+	}
+
+	cfg.Display.DayStartHour = 24
+	if errs := ValidateStrict(cfg); len(errs) != 1 {
+		t.Errorf("Expected 1 strict validation error for hour 24, got %v", errs)
+	}
+	cfg.Validate()
+	if cfg.Display.DayStartHour != 0 {
+		t.Errorf("Expected invalid day start hour to reset to 0, got %d", cfg.Display.DayStartHour)
+	}
+}
+
+func TestDayStartMinute(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		startsAt   string
+		hour       int
+		want       int
+		strictErrs int
+	}{
+		{"default is midnight", "", 0, 0, 0},
+		{"deprecated hour still works", "", 4, 240, 0},
+		{"schedule takes minutes", "04:30", 0, 270, 0},
+		{"schedule wins over the hour", "05:00", 3, 300, 1},
+		{"invalid schedule falls back to the hour", "25:00", 2, 120, 1},
+		{"24:00 isn't a day start", "24:00", 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Schedule.DayStartsAt = tt.startsAt
+			cfg.Display.DayStartHour = tt.hour
+			if errs := ValidateStrict(cfg); len(errs) != tt.strictErrs {
+				t.Errorf("ValidateStrict() = %v, want %d issue(s)", errs, tt.strictErrs)
+			}
+			if got := cfg.DayStartMinute(); got != tt.want {
+				t.Errorf("DayStartMinute() = %d, want %d", got, tt.want)
+			}
+			cfg.Validate()
+			if got := cfg.DayStartMinute(); got != tt.want {
+				t.Errorf("DayStartMinute() after Validate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkHours(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		hours      string
+		days       []string
+		start, end int
+		ndays      int
+		ok         bool
+		strictErrs int
+	}{
+		{"unset", "", nil, 0, 0, 0, false, 0},
+		{"office hours on weekdays", "09:00-18:00", nil, 540, 1080, 5, true, 0},
+		{"night shift", "22:00-06:00", []string{"sun", "mon"}, 1320, 360, 2, true, 0},
+		{"until midnight", "09:00-24:00", nil, 540, 0, 5, true, 0},
+		{"bad range", "9-5", nil, 0, 0, 0, false, 1},
+		{"bad day", "09:00-17:00", []string{"funday"}, 540, 1020, 5, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Schedule.WorkHours = tt.hours
+			cfg.Schedule.WorkDays = tt.days
+			if errs := ValidateStrict(cfg); len(errs) != tt.strictErrs {
+				t.Errorf("ValidateStrict() = %v, want %d issue(s)", errs, tt.strictErrs)
+			}
+			start, end, days, ok := cfg.WorkHours()
+			if start != tt.start || end != tt.end || len(days) != tt.ndays || ok != tt.ok {
+				t.Errorf("WorkHours() = %d, %d, %v, %v; want %d, %d, %d days, %v", start, end, days, ok, tt.start, tt.end, tt.ndays, tt.ok)
+			}
+		})
+	}
+}
+
+func TestValidateWellness(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if errs := ValidateStrict(cfg); len(errs) != 0 {
+		t.Fatalf("defaults: %v", errs)
+	}
+
+	cfg.Wellness.LongDayHours = 0
+	cfg.Wellness.MaxTabs = -5
+	cfg.Wellness.NoBreakHours = 30
+	cfg.Wellness.LateNight = "late"
+	if errs := ValidateStrict(cfg); len(errs) != 4 {
+		t.Errorf("ValidateStrict() = %v, want 4 issues", errs)
+	}
+
+	cfg.Validate()
+	want := Default().Wellness
+	want.NoBreakHours = 30
+	if cfg.Wellness != want {
+		t.Errorf("Validate() left %+v, want %+v", cfg.Wellness, want)
+	}
+	if start, end := cfg.LateNightWindow(); start != 0 || end != 360 {
+		t.Errorf("LateNightWindow() = %d-%d, want 0-360", start, end)
+	}
+}
+
+func TestFragmentationWeights(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		weights    FragmentationWeightsConfig
+		disable    []string
+		want       FragmentationWeightsConfig
+		strictErrs int
+	}{
+		{"defaults", FragmentationWeightsConfig{30, 25, 25, 20}, nil, FragmentationWeightsConfig{30, 25, 25, 20}, 0},
+		{"custom", FragmentationWeightsConfig{40, 10, 20, 30}, nil, FragmentationWeightsConfig{40, 10, 20, 30}, 0},
+		{"tabs off scales the rest", FragmentationWeightsConfig{30, 25, 25, 20}, []string{"Tabs"}, FragmentationWeightsConfig{41, 0, 33, 26}, 0},
+		{"zero weight is off", FragmentationWeightsConfig{50, 0, 25, 25}, nil, FragmentationWeightsConfig{50, 0, 25, 25}, 0},
+		{"unknown factor", FragmentationWeightsConfig{30, 25, 25, 20}, []string{"windows"}, FragmentationWeightsConfig{30, 25, 25, 20}, 1},
+		{"all off", FragmentationWeightsConfig{30, 25, 25, 20}, FragmentationFactors, FragmentationWeightsConfig{30, 25, 25, 20}, 1},
+		{"bad sum", FragmentationWeightsConfig{30, 30, 30, 30}, nil, FragmentationWeightsConfig{30, 25, 25, 20}, 1},
+		{"negative", FragmentationWeightsConfig{-10, 50, 40, 20}, nil, FragmentationWeightsConfig{30, 25, 25, 20}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Fragmentation.Weights = tt.weights
+			cfg.Fragmentation.Disable = tt.disable
+			if errs := ValidateStrict(cfg); len(errs) != tt.strictErrs {
+				t.Errorf("ValidateStrict() = %v, want %d issue(s)", errs, tt.strictErrs)
+			}
+			cfg.Validate()
+			if got := cfg.FragmentationWeights(); got != tt.want {
+				t.Errorf("FragmentationWeights() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeteredWarningBytes(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if got, want := cfg.MeteredWarningBytes(), int64(500*1024*1024); got != want {
+		t.Errorf("MeteredWarningBytes() = %d, want %d", got, want)
+	}
+
+	cfg.Network.MeteredWarningMB = -1
+	if errs := ValidateStrict(cfg); len(errs) != 1 {
+		t.Errorf("Expected 1 strict validation error for negative threshold, got %v", errs)
+	}
+	cfg.Validate()
+	if cfg.Network.MeteredWarningMB != 500 {
+		t.Errorf("Expected invalid threshold to reset to 500, got %d", cfg.Network.MeteredWarningMB)
+	}
+}
+
+func TestValidateHistoryRetention(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if cfg.History.RetentionDays != 30 || cfg.History.KeepWeeks != 0 {
+		t.Errorf("Expected default retention 30 days and keep_weeks 0, got %d and %d",
+			cfg.History.RetentionDays, cfg.History.KeepWeeks)
+	}
+
+	cfg.History.RetentionDays = 3
+	cfg.History.KeepWeeks = -1
+	if errs := ValidateStrict(cfg); len(errs) != 2 {
+		t.Errorf("Expected 2 strict validation errors, got %v", errs)
+	}
+	cfg.Validate()
+	if cfg.History.RetentionDays != MinRetentionDays {
+		t.Errorf("Expected short retention to be raised to %d, got %d", MinRetentionDays, cfg.History.RetentionDays)
+	}
+	if cfg.History.KeepWeeks != 0 {
+		t.Errorf("Expected negative keep_weeks to reset to 0, got %d", cfg.History.KeepWeeks)
+	}
+}
+
+func TestValidateHooks(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if cfg.Hooks.TimeoutSeconds != 10 {
+		t.Errorf("Expected default hook timeout 10, got %d", cfg.Hooks.TimeoutSeconds)
+	}
+
+	cfg.Hooks.PreCollect = []string{"echo ok", " "}
+	cfg.Hooks.PostCollect = []string{""}
+	cfg.Hooks.TimeoutSeconds = -5
+	if errs := ValidateStrict(cfg); len(errs) != 3 {
+		t.Errorf("Expected 3 hook validation errors, got %v", errs)
+	}
+	cfg.Validate()
+	if cfg.Hooks.TimeoutSeconds != 10 {
+		t.Errorf("Expected invalid hook timeout to reset to 10, got %d", cfg.Hooks.TimeoutSeconds)
+	}
+}
+
+func TestValidateSources(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if cfg.Sources.Screen != SourceAuto || cfg.Sources.Apps != SourceAuto || cfg.Sources.Media != SourceAuto {
+		t.Errorf("Expected auto sources by default, got %+v", cfg.Sources)
+	}
+
+	cfg.Sources.Screen = "KnowledgeC"
+	cfg.Sources.Media = "itunes"
+	if errs := ValidateStrict(cfg); len(errs) != 1 || !strings.Contains(errs[0], "sources.media") {
+		t.Errorf("Expected one sources.media error, got %v", errs)
+	}
+	cfg.Validate()
+	if cfg.Sources.Screen != SourceKnowledgeC {
+		t.Errorf("Expected screen source %q, got %q", SourceKnowledgeC, cfg.Sources.Screen)
+	}
+	if cfg.Sources.Media != SourceAuto {
+		t.Errorf("Expected unknown media source to reset to auto, got %q", cfg.Sources.Media)
+	}
+}
+
+func TestSectionSnoozed(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Snooze = []SnoozeRule{
+		{Sections: []string{"media"}, Hours: "09:00-17:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+		{Sections: []string{"Wellness"}, Hours: "00:00-12:00"},
+		{Sections: []string{"notifications"}, Hours: "22:00-06:00"},
+	}
+
+	// 2026-02-16 is a Monday, 2026-02-21 a Saturday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 2, day, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		section string
+		now     time.Time
+		want    bool
+	}{
+		{"media", at(16, 10, 0), true},
+		{"media", at(16, 17, 0), false}, // end is exclusive
+		{"media", at(21, 10, 0), false}, // weekend
+		{"wellness", at(21, 11, 59), true},
+		{"wellness", at(21, 12, 0), false},
+		{"notifications", at(16, 23, 30), true},
+		{"notifications", at(16, 5, 59), true},
+		{"notifications", at(16, 6, 0), false},
+		{"system", at(16, 10, 0), false},
+	}
+	for _, tt := range tests {
+		if got := cfg.SectionSnoozed(tt.section, tt.now); got != tt.want {
+			t.Errorf("SectionSnoozed(%q, %s) = %v, want %v", tt.section, tt.now.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestValidateStrictSnooze(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Snooze = []SnoozeRule{
+		{Sections: []string{"media"}, Hours: "09:00-24:00", Days: []string{"Mon"}},
+		{Sections: []string{"lyrics"}, Hours: "9-5"},
+		{Hours: "10:00-10:00", Days: []string{"someday"}},
+	}
+	// unknown section, bad hours, missing sections, empty window, unknown day
+	if errs := ValidateStrict(cfg); len(errs) != 5 {
+		t.Errorf("Expected 5 snooze validation errors, got %v", errs)
+	}
+}
+
+func TestProjectMatching(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Projects = []ProjectConfig{
+		{Name: "Client A", Apps: []string{"Figma"}, Domains: []string{"github.com/clienta/*", "clienta.com"}, Issues: []string{"PROJ-*"}},
+		{Name: "OSS", Domains: []string{"github.com/alexinslc/rekap"}, Issues: []string{"alexinslc/rekap#*"}, Titles: []string{"rekap"}},
+		{Name: "API", Titles: []string{"* — clienta-api"}},
+	}
+	cfg.Tracking.WindowTitles = []string{"Code", "com.apple.Terminal"}
+
+	if got := cfg.ProjectForApp("figma", ""); got != "Client A" {
+		t.Errorf("ProjectForApp(figma) = %q, want Client A", got)
+	}
+	if got := cfg.ProjectForApp("Slack", "com.tinyspeck.slackmacgap"); got != "" {
+		t.Errorf("ProjectForApp(Slack) = %q, want none", got)
+	}
+
+	tests := []struct {
+		url     string
+		issueID string
+		want    string
+	}{
+		{"https://github.com/clienta/app/pull/3", "", "Client A"},
+		{"https://www.clienta.com/dashboard", "", "Client A"},
+		{"https://app.clienta.com/", "", "Client A"},
+		{"https://jira.example.com/browse/PROJ-12", "PROJ-12", "Client A"},
+		{"https://github.com/alexinslc/rekap", "", "OSS"},
+		{"https://github.com/alexinslc/rekap/issues/4", "alexinslc/rekap#4", "OSS"},
+		{"https://github.com/alexinslc/rekap-extras", "", ""},
+		{"https://github.com/other/repo", "", ""},
+	}
+	for _, tt := range tests {
+		if got := cfg.ProjectForURL(tt.url, tt.issueID); got != tt.want {
+			t.Errorf("ProjectForURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	titles := map[string]string{
+		"main.go — Rekap":               "OSS",
+		"handler.go — clienta-api":      "API",
+		"README.md — clienta-api-tools": "",
+		"":                              "",
+	}
+	for title, want := range titles {
+		if got := cfg.ProjectForTitle(title); got != want {
+			t.Errorf("ProjectForTitle(%q) = %q, want %q", title, got, want)
+		}
+	}
+
+	if !cfg.SamplesWindowTitle("code", "com.microsoft.VSCode") || !cfg.SamplesWindowTitle("Terminal", "com.apple.Terminal") {
+		t.Error("SamplesWindowTitle should match allowlisted names and bundle IDs")
+	}
+	if cfg.SamplesWindowTitle("Slack", "com.tinyspeck.slackmacgap") {
+		t.Error("SamplesWindowTitle(Slack) = true, want false")
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s, pattern string
+		want       bool
+	}{
+		{"proj-12", "proj-*", true},
+		{"proj", "proj-*", false},
+		{"github.com/org/a/b", "github.com/org/*", true},
+		{"github.com/org/a/b", "github.com/*/a/*", true},
+		{"abc", "a*c", true},
+		{"ac", "a*bc", false},
+		{"exact", "exact", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.s, tt.pattern); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.s, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestValidateStrictProjects(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Projects = []ProjectConfig{
+		{Name: "A", Apps: []string{"Figma"}},
+		{Name: "A", Issues: []string{"X-*"}},
+		{Name: ""},
+		{Name: "Empty"},
+	}
+	if errs := ValidateStrict(cfg); len(errs) != 3 {
+		t.Errorf("Expected 3 project validation errors, got %v", errs)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	t.Parallel()
+	lat, lon, bad := 47.61, -122.33, 200.0
+
+	cfg := Default()
+	if _, _, ok := cfg.Coordinates(); ok {
+		t.Error("Coordinates() should be unset by default")
+	}
+	cfg.Location = LocationConfig{Latitude: &lat, Longitude: &lon}
+	if got, gotLon, ok := cfg.Coordinates(); !ok || got != lat || gotLon != lon {
+		t.Errorf("Coordinates() = %v, %v, %v; want %v, %v", got, gotLon, ok, lat, lon)
+	}
+	if errs := ValidateStrict(cfg); len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+
+	cfg.Location = LocationConfig{Latitude: &lat}
+	if errs := ValidateStrict(cfg); len(errs) != 1 {
+		t.Errorf("Expected 1 error for a missing longitude, got %v", errs)
+	}
+	cfg.Location = LocationConfig{Latitude: &lat, Longitude: &bad}
+	if _, _, ok := cfg.Coordinates(); ok {
+		t.Error("Coordinates() should reject an out-of-range longitude")
+	}
+	if errs := ValidateStrict(cfg); len(errs) != 1 {
+		t.Errorf("Expected 1 error for an out-of-range longitude, got %v", errs)
+	}
+}
+
+func TestSectionEnabled(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if !cfg.SectionEnabled("media") {
+		t.Error("every section should be enabled by default")
+	}
+
+	cfg.Sections = SectionsConfig{Only: []string{"browser", "Productivity", "media"}, Skip: []string{"media"}}
+	for section, want := range map[string]bool{"browser": true, "productivity": true, "media": false, "network": false} {
+		if got := cfg.SectionEnabled(section); got != want {
+			t.Errorf("SectionEnabled(%q) = %v, want %v", section, got, want)
+		}
+	}
+
+	cfg.Sections = SectionsConfig{Skip: []string{"notifications", "bogus"}}
+	if cfg.SectionEnabled("notifications") || !cfg.SectionEnabled("system") {
+		t.Error("skip should turn off only the sections it lists")
+	}
+	if errs := ValidateStrict(cfg); len(errs) != 1 {
+		t.Errorf("Expected 1 error for an unknown section, got %v", errs)
+	}
+}
+
+func TestSectionDisplay(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	keys := []string{"system", "productivity", "media", "wellness", "Deploys", "notes"}
+	if got := cfg.OrderSections(keys); !slices.Equal(got, keys) {
+		t.Errorf("OrderSections() = %v, want the default order", got)
+	}
+
+	cfg.Custom = []CustomSectionConfig{{Name: "Deploys", Command: "deploys --json"}}
+	cfg.Display.SectionOrder = []string{"Wellness", "deploys"}
+	cfg.Display.HiddenSections = []string{"media"}
+	want := []string{"wellness", "Deploys", "system", "productivity", "media", "notes"}
+	if got := cfg.OrderSections(keys); !slices.Equal(got, want) {
+		t.Errorf("OrderSections() = %v, want %v", got, want)
+	}
+
+	now := time.Now()
+	if cfg.SectionShown("media", now) || !cfg.SectionEnabled("media") {
+		t.Error("a hidden section should still run but not be shown")
+	}
+	if !cfg.SectionShown("wellness", now) {
+		t.Error("hiding media shouldn't hide other sections")
+	}
+	if errs := ValidateStrict(cfg); len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+
+	cfg.Display.SectionOrder = []string{"bogus"}
+	cfg.Display.HiddenSections = []string{"now playing"}
+	if errs := ValidateStrict(cfg); len(errs) != 2 {
+		t.Errorf("Expected 2 errors for unknown sections, got %v", errs)
+	}
+}
+
+func TestValidateStrictCustomSections(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Custom = []CustomSectionConfig{
+		{Name: "Deploys", Command: "deploys --json"},
+		{Name: "deploys", File: "~/deploys.json"},
+		{Name: "Media", File: "~/media.json"},
+		{Name: "Both", Command: "x", File: "y"},
+		{Name: ""},
+	}
+	cfg.Snooze = []SnoozeRule{{Sections: []string{"DEPLOYS"}}}
+	// duplicate name, built-in name, both sources, missing name
+	if errs := ValidateStrict(cfg); len(errs) != 4 {
+		t.Errorf("Expected 4 custom section validation errors, got %v", errs)
+	}
+}
+
 func TestAccessibilityDefaults(t *testing.T) {
 	t.Parallel()
 	cfg := Default()
@@ -230,6 +741,10 @@ func TestCategorizeDomain(t *testing.T) {
 		{"youtube.com", "distraction"},
 		{"tiktok.com", "distraction"},
 
+		// Learning domains
+		{"coursera.org", "learning"},
+		{"learning.oreilly.com", "learning"},
+
 		// Neutral/uncategorized domains
 		{"gmail.com", "neutral"},
 		{"example.com", "neutral"},
@@ -273,6 +788,66 @@ func TestCategorizeDomainCustomConfig(t *testing.T) {
 	}
 }
 
+func TestCategorizeURL(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Domains.Rules = []DomainRule{
+		{Match: "youtube.com/feed/subscriptions", Category: "distraction"},
+		{Match: "youtube.com", Category: "work", Hours: "09:00-17:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+		{Regex: `^reddit\.com/r/(golang|rust)\b`, Category: "work"},
+		{Match: "reddit.com", Category: "neutral", Hours: "19:00-24:00"},
+		{Regex: `^google\.com/search\?.*q=golang`, Category: "work"},
+	}
+	// 2026-10-16 is a Friday
+	at := func(day, hour int) time.Time { return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		url  string
+		at   time.Time
+		want string
+	}{
+		{"https://www.youtube.com/feed/subscriptions", at(16, 10), "distraction"},
+		{"https://www.youtube.com/watch?v=abc", at(16, 10), "work"},
+		{"https://www.youtube.com/watch?v=abc", at(16, 20), "distraction"},
+		{"https://www.youtube.com/watch?v=abc", at(17, 10), "distraction"},
+		{"https://reddit.com/r/golang/comments/1", at(16, 10), "work"},
+		{"https://reddit.com/r/golangnuts", at(16, 10), "distraction"},
+		{"https://reddit.com/r/pics", at(16, 20), "neutral"},
+		{"https://www.google.com/search?hl=en&q=golang+generics", at(16, 10), "work"},
+		{"https://github.com/org/repo", at(16, 10), "work"},
+		{"not a url", at(16, 10), "neutral"},
+	}
+	for _, tt := range tests {
+		if got := cfg.CategorizeURL(tt.url, tt.at); got != tt.want {
+			t.Errorf("CategorizeURL(%q, %s) = %q, want %q", tt.url, tt.at.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+
+	// Without a visit time only untimed rules apply
+	if got := cfg.CategorizeDomain("youtube.com"); got != "distraction" {
+		t.Errorf("CategorizeDomain(youtube.com) = %q, want distraction", got)
+	}
+	if got := cfg.CategorizeDomain("reddit.com"); got != "distraction" {
+		t.Errorf("CategorizeDomain(reddit.com) = %q, want distraction", got)
+	}
+}
+
+func TestValidateStrictDomainRules(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Domains.Rules = []DomainRule{
+		{Match: "reddit.com", Category: "neutral", Hours: "19:00-24:00"},
+		{Category: "work"},
+		{Match: "a.com", Regex: "a", Category: "work"},
+		{Regex: "(", Category: "work"},
+		{Match: "b.com", Category: "fun", Hours: "7pm", Days: []string{"someday"}},
+	}
+	// missing pattern, both patterns, bad regex, bad category, bad hours, bad day
+	if errs := ValidateStrict(cfg); len(errs) != 6 {
+		t.Errorf("Expected 6 domain rule errors, got %v", errs)
+	}
+}
+
 func TestMatchDomainPattern(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -309,3 +884,180 @@ func TestMatchDomainPattern(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateStrictSpaceNames(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Tracking.SpaceNames = map[int]string{1: "Mail", 2: "Client A", 0: "Nowhere"}
+	if errs := ValidateStrict(cfg); len(errs) != 1 {
+		t.Errorf("Expected 1 space_names validation error, got %v", errs)
+	}
+}
+
+func TestValidateStrictWebhooks(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Integrations.Webhooks = map[string]WebhookConfig{
+		"homeassistant": {URL: "http://homeassistant.local:8123/api/webhook/rekap"},
+		"n8n":           {URL: "https://n8n.example.com/webhook/rekap", Method: "put"},
+		"broken":        {URL: "ftp://example.com", Method: "DELETE"},
+	}
+	// Plain-text URLs are TestValidateStrictSecrets's business
+	errs := slices.DeleteFunc(ValidateStrict(cfg), func(e string) bool { return strings.Contains(e, "plain text") })
+	if len(errs) != 2 {
+		t.Errorf("Expected 2 webhook validation errors, got %v", errs)
+	}
+}
+
+func TestValidateStrictSecrets(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Integrations.Slack = SlackConfig{WebhookURL: "keychain:rekap-slack-webhook", Token: "$SLACK_TOKEN"}
+	cfg.Integrations.Webhooks = map[string]WebhookConfig{"ha": {
+		URL:     "keychain:ha-webhook",
+		Headers: map[string]string{"Authorization": "Bearer $HA_TOKEN", "Content-Type": "application/json"},
+	}}
+	if errs := ValidateStrict(cfg); len(errs) != 0 {
+		t.Errorf("Expected secret references to be valid, got %v", errs)
+	}
+
+	cfg.Integrations.Webhooks["ha"] = WebhookConfig{
+		URL:     "https://ha.example.com/api/webhook/abc123",
+		Headers: map[string]string{"Authorization": "Bearer abc123", "Content-Type": "application/json"},
+	}
+	errs := ValidateStrict(cfg)
+	want := []string{"integrations.webhooks.ha.url: secret is in plain text", "integrations.webhooks.ha.headers.Authorization: secret is in plain text"}
+	if len(errs) != 2 || !strings.HasPrefix(errs[0], want[0]) || !strings.HasPrefix(errs[1], want[1]) {
+		t.Errorf("Expected plain text errors for the webhook URL and Authorization header, got %v", errs)
+	}
+	cfg.Integrations.Webhooks = nil
+
+	cfg.Integrations.Slack = SlackConfig{WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX", Token: "keychain:bad name"}
+	errs = ValidateStrict(cfg)
+	if len(errs) != 2 || !strings.Contains(errs[0], "plain text") || !strings.Contains(errs[1], "invalid Keychain item name") {
+		t.Errorf("Expected a plain text and an invalid name error, got %v", errs)
+	}
+}
+
+func TestValidateStrictNarrate(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	if errs := ValidateStrict(cfg); slices.ContainsFunc(errs, func(e string) bool { return strings.HasPrefix(e, "narrate.") }) {
+		t.Errorf("Default config has narrate errors: %v", errs)
+	}
+
+	cfg.Narrate = NarrateConfig{Enabled: true, Prompt: "{{.Summary", TimeoutSeconds: -1}
+	var got []string
+	for _, e := range ValidateStrict(cfg) {
+		if strings.HasPrefix(e, "narrate.") {
+			got = append(got, e)
+		}
+	}
+	if len(got) != 3 {
+		t.Errorf("Expected 3 narrate validation errors, got %v", got)
+	}
+}
+
+func TestLearningMatching(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Learning.Domains = append(cfg.Learning.Domains, "docs.*", "youtube.com/watch?*list=PLcs4Gb*", "github.com/golang/go/wiki")
+	cfg.Learning.Apps = []string{"Anki", "com.apple.iBooksX"}
+
+	urls := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.coursera.org/learn/machine-learning", true},
+		{"https://learning.oreilly.com/library/view/x/123/", true},
+		{"https://docs.python.org/3/", true},
+		{"https://www.youtube.com/watch?v=abc&list=PLcs4GbXYZ&index=3", true},
+		{"https://www.youtube.com/watch?v=abc", false},
+		{"https://github.com/golang/go/wiki/Modules", true},
+		{"https://github.com/golang/go/issues", false},
+		{"https://example.com", false},
+	}
+	for _, tt := range urls {
+		if got := cfg.IsLearningURL(tt.url); got != tt.want {
+			t.Errorf("IsLearningURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+
+	// Domain-only patterns also categorize domains; path patterns don't
+	if got := cfg.CategorizeDomain("docs.python.org"); got != "learning" {
+		t.Errorf("CategorizeDomain(docs.python.org) = %q, want learning", got)
+	}
+	if got := cfg.CategorizeDomain("youtube.com"); got != "distraction" {
+		t.Errorf("CategorizeDomain(youtube.com) = %q, want distraction", got)
+	}
+
+	if !cfg.IsLearningApp("anki", "") || !cfg.IsLearningApp("Books", "com.apple.iBooksX") || cfg.IsLearningApp("Safari", "com.apple.Safari") {
+		t.Error("IsLearningApp should match names and bundle IDs case-insensitively")
+	}
+}
+
+func TestMatchApp(t *testing.T) {
+	patterns := []string{"Music", "com.apple.*", " *Helper ", ""}
+	tests := []struct {
+		name, bundleID string
+		want           bool
+	}{
+		{"music", "", true},
+		{"Safari", "com.apple.Safari", true},
+		{"Safari", "", false},
+		{"Google Chrome Helper", "com.google.Chrome.helper", true},
+		{"Slack", "com.tinyspeck.slackmacgap", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := MatchApp(patterns, tt.name, tt.bundleID); got != tt.want {
+			t.Errorf("MatchApp(%q, %q) = %v, want %v", tt.name, tt.bundleID, got, tt.want)
+		}
+	}
+}
+
+func TestPrivacyMatching(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Privacy.RedactDomains = []string{"*.chase.com", "mychart.org", "reddit.com/r/health", "youtube.com/watch?*v=secret*"}
+	cfg.Privacy.IssueDenylist = []string{"SEC-*", "github.com/acme/security"}
+
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://secure.chase.com/login", true},
+		{"https://www.mychart.org/visits", true},
+		{"https://reddit.com/r/health/comments/1", true},
+		{"https://reddit.com/r/golang", false},
+		{"https://youtube.com/watch?v=secret1", true},
+		{"https://youtube.com/watch?v=public", false},
+		{"https://github.com/", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.RedactsURL(tt.url); got != tt.want {
+			t.Errorf("RedactsURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+
+	// Path patterns need the whole URL, so bare domains only match domain patterns
+	if !cfg.RedactsDomain("www.MyChart.org") || !cfg.RedactsDomain("secure.chase.com") || cfg.RedactsDomain("reddit.com") {
+		t.Error("RedactsDomain should match domain patterns only")
+	}
+
+	if !cfg.DeniesIssue("sec-42", "https://acme.atlassian.net/browse/SEC-42") {
+		t.Error("DeniesIssue should match issue IDs case-insensitively")
+	}
+	if !cfg.DeniesIssue("acme/security#7", "https://github.com/acme/security/issues/7") {
+		t.Error("DeniesIssue should match issue URLs")
+	}
+	if cfg.DeniesIssue("PROJ-1", "https://acme.atlassian.net/browse/PROJ-1") {
+		t.Error("DeniesIssue matched an issue not on the denylist")
+	}
+
+	cfg.Privacy.RedactDomains = append(cfg.Privacy.RedactDomains, " ")
+	if errs := ValidateStrict(cfg); len(errs) != 1 || errs[0] != "privacy.redact_domains: empty pattern" {
+		t.Errorf("Expected an empty pattern error, got %v", errs)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Change is one setting that differs between two configs
+type Change struct {
+	Key string // Dotted key path, e.g. display.time_format
+	Old string // "" when the key isn't set in the old config
+	New string // "" when the key isn't set in the new config
+}
+
+// Diff lists the settings that differ between old and cfg, by dotted key
+// path in sorted order. Blocks are compared key by key; lists and values
+// are compared whole and shown as JSON when they aren't plain text.
+func Diff(old, cfg *Config) ([]Change, error) {
+	a, err := toTree(old)
+	if err != nil {
+		return nil, err
+	}
+	b, err := toTree(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var changes []Change
+	diffTree(a, b, "", &changes)
+	return changes, nil
+}
+
+// toTree converts cfg to nested maps keyed by its YAML names
+func toTree(cfg *Config) (map[string]any, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func diffTree(a, b map[string]any, prefix string, changes *[]Change) {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		va, vb := a[key], b[key]
+		ma, aIsMap := va.(map[string]any)
+		mb, bIsMap := vb.(map[string]any)
+		if aIsMap && bIsMap {
+			diffTree(ma, mb, prefix+key+".", changes)
+			continue
+		}
+		if isEmpty(va) && isEmpty(vb) || reflect.DeepEqual(va, vb) {
+			continue
+		}
+		*changes = append(*changes, Change{Key: prefix + key, Old: formatValue(va), New: formatValue(vb)})
+	}
+}
+
+// isEmpty reports whether v is unset: nil, or an empty list or block
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// formatValue shows a value as plain text, or as JSON for lists and blocks
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any, map[string]any:
+		if isEmpty(v) {
+			return ""
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
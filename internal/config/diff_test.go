@@ -0,0 +1,29 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	cfg.Display.TimeFormat = "24h"
+	cfg.Tracking.ExcludeApps = []string{"Finder", "Dock"}
+
+	changes, err := Diff(Default(), cfg)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	want := []Change{
+		{Key: "display.time_format", Old: "", New: "24h"},
+		{Key: "tracking.exclude_apps", Old: "", New: `["Finder","Dock"]`},
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("Diff = %+v, want %+v", changes, want)
+	}
+
+	if changes, err := Diff(cfg, cfg); err != nil || len(changes) != 0 {
+		t.Errorf("Diff(cfg, cfg) = %+v, %v; want no changes", changes, err)
+	}
+}
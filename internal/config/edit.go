@@ -0,0 +1,140 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alexinslc/rekap/internal/theme"
+)
+
+// EditFile applies edit to the YAML document in the config file at path,
+// creating the file if needed, and writes it back. Editing the node tree
+// keeps the user's comments and key order.
+func EditFile(path string, edit func(root *yaml.Node) error) error {
+	doc := &yaml.Node{Kind: yaml.DocumentNode}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a YAML mapping", path)
+	}
+	if err := edit(root); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// mappingValue returns the value node of key in the mapping m, adding an
+// empty node of kind when create is set and the key is missing
+func mappingValue(m *yaml.Node, key string, kind yaml.Kind, create bool) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			value := m.Content[i+1]
+			// An empty "sections:" parses as null; make it the kind asked for
+			if create && value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+				*value = yaml.Node{Kind: kind}
+			}
+			return value
+		}
+	}
+	if !create {
+		return nil
+	}
+	value := &yaml.Node{Kind: kind}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// SetSectionEnabled turns section on or off in the sections block of the
+// config root. Turning it off adds it to skip and drops it from only;
+// turning it on drops it from skip and, when only is in use, adds it there.
+func SetSectionEnabled(root *yaml.Node, section string, enabled bool) error {
+	sections := mappingValue(root, "sections", yaml.MappingNode, true)
+	if sections.Kind != yaml.MappingNode {
+		return fmt.Errorf("sections must be a mapping")
+	}
+	only := mappingValue(sections, "only", yaml.SequenceNode, false)
+	skip := mappingValue(sections, "skip", yaml.SequenceNode, false)
+	for _, list := range []*yaml.Node{only, skip} {
+		if list != nil && list.Kind != yaml.SequenceNode && list.Tag != "!!null" {
+			return fmt.Errorf("sections.only and sections.skip must be lists")
+		}
+	}
+
+	if enabled {
+		removeFromSequence(skip, section)
+		if only != nil && len(only.Content) > 0 && !sequenceHas(only, section) {
+			appendToSequence(only, section)
+		}
+		return nil
+	}
+	removeFromSequence(only, section)
+	if !sequenceHas(skip, section) {
+		appendToSequence(mappingValue(sections, "skip", yaml.SequenceNode, true), section)
+	}
+	return nil
+}
+
+// SetColors replaces the colors block of the config root with colors, as
+// when a theme is picked
+func SetColors(root *yaml.Node, colors theme.ThemeColors) error {
+	node := mappingValue(root, "colors", yaml.MappingNode, true)
+	if err := node.Encode(colors); err != nil {
+		return err
+	}
+	return nil
+}
+
+func sequenceHas(seq *yaml.Node, value string) bool {
+	if seq == nil {
+		return false
+	}
+	for _, item := range seq.Content {
+		if strings.EqualFold(item.Value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendToSequence(seq *yaml.Node, value string) {
+	seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: value})
+}
+
+func removeFromSequence(seq *yaml.Node, value string) {
+	if seq == nil {
+		return
+	}
+	kept := seq.Content[:0]
+	for _, item := range seq.Content {
+		if !strings.EqualFold(item.Value, value) {
+			kept = append(kept, item)
+		}
+	}
+	seq.Content = kept
+}
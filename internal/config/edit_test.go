@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alexinslc/rekap/internal/theme"
+)
+
+func TestSetSectionEnabled(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "# My settings\ndisplay:\n  time_format: 24h # Always\nsections:\n  only: [browser, productivity]\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	edit := func(section string, enabled bool) {
+		t.Helper()
+		err := EditFile(path, func(root *yaml.Node) error { return SetSectionEnabled(root, section, enabled) })
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	edit("productivity", false)
+	edit("media", true)
+	edit("notifications", false)
+	edit("notifications", false) // Already off
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# My settings", "# Always"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("comment %q was lost:\n%s", want, data)
+		}
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cfg.Sections.Only, ","); got != "browser,media" {
+		t.Errorf("only = %s, want browser,media", got)
+	}
+	if got := strings.Join(cfg.Sections.Skip, ","); got != "productivity,notifications" {
+		t.Errorf("skip = %s, want productivity,notifications", got)
+	}
+	if cfg.Display.TimeFormat != "24h" {
+		t.Errorf("other settings changed: time_format = %q", cfg.Display.TimeFormat)
+	}
+}
+
+func TestEditFileCreates(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "rekap", "config.yaml")
+	err := EditFile(path, func(root *yaml.Node) error { return SetSectionEnabled(root, "media", false) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "sections:\n  skip:\n    - media\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetColors(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "colors:\n  primary: \"1\"\n  text: \"2\"\ndisplay:\n  time_format: 24h\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nord, _ := theme.GetBuiltIn("nord")
+	if err := EditFile(path, func(root *yaml.Node) error { return SetColors(root, nord.Colors) }); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Colors.Primary != nord.Colors.Primary || cfg.Colors.Text != nord.Colors.Text {
+		t.Errorf("colors = %+v, want nord's", cfg.Colors)
+	}
+	if cfg.Display.TimeFormat != "24h" {
+		t.Errorf("other settings changed: time_format = %q", cfg.Display.TimeFormat)
+	}
+}
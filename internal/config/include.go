@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DecodeFile layers the config file at path over cfg, after the files it
+// includes. Each file's keys replace what came before, lists included, and
+// the rest carry over, so a file wins over its includes and later includes
+// win over earlier ones.
+func DecodeFile(cfg *Config, path string) error {
+	return decodeFile(cfg, path, nil)
+}
+
+// decodeFile is DecodeFile with chain, the files that included path, to
+// catch includes that loop back
+func decodeFile(cfg *Config, path string, chain []string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(chain, abs) {
+		return fmt.Errorf("include cycle: %s", strings.Join(append(chain, abs), " -> "))
+	}
+	chain = append(chain, abs)
+
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return err
+	}
+	root, err := parseRoot(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := Migrate(root); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	var head struct {
+		Include []string `yaml:"include"`
+	}
+	if err := root.Decode(&head); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, include := range head.Include {
+		included := includePath(abs, include)
+		if _, err := os.Stat(included); err != nil {
+			return fmt.Errorf("%s: include %q: %w", path, include, err)
+		}
+		if err := decodeFile(cfg, included, chain); err != nil {
+			return err
+		}
+	}
+
+	if err := root.Decode(cfg); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// includePath resolves an include entry: "~/" is the home directory, and
+// relative paths are relative to the including file's directory
+func includePath(from, include string) string {
+	if rest, ok := strings.CutPrefix(include, "~/"); ok {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(homeDir, rest)
+		}
+	}
+	if filepath.IsAbs(include) {
+		return include
+	}
+	return filepath.Join(filepath.Dir(from), include)
+}
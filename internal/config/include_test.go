@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeFileIncludes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	write := func(path, content string) string {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	team := write(filepath.Join(home, "dotfiles", "rekap", "team.yaml"),
+		"include: [domains.yaml]\nlearning:\n  weekly_goal_hours: 5\ntracking:\n  exclude_apps: [Finder]\n")
+	write(filepath.Join(home, "dotfiles", "rekap", "domains.yaml"),
+		"domains:\n  work: [corp.example.com]\ndisplay:\n  time_format: 24h\n")
+	write(filepath.Join(home, "extra.yaml"), "learning:\n  weekly_goal_hours: 8\n")
+	path := write(filepath.Join(home, "config.yaml"),
+		"include:\n  - ~/dotfiles/rekap/team.yaml\n  - extra.yaml\ntracking:\n  exclude_apps: [Music]\n")
+
+	cfg := Default()
+	if err := DecodeFile(cfg, path); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cfg.Domains.Work, ","); got != "corp.example.com" {
+		t.Errorf("domains.work = %s, want the nested include's", got)
+	}
+	if cfg.Display.TimeFormat != "24h" {
+		t.Errorf("time_format = %q, want it to carry over from the include", cfg.Display.TimeFormat)
+	}
+	if cfg.Learning.WeeklyGoalHours != 8 {
+		t.Errorf("weekly_goal_hours = %g, want the later include to win", cfg.Learning.WeeklyGoalHours)
+	}
+	if got := strings.Join(cfg.Tracking.ExcludeApps, ","); got != "Music" {
+		t.Errorf("exclude_apps = %s, want the including file's list to replace the include's", got)
+	}
+
+	// A file that includes itself, by way of another
+	write(team, "include: [../../config.yaml]\n")
+	err := DecodeFile(Default(), path)
+	if err == nil || !strings.Contains(err.Error(), "include cycle: ") || !strings.HasSuffix(err.Error(), filepath.Join(home, "config.yaml")) {
+		t.Errorf("DecodeFile() with a cycle = %v, want an include cycle ending in config.yaml", err)
+	}
+
+	write(path, "include: [missing.yaml]\n")
+	if err := DecodeFile(Default(), path); err == nil || !strings.Contains(err.Error(), `include "missing.yaml"`) {
+		t.Errorf("DecodeFile() with a missing include = %v, want it named", err)
+	}
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlName is the key a struct field is written under, or "" for none
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// keyField finds the field a dotted key path like display.time_format
+// names, returning its value within v
+func keyField(v reflect.Value, path string) (reflect.Value, error) {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v = reflect.New(v.Type().Elem())
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%s has no key %q", strings.Join(parts[:i], "."), part)
+		}
+		found := false
+		for j := 0; j < v.NumField(); j++ {
+			if yamlName(v.Type().Field(j)) == part {
+				v = v.Field(j)
+				found = true
+				break
+			}
+		}
+		if !found {
+			if i == 0 {
+				return reflect.Value{}, fmt.Errorf("unknown key %q", path)
+			}
+			return reflect.Value{}, fmt.Errorf("unknown key %q: %s has no %q", path, strings.Join(parts[:i], "."), part)
+		}
+	}
+	return v, nil
+}
+
+// settable reports whether "rekap config set" can write a value of type t:
+// scalars and lists of strings. Mappings and lists of mappings are edited
+// in the file.
+func settable(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// KeyPaths lists every key "rekap config set" can write, sorted
+func KeyPaths() []string {
+	var paths []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := yamlName(f)
+			if name == "" || !f.IsExported() {
+				continue
+			}
+			switch {
+			case settable(f.Type):
+				paths = append(paths, prefix+name)
+			case f.Type.Kind() == reflect.Struct:
+				walk(f.Type, prefix+name+".")
+			}
+		}
+	}
+	walk(reflect.TypeFor[Config](), "")
+	slices.Sort(paths)
+	return paths
+}
+
+// GetValue returns the value at path in cfg: scalars as plain text, and
+// lists and whole blocks like "colors" as YAML
+func GetValue(cfg *Config, path string) (string, error) {
+	v, err := keyField(reflect.ValueOf(cfg), path)
+	if err != nil {
+		return "", err
+	}
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+	out, err := yaml.Marshal(v.Interface())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// SetValue sets path in the config root to value, parsed as the key's
+// type: text as is, numbers and booleans as YAML, and lists as
+// comma-separated items or a YAML list like [a, b]. Comments on the old
+// value are kept. Values the key can't hold, or that fail validation, are
+// an error and leave root as it was.
+func SetValue(root *yaml.Node, path, value string) error {
+	field, err := keyField(reflect.ValueOf(Default()), path)
+	if err != nil {
+		return err
+	}
+	t := field.Type()
+	if path == "version" {
+		return fmt.Errorf("version is set by 'rekap config migrate'")
+	}
+	if !settable(t) {
+		return fmt.Errorf("%s is a block of settings, not a value; set one of its keys or edit the config file", path)
+	}
+
+	parsed := reflect.New(t).Elem()
+	switch {
+	case t.Kind() == reflect.String:
+		parsed.SetString(value)
+	case t.Kind() == reflect.Slice && !strings.HasPrefix(strings.TrimSpace(value), "["):
+		parsed.Set(reflect.MakeSlice(t, 0, 0))
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				parsed.Set(reflect.Append(parsed, reflect.ValueOf(item).Convert(t.Elem())))
+			}
+		}
+	default:
+		if err := yaml.Unmarshal([]byte(value), parsed.Addr().Interface()); err != nil || strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%s takes %s, not %q", path, typeName(t), value)
+		}
+	}
+	var node yaml.Node
+	if err := node.Encode(parsed.Interface()); err != nil {
+		return err
+	}
+
+	// Check the whole config with the new value before touching root
+	trial := copyNode(root)
+	if err := setNode(trial, path, copyNode(&node)); err != nil {
+		return err
+	}
+	check := Default()
+	if err := trial.Decode(check); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, problem := range ValidateStrict(check) {
+		if strings.HasPrefix(problem, path+":") {
+			return fmt.Errorf("%s", problem)
+		}
+	}
+	return setNode(root, path, &node)
+}
+
+// copyNode returns a deep copy of n
+func copyNode(n *yaml.Node) *yaml.Node {
+	c := *n
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = copyNode(child)
+	}
+	return &c
+}
+
+// setNode puts value at the dotted path in the mapping root, creating
+// mappings on the way and keeping any comments on the value it replaces
+func setNode(root *yaml.Node, path string, value *yaml.Node) error {
+	parts := strings.Split(path, ".")
+	m := root
+	for i, part := range parts[:len(parts)-1] {
+		m = mappingValue(m, part, yaml.MappingNode, true)
+		if m.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s must be a mapping", strings.Join(parts[:i+1], "."))
+		}
+	}
+	old := mappingValue(m, parts[len(parts)-1], value.Kind, true)
+	value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	*old = *value
+	return nil
+}
+
+// typeName describes the values a key of type t takes
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int:
+		return "a whole number"
+	case reflect.Float64:
+		return "a number"
+	case reflect.Slice:
+		return "a list"
+	}
+	return "text"
+}
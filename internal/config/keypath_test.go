@@ -0,0 +1,100 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSetValue(t *testing.T) {
+	t.Parallel()
+	root, err := parseRoot([]byte("# Mine\ndisplay:\n  time_format: 12h # Clock\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kv := range [][2]string{
+		{"display.time_format", "24h"},
+		{"colors.primary", "#ff79c6"},
+		{"tracking.exclude_apps", "Finder, Dock"},
+		{"display.show_media", "false"},
+		{"history.retention_days", "45"},
+	} {
+		if err := SetValue(root, kv[0], kv[1]); err != nil {
+			t.Fatalf("SetValue(%s, %s): %v", kv[0], kv[1], err)
+		}
+	}
+
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Mine", "time_format: 24h # Clock", "primary: '#ff79c6'"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	cfg := Default()
+	if err := root.Decode(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(cfg.Tracking.ExcludeApps, ","); got != "Finder,Dock" {
+		t.Errorf("exclude_apps = %s, want Finder,Dock", got)
+	}
+	if cfg.ShouldShowMedia() {
+		t.Error("show_media still on")
+	}
+	if cfg.History.RetentionDays != 45 {
+		t.Errorf("retention_days = %d, want 45", cfg.History.RetentionDays)
+	}
+}
+
+func TestSetValueRejects(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path, value, wantErr string
+	}{
+		{"display.time_format", "25h", "must be"},
+		{"history.enabled", "maybe", "true or false"},
+		{"history.retention_days", "", "whole number"},
+		{"colors", "red", "block of settings"},
+		{"display.nope", "1", "unknown key"},
+		{"version", "3", "migrate"},
+	}
+	for _, tt := range tests {
+		root := &yaml.Node{Kind: yaml.MappingNode}
+		err := SetValue(root, tt.path, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("SetValue(%s, %q) error = %v, want %q", tt.path, tt.value, err, tt.wantErr)
+		}
+		if len(root.Content) != 0 {
+			t.Errorf("SetValue(%s, %q) changed the config after failing", tt.path, tt.value)
+		}
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	t.Parallel()
+	cfg := Default()
+	for path, want := range map[string]string{
+		"colors.primary":     "13",
+		"display.show_media": "true",
+		"history.enabled":    "true",
+	} {
+		got, err := GetValue(cfg, path)
+		if err != nil || got != want {
+			t.Errorf("GetValue(%s) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	if got, err := GetValue(cfg, "colors"); err != nil || !strings.Contains(got, "primary: \"13\"") {
+		t.Errorf("GetValue(colors) = %q, %v; want the block as YAML", got, err)
+	}
+	if _, err := GetValue(cfg, "colors.nope"); err == nil {
+		t.Error("GetValue(colors.nope) succeeded")
+	}
+	if !slices.Contains(KeyPaths(), "display.time_format") || slices.Contains(KeyPaths(), "colors") {
+		t.Error("KeyPaths() should list values, not blocks")
+	}
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the config version this build writes. Files without a
+// version key are version 1.
+const SchemaVersion = 2
+
+// migration upgrades a config document from one version to the next.
+// Migrations edit the YAML node tree, so keys they don't touch keep their
+// comments and order.
+type migration struct {
+	from    int
+	summary string
+	apply   func(root *yaml.Node) error
+}
+
+// migrations in order; each takes a version-from document to from+1
+var migrations = []migration{
+	{1, "moved display.day_start_hour to schedule.day_starts_at", migrateDayStartHour},
+}
+
+// fileVersion is the version key of the config root, 1 when it's missing
+func fileVersion(root *yaml.Node) (int, error) {
+	node := mappingValue(root, "version", yaml.ScalarNode, false)
+	if node == nil {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(node.Value)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid config version %q", node.Value)
+	}
+	return version, nil
+}
+
+// PendingMigrations lists what Migrate would change in the config root
+func PendingMigrations(root *yaml.Node) ([]string, error) {
+	version, err := fileVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	var pending []string
+	for _, m := range migrations {
+		if m.from >= version {
+			pending = append(pending, m.summary)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate upgrades the config root to SchemaVersion and returns what it
+// changed. A config from a newer rekap is an error, since its keys may
+// mean something this build doesn't know.
+func Migrate(root *yaml.Node) ([]string, error) {
+	version, err := fileVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > SchemaVersion {
+		return nil, fmt.Errorf("config version %d is newer than this rekap supports (%d); upgrade rekap", version, SchemaVersion)
+	}
+	if version == SchemaVersion {
+		return nil, nil
+	}
+
+	var applied []string
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		if err := m.apply(root); err != nil {
+			return applied, fmt.Errorf("migrating from version %d: %w", m.from, err)
+		}
+		applied = append(applied, m.summary)
+	}
+	setVersion(root, SchemaVersion)
+	return applied, nil
+}
+
+// setVersion sets the version key, putting it first when it's new
+func setVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if node := mappingValue(root, "version", yaml.ScalarNode, false); node != nil {
+		*node = yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: "version"}
+	if len(root.Content) > 0 {
+		// The file's opening comment stays at the top
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+	root.Content = append([]*yaml.Node{key, {Kind: yaml.ScalarNode, Value: value}}, root.Content...)
+}
+
+// MigrateFile upgrades the config file at path in place, first copying it
+// to path.bak, and returns what it changed. An up-to-date file is left
+// alone, without a backup.
+func MigrateFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseRoot(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	version, err := fileVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version == SchemaVersion {
+		return nil, nil
+	}
+	if version < SchemaVersion {
+		if err := os.WriteFile(path+".bak", data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+
+	var applied []string
+	err = EditFile(path, func(root *yaml.Node) error {
+		applied, err = Migrate(root)
+		return err
+	})
+	return applied, err
+}
+
+// parseRoot parses a config document into its root mapping; an empty
+// document is an empty mapping
+func parseRoot(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config is not a YAML mapping")
+	}
+	return root, nil
+}
+
+// deleteKey removes key from the mapping m, handing the comment above it
+// to the next key
+func deleteKey(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			if comment := m.Content[i].HeadComment; comment != "" && i+2 < len(m.Content) {
+				next := m.Content[i+2]
+				next.HeadComment = strings.TrimSpace(comment + "\n" + next.HeadComment)
+			}
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// migrateDayStartHour turns display.day_start_hour: 4 into
+// schedule.day_starts_at: "04:00", unless day_starts_at is already set,
+// in which case it won anyway
+func migrateDayStartHour(root *yaml.Node) error {
+	display := mappingValue(root, "display", yaml.MappingNode, false)
+	if display == nil || display.Kind != yaml.MappingNode {
+		return nil
+	}
+	hourNode := mappingValue(display, "day_start_hour", yaml.ScalarNode, false)
+	if hourNode == nil {
+		return nil
+	}
+	// Out-of-range hours always meant midnight
+	if hour, err := strconv.Atoi(hourNode.Value); err == nil && hour > 0 && hour <= 23 {
+		schedule := mappingValue(root, "schedule", yaml.MappingNode, true)
+		if schedule.Kind != yaml.MappingNode {
+			return fmt.Errorf("schedule must be a mapping")
+		}
+		if mappingValue(schedule, "day_starts_at", yaml.ScalarNode, false) == nil {
+			value := mappingValue(schedule, "day_starts_at", yaml.ScalarNode, true)
+			*value = yaml.Node{Kind: yaml.ScalarNode, Style: yaml.DoubleQuotedStyle, Value: fmt.Sprintf("%02d:00", hour)}
+		}
+	}
+
+	// Removed after schedule is added, so comments above display move to it
+	deleteKey(display, "day_start_hour")
+	if len(display.Content) == 0 {
+		deleteKey(root, "display")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "# My settings\ndisplay:\n  day_start_hour: 4 # Night owl\n# Privacy\ntracking:\n  exclude_apps: [Finder] # Always\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := MigrateFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 {
+		t.Errorf("applied = %v, want the day_start_hour migration", applied)
+	}
+
+	backup, err := os.ReadFile(path + ".bak")
+	if err != nil || string(backup) != original {
+		t.Errorf("backup = %q, %v; want the original", backup, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# My settings", "# Privacy", "# Always", "version: 2", `day_starts_at: "04:00"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("migrated config is missing %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "day_start_hour") || strings.Contains(string(data), "display:") {
+		t.Errorf("old key was kept:\n%s", data)
+	}
+
+	// Already current: nothing to do
+	if applied, err := MigrateFile(path); err != nil || len(applied) != 0 {
+		t.Errorf("second MigrateFile = %v, %v; want nothing applied", applied, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		yaml    string
+		want    string // schedule.day_starts_at after migrating
+		wantErr bool
+	}{
+		{"hour", "display:\n  day_start_hour: 5\n", "05:00", false},
+		{"day_starts_at wins", "display:\n  day_start_hour: 5\nschedule:\n  day_starts_at: \"03:30\"\n", "03:30", false},
+		{"out of range", "display:\n  day_start_hour: 30\n", "", false},
+		{"current", "version: 2\ndisplay:\n  day_start_hour: 5\n", "", false},
+		{"newer", "version: 9\n", "", true},
+		{"bad version", "version: two\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, err := parseRoot([]byte(tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = Migrate(root)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var cfg Config
+			if err := root.Decode(&cfg); err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Version != SchemaVersion {
+				t.Errorf("version = %d, want %d", cfg.Version, SchemaVersion)
+			}
+			if cfg.Schedule.DayStartsAt != tt.want {
+				t.Errorf("day_starts_at = %q, want %q", cfg.Schedule.DayStartsAt, tt.want)
+			}
+		})
+	}
+}
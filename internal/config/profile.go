@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/xdg"
+)
+
+// ProfileEnv names the profile to use when --profile isn't given
+const ProfileEnv = "REKAP_PROFILE"
+
+// profile is the name set with SetProfile; empty defers to ProfileEnv
+var profile string
+
+// SetProfile picks the profile Load layers over the config, as --profile
+// does. An empty name leaves it to $REKAP_PROFILE.
+func SetProfile(name string) {
+	profile = name
+}
+
+// ActiveProfile returns the profile in use, or "" for none
+func ActiveProfile() string {
+	if profile != "" {
+		return profile
+	}
+	return strings.TrimSpace(os.Getenv(ProfileEnv))
+}
+
+// profilesDir is where profile files live, in the config directory even
+// when --config points elsewhere
+func profilesDir() (string, error) {
+	dir, err := xdg.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profiles"), nil
+}
+
+// ProfilePath returns the file of the named profile
+func ProfilePath(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	dir, err := profilesDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".yaml"), nil
+}
+
+// ListProfiles returns the names of the profiles in the profiles
+// directory, sorted
+func ListProfiles() []string {
+	dir, err := profilesDir()
+	if err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".yaml"); ok && !e.IsDir() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// EditPath returns the file that edits like "rekap config set" should
+// change: the active profile's, or the config file without one
+func EditPath() (string, error) {
+	if name := ActiveProfile(); name != "" {
+		return ProfilePath(name)
+	}
+	return GetConfigPath()
+}
+
+// applyProfile layers the active profile over cfg. A profile holds the
+// same keys as the config file, includes too; the ones it sets replace the
+// config's, lists included, and the rest carry over.
+func applyProfile(cfg *Config) error {
+	name := ActiveProfile()
+	if name == "" {
+		return nil
+	}
+	path, err := ProfilePath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("profile %q not found: create %s", name, path)
+	}
+	if err := DecodeFile(cfg, path); err != nil {
+		return fmt.Errorf("profile %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadProfile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(ProfileEnv, "")
+	dir := filepath.Join(home, ".config", "rekap")
+	if err := os.MkdirAll(filepath.Join(dir, "profiles"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	base := "display:\n  time_format: 24h\ntracking:\n  exclude_apps: [Finder, Dock]\n"
+	work := "colors:\n  primary: \"#88c0d0\"\ntracking:\n  exclude_apps: [Music]\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(base), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "profiles", "work.yaml"), []byte(work), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Colors.Primary != "13" {
+		t.Errorf("without a profile, primary = %q", cfg.Colors.Primary)
+	}
+
+	t.Setenv(ProfileEnv, "work")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Colors.Primary != "#88c0d0" {
+		t.Errorf("primary = %q, want the profile's", cfg.Colors.Primary)
+	}
+	if got := strings.Join(cfg.Tracking.ExcludeApps, ","); got != "Music" {
+		t.Errorf("exclude_apps = %s, want the profile's list to replace the config's", got)
+	}
+	if cfg.Display.TimeFormat != "24h" {
+		t.Errorf("time_format = %q, want the config's to carry over", cfg.Display.TimeFormat)
+	}
+	if got := strings.Join(ListProfiles(), ","); got != "work" {
+		t.Errorf("ListProfiles() = %s, want work", got)
+	}
+
+	// --profile wins over the environment
+	SetProfile("home")
+	defer SetProfile("")
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), `profile "home" not found`) {
+		t.Errorf("Load() with a missing profile = %v, want not found", err)
+	}
+	if _, err := ProfilePath("../work"); err == nil {
+		t.Error("ProfilePath accepted a path")
+	}
+}
@@ -0,0 +1,73 @@
+// Package golden compares rendered output against files checked in under a
+// test's testdata directory. Run a package's tests with -update to rewrite
+// the files after an intended change, then review the diff.
+package golden
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Normalize strips ANSI escapes and trailing spaces, so golden files hold
+// what a reader sees regardless of the terminal's color support
+func Normalize(s string) string {
+	lines := strings.Split(ansi.Strip(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Assert compares the normalized got against testdata/golden/<name>.golden,
+// or writes it there with -update
+func Assert(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	got = Normalize(got)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from %s (run with -update to accept):\n%s", name, path, diff(string(want), got))
+	}
+}
+
+// diff lists the lines that differ, numbered, which is enough to spot a
+// rendering regression without a diff library
+func diff(want, got string) string {
+	wantLines, gotLines := strings.Split(want, "\n"), strings.Split(got, "\n")
+	var b strings.Builder
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			fmt.Fprintf(&b, "%4d - %s\n%4d + %s\n", i+1, w, i+1, g)
+		}
+	}
+	return b.String()
+}
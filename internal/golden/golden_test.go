@@ -0,0 +1,30 @@
+package golden
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	in := "\x1b[1;38;5;205mTitle\x1b[0m   \nbody\t\n"
+	if got := Normalize(in); got != "Title\nbody\n" {
+		t.Errorf("Normalize = %q", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	got := diff("a\nb\nc", "a\nB\nc\nd")
+	if !strings.Contains(got, "2 - b") || !strings.Contains(got, "2 + B") || !strings.Contains(got, "4 + d") {
+		t.Errorf("diff = %q", got)
+	}
+	if strings.Contains(got, "a") {
+		t.Errorf("diff lists unchanged lines: %q", got)
+	}
+}
+
+func TestAssert(t *testing.T) {
+	// testdata/golden/sample.golden is checked in next to this test
+	Assert(t, "sample", "\x1b[32mrekap\x1b[0m  \n")
+}
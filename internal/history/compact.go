@@ -0,0 +1,407 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// weekTopN is how many apps and projects a weekly aggregate keeps
+const weekTopN = 10
+
+// WeekSummary aggregates the daily snapshots of one Monday-to-Sunday week.
+// Old daily snapshots are compacted into these so long-term trends survive
+// after the daily detail is pruned.
+type WeekSummary struct {
+	WeekStart           string         `json:"week_start"` // Monday, YYYY-MM-DD
+	Days                int            `json:"days"`       // Number of daily snapshots folded in
+	AwakeMinutes        int            `json:"awake_minutes"`
+	ScreenOnMinutes     int            `json:"screen_on_minutes"`
+	LockCount           int            `json:"lock_count"`
+	AppSwitches         int            `json:"app_switches"`
+	Notifications       int            `json:"notifications"`
+	URLsVisited         int            `json:"urls_visited"`
+	BytesReceived       int64          `json:"bytes_received"`
+	BytesSent           int64          `json:"bytes_sent"`
+	BurnoutWarnings     int            `json:"burnout_warnings"`
+	LongestFocusMinutes int            `json:"longest_focus_minutes"`
+	LongestFocusApp     string         `json:"longest_focus_app,omitempty"`
+	LongestFocusDate    string         `json:"longest_focus_date,omitempty"`
+	FragmentationTotal  int            `json:"fragmentation_total"` // Sum of daily scores, for averaging
+	FragmentationDays   int            `json:"fragmentation_days"`
+	MaxFragmentation    int            `json:"max_fragmentation,omitempty"` // Most fragmented day of the week
+	MaxFragmentationDay string         `json:"max_fragmentation_day,omitempty"`
+	TopApps             []AppUsage     `json:"top_apps"`
+	Projects            []ProjectUsage `json:"projects,omitempty"`
+	LearningMinutes     int            `json:"learning_minutes,omitempty"`
+	TopDomains          []DomainVisits `json:"top_domains,omitempty"`
+}
+
+// AvgScreenOnMinutes returns the average daily screen time for the week
+func (w WeekSummary) AvgScreenOnMinutes() int {
+	if w.Days == 0 {
+		return 0
+	}
+	return w.ScreenOnMinutes / w.Days
+}
+
+// AvgFragmentation returns the average daily fragmentation score
+func (w WeekSummary) AvgFragmentation() int {
+	if w.FragmentationDays == 0 {
+		return 0
+	}
+	return w.FragmentationTotal / w.FragmentationDays
+}
+
+// WeekStart returns the Monday (YYYY-MM-DD) of the week containing date
+func WeekStart(date string) (string, error) {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", err
+	}
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return t.AddDate(0, 0, -offset).Format("2006-01-02"), nil
+}
+
+// AddDay folds a daily snapshot into the week
+func (w *WeekSummary) AddDay(day DaySummary) {
+	w.Days++
+	w.AwakeMinutes += day.AwakeMinutes
+	w.ScreenOnMinutes += day.ScreenOnMinutes
+	w.LockCount += day.LockCount
+	w.AppSwitches += day.AppSwitches
+	w.Notifications += day.Notifications
+	w.URLsVisited += day.URLsVisited
+	w.BytesReceived += day.BytesReceived
+	w.BytesSent += day.BytesSent
+	w.BurnoutWarnings += day.BurnoutWarnings
+	w.LearningMinutes += day.LearningMinutes
+	if day.FocusStreakMinutes > w.LongestFocusMinutes {
+		w.LongestFocusMinutes = day.FocusStreakMinutes
+		w.LongestFocusApp = day.FocusApp
+		w.LongestFocusDate = day.Date
+	}
+	if day.FragmentationScore > 0 {
+		w.FragmentationTotal += day.FragmentationScore
+		w.FragmentationDays++
+		if day.FragmentationScore > w.MaxFragmentation {
+			w.MaxFragmentation = day.FragmentationScore
+			w.MaxFragmentationDay = day.Date
+		}
+	}
+	w.TopApps = mergeAppUsage(w.TopApps, day.TopApps)
+	w.Projects = mergeProjectUsage(w.Projects, day.Projects)
+	w.TopDomains = mergeDomainVisits(w.TopDomains, domainCounts(day.TopDomains))
+}
+
+// Merge folds another aggregate of the same week into w
+func (w *WeekSummary) Merge(other WeekSummary) {
+	w.Days += other.Days
+	w.AwakeMinutes += other.AwakeMinutes
+	w.ScreenOnMinutes += other.ScreenOnMinutes
+	w.LockCount += other.LockCount
+	w.AppSwitches += other.AppSwitches
+	w.Notifications += other.Notifications
+	w.URLsVisited += other.URLsVisited
+	w.BytesReceived += other.BytesReceived
+	w.BytesSent += other.BytesSent
+	w.BurnoutWarnings += other.BurnoutWarnings
+	w.LearningMinutes += other.LearningMinutes
+	if other.LongestFocusMinutes > w.LongestFocusMinutes {
+		w.LongestFocusMinutes = other.LongestFocusMinutes
+		w.LongestFocusApp = other.LongestFocusApp
+		w.LongestFocusDate = other.LongestFocusDate
+	}
+	w.FragmentationTotal += other.FragmentationTotal
+	w.FragmentationDays += other.FragmentationDays
+	if other.MaxFragmentation > w.MaxFragmentation {
+		w.MaxFragmentation = other.MaxFragmentation
+		w.MaxFragmentationDay = other.MaxFragmentationDay
+	}
+	w.TopApps = mergeAppUsage(w.TopApps, other.TopApps)
+	w.Projects = mergeProjectUsage(w.Projects, other.Projects)
+	w.TopDomains = mergeDomainVisits(w.TopDomains, domainCounts(other.TopDomains))
+}
+
+func mergeAppUsage(a, b []AppUsage) []AppUsage {
+	totals := make(map[string]int)
+	for _, u := range a {
+		totals[u.Name] += u.Minutes
+	}
+	for _, u := range b {
+		totals[u.Name] += u.Minutes
+	}
+	merged := make([]AppUsage, 0, len(totals))
+	for name, minutes := range totals {
+		merged = append(merged, AppUsage{Name: name, Minutes: minutes})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Minutes != merged[j].Minutes {
+			return merged[i].Minutes > merged[j].Minutes
+		}
+		return merged[i].Name < merged[j].Name
+	})
+	if len(merged) > weekTopN {
+		merged = merged[:weekTopN]
+	}
+	return merged
+}
+
+func mergeProjectUsage(a, b []ProjectUsage) []ProjectUsage {
+	var apps []AppUsage
+	for _, p := range append(append([]ProjectUsage{}, a...), b...) {
+		apps = append(apps, AppUsage(p))
+	}
+	merged := mergeAppUsage(nil, apps)
+	if len(merged) == 0 {
+		return nil
+	}
+	projects := make([]ProjectUsage, len(merged))
+	for i, p := range merged {
+		projects[i] = ProjectUsage(p)
+	}
+	return projects
+}
+
+// mergeDomainVisits adds counts to a and keeps the most visited domains
+func mergeDomainVisits(a []DomainVisits, counts map[string]int) []DomainVisits {
+	totals := domainCounts(a)
+	for domain, visits := range counts {
+		totals[domain] += visits
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+	merged := make([]DomainVisits, 0, len(totals))
+	for domain, visits := range totals {
+		merged = append(merged, DomainVisits{Domain: domain, Visits: visits})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Visits != merged[j].Visits {
+			return merged[i].Visits > merged[j].Visits
+		}
+		return merged[i].Domain < merged[j].Domain
+	})
+	if len(merged) > topDomainsN {
+		merged = merged[:topDomainsN]
+	}
+	return merged
+}
+
+func domainCounts(domains []DomainVisits) map[string]int {
+	counts := make(map[string]int, len(domains))
+	for _, d := range domains {
+		counts[d.Domain] += d.Visits
+	}
+	return counts
+}
+
+// CompactResult reports what Compact and PruneWeeks changed
+type CompactResult struct {
+	DaysCompacted int
+	WeeksTouched  int
+	WeeksPruned   int
+}
+
+// Compact folds daily snapshots dated before cutoff (YYYY-MM-DD) into their
+// weekly aggregates and deletes them. It runs in a single transaction, so
+// an interrupted compaction never loses or double-counts a day.
+func (s *Store) Compact(cutoff string) (CompactResult, error) {
+	var result CompactResult
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`SELECT data FROM days WHERE date < ? ORDER BY date`, cutoff)
+	if err != nil {
+		return result, fmt.Errorf("failed to query old snapshots: %w", err)
+	}
+	weeks := make(map[string]*WeekSummary)
+	var dates []string
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			rows.Close()
+			return result, err
+		}
+		var day DaySummary
+		if err := json.Unmarshal(blob, &day); err != nil {
+			continue
+		}
+		start, err := WeekStart(day.Date)
+		if err != nil {
+			continue
+		}
+		w, ok := weeks[start]
+		if !ok {
+			w = &WeekSummary{WeekStart: start}
+			weeks[start] = w
+		}
+		w.AddDay(day)
+		dates = append(dates, day.Date)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	for start, w := range weeks {
+		var blob []byte
+		err := tx.QueryRow(`SELECT data FROM weeks WHERE week_start = ?`, start).Scan(&blob)
+		if err == nil {
+			var existing WeekSummary
+			if json.Unmarshal(blob, &existing) == nil {
+				existing.Merge(*w)
+				w = &existing
+			}
+		}
+
+		data, err := json.Marshal(w)
+		if err != nil {
+			return result, err
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO weeks (week_start, data) VALUES (?, ?)
+			 ON CONFLICT(week_start) DO UPDATE SET data = excluded.data`, start, data); err != nil {
+			return result, fmt.Errorf("failed to save week %s: %w", start, err)
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM days WHERE date < ?`, cutoff); err != nil {
+		return result, fmt.Errorf("failed to delete compacted snapshots: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return result, err
+	}
+
+	result.DaysCompacted = len(dates)
+	result.WeeksTouched = len(weeks)
+	return result, nil
+}
+
+// Prune compacts daily snapshots before dayCutoff and, when weekCutoff is
+// set, deletes weekly aggregates for weeks starting before it.
+func (s *Store) Prune(dayCutoff, weekCutoff string) (CompactResult, error) {
+	result, err := s.Compact(dayCutoff)
+	if err != nil || weekCutoff == "" {
+		return result, err
+	}
+	result.WeeksPruned, err = s.PruneWeeks(weekCutoff)
+	return result, err
+}
+
+// PruneWeeks deletes weekly aggregates for weeks starting before cutoff
+func (s *Store) PruneWeeks(cutoff string) (int, error) {
+	res, err := s.db.Exec(`DELETE FROM weeks WHERE week_start < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune weekly aggregates: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+// Vacuum reclaims disk space after large deletions
+func (s *Store) Vacuum() error {
+	_, err := s.db.Exec(`VACUUM`)
+	return err
+}
+
+// Weeks returns weekly aggregates for weeks starting in [from, to], oldest first
+func (s *Store) Weeks(from, to string) ([]WeekSummary, error) {
+	rows, err := s.db.Query(`SELECT data FROM weeks WHERE week_start >= ? AND week_start <= ? ORDER BY week_start`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query weekly history: %w", err)
+	}
+	defer rows.Close()
+
+	var weeks []WeekSummary
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, err
+		}
+		var w WeekSummary
+		if err := json.Unmarshal(blob, &w); err != nil {
+			continue
+		}
+		weeks = append(weeks, w)
+	}
+	return weeks, rows.Err()
+}
+
+// WeeklyTrend returns one aggregate per week for weeks starting in
+// [from, to], combining compacted weeks with not-yet-compacted daily
+// snapshots so the trend is continuous across the retention boundary.
+func (s *Store) WeeklyTrend(from, to string) ([]WeekSummary, error) {
+	compacted, err := s.Weeks(from, to)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[string]*WeekSummary)
+	for i := range compacted {
+		byStart[compacted[i].WeekStart] = &compacted[i]
+	}
+
+	// Daily snapshots can run up to 6 days past the last week start
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.Range(from, toDate.AddDate(0, 0, 6).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range days {
+		start, err := WeekStart(day.Date)
+		if err != nil || start > to {
+			continue
+		}
+		w, ok := byStart[start]
+		if !ok {
+			w = &WeekSummary{WeekStart: start}
+			byStart[start] = w
+		}
+		w.AddDay(day)
+	}
+
+	weeks := make([]WeekSummary, 0, len(byStart))
+	for _, w := range byStart {
+		weeks = append(weeks, *w)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].WeekStart < weeks[j].WeekStart })
+	return weeks, nil
+}
+
+// Stats describes what the store currently holds
+type Stats struct {
+	Days      int
+	FirstDay  string
+	LastDay   string
+	Weeks     int
+	FirstWeek string
+	LastWeek  string
+}
+
+// Stats returns counts and date ranges for daily snapshots and weekly aggregates
+func (s *Store) Stats() (Stats, error) {
+	var st Stats
+	var first, last *string
+	if err := s.db.QueryRow(`SELECT COUNT(*), MIN(date), MAX(date) FROM days`).Scan(&st.Days, &first, &last); err != nil {
+		return st, err
+	}
+	if first != nil {
+		st.FirstDay, st.LastDay = *first, *last
+	}
+	first, last = nil, nil
+	if err := s.db.QueryRow(`SELECT COUNT(*), MIN(week_start), MAX(week_start) FROM weeks`).Scan(&st.Weeks, &first, &last); err != nil {
+		return st, err
+	}
+	if first != nil {
+		st.FirstWeek, st.LastWeek = *first, *last
+	}
+	return st, nil
+}
@@ -0,0 +1,139 @@
+package history
+
+import "testing"
+
+func TestWeekStart(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"2026-02-16": "2026-02-16", // Monday
+		"2026-02-18": "2026-02-16",
+		"2026-02-22": "2026-02-16", // Sunday belongs to the week before
+		"2026-02-23": "2026-02-23",
+	}
+	for date, want := range tests {
+		got, err := WeekStart(date)
+		if err != nil || got != want {
+			t.Errorf("WeekStart(%q) = %q, %v; want %q", date, got, err, want)
+		}
+	}
+}
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+	store := openTestStore(t)
+
+	for _, day := range []DaySummary{
+		{Date: "2026-02-16", ScreenOnMinutes: 300, Notifications: 10, FocusStreakMinutes: 40, FragmentationScore: 20,
+			TopApps:    []AppUsage{{Name: "Xcode", Minutes: 120}, {Name: "Slack", Minutes: 30}},
+			TopDomains: []DomainVisits{{Domain: "github.com", Visits: 20}, {Domain: "news.ycombinator.com", Visits: 15}}},
+		{Date: "2026-02-17", ScreenOnMinutes: 200, Notifications: 5, FocusStreakMinutes: 90, FocusApp: "Xcode", FragmentationScore: 40,
+			TopApps:    []AppUsage{{Name: "Slack", Minutes: 100}},
+			TopDomains: []DomainVisits{{Domain: "news.ycombinator.com", Visits: 10}}},
+		{Date: "2026-02-23", ScreenOnMinutes: 100},
+		{Date: "2026-03-02", ScreenOnMinutes: 50},
+	} {
+		if err := store.Save(day, 1); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	result, err := store.Compact("2026-02-24")
+	if err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if result.DaysCompacted != 3 || result.WeeksTouched != 2 {
+		t.Errorf("Compact = %+v, want 3 days into 2 weeks", result)
+	}
+
+	days, _ := store.Range("2026-01-01", "2026-12-31")
+	if len(days) != 1 || days[0].Date != "2026-03-02" {
+		t.Errorf("remaining days = %+v, want only 2026-03-02", days)
+	}
+
+	weeks, err := store.Weeks("2026-01-01", "2026-12-31")
+	if err != nil {
+		t.Fatalf("Weeks: %v", err)
+	}
+	if len(weeks) != 2 {
+		t.Fatalf("got %d weeks, want 2", len(weeks))
+	}
+	w := weeks[0]
+	if w.WeekStart != "2026-02-16" || w.Days != 2 || w.ScreenOnMinutes != 500 || w.Notifications != 15 {
+		t.Errorf("weeks[0] = %+v", w)
+	}
+	if w.LongestFocusMinutes != 90 || w.AvgFragmentation() != 30 || w.AvgScreenOnMinutes() != 250 {
+		t.Errorf("weeks[0] focus/fragmentation/avg = %d/%d/%d, want 90/30/250",
+			w.LongestFocusMinutes, w.AvgFragmentation(), w.AvgScreenOnMinutes())
+	}
+	if len(w.TopApps) != 2 || w.TopApps[0].Name != "Slack" || w.TopApps[0].Minutes != 130 {
+		t.Errorf("weeks[0].TopApps = %+v, want Slack 130 first", w.TopApps)
+	}
+	if len(w.TopDomains) != 2 || w.TopDomains[0].Domain != "news.ycombinator.com" || w.TopDomains[0].Visits != 25 {
+		t.Errorf("weeks[0].TopDomains = %+v, want news.ycombinator.com 25 first", w.TopDomains)
+	}
+	if w.LongestFocusApp != "Xcode" || w.LongestFocusDate != "2026-02-17" {
+		t.Errorf("weeks[0] longest focus = %q on %q, want Xcode on 2026-02-17", w.LongestFocusApp, w.LongestFocusDate)
+	}
+	if w.MaxFragmentation != 40 || w.MaxFragmentationDay != "2026-02-17" {
+		t.Errorf("weeks[0] max fragmentation = %d on %q, want 40 on 2026-02-17", w.MaxFragmentation, w.MaxFragmentationDay)
+	}
+
+	// A day of an already-compacted week merges into the existing aggregate
+	if err := store.Save(DaySummary{Date: "2026-02-25", ScreenOnMinutes: 60}, 2); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := store.Compact("2026-03-01"); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	weeks, _ = store.Weeks("2026-02-23", "2026-02-23")
+	if len(weeks) != 1 || weeks[0].Days != 2 || weeks[0].ScreenOnMinutes != 160 {
+		t.Errorf("merged week = %+v, want 2 days and 160 screen minutes", weeks)
+	}
+}
+
+func TestPruneAndWeeklyTrend(t *testing.T) {
+	t.Parallel()
+	store := openTestStore(t)
+
+	for _, day := range []DaySummary{
+		{Date: "2026-01-05", ScreenOnMinutes: 100},
+		{Date: "2026-02-16", ScreenOnMinutes: 200},
+		{Date: "2026-02-23", ScreenOnMinutes: 300},
+		{Date: "2026-02-24", ScreenOnMinutes: 100},
+	} {
+		if err := store.Save(day, 1); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	result, err := store.Prune("2026-02-20", "2026-02-01")
+	if err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	if result.DaysCompacted != 2 || result.WeeksPruned != 1 {
+		t.Errorf("Prune = %+v, want 2 days compacted and 1 week pruned", result)
+	}
+
+	stats, err := store.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Days != 2 || stats.FirstDay != "2026-02-23" || stats.Weeks != 1 || stats.FirstWeek != "2026-02-16" {
+		t.Errorf("Stats = %+v", stats)
+	}
+
+	// The trend spans compacted weeks and recent daily snapshots alike
+	trend, err := store.WeeklyTrend("2026-02-09", "2026-02-23")
+	if err != nil {
+		t.Fatalf("WeeklyTrend: %v", err)
+	}
+	if len(trend) != 2 {
+		t.Fatalf("got %d trend weeks, want 2", len(trend))
+	}
+	if trend[0].WeekStart != "2026-02-16" || trend[0].ScreenOnMinutes != 200 {
+		t.Errorf("trend[0] = %+v", trend[0])
+	}
+	if trend[1].WeekStart != "2026-02-23" || trend[1].Days != 2 || trend[1].ScreenOnMinutes != 400 {
+		t.Errorf("trend[1] = %+v", trend[1])
+	}
+}
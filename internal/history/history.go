@@ -0,0 +1,238 @@
+// Package history persists a compact summary of each day so multi-day
+// reports can be built without re-querying macOS data sources.
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexinslc/rekap/internal/state"
+	"github.com/alexinslc/rekap/internal/summary"
+	"github.com/alexinslc/rekap/internal/xdg"
+
+	_ "modernc.org/sqlite"
+)
+
+// AppUsage is an app and its foreground minutes for a day
+type AppUsage struct {
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// ProjectUsage is a project and the minutes attributed to it for a day
+type ProjectUsage struct {
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+// DomainVisits is a browser domain and its visit count
+type DomainVisits struct {
+	Domain string `json:"domain"`
+	Visits int    `json:"visits"`
+}
+
+// topDomainsN is how many domains a daily or weekly snapshot keeps
+const topDomainsN = 10
+
+// DaySummary is the per-day snapshot stored in the history database.
+// Fields are zero when the corresponding collector was unavailable.
+type DaySummary struct {
+	Date               string         `json:"date"` // YYYY-MM-DD
+	AwakeMinutes       int            `json:"awake_minutes"`
+	ScreenOnMinutes    int            `json:"screen_on_minutes"`
+	LockCount          int            `json:"lock_count"`
+	TopApps            []AppUsage     `json:"top_apps"`
+	AppSwitches        int            `json:"app_switches"`
+	FocusStreakMinutes int            `json:"focus_streak_minutes"`
+	FocusApp           string         `json:"focus_app"`
+	Notifications      int            `json:"notifications"`
+	URLsVisited        int            `json:"urls_visited"`
+	FragmentationScore int            `json:"fragmentation_score"`
+	BytesReceived      int64          `json:"bytes_received"`
+	BytesSent          int64          `json:"bytes_sent"`
+	BurnoutWarnings    int            `json:"burnout_warnings"`
+	Projects           []ProjectUsage `json:"projects,omitempty"`
+	LearningMinutes    int            `json:"learning_minutes,omitempty"`
+	TopDomains         []DomainVisits `json:"top_domains,omitempty"`
+}
+
+// FromData builds a DaySummary for date from a run's collector results
+func FromData(date string, data *summary.Data) DaySummary {
+	day := DaySummary{Date: date}
+
+	if data.Uptime.Available {
+		day.AwakeMinutes = data.Uptime.AwakeMinutes
+	}
+	if data.Screen.Available {
+		day.ScreenOnMinutes = data.Screen.ScreenOnMinutes
+		day.LockCount = data.Screen.LockCount
+	}
+	if data.Apps.Available {
+		for _, app := range data.Apps.TopApps {
+			day.TopApps = append(day.TopApps, AppUsage{Name: app.Name, Minutes: app.Minutes})
+		}
+		if data.Apps.SwitchingAvailable {
+			day.AppSwitches = data.Apps.TotalSwitches
+		}
+	}
+	if data.Focus.Available {
+		day.FocusStreakMinutes = data.Focus.StreakMinutes
+		day.FocusApp = data.Focus.AppName
+	}
+	if data.Notifications.Available {
+		day.Notifications = data.Notifications.TotalNotifications
+	}
+	if data.Browsers.Available {
+		day.URLsVisited = data.Browsers.TotalURLsVisited
+		day.TopDomains = mergeDomainVisits(nil, data.Browsers.HistoryDomains)
+	}
+	if data.Fragmentation.Available {
+		day.FragmentationScore = data.Fragmentation.Score
+	}
+	if data.Network.Available {
+		day.BytesReceived = data.Network.BytesReceived
+		day.BytesSent = data.Network.BytesSent
+	}
+	if data.Burnout.Available {
+		day.BurnoutWarnings = len(data.Burnout.Warnings)
+	}
+	if data.Projects.Available {
+		for _, p := range data.Projects.Projects {
+			day.Projects = append(day.Projects, ProjectUsage{Name: p.Name, Minutes: p.Minutes})
+		}
+	}
+	if data.Learning.Available {
+		day.LearningMinutes = data.Learning.Minutes
+	}
+
+	return day
+}
+
+// Store is the history database
+type Store struct {
+	db *sql.DB
+}
+
+// DefaultPath returns $XDG_DATA_HOME/rekap/history.db
+// (~/.local/share/rekap by default)
+func DefaultPath() (string, error) {
+	dir, err := xdg.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.db"), nil
+}
+
+// Open opens (creating if needed) the history database at the default path
+func Open() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return OpenPath(path)
+}
+
+// OpenPath opens (creating if needed) the history database at path
+func OpenPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", state.DSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	const schema = `CREATE TABLE IF NOT EXISTS days (
+		date       TEXT PRIMARY KEY,
+		updated_at INTEGER NOT NULL,
+		data       BLOB NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS weeks (
+		week_start TEXT PRIMARY KEY,
+		data       BLOB NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS notes (
+		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+		date       TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		text       TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS notes_date ON notes (date)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize history database: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save stores the snapshot for day.Date, replacing any earlier snapshot of
+// the same day. Later runs see more of the day, so the latest one wins.
+func (s *Store) Save(day DaySummary, updatedAt int64) error {
+	blob, err := json.Marshal(day)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO days (date, updated_at, data) VALUES (?, ?, ?)
+		 ON CONFLICT(date) DO UPDATE SET updated_at = excluded.updated_at, data = excluded.data`,
+		day.Date, updatedAt, blob)
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", day.Date, err)
+	}
+	return nil
+}
+
+// Dates returns the dates (YYYY-MM-DD) that have a daily snapshot, newest
+// first
+func (s *Store) Dates() ([]string, error) {
+	rows, err := s.db.Query(`SELECT date FROM days ORDER BY date DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	var dates []string
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		dates = append(dates, date)
+	}
+	return dates, rows.Err()
+}
+
+// Range returns snapshots with from <= date <= to (YYYY-MM-DD), oldest first
+func (s *Store) Range(from, to string) ([]DaySummary, error) {
+	rows, err := s.db.Query(`SELECT data FROM days WHERE date >= ? AND date <= ? ORDER BY date`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer rows.Close()
+
+	var days []DaySummary
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, err
+		}
+		var day DaySummary
+		if err := json.Unmarshal(blob, &day); err != nil {
+			// Skip corrupted rows rather than failing the whole report
+			continue
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
@@ -0,0 +1,85 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := OpenPath(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("OpenPath: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSaveAndRange(t *testing.T) {
+	t.Parallel()
+	store := openTestStore(t)
+
+	for _, day := range []DaySummary{
+		{Date: "2026-02-16", ScreenOnMinutes: 300},
+		{Date: "2026-02-17", ScreenOnMinutes: 200},
+		{Date: "2026-02-18", ScreenOnMinutes: 100},
+	} {
+		if err := store.Save(day, 1); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	// A later run of the same day replaces the earlier snapshot
+	if err := store.Save(DaySummary{Date: "2026-02-17", ScreenOnMinutes: 250}, 2); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	days, err := store.Range("2026-02-17", "2026-02-18")
+	if err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("got %d days, want 2", len(days))
+	}
+	if days[0].Date != "2026-02-17" || days[0].ScreenOnMinutes != 250 {
+		t.Errorf("days[0] = %+v, want 2026-02-17 with 250 screen minutes", days[0])
+	}
+	if days[1].Date != "2026-02-18" {
+		t.Errorf("days[1].Date = %q, want 2026-02-18", days[1].Date)
+	}
+
+	dates, err := store.Dates()
+	if err != nil {
+		t.Fatalf("Dates: %v", err)
+	}
+	if len(dates) != 3 || dates[0] != "2026-02-18" || dates[2] != "2026-02-16" {
+		t.Errorf("Dates() = %v, want newest first", dates)
+	}
+}
+
+func TestFromData(t *testing.T) {
+	t.Parallel()
+	data := &summary.Data{
+		Screen: collectors.ScreenResult{ScreenOnMinutes: 320, LockCount: 4, Available: true},
+		Apps: collectors.AppsResult{
+			TopApps:   []collectors.AppUsage{{Name: "VS Code", Minutes: 120, BundleID: "com.microsoft.VSCode"}},
+			Available: true,
+		},
+		Focus: collectors.FocusResult{StreakMinutes: 90, AppName: "VS Code", Available: false},
+	}
+
+	day := FromData("2026-02-18", data)
+	if day.Date != "2026-02-18" || day.ScreenOnMinutes != 320 || day.LockCount != 4 {
+		t.Errorf("unexpected screen fields: %+v", day)
+	}
+	if len(day.TopApps) != 1 || day.TopApps[0].Name != "VS Code" {
+		t.Errorf("TopApps = %+v, want VS Code", day.TopApps)
+	}
+	// Unavailable collectors are not recorded
+	if day.FocusStreakMinutes != 0 {
+		t.Errorf("FocusStreakMinutes = %d, want 0 for unavailable focus data", day.FocusStreakMinutes)
+	}
+}
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+// AddNote attaches a note to date (YYYY-MM-DD). Notes live alongside the
+// daily snapshots but are never compacted, so they survive retention.
+func (s *Store) AddNote(date string, at time.Time, text string) (summary.Note, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return summary.Note{}, fmt.Errorf("note text is empty")
+	}
+
+	res, err := s.db.Exec(`INSERT INTO notes (date, created_at, text) VALUES (?, ?, ?)`, date, at.Unix(), text)
+	if err != nil {
+		return summary.Note{}, fmt.Errorf("failed to save note: %w", err)
+	}
+	id, _ := res.LastInsertId()
+	return summary.Note{ID: id, Date: date, Time: time.Unix(at.Unix(), 0), Text: text}, nil
+}
+
+// DeleteNote removes the note with id, reporting whether it existed
+func (s *Store) DeleteNote(id int64) (bool, error) {
+	res, err := s.db.Exec(`DELETE FROM notes WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete note: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// Notes returns notes with from <= date <= to (YYYY-MM-DD), oldest first
+func (s *Store) Notes(from, to string) ([]summary.Note, error) {
+	rows, err := s.db.Query(`SELECT id, date, created_at, text FROM notes WHERE date >= ? AND date <= ? ORDER BY date, created_at, id`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []summary.Note
+	for rows.Next() {
+		var n summary.Note
+		var createdAt int64
+		if err := rows.Scan(&n.ID, &n.Date, &createdAt, &n.Text); err != nil {
+			return nil, err
+		}
+		n.Time = time.Unix(createdAt, 0)
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
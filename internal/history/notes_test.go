@@ -0,0 +1,60 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotes(t *testing.T) {
+	t.Parallel()
+	store := openTestStore(t)
+
+	at := time.Date(2026, 2, 16, 15, 4, 0, 0, time.Local)
+	first, err := store.AddNote("2026-02-16", at, "  shipped the release ")
+	if err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+	if first.Text != "shipped the release" || first.ID == 0 {
+		t.Errorf("AddNote = %+v, want trimmed text and an id", first)
+	}
+	if _, err := store.AddNote("2026-02-16", at.Add(time.Hour), "retro"); err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+	if _, err := store.AddNote("2026-02-17", at, "offsite"); err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+	if _, err := store.AddNote("2026-02-17", at, "   "); err == nil {
+		t.Error("expected an error for an empty note")
+	}
+
+	notes, err := store.Notes("2026-02-16", "2026-02-16")
+	if err != nil {
+		t.Fatalf("Notes: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Text != "shipped the release" || notes[1].Text != "retro" {
+		t.Errorf("Notes = %+v, want both notes for the 16th, oldest first", notes)
+	}
+	if !notes[0].Time.Equal(at) {
+		t.Errorf("note time = %v, want %v", notes[0].Time, at)
+	}
+
+	found, err := store.DeleteNote(first.ID)
+	if err != nil || !found {
+		t.Fatalf("DeleteNote = %v, %v", found, err)
+	}
+	if found, _ := store.DeleteNote(first.ID); found {
+		t.Error("deleting twice should report not found")
+	}
+
+	// Compaction leaves notes alone
+	if err := store.Save(DaySummary{Date: "2026-02-16"}, 1); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := store.Compact("2026-03-01"); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	notes, _ = store.Notes("2026-02-01", "2026-02-28")
+	if len(notes) != 2 {
+		t.Errorf("got %d notes after compaction, want 2", len(notes))
+	}
+}
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Redact removes the domains redacts matches from every daily snapshot and
+// weekly aggregate, so a domain added to privacy.redact_domains leaves the
+// history store too. It returns how many snapshots were rewritten.
+func (s *Store) Redact(redacts func(domain string) bool) (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	days, err := redactTable(tx, "days", "date", func(d *DaySummary) *[]DomainVisits { return &d.TopDomains }, redacts)
+	if err != nil {
+		return 0, err
+	}
+	weeks, err := redactTable(tx, "weeks", "week_start", func(w *WeekSummary) *[]DomainVisits { return &w.TopDomains }, redacts)
+	if err != nil {
+		return 0, err
+	}
+	return days + weeks, tx.Commit()
+}
+
+// redactTable rewrites the snapshots in table that list a domain redacts
+// matches. domains returns a snapshot's domain list.
+func redactTable[T any](tx *sql.Tx, table, key string, domains func(*T) *[]DomainVisits, redacts func(string) bool) (int, error) {
+	rows, err := tx.Query(`SELECT ` + key + `, data FROM ` + table)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	updates := make(map[string][]byte)
+	for rows.Next() {
+		var k string
+		var blob []byte
+		if err := rows.Scan(&k, &blob); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		var snapshot T
+		if err := json.Unmarshal(blob, &snapshot); err != nil {
+			continue
+		}
+		list := domains(&snapshot)
+		kept := slices.DeleteFunc(slices.Clone(*list), func(d DomainVisits) bool { return redacts(d.Domain) })
+		if len(kept) == len(*list) {
+			continue
+		}
+		*list = kept
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		updates[k] = data
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for k, data := range updates {
+		if _, err := tx.Exec(`UPDATE `+table+` SET data = ? WHERE `+key+` = ?`, data, k); err != nil {
+			return 0, fmt.Errorf("failed to redact %s: %w", k, err)
+		}
+	}
+	return len(updates), nil
+}
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+	store := openTestStore(t)
+
+	for _, day := range []DaySummary{
+		{Date: "2026-02-09", TopDomains: []DomainVisits{{"github.com", 9}, {"mychart.org", 4}}},
+		{Date: "2026-02-16", TopDomains: []DomainVisits{{"github.com", 5}, {"chase.com", 2}}, ScreenOnMinutes: 300},
+		{Date: "2026-02-17", TopDomains: []DomainVisits{{"github.com", 3}}},
+	} {
+		if err := store.Save(day, 1); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	if _, err := store.Compact("2026-02-16"); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+
+	redacts := func(domain string) bool { return domain == "mychart.org" || strings.HasSuffix(domain, "chase.com") }
+	n, err := store.Redact(redacts)
+	if err != nil {
+		t.Fatalf("Redact: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Redact rewrote %d snapshots, want the week and the 16th", n)
+	}
+
+	days, err := store.Range("2026-02-16", "2026-02-17")
+	if err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+	if len(days) != 2 || len(days[0].TopDomains) != 1 || days[0].TopDomains[0].Domain != "github.com" {
+		t.Errorf("days = %+v, want chase.com gone from the 16th", days)
+	}
+	if days[0].ScreenOnMinutes != 300 {
+		t.Errorf("screen_on_minutes = %d, want the rest of the snapshot kept", days[0].ScreenOnMinutes)
+	}
+
+	weeks, err := store.Weeks("2026-02-09", "2026-02-09")
+	if err != nil {
+		t.Fatalf("Weeks: %v", err)
+	}
+	if len(weeks) != 1 || len(weeks[0].TopDomains) != 1 || weeks[0].TopDomains[0].Domain != "github.com" {
+		t.Errorf("weeks = %+v, want mychart.org gone from the aggregate", weeks)
+	}
+
+	if n, err := store.Redact(redacts); err != nil || n != 0 {
+		t.Errorf("second Redact = %d, %v; want nothing left to rewrite", n, err)
+	}
+}
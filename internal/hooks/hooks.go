@@ -0,0 +1,69 @@
+// Package hooks runs user-configured shell commands around collection, so
+// custom automation (syncing to a personal server, logging, notifications)
+// doesn't need a dedicated integration.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Stage names, also exported to hooks as $REKAP_HOOK
+const (
+	PreCollect  = "pre_collect"
+	PostCollect = "post_collect"
+)
+
+// Options configures a hook run
+type Options struct {
+	Stage   string        // PreCollect or PostCollect
+	Timeout time.Duration // Per command
+	Payload []byte        // Piped to each command's stdin; nil for none
+	Env     []string      // Extra KEY=VALUE pairs on top of the environment
+	Output  io.Writer     // Receives the commands' stdout and stderr
+}
+
+// Run executes commands one after another with /bin/sh -c. A failing or
+// timed-out command doesn't stop the rest; its error is returned with the
+// others so the caller can report them.
+func Run(ctx context.Context, commands []string, opts Options) []error {
+	var errs []error
+	for _, command := range commands {
+		if err := runOne(ctx, command, opts); err != nil {
+			errs = append(errs, fmt.Errorf("%s hook %q: %w", opts.Stage, command, err))
+		}
+	}
+	return errs
+}
+
+func runOne(ctx context.Context, command string, opts Options) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = append(os.Environ(), "REKAP_HOOK="+opts.Stage)
+	cmd.Env = append(cmd.Env, opts.Env...)
+	if opts.Payload != nil {
+		cmd.Stdin = bytes.NewReader(opts.Payload)
+	}
+	if opts.Output != nil {
+		cmd.Stdout = opts.Output
+		cmd.Stderr = opts.Output
+	}
+	// Don't wait on grandchildren that inherited the output pipes
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", opts.Timeout)
+	}
+	return err
+}
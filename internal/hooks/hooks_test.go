@@ -0,0 +1,49 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunPipesPayloadAndEnv(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	errs := Run(context.Background(), []string{`cat; echo " $REKAP_HOOK $REKAP_DATE"`}, Options{
+		Stage:   PostCollect,
+		Timeout: 5 * time.Second,
+		Payload: []byte(`{"date":"2026-02-16"}`),
+		Env:     []string{"REKAP_DATE=2026-02-16"},
+		Output:  &out,
+	})
+	if len(errs) != 0 {
+		t.Fatalf("Run errors: %v", errs)
+	}
+	if got, want := strings.TrimSpace(out.String()), `{"date":"2026-02-16"} post_collect 2026-02-16`; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestRunContinuesAfterFailure(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	errs := Run(context.Background(), []string{"exit 3", "sleep 5", "echo ran"}, Options{
+		Stage:   PreCollect,
+		Timeout: 200 * time.Millisecond,
+		Output:  &out,
+	})
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "exit status 3") {
+		t.Errorf("errs[0] = %v, want exit status 3", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "timed out") {
+		t.Errorf("errs[1] = %v, want a timeout", errs[1])
+	}
+	if strings.TrimSpace(out.String()) != "ran" {
+		t.Errorf("output = %q, want the last command to still run", out.String())
+	}
+}
@@ -0,0 +1,105 @@
+// Package keychain keeps integration secrets (tokens, webhook URLs) in the
+// macOS login Keychain through the security command, so config files can
+// name them with "keychain:NAME" instead of holding them in plain text.
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Prefix marks a config value as the name of a Keychain item
+const Prefix = "keychain:"
+
+// account is the Keychain account every rekap item is saved under
+const account = "rekap"
+
+// ErrNotFound is returned for a name with no Keychain item
+var ErrNotFound = errors.New("not in the Keychain")
+
+// notFoundStatus is the exit status of security for a missing item
+const notFoundStatus = 44
+
+// validName matches the names items can have, which are passed to
+// security unquoted
+var validName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// ValidName reports whether name can name a Keychain item, e.g.
+// "rekap-slack-token"
+func ValidName(name string) bool {
+	return validName.MatchString(name)
+}
+
+// Get returns the secret saved under name
+func Get(name string) (string, error) {
+	if !ValidName(name) {
+		return "", fmt.Errorf("invalid Keychain item name %q (use letters, digits, '.', '_', and '-')", name)
+	}
+	out, err := exec.Command("security", "find-generic-password", "-a", account, "-s", name, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == notFoundStatus {
+			return "", fmt.Errorf("%s: %w; add it with 'rekap secrets set %s'", name, ErrNotFound, name)
+		}
+		return "", fmt.Errorf("failed to read %s from the Keychain: %w", name, err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set saves secret under name, replacing any secret already there. The
+// secret goes to security on stdin, so it never shows in the process list.
+func Set(name, secret string) error {
+	if !ValidName(name) {
+		return fmt.Errorf("invalid Keychain item name %q (use letters, digits, '.', '_', and '-')", name)
+	}
+	if secret == "" || strings.ContainsAny(secret, "\r\n") {
+		return fmt.Errorf("a secret must be one line of text")
+	}
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -a %s -s %s -w %s\n", account, name, quote(secret)))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to save %s to the Keychain: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Delete removes the secret saved under name
+func Delete(name string) error {
+	if !ValidName(name) {
+		return fmt.Errorf("invalid Keychain item name %q (use letters, digits, '.', '_', and '-')", name)
+	}
+	if err := exec.Command("security", "delete-generic-password", "-a", account, "-s", name).Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == notFoundStatus {
+			return fmt.Errorf("%s: %w", name, ErrNotFound)
+		}
+		return fmt.Errorf("failed to delete %s from the Keychain: %w", name, err)
+	}
+	return nil
+}
+
+// Ref returns the Keychain item name a config value refers to, and false
+// when the value isn't a "keychain:NAME" reference
+func Ref(value string) (string, bool) {
+	name, ok := strings.CutPrefix(strings.TrimSpace(value), Prefix)
+	return name, ok
+}
+
+// Resolve returns a config value with its secret filled in: the Keychain
+// item for "keychain:NAME", and anything else with $VARS expanded from the
+// environment
+func Resolve(value string) (string, error) {
+	if name, ok := Ref(value); ok {
+		return Get(name)
+	}
+	return os.ExpandEnv(value), nil
+}
+
+// quote single-quotes s for the shell-like command line security -i reads
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
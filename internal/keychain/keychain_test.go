@@ -0,0 +1,86 @@
+package keychain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeSecurity puts a security command on PATH that knows one item,
+// rekap-slack-token, and writes what it reads on stdin to the returned file
+func fakeSecurity(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "stdin")
+	script := `#!/bin/sh
+case "$1" in
+find-generic-password|delete-generic-password)
+	[ "$3" = rekap ] && [ "$5" = rekap-slack-token ] || exit 44
+	[ "$1" = find-generic-password ] && echo xoxp-123
+	exit 0 ;;
+-i)
+	cat > "` + log + `" ;;
+*)
+	exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "security"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestResolve(t *testing.T) {
+	fakeSecurity(t)
+	t.Setenv("REKAP_TEST_TOKEN", "s3cret")
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"keychain:rekap-slack-token", "xoxp-123"},
+		{" keychain:rekap-slack-token", "xoxp-123"},
+		{"Bearer $REKAP_TEST_TOKEN", "Bearer s3cret"},
+		{"https://hooks.slack.com/services/T000", "https://hooks.slack.com/services/T000"},
+	}
+	for _, tt := range tests {
+		if got, err := Resolve(tt.value); err != nil || got != tt.want {
+			t.Errorf("Resolve(%q) = %q, %v; want %q", tt.value, got, err, tt.want)
+		}
+	}
+
+	if _, err := Resolve("keychain:missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve of a missing item = %v, want ErrNotFound", err)
+	}
+	if _, err := Resolve("keychain:bad name"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve of an invalid name = %v, want an invalid name error", err)
+	}
+}
+
+func TestSetAndDelete(t *testing.T) {
+	log := fakeSecurity(t)
+
+	if err := Set("rekap-ha-token", "Bearer it's-a-secret"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `add-generic-password -U -a rekap -s rekap-ha-token -w 'Bearer it'"'"'s-a-secret'` + "\n"
+	if string(got) != want {
+		t.Errorf("security read %q, want %q", got, want)
+	}
+	if err := Set("rekap-ha-token", "two\nlines"); err == nil {
+		t.Error("Set accepted a secret with a line break")
+	}
+
+	if err := Delete("rekap-slack-token"); err != nil {
+		t.Errorf("Delete() = %v", err)
+	}
+	if err := Delete("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete of a missing item = %v, want ErrNotFound", err)
+	}
+}
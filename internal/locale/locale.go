@@ -0,0 +1,223 @@
+// Package locale formats dates, times, and numbers the way the user's
+// region writes them: the clock, the order of day and month, the decimal
+// separator, and the day weeks start on. Month and day names stay English.
+package locale
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Locale is how one region formats dates and numbers
+type Locale struct {
+	Tag      string // Normalized, e.g. "de_DE"
+	Clock24  bool
+	DayFirst bool   // "16 Feb" rather than "Feb 16"
+	Decimal  string // Decimal separator, "." or ","
+	FirstDay time.Weekday
+}
+
+// Default is US English, used when the locale can't be detected. It matches
+// rekap's output from before it knew about locales.
+var Default = Parse("en_US")
+
+// Regions that use a 12-hour clock; everywhere else defaults to 24h
+var clock12Regions = set("US", "CA", "AU", "NZ", "PH", "IN", "PK", "BD", "EG", "SA", "MY")
+
+// Regions and languages that write the month before the day. Year-first
+// languages (Japanese, Chinese, Korean, Hungarian) are closest to this too.
+var (
+	monthFirstRegions   = set("US", "CA", "PH")
+	monthFirstLanguages = set("ja", "zh", "ko", "hu", "lt")
+)
+
+// Languages that write "1,5" rather than "1.5", with regions that don't
+var (
+	decimalCommaLanguages = set("de", "fr", "es", "it", "pt", "nl", "ru", "pl", "sv", "da", "nb", "nn", "no", "fi",
+		"cs", "sk", "tr", "id", "uk", "el", "hu", "ro", "bg", "hr", "sl", "sr", "lt", "lv", "et", "ca", "vi")
+	decimalPointRegions = set("US", "MX", "CH")
+)
+
+// Regions whose weeks start on Sunday or Saturday; everywhere else Monday
+var (
+	sundayRegions   = set("US", "CA", "MX", "BR", "JP", "IL", "PH", "IN", "KR", "TW", "HK", "ZA", "SA")
+	saturdayRegions = set("EG", "DZ", "IQ", "JO", "KW", "LY", "OM", "QA", "SY")
+)
+
+func set(keys ...string) map[string]bool {
+	m := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		m[k] = true
+	}
+	return m
+}
+
+// split breaks a POSIX or BCP 47 tag like "de_DE.UTF-8", "en-GB", or
+// "fr_CA@euro" into a lowercase language and an uppercase region
+func split(tag string) (lang, region string) {
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	lang, region, _ = strings.Cut(strings.ReplaceAll(tag, "-", "_"), "_")
+	return strings.ToLower(lang), strings.ToUpper(region)
+}
+
+// Valid reports whether tag looks like a locale, e.g. "en_GB" or "de"
+func Valid(tag string) bool {
+	lang, region := split(tag)
+	if len(lang) < 2 || len(lang) > 3 || (region != "" && len(region) != 2) {
+		return false
+	}
+	for _, r := range lang + strings.ToLower(region) {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse returns the formatting for tag. A language without a region gets
+// that language's usual conventions; "C", "POSIX", and invalid tags get
+// Default.
+func Parse(tag string) Locale {
+	if !Valid(tag) {
+		tag = "en_US"
+	}
+	lang, region := split(tag)
+	if region == "" && lang == "en" {
+		region = "US"
+	}
+
+	l := Locale{
+		Tag:      lang,
+		Clock24:  !clock12Regions[region] || lang == "fr",
+		DayFirst: !monthFirstRegions[region] && !monthFirstLanguages[lang],
+		Decimal:  ".",
+		FirstDay: time.Monday,
+	}
+	if region != "" {
+		l.Tag += "_" + region
+	}
+	if region == "CA" && lang == "fr" {
+		l.DayFirst = true
+	}
+	if decimalCommaLanguages[lang] && !decimalPointRegions[region] {
+		l.Decimal = ","
+	}
+	switch {
+	case sundayRegions[region]:
+		l.FirstDay = time.Sunday
+	case saturdayRegions[region]:
+		l.FirstDay = time.Saturday
+	}
+	return l
+}
+
+// detected is the system locale, read once per process
+var detected = sync.OnceValue(func() string {
+	for _, key := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if tag := os.Getenv(key); Valid(tag) {
+			return tag
+		}
+	}
+	// Apps launched from Finder don't get $LANG; the system setting always exists
+	out, err := exec.Command("defaults", "read", "-g", "AppleLocale").Output()
+	if err == nil && Valid(strings.TrimSpace(string(out))) {
+		return strings.TrimSpace(string(out))
+	}
+	return ""
+})
+
+// Detect returns the system locale from $LC_ALL, $LC_TIME, $LANG, or the
+// macOS region setting, or "" when none is set
+func Detect() string {
+	return detected()
+}
+
+var current atomic.Pointer[Locale]
+
+// Set makes l the locale every formatter uses. Loading the config sets it.
+func Set(l Locale) {
+	current.Store(&l)
+}
+
+// Current returns the locale set with Set, or Default
+func Current() Locale {
+	if l := current.Load(); l != nil {
+		return *l
+	}
+	return Default
+}
+
+// TimeFormat returns the display.time_format value for l's clock
+func (l Locale) TimeFormat() string {
+	if l.Clock24 {
+		return "24h"
+	}
+	return "12h"
+}
+
+// FormatFloat formats f with prec decimals and l's decimal separator
+func (l Locale) FormatFloat(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	if l.Decimal != "." {
+		s = strings.Replace(s, ".", l.Decimal, 1)
+	}
+	return s
+}
+
+// ShortDate formats t as "Mon Jan 2" or "Mon 2 Jan"
+func (l Locale) ShortDate(t time.Time) string {
+	if l.DayFirst {
+		return t.Format("Mon 2 Jan")
+	}
+	return t.Format("Mon Jan 2")
+}
+
+// ShortDateYear formats t as "Mon Jan 2 2006" or "Mon 2 Jan 2006"
+func (l Locale) ShortDateYear(t time.Time) string {
+	return l.ShortDate(t) + t.Format(" 2006")
+}
+
+// LongDate formats t as "Monday, January 2" or "Monday 2 January"
+func (l Locale) LongDate(t time.Time) string {
+	if l.DayFirst {
+		return t.Format("Monday 2 January")
+	}
+	return t.Format("Monday, January 2")
+}
+
+// FullDate formats t as "Monday, January 2, 2006" or "Monday 2 January 2006"
+func (l Locale) FullDate(t time.Time) string {
+	if l.DayFirst {
+		return t.Format("Monday 2 January 2006")
+	}
+	return t.Format("Monday, January 2, 2006")
+}
+
+// MonthDay formats t as "Jan 2" or "2 Jan"
+func (l Locale) MonthDay(t time.Time) string {
+	if l.DayFirst {
+		return t.Format("2 Jan")
+	}
+	return t.Format("Jan 2")
+}
+
+// MonthDayYear formats t as "Jan 2, 2006" or "2 Jan 2006"
+func (l Locale) MonthDayYear(t time.Time) string {
+	if l.DayFirst {
+		return t.Format("2 Jan 2006")
+	}
+	return t.Format("Jan 2, 2006")
+}
+
+// WeekStart returns midnight on the first day of the week containing t
+func (l Locale) WeekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) - int(l.FirstDay) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
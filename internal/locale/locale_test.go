@@ -0,0 +1,60 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		tag      string
+		want     string
+		clock24  bool
+		dayFirst bool
+		decimal  string
+		firstDay time.Weekday
+	}{
+		{"en_US.UTF-8", "en_US", false, false, ".", time.Sunday},
+		{"en-GB", "en_GB", true, true, ".", time.Monday},
+		{"de_DE@euro", "de_DE", true, true, ",", time.Monday},
+		{"de_CH", "de_CH", true, true, ".", time.Monday},
+		{"fr_CA", "fr_CA", true, true, ",", time.Sunday},
+		{"ja_JP", "ja_JP", true, false, ".", time.Sunday},
+		{"pt", "pt", true, true, ",", time.Monday},
+		{"en", "en_US", false, false, ".", time.Sunday},
+		{"C.UTF-8", "en_US", false, false, ".", time.Sunday},
+		{"", "en_US", false, false, ".", time.Sunday},
+	}
+	for _, tt := range tests {
+		l := Parse(tt.tag)
+		if l.Tag != tt.want || l.Clock24 != tt.clock24 || l.DayFirst != tt.dayFirst || l.Decimal != tt.decimal || l.FirstDay != tt.firstDay {
+			t.Errorf("Parse(%q) = %+v", tt.tag, l)
+		}
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2026, 2, 18, 15, 4, 0, 0, time.UTC) // A Wednesday
+	us, de := Parse("en_US"), Parse("de_DE")
+
+	if got := us.ShortDate(day); got != "Wed Feb 18" {
+		t.Errorf("US ShortDate = %q", got)
+	}
+	if got := de.ShortDate(day); got != "Wed 18 Feb" {
+		t.Errorf("DE ShortDate = %q", got)
+	}
+	if got := de.FullDate(day); got != "Wednesday 18 February 2026" {
+		t.Errorf("DE FullDate = %q", got)
+	}
+	if got := de.FormatFloat(1.25, 1); got != "1,2" {
+		t.Errorf("DE FormatFloat = %q", got)
+	}
+	if got := us.WeekStart(day); !got.Equal(time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("US WeekStart = %v, want Sunday Feb 15", got)
+	}
+	if got := de.WeekStart(day); !got.Equal(time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DE WeekStart = %v, want Monday Feb 16", got)
+	}
+}
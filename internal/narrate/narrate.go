@@ -0,0 +1,99 @@
+// Package narrate turns the day's summary into a short prose recap by
+// piping a prompt through a local model command such as "ollama run
+// llama3". rekap itself never talks to the network here; whether the recap
+// stays on the machine is up to the command.
+package narrate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultPrompt is used when the config doesn't set narrate.prompt
+const DefaultPrompt = `You are summarizing a person's computer activity for {{.Date}}.
+Write a short, friendly recap in second person ("You spent most of the
+morning in VS Code..."), three to five sentences of plain prose with no
+lists, headings, or Markdown. Mention when the day started and ended, where
+the time went, and the longest focus stretch. Only use facts from the data
+below, and round durations the way a person would.
+
+Summary: {{.Summary}}
+
+Data (JSON):
+{{.JSON}}
+`
+
+// PromptData is what a prompt template can use
+type PromptData struct {
+	Date    string // The day, e.g. "Monday, January 2"
+	Summary string // The one-line summary, e.g. "5h 41m screen-on • 12 apps"
+	JSON    string // The same JSON as "rekap --json"
+}
+
+// Parse parses a prompt template. An empty prompt is DefaultPrompt.
+func Parse(prompt string) (*template.Template, error) {
+	if strings.TrimSpace(prompt) == "" {
+		prompt = DefaultPrompt
+	}
+	tmpl, err := template.New("prompt").Parse(prompt)
+	if err != nil {
+		return nil, fmt.Errorf("prompt template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render executes the prompt template against data
+func Render(prompt string, data PromptData) (string, error) {
+	tmpl, err := Parse(prompt)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("prompt template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// Run pipes prompt to command's stdin with /bin/sh -c and returns what it
+// prints, trimmed. On failure the command's stderr is part of the error so
+// a missing model or a stopped server is easy to spot.
+func Run(ctx context.Context, command, prompt string, timeout time.Duration) (string, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = os.Environ()
+	cmd.Stdin = strings.NewReader(prompt)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait on a model server the command may have started
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("%q timed out after %s", command, timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%q: %w: %s", command, err, msg)
+		}
+		return "", fmt.Errorf("%q: %w", command, err)
+	}
+
+	text := strings.TrimSpace(stdout.String())
+	if text == "" {
+		return "", fmt.Errorf("%q printed nothing", command)
+	}
+	return text, nil
+}
@@ -0,0 +1,64 @@
+package narrate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderDefaultPrompt(t *testing.T) {
+	t.Parallel()
+	got, err := Render("", PromptData{Date: "Monday, February 16", Summary: "5h screen-on", JSON: `{"version":"1"}`})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	for _, want := range []string{"Monday, February 16", "Summary: 5h screen-on", `{"version":"1"}`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderCustomPrompt(t *testing.T) {
+	t.Parallel()
+	got, err := Render("Recap {{.Date}}: {{.Summary}}", PromptData{Date: "today", Summary: "quiet"})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got != "Recap today: quiet" {
+		t.Errorf("Render = %q", got)
+	}
+	if _, err := Render("{{.Missing", PromptData{}); err == nil {
+		t.Error("Render accepted an unclosed action")
+	}
+}
+
+func TestRunPipesPrompt(t *testing.T) {
+	t.Parallel()
+	got, err := Run(context.Background(), `tr a-z A-Z`, "you focused\n", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got != "YOU FOCUSED" {
+		t.Errorf("Run = %q, want %q", got, "YOU FOCUSED")
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"echo model not found >&2; exit 1", "model not found"},
+		{"cat >/dev/null", "printed nothing"},
+		{"sleep 5", "timed out"},
+	}
+	for _, tt := range tests {
+		_, err := Run(context.Background(), tt.command, "prompt", 200*time.Millisecond)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Run(%q) error = %v, want %q", tt.command, err, tt.want)
+		}
+	}
+}
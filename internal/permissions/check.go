@@ -3,8 +3,9 @@ package permissions
 import (
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
+
+	"github.com/alexinslc/rekap/internal/compat"
 )
 
 // Capabilities represents the available permissions and capabilities
@@ -12,6 +13,7 @@ type Capabilities struct {
 	FullDiskAccess bool
 	Accessibility  bool
 	NowPlaying     bool
+	Calendar       bool
 }
 
 // Check returns the current permission status for all capabilities
@@ -20,17 +22,18 @@ func Check() Capabilities {
 		FullDiskAccess: checkFullDiskAccess(),
 		Accessibility:  checkAccessibility(),
 		NowPlaying:     checkNowPlaying(),
+		Calendar:       checkCalendar(),
 	}
 }
 
-// checkFullDiskAccess tests if we can read the Screen Time database
+// checkFullDiskAccess tests if we can read the per-user Screen Time database
 func checkFullDiskAccess() bool {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return false
 	}
 
-	dbPath := filepath.Join(homeDir, "Library", "Application Support", "Knowledge", "knowledgeC.db")
+	dbPath := compat.KnowledgeDBPaths(homeDir)[0]
 
 	// Try to open the file for reading
 	file, err := os.Open(dbPath)
@@ -96,6 +99,22 @@ func checkNowPlaying() bool {
 	return strings.TrimSpace(string(output)) == "true"
 }
 
+// checkCalendar tests if calendar events can be read, through icalBuddy or
+// EventKit's Calendars permission
+func checkCalendar() bool {
+	if _, err := exec.LookPath("icalBuddy"); err == nil {
+		return true
+	}
+	cmd := exec.Command("osascript", "-l", "JavaScript", "-e",
+		"ObjC.import('EventKit'); $.EKEventStore.authorizationStatusForEntityType($.EKEntityTypeEvent)")
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	// 3 is full access (EKAuthorizationStatusAuthorized)
+	return strings.TrimSpace(string(output)) == "3"
+}
+
 // GetCapabilitiesMatrix returns a map of capability names to status
 func GetCapabilitiesMatrix() map[string]bool {
 	caps := Check()
@@ -107,6 +126,7 @@ func GetCapabilitiesMatrix() map[string]bool {
 		"focus_streak":  caps.FullDiskAccess,
 		"accessibility": caps.Accessibility,
 		"media":         caps.NowPlaying,
+		"calendar":      caps.Calendar,
 	}
 }
 
@@ -139,5 +159,11 @@ func FormatCapabilities(caps Capabilities) string {
 		lines = append(lines, "✗ media           (Music app or nowplaying-cli)")
 	}
 
+	if caps.Calendar {
+		lines = append(lines, "✓ calendar        (meetings)")
+	} else {
+		lines = append(lines, "✗ calendar        (icalBuddy or Calendars access)")
+	}
+
 	return strings.Join(lines, "\n")
 }
@@ -1,7 +1,9 @@
 package permissions
 
 import (
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestCheck(t *testing.T) {
@@ -36,6 +38,7 @@ func TestGetCapabilitiesMatrix(t *testing.T) {
 		"focus_streak",
 		"accessibility",
 		"media",
+		"calendar",
 	}
 
 	for _, key := range expectedKeys {
@@ -65,3 +68,92 @@ func TestFormatCapabilities(t *testing.T) {
 		t.Logf("Output: %s", output)
 	}
 }
+
+func TestSettingsURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		major int
+		want  string
+	}{
+		{12, "x-apple.systempreferences:com.apple.preference.security?Privacy_AllFiles"},
+		{13, "x-apple.systempreferences:com.apple.settings.PrivacySecurity.extension?Privacy_AllFiles"},
+		{15, "x-apple.systempreferences:com.apple.settings.PrivacySecurity.extension?Privacy_AllFiles"},
+		{0, "x-apple.systempreferences:com.apple.settings.PrivacySecurity.extension?Privacy_AllFiles"},
+	}
+	for _, tt := range tests {
+		if got := SettingsURL(PaneFullDiskAccess, tt.major); got != tt.want {
+			t.Errorf("SettingsURL(macOS %d) = %q, want %q", tt.major, got, tt.want)
+		}
+	}
+}
+
+func TestTerminalFromEnv(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"TERM_PROGRAM": "Apple_Terminal"}, "Terminal"},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, "iTerm"},
+		{map[string]string{"TERM_PROGRAM": "WezTerm"}, "WezTerm"},
+		// Inside tmux TERM_PROGRAM is "tmux", but the bundle ID is inherited
+		{map[string]string{"TERM_PROGRAM": "tmux", "__CFBundleIdentifier": "com.googlecode.iterm2"}, "iTerm"},
+		{map[string]string{"TERM_PROGRAM": "tmux"}, ""},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := terminalFromEnv(getenv); got != tt.want {
+			t.Errorf("terminalFromEnv(%v) = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestAppFromPath(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"/Applications/iTerm.app/Contents/MacOS/iTerm2":                            "iTerm",
+		"/Applications/WezTerm.app/Contents/MacOS/wezterm-gui":                     "WezTerm",
+		"/System/Applications/Utilities/Terminal.app/Contents/MacOS/Terminal":      "Terminal",
+		"/Applications/Visual Studio Code.app/Contents/Frameworks/Code Helper.app": "Visual Studio Code",
+		"/bin/zsh": "",
+		"-zsh":     "",
+	}
+	for path, want := range tests {
+		if got := appFromPath(path); got != want {
+			t.Errorf("appFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestObserveLostAccess(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 2, 16, 9, 0, 0, 0, time.UTC)
+	var s AccessState
+
+	// Calendars never worked, so losing it isn't news
+	if lost := Observe(&s, map[string]bool{"Full Disk Access": true, "Calendars": false}, start); len(lost) != 0 {
+		t.Fatalf("first run lost = %v, want none", lost)
+	}
+
+	// Failing briefly, then past the grace period
+	if lost := Observe(&s, map[string]bool{"Full Disk Access": false}, start.Add(10*time.Minute)); len(lost) != 0 {
+		t.Errorf("lost inside grace = %v, want none", lost)
+	}
+	later := start.Add(10*time.Minute + LossGrace)
+	lost := Observe(&s, map[string]bool{"Full Disk Access": false, "Calendars": false}, later)
+	if !slices.Equal(lost, []string{"Full Disk Access"}) {
+		t.Errorf("lost after grace = %v, want [Full Disk Access]", lost)
+	}
+
+	// Reported once
+	if lost := Observe(&s, map[string]bool{"Full Disk Access": false}, later.Add(time.Hour)); len(lost) != 0 {
+		t.Errorf("lost again = %v, want none", lost)
+	}
+
+	// Recovering resets it, so a later loss is reported again
+	Observe(&s, map[string]bool{"Full Disk Access": true}, later.Add(2*time.Hour))
+	Observe(&s, map[string]bool{"Full Disk Access": false}, later.Add(3*time.Hour))
+	if lost := Observe(&s, map[string]bool{"Full Disk Access": false}, later.Add(5*time.Hour)); len(lost) != 1 {
+		t.Errorf("second loss = %v, want it reported", lost)
+	}
+}
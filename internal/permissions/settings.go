@@ -0,0 +1,57 @@
+package permissions
+
+import (
+	"os/exec"
+
+	"github.com/alexinslc/rekap/internal/compat"
+)
+
+// Pane is a Privacy & Security pane in System Settings
+type Pane string
+
+const (
+	PaneFullDiskAccess Pane = "Privacy_AllFiles"
+	PaneAccessibility  Pane = "Privacy_Accessibility"
+	PaneCalendars      Pane = "Privacy_Calendars"
+	PaneAutomation     Pane = "Privacy_Automation"
+)
+
+// Permission is one permission rekap can use, with a live check
+type Permission struct {
+	Name    string // As listed in System Settings
+	Enables string
+	Pane    Pane
+	Check   func() bool
+}
+
+// All returns the permissions rekap uses, most useful first
+func All() []Permission {
+	return []Permission{
+		{Name: "Full Disk Access", Enables: "App usage, screen time, focus streaks, notifications", Pane: PaneFullDiskAccess, Check: checkFullDiskAccess},
+		{Name: "Accessibility", Enables: "Frontmost app detection (fallback)", Pane: PaneAccessibility, Check: checkAccessibility},
+		{Name: "Calendars", Enables: "Meetings (not needed with icalBuddy)", Pane: PaneCalendars, Check: checkCalendar},
+		{Name: "Automation", Enables: "Now Playing from Music and Spotify", Pane: PaneAutomation, Check: checkNowPlaying},
+	}
+}
+
+// SettingsURL returns the URL that opens pane on the given major macOS
+// version. Ventura (13) replaced System Preferences with System Settings,
+// where the old URLs land on the top of Privacy & Security instead of the
+// pane. An unknown version (0) gets the current scheme.
+func SettingsURL(pane Pane, macOSMajor int) string {
+	if macOSMajor > 0 && macOSMajor < 13 {
+		return "x-apple.systempreferences:com.apple.preference.security?" + string(pane)
+	}
+	return "x-apple.systempreferences:com.apple.settings.PrivacySecurity.extension?" + string(pane)
+}
+
+// MacOSMajor returns the major macOS version, e.g. 14 for Sonoma, or 0
+// when it can't be read
+func MacOSMajor() int {
+	return compat.Current().Major
+}
+
+// OpenSettings opens pane in System Settings
+func OpenSettings(pane Pane) error {
+	return exec.Command("open", SettingsURL(pane, MacOSMajor())).Run()
+}
@@ -0,0 +1,87 @@
+package permissions
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// terminalBundles maps terminal bundle IDs to the names System Settings
+// lists them under
+var terminalBundles = map[string]string{
+	"com.apple.Terminal":     "Terminal",
+	"com.googlecode.iterm2":  "iTerm",
+	"com.github.wez.wezterm": "WezTerm",
+	"com.mitchellh.ghostty":  "Ghostty",
+	"net.kovidgoyal.kitty":   "kitty",
+	"org.alacritty":          "Alacritty",
+	"dev.warp.Warp-Stable":   "Warp",
+	"com.microsoft.VSCode":   "Visual Studio Code",
+}
+
+// termPrograms maps $TERM_PROGRAM values to the same names
+var termPrograms = map[string]string{
+	"Apple_Terminal": "Terminal",
+	"iTerm.app":      "iTerm",
+	"WezTerm":        "WezTerm",
+	"ghostty":        "Ghostty",
+	"WarpTerminal":   "Warp",
+	"vscode":         "Visual Studio Code",
+}
+
+// Terminal returns the name of the app macOS asks for rekap's permissions.
+// Privacy permissions belong to the app that started the process, so
+// they're granted to the terminal, not to rekap. It returns "" when the
+// terminal can't be told.
+func Terminal() string {
+	if name := terminalFromEnv(os.Getenv); name != "" {
+		return name
+	}
+	return terminalFromParents(os.Getppid())
+}
+
+// terminalFromEnv identifies the terminal from the bundle ID macOS sets for
+// processes an app launches, which tmux sessions and subshells inherit, and
+// then from $TERM_PROGRAM
+func terminalFromEnv(getenv func(string) string) string {
+	if name, ok := terminalBundles[getenv("__CFBundleIdentifier")]; ok {
+		return name
+	}
+	return termPrograms[getenv("TERM_PROGRAM")]
+}
+
+// terminalFromParents walks up the process tree to the first process
+// running from an app bundle, e.g. WezTerm's wezterm-gui helper or iTerm's
+// iTerm2 binary
+func terminalFromParents(pid int) string {
+	for range 20 {
+		if pid <= 1 {
+			return ""
+		}
+		out, err := exec.Command("ps", "-o", "ppid=", "-o", "comm=", "-p", strconv.Itoa(pid)).Output()
+		if err != nil {
+			return ""
+		}
+		ppid, comm, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+		if name := appFromPath(strings.TrimSpace(comm)); name != "" {
+			return name
+		}
+		if pid, err = strconv.Atoi(ppid); err != nil {
+			return ""
+		}
+	}
+	return ""
+}
+
+// appFromPath returns the outermost app bundle in path without ".app", so
+// "/Applications/iTerm.app/Contents/MacOS/iTerm2" is "iTerm"
+func appFromPath(path string) string {
+	for dir := range strings.SplitSeq(path, string(filepath.Separator)) {
+		if name, ok := strings.CutSuffix(dir, ".app"); ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package permissions
+
+import (
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/state"
+)
+
+// LossGrace is how long a permission has to keep failing before it counts
+// as lost, so a locked screen or a busy database doesn't raise a
+// notification
+const LossGrace = time.Hour
+
+// watchEvery keeps long-running commands from shelling out to osascript on
+// every tick
+const watchEvery = 10 * time.Minute
+
+// Access is what rekap remembers about one permission between runs
+type Access struct {
+	LastOK       time.Time `json:"last_ok,omitzero"`
+	FailingSince time.Time `json:"failing_since,omitzero"`
+	Notified     bool      `json:"notified,omitempty"`
+}
+
+// AccessState is the access record, keyed by permission name
+type AccessState struct {
+	CheckedAt time.Time         `json:"checked_at"`
+	Access    map[string]Access `json:"access"`
+}
+
+// Observe records which permissions worked at now and returns the names of
+// ones that used to work, have failed for at least LossGrace, and haven't
+// been reported yet. A permission that never worked is never reported:
+// that's a setup step, not lost data.
+func Observe(s *AccessState, working map[string]bool, now time.Time) []string {
+	if s.Access == nil {
+		s.Access = make(map[string]Access)
+	}
+	s.CheckedAt = now
+
+	var lost []string
+	for name, ok := range working {
+		a := s.Access[name]
+		switch {
+		case ok:
+			a = Access{LastOK: now}
+		case a.LastOK.IsZero():
+		case a.FailingSince.IsZero():
+			a.FailingSince = now
+		case !a.Notified && now.Sub(a.FailingSince) >= LossGrace:
+			a.Notified = true
+			lost = append(lost, name)
+		}
+		s.Access[name] = a
+	}
+	slices.Sort(lost)
+	return lost
+}
+
+// watched are the permissions checked for lost access. Automation is left
+// out because checking it can launch Music.
+func watched() []Permission {
+	return slices.DeleteFunc(All(), func(p Permission) bool { return p.Pane == PaneAutomation })
+}
+
+// WatchAccess checks the watched permissions, at most every 10 minutes,
+// and raises one desktop notification for each that stopped working,
+// typically because a macOS update reset privacy permissions. It's meant
+// for long-running commands, where an empty section could otherwise go
+// unnoticed for weeks.
+func WatchAccess(now time.Time) {
+	var s AccessState
+	if _, _, err := state.LoadLatest(state.KindAccess, &s); err != nil {
+		return
+	}
+	if now.Sub(s.CheckedAt) < watchEvery {
+		return
+	}
+
+	working := make(map[string]bool)
+	for _, p := range watched() {
+		working[p.Name] = p.Check()
+	}
+	for _, name := range Observe(&s, working, now) {
+		_ = notify("rekap lost "+name, name+" stopped working, often after a macOS update. Run 'rekap init' to grant it again.")
+	}
+	// Saved under today so the record survives state cleanup
+	_ = state.Save(state.KindAccess, now.Format("2006-01-02"), s)
+}
+
+// notify shows a desktop notification. The text is passed as arguments so
+// it needs no AppleScript quoting.
+func notify(title, message string) error {
+	return exec.Command("osascript",
+		"-e", "on run argv",
+		"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+		"-e", "end run",
+		title, message).Run()
+}
@@ -0,0 +1,66 @@
+package plan
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// scriptableBrowsers maps browser names to the AppleScript applications
+// whose tabs can be closed. Firefox has no AppleScript dictionary.
+var scriptableBrowsers = map[string]string{
+	"Chrome": "Google Chrome",
+	"Edge":   "Microsoft Edge",
+	"Safari": "Safari",
+}
+
+// closeDuplicateTabs closes every tab whose URL is already open in another
+// tab of app, keeping one copy of each page
+func closeDuplicateTabs(app string) (string, error) {
+	script := fmt.Sprintf(`
+tell application "%s"
+	if it is not running then return 0
+	set seen to {}
+	set closed to 0
+	repeat with w in windows
+		repeat with i from (count of tabs of w) to 1 by -1
+			set u to URL of tab i of w
+			if seen contains u then
+				close tab i of w
+				set closed to closed + 1
+			else
+				set end of seen to u
+			end if
+		end repeat
+	end repeat
+	return closed
+end tell
+`, app)
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return "", fmt.Errorf("couldn't reach %s (allow Automation for your terminal): %w", app, err)
+	}
+	n := strings.TrimSpace(string(out))
+	if n == "0" {
+		return "No duplicate tabs in " + app, nil
+	}
+	return fmt.Sprintf("Closed %s duplicate tab(s) in %s", n, app), nil
+}
+
+// hideApps hides the named apps' windows, which Cmd-Tab brings back.
+// Names are passed as arguments so they never need escaping.
+func hideApps(names []string) (string, error) {
+	args := []string{
+		"-e", "on run argv",
+		"-e", "tell application \"System Events\"",
+		"-e", "repeat with n in argv",
+		"-e", "if exists process (n as text) then set visible of process (n as text) to false",
+		"-e", "end repeat",
+		"-e", "end tell",
+		"-e", "end run",
+	}
+	if err := exec.Command("osascript", append(args, names...)...).Run(); err != nil {
+		return "", fmt.Errorf("couldn't hide apps (allow Accessibility for your terminal): %w", err)
+	}
+	return fmt.Sprintf("Hid %d app(s); Cmd-Tab brings them back", len(names)), nil
+}
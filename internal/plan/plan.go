@@ -0,0 +1,177 @@
+// Package plan turns the drivers of the fragmentation score into a ranked
+// list of concrete actions, for "rekap plan". A few actions come with a fix
+// rekap can carry out itself; fixes only do things that are easy to undo.
+package plan
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+// Action is one step toward a less fragmented day
+type Action struct {
+	Factor string  // The fragmentation factor it addresses
+	Points float64 // That factor's contribution to the score
+	Text   string
+	Fix    *Fix // nil when rekap can't do it for you
+}
+
+// Fix is a safe action rekap can take when asked
+type Fix struct {
+	Prompt string // Asked before running, e.g. "Close duplicate tabs in Chrome?"
+	Run    func() (string, error)
+}
+
+// rarelyUsedMinutes is how little use today makes an app worth quitting
+const rarelyUsedMinutes = 5
+
+// maxNamed is how many apps or domains an action names before "and N more"
+const maxNamed = 4
+
+// Build returns actions for every factor that added to today's score, the
+// biggest contributor first
+func Build(data *summary.Data) []Action {
+	if !data.Fragmentation.Available {
+		return nil
+	}
+
+	factors := data.Fragmentation.Breakdown.Factors()
+	slices.SortStableFunc(factors, func(a, b collectors.FragmentationFactor) int {
+		return cmp.Compare(b.Points, a.Points)
+	})
+
+	var actions []Action
+	for _, f := range factors {
+		if f.Points <= 0 {
+			continue
+		}
+		var factorActions []Action
+		switch f.Name {
+		case collectors.FactorTabs:
+			factorActions = tabActions(data.Browsers, int(f.Low))
+		case collectors.FactorDomains:
+			factorActions = domainActions(data.Browsers, int(f.Low))
+		case collectors.FactorApps:
+			factorActions = appActions(data.Apps)
+		case collectors.FactorSwitches:
+			factorActions = switchActions(data.Fragmentation.Breakdown, data.Notifications)
+		}
+		for _, a := range factorActions {
+			a.Factor, a.Points = f.Name, f.Points
+			actions = append(actions, a)
+		}
+	}
+	return actions
+}
+
+// tabActions asks for tabs to be closed until no more than limit are open,
+// starting with the browser holding the most
+func tabActions(b collectors.BrowsersResult, limit int) []Action {
+	browsers := []collectors.BrowserResult{b.Chrome, b.Safari, b.Edge, b.Firefox}
+	slices.SortStableFunc(browsers, func(x, y collectors.BrowserResult) int { return cmp.Compare(y.TabCount, x.TabCount) })
+
+	var actions []Action
+	excess := b.TotalTabs - limit
+	for _, br := range browsers {
+		if excess <= 0 || br.TabCount == 0 {
+			break
+		}
+		n := min(excess, br.TabCount)
+		excess -= n
+		a := Action{Text: fmt.Sprintf("Close %d stale %s in %s (%d open)", n, plural(n, "tab"), br.Browser, br.TabCount)}
+		if app := scriptableBrowsers[br.Browser]; app != "" {
+			a.Fix = &Fix{
+				Prompt: fmt.Sprintf("Close duplicate tabs in %s now?", br.Browser),
+				Run:    func() (string, error) { return closeDuplicateTabs(app) },
+			}
+		}
+		actions = append(actions, a)
+	}
+
+	if domain, n := topTabDomain(b.TopDomains); n >= 3 {
+		actions = append(actions, Action{Text: fmt.Sprintf("Bookmark the %d %s tabs as a group and close them", n, domain)})
+	}
+	return actions
+}
+
+// domainActions asks for the sites with a single open tab to be closed,
+// since each is a separate context to come back to
+func domainActions(b collectors.BrowsersResult, limit int) []Action {
+	var single []string
+	for domain, n := range b.TopDomains {
+		if n == 1 {
+			single = append(single, domain)
+		}
+	}
+	slices.Sort(single)
+	if len(single) == 0 {
+		return []Action{{Text: fmt.Sprintf("Narrow your open tabs to %d sites or fewer (%d now)", limit, len(b.TopDomains))}}
+	}
+	return []Action{{Text: fmt.Sprintf("Close the %d %s that are alone on their site: %s",
+		len(single), plural(len(single), "tab"), nameList(single))}}
+}
+
+// appActions asks for apps barely used today to be quit
+func appActions(apps collectors.AppsResult) []Action {
+	var rare []string
+	for _, app := range apps.TopApps {
+		if app.Minutes < rarelyUsedMinutes {
+			rare = append(rare, app.Name)
+		}
+	}
+	if len(rare) == 0 {
+		return []Action{{Text: fmt.Sprintf("Work in fewer apps at once: you used %d today", len(apps.TopApps))}}
+	}
+	return []Action{{
+		Text: fmt.Sprintf("Quit the %d %s you used less than %d minutes today: %s",
+			len(rare), plural(len(rare), "app"), rarelyUsedMinutes, nameList(rare)),
+		Fix: &Fix{
+			Prompt: fmt.Sprintf("Hide %s now?", nameList(rare)),
+			Run:    func() (string, error) { return hideApps(rare) },
+		},
+	}}
+}
+
+// switchActions suggests batching whatever pulls you away most, the app
+// sending the most notifications when that's known
+func switchActions(b collectors.FragmentationBreakdown, notifications collectors.NotificationsResult) []Action {
+	if notifications.Available && len(notifications.TopApps) > 0 {
+		top := notifications.TopApps[0]
+		return []Action{{Text: fmt.Sprintf("Batch %s checks to 3 windows and turn on a Focus between them (%d %s today)",
+			top.Name, top.Count, plural(top.Count, "notification"))}}
+	}
+	return []Action{{Text: fmt.Sprintf("Batch chat and email to 3 windows: you switched apps %.0f times an hour", b.AppSwitchesPerHour)}}
+}
+
+// topTabDomain returns the domain with the most open tabs, breaking ties
+// alphabetically
+func topTabDomain(domains map[string]int) (string, int) {
+	var top string
+	var most int
+	for domain, n := range domains {
+		if n > most || (n == most && domain < top) {
+			top, most = domain, n
+		}
+	}
+	return top, most
+}
+
+// nameList joins names for a sentence, eliding past maxNamed
+func nameList(names []string) string {
+	if len(names) <= maxNamed {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s, and %d more", strings.Join(names[:maxNamed], ", "), len(names)-maxNamed)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
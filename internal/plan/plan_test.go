@@ -0,0 +1,86 @@
+package plan
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	data := summary.Data{
+		Apps: collectors.AppsResult{
+			Available: true,
+			TopApps: []collectors.AppUsage{
+				{Name: "VS Code", Minutes: 180}, {Name: "Slack", Minutes: 40}, {Name: "Terminal", Minutes: 30},
+				{Name: "Preview", Minutes: 2}, {Name: "Calculator", Minutes: 1},
+			},
+		},
+		Browsers: collectors.BrowsersResult{
+			Available: true,
+			Chrome:    collectors.BrowserResult{Browser: "Chrome", TabCount: 40},
+			Firefox:   collectors.BrowserResult{Browser: "Firefox", TabCount: 5},
+			TotalTabs: 45,
+			TopDomains: map[string]int{
+				"github.com": 30, "docs.go.dev": 12, "a.com": 1, "b.com": 1,
+			},
+		},
+		Notifications: collectors.NotificationsResult{
+			Available: true,
+			TopApps:   []collectors.NotificationApp{{Name: "Slack", Count: 31}},
+		},
+	}
+	data.Apps.SwitchingAvailable, data.Apps.SwitchesPerHour = true, 6
+	data.Fragmentation = collectors.CalculateFragmentation(context.Background(), data.Apps, data.Browsers,
+		collectors.UptimeResult{}, collectors.DefaultFragmentationThresholds())
+
+	actions := Build(&data)
+	if len(actions) == 0 {
+		t.Fatal("no actions")
+	}
+
+	// Tabs max out at 25 points and apps reach 10, so tabs come first
+	if actions[0].Factor != collectors.FactorTabs || actions[0].Text != "Close 35 stale tabs in Chrome (40 open)" {
+		t.Errorf("first action = %+v", actions[0])
+	}
+	if actions[0].Fix == nil {
+		t.Error("Chrome tabs have no fix")
+	}
+	for i := 1; i < len(actions); i++ {
+		if actions[i].Points > actions[i-1].Points {
+			t.Errorf("actions not ranked: %v before %v", actions[i-1], actions[i])
+		}
+	}
+
+	var texts []string
+	for _, a := range actions {
+		texts = append(texts, a.Text)
+	}
+	all := strings.Join(texts, "\n")
+	for _, want := range []string{
+		"Bookmark the 30 github.com tabs as a group and close them",
+		"Batch Slack checks to 3 windows and turn on a Focus between them (31 notifications today)",
+		"Quit the 2 apps you used less than 5 minutes today: Preview, Calculator",
+	} {
+		if !strings.Contains(all, want) {
+			t.Errorf("missing %q in:\n%s", want, all)
+		}
+	}
+	// Firefox isn't scriptable, and the excess was all in Chrome
+	if strings.Contains(all, "in Firefox") {
+		t.Errorf("asked to close Firefox tabs though Chrome covers the excess:\n%s", all)
+	}
+}
+
+func TestBuildFocused(t *testing.T) {
+	t.Parallel()
+	data := summary.Data{Apps: collectors.AppsResult{Available: true, TopApps: []collectors.AppUsage{{Name: "VS Code", Minutes: 300}}}}
+	data.Fragmentation = collectors.CalculateFragmentation(context.Background(), data.Apps, data.Browsers,
+		collectors.UptimeResult{}, collectors.DefaultFragmentationThresholds())
+	if actions := Build(&data); len(actions) != 0 {
+		t.Errorf("focused day got actions: %+v", actions)
+	}
+}
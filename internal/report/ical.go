@@ -0,0 +1,77 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// icalTime is the UTC date-time form used for DTSTART, DTEND, and DTSTAMP
+const icalTime = "20060102T150405Z"
+
+// WriteICal writes blocks as an iCalendar file with one VEVENT per block.
+// Events are transparent so they overlay a calendar without showing as
+// busy, and their UIDs depend only on the block's kind and start, so
+// importing the same day again updates events rather than duplicating them.
+func WriteICal(w io.Writer, blocks []collectors.WorkBlock, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(foldICalLine(s))
+		bw.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//alexinslc//rekap//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:rekap")
+	for _, b := range blocks {
+		summary, category := "Deep work: "+b.App, "Deep work"
+		if b.Kind == collectors.BlockMeeting {
+			summary, category = "Meeting: "+b.App, "Meeting"
+		}
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%s-%d@rekap", b.Kind, b.Start.Unix()))
+		line("DTSTAMP:" + stamp.UTC().Format(icalTime))
+		line("DTSTART:" + b.Start.UTC().Format(icalTime))
+		line("DTEND:" + b.End.UTC().Format(icalTime))
+		line("SUMMARY:" + escapeICalText(summary))
+		line("DESCRIPTION:" + escapeICalText(fmt.Sprintf("%s in %s, recorded by rekap", ui.FormatDuration(b.Minutes()), b.App)))
+		line("CATEGORIES:" + category)
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeICalText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits lines longer than 75 octets, continuing each piece
+// on a line starting with a space. Multi-byte characters aren't split.
+func foldICalLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		n := len(string(r))
+		if width+n > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
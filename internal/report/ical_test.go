@@ -0,0 +1,59 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+)
+
+func TestWriteICal(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 2, 18, 9, 0, 0, 0, time.UTC)
+	blocks := []collectors.WorkBlock{
+		{Kind: collectors.BlockDeepWork, App: "Code", Period: collectors.Period{Start: start, End: start.Add(50 * time.Minute)}},
+		{Kind: collectors.BlockMeeting, App: "zoom.us", Period: collectors.Period{Start: start.Add(time.Hour), End: start.Add(90 * time.Minute)}},
+	}
+
+	var b strings.Builder
+	if err := WriteICal(&b, blocks, start); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:deep_work-1771405200@rekap\r\n",
+		"DTSTART:20260218T090000Z\r\nDTEND:20260218T095000Z\r\n",
+		"SUMMARY:Deep work: Code\r\n",
+		"SUMMARY:Meeting: zoom.us\r\n",
+		"DESCRIPTION:30m in zoom.us\\, recorded by rekap\r\n",
+		"TRANSP:TRANSPARENT\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("got %d events, want 2", n)
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	t.Parallel()
+	long := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := foldICalLine(long)
+	for _, l := range strings.Split(folded, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line of %d octets: %q", len(l), l)
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != long {
+		t.Errorf("unfolded = %q, want %q", got, long)
+	}
+	if got := foldICalLine("SUMMARY:short"); got != "SUMMARY:short" {
+		t.Errorf("short line changed: %q", got)
+	}
+}
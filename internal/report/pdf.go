@@ -0,0 +1,269 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// Page geometry in PDF points (US Letter, 0.75" margins)
+const (
+	pdfPageWidth  = 612.0
+	pdfPageHeight = 792.0
+	pdfMargin     = 54.0
+	pdfFooterY    = 30.0
+)
+
+// pdfDocument is a minimal text-only PDF writer using the built-in
+// Helvetica fonts, so no font files or external tools are needed.
+// It starts a new page whenever the next line would not fit.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64 // Baseline of the next line on the current page
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// ensure starts a new page unless height points fit above the bottom margin
+func (d *pdfDocument) ensure(height float64) {
+	if d.y-height < pdfMargin {
+		d.newPage()
+	}
+}
+
+// text draws s with its baseline at (x, y)
+func (d *pdfDocument) text(x, y float64, bold bool, size float64, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// line writes a single line of text at the left margin and advances
+func (d *pdfDocument) line(bold bool, size float64, s string) {
+	leading := size * 1.4
+	d.ensure(leading)
+	d.y -= leading
+	d.text(pdfMargin, d.y, bold, size, s)
+}
+
+// row writes cells at the given x offsets from the left margin and advances
+func (d *pdfDocument) row(bold bool, size float64, offsets []float64, cells []string) {
+	leading := size * 1.5
+	d.ensure(leading)
+	d.y -= leading
+	for i, cell := range cells {
+		if i < len(offsets) {
+			d.text(pdfMargin+offsets[i], d.y, bold, size, cell)
+		}
+	}
+}
+
+// rule draws a thin horizontal line across the text area
+func (d *pdfDocument) rule() {
+	d.ensure(10)
+	d.y -= 6
+	fmt.Fprintf(d.page(), "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n",
+		pdfMargin, d.y, pdfPageWidth-pdfMargin, d.y)
+	d.y -= 4
+}
+
+func (d *pdfDocument) space(height float64) {
+	d.y -= height
+}
+
+// writeTo serializes the document, adding a footer to every page
+func (d *pdfDocument) writeTo(w io.Writer, footer string) error {
+	var out bytes.Buffer
+	var offsets []int
+
+	beginObj := func() int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", id)
+		return id
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Fixed objects: 1 catalog, 2 page tree, 3-4 fonts. Pages follow in
+	// pairs (page, content stream) starting at object 5.
+	pageIDs := make([]int, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = 5 + i*2
+	}
+
+	beginObj()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	beginObj()
+	kids := make([]string, len(pageIDs))
+	for i, id := range pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(pageIDs))
+
+	beginObj()
+	out.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+	beginObj()
+	out.WriteString("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	for i, page := range d.pages {
+		content := page.String()
+		if footer != "" {
+			label := fmt.Sprintf("%s  |  Page %d of %d", footer, i+1, len(d.pages))
+			content += fmt.Sprintf("0.4 g BT /F1 8.0 Tf %.2f %.2f Td (%s) Tj ET 0 g\n", pdfMargin, pdfFooterY, pdfEscape(label))
+		}
+
+		pageID := beginObj()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pdfPageWidth, pdfPageHeight, pageID+1)
+
+		beginObj()
+		fmt.Fprintf(&out, "<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// winAnsiExtras maps common punctuation outside Latin-1 to WinAnsiEncoding
+var winAnsiExtras = map[rune]byte{
+	'•': 0x95,
+	'–': 0x96,
+	'—': 0x97,
+	'‘': 0x91,
+	'’': 0x92,
+	'“': 0x93,
+	'”': 0x94,
+	'…': 0x85,
+}
+
+// pdfEscape encodes s as a WinAnsi PDF string body. Characters the
+// built-in fonts cannot show (emoji, CJK) become "?".
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			if c, ok := winAnsiExtras[r]; ok {
+				b.WriteByte(c)
+			} else if r == '\t' || r == '\n' {
+				b.WriteByte(' ')
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
+
+// pdfNoteWidth is how many characters of a note fit beside its day label
+const pdfNoteWidth = 80
+
+// wrapRunes breaks s into lines of at most n runes, between words where it
+// can and inside a word longer than a whole line
+func wrapRunes(s string, n int) []string {
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(s) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) > n {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		for len(w) > n {
+			lines = append(lines, string(w[:n]))
+			w = w[n:]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, w...)
+	}
+	return append(lines, string(line))
+}
+
+// dailyColumnOffsets positions DailyColumns across the text area
+var dailyColumnOffsets = []float64{0, 90, 150, 210, 270, 320}
+
+// WritePDF renders the weekly report as a paginated, print-friendly PDF
+func WritePDF(w io.Writer, week Week) error {
+	d := newPDFDocument()
+
+	d.line(true, 20, "rekap weekly report")
+	d.line(false, 12, week.Title())
+	d.rule()
+
+	d.space(8)
+	d.line(true, 13, "Overview")
+	for _, l := range week.Overview() {
+		d.row(false, 10.5, []float64{0, 120}, []string{l.Label, l.Value})
+	}
+
+	if len(week.TopApps) > 0 {
+		d.space(12)
+		d.line(true, 13, "Top Apps")
+		for i, app := range week.TopApps {
+			d.row(false, 10.5, []float64{0, 20, 260},
+				[]string{fmt.Sprintf("%d.", i+1), app.Name, ui.FormatDuration(app.Minutes)})
+		}
+	}
+
+	d.space(12)
+	d.line(true, 13, "Daily Breakdown")
+	d.row(true, 9.5, dailyColumnOffsets, DailyColumns)
+	d.rule()
+	for _, row := range week.DailyRows() {
+		d.row(false, 9.5, dailyColumnOffsets, row)
+	}
+
+	if notes := week.NoteLines(); len(notes) > 0 {
+		d.space(12)
+		d.line(true, 13, "Notes")
+		for _, l := range notes {
+			// Long notes continue on rows of their own under the first
+			for i, text := range wrapRunes(l.Value, pdfNoteWidth) {
+				label := l.Label
+				if i > 0 {
+					label = ""
+				}
+				d.row(false, 10.5, []float64{0, 90}, []string{label, text})
+			}
+		}
+	}
+
+	return d.writeTo(w, "rekap weekly report • "+week.Title())
+}
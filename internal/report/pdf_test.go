@@ -0,0 +1,113 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWritePDF(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, testWeek()); err != nil {
+		t.Fatalf("WritePDF: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "%PDF-1.4") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatal("missing PDF header or trailer")
+	}
+	if !strings.Contains(out, "/Count 1") {
+		t.Error("expected a single page for one week of data")
+	}
+	if !strings.Contains(out, "(Daily Breakdown)") {
+		t.Error("expected daily breakdown heading")
+	}
+	checkXref(t, out)
+}
+
+func TestPDFPagination(t *testing.T) {
+	t.Parallel()
+	d := newPDFDocument()
+	for i := 0; i < 120; i++ {
+		d.line(false, 11, fmt.Sprintf("line %d", i))
+	}
+
+	var buf bytes.Buffer
+	if err := d.writeTo(&buf, "footer"); err != nil {
+		t.Fatalf("writeTo: %v", err)
+	}
+	out := buf.String()
+
+	if len(d.pages) < 2 {
+		t.Fatalf("got %d pages, want at least 2", len(d.pages))
+	}
+	if !strings.Contains(out, fmt.Sprintf("/Count %d", len(d.pages))) {
+		t.Errorf("page count not recorded in page tree")
+	}
+	if !strings.Contains(out, fmt.Sprintf("Page %d of %d", len(d.pages), len(d.pages))) {
+		t.Errorf("missing footer on last page")
+	}
+	checkXref(t, out)
+}
+
+// checkXref verifies every xref entry points at the start of its object
+func checkXref(t *testing.T, out string) {
+	t.Helper()
+	start := strings.LastIndex(out, "startxref\n")
+	if start < 0 {
+		t.Fatal("missing startxref")
+	}
+	xrefOffset, err := strconv.Atoi(strings.Fields(out[start+len("startxref\n"):])[0])
+	if err != nil || !strings.HasPrefix(out[xrefOffset:], "xref\n") {
+		t.Fatalf("startxref does not point at xref table")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[xrefOffset:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !strings.HasPrefix(out[off:], want) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, out[off:off+len(want)], want)
+		}
+	}
+}
+
+func TestWrapRunes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"a note", []string{"a note"}},
+		{"one two three four", []string{"one two", "three", "four"}},
+		{"a unbreakable", []string{"a", "unbreaka", "ble"}},
+		{"", []string{""}},
+	}
+	for _, tt := range tests {
+		if got := wrapRunes(tt.in, 8); !slices.Equal(got, tt.want) {
+			t.Errorf("wrapRunes(%q, 8) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPDFEscape(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"plain":      "plain",
+		"a (b) c\\d": `a \(b\) c\\d`,
+		"Café":       "Caf\xe9",
+		"a • b":      "a \x95 b",
+		"🎵 Spotify":  "? Spotify",
+		"日本":         "??",
+	}
+	for in, want := range tests {
+		if got := pdfEscape(in); got != want {
+			t.Errorf("pdfEscape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WritePlist writes v as an XML property list, the format the Shortcuts
+// "Get Dictionary from Input" action and AppleScript read natively. v goes
+// through encoding/json first, so keys and omitted fields match the JSON
+// output exactly. Nulls have no plist form and are left out.
+func WritePlist(w io.Writer, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("plist encode error: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("plist encode error: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	bw.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	bw.WriteString(`<plist version="1.0">` + "\n")
+	writePlistValue(bw, value, 0)
+	bw.WriteString("</plist>\n")
+	return bw.Flush()
+}
+
+// writePlistValue writes one decoded JSON value at the given depth
+func writePlistValue(w *bufio.Writer, v any, depth int) {
+	indent := strings.Repeat("\t", depth)
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			w.WriteString(indent + "<dict/>\n")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.WriteString(indent + "<dict>\n")
+		for _, k := range keys {
+			if v[k] == nil {
+				continue
+			}
+			w.WriteString(indent + "\t<key>" + plistEscape(k) + "</key>\n")
+			writePlistValue(w, v[k], depth+1)
+		}
+		w.WriteString(indent + "</dict>\n")
+	case []any:
+		if len(v) == 0 {
+			w.WriteString(indent + "<array/>\n")
+			return
+		}
+		w.WriteString(indent + "<array>\n")
+		for _, item := range v {
+			if item != nil {
+				writePlistValue(w, item, depth+1)
+			}
+		}
+		w.WriteString(indent + "</array>\n")
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			w.WriteString(indent + "<integer>" + v.String() + "</integer>\n")
+		} else {
+			w.WriteString(indent + "<real>" + v.String() + "</real>\n")
+		}
+	case bool:
+		if v {
+			w.WriteString(indent + "<true/>\n")
+		} else {
+			w.WriteString(indent + "<false/>\n")
+		}
+	case string:
+		w.WriteString(indent + "<string>" + plistEscape(v) + "</string>\n")
+	}
+}
+
+func plistEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWritePlist(t *testing.T) {
+	t.Parallel()
+	type app struct {
+		Name    string `json:"name"`
+		Minutes int    `json:"minutes"`
+	}
+	v := struct {
+		Date    string   `json:"date"`
+		Rate    float64  `json:"rate"`
+		Plugged bool     `json:"plugged"`
+		Apps    []app    `json:"apps"`
+		Missing *app     `json:"missing"`
+		Tags    []string `json:"tags"`
+	}{
+		Date:    "2026-02-18",
+		Rate:    4.5,
+		Plugged: true,
+		Apps:    []app{{Name: "R&D <tool>", Minutes: 42}},
+		Tags:    []string{},
+	}
+
+	var b strings.Builder
+	if err := WritePlist(&b, v); err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>apps</key>
+	<array>
+		<dict>
+			<key>minutes</key>
+			<integer>42</integer>
+			<key>name</key>
+			<string>R&amp;D &lt;tool&gt;</string>
+		</dict>
+	</array>
+	<key>date</key>
+	<string>2026-02-18</string>
+	<key>plugged</key>
+	<true/>
+	<key>rate</key>
+	<real>4.5</real>
+	<key>tags</key>
+	<array/>
+</dict>
+</plist>
+`
+	if got := b.String(); got != want {
+		t.Errorf("WritePlist =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,249 @@
+// Package report builds multi-day reports from the history store.
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/summary"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// weekTopApps is how many apps the weekly report lists
+const weekTopApps = 5
+
+// Week aggregates the daily snapshots of a 7-day period
+type Week struct {
+	Start time.Time // First day of the period
+	End   time.Time // Last day of the period (inclusive)
+	Days  []Day     // One entry per day, oldest first
+
+	DaysRecorded       int
+	TotalAwakeMinutes  int
+	TotalScreenMinutes int
+	TotalNotifications int
+	TotalSwitches      int
+	TotalBytesReceived int64
+	TotalBytesSent     int64
+	BurnoutWarnings    int
+	AvgFragmentation   int
+
+	TotalLearningMinutes int
+	LearningGoalMinutes  int // From learning.weekly_goal_hours; 0 when unset
+
+	LongestFocusMinutes int
+	LongestFocusApp     string
+	LongestFocusDate    string
+
+	TopApps []history.AppUsage // Summed across the week, most used first
+}
+
+// Day is one day of the report period
+type Day struct {
+	Date     time.Time
+	Recorded bool // false when no snapshot exists for the date
+	Summary  history.DaySummary
+	Notes    []summary.Note // Notes added with "rekap note", oldest first
+}
+
+// Line is a label/value pair shared by the terminal and PDF renderers
+type Line struct {
+	Label string
+	Value string
+}
+
+// WeekRange returns the YYYY-MM-DD bounds of the 7 days ending on end
+func WeekRange(end time.Time) (from, to string) {
+	return end.AddDate(0, 0, -6).Format("2006-01-02"), end.Format("2006-01-02")
+}
+
+// BuildWeek aggregates snapshots for the 7 days ending on end
+func BuildWeek(end time.Time, snapshots []history.DaySummary) Week {
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	week := Week{Start: end.AddDate(0, 0, -6), End: end}
+
+	byDate := make(map[string]history.DaySummary, len(snapshots))
+	for _, s := range snapshots {
+		byDate[s.Date] = s
+	}
+
+	appMinutes := make(map[string]int)
+	fragTotal, fragDays := 0, 0
+
+	for d := week.Start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		day, ok := byDate[date]
+		week.Days = append(week.Days, Day{Date: d, Recorded: ok, Summary: day})
+		if !ok {
+			continue
+		}
+		week.DaysRecorded++
+
+		week.TotalAwakeMinutes += day.AwakeMinutes
+		week.TotalScreenMinutes += day.ScreenOnMinutes
+		week.TotalNotifications += day.Notifications
+		week.TotalSwitches += day.AppSwitches
+		week.TotalBytesReceived += day.BytesReceived
+		week.TotalBytesSent += day.BytesSent
+		week.BurnoutWarnings += day.BurnoutWarnings
+		week.TotalLearningMinutes += day.LearningMinutes
+
+		if day.FragmentationScore > 0 {
+			fragTotal += day.FragmentationScore
+			fragDays++
+		}
+		if day.FocusStreakMinutes > week.LongestFocusMinutes {
+			week.LongestFocusMinutes = day.FocusStreakMinutes
+			week.LongestFocusApp = day.FocusApp
+			week.LongestFocusDate = date
+		}
+		for _, app := range day.TopApps {
+			appMinutes[app.Name] += app.Minutes
+		}
+	}
+
+	if fragDays > 0 {
+		week.AvgFragmentation = fragTotal / fragDays
+	}
+
+	for name, minutes := range appMinutes {
+		week.TopApps = append(week.TopApps, history.AppUsage{Name: name, Minutes: minutes})
+	}
+	sort.Slice(week.TopApps, func(i, j int) bool {
+		if week.TopApps[i].Minutes != week.TopApps[j].Minutes {
+			return week.TopApps[i].Minutes > week.TopApps[j].Minutes
+		}
+		return week.TopApps[i].Name < week.TopApps[j].Name
+	})
+	if len(week.TopApps) > weekTopApps {
+		week.TopApps = week.TopApps[:weekTopApps]
+	}
+
+	return week
+}
+
+// Title returns the date range heading, e.g. "Feb 12 – Feb 18, 2026"
+func (w Week) Title() string {
+	loc := locale.Current()
+	if w.Start.Year() != w.End.Year() {
+		return fmt.Sprintf("%s – %s", loc.MonthDayYear(w.Start), loc.MonthDayYear(w.End))
+	}
+	return fmt.Sprintf("%s – %s", loc.MonthDay(w.Start), loc.MonthDayYear(w.End))
+}
+
+// Overview returns the headline numbers for the week
+func (w Week) Overview() []Line {
+	lines := []Line{
+		{"Days recorded", fmt.Sprintf("%d of 7", w.DaysRecorded)},
+	}
+	if w.DaysRecorded == 0 {
+		return lines
+	}
+
+	lines = append(lines,
+		Line{"Screen time", fmt.Sprintf("%s total • %s/day avg",
+			ui.FormatDuration(w.TotalScreenMinutes), ui.FormatDuration(w.TotalScreenMinutes/w.DaysRecorded))},
+		Line{"Awake time", fmt.Sprintf("%s/day avg", ui.FormatDuration(w.TotalAwakeMinutes/w.DaysRecorded))},
+	)
+	if w.LongestFocusMinutes > 0 {
+		focus := ui.FormatDuration(w.LongestFocusMinutes)
+		if w.LongestFocusApp != "" {
+			focus += " in " + w.LongestFocusApp
+		}
+		if date, err := time.Parse("2006-01-02", w.LongestFocusDate); err == nil {
+			focus += " (" + locale.Current().ShortDate(date) + ")"
+		}
+		lines = append(lines, Line{"Longest focus", focus})
+	}
+	lines = append(lines,
+		Line{"Notifications", fmt.Sprintf("%d total • %d/day avg", w.TotalNotifications, w.TotalNotifications/w.DaysRecorded)},
+		Line{"App switches", fmt.Sprintf("%d total", w.TotalSwitches)},
+	)
+	if w.TotalLearningMinutes > 0 || w.LearningGoalMinutes > 0 {
+		lines = append(lines, Line{"Learning", w.learningText()})
+	}
+	if w.AvgFragmentation > 0 {
+		lines = append(lines, Line{"Fragmentation", fmt.Sprintf("%d/100 avg", w.AvgFragmentation)})
+	}
+	if w.TotalBytesReceived > 0 || w.TotalBytesSent > 0 {
+		lines = append(lines, Line{"Network", fmt.Sprintf("%s down / %s up",
+			collectors.FormatBytes(w.TotalBytesReceived), collectors.FormatBytes(w.TotalBytesSent))})
+	}
+	if w.BurnoutWarnings > 0 {
+		lines = append(lines, Line{"Burnout warnings", fmt.Sprintf("%d", w.BurnoutWarnings)})
+	}
+	return lines
+}
+
+// learningText describes the week's learning time and, with a goal set,
+// progress toward it
+func (w Week) learningText() string {
+	text := ui.FormatDuration(w.TotalLearningMinutes)
+	if w.LearningGoalMinutes <= 0 {
+		return text
+	}
+	text += fmt.Sprintf(" of %s goal (%d%%)", ui.FormatDuration(w.LearningGoalMinutes), w.TotalLearningMinutes*100/w.LearningGoalMinutes)
+	if w.TotalLearningMinutes >= w.LearningGoalMinutes {
+		text += " ✓"
+	}
+	return text
+}
+
+// AttachNotes files notes under the days of the week they belong to.
+// Notes can exist for days without a snapshot.
+func (w *Week) AttachNotes(notes []summary.Note) {
+	for _, n := range notes {
+		for i := range w.Days {
+			if w.Days[i].Date.Format("2006-01-02") == n.Date {
+				w.Days[i].Notes = append(w.Days[i].Notes, n)
+				break
+			}
+		}
+	}
+}
+
+// NoteLines returns one line per note, labeled with its day
+func (w Week) NoteLines() []Line {
+	var lines []Line
+	for _, d := range w.Days {
+		for _, n := range d.Notes {
+			lines = append(lines, Line{locale.Current().ShortDate(d.Date), n.Text})
+		}
+	}
+	return lines
+}
+
+// DailyColumns are the headers for DailyRows
+var DailyColumns = []string{"Day", "Awake", "Screen", "Focus", "Notifs", "Top app"}
+
+// DailyRows returns one row per day of the week, matching DailyColumns
+func (w Week) DailyRows() [][]string {
+	rows := make([][]string, 0, len(w.Days))
+	for _, d := range w.Days {
+		label := locale.Current().ShortDate(d.Date)
+		if !d.Recorded {
+			rows = append(rows, []string{label, "-", "-", "-", "-", "no data"})
+			continue
+		}
+
+		day := d.Summary
+
+		topApp := "-"
+		if len(day.TopApps) > 0 {
+			topApp = day.TopApps[0].Name
+		}
+		rows = append(rows, []string{
+			label,
+			ui.FormatDuration(day.AwakeMinutes),
+			ui.FormatDuration(day.ScreenOnMinutes),
+			ui.FormatDuration(day.FocusStreakMinutes),
+			fmt.Sprintf("%d", day.Notifications),
+			topApp,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+func testWeek() Week {
+	end := time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local)
+	return BuildWeek(end, []history.DaySummary{
+		{
+			Date: "2026-02-16", AwakeMinutes: 480, ScreenOnMinutes: 360, Notifications: 40,
+			FocusStreakMinutes: 95, FocusApp: "VS Code", FragmentationScore: 40, LearningMinutes: 30,
+			TopApps: []history.AppUsage{{Name: "VS Code", Minutes: 200}, {Name: "Slack", Minutes: 60}},
+		},
+		{
+			Date: "2026-02-18", AwakeMinutes: 420, ScreenOnMinutes: 300, Notifications: 20,
+			FocusStreakMinutes: 45, FocusApp: "Figma", FragmentationScore: 60, LearningMinutes: 60,
+			TopApps: []history.AppUsage{{Name: "Slack", Minutes: 180}, {Name: "Figma", Minutes: 90}},
+		},
+		// Outside the week; ignored
+		{Date: "2026-02-10", ScreenOnMinutes: 999},
+	})
+}
+
+func TestBuildWeek(t *testing.T) {
+	t.Parallel()
+	week := testWeek()
+
+	if len(week.Days) != 7 {
+		t.Fatalf("got %d days, want 7", len(week.Days))
+	}
+	if got := week.Days[0].Date.Format("2006-01-02"); got != "2026-02-12" {
+		t.Errorf("first day = %s, want 2026-02-12", got)
+	}
+	if week.DaysRecorded != 2 {
+		t.Errorf("DaysRecorded = %d, want 2", week.DaysRecorded)
+	}
+	if week.TotalScreenMinutes != 660 {
+		t.Errorf("TotalScreenMinutes = %d, want 660", week.TotalScreenMinutes)
+	}
+	if week.AvgFragmentation != 50 {
+		t.Errorf("AvgFragmentation = %d, want 50", week.AvgFragmentation)
+	}
+	if week.LongestFocusMinutes != 95 || week.LongestFocusApp != "VS Code" || week.LongestFocusDate != "2026-02-16" {
+		t.Errorf("longest focus = %d %q %q", week.LongestFocusMinutes, week.LongestFocusApp, week.LongestFocusDate)
+	}
+
+	// Slack (240) overtakes VS Code (200) once both days are summed
+	if len(week.TopApps) != 3 || week.TopApps[0].Name != "Slack" || week.TopApps[0].Minutes != 240 {
+		t.Errorf("TopApps = %+v, want Slack first with 240 minutes", week.TopApps)
+	}
+}
+
+func TestDailyRows(t *testing.T) {
+	t.Parallel()
+	rows := testWeek().DailyRows()
+	if len(rows) != 7 {
+		t.Fatalf("got %d rows, want 7", len(rows))
+	}
+	if rows[0][len(rows[0])-1] != "no data" {
+		t.Errorf("missing day row = %v, want \"no data\"", rows[0])
+	}
+	if rows[6][0] != "Wed Feb 18" || rows[6][5] != "Slack" {
+		t.Errorf("last row = %v, want Wed Feb 18 with Slack", rows[6])
+	}
+}
+
+func TestAttachNotes(t *testing.T) {
+	t.Parallel()
+	week := testWeek()
+	week.AttachNotes([]summary.Note{
+		{Date: "2026-02-12", Text: "offsite"}, // no snapshot that day
+		{Date: "2026-02-18", Text: "shipped the release"},
+		{Date: "2026-02-18", Text: "retro"},
+		{Date: "2026-02-01", Text: "outside the week"},
+	})
+
+	lines := week.NoteLines()
+	if len(lines) != 3 {
+		t.Fatalf("got %d note lines, want 3: %+v", len(lines), lines)
+	}
+	if lines[0] != (Line{"Thu Feb 12", "offsite"}) || lines[2] != (Line{"Wed Feb 18", "retro"}) {
+		t.Errorf("NoteLines() = %+v", lines)
+	}
+
+	// Notes are private and never shared
+	if got := week.Redacted(true).NoteLines(); len(got) != 0 {
+		t.Errorf("Redacted week kept notes: %+v", got)
+	}
+	if len(week.NoteLines()) != 3 {
+		t.Error("Redacted modified the original week")
+	}
+}
+
+func TestWeekTitle(t *testing.T) {
+	t.Parallel()
+	if got := testWeek().Title(); got != "Feb 12 – Feb 18, 2026" {
+		t.Errorf("Title() = %q", got)
+	}
+
+	span := BuildWeek(time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local), nil)
+	if got := span.Title(); got != "Dec 27, 2025 – Jan 2, 2026" {
+		t.Errorf("Title() across years = %q", got)
+	}
+}
+
+func TestWeekLearning(t *testing.T) {
+	t.Parallel()
+	week := testWeek()
+	if week.TotalLearningMinutes != 90 {
+		t.Errorf("TotalLearningMinutes = %d, want 90", week.TotalLearningMinutes)
+	}
+
+	tests := []struct {
+		goal int
+		want string
+	}{
+		{0, "1h 30m"},
+		{180, "1h 30m of 3h 0m goal (50%)"},
+		{60, "1h 30m of 1h 0m goal (150%) ✓"},
+	}
+	for _, tt := range tests {
+		week.LearningGoalMinutes = tt.goal
+		got := ""
+		for _, line := range week.Overview() {
+			if line.Label == "Learning" {
+				got = line.Value
+			}
+		}
+		if got != tt.want {
+			t.Errorf("goal %d: Learning = %q, want %q", tt.goal, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package report
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// ShareOptions controls what a shared report reveals and for how long
+type ShareOptions struct {
+	Expires     time.Time
+	IncludeApps bool // Keep app names; otherwise they are redacted
+}
+
+// sharePayload is embedded in the page; only the ciphertext holds report data
+type sharePayload struct {
+	Expires    string `json:"expires"` // RFC 3339; also the AES-GCM additional data
+	IV         string `json:"iv"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// Redacted returns a copy of the week suitable for sharing: network totals
+// and notes are always dropped, and app names are dropped unless
+// includeApps is set.
+func (w Week) Redacted(includeApps bool) Week {
+	r := w
+	r.TotalBytesReceived = 0
+	r.TotalBytesSent = 0
+	if !includeApps {
+		r.TopApps = nil
+		r.LongestFocusApp = ""
+	}
+
+	r.Days = make([]Day, len(w.Days))
+	for i, d := range w.Days {
+		d.Notes = nil
+		d.Summary.TopDomains = nil
+		if !includeApps {
+			d.Summary.TopApps = nil
+			d.Summary.FocusApp = ""
+			d.Summary.Projects = nil
+		}
+		r.Days[i] = d
+	}
+	return r
+}
+
+// WriteShareHTML writes a self-contained HTML page holding the redacted
+// weekly report, encrypted with a fresh AES-256-GCM key. The key is returned
+// for the link's #fragment, which browsers never send to the server hosting
+// the page. The expiry is bound to the ciphertext, so it can't be edited
+// without breaking decryption, and the page refuses to open after it.
+func WriteShareHTML(w io.Writer, week Week, opts ShareOptions) (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	expires := opts.Expires.UTC().Format(time.RFC3339)
+	payload, err := encryptShare(key, []byte(shareBody(week.Redacted(opts.IncludeApps))), expires)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	page := strings.Replace(sharePageTemplate, "{{TITLE}}", html.EscapeString("rekap weekly report • "+week.Title()), 1)
+	page = strings.Replace(page, "{{PAYLOAD}}", string(data), 1)
+	if _, err := io.WriteString(w, page); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+func encryptShare(key, plaintext []byte, expires string) (sharePayload, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return sharePayload{}, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return sharePayload{}, err
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return sharePayload{}, err
+	}
+
+	return sharePayload{
+		Expires:    expires,
+		IV:         base64.StdEncoding.EncodeToString(iv),
+		Ciphertext: base64.StdEncoding.EncodeToString(gcm.Seal(nil, iv, plaintext, []byte(expires))),
+	}, nil
+}
+
+// shareBody renders the report as an HTML fragment
+func shareBody(week Week) string {
+	var b strings.Builder
+	esc := html.EscapeString
+
+	b.WriteString("<h1>Weekly report</h1>\n")
+	b.WriteString(fmt.Sprintf("<p class=\"range\">%s</p>\n", esc(week.Title())))
+
+	b.WriteString("<h2>Overview</h2>\n<table class=\"overview\">\n")
+	for _, l := range week.Overview() {
+		b.WriteString(fmt.Sprintf("<tr><th>%s</th><td>%s</td></tr>\n", esc(l.Label), esc(l.Value)))
+	}
+	b.WriteString("</table>\n")
+
+	if len(week.TopApps) > 0 {
+		b.WriteString("<h2>Top Apps</h2>\n<ol>\n")
+		for _, app := range week.TopApps {
+			b.WriteString(fmt.Sprintf("<li>%s <span>%s</span></li>\n", esc(app.Name), esc(ui.FormatDuration(app.Minutes))))
+		}
+		b.WriteString("</ol>\n")
+	}
+
+	b.WriteString("<h2>Daily Breakdown</h2>\n<table class=\"daily\">\n<tr>")
+	for _, col := range DailyColumns {
+		b.WriteString(fmt.Sprintf("<th>%s</th>", esc(col)))
+	}
+	b.WriteString("</tr>\n")
+	for _, row := range week.DailyRows() {
+		b.WriteString("<tr>")
+		for _, cell := range row {
+			b.WriteString(fmt.Sprintf("<td>%s</td>", esc(cell)))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n")
+
+	return b.String()
+}
+
+const sharePageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<meta name="referrer" content="no-referrer">
+<title>{{TITLE}}</title>
+<style>
+  body { font: 15px/1.5 -apple-system, BlinkMacSystemFont, "Helvetica Neue", sans-serif; max-width: 720px; margin: 40px auto; padding: 0 20px; color: #222; }
+  h1 { margin-bottom: 0; }
+  .range { color: #666; margin-top: 4px; }
+  h2 { margin-top: 32px; border-bottom: 1px solid #ddd; padding-bottom: 4px; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { text-align: left; padding: 4px 8px 4px 0; vertical-align: top; }
+  .overview th { width: 180px; font-weight: 600; }
+  .daily th { border-bottom: 1px solid #ddd; }
+  ol span { color: #666; margin-left: 8px; }
+  .notice { color: #a33; }
+  footer { margin-top: 40px; color: #999; font-size: 12px; }
+  @media print { body { margin: 0; } footer { display: none; } }
+</style>
+</head>
+<body>
+<main id="report"><p>Decrypting report…</p></main>
+<footer>Generated by rekap. This page is end-to-end encrypted; the key lives only in the link.</footer>
+<script id="payload" type="application/json">{{PAYLOAD}}</script>
+<script>
+(async () => {
+  const el = document.getElementById("report");
+  const fail = (msg) => { el.innerHTML = ""; const p = document.createElement("p"); p.className = "notice"; p.textContent = msg; el.appendChild(p); };
+  const data = JSON.parse(document.getElementById("payload").textContent);
+  if (Date.now() > Date.parse(data.expires)) {
+    fail("This report expired on " + new Date(data.expires).toLocaleString() + ".");
+    return;
+  }
+  const m = location.hash.match(/k=([A-Za-z0-9_-]+)/);
+  if (!m) {
+    fail("This link is missing its key. Ask the sender for the full link.");
+    return;
+  }
+  const bytes = (s) => Uint8Array.from(atob(s.replace(/-/g, "+").replace(/_/g, "/")), (c) => c.charCodeAt(0));
+  try {
+    const key = await crypto.subtle.importKey("raw", bytes(m[1]), "AES-GCM", false, ["decrypt"]);
+    const plain = await crypto.subtle.decrypt(
+      { name: "AES-GCM", iv: bytes(data.iv), additionalData: new TextEncoder().encode(data.expires) },
+      key, bytes(data.ciphertext));
+    el.innerHTML = new TextDecoder().decode(plain);
+  } catch (e) {
+    fail("This report could not be opened. The link may be incomplete or the page was modified.");
+  }
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteShareHTML(t *testing.T) {
+	t.Parallel()
+	expires := time.Date(2026, 2, 25, 12, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	key, err := WriteShareHTML(&buf, testWeek(), ShareOptions{Expires: expires})
+	if err != nil {
+		t.Fatalf("WriteShareHTML: %v", err)
+	}
+	page := buf.String()
+
+	// Nothing from the report is readable without the key
+	if strings.Contains(page, "VS Code") || strings.Contains(page, "Overview") {
+		t.Error("page contains plaintext report data")
+	}
+
+	m := regexp.MustCompile(`<script id="payload" type="application/json">(.*?)</script>`).FindStringSubmatch(page)
+	if m == nil {
+		t.Fatal("payload not found")
+	}
+	var payload sharePayload
+	if err := json.Unmarshal([]byte(m[1]), &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if payload.Expires != "2026-02-25T12:00:00Z" {
+		t.Errorf("Expires = %q", payload.Expires)
+	}
+
+	plain := decryptShare(t, key, payload, payload.Expires)
+	if !strings.Contains(plain, "Daily Breakdown") {
+		t.Error("decrypted body missing report content")
+	}
+	// Redacted by default: no app names
+	if strings.Contains(plain, "VS Code") || strings.Contains(plain, "Slack") {
+		t.Error("app names should be redacted by default")
+	}
+
+	// Tampering with the expiry breaks decryption
+	if _, err := openShare(key, payload, "2099-01-01T00:00:00Z"); err == nil {
+		t.Error("expected decryption to fail with a modified expiry")
+	}
+}
+
+func TestShareIncludeApps(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	key, err := WriteShareHTML(&buf, testWeek(), ShareOptions{Expires: time.Now().Add(time.Hour), IncludeApps: true})
+	if err != nil {
+		t.Fatalf("WriteShareHTML: %v", err)
+	}
+	m := regexp.MustCompile(`<script id="payload" type="application/json">(.*?)</script>`).FindStringSubmatch(buf.String())
+	var payload sharePayload
+	if err := json.Unmarshal([]byte(m[1]), &payload); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if plain := decryptShare(t, key, payload, payload.Expires); !strings.Contains(plain, "Slack") {
+		t.Error("expected app names with IncludeApps")
+	}
+}
+
+func decryptShare(t *testing.T, key string, p sharePayload, aad string) string {
+	t.Helper()
+	plain, err := openShare(key, p, aad)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	return plain
+}
+
+func openShare(key string, p sharePayload, aad string) (string, error) {
+	k, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+	iv, _ := base64.StdEncoding.DecodeString(p.IV)
+	ct, _ := base64.StdEncoding.DecodeString(p.Ciphertext)
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcm.Open(nil, iv, ct, []byte(aad))
+	return string(plain), err
+}
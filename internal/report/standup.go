@@ -0,0 +1,90 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexinslc/rekap/internal/summary"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// standupMaxItems caps how many issues, apps, or hosts a standup bullet lists
+const standupMaxItems = 3
+
+// Standup composes a short update from a day's summary as Markdown
+// bullets: issues looked at, project and app time, remote hosts, focus, and
+// the day's notes. heading introduces it, e.g. "Yesterday I".
+func Standup(data *summary.Data, heading string) string {
+	var bullets []string
+	add := func(format string, args ...any) {
+		bullets = append(bullets, "- "+fmt.Sprintf(format, args...))
+	}
+
+	if data.Issues.Available && len(data.Issues.Issues) > 0 {
+		var ids []string
+		for _, issue := range data.Issues.Issues {
+			ids = append(ids, issue.ID)
+		}
+		add("Worked on %s", joinTop(ids))
+	}
+
+	if data.Projects.Available && len(data.Projects.Projects) > 0 {
+		var parts []string
+		for _, p := range data.Projects.Projects {
+			parts = append(parts, fmt.Sprintf("%s (%s)", p.Name, ui.FormatDuration(p.Minutes)))
+		}
+		add("Spent time on %s", joinTop(parts))
+	}
+
+	if data.Apps.Available && len(data.Apps.TopApps) > 0 {
+		var parts []string
+		for _, app := range data.Apps.TopApps {
+			parts = append(parts, fmt.Sprintf("%s (%s)", app.Name, ui.FormatDuration(app.Minutes)))
+		}
+		add("Mostly in %s", joinTop(parts))
+	}
+
+	if data.SSH.Available {
+		var hosts []string
+		for _, h := range data.SSH.Hosts {
+			hosts = append(hosts, h.Host)
+		}
+		add("Worked remotely on %s for %s", joinTop(hosts), ui.FormatDuration(data.SSH.TotalMinutes))
+	}
+
+	if data.Focus.Available && data.Focus.StreakMinutes > 0 {
+		line := fmt.Sprintf("Longest focus stretch: %s in %s", ui.FormatDuration(data.Focus.StreakMinutes), data.Focus.AppName)
+		if data.Sessions.Available {
+			line += fmt.Sprintf(" across %d session%s", data.Sessions.Count, plural(data.Sessions.Count))
+		}
+		add("%s", line)
+	}
+
+	for _, note := range data.Notes {
+		add("%s", note.Text)
+	}
+
+	if len(bullets) == 0 {
+		bullets = append(bullets, "- Nothing recorded")
+	}
+	return heading + "...\n" + strings.Join(bullets, "\n") + "\n"
+}
+
+// joinTop joins the first standupMaxItems of items as "a, b and c", or
+// "a, b, c and 2 more" when some are left out
+func joinTop(items []string) string {
+	if len(items) > standupMaxItems {
+		return strings.Join(items[:standupMaxItems], ", ") + fmt.Sprintf(" and %d more", len(items)-standupMaxItems)
+	}
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
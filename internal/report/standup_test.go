@@ -0,0 +1,39 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+func TestStandup(t *testing.T) {
+	t.Parallel()
+	data := &summary.Data{
+		Issues: collectors.IssuesResult{Available: true, Issues: []collectors.IssueVisit{
+			{ID: "PROJ-12"}, {ID: "PROJ-7"}, {ID: "OPS-3"}, {ID: "OPS-4"}, {ID: "OPS-5"},
+		}},
+		Apps: collectors.AppsResult{Available: true, TopApps: []collectors.AppUsage{
+			{Name: "VS Code", Minutes: 142}, {Name: "Safari", Minutes: 89},
+		}},
+		SSH:      collectors.SSHResult{Available: true, TotalMinutes: 50, Hosts: []collectors.SSHHost{{Host: "staging"}}},
+		Focus:    collectors.FocusResult{Available: true, StreakMinutes: 87, AppName: "VS Code"},
+		Sessions: collectors.SessionsResult{Available: true, Count: 1},
+		Notes:    []summary.Note{{Text: "Paired with Sam on the release"}},
+	}
+
+	want := `Yesterday I...
+- Worked on PROJ-12, PROJ-7, OPS-3 and 2 more
+- Mostly in VS Code (2h 22m) and Safari (1h 29m)
+- Worked remotely on staging for 50m
+- Longest focus stretch: 1h 27m in VS Code across 1 session
+- Paired with Sam on the release
+`
+	if got := Standup(data, "Yesterday I"); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := Standup(&summary.Data{}, "Today I"); got != "Today I...\n- Nothing recorded\n" {
+		t.Errorf("empty standup = %q", got)
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/history"
+)
+
+// TimesheetRow is the time attributed to one project on one day
+type TimesheetRow struct {
+	Date    string // YYYY-MM-DD
+	Project string
+	Minutes int
+}
+
+// TimesheetFromProjects returns today's rows from a live project breakdown
+func TimesheetFromProjects(date string, projects collectors.ProjectsResult) []TimesheetRow {
+	var rows []TimesheetRow
+	for _, p := range projects.Projects {
+		rows = append(rows, TimesheetRow{Date: date, Project: p.Name, Minutes: p.Minutes})
+	}
+	return rows
+}
+
+// TimesheetFromHistory returns rows for every project recorded in days
+func TimesheetFromHistory(days []history.DaySummary) []TimesheetRow {
+	var rows []TimesheetRow
+	for _, day := range days {
+		for _, p := range day.Projects {
+			rows = append(rows, TimesheetRow{Date: day.Date, Project: p.Name, Minutes: p.Minutes})
+		}
+	}
+	return rows
+}
+
+// WriteTimesheetCSV writes rows as CSV with date, project, minutes, and
+// decimal hours (rounded to 0.01) for pasting into timesheet tools
+func WriteTimesheetCSV(w io.Writer, rows []TimesheetRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "project", "minutes", "hours"}); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		record := []string{
+			r.Date,
+			r.Project,
+			fmt.Sprintf("%d", r.Minutes),
+			fmt.Sprintf("%.2f", float64(r.Minutes)/60),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
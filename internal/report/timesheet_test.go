@@ -0,0 +1,29 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/history"
+)
+
+func TestWriteTimesheetCSV(t *testing.T) {
+	t.Parallel()
+	rows := TimesheetFromHistory([]history.DaySummary{
+		{Date: "2026-02-17", Projects: []history.ProjectUsage{{Name: "Client A", Minutes: 150}}},
+		{Date: "2026-02-18"},
+		{Date: "2026-02-18", Projects: []history.ProjectUsage{{Name: "Acme, Inc.", Minutes: 45}}},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteTimesheetCSV(&buf, rows); err != nil {
+		t.Fatalf("WriteTimesheetCSV: %v", err)
+	}
+
+	want := "date,project,minutes,hours\n" +
+		"2026-02-17,Client A,150,2.50\n" +
+		"2026-02-18,\"Acme, Inc.\",45,0.75\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,186 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// wrappedTopN is how many apps and domains the year in review lists
+const wrappedTopN = 10
+
+// Wrapped is a year in review built from everything the history store
+// holds for one calendar year: daily snapshots plus the weekly aggregates
+// older days were compacted into.
+type Wrapped struct {
+	Year         int
+	DaysRecorded int
+
+	ScreenOnMinutes int
+	AwakeMinutes    int
+	Notifications   int
+	AppSwitches     int
+	URLsVisited     int
+
+	LongestFocusMinutes int
+	LongestFocusApp     string
+	LongestFocusDate    string
+
+	MostFragmentedScore int
+	MostFragmentedDate  string
+
+	TopApps    []history.AppUsage     // Most used first
+	TopDomains []history.DomainVisits // Most visited first
+}
+
+// YearRange returns the YYYY-MM-DD bounds of year
+func YearRange(year int) (from, to string) {
+	return fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-12-31", year)
+}
+
+// BuildWrapped aggregates the snapshots and weekly aggregates of year.
+// Weekly aggregates count toward the year their Monday falls in, since
+// their days can no longer be split.
+func BuildWrapped(year int, days []history.DaySummary, weeks []history.WeekSummary) Wrapped {
+	w := Wrapped{Year: year}
+	prefix := strconv.Itoa(year)
+	appMinutes := make(map[string]int)
+	domainVisits := make(map[string]int)
+
+	record := func(focus int, focusApp, focusDate string, frag int, fragDate string) {
+		if focus > w.LongestFocusMinutes {
+			w.LongestFocusMinutes = focus
+			w.LongestFocusApp = focusApp
+			w.LongestFocusDate = focusDate
+		}
+		if frag > w.MostFragmentedScore {
+			w.MostFragmentedScore = frag
+			w.MostFragmentedDate = fragDate
+		}
+	}
+
+	for _, d := range days {
+		if len(d.Date) < 4 || d.Date[:4] != prefix {
+			continue
+		}
+		w.DaysRecorded++
+		w.ScreenOnMinutes += d.ScreenOnMinutes
+		w.AwakeMinutes += d.AwakeMinutes
+		w.Notifications += d.Notifications
+		w.AppSwitches += d.AppSwitches
+		w.URLsVisited += d.URLsVisited
+		record(d.FocusStreakMinutes, d.FocusApp, d.Date, d.FragmentationScore, d.Date)
+		for _, app := range d.TopApps {
+			appMinutes[app.Name] += app.Minutes
+		}
+		for _, dv := range d.TopDomains {
+			domainVisits[dv.Domain] += dv.Visits
+		}
+	}
+
+	for _, wk := range weeks {
+		if len(wk.WeekStart) < 4 || wk.WeekStart[:4] != prefix {
+			continue
+		}
+		w.DaysRecorded += wk.Days
+		w.ScreenOnMinutes += wk.ScreenOnMinutes
+		w.AwakeMinutes += wk.AwakeMinutes
+		w.Notifications += wk.Notifications
+		w.AppSwitches += wk.AppSwitches
+		w.URLsVisited += wk.URLsVisited
+		record(wk.LongestFocusMinutes, wk.LongestFocusApp, wk.LongestFocusDate, wk.MaxFragmentation, wk.MaxFragmentationDay)
+		for _, app := range wk.TopApps {
+			appMinutes[app.Name] += app.Minutes
+		}
+		for _, dv := range wk.TopDomains {
+			domainVisits[dv.Domain] += dv.Visits
+		}
+	}
+
+	for name, minutes := range appMinutes {
+		w.TopApps = append(w.TopApps, history.AppUsage{Name: name, Minutes: minutes})
+	}
+	sort.Slice(w.TopApps, func(i, j int) bool {
+		if w.TopApps[i].Minutes != w.TopApps[j].Minutes {
+			return w.TopApps[i].Minutes > w.TopApps[j].Minutes
+		}
+		return w.TopApps[i].Name < w.TopApps[j].Name
+	})
+	if len(w.TopApps) > wrappedTopN {
+		w.TopApps = w.TopApps[:wrappedTopN]
+	}
+
+	for domain, visits := range domainVisits {
+		w.TopDomains = append(w.TopDomains, history.DomainVisits{Domain: domain, Visits: visits})
+	}
+	sort.Slice(w.TopDomains, func(i, j int) bool {
+		if w.TopDomains[i].Visits != w.TopDomains[j].Visits {
+			return w.TopDomains[i].Visits > w.TopDomains[j].Visits
+		}
+		return w.TopDomains[i].Domain < w.TopDomains[j].Domain
+	})
+	if len(w.TopDomains) > wrappedTopN {
+		w.TopDomains = w.TopDomains[:wrappedTopN]
+	}
+
+	return w
+}
+
+// ScreenHours returns total screen time in whole hours
+func (w Wrapped) ScreenHours() int {
+	return w.ScreenOnMinutes / 60
+}
+
+// ScreenTagline puts the screen total in perspective, e.g.
+// "That's 17 full days • 2h 10m a day across 190 recorded days"
+func (w Wrapped) ScreenTagline() string {
+	if w.DaysRecorded == 0 {
+		return ""
+	}
+	perDay := ui.FormatDuration(w.ScreenOnMinutes / w.DaysRecorded)
+	fullDays := w.ScreenOnMinutes / (24 * 60)
+	if fullDays < 1 {
+		return fmt.Sprintf("%s a day across %d recorded days", perDay, w.DaysRecorded)
+	}
+	return fmt.Sprintf("That's %d full days • %s a day across %d recorded days", fullDays, perDay, w.DaysRecorded)
+}
+
+// Records returns the standout days of the year
+func (w Wrapped) Records() []Line {
+	var lines []Line
+	if w.LongestFocusMinutes > 0 {
+		focus := ui.FormatDuration(w.LongestFocusMinutes)
+		if w.LongestFocusApp != "" {
+			focus += " in " + w.LongestFocusApp
+		}
+		focus += wrappedDate(w.LongestFocusDate)
+		lines = append(lines, Line{"Longest focus", focus})
+	}
+	if w.MostFragmentedScore > 0 {
+		lines = append(lines, Line{"Most fragmented", fmt.Sprintf("%d/100%s", w.MostFragmentedScore, wrappedDate(w.MostFragmentedDate))})
+	}
+	if w.Notifications > 0 {
+		lines = append(lines, Line{"Notifications", fmt.Sprintf("%d total", w.Notifications)})
+	}
+	if w.AppSwitches > 0 {
+		lines = append(lines, Line{"App switches", fmt.Sprintf("%d total", w.AppSwitches)})
+	}
+	if w.URLsVisited > 0 {
+		lines = append(lines, Line{"Pages visited", fmt.Sprintf("%d total", w.URLsVisited)})
+	}
+	return lines
+}
+
+// wrappedDate formats a YYYY-MM-DD date as " (Tue Mar 3)", or "" if unknown
+func wrappedDate(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	return " (" + locale.Current().ShortDate(t) + ")"
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexinslc/rekap/internal/history"
+)
+
+func TestBuildWrapped(t *testing.T) {
+	t.Parallel()
+	days := []history.DaySummary{
+		{
+			Date: "2026-10-12", ScreenOnMinutes: 600, Notifications: 30, FragmentationScore: 35,
+			FocusStreakMinutes: 80, FocusApp: "Xcode",
+			TopApps:    []history.AppUsage{{Name: "Xcode", Minutes: 300}},
+			TopDomains: []history.DomainVisits{{Domain: "github.com", Visits: 40}},
+		},
+		// Another year; ignored
+		{Date: "2025-12-31", ScreenOnMinutes: 999, FragmentationScore: 99},
+	}
+	weeks := []history.WeekSummary{
+		{
+			WeekStart: "2026-02-16", Days: 5, ScreenOnMinutes: 2400, Notifications: 100,
+			LongestFocusMinutes: 150, LongestFocusApp: "Figma", LongestFocusDate: "2026-02-18",
+			MaxFragmentation: 72, MaxFragmentationDay: "2026-02-17",
+			TopApps:    []history.AppUsage{{Name: "Slack", Minutes: 900}, {Name: "Xcode", Minutes: 500}},
+			TopDomains: []history.DomainVisits{{Domain: "github.com", Visits: 10}, {Domain: "figma.com", Visits: 30}},
+		},
+		// Monday in the previous year; counts toward that year
+		{WeekStart: "2025-12-29", Days: 7, ScreenOnMinutes: 5000},
+	}
+
+	w := BuildWrapped(2026, days, weeks)
+
+	if w.DaysRecorded != 6 || w.ScreenOnMinutes != 3000 || w.Notifications != 130 {
+		t.Errorf("totals = %d days / %d min / %d notifs, want 6 / 3000 / 130", w.DaysRecorded, w.ScreenOnMinutes, w.Notifications)
+	}
+	if w.ScreenHours() != 50 {
+		t.Errorf("ScreenHours = %d, want 50", w.ScreenHours())
+	}
+	if len(w.TopApps) != 2 || w.TopApps[0].Name != "Slack" || w.TopApps[1].Minutes != 800 {
+		t.Errorf("TopApps = %+v, want Slack then Xcode 800", w.TopApps)
+	}
+	if len(w.TopDomains) != 2 || w.TopDomains[0].Domain != "github.com" || w.TopDomains[0].Visits != 50 {
+		t.Errorf("TopDomains = %+v, want github.com 50 first", w.TopDomains)
+	}
+	if w.LongestFocusMinutes != 150 || w.LongestFocusApp != "Figma" || w.LongestFocusDate != "2026-02-18" {
+		t.Errorf("longest focus = %d in %q on %q", w.LongestFocusMinutes, w.LongestFocusApp, w.LongestFocusDate)
+	}
+	if w.MostFragmentedScore != 72 || w.MostFragmentedDate != "2026-02-17" {
+		t.Errorf("most fragmented = %d on %q, want 72 on 2026-02-17", w.MostFragmentedScore, w.MostFragmentedDate)
+	}
+
+	records := w.Records()
+	if len(records) == 0 || records[0].Value != "2h 30m in Figma (Wed Feb 18)" {
+		t.Errorf("Records()[0] = %+v", records)
+	}
+	if tagline := w.ScreenTagline(); !strings.HasPrefix(tagline, "That's 2 full days") {
+		t.Errorf("ScreenTagline = %q", tagline)
+	}
+}
+
+func TestBuildWrappedEmpty(t *testing.T) {
+	t.Parallel()
+	w := BuildWrapped(2026, nil, nil)
+	if w.DaysRecorded != 0 || w.ScreenTagline() != "" || len(w.Records()) != 0 {
+		t.Errorf("empty year = %+v", w)
+	}
+}
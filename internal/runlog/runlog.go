@@ -0,0 +1,66 @@
+// Package runlog appends each run's JSON output to a size-capped log file
+// that rotates like newsyslog: runs.jsonl, runs.jsonl.1, runs.jsonl.2, ...
+package runlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexinslc/rekap/internal/xdg"
+)
+
+// Keep is how many rotated files are kept besides the live one
+const Keep = 3
+
+// DefaultPath returns runs.jsonl in the data directory, next to the
+// history database
+func DefaultPath() (string, error) {
+	dir, err := xdg.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "runs.jsonl"), nil
+}
+
+// Append adds line (which should end in a newline) to the log at path. When
+// the log would grow past maxBytes it's rotated first, so a file only
+// exceeds maxBytes if a single line does.
+func Append(path string, line []byte, maxBytes int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && info.Size()+int64(len(line)) > maxBytes {
+		if err := rotate(path); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// One write per line keeps concurrent runs from interleaving
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to append to %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// rotate shifts path.N to path.N+1, dropping the oldest, and moves path to
+// path.1
+func rotate(path string) error {
+	if err := os.Remove(fmt.Sprintf("%s.%d", path, Keep)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate %s: %w", path, err)
+	}
+	for n := Keep - 1; n >= 1; n-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", path, n), fmt.Sprintf("%s.%d", path, n+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate %s: %w", path, err)
+		}
+	}
+	if err := os.Rename(path, path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate %s: %w", path, err)
+	}
+	return nil
+}
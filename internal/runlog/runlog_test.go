@@ -0,0 +1,55 @@
+package runlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppendRotates(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "runs.jsonl")
+	line := []byte(`{"run":1}` + "\n") // 10 bytes
+
+	// Three lines fit in 30 bytes; each later pair of lines starts a new file
+	for i := 0; i < 3+2*Keep+2; i++ {
+		if err := Append(path, line, 30); err != nil {
+			t.Fatalf("Append() #%d: %v", i+1, err)
+		}
+	}
+
+	read := func(p string) string {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("read %s: %v", filepath.Base(p), err)
+		}
+		return string(b)
+	}
+	if got := strings.Count(read(path), "\n"); got != 2 {
+		t.Errorf("live log has %d lines, want 2", got)
+	}
+	for n := 1; n <= Keep; n++ {
+		if got := read(fmt.Sprintf("%s.%d", path, n)); got == "" {
+			t.Errorf("runs.jsonl.%d is empty", n)
+		}
+	}
+	if _, err := os.Stat(fmt.Sprintf("%s.%d", path, Keep+1)); !os.IsNotExist(err) {
+		t.Errorf("expected at most %d rotated files, stat err = %v", Keep, err)
+	}
+}
+
+func TestAppendOversizedLine(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "runs.jsonl")
+	long := []byte(strings.Repeat("x", 50) + "\n")
+
+	// An empty log takes a line bigger than the cap rather than rotating forever
+	if err := Append(path, long, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Error("expected no rotation for the first line")
+	}
+}
@@ -0,0 +1,322 @@
+// Package state keeps small per-day records that collectors carry between
+// runs (network baselines; Wi-Fi, frontmost-app, Space, memory, load, VPN,
+// Bluetooth, clipboard counter, music, and window title samples; first
+// battery reading; screen-time checkpoints; installed Homebrew versions;
+// time zones seen; when onboarding was last offered) in a SQLite database
+// in the XDG state directory.
+package state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/alexinslc/rekap/internal/xdg"
+)
+
+// Record kinds. Each kind holds at most one record per day.
+const (
+	KindNetwork    = "network"
+	KindWiFi       = "wifi"
+	KindBattery    = "battery"
+	KindScreen     = "screen"
+	KindApps       = "apps"
+	KindSpaces     = "spaces"
+	KindMemory     = "memory"
+	KindCPU        = "cpu"
+	KindAppNetwork = "app_network"
+	KindVPN        = "vpn"
+	KindAudio      = "audio"
+	KindClipboard  = "clipboard"
+	KindMusic      = "music"
+	KindWindows    = "windows"
+	KindTimeZone   = "timezone"
+	KindBrew       = "brew"
+	KindStatus     = "status"
+	KindAccess     = "access"
+	KindOnboarding = "onboarding"
+	KindRedact     = "redact"
+)
+
+// KeepDays is how many days of state are kept. State only matters for the
+// current day; the extra days cover clock changes and late day boundaries.
+const KeepDays = 7
+
+// legacyKinds are kinds that used to be stored as <kind>-YYYY-MM-DD.json
+// files in the data directory.
+var legacyKinds = []string{KindNetwork, KindWiFi}
+
+// Store is the state table of the rekap database
+type Store struct {
+	db *sql.DB
+}
+
+// DefaultPath returns $XDG_STATE_HOME/rekap/state.db
+// (~/.local/state/rekap by default)
+func DefaultPath() (string, error) {
+	dir, err := xdg.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.db"), nil
+}
+
+// DSN returns the driver connection string for the database at path. Several
+// collectors and the history writer can hold a database at once, so writers
+// wait for a lock instead of failing immediately.
+func DSN(path string) string {
+	return path + "?_pragma=busy_timeout(5000)"
+}
+
+// OpenPath opens (creating if needed) the state table in the database at path
+func OpenPath(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", DSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	const schema = `CREATE TABLE IF NOT EXISTS state (
+		kind       TEXT NOT NULL,
+		date       TEXT NOT NULL,
+		updated_at INTEGER NOT NULL,
+		data       BLOB NOT NULL,
+		PRIMARY KEY (kind, date)
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize state database: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Get decodes the kind record for date (YYYY-MM-DD) into v. It reports
+// false with no error when there is no record.
+func (s *Store) Get(kind, date string, v any) (bool, error) {
+	var blob []byte
+	err := s.db.QueryRow(`SELECT data FROM state WHERE kind = ? AND date = ?`, kind, date).Scan(&blob)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s state: %w", kind, err)
+	}
+	if err := json.Unmarshal(blob, v); err != nil {
+		return false, fmt.Errorf("corrupted %s state for %s: %w", kind, date, err)
+	}
+	return true, nil
+}
+
+// Latest decodes the most recent kind record into v and returns its date.
+// It reports false with no error when there is no record.
+func (s *Store) Latest(kind string, v any) (string, bool, error) {
+	var date string
+	var blob []byte
+	err := s.db.QueryRow(`SELECT date, data FROM state WHERE kind = ? ORDER BY date DESC LIMIT 1`, kind).Scan(&date, &blob)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s state: %w", kind, err)
+	}
+	if err := json.Unmarshal(blob, v); err != nil {
+		return "", false, fmt.Errorf("corrupted %s state for %s: %w", kind, date, err)
+	}
+	return date, true, nil
+}
+
+// Put stores v as the kind record for date, replacing any earlier record
+func (s *Store) Put(kind, date string, v any) error {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.putRaw(kind, date, blob)
+}
+
+func (s *Store) putRaw(kind, date string, blob []byte) error {
+	_, err := s.db.Exec(
+		`INSERT INTO state (kind, date, updated_at, data) VALUES (?, ?, ?, ?)
+		 ON CONFLICT(kind, date) DO UPDATE SET updated_at = excluded.updated_at, data = excluded.data`,
+		kind, date, time.Now().Unix(), blob)
+	if err != nil {
+		return fmt.Errorf("failed to save %s state: %w", kind, err)
+	}
+	return nil
+}
+
+// Clean deletes every record older than cutoff (YYYY-MM-DD) and returns the
+// number removed.
+func (s *Store) Clean(cutoff string) (int64, error) {
+	res, err := s.db.Exec(`DELETE FROM state WHERE date < ?`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean state: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// MigrateFiles imports legacy <kind>-YYYY-MM-DD.json files from dir and
+// removes them. Records already in the store win over files, and files that
+// are not valid JSON are dropped, matching how they were read before.
+func (s *Store) MigrateFiles(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	migrated := 0
+	for _, e := range entries {
+		kind, date, ok := parseLegacyName(e.Name())
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+
+		blob, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if json.Valid(blob) {
+			_, err := s.db.Exec(
+				`INSERT INTO state (kind, date, updated_at, data) VALUES (?, ?, ?, ?)
+				 ON CONFLICT(kind, date) DO NOTHING`,
+				kind, date, time.Now().Unix(), blob)
+			if err != nil {
+				return migrated, fmt.Errorf("failed to migrate %s: %w", e.Name(), err)
+			}
+			migrated++
+		}
+		os.Remove(path)
+	}
+	return migrated, nil
+}
+
+// MigrateDatabase moves the records of the state table in the database at
+// path, where state lived alongside history before it had its own file,
+// into s and drops that table. Records already in s win.
+func (s *Store) MigrateDatabase(path string) (int64, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, nil
+	}
+	ctx := context.Background()
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS legacy`, path); err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer conn.ExecContext(ctx, `DETACH DATABASE legacy`)
+
+	var tables int
+	if err := conn.QueryRowContext(ctx, `SELECT count(*) FROM legacy.sqlite_master WHERE type = 'table' AND name = 'state'`).Scan(&tables); err != nil || tables == 0 {
+		return 0, err
+	}
+	res, err := conn.ExecContext(ctx, `INSERT INTO state SELECT * FROM legacy.state WHERE true ON CONFLICT(kind, date) DO NOTHING`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to migrate state from %s: %w", path, err)
+	}
+	migrated, _ := res.RowsAffected()
+	if _, err := conn.ExecContext(ctx, `DROP TABLE legacy.state`); err != nil {
+		return migrated, fmt.Errorf("failed to drop old state from %s: %w", path, err)
+	}
+	return migrated, nil
+}
+
+// parseLegacyName splits "network-2026-02-17.json" into its kind and date
+func parseLegacyName(name string) (kind, date string, ok bool) {
+	if !strings.HasSuffix(name, ".json") {
+		return "", "", false
+	}
+	for _, k := range legacyKinds {
+		if !strings.HasPrefix(name, k+"-") {
+			continue
+		}
+		date = strings.TrimSuffix(strings.TrimPrefix(name, k+"-"), ".json")
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return "", "", false
+		}
+		return k, date, true
+	}
+	return "", "", false
+}
+
+var (
+	defaultOnce  sync.Once
+	defaultStore *Store
+	defaultErr   error
+)
+
+// Default returns the process-wide store at DefaultPath. The first call
+// migrates state left in the data directory by earlier versions, as files
+// or as a table in history.db, and drops records older than KeepDays, so
+// cleanup happens once per run for every kind alike.
+func Default() (*Store, error) {
+	defaultOnce.Do(func() {
+		path, err := DefaultPath()
+		if err != nil {
+			defaultErr = fmt.Errorf("failed to get home directory: %w", err)
+			return
+		}
+		defaultStore, defaultErr = OpenPath(path)
+		if defaultErr != nil {
+			return
+		}
+		if dataDir, err := xdg.DataDir(); err == nil {
+			_, _ = defaultStore.MigrateFiles(dataDir)
+			_, _ = defaultStore.MigrateDatabase(filepath.Join(dataDir, "history.db"))
+		}
+		_, _ = defaultStore.Clean(time.Now().AddDate(0, 0, -KeepDays).Format("2006-01-02"))
+	})
+	return defaultStore, defaultErr
+}
+
+// Load reads the kind record for date from the default store into v
+func Load(kind, date string, v any) (bool, error) {
+	s, err := Default()
+	if err != nil {
+		return false, err
+	}
+	return s.Get(kind, date, v)
+}
+
+// LoadLatest reads the most recent kind record from the default store into v
+func LoadLatest(kind string, v any) (string, bool, error) {
+	s, err := Default()
+	if err != nil {
+		return "", false, err
+	}
+	return s.Latest(kind, v)
+}
+
+// Save writes v as the kind record for date in the default store
+func Save(kind, date string, v any) error {
+	s, err := Default()
+	if err != nil {
+		return err
+	}
+	return s.Put(kind, date, v)
+}
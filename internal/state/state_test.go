@@ -0,0 +1,204 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+	dir := t.TempDir()
+	s, err := OpenPath(filepath.Join(dir, "state.db"))
+	if err != nil {
+		t.Fatalf("OpenPath: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, dir
+}
+
+type reading struct {
+	Pct int `json:"pct"`
+}
+
+func TestGetPut(t *testing.T) {
+	s, _ := openTestStore(t)
+
+	var r reading
+	found, err := s.Get(KindBattery, "2026-02-17", &r)
+	if err != nil || found {
+		t.Fatalf("empty store: found=%v err=%v", found, err)
+	}
+
+	if err := s.Put(KindBattery, "2026-02-17", reading{Pct: 80}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Put(KindBattery, "2026-02-17", reading{Pct: 75}); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+
+	found, err = s.Get(KindBattery, "2026-02-17", &r)
+	if err != nil || !found {
+		t.Fatalf("Get: found=%v err=%v", found, err)
+	}
+	if r.Pct != 75 {
+		t.Errorf("Pct = %d, want 75 (latest write wins)", r.Pct)
+	}
+
+	// Kinds and days are independent
+	if found, _ := s.Get(KindNetwork, "2026-02-17", &r); found {
+		t.Error("other kind should have no record")
+	}
+	if found, _ := s.Get(KindBattery, "2026-02-18", &r); found {
+		t.Error("other day should have no record")
+	}
+}
+
+func TestClean(t *testing.T) {
+	s, _ := openTestStore(t)
+
+	for _, date := range []string{"2026-02-01", "2026-02-10", "2026-02-17"} {
+		if err := s.Put(KindNetwork, date, reading{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Put(KindScreen, "2026-02-01", reading{}); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := s.Clean("2026-02-10")
+	if err != nil {
+		t.Fatalf("Clean: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2 (one per kind before the cutoff)", removed)
+	}
+
+	var r reading
+	if found, _ := s.Get(KindNetwork, "2026-02-10", &r); !found {
+		t.Error("record on the cutoff day should be kept")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	s, _ := openTestStore(t)
+
+	var r reading
+	if _, found, err := s.Latest(KindAccess, &r); err != nil || found {
+		t.Fatalf("empty store: found=%v err=%v", found, err)
+	}
+	for i, date := range []string{"2026-02-10", "2026-02-17", "2026-02-12"} {
+		if err := s.Put(KindAccess, date, reading{Pct: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Put(KindBattery, "2026-02-20", reading{Pct: 99}); err != nil {
+		t.Fatal(err)
+	}
+
+	date, found, err := s.Latest(KindAccess, &r)
+	if err != nil || !found {
+		t.Fatalf("Latest: found=%v err=%v", found, err)
+	}
+	if date != "2026-02-17" || r.Pct != 1 {
+		t.Errorf("Latest = %s %+v, want 2026-02-17 with Pct 1", date, r)
+	}
+}
+
+func TestMigrateFiles(t *testing.T) {
+	s, dir := openTestStore(t)
+
+	files := map[string]string{
+		"network-2026-02-17.json": `{"interface":"en0","bytes_received":100}`,
+		"wifi-2026-02-17.json":    `[{"rssi":-60}]`,
+		"network-2026-02-16.json": `not valid json{{{`,
+		"network-corrupt.json":    `{}`,
+		"other-file.txt":          `keep`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A record already in the store wins over the legacy file
+	if err := s.Put(KindWiFi, "2026-02-17", []int{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := s.MigrateFiles(dir)
+	if err != nil {
+		t.Fatalf("MigrateFiles: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("migrated = %d, want 2", n)
+	}
+
+	var b struct {
+		Interface     string `json:"interface"`
+		BytesReceived int64  `json:"bytes_received"`
+	}
+	if found, err := s.Get(KindNetwork, "2026-02-17", &b); err != nil || !found || b.BytesReceived != 100 {
+		t.Errorf("network baseline not migrated: found=%v err=%v got=%+v", found, err, b)
+	}
+	var wifi []int
+	if _, err := s.Get(KindWiFi, "2026-02-17", &wifi); err != nil || len(wifi) != 1 || wifi[0] != 1 {
+		t.Errorf("existing wifi record should win, got %v (err %v)", wifi, err)
+	}
+	if found, _ := s.Get(KindNetwork, "2026-02-16", &b); found {
+		t.Error("corrupted file should not be migrated")
+	}
+
+	for _, name := range []string{"network-2026-02-17.json", "wifi-2026-02-17.json", "network-2026-02-16.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should have been removed", name)
+		}
+	}
+	for _, name := range []string{"network-corrupt.json", "other-file.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should still exist", name)
+		}
+	}
+}
+
+func TestMigrateDatabase(t *testing.T) {
+	s, dir := openTestStore(t)
+
+	// State as earlier versions kept it, in history.db
+	legacyPath := filepath.Join(dir, "history.db")
+	legacy, err := OpenPath(legacyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := legacy.Put(KindBattery, "2026-02-17", reading{Pct: 80}); err != nil {
+		t.Fatal(err)
+	}
+	if err := legacy.Put(KindBattery, "2026-02-18", reading{Pct: 60}); err != nil {
+		t.Fatal(err)
+	}
+	legacy.Close()
+
+	if err := s.Put(KindBattery, "2026-02-18", reading{Pct: 55}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := s.MigrateDatabase(legacyPath)
+	if err != nil || n != 1 {
+		t.Fatalf("MigrateDatabase = %d, %v; want 1 record moved", n, err)
+	}
+
+	var r reading
+	if found, err := s.Get(KindBattery, "2026-02-17", &r); err != nil || !found || r.Pct != 80 {
+		t.Errorf("migrated record = %+v, found=%v err=%v", r, found, err)
+	}
+	if _, err := s.Get(KindBattery, "2026-02-18", &r); err != nil || r.Pct != 55 {
+		t.Errorf("existing record was overwritten: %+v", r)
+	}
+
+	// The old table is gone, so a second run moves nothing
+	if n, err := s.MigrateDatabase(legacyPath); err != nil || n != 0 {
+		t.Errorf("second MigrateDatabase = %d, %v; want nothing", n, err)
+	}
+	if n, err := s.MigrateDatabase(filepath.Join(dir, "missing.db")); err != nil || n != 0 {
+		t.Errorf("MigrateDatabase(missing) = %d, %v", n, err)
+	}
+}
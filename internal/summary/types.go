@@ -1,20 +1,66 @@
 package summary
 
-import "github.com/alexinslc/rekap/internal/collectors"
+import (
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+)
 
 // Data holds all collector results for a single run.
 // Shared between cmd/rekap and internal/ui/tui to avoid duplication.
 type Data struct {
+	Window        collectors.Window // The span the collectors summarized
 	Uptime        collectors.UptimeResult
+	SleepWake     collectors.SleepWakeResult
+	TimeZone      collectors.TimeZoneResult
 	Battery       collectors.BatteryResult
 	Screen        collectors.ScreenResult
+	Daylight      collectors.DaylightResult
+	WorkHours     collectors.WorkHoursResult
+	Downloads     collectors.DownloadsResult
+	Updates       collectors.UpdatesResult
+	Screenshots   collectors.ScreenshotsResult
+	Memory        collectors.MemoryResult
+	CPU           collectors.CPUResult
 	Apps          collectors.AppsResult
+	AppSources    collectors.AppSourcesResult
+	AppCategories collectors.AppCategoriesResult
+	WindowTitles  collectors.WindowTitlesResult
+	Spaces        collectors.SpacesResult
+	SSH           collectors.SSHResult
+	Shell         collectors.ShellResult
+	Builds        collectors.BuildsResult
+	Reminders     collectors.RemindersResult
+	Docker        collectors.DockerResult
 	Focus         collectors.FocusResult
 	Media         collectors.MediaResult
+	AudioDevices  collectors.AudioDevicesResult
+	Music         collectors.MusicResult
 	Network       collectors.NetworkResult
+	WiFi          collectors.WiFiResult
+	VPN           collectors.VPNResult
 	Browsers      collectors.BrowsersResult
+	CloudConsoles collectors.CloudConsolesResult
 	Notifications collectors.NotificationsResult
+	Messages      collectors.MessagesResult
+	Sensors       collectors.SensorsResult
+	Clipboard     collectors.ClipboardResult
 	Issues        collectors.IssuesResult
 	Fragmentation collectors.FragmentationResult
 	Burnout       collectors.BurnoutResult
+	Projects      collectors.ProjectsResult
+	Learning      collectors.LearningResult
+	Sessions      collectors.SessionsResult
+	Meetings      collectors.MeetingsResult
+	DayBounds     collectors.DayBoundsResult
+	Notes         []Note                     // Notes attached to the window's day with "rekap note", oldest first
+	Custom        []collectors.CustomSection // Sections from custom_sections, in config order
+}
+
+// Note is a free-text annotation attached to a day
+type Note struct {
+	ID   int64
+	Date string    // YYYY-MM-DD day the note belongs to
+	Time time.Time // When the note was written
+	Text string
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/alexinslc/rekap/internal/xdg"
 )
 
 // Theme represents a complete color theme
@@ -25,6 +27,43 @@ type ThemeColors struct {
 	Warning   string `yaml:"warning"`
 	Muted     string `yaml:"muted"`
 	Text      string `yaml:"text"`
+
+	// Optional: themes without them derive chart and severity colors from
+	// the colors above, see ChartPalette and SeverityColors
+	Chart    []string       `yaml:"chart,omitempty"` // Series colors for bars and sparklines, in order
+	Severity SeverityColors `yaml:"severity,omitempty"`
+}
+
+// SeverityColors color warnings by how serious they are
+type SeverityColors struct {
+	Low    string `yaml:"low,omitempty"`
+	Medium string `yaml:"medium,omitempty"`
+	High   string `yaml:"high,omitempty"`
+}
+
+// ChartPalette returns the theme's chart colors, or primary, secondary,
+// accent, and success when it doesn't set any
+func (c ThemeColors) ChartPalette() []string {
+	if len(c.Chart) > 0 {
+		return c.Chart
+	}
+	return []string{c.Primary, c.Secondary, c.Accent, c.Success}
+}
+
+// SeverityPalette returns the theme's severity colors, filling unset ones
+// with accent (low), orange (medium), and warning (high)
+func (c ThemeColors) SeverityPalette() SeverityColors {
+	s := c.Severity
+	if s.Low == "" {
+		s.Low = c.Accent
+	}
+	if s.Medium == "" {
+		s.Medium = "214" // Orange
+	}
+	if s.High == "" {
+		s.High = c.Warning
+	}
+	return s
 }
 
 // builtInThemes contains all the built-in themes
@@ -40,6 +79,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "9",   // Bright red
 			Muted:     "240", // Darker gray
 			Text:      "255", // White
+			Chart:     []string{"13", "14", "10", "11", "12"},
+			Severity:  SeverityColors{Low: "11", Medium: "214", High: "9"}, // Yellow, orange, red
 		},
 	},
 	"minimal": {
@@ -53,6 +94,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "244", // Medium gray
 			Muted:     "240", // Dark gray
 			Text:      "255", // White
+			Chart:     []string{"255", "250", "246", "242"},
+			Severity:  SeverityColors{Low: "246", Medium: "250", High: "255"}, // Brighter is more serious
 		},
 	},
 	"hacker": {
@@ -66,6 +109,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "2",  // Green
 			Muted:     "22", // Dark green
 			Text:      "2",  // Green
+			Chart:     []string{"10", "2", "34", "22"},
+			Severity:  SeverityColors{Low: "2", Medium: "10", High: "46"}, // Brighter green is more serious
 		},
 	},
 	"pastel": {
@@ -79,6 +124,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "#ff9999", // Soft red
 			Muted:     "#cccccc", // Light gray
 			Text:      "#ffffff", // White
+			Chart:     []string{"#ff99cc", "#99ccff", "#ffcc99", "#99ff99", "#cc99ff"},
+			Severity:  SeverityColors{Low: "#ffee99", Medium: "#ffcc99", High: "#ff9999"}, // Soft yellow, orange, red
 		},
 	},
 	"nord": {
@@ -92,6 +139,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "#bf616a", // Nord aurora red
 			Muted:     "#4c566a", // Nord polar night
 			Text:      "#eceff4", // Nord snow storm
+			Chart:     []string{"#88c0d0", "#81a1c1", "#5e81ac", "#a3be8c", "#b48ead"},
+			Severity:  SeverityColors{Low: "#ebcb8b", Medium: "#d08770", High: "#bf616a"}, // Nord aurora
 		},
 	},
 	"dracula": {
@@ -105,6 +154,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "#ff5555", // Red
 			Muted:     "#6272a4", // Comment
 			Text:      "#f8f8f2", // Foreground
+			Chart:     []string{"#ff79c6", "#8be9fd", "#bd93f9", "#50fa7b", "#ffb86c"},
+			Severity:  SeverityColors{Low: "#f1fa8c", Medium: "#ffb86c", High: "#ff5555"}, // Yellow, orange, red
 		},
 	},
 	"solarized": {
@@ -118,6 +169,8 @@ var builtInThemes = map[string]Theme{
 			Warning:   "#dc322f", // Red
 			Muted:     "#586e75", // Base01
 			Text:      "#93a1a1", // Base1
+			Chart:     []string{"#268bd2", "#2aa198", "#6c71c4", "#859900", "#d33682"},
+			Severity:  SeverityColors{Low: "#b58900", Medium: "#cb4b16", High: "#dc322f"}, // Yellow, orange, red
 		},
 	},
 }
@@ -137,6 +190,34 @@ func ListBuiltIn() []string {
 	return names
 }
 
+// Dir returns the themes directory, $XDG_CONFIG_HOME/rekap/themes
+// (~/.config/rekap/themes by default)
+func Dir() (string, error) {
+	dir, err := xdg.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "themes"), nil
+}
+
+// ListInstalled returns the names of the theme files in the themes
+// directory, which Load finds by name
+func ListInstalled() []string {
+	dir, err := Dir()
+	if err != nil {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = strings.TrimSuffix(filepath.Base(file), ".yaml")
+	}
+	return names
+}
+
 // LoadFromFile loads a theme from a YAML file
 func LoadFromFile(path string) (Theme, error) {
 	var theme Theme
@@ -175,9 +256,8 @@ func Load(nameOrPath string) (Theme, error) {
 	}
 
 	// Otherwise, check in themes directory
-	homeDir, err := os.UserHomeDir()
+	themesDir, err := Dir()
 	if err == nil {
-		themesDir := filepath.Join(homeDir, ".config", "rekap", "themes")
 		// Try with .yaml extension
 		if filepath.Ext(path) == "" {
 			path = filepath.Join(themesDir, path+".yaml")
@@ -212,6 +292,11 @@ func (t *Theme) Validate() error {
 	if t.Colors.Text == "" {
 		return fmt.Errorf("theme missing required color: text")
 	}
+	for i, c := range t.Colors.Chart {
+		if c == "" {
+			return fmt.Errorf("theme chart color %d is empty", i+1)
+		}
+	}
 
 	return nil
 }
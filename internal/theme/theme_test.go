@@ -3,6 +3,7 @@ package theme
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -207,3 +208,25 @@ func TestLoad(t *testing.T) {
 		}
 	})
 }
+
+func TestPaletteDefaults(t *testing.T) {
+	t.Parallel()
+	colors := ThemeColors{Primary: "1", Secondary: "2", Accent: "3", Success: "4", Warning: "5"}
+
+	if got := colors.ChartPalette(); !reflect.DeepEqual(got, []string{"1", "2", "3", "4"}) {
+		t.Errorf("ChartPalette() = %v, want primary, secondary, accent, success", got)
+	}
+	want := SeverityColors{Low: "3", Medium: "214", High: "5"}
+	if got := colors.SeverityPalette(); got != want {
+		t.Errorf("SeverityPalette() = %+v, want %+v", got, want)
+	}
+
+	colors.Chart = []string{"#ff0000"}
+	colors.Severity = SeverityColors{High: "#00ff00"}
+	if got := colors.ChartPalette(); !reflect.DeepEqual(got, []string{"#ff0000"}) {
+		t.Errorf("ChartPalette() = %v, want the theme's chart colors", got)
+	}
+	if got := colors.SeverityPalette().High; got != "#00ff00" {
+		t.Errorf("SeverityPalette().High = %q, want %q", got, "#00ff00")
+	}
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkLevels are the glyphs a sparkline uses, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// barEighths are the partial cells at the end of a bar, one to seven eighths
+var barEighths = []rune("▏▎▍▌▋▊▉")
+
+// chartStyle returns the style for chart series, cycling through the palette
+func chartStyle(series int) lipgloss.Style {
+	if len(chartPalette) == 0 {
+		return lipgloss.NewStyle()
+	}
+	if series < 0 {
+		series = 0
+	}
+	return lipgloss.NewStyle().Foreground(chartPalette[series%len(chartPalette)])
+}
+
+// RenderBar draws value as a horizontal bar out of width cells, full at
+// max, in the chart color for series. The bar is padded to width so text
+// after it lines up.
+func RenderBar(value, max, width, series int) string {
+	if width <= 0 {
+		return ""
+	}
+	eighths := 0
+	if max > 0 && value > 0 {
+		eighths = min(value*width*8/max, width*8)
+		if eighths == 0 {
+			eighths = 1 // Anything above zero shows
+		}
+	}
+
+	bar := strings.Repeat("█", eighths/8)
+	if rest := eighths % 8; rest > 0 {
+		bar += string(barEighths[rest-1])
+	}
+	cells := len([]rune(bar))
+	return chartStyle(series).Render(bar) + strings.Repeat(" ", width-cells)
+}
+
+// RenderSparkline draws values as one row of block glyphs scaled to the
+// largest, in the chart color for series. Negative values are gaps with no
+// data and show as a muted dot.
+func RenderSparkline(values []int, series int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	style := chartStyle(series)
+	gap := lipgloss.NewStyle().Foreground(mutedColor).Render("·")
+	var b strings.Builder
+	for _, v := range values {
+		if v < 0 {
+			b.WriteString(gap)
+			continue
+		}
+		level := 0
+		if peak > 0 {
+			level = v * (len(sparkLevels) - 1) / peak
+		}
+		b.WriteString(style.Render(string(sparkLevels[level])))
+	}
+	return b.String()
+}
+
+// stackShades tell the segments of a stacked bar apart without color
+var stackShades = []rune("█▓▒░")
+
+// RenderStackedBar draws values as one bar of width cells split in
+// proportion to each value, cycling chart colors and shades per segment.
+// Every value above zero gets at least one cell when there's room.
+func RenderStackedBar(values []int, width int) string {
+	total := 0
+	for _, v := range values {
+		total += max(v, 0)
+	}
+	if width <= 0 || total == 0 {
+		return strings.Repeat(" ", max(width, 0))
+	}
+
+	var b strings.Builder
+	used, sum := 0, 0
+	for i, v := range values {
+		if v <= 0 {
+			continue
+		}
+		sum += v
+		end := sum * width / total
+		cells := end - used
+		if cells == 0 && used < width {
+			cells = 1
+		}
+		cells = min(cells, width-used)
+		if cells <= 0 {
+			continue
+		}
+		used += cells
+		shade := string(stackShades[i%len(stackShades)])
+		b.WriteString(chartStyle(i).Render(strings.Repeat(shade, cells)))
+	}
+	return b.String() + strings.Repeat(" ", width-used)
+}
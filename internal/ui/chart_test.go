@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderBar(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value, max, width int
+		want              string
+	}{
+		{100, 100, 4, "████"},
+		{50, 100, 4, "██  "},
+		{45, 100, 4, "█▊  "},
+		{1, 1000, 4, "▏   "},
+		{0, 100, 4, "    "},
+		{200, 100, 4, "████"},
+		{5, 0, 3, "   "},
+	}
+	for _, tt := range tests {
+		if got := ansi.Strip(RenderBar(tt.value, tt.max, tt.width, 0)); got != tt.want {
+			t.Errorf("RenderBar(%d, %d, %d) = %q, want %q", tt.value, tt.max, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	t.Parallel()
+	got := ansi.Strip(RenderSparkline([]int{0, 35, 70, -1, 140}, 1))
+	if want := "▁▂▄·█"; got != want {
+		t.Errorf("RenderSparkline = %q, want %q", got, want)
+	}
+	if got := ansi.Strip(RenderSparkline([]int{0, 0}, 0)); got != "▁▁" {
+		t.Errorf("RenderSparkline of zeros = %q, want %q", got, "▁▁")
+	}
+}
+
+func TestRenderStackedBar(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		values []int
+		width  int
+		want   string
+	}{
+		{[]int{50, 25, 25}, 8, "████▓▓▒▒"},
+		{[]int{99, 1}, 4, "███▓"},
+		{[]int{0, 10}, 4, "▓▓▓▓"},
+		{nil, 3, "   "},
+	}
+	for _, tt := range tests {
+		if got := ansi.Strip(RenderStackedBar(tt.values, tt.width)); got != tt.want {
+			t.Errorf("RenderStackedBar(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSeverityWarning(t *testing.T) {
+	t.Parallel()
+	got := ansi.Strip(RenderSeverityWarning("high", "⚠️", "Long day"))
+	if got != "  ⚠️  Long day" {
+		t.Errorf("RenderSeverityWarning = %q", got)
+	}
+}
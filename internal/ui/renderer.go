@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
 	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/term"
 )
@@ -21,6 +22,14 @@ var (
 	mutedColor     = lipgloss.Color("240") // Darker gray
 	textColor      = lipgloss.Color("255") // White
 
+	// Chart series colors and warning colors by severity
+	chartPalette   = []lipgloss.Color{"13", "14", "11", "10"}
+	severityColors = map[string]lipgloss.Color{
+		"low":    "11",  // Bright yellow
+		"medium": "214", // Orange
+		"high":   "9",   // Bright red
+	}
+
 	// Accessibility settings
 	accessibilityEnabled = false
 	accessibilityNoEmoji = false
@@ -84,6 +93,8 @@ func ApplyColors(cfg *config.Config) {
 		warningColor = lipgloss.Color("15")   // White
 		mutedColor = lipgloss.Color("250")    // Light gray
 		textColor = lipgloss.Color("15")      // White
+		chartPalette = []lipgloss.Color{"15"}
+		severityColors = map[string]lipgloss.Color{"low": "15", "medium": "15", "high": "15"}
 	} else {
 		primaryColor = lipgloss.Color(cfg.Colors.Primary)
 		secondaryColor = lipgloss.Color(cfg.Colors.Secondary)
@@ -92,6 +103,17 @@ func ApplyColors(cfg *config.Config) {
 		warningColor = lipgloss.Color(cfg.Colors.Warning)
 		mutedColor = lipgloss.Color(cfg.Colors.Muted)
 		textColor = lipgloss.Color(cfg.Colors.Text)
+
+		chartPalette = nil
+		for _, c := range cfg.ChartPalette() {
+			chartPalette = append(chartPalette, lipgloss.Color(c))
+		}
+		severity := cfg.SeverityPalette()
+		severityColors = map[string]lipgloss.Color{
+			"low":    lipgloss.Color(severity.Low),
+			"medium": lipgloss.Color(severity.Medium),
+			"high":   lipgloss.Color(severity.High),
+		}
 	}
 
 	// Rebuild styles with new colors
@@ -268,6 +290,29 @@ func FormatTime(t time.Time, timeFormat string) string {
 	return t.Format("3:04 PM")
 }
 
+// FormatDayBounds describes a working day's span, e.g.
+// "8:42 AM → 6:15 PM (9h 33m)" or "8:42 AM → still going (9h 33m so far)"
+func FormatDayBounds(arrival, wrapUp time.Time, ongoing bool, spanMinutes int, timeFormat string) string {
+	if ongoing {
+		return fmt.Sprintf("%s → still going (%s so far)", FormatTime(arrival, timeFormat), FormatDuration(spanMinutes))
+	}
+	return fmt.Sprintf("%s → %s (%s)", FormatTime(arrival, timeFormat), FormatTime(wrapUp, timeFormat), FormatDuration(spanMinutes))
+}
+
+// FormatPeriod names the span a summary covers for use in sentences:
+// "today", "on Mon, Feb 17", "since 6:00 AM", or "from 6:00 AM to 10:00 AM"
+func FormatPeriod(start, end time.Time, wholeDay, live bool, timeFormat string) string {
+	switch {
+	case wholeDay && live:
+		return "today"
+	case wholeDay:
+		return "on " + locale.Current().ShortDate(start)
+	case live:
+		return "since " + FormatTime(start, timeFormat)
+	}
+	return fmt.Sprintf("from %s to %s", FormatTime(start, timeFormat), FormatTime(end, timeFormat))
+}
+
 // removeEmoji strips emoji characters from text
 func removeEmoji(text string) string {
 	// Simple approach: keep only ASCII printable characters and spaces
@@ -299,14 +344,55 @@ func removeEmoji(text string) string {
 // getAccessibleIcon returns a text-based alternative to emoji icons
 var accessibleIconMap = map[string]string{
 	"⏰":  "[TIME]",
+	"🌅":  "[DAY]",
+	"☀️": "[WAKE]",
+	"💤":  "[SLEEP]",
+	"⏳":  "[AWAKE]",
+	"🌇":  "[DUSK]",
+	"🏢":  "[WORK]",
+	"✈️": "[TRAVEL]",
 	"🔋":  "[BAT]",
 	"🔌":  "[PWR]",
 	"📱":  "[APP]",
 	"⏱️": "[FOCUS]",
+	"🧭":  "[SESS]",
+	"📅":  "[CAL]",
+	"🟢":  "[FREE]",
+	"🗂️": "[SPACE]",
+	"🔁":  "[OPEN]",
+	"🛡️": "[SRC]",
+	"🥧":  "[CAT]",
+	"🖥️": "[SSH]",
+	"⌨️": "[SHELL]",
+	"☁️": "[CLOUD]",
+	"📚":  "[LEARN]",
+	"🧩":  "[PLUG]",
 	"🎵":  "[MUSIC]",
+	"🎶":  "[LISTEN]",
+	"🎙️": "[PODCAST]",
 	"🌐":  "[NET]",
+	"📶":  "[WIFI]",
+	"📋":  "[PROJ]",
+	"🔗":  "[LINK]",
+	"📝":  "[NOTE]",
+	"🏆":  "[BEST]",
 	"📊":  "[DATA]",
 	"💡":  "[INFO]",
+	"👉":  "[TODO]",
+	"💬":  "[MSG]",
+	"📥":  "[DL]",
+	"🧠":  "[MEM]",
+	"🌡️": "[CPU]",
+	"🔐":  "[VPN]",
+	"🎧":  "[AUDIO]",
+	"📷":  "[CAM]",
+	"✂️": "[CLIP]",
+	"🔨":  "[BUILD]",
+	"✅":  "[DONE]",
+	"🐳":  "[DOCKER]",
+	"🍺":  "[BREW]",
+	"📸":  "[SHOT]",
+	"⬆️": "[UPDATE]",
 	"✓":  "[OK]",
 	"✗":  "[ERR]",
 }
@@ -322,3 +408,21 @@ func getAccessibleIcon(emoji string) string {
 func RenderBurnoutWarning(icon, text string) string {
 	return fmt.Sprintf("  %s  %s", icon, warningSubtleStyle.Render(text))
 }
+
+// RenderSeverityWarning formats a warning in the color for its severity
+// ("low", "medium", or "high"). In accessibility mode the severity is also
+// spelled out, since it can't rely on color alone.
+func RenderSeverityWarning(severity, icon, text string) string {
+	if accessibilityEnabled && accessibilityNoEmoji {
+		icon = getAccessibleIcon(icon)
+	}
+	color, ok := severityColors[severity]
+	if !ok {
+		color = severityColors["medium"]
+	}
+	if accessibilityEnabled && ok {
+		text = "[" + strings.ToUpper(severity) + "] " + text
+	}
+	style := lipgloss.NewStyle().Foreground(color).Italic(true)
+	return fmt.Sprintf("  %s  %s", icon, style.Render(text))
+}
@@ -9,7 +9,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/alexinslc/rekap/internal/collectors"
 	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
+	"github.com/alexinslc/rekap/internal/ui"
 )
 
 // Section represents a single summary section shown in the TUI.
@@ -22,27 +25,92 @@ type Section struct {
 	Expanded  string
 }
 
+// RefreshFunc collects again and returns the new sections and the window
+// they cover
+type RefreshFunc func() ([]Section, collectors.Window)
+
 type Model struct {
-	sections  []Section
-	cursor    int
-	drillDown bool
-	viewport  viewport.Model
-	width     int
-	height    int
-	ready     bool
-	tooSmall  bool
-	styles    tuiStyles
-	palette   colorPalette
-	date      string
+	sections   []Section
+	cursor     int
+	drillDown  bool
+	viewport   viewport.Model
+	width      int
+	height     int
+	ready      bool
+	tooSmall   bool
+	styles     tuiStyles
+	palette    colorPalette
+	date       string
+	timeFormat string
+
+	// Set by WithRefresh for "rekap watch"
+	refresh    RefreshFunc
+	interval   time.Duration
+	updated    time.Time
+	refreshing bool
+	tick       int // Only the latest scheduled tick refreshes
 }
 
-func New(sections []Section, cfg *config.Config) Model {
+func New(sections []Section, cfg *config.Config, w collectors.Window) Model {
 	palette := colorsFromConfig(cfg)
 	return Model{
-		sections: sections,
-		styles:   buildStylesFromPalette(palette),
-		palette:  palette,
-		date:     time.Now().Format("Mon, Jan 2 2006"),
+		sections:   sections,
+		styles:     buildStylesFromPalette(palette),
+		palette:    palette,
+		date:       windowTitle(w, cfg.Display.TimeFormat),
+		timeFormat: cfg.Display.TimeFormat,
+	}
+}
+
+// WithRefresh makes the model call refresh every interval, and when r is
+// pressed, and show the new sections in place
+func (m Model) WithRefresh(interval time.Duration, refresh RefreshFunc) Model {
+	m.refresh = refresh
+	m.interval = interval
+	m.updated = time.Now()
+	return m
+}
+
+// windowTitle is the date shown in the title bar, with the times when w
+// isn't a whole day
+func windowTitle(w collectors.Window, timeFormat string) string {
+	date := locale.Current().ShortDateYear(w.Start)
+	if !w.WholeDay() {
+		date += " " + ui.FormatPeriod(w.Start, w.End, false, w.Live(), timeFormat)
+	}
+	return date
+}
+
+// refreshTickMsg is sent when the refresh interval is up
+type refreshTickMsg struct{ tick int }
+
+// refreshedMsg carries the sections from a finished refresh
+type refreshedMsg struct {
+	sections []Section
+	window   collectors.Window
+	at       time.Time
+}
+
+// scheduleRefresh starts the wait for the next refresh, superseding any
+// tick already scheduled
+func (m *Model) scheduleRefresh() tea.Cmd {
+	m.tick++
+	return m.waitForTick()
+}
+
+// waitForTick sends the current tick after the refresh interval
+func (m Model) waitForTick() tea.Cmd {
+	tick := m.tick
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return refreshTickMsg{tick: tick} })
+}
+
+// startRefresh runs the refresh in the background
+func (m *Model) startRefresh() tea.Cmd {
+	m.refreshing = true
+	refresh := m.refresh
+	return func() tea.Msg {
+		sections, w := refresh()
+		return refreshedMsg{sections: sections, window: w, at: time.Now()}
 	}
 }
 
@@ -81,11 +149,33 @@ func colorsFromConfig(cfg *config.Config) colorPalette {
 }
 
 func (m Model) Init() tea.Cmd {
-	return nil
+	if m.refresh == nil {
+		return nil
+	}
+	return m.waitForTick()
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case refreshTickMsg:
+		if msg.tick != m.tick || m.refreshing {
+			return m, nil
+		}
+		return m, m.startRefresh()
+
+	case refreshedMsg:
+		m.sections = msg.sections
+		m.date = windowTitle(msg.window, m.timeFormat)
+		m.updated = msg.at
+		m.refreshing = false
+		if m.cursor >= len(m.sections) {
+			m.cursor = max(len(m.sections)-1, 0)
+		}
+		if m.ready {
+			m.viewport.SetContent(m.detailContent())
+		}
+		return m, m.scheduleRefresh()
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -115,6 +205,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 
+		case "r":
+			if m.refresh != nil && !m.refreshing {
+				return m, m.startRefresh()
+			}
+
 		case "esc":
 			if m.drillDown {
 				m.drillDown = false
@@ -183,7 +278,13 @@ func (m Model) View() string {
 	}
 
 	// Title bar
-	title := m.styles.titleBar.Render(fmt.Sprintf("rekap - %s", m.date))
+	titleText := fmt.Sprintf("rekap - %s", m.date)
+	if m.refreshing {
+		titleText += " • refreshing…"
+	} else if m.refresh != nil {
+		titleText += " • updated " + ui.FormatTime(m.updated, m.timeFormat)
+	}
+	title := m.styles.titleBar.Render(titleText)
 	titleBar := lipgloss.NewStyle().
 		Width(m.width).
 		BorderStyle(lipgloss.NormalBorder()).
@@ -209,6 +310,9 @@ func (m Model) View() string {
 	} else {
 		footerText = "j/k navigate  Enter detail  Esc/q quit"
 	}
+	if m.refresh != nil {
+		footerText += "  r refresh"
+	}
 	footer := m.styles.footerBar.Render(footerText)
 
 	return lipgloss.JoinVertical(lipgloss.Left, titleBar, body, footer)
@@ -0,0 +1,297 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/theme"
+)
+
+// Onboarding wizard steps, in order
+const (
+	stepPermissions = iota
+	stepTheme
+	stepSections
+	stepSchedule
+	stepReview
+)
+
+var onboardingStepNames = []string{"Permissions", "Theme", "Sections", "Schedule", "Save"}
+
+// notifyHours are the times the schedule step offers for a daily
+// notification; -1 is no schedule
+var notifyHours = []int{-1, 17, 18, 19}
+
+// OnboardingChoices is what the onboarding wizard settled on
+type OnboardingChoices struct {
+	Theme    string   // Built-in theme name
+	Skip     []string // Sections turned off
+	NotifyAt int      // Hour of day for a daily "rekap notify"; -1 for none
+}
+
+// OnboardingModel is the first-run wizard: permissions, then a theme,
+// the sections to show, and an optional daily notification, saved
+// together at the end. It only collects choices; the caller writes them.
+type OnboardingModel struct {
+	cfg   *config.Config
+	step  int
+	perms PermissionsModel
+
+	themes      []string
+	themeCursor int
+
+	sections      []string
+	sectionOn     []bool
+	sectionCursor int
+
+	notifyCursor int
+
+	saved  bool
+	styles tuiStyles
+}
+
+// NewOnboarding builds the wizard, starting from cfg's sections and the
+// default theme
+func NewOnboarding(cfg *config.Config) OnboardingModel {
+	themes := theme.ListBuiltIn()
+	slices.Sort(themes)
+	// The default theme first, where the cursor starts
+	if i := slices.Index(themes, "default"); i > 0 {
+		themes = append([]string{"default"}, slices.Delete(themes, i, i+1)...)
+	}
+
+	sections := append([]string{}, config.SectionKeys...)
+	on := make([]bool, len(sections))
+	for i, s := range sections {
+		on[i] = cfg.SectionEnabled(s)
+	}
+
+	m := OnboardingModel{
+		cfg:       cfg,
+		perms:     NewPermissions(cfg),
+		themes:    themes,
+		sections:  sections,
+		sectionOn: on,
+	}
+	m.restyle()
+	return m
+}
+
+// Choices returns what the user picked, and whether they saved rather
+// than quit
+func (m OnboardingModel) Choices() (OnboardingChoices, bool) {
+	choices := OnboardingChoices{Theme: m.themes[m.themeCursor], NotifyAt: notifyHours[m.notifyCursor]}
+	for i, on := range m.sectionOn {
+		if !on {
+			choices.Skip = append(choices.Skip, m.sections[i])
+		}
+	}
+	return choices, m.saved
+}
+
+// restyle previews the theme under the cursor across the whole wizard
+func (m *OnboardingModel) restyle() {
+	preview := *m.cfg
+	if t, ok := theme.GetBuiltIn(m.themes[m.themeCursor]); ok {
+		preview.ApplyTheme(t)
+	}
+	m.styles = buildStylesFromPalette(colorsFromConfig(&preview))
+	m.perms.styles = m.styles
+}
+
+func (m OnboardingModel) Init() tea.Cmd {
+	return m.perms.Init()
+}
+
+func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Permission checks keep polling whatever step is showing
+		perms, cmd := m.perms.Update(msg)
+		m.perms = perms.(PermissionsModel)
+		return m, cmd
+	}
+
+	switch key.String() {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "tab", "right", "l":
+		if m.step < stepReview {
+			m.step++
+		}
+		return m, nil
+	case "shift+tab", "left", "h":
+		if m.step > stepPermissions {
+			m.step--
+		}
+		return m, nil
+	}
+
+	switch m.step {
+	case stepPermissions:
+		perms, cmd := m.perms.Update(msg)
+		m.perms = perms.(PermissionsModel)
+		return m, cmd
+	case stepTheme:
+		m.themeCursor = moveCursor(key.String(), m.themeCursor, len(m.themes))
+		m.restyle()
+	case stepSections:
+		m.sectionCursor = moveCursor(key.String(), m.sectionCursor, len(m.sections))
+		if key.String() == " " || key.String() == "x" {
+			m.sectionOn[m.sectionCursor] = !m.sectionOn[m.sectionCursor]
+		}
+	case stepSchedule:
+		m.notifyCursor = moveCursor(key.String(), m.notifyCursor, len(notifyHours))
+	case stepReview:
+		if key.String() == "enter" {
+			m.saved = true
+			return m, tea.Quit
+		}
+	}
+	if key.String() == "enter" && m.step < stepReview {
+		m.step++
+	}
+	return m, nil
+}
+
+// moveCursor applies an up or down key to a cursor over n items
+func moveCursor(key string, cursor, n int) int {
+	switch key {
+	case "up", "k":
+		if cursor > 0 {
+			cursor--
+		}
+	case "down", "j":
+		if cursor < n-1 {
+			cursor++
+		}
+	}
+	return cursor
+}
+
+func (m OnboardingModel) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.titleBar.Render("👋 Welcome to rekap") + "\n")
+
+	// Step breadcrumbs
+	var crumbs []string
+	for i, name := range onboardingStepNames {
+		switch {
+		case i == m.step:
+			crumbs = append(crumbs, m.styles.highlight.Render(name))
+		default:
+			crumbs = append(crumbs, m.styles.muted.Render(name))
+		}
+	}
+	b.WriteString(" " + strings.Join(crumbs, m.styles.muted.Render(" › ")) + "\n\n")
+
+	footer := "Tab next  Shift-Tab back  q quit without saving"
+	switch m.step {
+	case stepPermissions:
+		b.WriteString(m.perms.body("Press Tab to continue."))
+		footer = "j/k select  Enter open System Settings  " + footer
+	case stepTheme:
+		b.WriteString(m.themeView())
+		footer = "j/k choose  " + footer
+	case stepSections:
+		b.WriteString(m.sectionsView())
+		footer = "j/k select  Space toggle  " + footer
+	case stepSchedule:
+		b.WriteString(m.scheduleView())
+		footer = "j/k choose  " + footer
+	case stepReview:
+		b.WriteString(m.reviewView())
+		footer = "Enter save  Shift-Tab back  q quit without saving"
+	}
+
+	b.WriteString("\n" + m.styles.footerBar.Render(footer))
+	return b.String()
+}
+
+// choiceLine renders one row of a list, marked when it's under the cursor
+func (m OnboardingModel) choiceLine(selected bool, text string) string {
+	if selected {
+		return " > " + m.styles.sidebarActive.Render(text) + "\n"
+	}
+	return "   " + m.styles.dataValue.Render(text) + "\n"
+}
+
+func (m OnboardingModel) themeView() string {
+	var b strings.Builder
+	b.WriteString(" Pick a color theme. The wizard previews it as you move.\n\n")
+	for i, name := range m.themes {
+		b.WriteString(m.choiceLine(i == m.themeCursor, name))
+	}
+	b.WriteString("\n " + m.styles.sectionHeader.Render("PRODUCTIVITY") + "\n")
+	b.WriteString("   " + m.styles.dataLabel.Render("Best focus:") + " " + m.styles.highlight.Render("1h 27m") + m.styles.dataValue.Render(" in VS Code") + "\n")
+	b.WriteString("   " + m.styles.success.Render("✓ 7 reminders completed") + "  " + m.styles.warning.Render("⚠ 11h screen-on") + "\n")
+	b.WriteString("   " + m.styles.muted.Render("Screen Time via knowledgeC") + "\n")
+	return b.String()
+}
+
+func (m OnboardingModel) sectionsView() string {
+	var b strings.Builder
+	b.WriteString(" Choose the sections to show. Turned-off sections are skipped entirely,\n")
+	b.WriteString(" so rekap runs faster. 'rekap sections' changes them later.\n\n")
+	for i, name := range m.sections {
+		box := m.styles.success.Render("[x]")
+		if !m.sectionOn[i] {
+			box = m.styles.muted.Render("[ ]")
+		}
+		cursor := "   "
+		label := m.styles.dataValue.Render(name)
+		if i == m.sectionCursor {
+			cursor = " > "
+			label = m.styles.sidebarActive.Render(name)
+		}
+		b.WriteString(cursor + box + " " + label + "\n")
+	}
+	return b.String()
+}
+
+func (m OnboardingModel) scheduleView() string {
+	var b strings.Builder
+	b.WriteString(" rekap can post the day's summary as a notification every evening,\n")
+	b.WriteString(" using a launchd agent that runs 'rekap notify'.\n\n")
+	for i, hour := range notifyHours {
+		b.WriteString(m.choiceLine(i == m.notifyCursor, notifyLabel(hour)))
+	}
+	return b.String()
+}
+
+// notifyLabel describes a schedule choice
+func notifyLabel(hour int) string {
+	if hour < 0 {
+		return "No schedule"
+	}
+	return fmt.Sprintf("Notify me daily at %d:00 PM", hour-12)
+}
+
+func (m OnboardingModel) reviewView() string {
+	choices, _ := m.Choices()
+	var b strings.Builder
+	b.WriteString(" Ready to save your config:\n\n")
+	row := func(label, value string) {
+		b.WriteString("   " + m.styles.dataLabel.Render(fmt.Sprintf("%-10s", label)) + " " + m.styles.dataValue.Render(value) + "\n")
+	}
+
+	granted := 0
+	for _, ok := range m.perms.granted {
+		if ok {
+			granted++
+		}
+	}
+	row("Access", fmt.Sprintf("%d of %d permissions granted", granted, len(m.perms.perms)))
+	row("Theme", choices.Theme)
+	if len(choices.Skip) == 0 {
+		row("Sections", "all")
+	} else {
+		row("Sections", "all but "+strings.Join(choices.Skip, ", "))
+	}
+	row("Schedule", notifyLabel(choices.NotifyAt))
+	return b.String()
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/permissions"
+)
+
+// permissionPoll is how often the wizard rechecks every permission
+const permissionPoll = 2 * time.Second
+
+// PermissionsModel is the "rekap init" wizard: every permission with its
+// live status, the terminal it has to be granted to, and a key to open the
+// right System Settings pane
+type PermissionsModel struct {
+	perms    []permissions.Permission
+	granted  []bool
+	checked  bool // At least one check finished
+	checking bool
+	cursor   int
+	terminal string
+	status   string // Result of the last action
+	styles   tuiStyles
+}
+
+type permissionsCheckedMsg []bool
+type permissionsPollMsg struct{}
+type settingsOpenedMsg struct {
+	name string
+	err  error
+}
+
+// NewPermissions builds the wizard for the terminal rekap is running in
+func NewPermissions(cfg *config.Config) PermissionsModel {
+	perms := permissions.All()
+	return PermissionsModel{
+		perms:    perms,
+		granted:  make([]bool, len(perms)),
+		checking: true,
+		terminal: permissions.Terminal(),
+		styles:   buildStylesFromPalette(colorsFromConfig(cfg)),
+	}
+}
+
+func (m PermissionsModel) Init() tea.Cmd {
+	return m.check()
+}
+
+// check runs every permission check off the UI goroutine; some shell out
+// to osascript
+func (m PermissionsModel) check() tea.Cmd {
+	perms := m.perms
+	return func() tea.Msg {
+		granted := make([]bool, len(perms))
+		for i, p := range perms {
+			granted[i] = p.Check()
+		}
+		return permissionsCheckedMsg(granted)
+	}
+}
+
+func (m PermissionsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case permissionsCheckedMsg:
+		for i, ok := range msg {
+			if ok && m.checked && !m.granted[i] {
+				m.status = m.perms[i].Name + " granted"
+			}
+		}
+		m.granted = msg
+		m.checked = true
+		m.checking = false
+		return m, tea.Tick(permissionPoll, func(time.Time) tea.Msg { return permissionsPollMsg{} })
+
+	case permissionsPollMsg:
+		if m.checking {
+			return m, nil
+		}
+		m.checking = true
+		return m, m.check()
+
+	case settingsOpenedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Couldn't open System Settings: %v", msg.err)
+		} else {
+			m.status = fmt.Sprintf("Opened %s, turn on %s there", msg.name, m.terminalName())
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.perms)-1 {
+				m.cursor++
+			}
+		case "enter", "o":
+			p := m.perms[m.cursor]
+			return m, func() tea.Msg {
+				return settingsOpenedMsg{name: p.Name, err: permissions.OpenSettings(p.Pane)}
+			}
+		}
+	}
+	return m, nil
+}
+
+// terminalName names the app to grant permissions to, for sentences
+func (m PermissionsModel) terminalName() string {
+	if m.terminal == "" {
+		return "your terminal app"
+	}
+	return m.terminal
+}
+
+func (m PermissionsModel) View() string {
+	return m.styles.titleBar.Render("🔐 rekap permission setup") + "\n\n" +
+		m.body("Press q and run 'rekap'.") + "\n" +
+		m.styles.footerBar.Render("j/k select  Enter open System Settings  q quit")
+}
+
+// body is the permission list and status line, which the onboarding
+// wizard shows as its first step. next tells the user what to do once
+// everything is granted.
+func (m PermissionsModel) body(next string) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(" macOS grants these to the app rekap runs in: %s.\n",
+		m.styles.highlight.Render(m.terminalName())))
+	b.WriteString(m.styles.muted.Render(" Turn it on in each pane, not rekap. Full Disk Access may need the app restarted.") + "\n\n")
+
+	for i, p := range m.perms {
+		mark := m.styles.muted.Render("…")
+		if m.checked {
+			mark = m.styles.warning.Render("✗")
+			if m.granted[i] {
+				mark = m.styles.success.Render("✓")
+			}
+		}
+		name := m.styles.dataValue.Render(fmt.Sprintf("%-17s", p.Name))
+		if i == m.cursor {
+			name = m.styles.sidebarActive.Render(fmt.Sprintf("%-17s", p.Name))
+		}
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		b.WriteString(fmt.Sprintf(" %s%s %s %s\n", cursor, mark, name, m.styles.dataLabel.Render(p.Enables)))
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.checked && allGranted(m.granted):
+		b.WriteString(m.styles.success.Render(" All permissions granted. "+next) + "\n")
+	case m.status != "":
+		b.WriteString(" " + m.status + "\n")
+	default:
+		b.WriteString(m.styles.muted.Render(fmt.Sprintf(" Checking every %s...", permissionPoll)) + "\n")
+	}
+	return b.String()
+}
+
+func allGranted(granted []bool) bool {
+	for _, ok := range granted {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
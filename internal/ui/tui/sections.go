@@ -4,25 +4,54 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/alexinslc/rekap/internal/collectors"
 	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/locale"
 	"github.com/alexinslc/rekap/internal/summary"
 	"github.com/alexinslc/rekap/internal/ui"
 )
 
 func BuildSections(data *summary.Data, cfg *config.Config) []Section {
 	s := &sectionBuilder{data: data, cfg: cfg}
-	return []Section{
-		s.system(),
-		s.productivity(),
-		s.browser(),
-		s.network(),
-		s.wellness(),
-		s.media(),
-		s.notifications(),
-		s.issues(),
+	now := time.Now()
+
+	var keys []string
+	builders := map[string]func() Section{}
+	add := func(key string, build func() Section) {
+		keys = append(keys, key)
+		builders[key] = build
+	}
+
+	add("system", s.system)
+	add("productivity", s.productivity)
+	add("meetings", s.meetings)
+	// The timesheet only appears once projects are configured
+	if len(cfg.Projects) > 0 {
+		add("timesheet", s.timesheet)
+	}
+	add("dev", s.dev)
+	add("browser", s.browser)
+	add("network", s.network)
+	add("wellness", s.wellness)
+	add("media", s.media)
+	add("notifications", s.notifications)
+	add("privacy", s.privacy)
+	add("issues", s.issues)
+	for _, custom := range data.Custom {
+		add(custom.Name, func() Section { return customSection(custom) })
+	}
+	add("notes", s.notes)
+
+	var sections []Section
+	for _, key := range cfg.OrderSections(keys) {
+		// Snoozed, hidden, and disabled sections are left out entirely rather than shown as unavailable
+		if cfg.SectionShown(key, now) {
+			sections = append(sections, builders[key]())
+		}
 	}
+	return sections
 }
 
 type sectionBuilder struct {
@@ -30,8 +59,14 @@ type sectionBuilder struct {
 	cfg  *config.Config
 }
 
+// period names the span the summary covers, e.g. "today" or "since 6:00 AM"
+func (s *sectionBuilder) period() string {
+	w := s.data.Window
+	return ui.FormatPeriod(w.Start, w.End, w.WholeDay(), w.Live(), s.cfg.Display.TimeFormat)
+}
+
 func (s *sectionBuilder) system() Section {
-	available := s.data.Uptime.Available || s.data.Battery.Available || s.data.Screen.Available
+	available := s.data.Uptime.Available || s.data.TimeZone.Traveled() || s.data.Battery.Available || len(s.data.Battery.Peripherals) > 0 || s.data.Screen.Available || s.data.Downloads.Count > 0 || s.data.Screenshots.Screenshots+s.data.Screenshots.Recordings > 0 || len(s.data.Updates.Brew) > 0 || len(s.data.Updates.Pending) > 0 || s.data.Memory.Available || s.data.CPU.Available
 	if !available {
 		return Section{Name: "System", Available: false, HintText: "System data unavailable"}
 	}
@@ -41,10 +76,35 @@ func (s *sectionBuilder) system() Section {
 	if s.data.Uptime.Available {
 		summary.WriteString(fmt.Sprintf("Uptime:    %s\n", s.data.Uptime.FormattedTime))
 		expanded.WriteString(fmt.Sprintf("Uptime:    %s\n", s.data.Uptime.FormattedTime))
+		if s.data.Uptime.IdleAwakeMinutes > 0 {
+			expanded.WriteString(fmt.Sprintf("  Idle left out: %s\n", ui.FormatDuration(s.data.Uptime.IdleAwakeMinutes)))
+		}
+		if c := s.data.Uptime.Confidence; c != "" && c != collectors.AwakeConfidenceHigh {
+			expanded.WriteString(fmt.Sprintf("  Confidence:    %s\n", c))
+		}
 		expanded.WriteString(fmt.Sprintf("Boot time: %s\n",
 			ui.FormatTime(s.data.Uptime.BootTime, s.cfg.Display.TimeFormat)))
 	}
 
+	if tz := s.data.TimeZone; tz.Traveled() {
+		first, last := tz.Changes[0], tz.Changes[len(tz.Changes)-1]
+		summary.WriteString(fmt.Sprintf("Travel:    %s → %s\n", first.From, last.To))
+		expanded.WriteString("Travel:\n")
+		for _, c := range tz.Changes {
+			expanded.WriteString(fmt.Sprintf("  %s → %s at %s\n", c.From, c.To, ui.FormatTime(c.At, s.cfg.Display.TimeFormat)))
+		}
+	}
+
+	if b := s.data.DayBounds; b.Available {
+		text := ui.FormatDayBounds(b.Arrival, b.WrapUp, b.Ongoing, b.SpanMinutes, s.cfg.Display.TimeFormat)
+		summary.WriteString(fmt.Sprintf("Workday:   %s\n", text))
+		expanded.WriteString(fmt.Sprintf("Workday:   %s\n", text))
+		expanded.WriteString(fmt.Sprintf("  started by %s, wrapped up by %s\n", b.ArrivalSource, b.WrapUpSource))
+		if overtime := b.OvertimeMinutes(); overtime > 0 {
+			expanded.WriteString(fmt.Sprintf("  overtime: %s\n", ui.FormatDuration(overtime)))
+		}
+	}
+
 	if s.data.Battery.Available && s.cfg.ShouldShowBattery() {
 		status := "discharging"
 		if s.data.Battery.IsPlugged {
@@ -59,8 +119,27 @@ func (s *sectionBuilder) system() Section {
 		}
 		expanded.WriteString(fmt.Sprintf("Battery:   %d%% -> %d%% (%s)\n",
 			s.data.Battery.StartPct, s.data.Battery.CurrentPct, status))
+		if b := s.data.Battery; b.BatteryMinutes+b.PluggedMinutes > 0 {
+			expanded.WriteString(fmt.Sprintf("  %s on battery, %s plugged in, %s Wh used\n", ui.FormatDuration(b.BatteryMinutes), ui.FormatDuration(b.PluggedMinutes), locale.Current().FormatFloat(b.UsedWh, 1)))
+		}
+		if b := s.data.Battery; b.HealthPct > 0 {
+			expanded.WriteString(fmt.Sprintf("  health %d%% (%s), %d cycle%s\n", b.HealthPct, b.Condition, b.CycleCount, plural(b.CycleCount)))
+		}
 		if s.data.Battery.PlugCount > 0 {
-			expanded.WriteString(fmt.Sprintf("Plug events: %d today\n", s.data.Battery.PlugCount))
+			expanded.WriteString(fmt.Sprintf("Plug events: %d %s\n", s.data.Battery.PlugCount, s.period()))
+		}
+	}
+	if peripherals := s.data.Battery.Peripherals; len(peripherals) > 0 && s.cfg.ShouldShowBattery() {
+		low := 0
+		for _, p := range peripherals {
+			if p.Pct <= collectors.PeripheralLowPct {
+				low++
+			}
+		}
+		summary.WriteString(fmt.Sprintf("Devices:   %d connected, %d low\n", len(peripherals), low))
+		expanded.WriteString("Devices:\n")
+		for _, p := range peripherals {
+			expanded.WriteString(fmt.Sprintf("  %-28s %3d%%\n", p.Name, p.Pct))
 		}
 	}
 
@@ -76,6 +155,65 @@ func (s *sectionBuilder) system() Section {
 		}
 	}
 
+	if d := s.data.Downloads; d.Count > 0 {
+		summary.WriteString(fmt.Sprintf("Downloads: %d (%s)\n", d.Count, collectors.FormatBytes(d.TotalBytes)))
+		expanded.WriteString(fmt.Sprintf("Downloads: %d file%s, %s\n", d.Count, plural(d.Count), collectors.FormatBytes(d.TotalBytes)))
+		for _, t := range d.TopTypes {
+			expanded.WriteString(fmt.Sprintf("  %-14s %3d  %s\n", t.Type, t.Count, collectors.FormatBytes(t.Bytes)))
+		}
+	}
+
+	if sc := s.data.Screenshots; sc.Available && sc.Screenshots+sc.Recordings > 0 {
+		summary.WriteString(fmt.Sprintf("Captures:  %d screenshot%s, %d recording%s\n", sc.Screenshots, plural(sc.Screenshots), sc.Recordings, plural(sc.Recordings)))
+		expanded.WriteString(fmt.Sprintf("Captures:  %d screenshot%s, %d recording%s (%s)\n", sc.Screenshots, plural(sc.Screenshots), sc.Recordings, plural(sc.Recordings), collectors.FormatBytes(sc.Bytes)))
+	}
+	if u := s.data.Updates; len(u.Brew) > 0 {
+		summary.WriteString(fmt.Sprintf("Homebrew:  %d installed, %d upgraded\n", u.Installed, u.Upgraded))
+		expanded.WriteString(fmt.Sprintf("Homebrew:  %d installed, %d upgraded\n", u.Installed, u.Upgraded))
+		for _, c := range u.Brew {
+			version := c.Version
+			if c.From != "" {
+				version = c.From + " → " + c.Version
+			}
+			expanded.WriteString(fmt.Sprintf("  %-16s %s\n", c.Name, version))
+		}
+	}
+	if u := s.data.Updates; len(u.Pending) > 0 {
+		summary.WriteString(fmt.Sprintf("Updates:   %d macOS pending\n", len(u.Pending)))
+		expanded.WriteString(fmt.Sprintf("Updates:   %d macOS pending\n", len(u.Pending)))
+		for _, p := range u.Pending {
+			expanded.WriteString("  " + p.Name + "\n")
+		}
+	}
+
+	if mem := s.data.Memory; mem.Available {
+		heavy := ""
+		if mem.Heavy() {
+			heavy = ", heavy"
+		}
+		summary.WriteString(fmt.Sprintf("Memory:    peak %s%s\n", mem.PeakPressure, heavy))
+		expanded.WriteString(fmt.Sprintf("Memory:    peak %s, %d%% used%s\n", mem.PeakPressure, mem.PeakUsedPct, heavy))
+		expanded.WriteString(fmt.Sprintf("  swap: %s now, %s peak (%d samples)\n",
+			collectors.FormatBytes(mem.SwapUsedBytes), collectors.FormatBytes(mem.PeakSwapBytes), mem.Samples))
+	}
+
+	if cpu := s.data.CPU; cpu.Available {
+		throttled := "not throttled"
+		if len(cpu.Throttles) > 0 {
+			throttled = fmt.Sprintf("throttled %s", ui.FormatDuration(cpu.ThrottledMinutes))
+		}
+		summary.WriteString(fmt.Sprintf("CPU:       peak load %s, %s\n", locale.Current().FormatFloat(cpu.PeakLoad, 1), throttled))
+		expanded.WriteString(fmt.Sprintf("CPU:       peak load %s, avg %s on %d cores\n",
+			locale.Current().FormatFloat(cpu.PeakLoad, 1), locale.Current().FormatFloat(cpu.AvgLoad, 1), cpu.Cores))
+		for _, p := range cpu.Throttles {
+			expanded.WriteString(fmt.Sprintf("  throttled %s–%s\n",
+				ui.FormatTime(p.Start, s.cfg.Display.TimeFormat), ui.FormatTime(p.End, s.cfg.Display.TimeFormat)))
+		}
+		if len(cpu.Throttles) > 0 {
+			expanded.WriteString(fmt.Sprintf("  lowest speed limit: %d%%\n", cpu.MinSpeedLimit))
+		}
+	}
+
 	return Section{
 		Name:      "System",
 		Available: true,
@@ -85,7 +223,7 @@ func (s *sectionBuilder) system() Section {
 }
 
 func (s *sectionBuilder) productivity() Section {
-	available := s.data.Apps.Available || s.data.Focus.Available
+	available := s.data.Apps.Available || s.data.Focus.Available || s.data.Sessions.Available || s.data.Spaces.Available || s.data.SSH.Available || s.data.Shell.Available || s.data.Builds.Available || s.data.Reminders.Completed > 0
 	if !available {
 		return Section{
 			Name:      "Productivity",
@@ -103,6 +241,18 @@ func (s *sectionBuilder) productivity() Section {
 			ui.FormatDuration(s.data.Focus.StreakMinutes), s.data.Focus.AppName))
 	}
 
+	if s.data.Sessions.Available {
+		summary.WriteString(fmt.Sprintf("Sessions:  %d • longest %s\n",
+			s.data.Sessions.Count, ui.FormatDuration(s.data.Sessions.LongestMinutes)))
+		expanded.WriteString(fmt.Sprintf("Sessions:  %d • longest %s • avg %s\n",
+			s.data.Sessions.Count, ui.FormatDuration(s.data.Sessions.LongestMinutes), ui.FormatDuration(s.data.Sessions.AvgMinutes)))
+		for _, session := range s.data.Sessions.Sessions {
+			expanded.WriteString(fmt.Sprintf("  %s – %s  %s\n",
+				ui.FormatTime(session.Start, s.cfg.Display.TimeFormat), ui.FormatTime(session.End, s.cfg.Display.TimeFormat),
+				ui.FormatDuration(session.Minutes())))
+		}
+	}
+
 	if s.data.Apps.Available && len(s.data.Apps.TopApps) > 0 {
 		summary.WriteString("\nTop Apps:\n")
 		for i, app := range s.data.Apps.TopApps {
@@ -117,16 +267,98 @@ func (s *sectionBuilder) productivity() Section {
 			if i >= 10 {
 				break
 			}
+			duration := ui.FormatDuration(app.Minutes)
+			if app.Activations > 0 {
+				duration = fmt.Sprintf("%-7s activated %d×", duration, app.Activations)
+			}
 			expanded.WriteString(fmt.Sprintf("  %d. %-16s %s  (%s)\n",
-				i+1, app.Name, ui.FormatDuration(app.Minutes), app.BundleID))
+				i+1, app.Name, duration, app.BundleID))
+		}
+		if s.data.Apps.Source == config.SourceSampling {
+			expanded.WriteString("  (sampled from the frontmost app; grant Full Disk Access for Screen Time data)\n")
 		}
 
 		if s.data.Apps.SwitchingAvailable {
-			expanded.WriteString(fmt.Sprintf("\nSwitches:  %d total (%.1f/hr)\n",
-				s.data.Apps.TotalSwitches, s.data.Apps.SwitchesPerHour))
+			expanded.WriteString(fmt.Sprintf("\nSwitches:  %d total (%s/hr)\n",
+				s.data.Apps.TotalSwitches, locale.Current().FormatFloat(s.data.Apps.SwitchesPerHour, 1)))
 			if s.data.Apps.AvgMinsBetween > 0 {
-				expanded.WriteString(fmt.Sprintf("Avg between: %.1f min\n", s.data.Apps.AvgMinsBetween))
+				expanded.WriteString(fmt.Sprintf("Avg between: %s min\n", locale.Current().FormatFloat(s.data.Apps.AvgMinsBetween, 1)))
+			}
+		}
+	}
+
+	if src := s.data.AppSources; src.Available {
+		expanded.WriteString(fmt.Sprintf("\nSources:   Apple %s • App Store %s • Third-party %s\n",
+			ui.FormatDuration(src.AppleMinutes), ui.FormatDuration(src.AppStoreMinutes), ui.FormatDuration(src.ThirdPartyMinutes)))
+		if src.UnsandboxedMinutes > 0 {
+			expanded.WriteString(fmt.Sprintf("Unsandboxed: %s (%s)\n",
+				ui.FormatDuration(src.UnsandboxedMinutes), strings.Join(src.UnsandboxedThirdParty, ", ")))
+		}
+	}
+
+	if cats := s.data.AppCategories; cats.Available && len(cats.Categories) > 1 {
+		expanded.WriteString("\nCategories:\n")
+		for _, c := range cats.Categories {
+			expanded.WriteString(fmt.Sprintf("  %-22s %s\n", c.Name, ui.FormatDuration(c.Minutes)))
+		}
+	}
+
+	// A single Space just repeats the screen time
+	if s.data.Spaces.Available && len(s.data.Spaces.Spaces) > 1 {
+		top := s.data.Spaces.Spaces[0]
+		summary.WriteString(fmt.Sprintf("\nSpace:     %s (%s)\n", top.Name, ui.FormatDuration(top.Minutes)))
+		expanded.WriteString("\nSpaces:\n")
+		for _, space := range s.data.Spaces.Spaces {
+			expanded.WriteString(fmt.Sprintf("  %-16s %s\n", space.Name, ui.FormatDuration(space.Minutes)))
+		}
+	}
+
+	if s.data.SSH.Available {
+		top := s.data.SSH.Hosts[0]
+		summary.WriteString(fmt.Sprintf("\nRemote:    %s (%s)\n", top.Host, ui.FormatDuration(top.Minutes)))
+		expanded.WriteString(fmt.Sprintf("\nRemote hosts (%s):\n", ui.FormatDuration(s.data.SSH.TotalMinutes)))
+		for _, host := range s.data.SSH.Hosts {
+			status := ""
+			if host.Active {
+				status = "  open"
 			}
+			expanded.WriteString(fmt.Sprintf("  %-16s %s  ×%d%s\n",
+				host.Host, ui.FormatDuration(host.Minutes), host.Sessions, status))
+		}
+	}
+
+	if shell := s.data.Shell; shell.Available {
+		busiest := ui.FormatTime(time.Date(2000, 1, 1, shell.BusiestHour, 0, 0, 0, time.Local), s.cfg.Display.TimeFormat)
+		summary.WriteString(fmt.Sprintf("Shell:     %d command%s\n", shell.Commands, plural(shell.Commands)))
+		expanded.WriteString(fmt.Sprintf("\nShell (%d command%s, busiest %s):\n", shell.Commands, plural(shell.Commands), busiest))
+		for _, c := range shell.TopCommands {
+			expanded.WriteString(fmt.Sprintf("  %-16s %d\n", c.Name, c.Count))
+		}
+	}
+
+	if builds := s.data.Builds; builds.Available {
+		summary.WriteString(fmt.Sprintf("Builds:    %d (%s waiting)\n", builds.Builds, ui.FormatDuration(builds.WaitMinutes)))
+		expanded.WriteString(fmt.Sprintf("\nBuilds (%d, %s waiting, %d failed):\n", builds.Builds, ui.FormatDuration(builds.WaitMinutes), builds.Failed))
+		for _, t := range builds.Tools {
+			expanded.WriteString(fmt.Sprintf("  %-16s %3d  %s\n", t.Tool, t.Builds, ui.FormatDuration(t.Minutes)))
+		}
+	}
+
+	if reminders := s.data.Reminders; reminders.Available && reminders.Completed > 0 {
+		summary.WriteString(fmt.Sprintf("Reminders: %d completed\n", reminders.Completed))
+		expanded.WriteString(fmt.Sprintf("\nReminders (%d completed):\n", reminders.Completed))
+		for _, l := range reminders.Lists {
+			expanded.WriteString(fmt.Sprintf("  %-16s %3d\n", l.Name, l.Completed))
+		}
+	}
+
+	if learning := s.data.Learning; learning.Available && learning.Minutes > 0 {
+		summary.WriteString(fmt.Sprintf("Learning:  %s\n", ui.FormatDuration(learning.Minutes)))
+		expanded.WriteString(fmt.Sprintf("\nLearning (%s):\n", ui.FormatDuration(learning.Minutes)))
+		expanded.WriteString(fmt.Sprintf("  Apps:    %s\n", ui.FormatDuration(learning.AppMinutes)))
+		expanded.WriteString(fmt.Sprintf("  Browser: %s  (%d visit%s)\n", ui.FormatDuration(learning.BrowserMinutes), learning.Visits, plural(learning.Visits)))
+		if goal := s.cfg.Learning.WeeklyGoalHours; goal > 0 {
+			expanded.WriteString(fmt.Sprintf("  Weekly goal: %s (see rekap report)\n", ui.FormatDuration(int(goal*60))))
 		}
 	}
 
@@ -149,7 +381,7 @@ func (s *sectionBuilder) browser() Section {
 		summary.WriteString(fmt.Sprintf("Tabs:      %d open\n", s.data.Browsers.TotalTabs))
 	}
 	if s.data.Browsers.TotalURLsVisited > 0 {
-		summary.WriteString(fmt.Sprintf("Visited:   %d URLs today\n", s.data.Browsers.TotalURLsVisited))
+		summary.WriteString(fmt.Sprintf("Visited:   %d URLs %s\n", s.data.Browsers.TotalURLsVisited, s.period()))
 	}
 	if s.data.Browsers.TopHistoryDomain != "" {
 		summary.WriteString(fmt.Sprintf("Top site:  %s (%d visits)\n",
@@ -166,6 +398,9 @@ func (s *sectionBuilder) browser() Section {
 	if s.data.Browsers.Edge.Available {
 		expanded.WriteString(fmt.Sprintf("Edge:      %d tabs\n", s.data.Browsers.Edge.TabCount))
 	}
+	if s.data.Browsers.Firefox.Available {
+		expanded.WriteString(fmt.Sprintf("Firefox:   %d tabs\n", s.data.Browsers.Firefox.TabCount))
+	}
 
 	if s.data.Browsers.TotalURLsVisited > 0 {
 		expanded.WriteString(fmt.Sprintf("\nURLs visited: %d\n", s.data.Browsers.TotalURLsVisited))
@@ -186,7 +421,10 @@ func (s *sectionBuilder) browser() Section {
 			domains = append(domains, dc{domain, count})
 		}
 		sort.Slice(domains, func(i, j int) bool {
-			return domains[i].count > domains[j].count
+			if domains[i].count != domains[j].count {
+				return domains[i].count > domains[j].count
+			}
+			return domains[i].domain < domains[j].domain
 		})
 		expanded.WriteString("\nTop tab domains:\n")
 		for i, d := range domains {
@@ -198,17 +436,34 @@ func (s *sectionBuilder) browser() Section {
 	}
 
 	// Work/distraction breakdown
-	total := s.data.Browsers.WorkVisits + s.data.Browsers.DistractionVisits + s.data.Browsers.NeutralVisits
+	total := s.data.Browsers.WorkVisits + s.data.Browsers.DistractionVisits + s.data.Browsers.LearningVisits + s.data.Browsers.NeutralVisits
 	if total > 0 {
 		expanded.WriteString("\nDomain breakdown:\n")
 		expanded.WriteString(fmt.Sprintf("  Work:        %d visits (%d%%)\n",
 			s.data.Browsers.WorkVisits, pct(s.data.Browsers.WorkVisits, total)))
 		expanded.WriteString(fmt.Sprintf("  Distraction: %d visits (%d%%)\n",
 			s.data.Browsers.DistractionVisits, pct(s.data.Browsers.DistractionVisits, total)))
+		if s.data.Browsers.LearningVisits > 0 {
+			expanded.WriteString(fmt.Sprintf("  Learning:    %d visits (%d%%)\n",
+				s.data.Browsers.LearningVisits, pct(s.data.Browsers.LearningVisits, total)))
+		}
 		expanded.WriteString(fmt.Sprintf("  Neutral:     %d visits (%d%%)\n",
 			s.data.Browsers.NeutralVisits, pct(s.data.Browsers.NeutralVisits, total)))
 	}
 
+	if cloud := s.data.CloudConsoles; cloud.Available {
+		top := cloud.Providers[0]
+		summary.WriteString(fmt.Sprintf("Consoles:  %s (%s)\n", top.Name, ui.FormatDuration(top.Minutes)))
+		expanded.WriteString("\nCloud consoles:\n")
+		for _, p := range cloud.Providers {
+			expanded.WriteString(fmt.Sprintf("  %-14s %s  (%d session%s)\n", p.Name, ui.FormatDuration(p.Minutes), p.Sessions, plural(p.Sessions)))
+		}
+		for _, ls := range cloud.LongSessions {
+			expanded.WriteString(fmt.Sprintf("  ⚠ Long %s session: %s – %s (%s)\n", collectors.CloudProviderNames[ls.Provider],
+				ui.FormatTime(ls.Start, s.cfg.Display.TimeFormat), ui.FormatTime(ls.End, s.cfg.Display.TimeFormat), ui.FormatDuration(ls.Minutes())))
+		}
+	}
+
 	return Section{
 		Name:      "Browser",
 		Available: true,
@@ -217,42 +472,198 @@ func (s *sectionBuilder) browser() Section {
 	}
 }
 
+func (s *sectionBuilder) meetings() Section {
+	m := s.data.Meetings
+	if !m.Available {
+		return Section{Name: "Meetings", Available: false, HintText: "Calendar unavailable.\nInstall icalBuddy (brew install ical-buddy) or allow Calendars access."}
+	}
+
+	var summary, expanded strings.Builder
+	timeFormat := s.cfg.Display.TimeFormat
+
+	summary.WriteString(fmt.Sprintf("%d meeting%s • %s\n", m.Count, plural(m.Count), ui.FormatDuration(m.TotalMinutes)))
+	for _, meeting := range m.Meetings {
+		expanded.WriteString(fmt.Sprintf("%-8s %s (%s)\n", ui.FormatTime(meeting.Start, timeFormat), meeting.Title, ui.FormatDuration(meeting.Minutes())))
+	}
+	if m.LongestFreeMinutes > 0 {
+		free := fmt.Sprintf("Longest free block: %s (%s–%s)\n", ui.FormatDuration(m.LongestFreeMinutes),
+			ui.FormatTime(m.LongestFree.Start, timeFormat), ui.FormatTime(m.LongestFree.End, timeFormat))
+		summary.WriteString(free)
+		expanded.WriteString("\n" + free)
+	}
+
+	return Section{
+		Name:      "Meetings",
+		Available: true,
+		Summary:   strings.TrimRight(summary.String(), "\n"),
+		Expanded:  strings.TrimRight(expanded.String(), "\n"),
+	}
+}
+
+func (s *sectionBuilder) timesheet() Section {
+	if !s.data.Projects.Available {
+		return Section{Name: "Timesheet", Available: false, HintText: "Project time needs app usage data.\nRun 'rekap init' for setup."}
+	}
+
+	var summary, expanded strings.Builder
+
+	if len(s.data.Projects.Projects) == 0 {
+		summary.WriteString("No time matched your projects yet\n")
+	}
+	for _, p := range s.data.Projects.Projects {
+		summary.WriteString(fmt.Sprintf("%-16s %s\n", p.Name, ui.FormatDuration(p.Minutes)))
+		expanded.WriteString(fmt.Sprintf("%-16s %s (%sh)\n", p.Name, ui.FormatDuration(p.Minutes), locale.Current().FormatFloat(float64(p.Minutes)/60, 2)))
+		expanded.WriteString(fmt.Sprintf("  Apps:    %s\n", ui.FormatDuration(p.AppMinutes)))
+		expanded.WriteString(fmt.Sprintf("  Browser: %s\n", ui.FormatDuration(p.BrowserMinutes)))
+		if p.WindowMinutes > 0 {
+			expanded.WriteString(fmt.Sprintf("  Windows: %s\n", ui.FormatDuration(p.WindowMinutes)))
+		}
+	}
+	if s.data.Projects.UnattributedMinutes > 0 {
+		summary.WriteString(fmt.Sprintf("%-16s %s\n", "Unattributed", ui.FormatDuration(s.data.Projects.UnattributedMinutes)))
+		expanded.WriteString(fmt.Sprintf("\nUnattributed:    %s\n", ui.FormatDuration(s.data.Projects.UnattributedMinutes)))
+	}
+	expanded.WriteString("\nExport: rekap timesheet --csv\n")
+
+	return Section{
+		Name:      "Timesheet",
+		Available: true,
+		Summary:   strings.TrimRight(summary.String(), "\n"),
+		Expanded:  strings.TrimRight(expanded.String(), "\n"),
+	}
+}
+
+func (s *sectionBuilder) dev() Section {
+	docker := s.data.Docker
+	if !docker.Available || (docker.Started == 0 && docker.Running == 0) {
+		return Section{Name: "Dev", Available: false, HintText: "No Docker containers " + s.period()}
+	}
+
+	var summary, expanded strings.Builder
+	summary.WriteString(fmt.Sprintf("Containers: %d started, %d running\n", docker.Started, docker.Running))
+	if docker.MemBytes > 0 {
+		summary.WriteString(fmt.Sprintf("Using:      %s%% CPU, %s\n", locale.Current().FormatFloat(docker.CPUPct, 1), collectors.FormatBytes(docker.MemBytes)))
+	}
+
+	expanded.WriteString(fmt.Sprintf("Containers: %d started %s, %d running now\n\n", docker.Started, s.period(), docker.Running))
+	for _, c := range docker.Containers {
+		status := "stopped"
+		if c.Running {
+			status = "running"
+		}
+		expanded.WriteString(fmt.Sprintf("  %-16s %-20s %8s  %s\n", c.Name, c.Image, ui.FormatDuration(c.Minutes), status))
+	}
+
+	return Section{
+		Name:      "Dev",
+		Available: true,
+		Summary:   strings.TrimRight(summary.String(), "\n"),
+		Expanded:  strings.TrimRight(expanded.String(), "\n"),
+	}
+}
+
 func (s *sectionBuilder) network() Section {
-	if !s.data.Network.Available {
+	vpn := s.data.VPN
+	showVPN := vpn.Available && (vpn.Minutes > 0 || vpn.Connected)
+	if !s.data.Network.Available && !s.data.WiFi.Available && !showVPN {
 		return Section{Name: "Network", Available: false, HintText: "No network data available"}
 	}
 
-	qualifier := ""
-	if s.data.Network.SinceBoot {
-		qualifier = " (since boot)"
+	var summary, expanded strings.Builder
+
+	if s.data.Network.Available {
+		qualifier := ""
+		if s.data.Network.SinceBoot {
+			qualifier = " (since boot)"
+		}
+
+		summary.WriteString(fmt.Sprintf("%s: %s down / %s up%s\n",
+			s.data.Network.InterfaceName,
+			collectors.FormatBytes(s.data.Network.BytesReceived),
+			collectors.FormatBytes(s.data.Network.BytesSent),
+			qualifier))
+
+		expanded.WriteString(fmt.Sprintf("Interface: %s\nNetwork:   %s\nReceived:  %s\nSent:      %s%s\n",
+			s.data.Network.InterfaceName,
+			s.data.Network.NetworkName,
+			collectors.FormatBytes(s.data.Network.BytesReceived),
+			collectors.FormatBytes(s.data.Network.BytesSent),
+			qualifier))
+
+		if s.data.Network.Metered || s.data.Network.MeteredBytesReceived > 0 {
+			if s.data.Network.Metered {
+				expanded.WriteString(fmt.Sprintf("Metered:   yes (%s)\n", s.data.Network.MeteredReason))
+			}
+			expanded.WriteString(fmt.Sprintf("Metered usage: %s down / %s up\n",
+				collectors.FormatBytes(s.data.Network.MeteredBytesReceived),
+				collectors.FormatBytes(s.data.Network.MeteredBytesSent)))
+		}
+		if apps := s.data.Network.TopApps; len(apps) > 0 {
+			summary.WriteString(fmt.Sprintf("Top app:   %s (%s)\n", apps[0].Name, collectors.FormatBytes(apps[0].Bytes())))
+			expanded.WriteString("\nTop apps:\n")
+			for _, a := range apps {
+				expanded.WriteString(fmt.Sprintf("  %-18s %s down / %s up\n", a.Name,
+					collectors.FormatBytes(a.BytesReceived), collectors.FormatBytes(a.BytesSent)))
+			}
+		}
+		if s.data.Network.MeteredBytesReceived >= s.cfg.MeteredWarningBytes() {
+			summary.WriteString(fmt.Sprintf("Metered:   %s downloaded (warning)\n",
+				collectors.FormatBytes(s.data.Network.MeteredBytesReceived)))
+		}
 	}
 
-	summary := fmt.Sprintf("%s: %s down / %s up%s",
-		s.data.Network.InterfaceName,
-		collectors.FormatBytes(s.data.Network.BytesReceived),
-		collectors.FormatBytes(s.data.Network.BytesSent),
-		qualifier)
+	if s.data.WiFi.Available {
+		summary.WriteString(fmt.Sprintf("Wi-Fi:     %s (SNR %d dB)\n", s.data.WiFi.Quality, s.data.WiFi.AvgSNR))
 
-	expanded := fmt.Sprintf("Interface: %s\nNetwork:   %s\nReceived:  %s\nSent:      %s%s",
-		s.data.Network.InterfaceName,
-		s.data.Network.NetworkName,
-		collectors.FormatBytes(s.data.Network.BytesReceived),
-		collectors.FormatBytes(s.data.Network.BytesSent),
-		qualifier)
+		expanded.WriteString("\nWi-Fi Link Quality:\n")
+		expanded.WriteString(fmt.Sprintf("  Quality:   %s\n", s.data.WiFi.Quality))
+		expanded.WriteString(fmt.Sprintf("  Signal:    %d dBm avg\n", s.data.WiFi.AvgRSSI))
+		expanded.WriteString(fmt.Sprintf("  Noise:     %d dBm avg\n", s.data.WiFi.AvgNoise))
+		expanded.WriteString(fmt.Sprintf("  SNR:       %d dB avg\n", s.data.WiFi.AvgSNR))
+		expanded.WriteString(fmt.Sprintf("  Tx rate:   %d Mbps avg\n", s.data.WiFi.AvgTxRate))
+		expanded.WriteString(fmt.Sprintf("  Samples:   %d %s\n", s.data.WiFi.Samples, s.period()))
+		if !s.data.WiFi.WorstPeriodStart.IsZero() {
+			expanded.WriteString(fmt.Sprintf("  Worst:     %s (SNR %d dB)\n",
+				ui.FormatTime(s.data.WiFi.WorstPeriodStart, s.cfg.Display.TimeFormat), s.data.WiFi.WorstPeriodSNR))
+		}
+		if networks := s.data.WiFi.Networks; len(networks) > 0 {
+			switched := fmt.Sprintf("switched %d time%s", s.data.WiFi.Switches, plural(s.data.WiFi.Switches))
+			summary.WriteString(fmt.Sprintf("Networks:  %d (%s)\n", len(networks), switched))
+			expanded.WriteString(fmt.Sprintf("  Networks:  %d, %s\n", len(networks), switched))
+			for _, n := range networks {
+				expanded.WriteString(fmt.Sprintf("    %-18s %s\n", n.SSID, ui.FormatDuration(n.Minutes)))
+			}
+		}
+	}
+
+	if showVPN {
+		status := "not connected"
+		if vpn.Connected {
+			status = "connected"
+		}
+		summary.WriteString(fmt.Sprintf("VPN:       %s (%s)\n", ui.FormatDuration(vpn.Minutes), status))
+		expanded.WriteString(fmt.Sprintf("VPN:       %s %s, %s now\n", ui.FormatDuration(vpn.Minutes), s.period(), status))
+		if vpn.Name != "" {
+			expanded.WriteString(fmt.Sprintf("  via %s\n", vpn.Name))
+		}
+	}
 
 	return Section{
 		Name:      "Network",
 		Available: true,
-		Summary:   summary,
-		Expanded:  expanded,
+		Summary:   strings.TrimRight(summary.String(), "\n"),
+		Expanded:  strings.TrimLeft(strings.TrimRight(expanded.String(), "\n"), "\n"),
 	}
 }
 
 func (s *sectionBuilder) wellness() Section {
-	fragAvail := s.data.Fragmentation.Available
+	// Fragmentation lives in this section in the TUI but can be snoozed on its own
+	fragAvail := s.data.Fragmentation.Available && s.cfg.SectionShown("fragmentation", time.Now())
 	burnoutAvail := s.data.Burnout.Available
 	hasWarnings := burnoutAvail && len(s.data.Burnout.Warnings) > 0
-	if !fragAvail && !burnoutAvail {
+	daylight := s.data.Daylight
+	work := s.data.WorkHours
+	if !fragAvail && !burnoutAvail && !daylight.Available && !work.Available {
 		return Section{Name: "Wellness", Available: false, HintText: "No wellness data available"}
 	}
 
@@ -266,10 +677,43 @@ func (s *sectionBuilder) wellness() Section {
 			s.data.Fragmentation.Score, s.data.Fragmentation.Level))
 		expanded.WriteString("Score Breakdown:\n")
 		b := s.data.Fragmentation.Breakdown
-		expanded.WriteString(fmt.Sprintf("  Apps:     %d unique (weight: 30%%)\n", b.UniqueApps))
-		expanded.WriteString(fmt.Sprintf("  Tabs:     %d total (weight: 25%%)\n", b.TotalTabs))
-		expanded.WriteString(fmt.Sprintf("  Domains:  %d unique (weight: 25%%)\n", b.UniqueDomains))
-		expanded.WriteString(fmt.Sprintf("  Switches: %.1f/hr (weight: 20%%)\n", b.AppSwitchesPerHour))
+		weight := make(map[string]string)
+		for _, f := range b.Factors() {
+			weight[f.Name] = fmt.Sprintf("weight: %d%%", f.Weight)
+			if f.Weight == 0 {
+				weight[f.Name] = "not scored"
+			}
+		}
+		expanded.WriteString(fmt.Sprintf("  Apps:     %d unique (%s)\n", b.UniqueApps, weight[collectors.FactorApps]))
+		expanded.WriteString(fmt.Sprintf("  Tabs:     %d total (%s)\n", b.TotalTabs, weight[collectors.FactorTabs]))
+		expanded.WriteString(fmt.Sprintf("  Domains:  %d unique (%s)\n", b.UniqueDomains, weight[collectors.FactorDomains]))
+		expanded.WriteString(fmt.Sprintf("  Switches: %s/hr (%s)\n", locale.Current().FormatFloat(b.AppSwitchesPerHour, 1), weight[collectors.FactorSwitches]))
+		if clip := s.data.Clipboard; clip.Available {
+			expanded.WriteString(fmt.Sprintf("  Clipboard: %s changes/hr, %d %s (not scored)\n", locale.Current().FormatFloat(b.ClipboardChangesPerHour, 1), clip.Changes, s.period()))
+		}
+	}
+
+	if daylight.Available {
+		var sun string
+		switch daylight.Polar {
+		case collectors.SunAlwaysUp:
+			sun = "midnight sun"
+		case collectors.SunAlwaysDown:
+			sun = "polar night"
+		default:
+			sun = fmt.Sprintf("%s-%s", ui.FormatTime(daylight.Sunrise, s.cfg.Display.TimeFormat), ui.FormatTime(daylight.Sunset, s.cfg.Display.TimeFormat))
+		}
+		summary.WriteString(fmt.Sprintf("After dark:    %s (%d%%)\n", ui.FormatDuration(daylight.AfterDarkMinutes), daylight.AfterDarkPct()))
+		expanded.WriteString(fmt.Sprintf("\nDaylight: %s\n", sun))
+		expanded.WriteString(fmt.Sprintf("  %s of %s screen time after dark\n", ui.FormatDuration(daylight.AfterDarkMinutes), ui.FormatDuration(daylight.ScreenMinutes)))
+	}
+
+	if work.Available {
+		summary.WriteString(fmt.Sprintf("After hours:   %s\n", ui.FormatDuration(work.AfterHoursMinutes)))
+		expanded.WriteString(fmt.Sprintf("\nWork hours: %s\n", work.Hours))
+		expanded.WriteString(fmt.Sprintf("  Screen time:        %s in, %s after\n", ui.FormatDuration(work.WorkMinutes()), ui.FormatDuration(work.AfterHoursMinutes)))
+		expanded.WriteString(fmt.Sprintf("  Notifications:      %d in, %d after\n", work.Notifications-work.AfterHoursNotifications, work.AfterHoursNotifications))
+		expanded.WriteString(fmt.Sprintf("  Distraction visits: %d in, %d after\n", work.DistractionVisits-work.AfterHoursDistractionVisits, work.AfterHoursDistractionVisits))
 	}
 
 	if hasWarnings {
@@ -298,38 +742,89 @@ func (s *sectionBuilder) wellness() Section {
 }
 
 func (s *sectionBuilder) media() Section {
-	if !s.data.Media.Available || !s.cfg.ShouldShowMedia() {
+	devices := s.data.AudioDevices.Devices
+	music := s.data.Music
+	if (!s.data.Media.Available && len(devices) == 0 && music.Minutes+music.PodcastMinutes+music.AudiobookMinutes == 0) || !s.cfg.ShouldShowMedia() {
 		return Section{Name: "Media", Available: false, HintText: "No media playing"}
 	}
 
-	content := fmt.Sprintf("\"%s\" in %s", s.data.Media.Track, s.data.Media.App)
+	var summary, expanded strings.Builder
+	if s.data.Media.Available {
+		content := fmt.Sprintf("\"%s\" in %s\n", s.data.Media.Track, s.data.Media.App)
+		summary.WriteString(content)
+		expanded.WriteString(content)
+	}
+	if music.Available && music.Minutes > 0 {
+		summary.WriteString(fmt.Sprintf("Music:     %s, %d track%s\n", ui.FormatDuration(music.Minutes), music.Tracks, plural(music.Tracks)))
+		expanded.WriteString(fmt.Sprintf("\nMusic (%s, %d track%s):\n", ui.FormatDuration(music.Minutes), music.Tracks, plural(music.Tracks)))
+		for i, a := range music.Artists {
+			if i >= 5 {
+				break
+			}
+			expanded.WriteString(fmt.Sprintf("  %-18s %s\n", a.Name, ui.FormatDuration(a.Minutes)))
+		}
+	}
+	if music.Available && music.PodcastMinutes > 0 {
+		line := fmt.Sprintf("Podcasts:  %s, %d episode%s\n", ui.FormatDuration(music.PodcastMinutes), music.Episodes, plural(music.Episodes))
+		summary.WriteString(line)
+		expanded.WriteString("\n" + line)
+	}
+	if music.Available && music.AudiobookMinutes > 0 {
+		line := fmt.Sprintf("Books:     %s\n", ui.FormatDuration(music.AudiobookMinutes))
+		summary.WriteString(line)
+		expanded.WriteString(line)
+	}
+	if len(devices) > 0 {
+		summary.WriteString(fmt.Sprintf("Audio:     %s (%s)\n", devices[0].Name, ui.FormatDuration(devices[0].Minutes)))
+		expanded.WriteString("\nBluetooth audio:\n")
+		for _, d := range devices {
+			status := ""
+			if d.Connected {
+				status = "  connected"
+			}
+			expanded.WriteString(fmt.Sprintf("  %-18s %s%s\n", d.Name, ui.FormatDuration(d.Minutes), status))
+		}
+	}
+
 	return Section{
 		Name:      "Media",
 		Available: true,
-		Summary:   content,
-		Expanded:  content,
+		Summary:   strings.TrimRight(summary.String(), "\n"),
+		Expanded:  strings.TrimSpace(expanded.String()),
 	}
 }
 
 func (s *sectionBuilder) notifications() Section {
-	if !s.data.Notifications.Available || s.data.Notifications.TotalNotifications == 0 {
-		return Section{Name: "Notifications", Available: false, HintText: "No notifications today"}
+	hasNotifications := s.data.Notifications.Available && s.data.Notifications.TotalNotifications > 0
+	messages := s.data.Messages
+	if !hasNotifications && !messages.Available {
+		return Section{Name: "Notifications", Available: false, HintText: "No notifications " + s.period()}
 	}
 
 	var summary, expanded strings.Builder
 
-	summary.WriteString(fmt.Sprintf("Total: %d notifications\n", s.data.Notifications.TotalNotifications))
-	if len(s.data.Notifications.TopApps) > 0 {
-		summary.WriteString(fmt.Sprintf("Top:   %s (%d)\n",
-			s.data.Notifications.TopApps[0].Name, s.data.Notifications.TopApps[0].Count))
+	if hasNotifications {
+		summary.WriteString(fmt.Sprintf("Total: %d notifications\n", s.data.Notifications.TotalNotifications))
+		if len(s.data.Notifications.TopApps) > 0 {
+			summary.WriteString(fmt.Sprintf("Top:   %s (%d)\n",
+				s.data.Notifications.TopApps[0].Name, s.data.Notifications.TopApps[0].Count))
+		}
+
+		expanded.WriteString(fmt.Sprintf("Total: %d notifications\n\nTop Apps:\n", s.data.Notifications.TotalNotifications))
+		for i, app := range s.data.Notifications.TopApps {
+			if i >= 10 {
+				break
+			}
+			expanded.WriteString(fmt.Sprintf("  %d. %-16s %d\n", i+1, app.Name, app.Count))
+		}
 	}
 
-	expanded.WriteString(fmt.Sprintf("Total: %d notifications\n\nTop Apps:\n", s.data.Notifications.TotalNotifications))
-	for i, app := range s.data.Notifications.TopApps {
-		if i >= 10 {
-			break
+	if messages.Available {
+		summary.WriteString(fmt.Sprintf("Messages: %d sent, %d received\n", messages.Sent, messages.Received))
+		expanded.WriteString(fmt.Sprintf("\nMessages (%d sent, %d received):\n", messages.Sent, messages.Received))
+		for _, svc := range messages.Services {
+			expanded.WriteString(fmt.Sprintf("  %-16s %d sent, %d received\n", svc.Service, svc.Sent, svc.Received))
 		}
-		expanded.WriteString(fmt.Sprintf("  %d. %-16s %d\n", i+1, app.Name, app.Count))
 	}
 
 	return Section{
@@ -340,14 +835,40 @@ func (s *sectionBuilder) notifications() Section {
 	}
 }
 
+func (s *sectionBuilder) privacy() Section {
+	sensors := s.data.Sensors
+	if !sensors.Available || len(sensors.Apps) == 0 {
+		return Section{Name: "Privacy", Available: false, HintText: "No camera or microphone use " + s.period()}
+	}
+
+	var summary, expanded strings.Builder
+	summary.WriteString(fmt.Sprintf("Camera: %s (%d session%s)\n", ui.FormatDuration(sensors.CameraMinutes), sensors.CameraSessions, plural(sensors.CameraSessions)))
+	summary.WriteString(fmt.Sprintf("Mic:    %s\n", ui.FormatDuration(sensors.MicMinutes)))
+	summary.WriteString(fmt.Sprintf("Top:    %s\n", sensors.Apps[0].Name))
+
+	expanded.WriteString(fmt.Sprintf("Camera: %s %s (%d session%s)\n", ui.FormatDuration(sensors.CameraMinutes), s.period(), sensors.CameraSessions, plural(sensors.CameraSessions)))
+	expanded.WriteString(fmt.Sprintf("Mic:    %s %s\n\n", ui.FormatDuration(sensors.MicMinutes), s.period()))
+	expanded.WriteString(fmt.Sprintf("  %-16s %8s %8s\n", "App", "Camera", "Mic"))
+	for _, app := range sensors.Apps {
+		expanded.WriteString(fmt.Sprintf("  %-16s %8s %8s\n", app.Name, ui.FormatDuration(app.CameraMinutes), ui.FormatDuration(app.MicMinutes)))
+	}
+
+	return Section{
+		Name:      "Privacy",
+		Available: true,
+		Summary:   strings.TrimRight(summary.String(), "\n"),
+		Expanded:  strings.TrimRight(expanded.String(), "\n"),
+	}
+}
+
 func (s *sectionBuilder) issues() Section {
 	if !s.data.Issues.Available || len(s.data.Issues.Issues) == 0 {
-		return Section{Name: "Issues", Available: false, HintText: "No issues/tickets viewed today"}
+		return Section{Name: "Issues", Available: false, HintText: "No issues/tickets viewed " + s.period()}
 	}
 
 	var summary, expanded strings.Builder
 
-	summary.WriteString(fmt.Sprintf("%d issues/tickets viewed today", len(s.data.Issues.Issues)))
+	summary.WriteString(fmt.Sprintf("%d issues/tickets viewed %s", len(s.data.Issues.Issues), s.period()))
 
 	expanded.WriteString("Issues/Tickets Viewed:\n")
 	for i, issue := range s.data.Issues.Issues {
@@ -366,9 +887,66 @@ func (s *sectionBuilder) issues() Section {
 	}
 }
 
+func (s *sectionBuilder) notes() Section {
+	if len(s.data.Notes) == 0 {
+		return Section{Name: "Notes", Available: false, HintText: "No notes today.\nAdd one with: rekap note \"what happened\""}
+	}
+
+	var summary, expanded strings.Builder
+
+	last := s.data.Notes[len(s.data.Notes)-1]
+	summary.WriteString(fmt.Sprintf("%d note(s) • latest: %s", len(s.data.Notes), last.Text))
+
+	for _, note := range s.data.Notes {
+		expanded.WriteString(fmt.Sprintf("%s  %s\n", ui.FormatTime(note.Time, s.cfg.Display.TimeFormat), note.Text))
+	}
+
+	return Section{
+		Name:      "Notes",
+		Available: true,
+		Summary:   summary.String(),
+		Expanded:  strings.TrimRight(expanded.String(), "\n"),
+	}
+}
+
+// customSection shows a section from custom_sections: the first line as the
+// summary and every line when expanded
+func customSection(custom collectors.CustomSection) Section {
+	if !custom.Available {
+		hint := "No data from this section's command or file yet"
+		if custom.Error != nil {
+			hint = fmt.Sprintf("Couldn't read section data: %v", custom.Error)
+		}
+		return Section{Name: custom.Title, Available: false, HintText: hint}
+	}
+
+	summary := custom.Icon + " " + custom.Lines[0]
+	if more := len(custom.Lines) - 1; more > 0 {
+		summary += fmt.Sprintf(" (+%d more)", more)
+	}
+	var expanded strings.Builder
+	for _, line := range custom.Lines {
+		expanded.WriteString(custom.Icon + " " + line + "\n")
+	}
+
+	return Section{
+		Name:      custom.Title,
+		Available: true,
+		Summary:   summary,
+		Expanded:  strings.TrimRight(expanded.String(), "\n"),
+	}
+}
+
 func pct(part, total int) int {
 	if total == 0 {
 		return 0
 	}
 	return int(float64(part) / float64(total) * 100)
 }
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,161 @@
+// Package webhook posts summaries to user-configured HTTP endpoints (Home
+// Assistant, n8n, personal servers), with the request body rendered from a
+// Go template so each endpoint gets the shape it expects.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/keychain"
+	"github.com/alexinslc/rekap/internal/ui"
+)
+
+// Options describes one webhook request
+type Options struct {
+	URL     string            // Like a header value, may be "keychain:NAME"
+	Method  string            // Defaults to POST
+	Headers map[string]string // Values expand $VARS from the environment, or are "keychain:NAME"
+	Body    string            // Go template; empty sends the JSON payload as is
+}
+
+// Request is a rendered webhook request, ready to send or print
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    string
+}
+
+// Build renders opts against payload, the summary in rekap's JSON output
+// format. Templates see the payload's fields under their JSON names (e.g.
+// {{.screen.screen_on_minutes}}) plus .summary, the one-line summary.
+func Build(opts Options, payload []byte, summary string) (Request, error) {
+	url, err := keychain.Resolve(opts.URL)
+	if err != nil {
+		return Request{}, fmt.Errorf("url: %w", err)
+	}
+	req := Request{
+		Method:  strings.ToUpper(strings.TrimSpace(opts.Method)),
+		URL:     url,
+		Headers: make(map[string]string, len(opts.Headers)),
+	}
+	if req.Method == "" {
+		req.Method = http.MethodPost
+	}
+	for k, v := range opts.Headers {
+		if req.Headers[k], err = keychain.Resolve(v); err != nil {
+			return Request{}, fmt.Errorf("headers.%s: %w", k, err)
+		}
+	}
+
+	if opts.Body == "" {
+		req.Body = string(payload)
+		if !hasHeader(req.Headers, "Content-Type") {
+			req.Headers["Content-Type"] = "application/json"
+		}
+		return req, nil
+	}
+
+	body, err := Render(opts.Body, payload, summary)
+	if err != nil {
+		return Request{}, err
+	}
+	req.Body = body
+	return req, nil
+}
+
+// Render executes the body template against payload
+func Render(body string, payload []byte, summary string) (string, error) {
+	tmpl, err := Parse(body)
+	if err != nil {
+		return "", err
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return "", fmt.Errorf("invalid payload: %w", err)
+	}
+	data["summary"] = summary
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("body template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// Parse parses a body template with the webhook template functions:
+// json (encode a value as JSON) and duration (format minutes as "2h 5m")
+func Parse(body string) (*template.Template, error) {
+	tmpl, err := template.New("body").Funcs(template.FuncMap{
+		"json":     toJSON,
+		"duration": duration,
+	}).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("body template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Send delivers req, treating any non-2xx response as an error
+func Send(ctx context.Context, req Request, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, strings.NewReader(req.Body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook rejected the request: %s %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// duration formats a number of minutes like the rest of rekap's output.
+// JSON numbers decode as float64, so both kinds are accepted.
+func duration(v any) string {
+	switch n := v.(type) {
+	case float64:
+		return ui.FormatDuration(int(n))
+	case int:
+		return ui.FormatDuration(n)
+	}
+	return ui.FormatDuration(0)
+}
@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPayload = `{"date":"2026-03-10","screen":{"screen_on_minutes":125},"apps":{"top_apps":[{"name":"VS Code","minutes":90}]}}`
+
+func TestBuildWithoutBodySendsPayload(t *testing.T) {
+	t.Parallel()
+	req, err := Build(Options{URL: "https://example.com/hook"}, []byte(testPayload), "2h 5m screen-on")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if req.Method != http.MethodPost {
+		t.Errorf("Method = %q, want POST", req.Method)
+	}
+	if req.Body != testPayload {
+		t.Errorf("Body = %q, want the payload", req.Body)
+	}
+	if req.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", req.Headers["Content-Type"])
+	}
+}
+
+func TestBuildRendersTemplate(t *testing.T) {
+	t.Setenv("REKAP_TEST_TOKEN", "s3cret")
+	req, err := Build(Options{
+		URL:     "http://homeassistant.local:8123/api/webhook/rekap",
+		Method:  "put",
+		Headers: map[string]string{"Authorization": "Bearer $REKAP_TEST_TOKEN"},
+		Body:    `{"state": {{json .summary}}, "screen": "{{duration .screen.screen_on_minutes}}", "top": "{{(index .apps.top_apps 0).name}}"}`,
+	}, []byte(testPayload), "2h 5m screen-on")
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if req.Method != http.MethodPut {
+		t.Errorf("Method = %q, want PUT", req.Method)
+	}
+	if got := req.Headers["Authorization"]; got != "Bearer s3cret" {
+		t.Errorf("Authorization = %q, want the expanded token", got)
+	}
+	if want := `{"state": "2h 5m screen-on", "screen": "2h 5m", "top": "VS Code"}`; req.Body != want {
+		t.Errorf("Body = %q, want %q", req.Body, want)
+	}
+}
+
+func TestParseRejectsBadTemplate(t *testing.T) {
+	t.Parallel()
+	if _, err := Parse("{{.date"); err == nil {
+		t.Error("expected an error for an unterminated action")
+	}
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+	var gotBody, gotHeader string
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody, gotHeader = string(b), r.Header.Get("X-Token")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ok.Close()
+
+	req := Request{Method: http.MethodPost, URL: ok.URL, Headers: map[string]string{"X-Token": "abc"}, Body: "hello"}
+	if err := Send(context.Background(), req, 5*time.Second); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if gotBody != "hello" || gotHeader != "abc" {
+		t.Errorf("server got body %q, header %q", gotBody, gotHeader)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer failing.Close()
+
+	req.URL = failing.URL
+	err := Send(context.Background(), req, 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Send to a failing endpoint = %v, want a 403 error", err)
+	}
+}
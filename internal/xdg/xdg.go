@@ -0,0 +1,38 @@
+// Package xdg resolves rekap's directories from the XDG base directory
+// variables, falling back to the spec's defaults under the home directory:
+// ~/.config/rekap for settings and themes, ~/.local/share/rekap for
+// history, and ~/.local/state/rekap for per-day collector state.
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ConfigDir returns $XDG_CONFIG_HOME/rekap, or ~/.config/rekap
+func ConfigDir() (string, error) {
+	return dir("XDG_CONFIG_HOME", ".config")
+}
+
+// DataDir returns $XDG_DATA_HOME/rekap, or ~/.local/share/rekap
+func DataDir() (string, error) {
+	return dir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// StateDir returns $XDG_STATE_HOME/rekap, or ~/.local/state/rekap
+func StateDir() (string, error) {
+	return dir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// dir is rekap's directory under the base in env, or under fallback in
+// the home directory. The spec says to ignore relative paths.
+func dir(env, fallback string) (string, error) {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, "rekap"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, fallback, "rekap"), nil
+}
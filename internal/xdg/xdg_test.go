@@ -0,0 +1,30 @@
+package xdg
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "relative/ignored")
+	t.Setenv("XDG_STATE_HOME", "/srv/state")
+
+	tests := []struct {
+		name string
+		fn   func() (string, error)
+		want string
+	}{
+		{"config", ConfigDir, filepath.Join(home, ".config", "rekap")},
+		{"data", DataDir, filepath.Join(home, ".local", "share", "rekap")},
+		{"state", StateDir, "/srv/state/rekap"},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn()
+		if err != nil || got != tt.want {
+			t.Errorf("%s dir = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
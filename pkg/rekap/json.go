@@ -0,0 +1,1140 @@
+package rekap
+
+import (
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+)
+
+// JSON output types, separate from the internal collector results to form a
+// stable API contract. They hold only the fields consumers need, leaving out
+// Available, Error, and other implementation details.
+
+type JSONOutput struct {
+	Version         string               `json:"version"`
+	Date            string               `json:"date"`
+	CollectedAt     string               `json:"collected_at"`
+	Window          WindowJSON           `json:"window"`
+	Uptime          *UptimeJSON          `json:"uptime,omitempty"`
+	TimeZoneChanges []TimeZoneChangeJSON `json:"time_zone_changes,omitempty"`
+	SleepWake       *SleepWakeJSON       `json:"sleep_wake,omitempty"`
+	Day             *DayBoundsJSON       `json:"day,omitempty"`
+	Battery         *BatteryJSON         `json:"battery,omitempty"`
+	Peripherals     []PeripheralJSON     `json:"peripheral_batteries,omitempty"`
+	Screen          *ScreenJSON          `json:"screen,omitempty"`
+	Daylight        *DaylightJSON        `json:"daylight,omitempty"`
+	WorkHours       *WorkHoursJSON       `json:"work_hours,omitempty"`
+	Downloads       *DownloadsJSON       `json:"downloads,omitempty"`
+	Updates         *UpdatesJSON         `json:"updates,omitempty"`
+	Screenshots     *ScreenshotsJSON     `json:"screenshots,omitempty"`
+	Memory          *MemoryJSON          `json:"memory,omitempty"`
+	CPU             *CPUJSON             `json:"cpu,omitempty"`
+	Apps            *AppsJSON            `json:"apps,omitempty"`
+	Focus           *FocusJSON           `json:"focus,omitempty"`
+	Sessions        *SessionsJSON        `json:"sessions,omitempty"`
+	Meetings        *MeetingsJSON        `json:"meetings,omitempty"`
+	Spaces          []SpaceJSON          `json:"spaces,omitempty"`
+	SSH             *SSHJSON             `json:"ssh,omitempty"`
+	Shell           *ShellJSON           `json:"shell,omitempty"`
+	Builds          *BuildsJSON          `json:"builds,omitempty"`
+	Reminders       *RemindersJSON       `json:"reminders,omitempty"`
+	Docker          *DockerJSON          `json:"docker,omitempty"`
+	Media           *MediaJSON           `json:"media,omitempty"`
+	AudioDevices    []AudioDeviceJSON    `json:"audio_devices,omitempty"`
+	Music           *MusicJSON           `json:"music,omitempty"`
+	Network         *NetworkJSON         `json:"network,omitempty"`
+	WiFi            *WiFiJSON            `json:"wifi,omitempty"`
+	VPN             *VPNJSON             `json:"vpn,omitempty"`
+	Browsers        *BrowsersJSON        `json:"browsers,omitempty"`
+	CloudConsoles   *CloudConsolesJSON   `json:"cloud_consoles,omitempty"`
+	Notifications   *NotificationsJSON   `json:"notifications,omitempty"`
+	Messages        *MessagesJSON        `json:"messages,omitempty"`
+	Sensors         *SensorsJSON         `json:"sensors,omitempty"`
+	Clipboard       *ClipboardJSON       `json:"clipboard,omitempty"`
+	Fragmentation   *FragmentationJSON   `json:"fragmentation,omitempty"`
+	Issues          *IssuesJSON          `json:"issues,omitempty"`
+	Burnout         *BurnoutJSON         `json:"burnout,omitempty"`
+	ContextOverload *ContextOverloadJSON `json:"context_overload,omitempty"`
+	Projects        *ProjectsJSON        `json:"projects,omitempty"`
+	Learning        *LearningJSON        `json:"learning,omitempty"`
+	Custom          []CustomSectionJSON  `json:"custom_sections,omitempty"`
+	Notes           []NoteJSON           `json:"notes,omitempty"`
+}
+
+type SSHJSON struct {
+	TotalMinutes int           `json:"total_minutes"`
+	Sessions     int           `json:"sessions"`
+	Hosts        []SSHHostJSON `json:"hosts"`
+}
+
+type SSHHostJSON struct {
+	Host     string `json:"host"`
+	Sessions int    `json:"sessions"`
+	Minutes  int    `json:"minutes"`
+	Active   bool   `json:"active"`
+}
+
+type ShellJSON struct {
+	Commands            int                `json:"commands"`
+	TopCommands         []ShellCommandJSON `json:"top_commands"`
+	BusiestHour         int                `json:"busiest_hour"`
+	BusiestHourCommands int                `json:"busiest_hour_commands"`
+}
+
+type ShellCommandJSON struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type BuildsJSON struct {
+	Count       int             `json:"count"`
+	WaitMinutes int             `json:"wait_minutes"`
+	Failed      int             `json:"failed"`
+	Tools       []BuildToolJSON `json:"tools"`
+}
+
+type RemindersJSON struct {
+	Completed int                `json:"completed"`
+	Lists     []ReminderListJSON `json:"lists"`
+}
+
+type ReminderListJSON struct {
+	Name      string `json:"name"`
+	Completed int    `json:"completed"`
+}
+
+type DockerJSON struct {
+	Started    int             `json:"started"`
+	Running    int             `json:"running"`
+	CPUPct     float64         `json:"cpu_pct"`
+	MemBytes   int64           `json:"mem_bytes"`
+	Containers []ContainerJSON `json:"containers"`
+}
+
+type ContainerJSON struct {
+	Name        string  `json:"name"`
+	Image       string  `json:"image"`
+	StartedUnix int64   `json:"started_unix"`
+	Running     bool    `json:"running"`
+	Minutes     int     `json:"minutes"`
+	CPUPct      float64 `json:"cpu_pct,omitempty"`
+	MemBytes    int64   `json:"mem_bytes,omitempty"`
+}
+
+type BuildToolJSON struct {
+	Tool    string `json:"tool"`
+	Count   int    `json:"count"`
+	Minutes int    `json:"minutes"`
+	Failed  int    `json:"failed"`
+}
+
+type WindowJSON struct {
+	StartUnix int64 `json:"start_unix"`
+	EndUnix   int64 `json:"end_unix"`
+	WholeDay  bool  `json:"whole_day"`
+}
+
+type UptimeJSON struct {
+	AwakeMinutes     int    `json:"awake_minutes"`
+	RawAwakeMinutes  int    `json:"raw_awake_minutes"`
+	IdleAwakeMinutes int    `json:"idle_awake_minutes"`
+	Confidence       string `json:"confidence,omitempty"`
+	BootTimeUnix     int64  `json:"boot_time_unix"`
+}
+
+type TimeZoneChangeJSON struct {
+	AtUnix     int64  `json:"at_unix"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	FromOffset int    `json:"from_offset_seconds"`
+	ToOffset   int    `json:"to_offset_seconds"`
+}
+
+type SleepWakeJSON struct {
+	FirstWakeUnix       int64 `json:"first_wake_unix,omitempty"`
+	Sleeps              int   `json:"sleeps"`
+	SleepMinutes        int   `json:"sleep_minutes"`
+	AwakeStretches      int   `json:"awake_stretches"`
+	LongestAwakeMinutes int   `json:"longest_awake_minutes"`
+	LongestAwakeStart   int64 `json:"longest_awake_start_unix"`
+	LongestAwakeEnd     int64 `json:"longest_awake_end_unix"`
+}
+
+type DaylightJSON struct {
+	SunriseUnix      int64  `json:"sunrise_unix,omitempty"`
+	SunsetUnix       int64  `json:"sunset_unix,omitempty"`
+	Polar            string `json:"polar,omitempty"`
+	ScreenMinutes    int    `json:"screen_minutes"`
+	AfterDarkMinutes int    `json:"after_dark_minutes"`
+	AfterDarkPct     int    `json:"after_dark_pct"`
+}
+
+type WorkHoursJSON struct {
+	Hours                       string `json:"hours"`
+	ScreenMinutes               int    `json:"screen_minutes"`
+	WorkMinutes                 int    `json:"work_minutes"`
+	AfterHoursMinutes           int    `json:"after_hours_minutes"`
+	Notifications               int    `json:"notifications"`
+	AfterHoursNotifications     int    `json:"after_hours_notifications"`
+	DistractionVisits           int    `json:"distraction_visits"`
+	AfterHoursDistractionVisits int    `json:"after_hours_distraction_visits"`
+}
+
+type DayBoundsJSON struct {
+	StartUnix       int64  `json:"start_unix"`
+	StartSource     string `json:"start_source"`
+	EndUnix         int64  `json:"end_unix"`
+	EndSource       string `json:"end_source"`
+	Ongoing         bool   `json:"ongoing"`
+	SpanMinutes     int    `json:"span_minutes"`
+	OvertimeMinutes int    `json:"overtime_minutes"`
+}
+
+type BatteryJSON struct {
+	StartPct       int     `json:"start_pct"`
+	CurrentPct     int     `json:"current_pct"`
+	PlugEvents     int     `json:"plug_events"`
+	IsPlugged      bool    `json:"is_plugged"`
+	BatteryMinutes int     `json:"battery_minutes"`
+	PluggedMinutes int     `json:"plugged_minutes"`
+	UsedWh         float64 `json:"used_wh"`
+	HourlyPct      []int   `json:"hourly_pct,omitempty"`
+	HealthPct      int     `json:"health_pct,omitempty"`
+	CycleCount     int     `json:"cycle_count,omitempty"`
+	Condition      string  `json:"condition,omitempty"`
+}
+
+type PeripheralJSON struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Pct  int    `json:"pct"`
+}
+
+type ScreenJSON struct {
+	ScreenOnMinutes    int    `json:"screen_on_minutes"`
+	LockCount          int    `json:"lock_count"`
+	AvgMinsBetweenLock int    `json:"avg_mins_between_locks"`
+	Source             string `json:"source"`
+}
+
+type DownloadsJSON struct {
+	Count int                `json:"count"`
+	Bytes int64              `json:"bytes"`
+	Types []DownloadTypeJSON `json:"types"`
+}
+
+type DownloadTypeJSON struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+	Bytes int64  `json:"bytes"`
+}
+
+type UpdatesJSON struct {
+	Installed int                  `json:"installed"`
+	Upgraded  int                  `json:"upgraded"`
+	Brew      []BrewChangeJSON     `json:"brew"`
+	Pending   []SoftwareUpdateJSON `json:"pending"`
+}
+
+type BrewChangeJSON struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	From    string `json:"from,omitempty"`
+	Cask    bool   `json:"cask"`
+	AtUnix  int64  `json:"at_unix"`
+}
+
+type SoftwareUpdateJSON struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type ScreenshotsJSON struct {
+	Screenshots int    `json:"screenshots"`
+	Recordings  int    `json:"recordings"`
+	Bytes       int64  `json:"bytes"`
+	Folder      string `json:"folder"`
+}
+
+type MemoryJSON struct {
+	PeakPressure  string `json:"peak_pressure"`
+	PeakUsedPct   int    `json:"peak_used_pct"`
+	SwapUsedBytes int64  `json:"swap_used_bytes"`
+	PeakSwapBytes int64  `json:"peak_swap_bytes"`
+	Samples       int    `json:"samples"`
+	Heavy         bool   `json:"heavy"`
+}
+
+type CPUJSON struct {
+	PeakLoad         float64        `json:"peak_load"`
+	AvgLoad          float64        `json:"avg_load"`
+	Cores            int            `json:"cores"`
+	Samples          int            `json:"samples"`
+	ThrottledMinutes int            `json:"throttled_minutes"`
+	MinSpeedLimit    int            `json:"min_speed_limit_pct"`
+	Throttles        []ThrottleJSON `json:"throttles"`
+}
+
+type ThrottleJSON struct {
+	StartUnix int64 `json:"start_unix"`
+	EndUnix   int64 `json:"end_unix"`
+}
+
+type AppJSON struct {
+	Name          string `json:"name"`
+	Minutes       int    `json:"minutes"`
+	BundleID      string `json:"bundle_id"`
+	Activations   int    `json:"activations,omitempty"`
+	InstallSource string `json:"install_source,omitempty"`
+	Sandboxed     *bool  `json:"sandboxed,omitempty"`
+}
+
+type AppSourcesJSON struct {
+	AppleMinutes       int `json:"apple_minutes"`
+	AppStoreMinutes    int `json:"app_store_minutes"`
+	ThirdPartyMinutes  int `json:"third_party_minutes"`
+	UnsandboxedMinutes int `json:"unsandboxed_minutes"`
+}
+
+type AppCategoryJSON struct {
+	Name    string   `json:"name"`
+	Minutes int      `json:"minutes"`
+	Apps    []string `json:"apps"`
+}
+
+type AppsJSON struct {
+	TopApps              []AppJSON         `json:"top_apps"`
+	TotalSwitches        int               `json:"total_switches"`
+	SwitchesPerHour      float64           `json:"switches_per_hour"`
+	AvgMinsBetweenSwitch float64           `json:"avg_mins_between_switches"`
+	Source               string            `json:"source"`
+	BySource             *AppSourcesJSON   `json:"by_source,omitempty"`
+	ByCategory           []AppCategoryJSON `json:"by_category,omitempty"`
+}
+
+type FocusJSON struct {
+	StreakMinutes int    `json:"streak_minutes"`
+	AppName       string `json:"app_name"`
+}
+
+type SessionJSON struct {
+	StartUnix int64 `json:"start_unix"`
+	EndUnix   int64 `json:"end_unix"`
+	Minutes   int   `json:"minutes"`
+}
+
+type SessionsJSON struct {
+	Count          int           `json:"count"`
+	LongestMinutes int           `json:"longest_minutes"`
+	AvgMinutes     int           `json:"avg_minutes"`
+	Sessions       []SessionJSON `json:"sessions"`
+}
+
+type MeetingJSON struct {
+	Title     string `json:"title"`
+	StartUnix int64  `json:"start_unix"`
+	EndUnix   int64  `json:"end_unix"`
+	Minutes   int    `json:"minutes"`
+}
+
+type MeetingsJSON struct {
+	Count              int           `json:"count"`
+	TotalMinutes       int           `json:"total_minutes"`
+	LongestFreeMinutes int           `json:"longest_free_minutes"`
+	LongestFreeStart   int64         `json:"longest_free_start_unix,omitempty"`
+	LongestFreeEnd     int64         `json:"longest_free_end_unix,omitempty"`
+	Source             string        `json:"source"`
+	Meetings           []MeetingJSON `json:"meetings"`
+}
+
+type SpaceJSON struct {
+	Number  int    `json:"number"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+type AudioDeviceJSON struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Minutes   int    `json:"minutes"`
+	Connected bool   `json:"connected"`
+}
+
+type MusicJSON struct {
+	Minutes          int               `json:"minutes"`
+	Tracks           int               `json:"tracks"`
+	Artists          []MusicArtistJSON `json:"artists"`
+	PodcastMinutes   int               `json:"podcast_minutes"`
+	PodcastEpisodes  int               `json:"podcast_episodes"`
+	AudiobookMinutes int               `json:"audiobook_minutes"`
+}
+
+type MusicArtistJSON struct {
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+type MediaJSON struct {
+	Track  string `json:"track"`
+	App    string `json:"app"`
+	Source string `json:"source"`
+}
+
+type NetworkJSON struct {
+	Interface     string `json:"interface"`
+	NetworkName   string `json:"network_name"`
+	BytesReceived int64  `json:"bytes_received"`
+	BytesSent     int64  `json:"bytes_sent"`
+	SinceBoot     bool   `json:"since_boot"`
+
+	Metered              bool   `json:"metered"`
+	MeteredReason        string `json:"metered_reason,omitempty"`
+	MeteredBytesReceived int64  `json:"metered_bytes_received"`
+	MeteredBytesSent     int64  `json:"metered_bytes_sent"`
+
+	TopApps        []AppTrafficJSON `json:"top_apps,omitempty"`
+	AppsSinceStart bool             `json:"apps_since_start,omitempty"`
+}
+
+type AppTrafficJSON struct {
+	Name          string `json:"name"`
+	BytesReceived int64  `json:"bytes_received"`
+	BytesSent     int64  `json:"bytes_sent"`
+}
+
+type WiFiJSON struct {
+	AvgRSSI              int    `json:"avg_rssi"`
+	AvgNoise             int    `json:"avg_noise"`
+	AvgSNR               int    `json:"avg_snr"`
+	AvgTxRate            int    `json:"avg_tx_rate"`
+	Quality              string `json:"quality"`
+	Samples              int    `json:"samples"`
+	WorstPeriodStartUnix int64  `json:"worst_period_start_unix,omitempty"`
+	WorstPeriodSNR       int    `json:"worst_period_snr,omitempty"`
+
+	Networks []WiFiNetworkJSON `json:"networks,omitempty"`
+	Switches int               `json:"switches"`
+}
+
+type VPNJSON struct {
+	Minutes   int    `json:"vpn_minutes"`
+	Connected bool   `json:"connected"`
+	Name      string `json:"name,omitempty"`
+}
+
+type WiFiNetworkJSON struct {
+	SSID    string `json:"ssid"`
+	Minutes int    `json:"minutes"`
+}
+
+type BrowserJSON struct {
+	Tabs int `json:"tabs"`
+}
+
+type BrowsersJSON struct {
+	TotalTabs         int          `json:"total_tabs"`
+	Chrome            *BrowserJSON `json:"chrome,omitempty"`
+	Safari            *BrowserJSON `json:"safari,omitempty"`
+	Edge              *BrowserJSON `json:"edge,omitempty"`
+	Firefox           *BrowserJSON `json:"firefox,omitempty"`
+	URLsVisited       int          `json:"urls_visited"`
+	TopDomain         string       `json:"top_domain,omitempty"`
+	TopDomainVisits   int          `json:"top_domain_visits,omitempty"`
+	WorkVisits        int          `json:"work_visits"`
+	DistractionVisits int          `json:"distraction_visits"`
+	LearningVisits    int          `json:"learning_visits"`
+	NeutralVisits     int          `json:"neutral_visits"`
+	IssuesViewed      []string     `json:"issues_viewed,omitempty"`
+}
+
+type CloudConsolesJSON struct {
+	TotalMinutes int                 `json:"total_minutes"`
+	Providers    []CloudProviderJSON `json:"providers"`
+	LongSessions []CloudSessionJSON  `json:"long_sessions,omitempty"`
+}
+
+type CloudProviderJSON struct {
+	Provider string `json:"provider"`
+	Name     string `json:"name"`
+	Minutes  int    `json:"minutes"`
+	Sessions int    `json:"sessions"`
+	Visits   int    `json:"visits"`
+}
+
+type CloudSessionJSON struct {
+	Provider  string `json:"provider"`
+	StartUnix int64  `json:"start_unix"`
+	EndUnix   int64  `json:"end_unix"`
+	Minutes   int    `json:"minutes"`
+}
+
+type NotificationAppJSON struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type NotificationsJSON struct {
+	Total   int                   `json:"total"`
+	TopApps []NotificationAppJSON `json:"top_apps,omitempty"`
+}
+
+type MessagesJSON struct {
+	Sent     int                  `json:"sent"`
+	Received int                  `json:"received"`
+	Services []MessageServiceJSON `json:"services"`
+}
+
+type MessageServiceJSON struct {
+	Service  string `json:"service"`
+	Sent     int    `json:"sent"`
+	Received int    `json:"received"`
+}
+
+type SensorsJSON struct {
+	CameraMinutes  int             `json:"camera_minutes"`
+	MicMinutes     int             `json:"mic_minutes"`
+	CameraSessions int             `json:"camera_sessions"`
+	Apps           []SensorAppJSON `json:"apps"`
+}
+
+type SensorAppJSON struct {
+	Name          string `json:"name"`
+	BundleID      string `json:"bundle_id"`
+	CameraMinutes int    `json:"camera_minutes"`
+	MicMinutes    int    `json:"mic_minutes"`
+}
+
+type ClipboardJSON struct {
+	Changes        int     `json:"changes"`
+	ChangesPerHour float64 `json:"changes_per_hour"`
+	BusiestHour    int     `json:"busiest_hour"`
+	Hourly         [24]int `json:"hourly"`
+}
+
+type FragmentationJSON struct {
+	Score int    `json:"score"`
+	Level string `json:"level"`
+}
+
+type IssueJSON struct {
+	ID         string `json:"id"`
+	Tracker    string `json:"tracker"`
+	URL        string `json:"url"`
+	VisitCount int    `json:"visit_count"`
+}
+
+type IssuesJSON struct {
+	Issues []IssueJSON `json:"issues"`
+}
+
+type BurnoutWarningJSON struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+type BurnoutJSON struct {
+	Warnings []BurnoutWarningJSON `json:"warnings"`
+}
+
+type ProjectJSON struct {
+	Name           string `json:"name"`
+	Minutes        int    `json:"minutes"`
+	AppMinutes     int    `json:"app_minutes"`
+	BrowserMinutes int    `json:"browser_minutes"`
+	WindowMinutes  int    `json:"window_minutes"`
+}
+
+type ProjectsJSON struct {
+	Projects            []ProjectJSON `json:"projects"`
+	UnattributedMinutes int           `json:"unattributed_minutes"`
+}
+
+type LearningJSON struct {
+	Minutes        int `json:"minutes"`
+	AppMinutes     int `json:"app_minutes"`
+	BrowserMinutes int `json:"browser_minutes"`
+	Visits         int `json:"visits"`
+}
+
+type CustomSectionJSON struct {
+	Name  string   `json:"name"`
+	Title string   `json:"title"`
+	Icon  string   `json:"icon"`
+	Lines []string `json:"lines"`
+}
+
+type NoteJSON struct {
+	Time string `json:"time"` // RFC 3339
+	Text string `json:"text"`
+}
+
+type ContextOverloadJSON struct {
+	IsOverloaded bool   `json:"is_overloaded"`
+	Message      string `json:"message,omitempty"`
+}
+
+// ToJSON converts a run's results to the stable JSON output format, stamped
+// with version as the rekap version that produced it
+func ToJSON(data *SummaryData, version string) JSONOutput {
+	out := JSONOutput{
+		Version:     version,
+		Date:        data.Window.Day(),
+		CollectedAt: time.Now().Format(time.RFC3339),
+		Window: WindowJSON{
+			StartUnix: data.Window.Start.Unix(),
+			EndUnix:   data.Window.End.Unix(),
+			WholeDay:  data.Window.WholeDay(),
+		},
+	}
+
+	if data.Uptime.Available {
+		out.Uptime = &UptimeJSON{
+			AwakeMinutes:     data.Uptime.AwakeMinutes,
+			RawAwakeMinutes:  data.Uptime.RawAwakeMinutes,
+			IdleAwakeMinutes: data.Uptime.IdleAwakeMinutes,
+			Confidence:       data.Uptime.Confidence,
+			BootTimeUnix:     data.Uptime.BootTime.Unix(),
+		}
+	}
+
+	for _, c := range data.TimeZone.Changes {
+		out.TimeZoneChanges = append(out.TimeZoneChanges, TimeZoneChangeJSON{
+			AtUnix:     c.At.Unix(),
+			From:       c.From,
+			To:         c.To,
+			FromOffset: c.FromOffset,
+			ToOffset:   c.ToOffset,
+		})
+	}
+
+	if s := data.SleepWake; s.Available {
+		out.SleepWake = &SleepWakeJSON{
+			Sleeps:              s.Sleeps,
+			SleepMinutes:        s.SleepMinutes,
+			AwakeStretches:      s.AwakeStretches,
+			LongestAwakeMinutes: s.LongestAwake.Minutes(),
+			LongestAwakeStart:   s.LongestAwake.Start.Unix(),
+			LongestAwakeEnd:     s.LongestAwake.End.Unix(),
+		}
+		if !s.FirstWake.IsZero() {
+			out.SleepWake.FirstWakeUnix = s.FirstWake.Unix()
+		}
+	}
+
+	if d := data.Daylight; d.Available {
+		out.Daylight = &DaylightJSON{
+			Polar:            d.Polar,
+			ScreenMinutes:    d.ScreenMinutes,
+			AfterDarkMinutes: d.AfterDarkMinutes,
+			AfterDarkPct:     d.AfterDarkPct(),
+		}
+		if d.Polar == "" {
+			out.Daylight.SunriseUnix = d.Sunrise.Unix()
+			out.Daylight.SunsetUnix = d.Sunset.Unix()
+		}
+	}
+
+	if h := data.WorkHours; h.Available {
+		out.WorkHours = &WorkHoursJSON{
+			Hours:                       h.Hours.String(),
+			ScreenMinutes:               h.ScreenMinutes,
+			WorkMinutes:                 h.WorkMinutes(),
+			AfterHoursMinutes:           h.AfterHoursMinutes,
+			Notifications:               h.Notifications,
+			AfterHoursNotifications:     h.AfterHoursNotifications,
+			DistractionVisits:           h.DistractionVisits,
+			AfterHoursDistractionVisits: h.AfterHoursDistractionVisits,
+		}
+	}
+
+	if b := data.DayBounds; b.Available {
+		out.Day = &DayBoundsJSON{
+			StartUnix:       b.Arrival.Unix(),
+			StartSource:     b.ArrivalSource,
+			EndUnix:         b.WrapUp.Unix(),
+			EndSource:       b.WrapUpSource,
+			Ongoing:         b.Ongoing,
+			SpanMinutes:     b.SpanMinutes,
+			OvertimeMinutes: b.OvertimeMinutes(),
+		}
+	}
+
+	if data.Battery.Available {
+		out.Battery = &BatteryJSON{
+			StartPct:       data.Battery.StartPct,
+			CurrentPct:     data.Battery.CurrentPct,
+			PlugEvents:     data.Battery.PlugCount,
+			IsPlugged:      data.Battery.IsPlugged,
+			BatteryMinutes: data.Battery.BatteryMinutes,
+			PluggedMinutes: data.Battery.PluggedMinutes,
+			UsedWh:         data.Battery.UsedWh,
+			HourlyPct:      data.Battery.HourlyPct,
+			HealthPct:      data.Battery.HealthPct,
+			CycleCount:     data.Battery.CycleCount,
+			Condition:      data.Battery.Condition,
+		}
+	}
+
+	for _, p := range data.Battery.Peripherals {
+		out.Peripherals = append(out.Peripherals, PeripheralJSON{Name: p.Name, Kind: p.Kind, Pct: p.Pct})
+	}
+
+	if data.Screen.Available {
+		out.Screen = &ScreenJSON{
+			ScreenOnMinutes:    data.Screen.ScreenOnMinutes,
+			LockCount:          data.Screen.LockCount,
+			AvgMinsBetweenLock: data.Screen.AvgMinsBetweenLock,
+			Source:             data.Screen.Source,
+		}
+	}
+
+	if data.Downloads.Available {
+		downloadsJSON := &DownloadsJSON{Count: data.Downloads.Count, Bytes: data.Downloads.TotalBytes, Types: []DownloadTypeJSON{}}
+		for _, t := range data.Downloads.TopTypes {
+			downloadsJSON.Types = append(downloadsJSON.Types, DownloadTypeJSON{Type: t.Type, Count: t.Count, Bytes: t.Bytes})
+		}
+		out.Downloads = downloadsJSON
+	}
+
+	if data.Updates.Available {
+		updatesJSON := &UpdatesJSON{
+			Installed: data.Updates.Installed,
+			Upgraded:  data.Updates.Upgraded,
+			Brew:      []BrewChangeJSON{},
+			Pending:   []SoftwareUpdateJSON{},
+		}
+		for _, c := range data.Updates.Brew {
+			updatesJSON.Brew = append(updatesJSON.Brew, BrewChangeJSON{Name: c.Name, Version: c.Version, From: c.From, Cask: c.Cask, AtUnix: c.At.Unix()})
+		}
+		for _, u := range data.Updates.Pending {
+			updatesJSON.Pending = append(updatesJSON.Pending, SoftwareUpdateJSON{Name: u.Name, Version: u.Version})
+		}
+		out.Updates = updatesJSON
+	}
+
+	if data.Screenshots.Available {
+		out.Screenshots = &ScreenshotsJSON{
+			Screenshots: data.Screenshots.Screenshots,
+			Recordings:  data.Screenshots.Recordings,
+			Bytes:       data.Screenshots.Bytes,
+			Folder:      data.Screenshots.Folder,
+		}
+	}
+
+	if data.Memory.Available {
+		out.Memory = &MemoryJSON{
+			PeakPressure:  data.Memory.PeakPressure,
+			PeakUsedPct:   data.Memory.PeakUsedPct,
+			SwapUsedBytes: data.Memory.SwapUsedBytes,
+			PeakSwapBytes: data.Memory.PeakSwapBytes,
+			Samples:       data.Memory.Samples,
+			Heavy:         data.Memory.Heavy(),
+		}
+	}
+
+	if data.CPU.Available {
+		cpuJSON := &CPUJSON{
+			PeakLoad:         data.CPU.PeakLoad,
+			AvgLoad:          data.CPU.AvgLoad,
+			Cores:            data.CPU.Cores,
+			Samples:          data.CPU.Samples,
+			ThrottledMinutes: data.CPU.ThrottledMinutes,
+			MinSpeedLimit:    data.CPU.MinSpeedLimit,
+			Throttles:        []ThrottleJSON{},
+		}
+		for _, p := range data.CPU.Throttles {
+			cpuJSON.Throttles = append(cpuJSON.Throttles, ThrottleJSON{StartUnix: p.Start.Unix(), EndUnix: p.End.Unix()})
+		}
+		out.CPU = cpuJSON
+	}
+
+	if data.Apps.Available {
+		appsJSON := &AppsJSON{Source: data.Apps.Source}
+		for i, app := range data.Apps.TopApps {
+			appJSON := AppJSON{
+				Name:        app.Name,
+				Minutes:     app.Minutes,
+				BundleID:    app.BundleID,
+				Activations: app.Activations,
+			}
+			if data.AppSources.Available && i < len(data.AppSources.Apps) {
+				origin := data.AppSources.Apps[i]
+				appJSON.InstallSource = origin.Source
+				appJSON.Sandboxed = &origin.Sandboxed
+			}
+			appsJSON.TopApps = append(appsJSON.TopApps, appJSON)
+		}
+		if data.AppSources.Available {
+			appsJSON.BySource = &AppSourcesJSON{
+				AppleMinutes:       data.AppSources.AppleMinutes,
+				AppStoreMinutes:    data.AppSources.AppStoreMinutes,
+				ThirdPartyMinutes:  data.AppSources.ThirdPartyMinutes,
+				UnsandboxedMinutes: data.AppSources.UnsandboxedMinutes,
+			}
+		}
+		if data.AppCategories.Available {
+			for _, c := range data.AppCategories.Categories {
+				appsJSON.ByCategory = append(appsJSON.ByCategory, AppCategoryJSON{Name: c.Name, Minutes: c.Minutes, Apps: c.Apps})
+			}
+		}
+		if data.Apps.SwitchingAvailable {
+			appsJSON.TotalSwitches = data.Apps.TotalSwitches
+			appsJSON.SwitchesPerHour = data.Apps.SwitchesPerHour
+			appsJSON.AvgMinsBetweenSwitch = data.Apps.AvgMinsBetween
+		}
+		out.Apps = appsJSON
+	}
+
+	if data.Focus.Available {
+		out.Focus = &FocusJSON{
+			StreakMinutes: data.Focus.StreakMinutes,
+			AppName:       data.Focus.AppName,
+		}
+	}
+
+	if data.Sessions.Available {
+		sessionsJSON := &SessionsJSON{
+			Count:          data.Sessions.Count,
+			LongestMinutes: data.Sessions.LongestMinutes,
+			AvgMinutes:     data.Sessions.AvgMinutes,
+		}
+		for _, s := range data.Sessions.Sessions {
+			sessionsJSON.Sessions = append(sessionsJSON.Sessions, SessionJSON{
+				StartUnix: s.Start.Unix(),
+				EndUnix:   s.End.Unix(),
+				Minutes:   s.Minutes(),
+			})
+		}
+		out.Sessions = sessionsJSON
+	}
+
+	if data.Meetings.Available {
+		meetingsJSON := &MeetingsJSON{
+			Count:              data.Meetings.Count,
+			TotalMinutes:       data.Meetings.TotalMinutes,
+			LongestFreeMinutes: data.Meetings.LongestFreeMinutes,
+			Source:             data.Meetings.Source,
+			Meetings:           []MeetingJSON{},
+		}
+		if data.Meetings.LongestFreeMinutes > 0 {
+			meetingsJSON.LongestFreeStart = data.Meetings.LongestFree.Start.Unix()
+			meetingsJSON.LongestFreeEnd = data.Meetings.LongestFree.End.Unix()
+		}
+		for _, m := range data.Meetings.Meetings {
+			meetingsJSON.Meetings = append(meetingsJSON.Meetings, MeetingJSON{
+				Title:     m.Title,
+				StartUnix: m.Start.Unix(),
+				EndUnix:   m.End.Unix(),
+				Minutes:   m.Minutes(),
+			})
+		}
+		out.Meetings = meetingsJSON
+	}
+
+	if data.Spaces.Available {
+		for _, space := range data.Spaces.Spaces {
+			out.Spaces = append(out.Spaces, SpaceJSON{Number: space.Number, Name: space.Name, Minutes: space.Minutes})
+		}
+	}
+
+	if data.SSH.Available {
+		sshJSON := &SSHJSON{TotalMinutes: data.SSH.TotalMinutes, Sessions: data.SSH.Sessions}
+		for _, host := range data.SSH.Hosts {
+			sshJSON.Hosts = append(sshJSON.Hosts, SSHHostJSON{Host: host.Host, Sessions: host.Sessions, Minutes: host.Minutes, Active: host.Active})
+		}
+		out.SSH = sshJSON
+	}
+
+	if data.Shell.Available {
+		shellJSON := &ShellJSON{
+			Commands:            data.Shell.Commands,
+			BusiestHour:         data.Shell.BusiestHour,
+			BusiestHourCommands: data.Shell.BusiestHourCommands,
+		}
+		for _, c := range data.Shell.TopCommands {
+			shellJSON.TopCommands = append(shellJSON.TopCommands, ShellCommandJSON{Name: c.Name, Count: c.Count})
+		}
+		out.Shell = shellJSON
+	}
+
+	if data.Builds.Available {
+		buildsJSON := &BuildsJSON{Count: data.Builds.Builds, WaitMinutes: data.Builds.WaitMinutes, Failed: data.Builds.Failed, Tools: []BuildToolJSON{}}
+		for _, t := range data.Builds.Tools {
+			buildsJSON.Tools = append(buildsJSON.Tools, BuildToolJSON{Tool: t.Tool, Count: t.Builds, Minutes: t.Minutes, Failed: t.Failed})
+		}
+		out.Builds = buildsJSON
+	}
+
+	if data.Reminders.Available {
+		remindersJSON := &RemindersJSON{Completed: data.Reminders.Completed, Lists: []ReminderListJSON{}}
+		for _, l := range data.Reminders.Lists {
+			remindersJSON.Lists = append(remindersJSON.Lists, ReminderListJSON{Name: l.Name, Completed: l.Completed})
+		}
+		out.Reminders = remindersJSON
+	}
+
+	if data.Docker.Available {
+		dockerJSON := &DockerJSON{
+			Started:    data.Docker.Started,
+			Running:    data.Docker.Running,
+			CPUPct:     data.Docker.CPUPct,
+			MemBytes:   data.Docker.MemBytes,
+			Containers: []ContainerJSON{},
+		}
+		for _, c := range data.Docker.Containers {
+			dockerJSON.Containers = append(dockerJSON.Containers, ContainerJSON{
+				Name:        c.Name,
+				Image:       c.Image,
+				StartedUnix: c.Started.Unix(),
+				Running:     c.Running,
+				Minutes:     c.Minutes,
+				CPUPct:      c.CPUPct,
+				MemBytes:    c.MemBytes,
+			})
+		}
+		out.Docker = dockerJSON
+	}
+
+	for _, d := range data.AudioDevices.Devices {
+		out.AudioDevices = append(out.AudioDevices, AudioDeviceJSON{Name: d.Name, Type: d.Type, Minutes: d.Minutes, Connected: d.Connected})
+	}
+
+	if data.Music.Available {
+		musicJSON := &MusicJSON{
+			Minutes:          data.Music.Minutes,
+			Tracks:           data.Music.Tracks,
+			Artists:          []MusicArtistJSON{},
+			PodcastMinutes:   data.Music.PodcastMinutes,
+			PodcastEpisodes:  data.Music.Episodes,
+			AudiobookMinutes: data.Music.AudiobookMinutes,
+		}
+		for _, a := range data.Music.Artists {
+			musicJSON.Artists = append(musicJSON.Artists, MusicArtistJSON{Name: a.Name, Minutes: a.Minutes})
+		}
+		out.Music = musicJSON
+	}
+
+	if data.Media.Available {
+		out.Media = &MediaJSON{
+			Track:  data.Media.Track,
+			App:    data.Media.App,
+			Source: data.Media.Source,
+		}
+	}
+
+	if data.Network.Available {
+		out.Network = &NetworkJSON{
+			Interface:     data.Network.InterfaceName,
+			NetworkName:   data.Network.NetworkName,
+			BytesReceived: data.Network.BytesReceived,
+			BytesSent:     data.Network.BytesSent,
+			SinceBoot:     data.Network.SinceBoot,
+
+			Metered:              data.Network.Metered,
+			MeteredReason:        data.Network.MeteredReason,
+			MeteredBytesReceived: data.Network.MeteredBytesReceived,
+			MeteredBytesSent:     data.Network.MeteredBytesSent,
+
+			AppsSinceStart: data.Network.AppsSinceStart,
+		}
+		for _, a := range data.Network.TopApps {
+			out.Network.TopApps = append(out.Network.TopApps, AppTrafficJSON{Name: a.Name, BytesReceived: a.BytesReceived, BytesSent: a.BytesSent})
+		}
+	}
+
+	if data.WiFi.Available {
+		wifiJSON := &WiFiJSON{
+			AvgRSSI:   data.WiFi.AvgRSSI,
+			AvgNoise:  data.WiFi.AvgNoise,
+			AvgSNR:    data.WiFi.AvgSNR,
+			AvgTxRate: data.WiFi.AvgTxRate,
+			Quality:   data.WiFi.Quality,
+			Samples:   data.WiFi.Samples,
+			Switches:  data.WiFi.Switches,
+		}
+		if !data.WiFi.WorstPeriodStart.IsZero() {
+			wifiJSON.WorstPeriodStartUnix = data.WiFi.WorstPeriodStart.Unix()
+			wifiJSON.WorstPeriodSNR = data.WiFi.WorstPeriodSNR
+		}
+		for _, n := range data.WiFi.Networks {
+			wifiJSON.Networks = append(wifiJSON.Networks, WiFiNetworkJSON{SSID: n.SSID, Minutes: n.Minutes})
+		}
+		out.WiFi = wifiJSON
+	}
+
+	if data.VPN.Available {
+		out.VPN = &VPNJSON{Minutes: data.VPN.Minutes, Connected: data.VPN.Connected, Name: data.VPN.Name}
+	}
+
+	if data.Browsers.Available {
+		browsersJSON := &BrowsersJSON{
+			TotalTabs:         data.Browsers.TotalTabs,
+			URLsVisited:       data.Browsers.TotalURLsVisited,
+			TopDomain:         data.Browsers.TopHistoryDomain,
+			TopDomainVisits:   data.Browsers.TopDomainVisits,
+			WorkVisits:        data.Browsers.WorkVisits,
+			DistractionVisits: data.Browsers.DistractionVisits,
+			LearningVisits:    data.Browsers.LearningVisits,
+			NeutralVisits:     data.Browsers.NeutralVisits,
+			IssuesViewed:      data.Browsers.AllIssueURLs,
+		}
+		if data.Browsers.Chrome.Available {
+			browsersJSON.Chrome = &BrowserJSON{Tabs: data.Browsers.Chrome.TabCount}
+		}
+		if data.Browsers.Safari.Available {
+			browsersJSON.Safari = &BrowserJSON{Tabs: data.Browsers.Safari.TabCount}
+		}
+		if data.Browsers.Edge.Available {
+			browsersJSON.Edge = &BrowserJSON{Tabs: data.Browsers.Edge.TabCount}
+		}
+		if data.Browsers.Firefox.Available {
+			browsersJSON.Firefox = &BrowserJSON{Tabs: data.Browsers.Firefox.TabCount}
+		}
+		out.Browsers = browsersJSON
+	}
+
+	if data.CloudConsoles.Available {
+		cloudJSON := &CloudConsolesJSON{TotalMinutes: data.CloudConsoles.TotalMinutes}
+		for _, p := range data.CloudConsoles.Providers {
+			cloudJSON.Providers = append(cloudJSON.Providers, CloudProviderJSON{
+				Provider: p.Provider, Name: p.Name, Minutes: p.Minutes, Sessions: p.Sessions, Visits: p.Visits,
+			})
+		}
+		for _, s := range data.CloudConsoles.LongSessions {
+			cloudJSON.LongSessions = append(cloudJSON.LongSessions, CloudSessionJSON{
+				Provider: s.Provider, StartUnix: s.Start.Unix(), EndUnix: s.End.Unix(), Minutes: s.Minutes(),
+			})
+		}
+		out.CloudConsoles = cloudJSON
+	}
+
+	if data.Notifications.Available {
+		notifJSON := &NotificationsJSON{
+			Total: data.Notifications.TotalNotifications,
+		}
+		for _, app := range data.Notifications.TopApps {
+			notifJSON.TopApps = append(notifJSON.TopApps, NotificationAppJSON{
+				Name:  app.Name,
+				Count: app.Count,
+			})
+		}
+		out.Notifications = notifJSON
+	}
+
+	if data.Messages.Available {
+		messagesJSON := &MessagesJSON{Sent: data.Messages.Sent, Received: data.Messages.Received}
+		for _, s := range data.Messages.Services {
+			messagesJSON.Services = append(messagesJSON.Services, MessageServiceJSON{Service: s.Service, Sent: s.Sent, Received: s.Received})
+		}
+		out.Messages = messagesJSON
+	}
+
+	if data.Sensors.Available {
+		sensorsJSON := &SensorsJSON{
+			CameraMinutes:  data.Sensors.CameraMinutes,
+			MicMinutes:     data.Sensors.MicMinutes,
+			CameraSessions: data.Sensors.CameraSessions,
+			Apps:           []SensorAppJSON{},
+		}
+		for _, a := range data.Sensors.Apps {
+			sensorsJSON.Apps = append(sensorsJSON.Apps, SensorAppJSON{Name: a.Name, BundleID: a.BundleID, CameraMinutes: a.CameraMinutes, MicMinutes: a.MicMinutes})
+		}
+		out.Sensors = sensorsJSON
+	}
+
+	if data.Clipboard.Available {
+		out.Clipboard = &ClipboardJSON{
+			Changes:        data.Clipboard.Changes,
+			ChangesPerHour: data.Clipboard.ChangesPerHour,
+			BusiestHour:    data.Clipboard.BusiestHour,
+			Hourly:         data.Clipboard.PerHour,
+		}
+	}
+
+	if data.Fragmentation.Available {
+		out.Fragmentation = &FragmentationJSON{
+			Score: data.Fragmentation.Score,
+			Level: data.Fragmentation.Level,
+		}
+	}
+
+	if data.Issues.Available && len(data.Issues.Issues) > 0 {
+		issuesJSON := &IssuesJSON{}
+		for _, issue := range data.Issues.Issues {
+			issuesJSON.Issues = append(issuesJSON.Issues, IssueJSON{
+				ID:         issue.ID,
+				Tracker:    issue.Tracker,
+				URL:        issue.URL,
+				VisitCount: issue.VisitCount,
+			})
+		}
+		out.Issues = issuesJSON
+	}
+
+	if data.Burnout.Available && len(data.Burnout.Warnings) > 0 {
+		burnoutJSON := &BurnoutJSON{}
+		for _, w := range data.Burnout.Warnings {
+			burnoutJSON.Warnings = append(burnoutJSON.Warnings, BurnoutWarningJSON{
+				Type:     w.Type,
+				Severity: w.Severity,
+				Message:  w.Message,
+			})
+		}
+		out.Burnout = burnoutJSON
+	}
+
+	if data.Apps.Available && data.Browsers.Available {
+		overload := collectors.CheckContextOverload(data.Apps, data.Browsers)
+		out.ContextOverload = &ContextOverloadJSON{
+			IsOverloaded: overload.IsOverloaded,
+			Message:      overload.WarningMessage,
+		}
+	}
+
+	if data.Projects.Available {
+		projectsJSON := &ProjectsJSON{
+			Projects:            []ProjectJSON{},
+			UnattributedMinutes: data.Projects.UnattributedMinutes,
+		}
+		for _, p := range data.Projects.Projects {
+			projectsJSON.Projects = append(projectsJSON.Projects, ProjectJSON{
+				Name:           p.Name,
+				Minutes:        p.Minutes,
+				AppMinutes:     p.AppMinutes,
+				BrowserMinutes: p.BrowserMinutes,
+				WindowMinutes:  p.WindowMinutes,
+			})
+		}
+		out.Projects = projectsJSON
+	}
+
+	if data.Learning.Available {
+		out.Learning = &LearningJSON{
+			Minutes:        data.Learning.Minutes,
+			AppMinutes:     data.Learning.AppMinutes,
+			BrowserMinutes: data.Learning.BrowserMinutes,
+			Visits:         data.Learning.Visits,
+		}
+	}
+
+	for _, custom := range data.Custom {
+		if custom.Available {
+			out.Custom = append(out.Custom, CustomSectionJSON{
+				Name:  custom.Name,
+				Title: custom.Title,
+				Icon:  custom.Icon,
+				Lines: custom.Lines,
+			})
+		}
+	}
+
+	for _, note := range data.Notes {
+		out.Notes = append(out.Notes, NoteJSON{
+			Time: note.Time.Format(time.RFC3339),
+			Text: note.Text,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,323 @@
+// Package rekap embeds rekap's collection engine in other Go programs, such
+// as bots, dashboards, and menu bar apps, so they don't need to shell out to
+// "rekap --json".
+//
+//	data, err := rekap.Collect(ctx, rekap.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	out := rekap.ToJSON(&data, "my-tool")
+//	fmt.Println(out.Screen.ScreenOnMinutes)
+//
+// Collect only reads; it doesn't save history snapshots, run hooks, or
+// append to the run log the way the rekap command does. Collectors are
+// best-effort, so each section of SummaryData reports its own Available
+// and Error, and ToJSON leaves unavailable sections out.
+package rekap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+	"github.com/alexinslc/rekap/internal/history"
+	"github.com/alexinslc/rekap/internal/summary"
+)
+
+// SummaryData holds all collector results for a single run
+type SummaryData = summary.Data
+
+// Window is the span of time a run summarizes
+type Window = collectors.Window
+
+// Config is rekap's configuration, as read from ~/.config/rekap/config.yaml
+type Config = config.Config
+
+// DefaultTimeout bounds a Collect call whose context has no deadline
+const DefaultTimeout = 5 * time.Second
+
+// Options control what Collect summarizes
+type Options struct {
+	// Config to collect with. Nil loads the user's config file, falling
+	// back to defaults when there is none.
+	Config *Config
+
+	// Window to summarize. The zero Window is today so far.
+	Window Window
+
+	// Timeout for the collectors when ctx has no deadline. Zero means
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// LoadConfig reads the user's config file, returning defaults when it
+// doesn't exist
+func LoadConfig() (*Config, error) {
+	return config.Load()
+}
+
+// DefaultConfig returns the built-in defaults
+func DefaultConfig() *Config {
+	return config.Default()
+}
+
+// DayWindow returns the whole day starting at start, cut off at now if the
+// day isn't over yet
+func DayWindow(start, now time.Time) Window {
+	return collectors.DayWindow(start, now)
+}
+
+// Collect runs all collectors concurrently over opts.Window and derives the
+// computed sections. It fails only on a bad config file, a window that ends
+// before it starts, or a canceled context; a collector that can't read its
+// source marks its section unavailable instead.
+//
+// The collectors read the day boundary (schedule.day_starts_at), work
+// hours, and performance settings from process-wide state, so Collect sets
+// them from the config it uses and runs one call at a time: concurrent
+// calls with different configs each see their own settings.
+func Collect(ctx context.Context, opts Options) (SummaryData, error) {
+	cfg := opts.Config
+	if cfg == nil {
+		var err error
+		if cfg, err = config.Load(); err != nil {
+			return SummaryData{}, fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	collectMu.Lock()
+	defer collectMu.Unlock()
+	collectors.SetDayStart(cfg.DayStartMinute())
+	collectors.SetFast(cfg.Performance.Fast)
+	collectors.SetMemoryBudget(cfg.Performance.MemoryMB)
+	if start, end, days, ok := cfg.WorkHours(); ok {
+		collectors.SetWorkHours(&collectors.WorkHours{Start: start, End: end, Days: days})
+	} else {
+		collectors.SetWorkHours(nil)
+	}
+
+	w := opts.Window
+	if w.Start.IsZero() && w.End.IsZero() {
+		w = collectors.Today(time.Now())
+	}
+	if w.End.Before(w.Start) {
+		return SummaryData{}, fmt.Errorf("window ends before it starts")
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := opts.Timeout
+		if timeout <= 0 {
+			timeout = DefaultTimeout
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	data := collect(ctx, cfg, w)
+	// Collectors that ran out of time are already marked unavailable, but
+	// a canceled run is abandoned
+	if err := ctx.Err(); errors.Is(err, context.Canceled) {
+		return SummaryData{}, err
+	}
+	return data, nil
+}
+
+// collectMu serializes Collect, which sets the collectors' process-wide
+// settings for the length of a run
+var collectMu sync.Mutex
+
+// collect runs the collectors over w and fills in the derived sections
+func collect(ctx context.Context, cfg *Config, w Window) SummaryData {
+	// Collect data from all sources concurrently
+	uptimeCh := make(chan collectors.UptimeResult, 1)
+	timeZoneCh := make(chan collectors.TimeZoneResult, 1)
+	batteryCh := make(chan collectors.BatteryResult, 1)
+	screenCh := make(chan collectors.ScreenResult, 1)
+	downloadsCh := make(chan collectors.DownloadsResult, 1)
+	updatesCh := make(chan collectors.UpdatesResult, 1)
+	screenshotsCh := make(chan collectors.ScreenshotsResult, 1)
+	memoryCh := make(chan collectors.MemoryResult, 1)
+	cpuCh := make(chan collectors.CPUResult, 1)
+	appsCh := make(chan collectors.AppsResult, 1)
+	windowsCh := make(chan collectors.WindowTitlesResult, 1)
+	spacesCh := make(chan collectors.SpacesResult, 1)
+	sshCh := make(chan collectors.SSHResult, 1)
+	shellCh := make(chan collectors.ShellResult, 1)
+	buildsCh := make(chan collectors.BuildsResult, 1)
+	remindersCh := make(chan collectors.RemindersResult, 1)
+	dockerCh := make(chan collectors.DockerResult, 1)
+	focusCh := make(chan collectors.FocusResult, 1)
+	mediaCh := make(chan collectors.MediaResult, 1)
+	audioCh := make(chan collectors.AudioDevicesResult, 1)
+	musicCh := make(chan collectors.MusicResult, 1)
+	networkCh := make(chan collectors.NetworkResult, 1)
+	wifiCh := make(chan collectors.WiFiResult, 1)
+	vpnCh := make(chan collectors.VPNResult, 1)
+	browsersCh := make(chan collectors.BrowsersResult, 1)
+	issuesCh := make(chan collectors.IssuesResult, 1)
+	notificationsCh := make(chan collectors.NotificationsResult, 1)
+	messagesCh := make(chan collectors.MessagesResult, 1)
+	sensorsCh := make(chan collectors.SensorsResult, 1)
+	clipboardCh := make(chan collectors.ClipboardResult, 1)
+	meetingsCh := make(chan collectors.MeetingsResult, 1)
+	customCh := make(chan []collectors.CustomSection, 1)
+
+	start(cfg, "uptime", uptimeCh, func() collectors.UptimeResult { return collectors.CollectUptime(ctx, w) })
+	start(cfg, "timezone", timeZoneCh, func() collectors.TimeZoneResult { return collectors.CollectTimeZone(ctx, w) })
+	start(cfg, "battery", batteryCh, func() collectors.BatteryResult { return collectors.CollectBattery(ctx, w) })
+	start(cfg, "screen", screenCh, func() collectors.ScreenResult { return collectors.CollectScreen(ctx, cfg.Sources.Screen, w) })
+	start(cfg, "downloads", downloadsCh, func() collectors.DownloadsResult { return collectors.CollectDownloads(ctx, w) })
+	start(cfg, "updates", updatesCh, func() collectors.UpdatesResult { return collectors.CollectUpdates(ctx, w) })
+	start(cfg, "screenshots", screenshotsCh, func() collectors.ScreenshotsResult { return collectors.CollectScreenshots(ctx, w) })
+	start(cfg, "memory", memoryCh, func() collectors.MemoryResult { return collectors.CollectMemory(ctx, w) })
+	start(cfg, "cpu", cpuCh, func() collectors.CPUResult { return collectors.CollectCPU(ctx, w) })
+	start(cfg, "apps", appsCh, func() collectors.AppsResult {
+		return collectors.CollectApps(ctx, cfg.Tracking.ExcludeApps, cfg.Sources.Apps, w)
+	})
+	start(cfg, "windows", windowsCh, func() collectors.WindowTitlesResult { return collectors.CollectWindowTitles(ctx, cfg, w) })
+	start(cfg, "spaces", spacesCh, func() collectors.SpacesResult { return collectors.CollectSpaces(ctx, cfg.Tracking.SpaceNames, w) })
+	start(cfg, "ssh", sshCh, func() collectors.SSHResult { return collectors.CollectSSH(ctx, w) })
+	start(cfg, "shell", shellCh, func() collectors.ShellResult { return collectors.CollectShell(ctx, cfg.Tracking.ShellHistory, w) })
+	start(cfg, "builds", buildsCh, func() collectors.BuildsResult { return collectors.CollectBuilds(ctx, cfg.Tracking.ShellHistory, w) })
+	start(cfg, "reminders", remindersCh, func() collectors.RemindersResult { return collectors.CollectReminders(ctx, cfg.Tracking.Reminders, w) })
+	start(cfg, "docker", dockerCh, func() collectors.DockerResult { return collectors.CollectDocker(ctx, w) })
+	start(cfg, "focus", focusCh, func() collectors.FocusResult { return collectors.CollectFocus(ctx, cfg.Tracking.ExcludeApps, w) })
+	start(cfg, "media", mediaCh, func() collectors.MediaResult { return collectors.CollectMedia(ctx, cfg.Sources.Media, w) })
+	start(cfg, "audio", audioCh, func() collectors.AudioDevicesResult { return collectors.CollectAudioDevices(ctx, w) })
+	start(cfg, "music", musicCh, func() collectors.MusicResult { return collectors.CollectMusic(ctx, w) })
+	start(cfg, "network", networkCh, func() collectors.NetworkResult {
+		return collectors.CollectNetwork(ctx, cfg.Network.MeteredNetworks, cfg.Network.PerApp, w)
+	})
+	start(cfg, "wifi", wifiCh, func() collectors.WiFiResult { return collectors.CollectWiFi(ctx, w) })
+	start(cfg, "vpn", vpnCh, func() collectors.VPNResult { return collectors.CollectVPN(ctx, w) })
+	start(cfg, "browsers", browsersCh, func() collectors.BrowsersResult { return collectors.CollectBrowserTabs(ctx, cfg, w) })
+	start(cfg, "issues", issuesCh, func() collectors.IssuesResult { return collectors.CollectIssues(ctx, cfg, w) })
+	start(cfg, "notifications", notificationsCh, func() collectors.NotificationsResult {
+		return collectors.CollectNotifications(ctx, cfg.Tracking.ExcludeApps, w)
+	})
+	start(cfg, "messages", messagesCh, func() collectors.MessagesResult {
+		// The token may come from the Keychain, so it's only read when used
+		var token string
+		var tokenErr error
+		if cfg.Tracking.Messages {
+			token, tokenErr = cfg.SlackToken()
+		}
+		result := collectors.CollectMessages(ctx, cfg.Tracking.Messages, token, w)
+		if tokenErr != nil {
+			result.Error = errors.Join(result.Error, fmt.Errorf("slack: %w", tokenErr))
+		}
+		return result
+	})
+	start(cfg, "sensors", sensorsCh, func() collectors.SensorsResult { return collectors.CollectSensors(ctx, w) })
+	start(cfg, "clipboard", clipboardCh, func() collectors.ClipboardResult { return collectors.CollectClipboard(ctx, cfg.Tracking.Clipboard, w) })
+	start(cfg, "meetings", meetingsCh, func() collectors.MeetingsResult { return collectors.CollectMeetings(ctx, w) })
+	start(cfg, "custom", customCh, func() []collectors.CustomSection {
+		return collectors.CollectCustomSections(ctx, enabledCustomSections(cfg), w)
+	})
+
+	data := SummaryData{
+		Window:        w,
+		Uptime:        <-uptimeCh,
+		TimeZone:      <-timeZoneCh,
+		Battery:       <-batteryCh,
+		Screen:        <-screenCh,
+		Downloads:     <-downloadsCh,
+		Updates:       <-updatesCh,
+		Screenshots:   <-screenshotsCh,
+		Memory:        <-memoryCh,
+		CPU:           <-cpuCh,
+		Apps:          <-appsCh,
+		WindowTitles:  <-windowsCh,
+		Spaces:        <-spacesCh,
+		SSH:           <-sshCh,
+		Shell:         <-shellCh,
+		Builds:        <-buildsCh,
+		Reminders:     <-remindersCh,
+		Docker:        <-dockerCh,
+		Focus:         <-focusCh,
+		Media:         <-mediaCh,
+		AudioDevices:  <-audioCh,
+		Music:         <-musicCh,
+		Network:       <-networkCh,
+		WiFi:          <-wifiCh,
+		VPN:           <-vpnCh,
+		Browsers:      <-browsersCh,
+		Issues:        <-issuesCh,
+		Notifications: <-notificationsCh,
+		Messages:      <-messagesCh,
+		Sensors:       <-sensorsCh,
+		Clipboard:     <-clipboardCh,
+		Meetings:      <-meetingsCh,
+		Custom:        <-customCh,
+	}
+
+	// Check awake time against screen and input activity before anything uses it
+	data.Uptime = collectors.ReconcileAwake(data.Uptime, data.Screen, w)
+	data.SleepWake = collectors.CalculateSleepWake(data.Uptime, w)
+
+	// Sunrise and sunset are worked out offline from the configured location
+	lat, lon, located := cfg.Coordinates()
+	data.Daylight = collectors.CalculateDaylight(data.Screen, lat, lon, located, w)
+
+	// Calculate fragmentation score after collecting data
+	fragmentationThresholds := collectors.FragmentationThresholdsFor(cfg)
+	data.Fragmentation = collectors.CalculateFragmentation(ctx, data.Apps, data.Browsers, data.Uptime, fragmentationThresholds)
+	if data.Clipboard.Available {
+		data.Fragmentation.Breakdown.ClipboardChangesPerHour = data.Clipboard.ChangesPerHour
+	}
+
+	// Split screen-on time into work sessions and find when the day started
+	// and wrapped up; long-day checks measure against those bounds
+	data.Sessions = collectors.CalculateSessions(data.Screen)
+	data.DayBounds = collectors.CalculateDayBounds(data.Screen, data.WiFi, data.Apps, w.End)
+
+	// Find the longest meeting-free stretch of the workday
+	data.Meetings = collectors.CalculateMeetingFree(data.Meetings, data.DayBounds, w)
+
+	// Split app time by where each top app was installed from
+	data.AppSources = collectors.ClassifyAppSources(ctx, data.Apps)
+
+	// Split app time by App Store category, as Screen Time groups it
+	data.AppCategories = collectors.ClassifyAppCategories(ctx, data.Apps)
+
+	// Analyze burnout patterns after collecting primary data
+	burnoutConfig := collectors.BurnoutConfigFor(cfg)
+	data.Burnout = collectors.CollectBurnout(ctx, cfg.Tracking.ExcludeApps, data.Screen, data.DayBounds, data.Browsers, burnoutConfig, w)
+
+	// Split the day by schedule.work_hours; long stretches after hours
+	// count toward burnout
+	data.WorkHours = collectors.CalculateWorkHours(data.Screen, data.Notifications, data.Browsers, w)
+	data.Burnout = collectors.CheckAfterHours(data.Burnout, data.WorkHours, burnoutConfig)
+
+	// Break down time in cloud provider consoles
+	data.CloudConsoles = collectors.CalculateCloudConsoles(data.Browsers)
+
+	// Attribute time to configured projects for timesheets
+	data.Projects = collectors.CalculateProjects(data.Apps, data.Browsers, data.WindowTitles, cfg)
+
+	// Time spent on courses, docs, and other learning
+	data.Learning = collectors.CalculateLearning(data.Apps, data.Browsers, cfg)
+
+	// Notes added with "rekap note" for the window's day
+	data.Notes = loadNotes(w.Day())
+
+	return data
+}
+
+// loadNotes reads the notes attached to date. Best-effort: notes are extra
+// context and never worth failing a summary over.
+func loadNotes(date string) []summary.Note {
+	store, err := history.Open()
+	if err != nil {
+		return nil
+	}
+	defer store.Close()
+
+	notes, _ := store.Notes(date, date)
+	return notes
+}
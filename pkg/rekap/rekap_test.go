@@ -0,0 +1,106 @@
+package rekap
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexinslc/rekap/internal/collectors"
+	"github.com/alexinslc/rekap/internal/config"
+)
+
+func TestCollectRejectsReversedWindow(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 2, 18, 12, 0, 0, 0, time.Local)
+	_, err := Collect(context.Background(), Options{
+		Config: DefaultConfig(),
+		Window: Window{Start: now, End: now.Add(-time.Hour)},
+	})
+	if err == nil {
+		t.Fatal("Collect with a reversed window succeeded")
+	}
+}
+
+func TestCollectConcurrentConfigs(t *testing.T) {
+	t.Parallel()
+	configFor := func(hours string) *Config {
+		cfg := DefaultConfig()
+		cfg.Sections.Only = []string{"wait"}
+		cfg.Schedule.WorkHours = hours
+		// Hold each run open long enough for the others to overlap it
+		cfg.Custom = []config.CustomSectionConfig{{Name: "wait", Command: "sleep 0.05"}}
+		return cfg
+	}
+	configs := []*Config{configFor("09:00-17:00"), configFor("22:00-06:00")}
+
+	var wg sync.WaitGroup
+	for i := range 6 {
+		cfg := configs[i%len(configs)]
+		wg.Go(func() {
+			data, err := Collect(context.Background(), Options{Config: cfg})
+			if err != nil {
+				t.Errorf("Collect: %v", err)
+				return
+			}
+			if got := data.WorkHours.Hours.String(); got != cfg.Schedule.WorkHours {
+				t.Errorf("work hours = %s, want %s from the call's own config", got, cfg.Schedule.WorkHours)
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestCollectorEnabled(t *testing.T) {
+	t.Parallel()
+	cfg := DefaultConfig()
+	cfg.Sections.Only = []string{"browser"}
+
+	// Browser history also feeds fragmentation, but only its sections matter
+	for collector, want := range map[string]bool{"browsers": true, "media": false, "apps": false, "custom": true} {
+		if got := collectorEnabled(cfg, collector); got != want {
+			t.Errorf("collectorEnabled(%q) = %v, want %v", collector, got, want)
+		}
+	}
+
+	// A collector runs while any section it feeds is on
+	cfg.Sections = config.SectionsConfig{Skip: []string{"browser", "timesheet"}}
+	if !collectorEnabled(cfg, "browsers") {
+		t.Error("browsers should still run for fragmentation and wellness")
+	}
+	cfg.Sections.Skip = append(cfg.Sections.Skip, "fragmentation", "wellness")
+	if collectorEnabled(cfg, "browsers") {
+		t.Error("browsers should be skipped once every section it feeds is off")
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 2, 18, 0, 0, 0, 0, time.Local)
+	data := SummaryData{
+		Window: Window{Start: start, End: start.Add(9 * time.Hour)},
+		Screen: collectors.ScreenResult{ScreenOnMinutes: 300, Source: "pmset", Available: true},
+		Apps:   collectors.AppsResult{Available: false},
+	}
+
+	out := ToJSON(&data, "1.2.3")
+	if out.Version != "1.2.3" || out.Date != "2026-02-18" {
+		t.Errorf("version, date = %q, %q", out.Version, out.Date)
+	}
+	if out.Screen == nil || out.Screen.ScreenOnMinutes != 300 {
+		t.Errorf("Screen = %+v, want 300 minutes", out.Screen)
+	}
+	if out.Apps != nil {
+		t.Errorf("Apps = %+v, want nil for an unavailable section", out.Apps)
+	}
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), `"apps"`) {
+		t.Errorf("unavailable apps section encoded: %s", raw)
+	}
+}
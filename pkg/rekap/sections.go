@@ -0,0 +1,79 @@
+package rekap
+
+import "github.com/alexinslc/rekap/internal/config"
+
+// collectorSections lists the summary sections each collector feeds. A
+// collector runs when any of its sections is enabled (sections.only and
+// sections.skip); otherwise its result is left at the zero value, which
+// every output treats as unavailable.
+var collectorSections = map[string][]string{
+	"uptime":        {"system", "sleep", "fragmentation"},
+	"timezone":      {"system"},
+	"battery":       {"system"},
+	"screen":        {"system", "sleep", "productivity", "meetings", "wellness"},
+	"downloads":     {"system"},
+	"updates":       {"system"},
+	"screenshots":   {"system"},
+	"memory":        {"system"},
+	"cpu":           {"system"},
+	"apps":          {"productivity", "timesheet", "fragmentation"},
+	"windows":       {"timesheet"},
+	"spaces":        {"productivity"},
+	"ssh":           {"productivity"},
+	"shell":         {"productivity"},
+	"builds":        {"productivity"},
+	"reminders":     {"productivity"},
+	"focus":         {"productivity"},
+	"docker":        {"dev"},
+	"media":         {"media"},
+	"audio":         {"media"},
+	"music":         {"media"},
+	"network":       {"network"},
+	"wifi":          {"network"},
+	"vpn":           {"network"},
+	"browsers":      {"browser", "timesheet", "fragmentation", "wellness"},
+	"issues":        {"issues"},
+	"notifications": {"notifications"},
+	"messages":      {"notifications"},
+	"sensors":       {"privacy"},
+	"clipboard":     {"fragmentation"},
+	"meetings":      {"meetings"},
+}
+
+// collectorEnabled reports whether any section the collector feeds is
+// enabled. Collectors not listed, like the custom sections (checked one by
+// one in enabledCustomSections), always run.
+func collectorEnabled(cfg *config.Config, collector string) bool {
+	sections, ok := collectorSections[collector]
+	if !ok {
+		return true
+	}
+	for _, section := range sections {
+		if cfg.SectionEnabled(section) {
+			return true
+		}
+	}
+	return false
+}
+
+// start runs collect in the background, sending its result on ch, or sends
+// the zero result straight away when the collector's sections are all off
+func start[T any](cfg *config.Config, collector string, ch chan<- T, collect func() T) {
+	if !collectorEnabled(cfg, collector) {
+		var zero T
+		ch <- zero
+		return
+	}
+	go func() { ch <- collect() }()
+}
+
+// enabledCustomSections returns the custom sections that aren't turned off
+func enabledCustomSections(cfg *config.Config) []config.CustomSectionConfig {
+	var sections []config.CustomSectionConfig
+	for _, section := range cfg.Custom {
+		if cfg.SectionEnabled(section.Name) {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}